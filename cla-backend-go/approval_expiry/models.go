@@ -0,0 +1,31 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_expiry
+
+import "time"
+
+// DefaultReminderWindow is how long before the expiry of an approval list entry the CLA managers are reminded
+const DefaultReminderWindow = 7 * 24 * time.Hour
+
+// Options controls the approval list expiry run
+type Options struct {
+	// Now is the time of the run, the current time when zero
+	Now time.Time
+	// ReminderWindow is how long before the expiry the CLA managers are reminded, DefaultReminderWindow when zero
+	ReminderWindow time.Duration
+	// DryRun only reports the entries which would be removed and reminded
+	DryRun bool
+}
+
+// Report is the result of an approval list expiry run
+type Report struct {
+	// Signatures is the number of CCLA signatures with expired or expiring entries
+	Signatures int
+	// Expired is the number of entries removed from the approval lists
+	Expired int
+	// Reminded is the number of expiring entries the CLA managers were reminded of
+	Reminded int
+	// Failed is the number of entries which couldn't be removed or reminded, they are retried in the next run
+	Failed int
+}
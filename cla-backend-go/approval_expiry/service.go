@@ -0,0 +1,266 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_expiry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	"github.com/sirupsen/logrus"
+)
+
+// Expirer removes the expired entries from the approval list of a CCLA signature, implemented by the signatures service
+type Expirer interface {
+	ExpireApprovalListEntries(ctx context.Context, signatureID string, expired []*signatures.ApprovalListEntryExpiry) (*models.Signature, error)
+}
+
+// Service removes the expired approval list entries and reminds the CLA managers of the entries which expire soon
+type Service interface {
+	// Process removes the entries which expired and sends one reminder per signature for the entries which expire
+	// within the reminder window
+	Process(ctx context.Context, options Options) (*Report, error)
+}
+
+type service struct {
+	approvalRepo         approvals.IRepository
+	signatureRepo        signatures.SignatureRepository
+	expirer              Expirer
+	emailTemplateService emails.EmailTemplateService
+	eventsService        events.Service
+}
+
+// NewService creates the approval list expiry service
+func NewService(approvalRepo approvals.IRepository, signatureRepo signatures.SignatureRepository, expirer Expirer, emailTemplateService emails.EmailTemplateService, eventsService events.Service) Service {
+	return &service{
+		approvalRepo:         approvalRepo,
+		signatureRepo:        signatureRepo,
+		expirer:              expirer,
+		emailTemplateService: emailTemplateService,
+		eventsService:        eventsService,
+	}
+}
+
+// Process removes the expired entries and reminds the CLA managers of the expiring entries
+func (s *service) Process(ctx context.Context, options Options) (*Report, error) {
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+	if options.ReminderWindow <= 0 {
+		options.ReminderWindow = DefaultReminderWindow
+	}
+	f := logrus.Fields{
+		"functionName":   "approval_expiry.service.Process",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"now":            utils.TimeToString(options.Now),
+		"reminderWindow": options.ReminderWindow.String(),
+		"dryRun":         options.DryRun,
+	}
+
+	before := options.Now.Add(options.ReminderWindow).UTC().Format(signatures.ApprovalExpiryTimeFormat)
+	items, err := s.approvalRepo.GetExpiringApprovalItems(before)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the expiring approval list entries")
+		return nil, err
+	}
+
+	expired, expiring, invalid := partition(items, options.Now)
+	report := &Report{Failed: invalid}
+	signatureIDs := map[string]bool{}
+	for _, signatureID := range sortedKeys(expired) {
+		signatureIDs[signatureID] = true
+		s.expire(ctx, signatureID, expired[signatureID], options, report)
+	}
+	for _, signatureID := range sortedKeys(expiring) {
+		signatureIDs[signatureID] = true
+		s.remind(ctx, signatureID, expiring[signatureID], options, report)
+	}
+	report.Signatures = len(signatureIDs)
+
+	log.WithFields(f).Infof("approval list expiry done - signatures: %d, expired: %d, reminded: %d, failed: %d",
+		report.Signatures, report.Expired, report.Reminded, report.Failed)
+	return report, nil
+}
+
+// expire removes the expired entries of the signature through the approval list update and deactivates their
+// approval records, including the records of entries already removed from the approval list by other means
+func (s *service) expire(ctx context.Context, signatureID string, items []approvals.ApprovalItem, options Options, report *Report) {
+	f := logrus.Fields{
+		"functionName":   "approval_expiry.service.expire",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	if options.DryRun {
+		for _, item := range items {
+			log.WithFields(f).Infof("dry run - would remove the %s entry %s which expired at %s", item.ApprovalCriteria, item.ApprovalName, item.DateExpires)
+		}
+		report.Expired += len(items)
+		return
+	}
+
+	if _, err := s.expirer.ExpireApprovalListEntries(ctx, signatureID, toEntries(items)); err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to remove %d expired entries from the approval list", len(items))
+		report.Failed += len(items)
+		return
+	}
+
+	currentTime := utils.TimeToString(options.Now)
+	for _, item := range items {
+		item.Active = false
+		item.DateRemoved = currentTime
+		item.DateModified = currentTime
+		item.Note = "Expired"
+		if err := s.approvalRepo.UpdateApprovalItem(item); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to deactivate the approval record of the expired entry: %s", item.ApprovalName)
+		}
+	}
+	report.Expired += len(items)
+}
+
+// remind sends one email per CLA manager of the signature with the entries which expire soon, the entries are
+// reminded once
+func (s *service) remind(ctx context.Context, signatureID string, items []approvals.ApprovalItem, options Options, report *Report) {
+	f := logrus.Fields{
+		"functionName":   "approval_expiry.service.remind",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	cclaSignature, err := s.signatureRepo.GetSignature(ctx, signatureID)
+	if err != nil || cclaSignature == nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the signature of the expiring entries")
+		report.Failed += len(items)
+		return
+	}
+
+	var entries []emails.ApprovalListExpiryEntry
+	for _, item := range items {
+		entries = append(entries, emails.ApprovalListExpiryEntry{
+			Criteria:  item.ApprovalCriteria,
+			Value:     item.ApprovalName,
+			ExpiresAt: item.DateExpires,
+		})
+	}
+
+	companyName := items[0].ApprovalCompanyName
+	subject := fmt.Sprintf("EasyCLA: Approval list entries of %s expire soon", companyName)
+	sent := 0
+	for i := range cclaSignature.SignatureACL {
+		claManager := cclaSignature.SignatureACL[i]
+		recipient := utils.GetBestEmail(&claManager)
+		if recipient == "" {
+			continue
+		}
+		params := emails.ApprovalListExpiryReminderTemplateParams{
+			CommonEmailParams: emails.CommonEmailParams{
+				RecipientName:    utils.GetBestUsername(&claManager),
+				RecipientAddress: recipient,
+				CompanyName:      companyName,
+			},
+			CompanyID: cclaSignature.SignatureReferenceID,
+			Entries:   entries,
+		}
+		emailSubject, body, renderErr := emails.RenderApprovalListExpiryReminderTemplate(s.emailTemplateService, subject, cclaSignature.ProjectID, params)
+		if renderErr != nil {
+			log.WithFields(f).WithError(renderErr).Warnf("rendering email template: %s failed", emails.ApprovalListExpiryReminderTemplateName)
+			continue
+		}
+
+		if options.DryRun {
+			log.WithFields(f).Infof("dry run - would remind %s of %d expiring entries", recipient, len(entries))
+			sent++
+			continue
+		}
+		if sendErr := utils.SendEmail(emailSubject, body, []string{recipient}); sendErr != nil {
+			log.WithFields(f).WithError(sendErr).Warnf("problem sending email with subject: %s to recipient: %s", emailSubject, recipient)
+			continue
+		}
+		sent++
+	}
+
+	if sent == 0 {
+		log.WithFields(f).Warn("no CLA manager could be reminded of the expiring entries")
+		report.Failed += len(items)
+		return
+	}
+	report.Reminded += len(items)
+	if options.DryRun {
+		return
+	}
+
+	currentTime := utils.TimeToString(options.Now)
+	for _, item := range items {
+		item.DateExpiryNotified = currentTime
+		if updateErr := s.approvalRepo.UpdateApprovalItem(item); updateErr != nil {
+			log.WithFields(f).WithError(updateErr).Warnf("unable to record the reminder of the entry: %s", item.ApprovalName)
+		}
+		s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:  events.ApprovalListEntryExpiryReminder,
+			LfUsername: signatures.SystemUsername,
+			UserID:     signatures.SystemUsername,
+			CLAGroupID: cclaSignature.ProjectID,
+			ProjectID:  cclaSignature.ProjectID,
+			CompanyID:  cclaSignature.SignatureReferenceID,
+			EventData: &events.ApprovalListEntryExpiryEventData{
+				Criteria:  item.ApprovalCriteria,
+				Value:     item.ApprovalName,
+				ExpiresAt: item.DateExpires,
+				Action:    "reminder",
+			},
+		})
+	}
+}
+
+// partition groups the entries by signature into the expired entries and the expiring entries not reminded yet,
+// it returns the number of entries with an invalid expiry date
+func partition(items []approvals.ApprovalItem, now time.Time) (map[string][]approvals.ApprovalItem, map[string][]approvals.ApprovalItem, int) {
+	expired := map[string][]approvals.ApprovalItem{}
+	expiring := map[string][]approvals.ApprovalItem{}
+	invalid := 0
+	for _, item := range items {
+		expiresAt, err := time.Parse(signatures.ApprovalExpiryTimeFormat, item.DateExpires)
+		if err != nil {
+			log.Warnf("invalid expiry date: %s of approval record: %s", item.DateExpires, item.ApprovalID)
+			invalid++
+			continue
+		}
+		if !expiresAt.After(now) {
+			expired[item.SignatureID] = append(expired[item.SignatureID], item)
+		} else if item.DateExpiryNotified == "" {
+			expiring[item.SignatureID] = append(expiring[item.SignatureID], item)
+		}
+	}
+	return expired, expiring, invalid
+}
+
+func toEntries(items []approvals.ApprovalItem) []*signatures.ApprovalListEntryExpiry {
+	var entries []*signatures.ApprovalListEntryExpiry
+	for _, item := range items {
+		expiresAt, _ := time.Parse(signatures.ApprovalExpiryTimeFormat, item.DateExpires)
+		entries = append(entries, &signatures.ApprovalListEntryExpiry{
+			Criteria:  item.ApprovalCriteria,
+			Value:     item.ApprovalName,
+			ExpiresAt: expiresAt,
+		})
+	}
+	return entries
+}
+
+func sortedKeys(m map[string][]approvals.ApprovalItem) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
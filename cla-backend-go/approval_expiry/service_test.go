@@ -0,0 +1,117 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_expiry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/approval_expiry"
+	mock_approval_expiry "github.com/linuxfoundation/easycla/cla-backend-go/approval_expiry/mock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	mock_emails "github.com/linuxfoundation/easycla/cla-backend-go/emails/mock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	eventsMock "github.com/linuxfoundation/easycla/cla-backend-go/events/mock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	mock_signatures "github.com/linuxfoundation/easycla/cla-backend-go/signatures/mocks"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	mock_utils "github.com/linuxfoundation/easycla/cla-backend-go/utils/mock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	mock_approvals "github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcess(t *testing.T) {
+	now := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	approvalRepo := mock_approvals.NewMockIRepository(ctrl)
+	approvalRepo.EXPECT().GetExpiringApprovalItems("2026-10-26T06:00:00Z").Return([]approvals.ApprovalItem{
+		{ApprovalID: "1", SignatureID: "sig-1", ApprovalCriteria: "email", ApprovalName: "expired@example.org", Active: true, DateExpires: "2026-10-18T00:00:00Z"},
+		{ApprovalID: "2", SignatureID: "sig-1", ApprovalCriteria: "githubUsername", ApprovalName: "expired", Active: true, DateExpires: "2026-10-19T06:00:00Z"},
+		{ApprovalID: "3", SignatureID: "sig-2", ApprovalCriteria: "domain", ApprovalName: "example.com", Active: true, DateExpires: "2026-10-20T00:00:00Z", ApprovalCompanyName: "Example"},
+		{ApprovalID: "4", SignatureID: "sig-2", ApprovalCriteria: "email", ApprovalName: "reminded@example.com", Active: true, DateExpires: "2026-10-21T00:00:00Z", DateExpiryNotified: "2026-10-14T06:00:00Z"},
+		{ApprovalID: "5", SignatureID: "sig-3", ApprovalCriteria: "email", ApprovalName: "failed@example.net", Active: true, DateExpires: "2026-10-01T00:00:00Z"},
+		{ApprovalID: "6", SignatureID: "sig-3", ApprovalCriteria: "email", ApprovalName: "invalid@example.net", Active: true, DateExpires: "tomorrow"},
+	}, nil)
+	var updated []approvals.ApprovalItem
+	approvalRepo.EXPECT().UpdateApprovalItem(gomock.Any()).DoAndReturn(func(item approvals.ApprovalItem) error {
+		updated = append(updated, item)
+		return nil
+	}).Times(3)
+
+	expirer := mock_approval_expiry.NewMockExpirer(ctrl)
+	expirer.EXPECT().ExpireApprovalListEntries(gomock.Any(), "sig-1", []*signatures.ApprovalListEntryExpiry{
+		{Criteria: "email", Value: "expired@example.org", ExpiresAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{Criteria: "githubUsername", Value: "expired", ExpiresAt: time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)},
+	}).Return(&models.Signature{SignatureID: "sig-1"}, nil)
+	expirer.EXPECT().ExpireApprovalListEntries(gomock.Any(), "sig-3", gomock.Any()).Return(nil, errors.New("update failed"))
+
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	signatureRepo.EXPECT().GetSignature(gomock.Any(), "sig-2").Return(&models.Signature{
+		SignatureID:          "sig-2",
+		ProjectID:            "cla-group-id",
+		SignatureReferenceID: "company-id",
+		SignatureACL: []models.User{
+			{Username: "Manager", LfEmail: "manager@example.com"},
+			{Username: "No Email"},
+		},
+	}, nil)
+
+	emailTemplateService := mock_emails.NewMockEmailTemplateService(ctrl)
+	emailTemplateService.EXPECT().GetCLAGroupTemplateParamsFromCLAGroup("cla-group-id").
+		Return(emails.CLAGroupTemplateParams{CLAGroupName: "CLA Group", CorporateConsole: "https://corporate.lfcla.com"}, nil).AnyTimes()
+	emailTemplateService.EXPECT().GetTemplateOverride(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	eventsService := eventsMock.NewMockService(ctrl)
+	eventsService.EXPECT().LogEventWithContext(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, args *events.LogEventArgs) {
+		assert.Equal(t, events.ApprovalListEntryExpiryReminder, args.EventType)
+		assert.Equal(t, "company-id", args.CompanyID)
+	})
+
+	emailSender := mock_utils.NewMockEmailSender(ctrl)
+	emailSender.EXPECT().SendEmail(gomock.Any(), gomock.Any(), []string{"manager@example.com"}).DoAndReturn(func(subject, body string, recipients []string) error {
+		assert.Contains(t, body, "<li>domain: example.com expires at 2026-10-20T00:00:00Z</li>")
+		assert.NotContains(t, body, "reminded@example.com")
+		assert.Contains(t, body, "https://corporate.lfcla.com#/company/company-id")
+		return nil
+	})
+	utils.SetEmailSender(emailSender)
+
+	s := approval_expiry.NewService(approvalRepo, signatureRepo, expirer, emailTemplateService, eventsService)
+	report, err := s.Process(context.Background(), approval_expiry.Options{Now: now})
+	assert.NoError(t, err)
+	assert.Equal(t, &approval_expiry.Report{Signatures: 3, Expired: 2, Reminded: 1, Failed: 2}, report)
+
+	if assert.Len(t, updated, 3) {
+		assert.False(t, updated[0].Active)
+		assert.Equal(t, "2026-10-19T06:00:00Z", updated[0].DateRemoved)
+		assert.Equal(t, "3", updated[2].ApprovalID)
+		assert.True(t, updated[2].Active)
+		assert.Equal(t, "2026-10-19T06:00:00Z", updated[2].DateExpiryNotified)
+	}
+}
+
+func TestProcessDryRun(t *testing.T) {
+	now := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	approvalRepo := mock_approvals.NewMockIRepository(ctrl)
+	approvalRepo.EXPECT().GetExpiringApprovalItems("2026-10-20T06:00:00Z").Return([]approvals.ApprovalItem{
+		{ApprovalID: "1", SignatureID: "sig-1", ApprovalCriteria: "email", ApprovalName: "expired@example.org", Active: true, DateExpires: "2026-10-18T00:00:00Z"},
+	}, nil)
+
+	// the dry run neither updates the approval list nor the approval records
+	s := approval_expiry.NewService(approvalRepo, mock_signatures.NewMockSignatureRepository(ctrl), mock_approval_expiry.NewMockExpirer(ctrl),
+		mock_emails.NewMockEmailTemplateService(ctrl), eventsMock.NewMockService(ctrl))
+	report, err := s.Process(context.Background(), approval_expiry.Options{Now: now, DryRun: true, ReminderWindow: 24 * time.Hour})
+	assert.NoError(t, err)
+	assert.Equal(t, &approval_expiry.Report{Signatures: 1, Expired: 1}, report)
+}
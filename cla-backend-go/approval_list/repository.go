@@ -42,6 +42,8 @@ type IRepository interface {
 	ListCclaApprovalListRequests(companyID string, projectID, status, userID *string) (*models.CclaWhitelistRequestList, error)
 	GetRequestsByCLAGroup(claGroupID string) ([]CLARequestModel, error)
 	UpdateRequestsByCLAGroup(model *models2.DBProjectModel) error
+	GetRequestIDsByUser(userID string) ([]string, error)
+	UpdateRequestUserID(requestID, userID string) error
 }
 
 type repository struct {
@@ -213,6 +215,98 @@ func (repo repository) RejectCclaApprovalListRequest(requestID string) error {
 	return nil
 }
 
+// GetRequestIDsByUser returns the IDs of the contributor approval requests of the user
+func (repo repository) GetRequestIDsByUser(userID string) ([]string, error) {
+	f := logrus.Fields{
+		"functionName": "v1.approval_list.repository.GetRequestIDsByUser",
+		"userID":       userID,
+		"tableName":    repo.tableName,
+	}
+
+	expr, err := expression.NewBuilder().
+		WithFilter(expression.Name("user_id").Equal(expression.Value(userID))).
+		WithProjection(expression.NamesList(expression.Name("request_id"))).
+		Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for contributor approval requests scan by user id")
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.tableName),
+	}
+
+	var requestIDs []string
+	for {
+		results, errScan := repo.dynamoDBClient.Scan(scanInput)
+		if errScan != nil {
+			log.WithFields(f).WithError(errScan).Warn("error retrieving contributor approval requests by user id")
+			return nil, errScan
+		}
+
+		var requests []CLARequestModel
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &requests)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("error unmarshalling contributor approval requests from database")
+			return nil, err
+		}
+		for _, request := range requests {
+			requestIDs = append(requestIDs, request.RequestID)
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return requestIDs, nil
+}
+
+// UpdateRequestUserID points the contributor approval request to the specified user
+func (repo repository) UpdateRequestUserID(requestID, userID string) error {
+	f := logrus.Fields{
+		"functionName": "v1.approval_list.repository.UpdateRequestUserID",
+		"requestID":    requestID,
+		"userID":       userID,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"request_id": {
+				S: aws.String(requestID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#U": aws.String("user_id"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":u": {
+				S: aws.String(userID),
+			},
+			":m": {
+				S: aws.String(currentTime),
+			},
+		},
+		UpdateExpression: aws.String("SET #U = :u, #M = :m"),
+		TableName:        aws.String(repo.tableName),
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update the user of the contributor approval request")
+		return err
+	}
+
+	return nil
+}
+
 // ListCclaApprovalListRequests list the requests for the specified query parameters
 func (repo repository) ListCclaApprovalListRequests(companyID string, projectID, status, userID *string) (*models.CclaWhitelistRequestList, error) {
 	f := logrus.Fields{
@@ -17,6 +17,7 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	"github.com/linuxfoundation/easycla/cla-backend-go/notification_digest"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
@@ -40,6 +41,13 @@ const (
 	DontLoadRepoDetails = true
 )
 
+// approval request email events, combined with the request ID to form the email idempotency key
+const (
+	approvalRequestEmailRequested = "requested"
+	approvalRequestEmailApproved  = "approved"
+	approvalRequestEmailRejected  = "rejected"
+)
+
 // IService interface defines the service methods/functions
 type IService interface {
 	AddCclaApprovalListRequest(ctx context.Context, companyID string, claGroupID string, args models.CclaWhitelistRequestInput) (string, error)
@@ -58,12 +66,13 @@ type service struct {
 	signatureRepo              signatures.SignatureRepository
 	projectsCLAGroupRepository projects_cla_groups.Repository
 	emailTemplateService       emails.EmailTemplateService
+	digestService              notification_digest.Service
 	corpConsoleURL             string
 	httpClient                 *http.Client
 }
 
 // NewService creates a new approval list service
-func NewService(repo IRepository, projectsCLAGroupRepository projects_cla_groups.Repository, projService service2.Service, userRepo users.UserRepository, companyRepo company.IRepository, projectRepo repository2.ProjectRepository, signatureRepo signatures.SignatureRepository, emailTemplateService emails.EmailTemplateService, corpConsoleURL string, httpClient *http.Client) IService {
+func NewService(repo IRepository, projectsCLAGroupRepository projects_cla_groups.Repository, projService service2.Service, userRepo users.UserRepository, companyRepo company.IRepository, projectRepo repository2.ProjectRepository, signatureRepo signatures.SignatureRepository, emailTemplateService emails.EmailTemplateService, digestService notification_digest.Service, corpConsoleURL string, httpClient *http.Client) IService {
 	return service{
 		repo:                       repo,
 		projectService:             projService,
@@ -73,6 +82,7 @@ func NewService(repo IRepository, projectsCLAGroupRepository projects_cla_groups
 		signatureRepo:              signatureRepo,
 		projectsCLAGroupRepository: projectsCLAGroupRepository,
 		emailTemplateService:       emailTemplateService,
+		digestService:              digestService,
 		corpConsoleURL:             corpConsoleURL,
 		httpClient:                 httpClient,
 	}
@@ -151,7 +161,7 @@ func (s service) AddCclaApprovalListRequest(ctx context.Context, companyID strin
 	}
 
 	// Send the emails to the CLA managers for this CCLA Signature which includes the managers in the ACL list
-	s.sendRequestSentEmail(companyModel, claGroupModel, sig.Signatures[0], args.ContributorName, args.ContributorEmail, args.RecipientName, args.RecipientEmail, args.Message)
+	s.sendRequestSentEmail(ctx, requestID, companyModel, claGroupModel, sig.Signatures[0], args.ContributorName, args.ContributorEmail, args.RecipientName, args.RecipientEmail, args.Message)
 
 	return requestID, nil
 }
@@ -232,7 +242,7 @@ func (s service) ApproveCclaApprovalListRequest(ctx context.Context, claUser *us
 	}
 
 	// Send the email
-	s.sendRequestApprovedEmailToRecipient(ctx,
+	s.sendRequestApprovedEmailToRecipient(ctx, requestID,
 		emails.CommonEmailParams{
 			RecipientName:    requestModel.UserName,
 			RecipientAddress: requestModel.UserEmails[0],
@@ -292,7 +302,7 @@ func (s service) RejectCclaApprovalListRequest(ctx context.Context, companyID, c
 	}
 
 	// Send the email
-	s.sendRequestRejectedEmailToRecipient(emails.CommonEmailParams{
+	s.sendRequestRejectedEmailToRecipient(requestID, emails.CommonEmailParams{
 		RecipientName:    requestModel.UserName,
 		RecipientAddress: requestModel.UserEmails[0],
 		CompanyName:      companyModel.CompanyName,
@@ -312,13 +322,13 @@ func (s service) ListCclaApprovalListRequestByCompanyProjectUser(companyID strin
 }
 
 // sendRequestSentEmail sends emails to the CLA managers specified in the signature record
-func (s service) sendRequestSentEmail(companyModel *models.Company, claGroupModel *models.ClaGroup, signature *models.Signature, contributorName, contributorEmail, recipientName, recipientEmail, message string) {
+func (s service) sendRequestSentEmail(ctx context.Context, requestID string, companyModel *models.Company, claGroupModel *models.ClaGroup, signature *models.Signature, contributorName, contributorEmail, recipientName, recipientEmail, message string) {
 
 	// If we have an override name and email from the request - possibly from the web form where the user selected the
 	// CLA Manager Name/Email from a list, send this to this recipient (CLA Manager) - otherwise we will send to all
 	// CLA Managers on the Signature ACL
 	if recipientName != "" && recipientEmail != "" {
-		s.sendRequestEmailToRecipient(emails.RequestToAuthorizeTemplateParams{
+		s.sendRequestEmailToRecipient(ctx, emails.RequestToAuthorizeTemplateParams{
 			CommonEmailParams: emails.CommonEmailParams{
 				RecipientName:    recipientName,
 				RecipientAddress: recipientEmail,
@@ -328,7 +338,7 @@ func (s service) sendRequestSentEmail(companyModel *models.Company, claGroupMode
 			ContributorEmail: contributorEmail,
 			OptionalMessage:  message,
 			CompanyID:        companyModel.CompanyID,
-		}, claGroupModel)
+		}, requestID, companyModel.CompanyID, claGroupModel)
 		return
 	}
 
@@ -349,7 +359,7 @@ func (s service) sendRequestSentEmail(companyModel *models.Company, claGroupMode
 			log.Warnf("unable to send email to manager: %+v - no email on file...", manager)
 		} else {
 			// Send the email
-			s.sendRequestEmailToRecipient(emails.RequestToAuthorizeTemplateParams{
+			s.sendRequestEmailToRecipient(ctx, emails.RequestToAuthorizeTemplateParams{
 				CommonEmailParams: emails.CommonEmailParams{
 					RecipientName:    manager.Username,
 					RecipientAddress: whichEmail,
@@ -358,23 +368,36 @@ func (s service) sendRequestSentEmail(companyModel *models.Company, claGroupMode
 				ContributorName:  contributorName,
 				ContributorEmail: contributorEmail,
 				OptionalMessage:  message,
-			}, claGroupModel)
+			}, requestID, companyModel.CompanyID, claGroupModel)
 		}
 	}
 }
 
-// sendRequestEmailToRecipient generates and sends an email to the specified recipient
-func (s service) sendRequestEmailToRecipient(emailParams emails.RequestToAuthorizeTemplateParams, claGroupModel *models.ClaGroup) {
+// sendRequestEmailToRecipient generates and sends an email to the specified recipient, the request is added to the
+// digest instead when the recipient prefers the daily or weekly digest
+func (s service) sendRequestEmailToRecipient(ctx context.Context, emailParams emails.RequestToAuthorizeTemplateParams, requestID, companyID string, claGroupModel *models.ClaGroup) {
 	projectName := claGroupModel.ProjectName
+	if s.digestService != nil && s.digestService.QueueNotification(ctx, &notification_digest.Notification{
+		RecipientEmail:   emailParams.RecipientAddress,
+		RecipientName:    emailParams.RecipientName,
+		NotificationType: notification_digest.NotificationTypeApprovalRequest,
+		CompanyID:        companyID,
+		CompanyName:      emailParams.CompanyName,
+		CLAGroupID:       claGroupModel.ProjectID,
+		CLAGroupName:     projectName,
+		Message:          fmt.Sprintf("%s (%s) requested to be added to the approval list", emailParams.ContributorName, emailParams.ContributorEmail),
+	}) {
+		return
+	}
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: Request to Authorize %s for %s", emailParams.ContributorName, projectName)
 	recipients := []string{emailParams.RecipientAddress}
-	body, err := emails.RenderRequestToAuthorizeTemplate(s.emailTemplateService, claGroupModel.Version, claGroupModel.ProjectExternalID, emailParams)
+	subject, body, err := emails.RenderRequestToAuthorizeTemplate(s.emailTemplateService, subject, claGroupModel.Version, claGroupModel.ProjectExternalID, emailParams)
 	if err != nil {
 		log.Warnf("rendering email template : %s failed : %v", emails.RequestToAuthorizeTemplateName, err)
 		return
 	}
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmailWithIdempotencyKey(approvalRequestEmailKey(requestID, approvalRequestEmailRequested), subject, body, recipients)
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -383,7 +406,7 @@ func (s service) sendRequestEmailToRecipient(emailParams emails.RequestToAuthori
 }
 
 // sendRequestRejectedEmailToRecipient generates and sends an email to the specified recipient
-func (s service) sendRequestRejectedEmailToRecipient(emailParams emails.CommonEmailParams, claGroupModel *models.ClaGroup, signature *models.Signature) {
+func (s service) sendRequestRejectedEmailToRecipient(requestID string, emailParams emails.CommonEmailParams, claGroupModel *models.ClaGroup, signature *models.Signature) {
 	projectName := claGroupModel.ProjectName
 
 	emailCLAManagerParams := []emails.ClaManagerInfoParams{}
@@ -413,8 +436,8 @@ func (s service) sendRequestRejectedEmailToRecipient(emailParams emails.CommonEm
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: Approval List Request Denied for Project %s", projectName)
 	recipients := []string{emailParams.RecipientAddress}
-	body, err := emails.RenderApprovalListRejectedTemplate(
-		s.emailTemplateService, claGroupModel.Version, claGroupModel.ProjectExternalID, emails.ApprovalListRejectedTemplateParams{
+	subject, body, err := emails.RenderApprovalListRejectedTemplate(
+		s.emailTemplateService, subject, claGroupModel.Version, claGroupModel.ProjectExternalID, emails.ApprovalListRejectedTemplateParams{
 			CommonEmailParams: emailParams,
 			CLAManagers:       emailCLAManagerParams,
 		})
@@ -422,7 +445,7 @@ func (s service) sendRequestRejectedEmailToRecipient(emailParams emails.CommonEm
 		log.Warnf("rendering email failed for : %s : %v", emails.ApprovalListRejectedTemplateName, err)
 		return
 	}
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmailWithIdempotencyKey(approvalRequestEmailKey(requestID, approvalRequestEmailRejected), subject, body, recipients)
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -430,7 +453,8 @@ func (s service) sendRequestRejectedEmailToRecipient(emailParams emails.CommonEm
 	}
 }
 
-func (s service) sendRequestApprovedEmailToRecipient(ctx context.Context, emailParams emails.CommonEmailParams, claUser user.CLAUser, projectSFIDs []string) {
+// sendRequestApprovedEmailToRecipient generates and sends an email to the specified recipient
+func (s service) sendRequestApprovedEmailToRecipient(ctx context.Context, requestID string, emailParams emails.CommonEmailParams, claUser user.CLAUser, projectSFIDs []string) {
 	f := logrus.Fields{
 		"functionName":     "v1.approval_list.service.sendRequestApprovedEmailToRecipient",
 		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
@@ -453,8 +477,8 @@ func (s service) sendRequestApprovedEmailToRecipient(ctx context.Context, emailP
 		approver = claUser.Emails[0]
 	}
 
-	body, err := emails.RenderApprovalListTemplate(
-		s.emailTemplateService, projectSFIDs, emails.ApprovalListApprovedTemplateParams{
+	subject, body, err := emails.RenderApprovalListTemplate(
+		s.emailTemplateService, subject, projectSFIDs, emails.ApprovalListApprovedTemplateParams{
 			CommonEmailParams: emailParams,
 			Approver:          approver,
 		})
@@ -462,10 +486,19 @@ func (s service) sendRequestApprovedEmailToRecipient(ctx context.Context, emailP
 		log.WithFields(f).Warnf("rendering email failed for : %s : %v", emails.ApprovalListApprovedTemplateName, err)
 		return
 	}
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmailWithIdempotencyKey(approvalRequestEmailKey(requestID, approvalRequestEmailApproved), subject, body, recipients)
 	if err != nil {
 		log.WithFields(f).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
 		log.WithFields(f).Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
 	}
 }
+
+// approvalRequestEmailKey returns the email idempotency key for the approval request event, an empty key falls back
+// to the email outbox content dedupe when the request was not stored
+func approvalRequestEmailKey(requestID, event string) string {
+	if requestID == "" {
+		return ""
+	}
+	return fmt.Sprintf("approval-request:%s:%s", requestID, event)
+}
@@ -0,0 +1,129 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_rules
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Registry holds the ordered set of rule types evaluated by the engine
+type Registry struct {
+	lock  sync.RWMutex
+	rules []Rule
+	index map[string]Rule
+}
+
+// NewRegistry creates an empty rule registry
+func NewRegistry() *Registry {
+	return &Registry{
+		index: make(map[string]Rule),
+	}
+}
+
+// Register adds the rule to the registry - rules are evaluated in registration order
+func (r *Registry) Register(rule Rule) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.index[rule.Type()]; ok {
+		return fmt.Errorf("approval rule type: %s is already registered", rule.Type())
+	}
+	r.index[rule.Type()] = rule
+	r.rules = append(r.rules, rule)
+	return nil
+}
+
+// Get returns the rule registered for the specified type, if any
+func (r *Registry) Get(ruleType string) (Rule, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	rule, ok := r.index[ruleType]
+	return rule, ok
+}
+
+// Rules returns the registered rules in evaluation order
+func (r *Registry) Rules() []Rule {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	rules := make([]Rule, len(r.rules))
+	copy(rules, r.rules)
+	return rules
+}
+
+// Checkers contains the membership checkers used by the organization/group rule types - nil checkers are allowed
+// if the caller's flow doesn't support the rule type, in which case the rule never matches
+type Checkers struct {
+	GitHubOrg   MembershipChecker
+	GitLabGroup MembershipChecker
+	GerritGroup MembershipChecker
+}
+
+// NewDefaultRegistry returns a registry with all the built-in rule types in the default evaluation order
+func NewDefaultRegistry(checkers Checkers) *Registry {
+	registry := NewRegistry()
+	for _, rule := range []Rule{
+		NewGitHubUsernameRule(),
+		NewGitLabUsernameRule(),
+		NewEmailRule(),
+		NewEmailRegexRule(),
+		NewDomainRule(),
+		NewGitHubOrgRule(checkers.GitHubOrg),
+		NewGitLabGroupRule(checkers.GitLabGroup),
+		NewGerritGroupRule(checkers.GerritGroup),
+	} {
+		// built-in types are unique, so registration can't fail
+		_ = registry.Register(rule)
+	}
+	return registry
+}
+
+// Engine evaluates an actor against a set of approval lists
+type Engine interface {
+	Evaluate(ctx context.Context, actor *Actor, lists *ApprovalLists) *Decision
+}
+
+type engine struct {
+	registry *Registry
+}
+
+// NewEngine creates a new approval rule engine using the rules of the specified registry
+func NewEngine(registry *Registry) Engine {
+	return &engine{
+		registry: registry,
+	}
+}
+
+// Evaluate runs each registered rule in order, stopping at the first match
+func (e *engine) Evaluate(ctx context.Context, actor *Actor, lists *ApprovalLists) *Decision {
+	f := logrus.Fields{
+		"functionName": "approval_rules.engine.Evaluate",
+		"userID":       actor.UserID,
+		"signatureID":  lists.SignatureID,
+	}
+
+	decision := &Decision{}
+	for _, rule := range e.registry.Rules() {
+		result := rule.Evaluate(ctx, actor, lists)
+		if result == nil {
+			continue
+		}
+		decision.Results = append(decision.Results, result)
+		if result.Err != nil {
+			log.WithFields(f).WithError(result.Err).Warnf("problem evaluating approval rule: %s", rule.Type())
+			continue
+		}
+		if result.Matched {
+			decision.Approved = true
+			decision.MatchedRule = result
+			break
+		}
+	}
+
+	log.WithFields(f).Debug(decision.Explain())
+	return decision
+}
@@ -0,0 +1,149 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_rules
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngineEvaluate(t *testing.T) {
+	memberOf := func(groups ...string) MembershipChecker {
+		return MembershipCheckerFunc(func(ctx context.Context, group, username string) (bool, error) {
+			for _, g := range groups {
+				if g == group {
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+	}
+	failing := MembershipCheckerFunc(func(ctx context.Context, group, username string) (bool, error) {
+		return false, errors.New("service unavailable")
+	})
+
+	actor := &Actor{
+		UserID:         "user-1",
+		GitHubUsername: "octocat",
+		GitLabUsername: "tanuki",
+		LFUsername:     "lfuser",
+		Emails:         []string{"Octo.Cat@example.com"},
+	}
+
+	testCases := []struct {
+		name         string
+		checkers     Checkers
+		lists        *ApprovalLists
+		expected     bool
+		expectedRule string
+		expectErr    bool
+	}{
+		{
+			name:  "empty approval lists",
+			lists: &ApprovalLists{},
+		},
+		{
+			name:         "github username match is case insensitive",
+			lists:        &ApprovalLists{GitHubUsernameApprovalList: []string{"OctoCat"}},
+			expected:     true,
+			expectedRule: RuleTypeGitHubUsername,
+		},
+		{
+			name:         "email match",
+			lists:        &ApprovalLists{EmailApprovalList: []string{"octo.cat@example.com"}},
+			expected:     true,
+			expectedRule: RuleTypeEmail,
+		},
+		{
+			name:         "email regex match",
+			lists:        &ApprovalLists{EmailRegexApprovalList: []string{`octo\..*@example\.com`}},
+			expected:     true,
+			expectedRule: RuleTypeEmailRegex,
+		},
+		{
+			name:  "email regex must match the whole address",
+			lists: &ApprovalLists{EmailRegexApprovalList: []string{`octo`}},
+		},
+		{
+			name:      "invalid email regex",
+			lists:     &ApprovalLists{EmailRegexApprovalList: []string{`(`}},
+			expectErr: true,
+		},
+		{
+			name:         "invalid email regex doesn't disable a later entry",
+			lists:        &ApprovalLists{EmailRegexApprovalList: []string{`(`, `octo\..*@example\.com`}},
+			expected:     true,
+			expectedRule: RuleTypeEmailRegex,
+		},
+		{
+			name:         "domain match",
+			lists:        &ApprovalLists{DomainApprovalList: []string{"example.com"}},
+			expected:     true,
+			expectedRule: RuleTypeDomain,
+		},
+		{
+			name:         "github org membership",
+			checkers:     Checkers{GitHubOrg: memberOf("acme")},
+			lists:        &ApprovalLists{GitHubOrgApprovalList: []string{"other", "acme"}},
+			expected:     true,
+			expectedRule: RuleTypeGitHubOrg,
+		},
+		{
+			name:     "github org without checker never matches",
+			lists:    &ApprovalLists{GitHubOrgApprovalList: []string{"acme"}},
+			expected: false,
+		},
+		{
+			name:         "gitlab group membership",
+			checkers:     Checkers{GitLabGroup: memberOf("https://gitlab.com/groups/acme")},
+			lists:        &ApprovalLists{GitLabGroupApprovalList: []string{"https://gitlab.com/groups/acme"}},
+			expected:     true,
+			expectedRule: RuleTypeGitLabGroup,
+		},
+		{
+			name:         "gerrit group membership",
+			checkers:     Checkers{GerritGroup: memberOf("ldap-group")},
+			lists:        &ApprovalLists{GerritGroupApprovalList: []string{"ldap-group"}},
+			expected:     true,
+			expectedRule: RuleTypeGerritGroup,
+		},
+		{
+			name:      "membership error is reported",
+			checkers:  Checkers{GitHubOrg: failing},
+			lists:     &ApprovalLists{GitHubOrgApprovalList: []string{"acme"}},
+			expectErr: true,
+		},
+		{
+			name:         "membership error doesn't hide a later match",
+			checkers:     Checkers{GitHubOrg: failing, GerritGroup: memberOf("ldap-group")},
+			lists:        &ApprovalLists{GitHubOrgApprovalList: []string{"acme"}, GerritGroupApprovalList: []string{"ldap-group"}},
+			expected:     true,
+			expectedRule: RuleTypeGerritGroup,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			engine := NewEngine(NewDefaultRegistry(tc.checkers))
+			decision := engine.Evaluate(context.Background(), actor, tc.lists)
+			assert.Equal(t, tc.expected, decision.Approved, decision.Explain())
+			if tc.expectedRule != "" {
+				assert.NotNil(t, decision.MatchedRule)
+				assert.Equal(t, tc.expectedRule, decision.MatchedRule.RuleType)
+			}
+			assert.Equal(t, tc.expectErr, decision.Err() != nil)
+			assert.NotEmpty(t, decision.Explain())
+		})
+	}
+}
+
+func TestRegistryRejectsDuplicates(t *testing.T) {
+	registry := NewRegistry()
+	assert.Nil(t, registry.Register(NewEmailRule()))
+	assert.NotNil(t, registry.Register(NewEmailRule()))
+	assert.Len(t, registry.Rules(), 1)
+}
@@ -0,0 +1,101 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule type identifiers - these are reported back in the decision so CLA managers know which rule matched
+const (
+	RuleTypeGitHubUsername = "github_username"
+	RuleTypeGitLabUsername = "gitlab_username"
+	RuleTypeEmail          = "email"
+	RuleTypeEmailRegex     = "email_regex"
+	RuleTypeDomain         = "domain"
+	RuleTypeGitHubOrg      = "github_org"
+	RuleTypeGitLabGroup    = "gitlab_group"
+	RuleTypeGerritGroup    = "gerrit_ldap_group"
+)
+
+// Actor is the contributor identity being evaluated against the approval lists
+type Actor struct {
+	UserID         string
+	GitHubUsername string
+	GitLabUsername string
+	LFUsername     string
+	Emails         []string
+}
+
+// ApprovalLists holds the approval list values of a CCLA signature
+type ApprovalLists struct {
+	SignatureID                string
+	EmailApprovalList          []string
+	EmailRegexApprovalList     []string
+	DomainApprovalList         []string
+	GitHubUsernameApprovalList []string
+	GitHubOrgApprovalList      []string
+	GitLabUsernameApprovalList []string
+	GitLabGroupApprovalList    []string
+	GerritGroupApprovalList    []string
+}
+
+// Result is the outcome of evaluating a single rule
+type Result struct {
+	RuleType string
+	Matched  bool
+	// Entry is the approval list entry that matched, if any
+	Entry string
+	// Identity is the actor identity (email, username) that matched the entry, if any
+	Identity string
+	Reason   string
+	Err      error
+}
+
+// String returns a human-readable version of the result
+func (r *Result) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("%s: error - %s", r.RuleType, r.Err.Error())
+	}
+	if r.Matched {
+		return fmt.Sprintf("%s: %s matched approval list entry %s", r.RuleType, r.Identity, r.Entry)
+	}
+	return fmt.Sprintf("%s: %s", r.RuleType, r.Reason)
+}
+
+// Decision is the overall approval decision along with the explanation of every rule evaluated
+type Decision struct {
+	Approved    bool
+	MatchedRule *Result
+	Results     []*Result
+}
+
+// Explain returns a summary of which rule matched, or why none did
+func (d *Decision) Explain() string {
+	if d.MatchedRule != nil {
+		return fmt.Sprintf("approved - %s", d.MatchedRule.String())
+	}
+	if len(d.Results) == 0 {
+		return "not approved - no approval rules evaluated"
+	}
+	var reasons []string
+	for _, result := range d.Results {
+		reasons = append(reasons, result.String())
+	}
+	return fmt.Sprintf("not approved - %s", strings.Join(reasons, "; "))
+}
+
+// Err returns the first rule evaluation error of a decision that wasn't approved, if any
+func (d *Decision) Err() error {
+	if d.Approved {
+		return nil
+	}
+	for _, result := range d.Results {
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+	return nil
+}
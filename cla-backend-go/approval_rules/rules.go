@@ -0,0 +1,256 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_rules
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/domain_matcher"
+)
+
+// Rule is a single approval rule type - rules return nil when they have nothing to evaluate (e.g. empty approval list)
+type Rule interface {
+	Type() string
+	Evaluate(ctx context.Context, actor *Actor, lists *ApprovalLists) *Result
+}
+
+// MembershipChecker determines if the username is a member of the specified organization or group
+type MembershipChecker interface {
+	IsMember(ctx context.Context, group, username string) (bool, error)
+}
+
+// MembershipCheckerFunc adapts a function to the MembershipChecker interface
+type MembershipCheckerFunc func(ctx context.Context, group, username string) (bool, error)
+
+// IsMember calls f(ctx, group, username)
+func (f MembershipCheckerFunc) IsMember(ctx context.Context, group, username string) (bool, error) {
+	return f(ctx, group, username)
+}
+
+// usernameRule matches a username against a list of usernames, case-insensitive
+type usernameRule struct {
+	ruleType string
+	username func(actor *Actor) string
+	entries  func(lists *ApprovalLists) []string
+}
+
+// NewGitHubUsernameRule returns a rule matching the GitHub username approval list
+func NewGitHubUsernameRule() Rule {
+	return &usernameRule{
+		ruleType: RuleTypeGitHubUsername,
+		username: func(actor *Actor) string { return actor.GitHubUsername },
+		entries:  func(lists *ApprovalLists) []string { return lists.GitHubUsernameApprovalList },
+	}
+}
+
+// NewGitLabUsernameRule returns a rule matching the GitLab username approval list
+func NewGitLabUsernameRule() Rule {
+	return &usernameRule{
+		ruleType: RuleTypeGitLabUsername,
+		username: func(actor *Actor) string { return actor.GitLabUsername },
+		entries:  func(lists *ApprovalLists) []string { return lists.GitLabUsernameApprovalList },
+	}
+}
+
+func (r *usernameRule) Type() string {
+	return r.ruleType
+}
+
+func (r *usernameRule) Evaluate(_ context.Context, actor *Actor, lists *ApprovalLists) *Result {
+	entries := r.entries(lists)
+	if len(entries) == 0 {
+		return nil
+	}
+	username := strings.TrimSpace(r.username(actor))
+	if username == "" {
+		return &Result{RuleType: r.ruleType, Reason: "user has no username for this approval list"}
+	}
+	for _, entry := range entries {
+		if strings.EqualFold(strings.TrimSpace(entry), username) {
+			return &Result{RuleType: r.ruleType, Matched: true, Entry: entry, Identity: username}
+		}
+	}
+	return &Result{RuleType: r.ruleType, Reason: fmt.Sprintf("username %s not in the approval list", username)}
+}
+
+// emailRule matches the user emails against the email approval list, case-insensitive
+type emailRule struct{}
+
+// NewEmailRule returns a rule matching the email approval list
+func NewEmailRule() Rule {
+	return &emailRule{}
+}
+
+func (r *emailRule) Type() string {
+	return RuleTypeEmail
+}
+
+func (r *emailRule) Evaluate(_ context.Context, actor *Actor, lists *ApprovalLists) *Result {
+	if len(lists.EmailApprovalList) == 0 {
+		return nil
+	}
+	for _, email := range actor.Emails {
+		for _, entry := range lists.EmailApprovalList {
+			if strings.EqualFold(strings.TrimSpace(email), strings.TrimSpace(entry)) {
+				return &Result{RuleType: RuleTypeEmail, Matched: true, Entry: entry, Identity: email}
+			}
+		}
+	}
+	return &Result{RuleType: RuleTypeEmail, Reason: fmt.Sprintf("none of the user emails %v are in the approval list", actor.Emails)}
+}
+
+// emailRegexRule matches the user emails against a list of regular expressions
+type emailRegexRule struct{}
+
+// NewEmailRegexRule returns a rule matching the email regex approval list - each expression must match the whole email address, case-insensitive
+func NewEmailRegexRule() Rule {
+	return &emailRegexRule{}
+}
+
+func (r *emailRegexRule) Type() string {
+	return RuleTypeEmailRegex
+}
+
+func (r *emailRegexRule) Evaluate(_ context.Context, actor *Actor, lists *ApprovalLists) *Result {
+	if len(lists.EmailRegexApprovalList) == 0 {
+		return nil
+	}
+	var lastErr error
+	for _, entry := range lists.EmailRegexApprovalList {
+		compiled, err := CompileEmailRegex(entry)
+		if err != nil {
+			// keep checking the remaining entries - one invalid expression shouldn't disable the valid ones
+			lastErr = err
+			continue
+		}
+		for _, email := range actor.Emails {
+			if compiled.MatchString(strings.TrimSpace(email)) {
+				return &Result{RuleType: RuleTypeEmailRegex, Matched: true, Entry: entry, Identity: email}
+			}
+		}
+	}
+	if lastErr != nil {
+		return &Result{RuleType: RuleTypeEmailRegex, Err: lastErr}
+	}
+	return &Result{RuleType: RuleTypeEmailRegex, Reason: fmt.Sprintf("none of the user emails %v match the approval list expressions", actor.Emails)}
+}
+
+// CompileEmailRegex compiles an email regex approval list entry - the expression is anchored and case-insensitive
+func CompileEmailRegex(expression string) (*regexp.Regexp, error) {
+	compiled, err := regexp.Compile(fmt.Sprintf("(?i)^(?:%s)$", strings.TrimSpace(expression)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid email regex approval list entry %q: %w", expression, err)
+	}
+	return compiled, nil
+}
+
+// domainRule matches the user emails against the domain approval list
+type domainRule struct {
+	cache *domain_matcher.Cache
+}
+
+// NewDomainRule returns a rule matching the domain approval list - the compiled approval lists are cached per signature
+func NewDomainRule() Rule {
+	return &domainRule{
+		cache: domain_matcher.NewCache(domain_matcher.DefaultCacheSize),
+	}
+}
+
+func (r *domainRule) Type() string {
+	return RuleTypeDomain
+}
+
+func (r *domainRule) Evaluate(_ context.Context, actor *Actor, lists *ApprovalLists) *Result {
+	if len(lists.DomainApprovalList) == 0 {
+		return nil
+	}
+	// invalid entries are skipped - they can't match anyone, but shouldn't block the valid entries either
+	matcher, err := r.cache.Get(lists.SignatureID, lists.DomainApprovalList)
+	for _, email := range actor.Emails {
+		if pattern, ok := matcher.MatchEmail(email); ok {
+			return &Result{RuleType: RuleTypeDomain, Matched: true, Entry: pattern.Entry, Identity: email}
+		}
+	}
+	reason := fmt.Sprintf("none of the user emails %v match the domain approval list", actor.Emails)
+	if err != nil {
+		reason = fmt.Sprintf("%s (ignored invalid entries: %s)", reason, strings.ReplaceAll(err.Error(), "\n", "; "))
+	}
+	return &Result{RuleType: RuleTypeDomain, Reason: reason}
+}
+
+// membershipRule checks the membership of a username in each of the organizations/groups in an approval list
+type membershipRule struct {
+	ruleType string
+	checker  MembershipChecker
+	username func(actor *Actor) string
+	entries  func(lists *ApprovalLists) []string
+}
+
+// NewGitHubOrgRule returns a rule matching GitHub organization membership
+func NewGitHubOrgRule(checker MembershipChecker) Rule {
+	return &membershipRule{
+		ruleType: RuleTypeGitHubOrg,
+		checker:  checker,
+		username: func(actor *Actor) string { return actor.GitHubUsername },
+		entries:  func(lists *ApprovalLists) []string { return lists.GitHubOrgApprovalList },
+	}
+}
+
+// NewGitLabGroupRule returns a rule matching GitLab group membership
+func NewGitLabGroupRule(checker MembershipChecker) Rule {
+	return &membershipRule{
+		ruleType: RuleTypeGitLabGroup,
+		checker:  checker,
+		username: func(actor *Actor) string { return actor.GitLabUsername },
+		entries:  func(lists *ApprovalLists) []string { return lists.GitLabGroupApprovalList },
+	}
+}
+
+// NewGerritGroupRule returns a rule matching LF LDAP group membership, as used by Gerrit
+func NewGerritGroupRule(checker MembershipChecker) Rule {
+	return &membershipRule{
+		ruleType: RuleTypeGerritGroup,
+		checker:  checker,
+		username: func(actor *Actor) string { return actor.LFUsername },
+		entries:  func(lists *ApprovalLists) []string { return lists.GerritGroupApprovalList },
+	}
+}
+
+func (r *membershipRule) Type() string {
+	return r.ruleType
+}
+
+func (r *membershipRule) Evaluate(ctx context.Context, actor *Actor, lists *ApprovalLists) *Result {
+	entries := r.entries(lists)
+	if len(entries) == 0 {
+		return nil
+	}
+	username := strings.TrimSpace(r.username(actor))
+	if username == "" {
+		return &Result{RuleType: r.ruleType, Reason: "user has no username for this approval list"}
+	}
+	if r.checker == nil {
+		return &Result{RuleType: r.ruleType, Reason: "membership checks are not supported for this flow"}
+	}
+
+	var lastErr error
+	for _, entry := range entries {
+		isMember, err := r.checker.IsMember(ctx, entry, username)
+		if err != nil {
+			// keep checking the remaining entries - a failure for one group shouldn't hide a match in another
+			lastErr = fmt.Errorf("unable to check membership of %s in %s: %w", username, entry, err)
+			continue
+		}
+		if isMember {
+			return &Result{RuleType: r.ruleType, Matched: true, Entry: entry, Identity: username}
+		}
+	}
+	if lastErr != nil {
+		return &Result{RuleType: r.ruleType, Err: lastErr}
+	}
+	return &Result{RuleType: r.ruleType, Reason: fmt.Sprintf("%s is not a member of any of %v", username, entries)}
+}
@@ -4,8 +4,10 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"path"
 
@@ -88,6 +90,37 @@ type jsonWebKeys struct {
 	X5c []string `json:"x5c"`
 }
 
+// CheckJWKS fetches the signing keys of the Auth0 tenant, the tokens can't be verified without them
+func (av Validator) CheckJWKS(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, av.wellKnownURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := resp.Body.Close()
+		if closeErr != nil {
+			log.WithError(closeErr).Warn("problem closing response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status code %d", av.wellKnownURL, resp.StatusCode)
+	}
+
+	var j = jwks{}
+	err = json.NewDecoder(resp.Body).Decode(&j)
+	if err != nil {
+		return err
+	}
+	if len(j.Keys) == 0 {
+		return errors.New("no signing keys in the JWKS")
+	}
+	return nil
+}
+
 func (av Validator) getPemCert(token *jwt.Token) (interface{}, error) {
 	cert := ""
 	resp, err := http.Get(av.wellKnownURL)
@@ -0,0 +1,314 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bot_allowlist
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ParseSkipCLA converts the skip_cla organization configuration into allowlist entries. The configuration maps a
+// repository pattern to a "<login>;<email>;<name>" actor pattern or to a "[<pattern>||<pattern>...]" list of them.
+// Entries are returned in evaluation order: exact repository names, regular expressions and then the wildcard.
+func ParseSkipCLA(skipCLA map[string]string) []*Entry {
+	repositories := make([]string, 0, len(skipCLA))
+	for repository := range skipCLA {
+		repositories = append(repositories, repository)
+	}
+	sort.Slice(repositories, func(i, j int) bool {
+		if repositoryRank(repositories[i]) != repositoryRank(repositories[j]) {
+			return repositoryRank(repositories[i]) < repositoryRank(repositories[j])
+		}
+		return repositories[i] < repositories[j]
+	})
+
+	var entries []*Entry
+	for _, repository := range repositories {
+		for _, actorPattern := range parseActorPatterns(skipCLA[repository]) {
+			parts := strings.Split(actorPattern, propertySeparator)
+			for len(parts) < 3 {
+				parts = append(parts, "")
+			}
+			// any extra separators belong to the name pattern, as it is the last one
+			entries = append(entries, NewEntry(repository, parts[0], parts[1], strings.Join(parts[2:], propertySeparator)))
+		}
+	}
+	return entries
+}
+
+// ToSkipCLA converts the allowlist entries back into the skip_cla organization configuration format
+func ToSkipCLA(entries []*Entry) map[string]string {
+	grouped := make(map[string][]string)
+	for _, entry := range entries {
+		grouped[entry.Repository] = append(grouped[entry.Repository], entry.ActorPattern())
+	}
+
+	skipCLA := make(map[string]string, len(grouped))
+	for repository, actorPatterns := range grouped {
+		value := actorPatterns[0]
+		// a single pattern that looks like a list must be wrapped, otherwise it would be parsed as one
+		if len(actorPatterns) > 1 || isList(value) {
+			value = listPrefix + strings.Join(actorPatterns, listSeparator) + listSuffix
+		}
+		skipCLA[repository] = value
+	}
+	return skipCLA
+}
+
+// parseActorPatterns splits a skip_cla value into its actor patterns
+func parseActorPatterns(value string) []string {
+	value = strings.TrimSpace(value)
+	if !isList(value) {
+		return []string{value}
+	}
+	parts := strings.Split(value[len(listPrefix):len(value)-len(listSuffix)], listSeparator)
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return parts
+}
+
+func isList(value string) bool {
+	return len(value) >= 2 && strings.HasPrefix(value, listPrefix) && strings.HasSuffix(value, listSuffix)
+}
+
+// repositoryRank returns the evaluation precedence of a repository pattern
+func repositoryRank(repository string) int {
+	switch {
+	case repository == Wildcard:
+		return 2
+	case strings.HasPrefix(repository, RegexPrefix):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Validate checks that the entries can be compiled and stored - the repository must be set, each regular expression
+// must compile, the patterns can't contain the skip_cla separators, and there can't be duplicate entries
+func Validate(entries []*Entry) error {
+	var errs []error
+	seen := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		if entry.Repository == "" {
+			errs = append(errs, fmt.Errorf("entry %s: repository pattern is required - use '%s' for all repositories", entry, Wildcard))
+		}
+		for property, pattern := range map[string]string{"repository": entry.Repository, "login": entry.Login, "email": entry.Email, "name": entry.Name} {
+			if strings.Contains(pattern, listSeparator) || (property != "repository" && strings.Contains(pattern, propertySeparator)) {
+				errs = append(errs, fmt.Errorf("entry %s: %s pattern can't contain '%s' or '%s'", entry, property, propertySeparator, listSeparator))
+			}
+		}
+		if _, ok := seen[entry.ID]; ok {
+			errs = append(errs, fmt.Errorf("entry %s: duplicate entry", entry))
+		}
+		seen[entry.ID] = struct{}{}
+	}
+
+	if _, err := Compile(entries); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// matcher matches a single actor property value
+type matcher func(value string) bool
+
+// compileMatcher compiles an actor property pattern
+func compileMatcher(pattern string) (matcher, error) {
+	switch {
+	case pattern == Wildcard:
+		return func(string) bool { return true }, nil
+	case pattern == "":
+		return func(value string) bool { return value == "" }, nil
+	case strings.HasPrefix(pattern, RegexPrefix):
+		re, err := regexp.Compile(pattern[len(RegexPrefix):])
+		if err != nil {
+			return nil, err
+		}
+		return func(value string) bool { return value != "" && re.MatchString(value) }, nil
+	default:
+		return func(value string) bool { return value == pattern }, nil
+	}
+}
+
+type compiledEntry struct {
+	entry *Entry
+	login matcher
+	email matcher
+	name  matcher
+}
+
+func (c *compiledEntry) test(actor *Actor, result *EntryResult) {
+	result.LoginMatched = c.login(actor.Login)
+	result.EmailMatched = c.email(actor.Email)
+	result.NameMatched = c.name(actor.Name)
+	result.ActorMatched = result.LoginMatched && result.EmailMatched && result.NameMatched
+}
+
+// repositoryConfig is the set of entries sharing the same repository pattern
+type repositoryConfig struct {
+	pattern string
+	regex   *regexp.Regexp
+	entries []*compiledEntry
+}
+
+func (r *repositoryConfig) matches(repository string) bool {
+	switch {
+	case r.pattern == Wildcard:
+		return true
+	case r.regex != nil:
+		return r.regex.MatchString(repository)
+	default:
+		return r.pattern == repository
+	}
+}
+
+// Allowlist is a compiled bot allowlist
+type Allowlist struct {
+	// configs are kept in evaluation order
+	configs []*repositoryConfig
+	invalid map[*Entry]error
+	entries []*Entry
+}
+
+// Compile compiles the allowlist entries. Invalid entries are left out of the allowlist and reported in the
+// returned error, so callers evaluating existing configuration can still use the valid entries.
+func Compile(entries []*Entry) (*Allowlist, error) {
+	allowlist := &Allowlist{
+		invalid: make(map[*Entry]error),
+		entries: entries,
+	}
+	byRepository := make(map[string]*repositoryConfig)
+	var errs []error
+
+	for _, entry := range entries {
+		config, ok := byRepository[entry.Repository]
+		if !ok {
+			config = &repositoryConfig{pattern: entry.Repository}
+			if strings.HasPrefix(entry.Repository, RegexPrefix) {
+				re, err := regexp.Compile(entry.Repository[len(RegexPrefix):])
+				if err != nil {
+					err = fmt.Errorf("entry %s: invalid repository regular expression: %w", entry, err)
+					allowlist.invalid[entry] = err
+					errs = append(errs, err)
+					continue
+				}
+				config.regex = re
+			}
+			byRepository[entry.Repository] = config
+			allowlist.configs = append(allowlist.configs, config)
+		}
+
+		compiled := &compiledEntry{entry: entry}
+		var err error
+		for _, p := range []struct {
+			property string
+			pattern  string
+			target   *matcher
+		}{
+			{"login", entry.Login, &compiled.login},
+			{"email", entry.Email, &compiled.email},
+			{"name", entry.Name, &compiled.name},
+		} {
+			*p.target, err = compileMatcher(p.pattern)
+			if err != nil {
+				err = fmt.Errorf("entry %s: invalid %s regular expression: %w", entry, p.property, err)
+				break
+			}
+		}
+		if err != nil {
+			allowlist.invalid[entry] = err
+			errs = append(errs, err)
+			continue
+		}
+		config.entries = append(config.entries, compiled)
+	}
+
+	sort.SliceStable(allowlist.configs, func(i, j int) bool {
+		return repositoryRank(allowlist.configs[i].pattern) < repositoryRank(allowlist.configs[j].pattern)
+	})
+	return allowlist, errors.Join(errs...)
+}
+
+// CompileSkipCLA parses and compiles the skip_cla organization configuration
+func CompileSkipCLA(skipCLA map[string]string) (*Allowlist, error) {
+	return Compile(ParseSkipCLA(skipCLA))
+}
+
+// IsEmpty returns true if the allowlist has no valid entries
+func (a *Allowlist) IsEmpty() bool {
+	for _, config := range a.configs {
+		if len(config.entries) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// selectRepository returns the repository configuration in effect for the repository - an exact name match first,
+// then the first matching regular expression and finally the wildcard
+func (a *Allowlist) selectRepository(repository string) *repositoryConfig {
+	for _, config := range a.configs {
+		if len(config.entries) > 0 && config.matches(repository) {
+			return config
+		}
+	}
+	return nil
+}
+
+// Match returns the first entry of the repository configuration in effect matching the actor, if any
+func (a *Allowlist) Match(repository string, actor *Actor) (*Entry, bool) {
+	config := a.selectRepository(repository)
+	if config == nil || actor == nil {
+		return nil, false
+	}
+	for _, compiled := range config.entries {
+		result := &EntryResult{}
+		compiled.test(actor, result)
+		if result.ActorMatched {
+			return compiled.entry, true
+		}
+	}
+	return nil, false
+}
+
+// Test checks the actor against every entry of the allowlist, reporting the outcome of each one
+func (a *Allowlist) Test(repository string, actor *Actor) *TestResult {
+	testResult := &TestResult{}
+	selected := a.selectRepository(repository)
+	if selected != nil {
+		testResult.SelectedRepository = selected.pattern
+	}
+
+	compiledEntries := make(map[*Entry]*compiledEntry)
+	repositoryMatches := make(map[string]bool)
+	for _, config := range a.configs {
+		repositoryMatches[config.pattern] = config.matches(repository)
+		for _, compiled := range config.entries {
+			compiledEntries[compiled.entry] = compiled
+		}
+	}
+
+	for _, entry := range a.entries {
+		result := &EntryResult{Entry: entry}
+		if err, ok := a.invalid[entry]; ok {
+			result.InvalidReason = err.Error()
+			testResult.Results = append(testResult.Results, result)
+			continue
+		}
+		result.RepositoryMatched = repositoryMatches[entry.Repository]
+		result.Selected = selected != nil && selected.pattern == entry.Repository
+		compiledEntries[entry].test(actor, result)
+		result.Matched = result.Selected && result.ActorMatched
+		if result.Matched && testResult.MatchedEntry == nil {
+			testResult.Matched = true
+			testResult.MatchedEntry = entry
+		}
+		testResult.Results = append(testResult.Results, result)
+	}
+	return testResult
+}
@@ -0,0 +1,219 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bot_allowlist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSkipCLA(t *testing.T) {
+	entries := ParseSkipCLA(map[string]string{
+		"*":          "dependabot[bot]",
+		"re:^docs-":  "[re:^renovate;*;||copilot-swe-agent[bot];;Copilot]",
+		"repo-1":     " bot-1;bot@example.com ",
+		"re:^tools-": "[[bot-2]]",
+	})
+
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Repository+" => "+entry.ActorPattern())
+	}
+	assert.Equal(t, []string{
+		"repo-1 => bot-1;bot@example.com;",
+		"re:^docs- => re:^renovate;*;",
+		"re:^docs- => copilot-swe-agent[bot];;Copilot",
+		"re:^tools- => [bot-2];;",
+		"* => dependabot[bot];;",
+	}, got)
+}
+
+func TestToSkipCLARoundTrip(t *testing.T) {
+	entries := []*Entry{
+		NewEntry("*", "dependabot[bot]", "", ""),
+		NewEntry("repo-1", "re:^bot-", "*", ""),
+		NewEntry("repo-1", "other-bot", "", ""),
+		NewEntry("repo-2", "[bot]", "*", "[bot]"),
+	}
+
+	skipCLA := ToSkipCLA(entries)
+	assert.Equal(t, map[string]string{
+		"*":      "dependabot[bot];;",
+		"repo-1": "[re:^bot-;*;||other-bot;;]",
+		"repo-2": "[[bot];*;[bot]]",
+	}, skipCLA)
+
+	parsed := ParseSkipCLA(skipCLA)
+	assert.ElementsMatch(t, entries, parsed)
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		entries []*Entry
+		valid   bool
+	}{
+		{
+			name:    "valid entries",
+			entries: []*Entry{NewEntry("*", "re:^bot-[0-9]+$", "*", ""), NewEntry("re:^repo-", "bot", "", "")},
+			valid:   true,
+		},
+		{
+			name:    "invalid login regex",
+			entries: []*Entry{NewEntry("*", "re:^bot-(", "", "")},
+		},
+		{
+			name:    "invalid repository regex",
+			entries: []*Entry{NewEntry("re:[repo", "bot", "", "")},
+		},
+		{
+			name:    "missing repository",
+			entries: []*Entry{NewEntry("", "bot", "", "")},
+		},
+		{
+			name:    "property separator in pattern",
+			entries: []*Entry{NewEntry("*", "bot;extra", "", "")},
+		},
+		{
+			name:    "list separator in pattern",
+			entries: []*Entry{NewEntry("*", "", "", "a||b")},
+		},
+		{
+			name:    "duplicate entries",
+			entries: []*Entry{NewEntry("*", "bot", "", ""), NewEntry("*", "bot", "", "")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.entries)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestAllowlistMatch(t *testing.T) {
+	allowlist, err := CompileSkipCLA(map[string]string{
+		"repo-1":    "exact-bot",
+		"re:^repo-": "regex-bot;*;*",
+		"*":         "[re:\\[bot\\]$;*;*||;ci@example.com;CI]",
+	})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		repository string
+		actor      *Actor
+		matched    bool
+		pattern    string
+	}{
+		{
+			name:       "exact repository takes precedence",
+			repository: "repo-1",
+			actor:      &Actor{Login: "exact-bot"},
+			matched:    true,
+			pattern:    "exact-bot;;",
+		},
+		{
+			name:       "exact repository hides the regex and wildcard entries",
+			repository: "repo-1",
+			actor:      &Actor{Login: "regex-bot"},
+		},
+		{
+			name:       "empty pattern requires an empty value",
+			repository: "repo-1",
+			actor:      &Actor{Login: "exact-bot", Email: "bot@example.com"},
+		},
+		{
+			name:       "regex repository",
+			repository: "repo-2",
+			actor:      &Actor{Login: "regex-bot", Email: "bot@example.com"},
+			matched:    true,
+			pattern:    "regex-bot;*;*",
+		},
+		{
+			name:       "wildcard repository with login regex",
+			repository: "other",
+			actor:      &Actor{Login: "dependabot[bot]", Name: "dependabot"},
+			matched:    true,
+			pattern:    "re:\\[bot\\]$;*;*",
+		},
+		{
+			name:       "regex pattern requires a value",
+			repository: "other",
+			actor:      &Actor{Email: "ci@example.com", Name: "CI"},
+			matched:    true,
+			pattern:    ";ci@example.com;CI",
+		},
+		{
+			name:       "no matching entry",
+			repository: "other",
+			actor:      &Actor{Login: "octocat", Email: "octocat@example.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entry, ok := allowlist.Match(tc.repository, tc.actor)
+			assert.Equal(t, tc.matched, ok)
+			if tc.matched {
+				assert.Equal(t, tc.pattern, entry.ActorPattern())
+			}
+		})
+	}
+}
+
+func TestAllowlistInvalidEntries(t *testing.T) {
+	allowlist, err := CompileSkipCLA(map[string]string{
+		"re:(":   "bot",
+		"repo-1": "[re:(;;||bot;;]",
+	})
+	assert.Error(t, err)
+	assert.False(t, allowlist.IsEmpty())
+
+	entry, ok := allowlist.Match("repo-1", &Actor{Login: "bot"})
+	assert.True(t, ok)
+	assert.Equal(t, "bot;;", entry.ActorPattern())
+
+	result := allowlist.Test("repo-1", &Actor{Login: "bot"})
+	assert.True(t, result.Matched)
+	assert.Equal(t, "repo-1", result.SelectedRepository)
+	assert.Len(t, result.Results, 3)
+
+	invalid := 0
+	for _, entryResult := range result.Results {
+		if entryResult.InvalidReason != "" {
+			invalid++
+			assert.False(t, entryResult.Matched)
+		}
+	}
+	assert.Equal(t, 2, invalid)
+}
+
+func TestAllowlistTest(t *testing.T) {
+	allowlist, err := CompileSkipCLA(map[string]string{
+		"repo-1": "bot",
+		"*":      "[bot||*;*;*]",
+	})
+	assert.NoError(t, err)
+
+	result := allowlist.Test("repo-1", &Actor{Login: "someone"})
+	assert.False(t, result.Matched)
+	assert.Nil(t, result.MatchedEntry)
+	assert.Equal(t, "repo-1", result.SelectedRepository)
+	assert.Len(t, result.Results, 3)
+
+	// the wildcard entry matches the actor, but is not in effect for the repository
+	wildcard := result.Results[2]
+	assert.Equal(t, "*", wildcard.Entry.Repository)
+	assert.True(t, wildcard.RepositoryMatched)
+	assert.False(t, wildcard.Selected)
+	assert.True(t, wildcard.ActorMatched)
+	assert.False(t, wildcard.Matched)
+}
@@ -0,0 +1,102 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bot_allowlist
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Wildcard matches any repository or any actor property value
+	Wildcard = "*"
+	// RegexPrefix marks a repository or actor property pattern as a regular expression
+	RegexPrefix = "re:"
+
+	propertySeparator = ";"
+	listPrefix        = "["
+	listSuffix        = "]"
+	listSeparator     = "||"
+)
+
+// Entry is a single bot allowlist entry - the actor patterns are applied to the repositories matching the
+// repository pattern. Each pattern is one of:
+//   - "*" which matches anything
+//   - "" which matches an empty or missing value
+//   - "re:<expression>" which matches a non-empty value against the regular expression
+//   - any other value, which must match exactly
+type Entry struct {
+	ID         string `json:"id"`
+	Repository string `json:"repository"`
+	Login      string `json:"login"`
+	Email      string `json:"email"`
+	Name       string `json:"name"`
+}
+
+// NewEntry creates an allowlist entry, assigning the entry ID
+func NewEntry(repository, login, email, name string) *Entry {
+	entry := &Entry{
+		Repository: strings.TrimSpace(repository),
+		Login:      strings.TrimSpace(login),
+		Email:      strings.TrimSpace(email),
+		Name:       strings.TrimSpace(name),
+	}
+	entry.ID = entryID(entry.Repository, entry.ActorPattern())
+	return entry
+}
+
+// ActorPattern returns the actor patterns in the skip_cla "<login>;<email>;<name>" format
+func (e *Entry) ActorPattern() string {
+	return strings.Join([]string{e.Login, e.Email, e.Name}, propertySeparator)
+}
+
+// String returns a human-readable version of the entry
+func (e *Entry) String() string {
+	return fmt.Sprintf("repository: '%s', actor: '%s'", e.Repository, e.ActorPattern())
+}
+
+// entryID returns a stable identifier for the entry - entries are stored in the skip_cla map, which has no room for
+// an identifier, so the ID is derived from the entry patterns
+func entryID(repository, actorPattern string) string {
+	sum := sha256.Sum256([]byte(repository + "\x00" + actorPattern))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// Actor is the commit author or merge request participant checked against the allowlist
+type Actor struct {
+	Login string
+	Email string
+	Name  string
+}
+
+// String returns a human-readable version of the actor
+func (a *Actor) String() string {
+	return fmt.Sprintf("login='%s',email='%s',name='%s'", a.Login, a.Email, a.Name)
+}
+
+// EntryResult is the outcome of checking an actor against a single entry
+type EntryResult struct {
+	Entry *Entry
+	// RepositoryMatched is true if the entry repository pattern matches the repository
+	RepositoryMatched bool
+	// Selected is true if the entry belongs to the repository configuration in effect - only one repository pattern
+	// applies to a repository: an exact name first, then a regular expression and finally the wildcard
+	Selected      bool
+	LoginMatched  bool
+	EmailMatched  bool
+	NameMatched   bool
+	ActorMatched  bool
+	Matched       bool
+	InvalidReason string
+}
+
+// TestResult is the outcome of checking an actor against the whole allowlist
+type TestResult struct {
+	Matched            bool
+	MatchedEntry       *Entry
+	SelectedRepository string
+	Results            []*EntryResult
+}
@@ -924,8 +924,8 @@ func sendRequestAccessEmailToCLAManagers(emailSvc emails.EmailTemplateService, e
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: New CLA Manager Access Request for %s on %s", companyName, projectName)
 	recipients := []string{emailParams.RecipientAddress}
-	body, err := emails.RenderRequestAccessToCLAManagersTemplate(
-		emailSvc, claGroupModel.Version, claGroupModel.ProjectExternalID, emailParams)
+	subject, body, err := emails.RenderRequestAccessToCLAManagersTemplate(
+		emailSvc, subject, claGroupModel.Version, claGroupModel.ProjectExternalID, emailParams)
 	if err != nil {
 		log.Warnf("rendering email template : %s failed : %v", emails.RequestAccessToCLAManagersTemplateName, err)
 		return
@@ -945,7 +945,7 @@ func sendRequestApprovedEmailToCLAManagers(emailSvc emails.EmailTemplateService,
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: CLA Manager Access Approval Notice for %s", projectName)
 	recipients := []string{emailParams.RecipientAddress}
-	body, err := emails.RenderRequestApprovedToCLAManagersTemplate(emailSvc, claGroupModel.Version, claGroupModel.ProjectExternalID, emailParams)
+	subject, body, err := emails.RenderRequestApprovedToCLAManagersTemplate(emailSvc, subject, claGroupModel.Version, claGroupModel.ProjectExternalID, emailParams)
 	if err != nil {
 		log.Warnf("rendering email template : %s failed : %v", emails.RequestApprovedToCLAManagersTemplateName, err)
 		return
@@ -964,7 +964,7 @@ func sendRequestApprovedEmailToRequester(emailSvc emails.EmailTemplateService, e
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: New CLA Manager Access Approved for %s", projectName)
 	recipients := []string{emailParams.RecipientAddress}
-	body, err := emails.RenderRequestApprovedToRequesterTemplate(emailSvc, claGroupModel.Version, claGroupModel.ProjectExternalID, emailParams)
+	subject, body, err := emails.RenderRequestApprovedToRequesterTemplate(emailSvc, subject, claGroupModel.Version, claGroupModel.ProjectExternalID, emailParams)
 	if err != nil {
 		log.Warnf("email template : %s failed rendering : %s", emails.RequestApprovedToRequesterTemplateName, err)
 		return
@@ -983,7 +983,7 @@ func sendRequestDeniedEmailToCLAManagers(emailSvc emails.EmailTemplateService, e
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: CLA Manager Access Denied Notice for %s", projectName)
 	recipients := []string{emailParams.RecipientAddress}
-	body, err := emails.RenderRequestDeniedToCLAManagersTemplate(emailSvc, claGroupModel.Version, claGroupModel.ProjectExternalID, emailParams)
+	subject, body, err := emails.RenderRequestDeniedToCLAManagersTemplate(emailSvc, subject, claGroupModel.Version, claGroupModel.ProjectExternalID, emailParams)
 
 	if err != nil {
 		log.Warnf("email template render : %s failed : %v", emails.RequestDeniedToCLAManagersTemplateName, err)
@@ -1004,7 +1004,7 @@ func sendRequestDeniedEmailToRequester(emailSvc emails.EmailTemplateService, ema
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: New CLA Manager Access Denied for %s", projectName)
 	recipients := []string{emailParams.RecipientAddress}
-	body, err := emails.RenderRequestDeniedToRequesterTemplate(emailSvc, claGroupModel.Version, claGroupModel.ProjectExternalID, emails.RequestDeniedToRequesterTemplateParams{
+	subject, body, err := emails.RenderRequestDeniedToRequesterTemplate(emailSvc, subject, claGroupModel.Version, claGroupModel.ProjectExternalID, emails.RequestDeniedToRequesterTemplateParams{
 		CommonEmailParams: emailParams,
 	})
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	"github.com/linuxfoundation/easycla/cla-backend-go/notification_digest"
 	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -52,11 +53,12 @@ type service struct {
 	sigService           signatures.SignatureService
 	eventsService        events.Service
 	emailTemplateService emails.EmailTemplateService
+	digestService        notification_digest.Service
 	corporateConsoleURL  string
 }
 
 // NewService creates a new service object
-func NewService(repo IRepository, projectClaRepository projects_cla_groups.Repository, companyService company.IService, projectService service2.Service, usersService users.Service, sigService signatures.SignatureService, eventsService events.Service, emailTemplateService emails.EmailTemplateService, corporateConsoleURL string) IService {
+func NewService(repo IRepository, projectClaRepository projects_cla_groups.Repository, companyService company.IService, projectService service2.Service, usersService users.Service, sigService signatures.SignatureService, eventsService events.Service, emailTemplateService emails.EmailTemplateService, digestService notification_digest.Service, corporateConsoleURL string) IService {
 	return service{
 		repo:                 repo,
 		projectClaRepository: projectClaRepository,
@@ -66,6 +68,7 @@ func NewService(repo IRepository, projectClaRepository projects_cla_groups.Repos
 		sigService:           sigService,
 		eventsService:        eventsService,
 		emailTemplateService: emailTemplateService,
+		digestService:        digestService,
 		corporateConsoleURL:  corporateConsoleURL,
 	}
 }
@@ -245,8 +248,12 @@ func (s service) AddClaManager(ctx context.Context, authUser *auth.User, company
 		return nil, companyACLError
 	}
 
-	// Notify CLA Managers - send email to each manager
+	// Notify CLA Managers - send email to each manager or add the change to their digest
 	for _, manager := range claManagers {
+		if s.queueCLAManagerChange(ctx, manager, companyModel, claGroupModel,
+			fmt.Sprintf("%s (%s) was added as a CLA Manager", userModel.Username, userModel.LfEmail.String())) {
+			continue
+		}
 		sendClaManagerAddedEmailToCLAManagers(s.emailTemplateService, emails.ClaManagerAddedToCLAManagersTemplateParams{
 			CommonEmailParams: emails.CommonEmailParams{
 				RecipientName:    manager.Username,
@@ -366,8 +373,12 @@ func (s service) RemoveClaManager(ctx context.Context, authUser *auth.User, comp
 		return nil, sigErr
 	}
 	claManagers := sigModel.SignatureACL
-	// Notify CLA Managers - send email to each manager
+	// Notify CLA Managers - send email to each manager or add the change to their digest
 	for _, manager := range claManagers {
+		if s.queueCLAManagerChange(ctx, manager, companyModel, claGroupModel,
+			fmt.Sprintf("%s (%s) was removed as a CLA Manager", userModel.LfUsername, userModel.LfEmail.String())) {
+			continue
+		}
 		s.sendClaManagerDeleteEmailToCLAManagers(s.emailTemplateService, emails.ClaManagerDeletedToCLAManagersTemplateParams{
 			CommonEmailParams: emails.CommonEmailParams{
 				RecipientName:    manager.Username,
@@ -463,7 +474,7 @@ func (s service) sendClaManagerAddedEmailToUser(emailSvc emails.EmailTemplateSer
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: Added as CLA Manager for Project :%s", claGroupModel.ProjectName)
 	recipients := []string{emailParams.RecipientAddress}
-	body, err := emails.RenderClaManagerAddedEToUserTemplate(emailSvc, claGroupModel.Version, projectSFID, emails.ClaManagerAddedEToUserTemplateParams{
+	subject, body, err := emails.RenderClaManagerAddedEToUserTemplate(emailSvc, subject, claGroupModel.Version, projectSFID, emails.ClaManagerAddedEToUserTemplateParams{
 		CommonEmailParams: emailParams,
 	})
 	if err != nil {
@@ -485,7 +496,7 @@ func sendClaManagerAddedEmailToCLAManagers(emailSvc emails.EmailTemplateService,
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: CLA Manager Added Notice for %s", projectName)
 	recipients := []string{emailParams.RecipientAddress}
-	body, err := emails.RenderClaManagerAddedToCLAManagersTemplate(emailSvc, claGroupModel.Version, projectName, emailParams)
+	subject, body, err := emails.RenderClaManagerAddedToCLAManagersTemplate(emailSvc, subject, claGroupModel.Version, claGroupModel.ProjectID, projectName, emailParams)
 	if err != nil {
 		log.Warnf("email template render : %s failed : %v", emails.ClaManagerAddedToCLAManagersTemplate, err)
 		return
@@ -548,14 +559,16 @@ func sendRemovedClaManagerEmailToRecipient(emailSvc emails.EmailTemplateService,
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: Removed as CLA Manager for Project %s", projectName)
 	recipients := []string{emailParams.RecipientAddress}
-	body, err := emails.RenderRemovedCLAManagerTemplate(
-		emailSvc,
+	subject, body, err := emails.RenderRemovedCLAManagerTemplate(
+		emailSvc, subject,
 		claGroupModel.Version,
 		emails.RemovedCLAManagerTemplateParams{
 			CommonEmailParams: emailParams,
 			CLAManagers:       emailCLAManagerParams,
 			CLAGroupTemplateParams: emails.CLAGroupTemplateParams{
-				CLAGroupName: projectName,
+				CLAGroupID:     claGroupModel.ProjectID,
+				CLAGroupName:   projectName,
+				FoundationSFID: claGroupModel.FoundationSFID,
 			},
 		})
 
@@ -572,12 +585,30 @@ func sendRemovedClaManagerEmailToRecipient(emailSvc emails.EmailTemplateService,
 	}
 }
 
+// queueCLAManagerChange adds the CLA manager change to the digest of the manager, it returns false when the manager
+// wants the notification emails immediately
+func (s service) queueCLAManagerChange(ctx context.Context, manager models.User, companyModel *models.Company, claGroupModel *models.ClaGroup, message string) bool {
+	if s.digestService == nil {
+		return false
+	}
+	return s.digestService.QueueNotification(ctx, &notification_digest.Notification{
+		RecipientEmail:   manager.LfEmail.String(),
+		RecipientName:    manager.Username,
+		NotificationType: notification_digest.NotificationTypeCLAManagerChange,
+		CompanyID:        companyModel.CompanyID,
+		CompanyName:      companyModel.CompanyName,
+		CLAGroupID:       claGroupModel.ProjectID,
+		CLAGroupName:     claGroupModel.ProjectName,
+		Message:          message,
+	})
+}
+
 func (s service) sendClaManagerDeleteEmailToCLAManagers(emailSvc emails.EmailTemplateService, emailParams emails.ClaManagerDeletedToCLAManagersTemplateParams, claGroupModel *models.ClaGroup) {
 
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: CLA Manager Removed Notice for %s", claGroupModel.ProjectName)
 	recipients := []string{emailParams.RecipientAddress}
-	body, err := emails.RenderClaManagerDeletedToCLAManagersTemplate(emailSvc, claGroupModel.Version, claGroupModel.ProjectName)
+	subject, body, err := emails.RenderClaManagerDeletedToCLAManagersTemplate(emailSvc, subject, claGroupModel.Version, claGroupModel.ProjectID, claGroupModel.ProjectName, emailParams)
 
 	if err != nil {
 		log.Warnf("email template render : %s failed : %v", emails.ClaManagerDeletedToCLAManagersTemplateName, err)
@@ -0,0 +1,157 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/linuxfoundation/easycla/cla-backend-go/approval_expiry"
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	claevents "github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github_organizations"
+	gitlab "github.com/linuxfoundation/easycla/cla-backend-go/gitlab_api"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/service"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/repositories"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/user"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+var expiryService approval_expiry.Service
+var reminderWindow time.Duration
+var dryRun bool
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+	if err := utils.SetEmailTransport(awsSession, stage, configFile); err != nil {
+		log.Panicf("Unable to configure the email transport - Error: %v", err)
+	}
+	// the pull requests of the users removed from the approval lists are updated
+	github.Init(configFile.GitHub.AppID, configFile.GitHub.AppPrivateKey, configFile.GitHub.AccessToken)
+	gitlabApp := gitlab.Init(configFile.Gitlab.AppClientID, configFile.Gitlab.AppClientSecret, configFile.Gitlab.AppPrivateKey)
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	userRepo := user.NewDynamoRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo := repository.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	githubOrganizationsRepo := github_organizations.NewRepository(awsSession, stage)
+	eventsRepo := claevents.NewRepository(awsSession, stage)
+	approvalRepo := approvals.NewRepository(stage, awsSession, fmt.Sprintf("cla-%s-approvals", stage))
+
+	type combinedRepo struct {
+		users.UserRepository
+		company.IRepository
+		repository.ProjectRepository
+		projects_cla_groups.Repository
+	}
+
+	eventsService := claevents.NewService(eventsRepo, combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+		projectClaGroupRepo,
+	})
+
+	usersService := users.NewService(usersRepo, eventsService)
+	projectService := service.NewService(projectRepo, repositoriesRepo, gerritRepo, projectClaGroupRepo, usersRepo)
+	companyService := company.NewService(companyRepo, configFile.CorporateConsoleV1URL, userRepo, usersService)
+	repositoriesService := repositories.NewService(repositoriesRepo, githubOrganizationsRepo, projectClaGroupRepo)
+	githubOrganizationsService := github_organizations.NewService(githubOrganizationsRepo, repositoriesRepo, projectClaGroupRepo)
+	signaturesRepo := signatures.NewRepository(awsSession, stage, companyRepo, usersRepo, eventsService, repositoriesRepo, githubOrganizationsRepo, gerrits.NewService(gerritRepo), approvalRepo)
+	signaturesService := signatures.NewService(signaturesRepo, companyService, usersService, eventsService, false, repositoriesService, githubOrganizationsService, projectService, nil, gitlabApp, configFile.ClaV1ApiURL, configFile.CLALandingPage, configFile.CLALogoURL)
+	emailTemplateService := emails.NewEmailTemplateService(projectRepo, projectClaGroupRepo, projectService,
+		emails.NewTemplateOverrideRepository(awsSession, stage), usersRepo, configFile.CorporateConsoleV1URL, configFile.CorporateConsoleV2URL)
+
+	expiryService = approval_expiry.NewService(approvalRepo, signaturesRepo, signaturesService, emailTemplateService, eventsService)
+	reminderWindow = time.Duration(intFromEnv("APPROVAL_EXPIRY_REMINDER_DAYS", int(approval_expiry.DefaultReminderWindow/(24*time.Hour)))) * 24 * time.Hour
+	dryRun = os.Getenv("DRY_RUN") == "true"
+}
+
+// intFromEnv returns the number in the environment variable, or the default value when not set
+func intFromEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		log.Warnf("invalid %s value: %s - using the default value: %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return number
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	report, err := expiryService.Process(ctx, approval_expiry.Options{
+		Now:            time.Now().UTC(),
+		ReminderWindow: reminderWindow,
+		DryRun:         dryRun,
+	})
+	if err != nil {
+		log.Fatalf("Unable to process the approval list expiry. error = %s", err)
+	}
+	log.Infof("approval list expiry - signatures: %d, expired: %d, reminded: %d, failed: %d, dry run: %t",
+		report.Signatures, report.Expired, report.Reminded, report.Failed, dryRun)
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(utils.NewContext(), events.CloudWatchEvent{})
+	} else {
+		lambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
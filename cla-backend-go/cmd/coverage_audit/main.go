@@ -0,0 +1,109 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/sirupsen/logrus"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github_organizations"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/repositories"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/coverage_audit"
+)
+
+var coverageAuditService coverage_audit.Service
+
+type combinedRepo struct {
+	users.UserRepository
+	company.IRepository
+	repository.ProjectRepository
+	projects_cla_groups.Repository
+}
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo := repository.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	githubOrganizationsRepo := github_organizations.NewRepository(awsSession, stage)
+	eventsRepo := events.NewRepository(awsSession, stage)
+	approvalRepo := approvals.NewRepository(stage, awsSession, fmt.Sprintf("cla-%s-approvals", stage))
+	eventsService := events.NewService(eventsRepo, combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+		projectClaGroupRepo,
+	})
+	signaturesRepo := signatures.NewRepository(awsSession, stage, companyRepo, usersRepo, eventsService, repositoriesRepo, githubOrganizationsRepo, gerrits.NewService(gerritRepo), approvalRepo)
+
+	coverageAuditService = coverage_audit.NewService(usersRepo, signaturesRepo, approvalRepo, eventsRepo)
+	log.Info("initialized repositories\n")
+}
+
+func main() {
+	f := logrus.Fields{
+		"functionName": "main",
+	}
+	claGroupID := flag.String("cla-group-id", "", "the CLA group ID")
+	identityType := flag.String("identity-type", coverage_audit.IdentityTypeEmail, "the identity type: email, githubUsername or gitlabUsername")
+	identity := flag.String("identity", "", "the contributor email address, GitHub username or GitLab username")
+	timestamp := flag.String("timestamp", "", "the point in time to audit, a RFC3339 timestamp or a YYYY-MM-DD date")
+	companyID := flag.String("company-id", "", "the company of the corporate coverage, defaults to the company of the user")
+	flag.Parse()
+
+	if *claGroupID == "" {
+		log.Fatal("cla-group-id is required")
+	}
+	at, err := coverage_audit.ParseTimestamp(*timestamp)
+	if err != nil {
+		log.WithFields(f).WithError(err).Fatal("invalid timestamp")
+	}
+	query := coverage_audit.Query{
+		IdentityType: *identityType,
+		Identity:     *identity,
+		At:           at,
+		CompanyID:    *companyID,
+	}
+	if err = query.Validate(); err != nil {
+		log.WithFields(f).WithError(err).Fatal("invalid coverage audit query")
+	}
+
+	ctx := context.WithValue(context.Background(), utils.XREQUESTID, "coverage-audit-cli") // nolint
+	result, err := coverageAuditService.GetCoverage(ctx, *claGroupID, query)
+	if err != nil {
+		log.WithFields(f).WithError(err).Fatal("unable to audit the CLA coverage")
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(result); err != nil {
+		log.WithFields(f).WithError(err).Fatal("unable to write the coverage audit")
+	}
+}
@@ -6,6 +6,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 
@@ -38,6 +39,7 @@ import (
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/dynamo_events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/webhooks"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/token"
 
@@ -46,6 +48,7 @@ import (
 	v2Company "github.com/linuxfoundation/easycla/cla-backend-go/v2/company"
 
 	claevents "github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/notification_digest"
 	"github.com/linuxfoundation/easycla/cla-backend-go/user"
 	"github.com/linuxfoundation/easycla/cla-backend-go/users"
 
@@ -160,6 +163,10 @@ func init() {
 		approvalListRequestsRepo,
 		gitlabApp,
 		gitlabOrgService,
+		dynamo_events.NewDeadLetterRepository(awsSession, stage),
+		// only queues the notifications, the digests are rendered and sent by the notification digest lambda
+		notification_digest.NewService(notification_digest.NewRepository(awsSession, stage), usersRepo, nil, ""),
+		webhooks.NewService(webhooks.NewRepository(awsSession, stage)),
 	)
 }
 
@@ -174,12 +181,51 @@ func printBuildInfo() {
 	log.Infof("Build date              : %s", buildDate)
 }
 
+// runDeadLetterCommand lists or replays the dead letter records, it returns false when no command was requested
+func runDeadLetterCommand(ctx context.Context) bool {
+	listDeadLetters := flag.Bool("list-dead-letters", false, "list the dead letter records")
+	status := flag.String("status", dynamo_events.DeadLetterStatusPending, "the status of the dead letter records to list, empty for all")
+	replayID := flag.String("replay", "", "the ID of the dead letter record to replay")
+	replayHandler := flag.String("replay-handler", "", "replay the pending dead letter records of the handler, e.g. UpdateCLAPermissions")
+	handlerName := flag.String("handler", "", "the handler name used to filter the listed dead letter records")
+	flag.Parse()
+
+	switch {
+	case *listDeadLetters:
+		records, err := dynamoEventsService.ListDeadLetters(ctx, *status, *handlerName)
+		if err != nil {
+			log.WithError(err).Fatal("unable to list the dead letter records")
+		}
+		for _, record := range records {
+			fmt.Printf("%s\t%s\t%s\t%s\tattempts=%d\treplays=%d\t%s\n", record.DeadLetterID, record.Status, record.TableName, record.DateCreated, record.Attempts, record.ReplayCount, record.ErrorMessage)
+		}
+		log.Infof("%d dead letter records", len(records))
+	case *replayID != "":
+		if err := dynamoEventsService.ReplayDeadLetter(ctx, *replayID); err != nil {
+			log.WithError(err).Fatalf("unable to replay the dead letter record %s", *replayID)
+		}
+		log.Infof("replayed the dead letter record %s", *replayID)
+	case *replayHandler != "":
+		result, err := dynamoEventsService.ReplayDeadLetters(ctx, *replayHandler)
+		if err != nil {
+			log.WithError(err).Fatalf("unable to replay the dead letter records of handler %s", *replayHandler)
+		}
+		log.Infof("replayed %d dead letter records of handler %s, %d failed", result.Replayed, *replayHandler, result.Failed)
+	default:
+		return false
+	}
+	return true
+}
+
 func main() {
 	log.Info("Lambda server starting...")
 	printBuildInfo()
 	if os.Getenv("LOCAL_MODE") == "true" {
+		if runDeadLetterCommand(utils.NewContext()) {
+			return
+		}
 		var dynamodbEvent events.DynamoDBEvent
-		args := os.Args[1:]
+		args := flag.Args()
 		if len(args) > 0 {
 			if err := json.Unmarshal([]byte(args[0]), &dynamodbEvent); err != nil {
 				log.Fatal(err)
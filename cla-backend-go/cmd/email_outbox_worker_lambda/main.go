@@ -0,0 +1,95 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+// defaultBatchSize is the number of queued emails sent per run
+const defaultBatchSize = 500
+
+var emailTransport utils.EmailSender
+var emailOutbox utils.EmailOutboxStore
+var batchSize int64
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+
+	// the queued emails are already recorded in the outbox, they are sent through the transport directly
+	emailTransport, err = utils.NewEmailTransport(awsSession, configFile)
+	if err != nil {
+		log.Panicf("Unable to configure the email transport - Error: %v", err)
+	}
+	emailOutbox = utils.NewDynamoEmailOutboxStore(awsSession, stage)
+
+	batchSize = defaultBatchSize
+	if value := os.Getenv("BATCH_SIZE"); value != "" {
+		size, parseErr := strconv.ParseInt(value, 10, 64)
+		if parseErr != nil || size <= 0 {
+			log.Warnf("invalid BATCH_SIZE value: %s - using the default value: %d", value, defaultBatchSize)
+		} else {
+			batchSize = size
+		}
+	}
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	result, err := utils.SendQueuedEmails(emailTransport, emailOutbox, batchSize)
+	if err != nil {
+		log.Fatalf("Unable to send the queued emails. error = %s", err)
+	}
+	log.Infof("queued emails - sent: %d, requeued: %d, failed: %d", result.Sent, result.Requeued, result.Failed)
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(utils.NewContext(), events.CloudWatchEvent{})
+	} else {
+		lambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
@@ -0,0 +1,175 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	claevents "github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/repositories"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/sign"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/store"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+var signService sign.Service
+var signaturesRepo signatures.SignatureRepository
+var reconcileOptions sign.ReconcileOptions
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo := repository.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	eventsRepo := claevents.NewRepository(awsSession, stage)
+	storeRepo := store.NewRepository(awsSession, stage)
+
+	type combinedRepo struct {
+		users.UserRepository
+		company.IRepository
+		repository.ProjectRepository
+		projects_cla_groups.Repository
+	}
+
+	eventsService := claevents.NewService(eventsRepo, combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+		projectClaGroupRepo,
+	})
+
+	// the reconciliation only reads the pending envelopes and records their status
+	signaturesRepo = signatures.NewRepository(awsSession, stage, companyRepo, usersRepo, eventsService, nil, nil, nil, nil)
+	signaturesService := signatures.NewService(signaturesRepo, nil, nil, eventsService, false, nil, nil, nil, nil, nil, configFile.ClaV1ApiURL, configFile.CLALandingPage, configFile.CLALogoURL)
+
+	claGroupProviders, err := sign.ParseCLAGroupProviders(os.Getenv("ESIGN_CLA_GROUP_PROVIDERS"))
+	if err != nil {
+		log.Panicf("Unable to parse the CLA Group e-signature providers - Error: %v", err)
+	}
+	providers, err := sign.NewProviders(os.Getenv("ESIGN_DEFAULT_PROVIDER"), claGroupProviders,
+		sign.NewDocuSignProvider(configFile.DocuSignPrivateKey),
+		sign.NewClickThroughProvider(configFile.ClaAPIV4Base, storeRepo))
+	if err != nil {
+		log.Panicf("Unable to initialize the e-signature providers - Error: %v", err)
+	}
+
+	signService = sign.NewService(configFile.ClaAPIV4Base, configFile.ClaV1ApiURL, companyRepo, projectRepo, projectClaGroupRepo, nil, nil, providers, nil, signaturesService, storeRepo, nil, nil, nil, configFile.CLALandingPage, configFile.CLALogoURL, nil, eventsService, nil, nil, nil)
+
+	reconcileOptions = sign.ReconcileOptions{
+		StaleAfter: daysFromEnv("ENVELOPE_STALE_DAYS", sign.DefaultEnvelopeStaleAfter),
+		VoidAfter:  daysFromEnv("ENVELOPE_VOID_AFTER_DAYS", 0),
+		MaxAge:     daysFromEnv("ENVELOPE_MAX_AGE_DAYS", sign.DefaultEnvelopeMaxAge),
+		Limit:      intFromEnv("ENVELOPE_LIMIT", sign.DefaultEnvelopeLimit),
+		DryRun:     os.Getenv("DRY_RUN") == "true",
+	}
+}
+
+// daysFromEnv returns the number of days in the environment variable as a duration, or the default value when not set
+func daysFromEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		log.Warnf("invalid %s value: %s - using the default value: %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// intFromEnv returns the positive number in the environment variable, or the default value when not set
+func intFromEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		log.Warnf("invalid %s value: %s - using the default value: %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return number
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	result, err := signService.ReconcileEnvelopes(ctx, reconcileOptions)
+	if err != nil {
+		log.Fatalf("Unable to reconcile the signature envelopes. error = %s", err)
+	}
+	log.Infof("envelope reconciliation - checked: %d, unchanged: %d, unprocessed: %d, completed: %d, declined: %d, voided: %d, abandoned: %d, stale: %d, failed: %d, dry run: %t",
+		result.Checked, result.Unchanged, result.Unprocessed, result.Completed, result.Declined, result.Voided, result.Abandoned, result.Stale, result.Failed, reconcileOptions.DryRun)
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	backfill := flag.Bool("backfill-pending-envelopes", false, "add the pending envelopes sent before the pending envelope index was added to the index")
+	flag.Parse()
+
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if *backfill {
+		added, err := signaturesRepo.BackfillPendingEnvelopeSignatures(utils.NewContext())
+		if err != nil {
+			log.Fatalf("Unable to backfill the pending envelope index. error = %s", err)
+		}
+		log.Infof("added %d signatures to the pending envelope index", added)
+		return
+	}
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(utils.NewContext(), events.CloudWatchEvent{})
+	} else {
+		lambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
@@ -0,0 +1,771 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package docusign
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/sign"
+	"github.com/sirupsen/logrus"
+)
+
+// Envelope statuses the simulator reports in the Connect notifications
+const (
+	StatusSent      = "Sent"
+	StatusCompleted = "Completed"
+	StatusDeclined  = "Declined"
+	StatusVoided    = "Voided"
+)
+
+// DefaultAccountID is the account ID used when none is configured
+const DefaultAccountID = "00000000-0000-0000-0000-000000000000"
+
+// timeFormat is the timestamp format used by DocuSign Connect
+const timeFormat = "2006-01-02T15:04:05.000"
+
+// errors
+var (
+	ErrEnvelopeNotFound = errors.New("envelope not found")
+	ErrInvalidOutcome   = errors.New("invalid signing outcome")
+)
+
+// Config is the simulator configuration
+type Config struct {
+	// Addr is the listen address, such as 127.0.0.1:8085 - a random local port is used if empty
+	Addr string
+	// AccountID is the DocuSign account ID the backend is configured with (DOCUSIGN_ACCOUNT_ID)
+	AccountID string
+	// Outcome is the envelope status emitted when the signer visits the sign URL: Completed, Declined or Voided
+	Outcome string
+	// NotifyAllEvents posts every status change to the envelope callback URL, by default only the
+	// events the envelope subscribed to are posted - the backend subscribes to Completed only
+	NotifyAllEvents bool
+}
+
+// Callback is a Connect notification posted by the simulator
+type Callback struct {
+	Status     string
+	StatusCode int
+	Error      string
+}
+
+// Envelope is an envelope created on the simulator
+type Envelope struct {
+	EnvelopeID      string
+	Request         sign.DocuSignEnvelopeRequest
+	Status          string
+	Created         time.Time
+	Completed       time.Time
+	ReturnURL       string
+	DeclineReason   string
+	Callbacks       []Callback
+	DocumentFetches int
+}
+
+// Simulator is an in-process fake of the DocuSign eSignature REST API. It implements the OAuth token,
+// envelope, recipient, recipient view and document endpoints used by the backend and posts DocuSign
+// Connect notifications to the envelope callback URL when an envelope changes status.
+type Simulator struct {
+	mu         sync.Mutex
+	config     Config
+	listener   net.Listener
+	server     *http.Server
+	baseURL    string
+	envelopes  map[string]*Envelope
+	order      []string
+	httpClient *http.Client
+}
+
+// NewSimulator creates a new simulator, call Start to begin serving requests
+func NewSimulator(config Config) *Simulator {
+	if config.AccountID == "" {
+		config.AccountID = DefaultAccountID
+	}
+	if config.Outcome == "" {
+		config.Outcome = StatusCompleted
+	}
+
+	return &Simulator{
+		config:     config,
+		envelopes:  make(map[string]*Envelope),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Start starts serving the simulator endpoints in the background
+func (s *Simulator) Start() error {
+	if err := validateOutcome(s.config.Outcome); err != nil {
+		return err
+	}
+
+	addr := s.config.Addr
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	s.listener = listener
+	s.baseURL = fmt.Sprintf("http://%s", listener.Addr().String())
+	s.server = &http.Server{
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if serveErr := s.server.Serve(listener); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			log.WithError(serveErr).Warn("docusign simulator stopped")
+		}
+	}()
+
+	log.Debugf("docusign simulator listening on %s", s.baseURL)
+	return nil
+}
+
+// Close stops the simulator
+func (s *Simulator) Close() error {
+	if s.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+// URL returns the simulator base URL, use it as the DOCUSIGN_AUTH_SERVER of the backend
+func (s *Simulator) URL() string {
+	return s.baseURL
+}
+
+// RootURL returns the REST API root URL, use it as the DOCUSIGN_ROOT_URL of the backend
+func (s *Simulator) RootURL() string {
+	return s.baseURL + "/restapi/v2.1"
+}
+
+// AccountID returns the simulated account ID
+func (s *Simulator) AccountID() string {
+	return s.config.AccountID
+}
+
+// SetOutcome changes the status emitted for envelopes signed from now on
+func (s *Simulator) SetOutcome(outcome string) error {
+	if err := validateOutcome(outcome); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config.Outcome = outcome
+	return nil
+}
+
+// SetNotifyAllEvents toggles posting of events the envelope did not subscribe to
+func (s *Simulator) SetNotifyAllEvents(notifyAllEvents bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config.NotifyAllEvents = notifyAllEvents
+}
+
+// Envelope returns a copy of the envelope with the specified ID
+func (s *Simulator) Envelope(envelopeID string) (Envelope, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	envelope, ok := s.envelopes[envelopeID]
+	if !ok {
+		return Envelope{}, false
+	}
+	return copyEnvelope(envelope), true
+}
+
+// Envelopes returns a copy of all envelopes in creation order
+func (s *Simulator) Envelopes() []Envelope {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	envelopes := make([]Envelope, 0, len(s.order))
+	for _, envelopeID := range s.order {
+		envelopes = append(envelopes, copyEnvelope(s.envelopes[envelopeID]))
+	}
+	return envelopes
+}
+
+// LastEnvelope returns the most recently created envelope
+func (s *Simulator) LastEnvelope() (Envelope, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.order) == 0 {
+		return Envelope{}, false
+	}
+	return copyEnvelope(s.envelopes[s.order[len(s.order)-1]]), true
+}
+
+// Sign applies the configured outcome to the envelope as if the signer acted on it, this is used for
+// envelopes which were emailed to the signer rather than opened from an embedded sign URL
+func (s *Simulator) Sign(envelopeID string) (Callback, error) {
+	s.mu.Lock()
+	outcome := s.config.Outcome
+	s.mu.Unlock()
+	return s.transition(envelopeID, outcome)
+}
+
+// Emit sets the envelope status and posts the Connect notification to the envelope callback URL, the
+// notification is posted even if the envelope did not subscribe to the event
+func (s *Simulator) Emit(envelopeID, status string) (Callback, error) {
+	if err := validateOutcome(status); err != nil {
+		return Callback{}, err
+	}
+
+	payload, callbackURL, err := s.updateStatus(envelopeID, status)
+	if err != nil {
+		return Callback{}, err
+	}
+
+	return s.notify(envelopeID, status, callbackURL, payload), nil
+}
+
+// updateStatus sets the envelope status and returns the Connect notification payload
+func (s *Simulator) updateStatus(envelopeID, status string) ([]byte, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	envelope, ok := s.envelopes[envelopeID]
+	if !ok {
+		return nil, "", ErrEnvelopeNotFound
+	}
+
+	envelope.Status = status
+	switch status {
+	case StatusCompleted:
+		envelope.Completed = time.Now().UTC()
+	case StatusDeclined:
+		envelope.DeclineReason = "Declined by the signer in the DocuSign simulator"
+	}
+
+	payload, err := xml.Marshal(connectNotification(envelope))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return payload, envelope.Request.EventNotification.URL, nil
+}
+
+// notify posts the Connect notification and records the result on the envelope
+func (s *Simulator) notify(envelopeID, status, callbackURL string, payload []byte) Callback {
+	f := logrus.Fields{
+		"functionName": "docusign.Simulator.notify",
+		"envelopeID":   envelopeID,
+		"status":       status,
+		"callbackURL":  callbackURL,
+	}
+
+	callback := Callback{Status: status}
+	if callbackURL == "" {
+		callback.Error = "envelope has no callback URL"
+	} else {
+		resp, err := s.httpClient.Post(callbackURL, "text/xml", bytes.NewReader(payload))
+		if err != nil {
+			callback.Error = err.Error()
+		} else {
+			callback.StatusCode = resp.StatusCode
+			if closeErr := resp.Body.Close(); closeErr != nil {
+				log.WithFields(f).WithError(closeErr).Warn("problem closing the response body")
+			}
+		}
+	}
+	log.WithFields(f).Debugf("connect notification result: %+v", callback)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if envelope, ok := s.envelopes[envelopeID]; ok {
+		envelope.Callbacks = append(envelope.Callbacks, callback)
+	}
+
+	return callback
+}
+
+// transition changes the envelope status as a result of an API call or signer action, the
+// notification is only posted if the envelope subscribed to the event
+func (s *Simulator) transition(envelopeID, status string) (Callback, error) {
+	s.mu.Lock()
+	envelope, ok := s.envelopes[envelopeID]
+	if !ok {
+		s.mu.Unlock()
+		return Callback{}, ErrEnvelopeNotFound
+	}
+	subscribed := s.config.NotifyAllEvents || isSubscribed(envelope.Request.EventNotification, status)
+	s.mu.Unlock()
+
+	if subscribed {
+		return s.Emit(envelopeID, status)
+	}
+
+	if _, _, err := s.updateStatus(envelopeID, status); err != nil {
+		return Callback{}, err
+	}
+	return Callback{Status: status}, nil
+}
+
+func (s *Simulator) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", s.handleToken)
+
+	prefix := "/restapi/v2.1/accounts/{accountID}/envelopes"
+	mux.HandleFunc("POST "+prefix, s.authorized(s.handleCreateEnvelope))
+	mux.HandleFunc("GET "+prefix+"/{envelopeID}", s.authorized(s.handleGetEnvelope))
+	mux.HandleFunc("GET "+prefix+"/{envelopeID}/recipients", s.authorized(s.handleRecipients))
+	mux.HandleFunc("POST "+prefix+"/{envelopeID}/views/recipient", s.authorized(s.handleRecipientView))
+	mux.HandleFunc("GET "+prefix+"/{envelopeID}/documents", s.authorized(s.handleDocuments))
+	mux.HandleFunc("GET "+prefix+"/{envelopeID}/documents/{documentID}", s.authorized(s.handleDocument))
+	mux.HandleFunc("PUT "+prefix+"/{envelopeID}/void", s.authorized(s.handleVoid))
+
+	mux.HandleFunc("GET /signing/{envelopeID}", s.handleSigning)
+	return mux
+}
+
+// authorized checks the bearer token and account ID of the REST API requests
+func (s *Simulator) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			writeError(w, http.StatusUnauthorized, "AUTHORIZATION_INVALID_TOKEN", "missing bearer token")
+			return
+		}
+		if r.PathValue("accountID") != s.config.AccountID {
+			writeError(w, http.StatusBadRequest, "INVALID_ACCOUNT_ID", "unknown account: "+r.PathValue("accountID"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Simulator) handleToken(w http.ResponseWriter, r *http.Request) {
+	var request sign.DocuSignGetTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Assertion == "" {
+		writeError(w, http.StatusBadRequest, "invalid_grant", "missing JWT assertion")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, sign.DocuSignGetTokenResponse{
+		AccessToken: "simulator-" + newID(),
+		TokenType:   "Bearer",
+		ExpiresIn:   3600,
+		Scope:       "signature impersonation",
+	})
+}
+
+func (s *Simulator) handleCreateEnvelope(w http.ResponseWriter, r *http.Request) {
+	var request sign.DocuSignEnvelopeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+	if len(request.Documents) == 0 || len(request.Recipients.Signers) == 0 {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST_BODY", "envelope requires at least one document and one signer")
+		return
+	}
+	for _, document := range request.Documents {
+		if _, err := base64.StdEncoding.DecodeString(document.DocumentBase64); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_DOCUMENT", "document is not base64 encoded")
+			return
+		}
+	}
+
+	envelope := &Envelope{
+		EnvelopeID: newID(),
+		Request:    request,
+		Status:     StatusSent,
+		Created:    time.Now().UTC(),
+	}
+
+	s.mu.Lock()
+	s.envelopes[envelope.EnvelopeID] = envelope
+	s.order = append(s.order, envelope.EnvelopeID)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, sign.DocusignEnvelopeResponse{
+		EnvelopeId:     envelope.EnvelopeID,
+		Status:         strings.ToLower(StatusSent),
+		StatusDateTime: envelope.Created.Format(time.RFC3339),
+		Uri:            "/envelopes/" + envelope.EnvelopeID,
+	})
+}
+
+func (s *Simulator) handleRecipients(w http.ResponseWriter, r *http.Request) {
+	envelope, ok := s.Envelope(r.PathValue("envelopeID"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "ENVELOPE_DOES_NOT_EXIST", "envelope not found")
+		return
+	}
+
+	response := sign.DocusignRecipientResponse{}
+	for _, signer := range envelope.Request.Recipients.Signers {
+		response.Signers = append(response.Signers, sign.Signer{
+			CreationReason:  "sender",
+			IsBulkRecipient: "false",
+			Name:            signer.Name,
+			Email:           signer.Email,
+			RecipientId:     signer.RecipientId,
+			RecipientIdGuid: newID(),
+			RequireIdLookup: "false",
+			ClientUserId:    signer.ClientUserId,
+			RoutingOrder:    "1",
+			RoleName:        signer.RoleName,
+			Status:          strings.ToLower(envelope.Status),
+			DeclinedReason:  envelope.DeclineReason,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleGetEnvelope returns the envelope status polled by the envelope reconciliation
+func (s *Simulator) handleGetEnvelope(w http.ResponseWriter, r *http.Request) {
+	envelope, ok := s.Envelope(r.PathValue("envelopeID"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "ENVELOPE_DOES_NOT_EXIST", "envelope not found")
+		return
+	}
+
+	statusChanged := envelope.Created
+	if !envelope.Completed.IsZero() {
+		statusChanged = envelope.Completed
+	}
+	response := sign.DocuSignEnvelopeResponseModel{
+		CreatedDateTime:       envelope.Created.Format(time.RFC3339),
+		EmailSubject:          envelope.Request.EmailSubject,
+		EnvelopeId:            envelope.EnvelopeID,
+		EnvelopeUri:           "/envelopes/" + envelope.EnvelopeID,
+		SentDateTime:          envelope.Created.Format(time.RFC3339),
+		Status:                strings.ToLower(envelope.Status),
+		StatusChangedDateTime: statusChanged.Format(time.RFC3339),
+	}
+	if envelope.Status == StatusVoided {
+		response.VoidedReason = "Voided in the DocuSign simulator"
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Simulator) handleRecipientView(w http.ResponseWriter, r *http.Request) {
+	var view sign.DocusignRecipientView
+	if err := json.NewDecoder(r.Body).Decode(&view); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	envelopeID := r.PathValue("envelopeID")
+	s.mu.Lock()
+	envelope, ok := s.envelopes[envelopeID]
+	if !ok {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "ENVELOPE_DOES_NOT_EXIST", "envelope not found")
+		return
+	}
+	if !hasEmbeddedSigner(envelope.Request, view.ClientUserId) {
+		s.mu.Unlock()
+		writeError(w, http.StatusBadRequest, "UNKNOWN_ENVELOPE_RECIPIENT", "the recipient is not an embedded signer of the envelope")
+		return
+	}
+	envelope.ReturnURL = view.ReturnURL
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, sign.RecipientViewResponse{
+		URL: s.baseURL + "/signing/" + envelopeID,
+	})
+}
+
+func (s *Simulator) handleDocuments(w http.ResponseWriter, r *http.Request) {
+	envelope, ok := s.Envelope(r.PathValue("envelopeID"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "ENVELOPE_DOES_NOT_EXIST", "envelope not found")
+		return
+	}
+
+	documents := make([]sign.DocuSignDocument, 0, len(envelope.Request.Documents))
+	for _, document := range envelope.Request.Documents {
+		documents = append(documents, sign.DocuSignDocument{
+			DocumentId:    document.DocumentId,
+			Name:          document.Name,
+			FileExtension: document.FileExtension,
+			Order:         document.Order,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, documents)
+}
+
+func (s *Simulator) handleDocument(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	envelope, ok := s.envelopes[r.PathValue("envelopeID")]
+	if !ok {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "ENVELOPE_DOES_NOT_EXIST", "envelope not found")
+		return
+	}
+
+	var content string
+	found := false
+	for _, document := range envelope.Request.Documents {
+		if document.DocumentId == r.PathValue("documentID") {
+			content, found = document.DocumentBase64, true
+			break
+		}
+	}
+	if found {
+		envelope.DocumentFetches++
+	}
+	s.mu.Unlock()
+
+	if !found {
+		writeError(w, http.StatusNotFound, "DOCUMENT_DOES_NOT_EXIST", "document not found")
+		return
+	}
+
+	pdf, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "INVALID_DOCUMENT", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(pdf); err != nil {
+		log.WithError(err).Warn("problem writing the document")
+	}
+}
+
+func (s *Simulator) handleVoid(w http.ResponseWriter, r *http.Request) {
+	envelopeID := r.PathValue("envelopeID")
+	envelope, ok := s.Envelope(envelopeID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "ENVELOPE_DOES_NOT_EXIST", "envelope not found")
+		return
+	}
+	if envelope.Status != StatusSent {
+		writeError(w, http.StatusBadRequest, "ENVELOPE_CANNOT_VOID_INVALID_STATE", "only sent envelopes can be voided")
+		return
+	}
+
+	// notify in the background, the backend voids envelopes while handling a signature request
+	go func() {
+		if _, err := s.transition(envelopeID, StatusVoided); err != nil {
+			log.WithError(err).Warnf("problem voiding envelope: %s", envelopeID)
+		}
+	}()
+
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+// handleSigning is the sign URL returned by the recipient view, it applies the configured outcome as if
+// the signer completed the DocuSign signing ceremony and redirects to the return URL
+func (s *Simulator) handleSigning(w http.ResponseWriter, r *http.Request) {
+	envelopeID := r.PathValue("envelopeID")
+	envelope, ok := s.Envelope(envelopeID)
+	if !ok {
+		http.Error(w, "envelope not found", http.StatusNotFound)
+		return
+	}
+	if envelope.Status != StatusSent {
+		http.Error(w, "envelope is "+strings.ToLower(envelope.Status), http.StatusConflict)
+		return
+	}
+
+	s.mu.Lock()
+	outcome := s.config.Outcome
+	s.mu.Unlock()
+
+	if _, err := s.transition(envelopeID, outcome); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if envelope.ReturnURL == "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	returnURL, err := url.Parse(envelope.ReturnURL)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	query := returnURL.Query()
+	query.Set("event", signingEvent(outcome))
+	returnURL.RawQuery = query.Encode()
+
+	http.Redirect(w, r, returnURL.String(), http.StatusFound)
+}
+
+// connectNotification builds the DocuSign Connect envelope information document for the envelope
+func connectNotification(envelope *Envelope) sign.DocuSignEnvelopeInformation {
+	now := time.Now().UTC().Format(timeFormat)
+	created := envelope.Created.Format(timeFormat)
+
+	status := sign.EnvelopeStatus{
+		TimeGenerated: now,
+		EnvelopeID:    envelope.EnvelopeID,
+		Subject:       envelope.Request.EmailSubject,
+		Status:        envelope.Status,
+		Created:       created,
+		Sent:          created,
+		Delivered:     created,
+	}
+	if envelope.Status == StatusCompleted {
+		status.Signed = envelope.Completed.Format(timeFormat)
+		status.Completed = status.Signed
+	}
+
+	for i, signer := range envelope.Request.Recipients.Signers {
+		recipient := sign.RecipientStatus{
+			Type:          "Signer",
+			Email:         signer.Email,
+			UserName:      signer.Name,
+			RoutingOrder:  i + 1,
+			Sent:          created,
+			Delivered:     created,
+			Signed:        status.Signed,
+			DeclineReason: envelope.DeclineReason,
+			Status:        envelope.Status,
+			ClientUserId:  signer.ClientUserId,
+			RecipientId:   signer.RecipientId,
+			TabStatuses:   tabStatuses(signer),
+		}
+		if envelope.Status != StatusDeclined {
+			recipient.DeclineReason = ""
+		}
+		status.RecipientStatuses = append(status.RecipientStatuses, recipient)
+	}
+
+	for i, document := range envelope.Request.Documents {
+		status.DocumentStatuses = append(status.DocumentStatuses, sign.DocumentStatus{
+			ID:       document.DocumentId,
+			Name:     document.Name,
+			Sequence: i + 1,
+		})
+	}
+
+	return sign.DocuSignEnvelopeInformation{EnvelopeStatus: status}
+}
+
+// tabStatuses returns the tab values entered by the simulated signer - text tabs keep their default value and
+// the name tabs fall back to the recipient name
+func tabStatuses(signer sign.DocuSignRecipient) []sign.TabStatus {
+	groups := []struct {
+		tabType string
+		tabs    []sign.DocuSignTabDetails
+	}{
+		{"Custom", signer.Tabs.TextTabs},
+		{"Custom", signer.Tabs.TextOptionalTabs},
+		{"Custom", signer.Tabs.NumberTabs},
+		{"SignHere", signer.Tabs.SignHereTabs},
+		{"SignHere", signer.Tabs.SignHereOptionalTabs},
+		{"DateSigned", signer.Tabs.DateSignedTabs},
+	}
+
+	var statuses []sign.TabStatus
+	for _, group := range groups {
+		for _, tab := range group.tabs {
+			value := tab.Value
+			if value == "" && (tab.TabLabel == "full_name" || tab.TabLabel == "signatory_name") {
+				value = signer.Name
+			}
+			statuses = append(statuses, sign.TabStatus{
+				TabType:    group.tabType,
+				Status:     "Signed",
+				TabLabel:   tab.TabLabel,
+				TabName:    tab.Name,
+				TabValue:   value,
+				DocumentID: tab.DocumentId,
+			})
+		}
+	}
+	return statuses
+}
+
+func isSubscribed(notification sign.DocuSignEventNotification, status string) bool {
+	for _, event := range notification.EnvelopeEvents {
+		if strings.EqualFold(event.EnvelopeEventStatusCode, status) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEmbeddedSigner(request sign.DocuSignEnvelopeRequest, clientUserID string) bool {
+	if clientUserID == "" {
+		return false
+	}
+	for _, signer := range request.Recipients.Signers {
+		if signer.ClientUserId == clientUserID {
+			return true
+		}
+	}
+	return false
+}
+
+// signingEvent returns the event query parameter DocuSign appends to the return URL
+func signingEvent(outcome string) string {
+	switch outcome {
+	case StatusCompleted:
+		return "signing_complete"
+	case StatusDeclined:
+		return "decline"
+	default:
+		return "cancel"
+	}
+}
+
+func validateOutcome(outcome string) error {
+	switch outcome {
+	case StatusCompleted, StatusDeclined, StatusVoided:
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidOutcome, outcome)
+}
+
+func copyEnvelope(envelope *Envelope) Envelope {
+	result := *envelope
+	result.Callbacks = append([]Callback(nil), envelope.Callbacks...)
+	return result
+}
+
+func newID() string {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return fmt.Sprintf("%032x", time.Now().UnixNano())
+	}
+	return id.String()
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.WithError(err).Warn("problem writing the response")
+	}
+}
+
+func writeError(w http.ResponseWriter, statusCode int, errorCode, message string) {
+	writeJSON(w, statusCode, struct {
+		ErrorCode string `json:"errorCode"`
+		Message   string `json:"message"`
+	}{
+		ErrorCode: errorCode,
+		Message:   message,
+	})
+}
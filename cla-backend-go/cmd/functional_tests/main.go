@@ -10,8 +10,10 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/cmd/functional_tests/cla_group"
 	"github.com/linuxfoundation/easycla/cla-backend-go/cmd/functional_tests/cla_manager"
 	"github.com/linuxfoundation/easycla/cla-backend-go/cmd/functional_tests/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/cmd/functional_tests/docusign"
 	"github.com/linuxfoundation/easycla/cla-backend-go/cmd/functional_tests/health"
 	"github.com/linuxfoundation/easycla/cla-backend-go/cmd/functional_tests/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/cmd/functional_tests/signing"
 	"github.com/linuxfoundation/easycla/cla-backend-go/cmd/functional_tests/template"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/cmd/repositories"
@@ -78,6 +80,12 @@ func main() {
 	log.Debugf("API_URL                 : %s", apiURL)
 	log.Debugf("v2_API_URL              : %s", v2APIURL)
 
+	// The signing flows run offline against a local backend configured with the DocuSign simulator
+	if simulatorAddr := os.Getenv("DOCUSIGN_SIMULATOR_ADDR"); simulatorAddr != "" {
+		runSigningTests(apiURL, simulatorAddr)
+		return
+	}
+
 	// Used as Prospective CLA Manager for Deal Project, Deal Company
 	auth0User1Config := loadUser("AUTH0_USER1")
 	// Used as CLA Manager for Deal Project, Deal Company
@@ -99,3 +107,35 @@ func main() {
 	repositories.NewTestBehaviour(v2APIURL, auth0User5Config).RunAllTests()
 	frisby.Global.PrintReport()
 }
+
+// runSigningTests starts the DocuSign simulator and runs the ICLA and CCLA signing flows
+func runSigningTests(apiURL, simulatorAddr string) {
+	simulator := docusign.NewSimulator(docusign.Config{
+		Addr:      simulatorAddr,
+		AccountID: os.Getenv("DOCUSIGN_SIMULATOR_ACCOUNT_ID"),
+	})
+	if err := simulator.Start(); err != nil {
+		log.WithError(err).Warnf("unable to start the DocuSign simulator on %s", simulatorAddr)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := simulator.Close(); err != nil {
+			log.WithError(err).Warn("problem stopping the DocuSign simulator")
+		}
+	}()
+
+	log.Debugf("DOCUSIGN_AUTH_SERVER    : %s", simulator.URL())
+	log.Debugf("DOCUSIGN_ROOT_URL       : %s", simulator.RootURL())
+	log.Debugf("DOCUSIGN_ACCOUNT_ID     : %s", simulator.AccountID())
+
+	signing.NewTestBehaviour(apiURL, simulator, signing.Config{
+		ProjectID:     os.Getenv("SIGNING_PROJECT_ID"),
+		UserID:        os.Getenv("SIGNING_USER_ID"),
+		ReturnURLType: os.Getenv("SIGNING_RETURN_URL_TYPE"),
+		ReturnURL:     os.Getenv("SIGNING_RETURN_URL"),
+		ProjectSFID:   os.Getenv("SIGNING_PROJECT_SFID"),
+		CompanySFID:   os.Getenv("SIGNING_COMPANY_SFID"),
+		AuthToken:     os.Getenv("SIGNING_AUTH_TOKEN"),
+	}).RunAllTests()
+	frisby.Global.PrintReport()
+}
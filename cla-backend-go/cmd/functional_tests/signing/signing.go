@@ -0,0 +1,210 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package signing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/cmd/functional_tests/docusign"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/verdverm/frisby"
+)
+
+// Config contains the test data used by the signing flows. The backend under test must be
+// configured with the DOCUSIGN_ROOT_URL, DOCUSIGN_AUTH_SERVER and DOCUSIGN_ACCOUNT_ID of the simulator.
+type Config struct {
+	// ICLA flow - the user must have active signature metadata, such as after opening a pull request
+	ProjectID     string
+	UserID        string
+	ReturnURLType string
+	ReturnURL     string
+
+	// CCLA flow - skipped when no auth token is configured
+	ProjectSFID string
+	CompanySFID string
+	AuthToken   string
+}
+
+// signatureOutput is the response of the signature request endpoints
+type signatureOutput struct {
+	SignatureID string `json:"signature_id"`
+	SignURL     string `json:"sign_url"`
+}
+
+// TestBehaviour data model
+type TestBehaviour struct {
+	apiURL     string
+	simulator  *docusign.Simulator
+	config     Config
+	httpClient *http.Client
+}
+
+// NewTestBehaviour creates a new test behavior model
+func NewTestBehaviour(apiURL string, simulator *docusign.Simulator, config Config) *TestBehaviour {
+	if config.ReturnURLType == "" {
+		config.ReturnURLType = "github"
+	}
+	if config.ReturnURL == "" {
+		config.ReturnURL = "https://github.com/"
+	}
+
+	return &TestBehaviour{
+		apiURL:    apiURL + "/v4",
+		simulator: simulator,
+		config:    config,
+		httpClient: &http.Client{
+			// stop at the return URL redirect issued by the simulator
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// RunICLADeclined requests an ICLA and declines the envelope
+func (t *TestBehaviour) RunICLADeclined() {
+	t.runICLA("Signing - ICLA - Declined", docusign.StatusDeclined)
+}
+
+// RunICLAVoided requests an ICLA and voids the envelope
+func (t *TestBehaviour) RunICLAVoided() {
+	t.runICLA("Signing - ICLA - Voided", docusign.StatusVoided)
+}
+
+// RunICLACompleted requests and signs an ICLA
+func (t *TestBehaviour) RunICLACompleted() {
+	t.runICLA("Signing - ICLA - Completed", docusign.StatusCompleted)
+}
+
+// RunCCLACompleted requests and signs a CCLA as the signatory
+func (t *TestBehaviour) RunCCLACompleted() {
+	if t.config.AuthToken == "" {
+		log.Warn("skipping CCLA signing tests - no auth token configured")
+		return
+	}
+
+	t.setOutcome(docusign.StatusCompleted)
+	frisby.Create("Signing - CCLA - Completed").
+		Post(t.apiURL+"/request-corporate-signature").
+		SetHeaders(map[string]string{
+			"Authorization":   "Bearer " + t.config.AuthToken,
+			"Content-Type":    "application/json",
+			"Accept-Encoding": "application/json",
+		}).
+		SetJson(map[string]interface{}{
+			"project_sfid":  t.config.ProjectSFID,
+			"company_sfid":  t.config.CompanySFID,
+			"send_as_email": false,
+			"return_url":    t.config.ReturnURL,
+		}).
+		Send().
+		ExpectStatus(200).
+		ExpectJsonType("signature_id", reflect.String).
+		ExpectJsonType("sign_url", reflect.String).
+		AfterText(func(F *frisby.Frisby, text string, err error) {
+			t.sign(F, text, docusign.StatusCompleted)
+		})
+}
+
+// RunAllTests runs all the signing tests - the declined and voided scenarios run first
+// as the user can no longer request an ICLA once it is signed
+func (t *TestBehaviour) RunAllTests() {
+	t.RunICLADeclined()
+	t.RunICLAVoided()
+	t.RunICLACompleted()
+	t.RunCCLACompleted()
+}
+
+func (t *TestBehaviour) runICLA(name, outcome string) {
+	t.setOutcome(outcome)
+	frisby.Create(name).
+		Post(t.apiURL+"/request-individual-signature").
+		SetHeaders(map[string]string{
+			"Content-Type":    "application/json",
+			"Accept-Encoding": "application/json",
+		}).
+		SetJson(map[string]string{
+			"project_id":      t.config.ProjectID,
+			"user_id":         t.config.UserID,
+			"return_url_type": t.config.ReturnURLType,
+			"return_url":      t.config.ReturnURL,
+		}).
+		Send().
+		ExpectStatus(200).
+		ExpectJsonType("signature_id", reflect.String).
+		ExpectJsonType("sign_url", reflect.String).
+		AfterText(func(F *frisby.Frisby, text string, err error) {
+			t.sign(F, text, outcome)
+		})
+}
+
+// setOutcome configures the simulator, declined and voided events are only posted to the
+// backend when the simulator ignores the envelope event subscription
+func (t *TestBehaviour) setOutcome(outcome string) {
+	if err := t.simulator.SetOutcome(outcome); err != nil {
+		log.WithError(err).Warnf("unable to set simulator outcome: %s", outcome)
+	}
+	t.simulator.SetNotifyAllEvents(outcome != docusign.StatusCompleted)
+}
+
+// sign opens the sign URL from the signature request response and verifies the simulator
+// redirected to the return URL and the backend accepted the Connect notification
+func (t *TestBehaviour) sign(F *frisby.Frisby, text, outcome string) {
+	var output signatureOutput
+	if err := json.Unmarshal([]byte(text), &output); err != nil {
+		F.AddError(fmt.Sprintf("unable to unmarshal signature response: %+v", err))
+		return
+	}
+	if output.SignURL == "" {
+		F.AddError("signature response is missing the sign_url")
+		return
+	}
+
+	resp, err := t.httpClient.Get(output.SignURL)
+	if err != nil {
+		F.AddError(fmt.Sprintf("unable to open sign url: %s - error: %+v", output.SignURL, err))
+		return
+	}
+	if closeErr := resp.Body.Close(); closeErr != nil {
+		log.WithError(closeErr).Warn("problem closing the response body")
+	}
+
+	F.Expect(func(F *frisby.Frisby) (bool, string) {
+		return resp.StatusCode == http.StatusFound, fmt.Sprintf("sign url returned status code: %d", resp.StatusCode)
+	})
+
+	redirect, err := url.Parse(resp.Header.Get("Location"))
+	F.Expect(func(F *frisby.Frisby) (bool, string) {
+		return err == nil && redirect.Query().Get("event") != "", fmt.Sprintf("unexpected return url redirect: %s", resp.Header.Get("Location"))
+	})
+
+	envelopeID := path.Base(output.SignURL)
+	envelope, ok := t.simulator.Envelope(envelopeID)
+	if !ok {
+		F.AddError(fmt.Sprintf("envelope: %s not found on the simulator", envelopeID))
+		return
+	}
+
+	F.Expect(func(F *frisby.Frisby) (bool, string) {
+		return envelope.Status == outcome, fmt.Sprintf("envelope status: %s, expected: %s", envelope.Status, outcome)
+	})
+	F.Expect(func(F *frisby.Frisby) (bool, string) {
+		if len(envelope.Callbacks) == 0 {
+			return false, "no Connect notification was posted to the backend"
+		}
+		callback := envelope.Callbacks[len(envelope.Callbacks)-1]
+		return callback.StatusCode == http.StatusOK, fmt.Sprintf("Connect notification returned status code: %d, error: %s", callback.StatusCode, callback.Error)
+	})
+
+	// the backend archives the signed document only for completed envelopes
+	F.Expect(func(F *frisby.Frisby) (bool, string) {
+		fetched := envelope.DocumentFetches > 0
+		return fetched == (outcome == docusign.StatusCompleted), fmt.Sprintf("signed document fetched %d times for %s envelope", envelope.DocumentFetches, outcome)
+	})
+}
@@ -0,0 +1,171 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/linuxfoundation/easycla/cla-backend-go/approval_rules"
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	claevents "github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrit_reconciliation"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/repositories"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+var reconciliationService gerrit_reconciliation.Service
+var reconciliationOptions gerrit_reconciliation.Options
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+	github.Init(configFile.GitHub.AppID, configFile.GitHub.AppPrivateKey, configFile.GitHub.AccessToken)
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo := repository.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	eventsRepo := claevents.NewRepository(awsSession, stage)
+
+	type combinedRepo struct {
+		users.UserRepository
+		company.IRepository
+		repository.ProjectRepository
+		projects_cla_groups.Repository
+	}
+
+	eventsService := claevents.NewService(eventsRepo, combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+		projectClaGroupRepo,
+	})
+
+	// the reconciliation only reads the signatures
+	signaturesRepo := signatures.NewRepository(awsSession, stage, companyRepo, usersRepo, eventsService, nil, nil, nil, nil)
+
+	lfGroup := &gerrits.LFGroup{
+		LfBaseURL:     configFile.LFGroup.ClientURL,
+		ClientID:      configFile.LFGroup.ClientID,
+		ClientSecret:  configFile.LFGroup.ClientSecret,
+		RefreshToken:  configFile.LFGroup.RefreshToken,
+		EventsService: eventsService,
+	}
+	approvalEngine := approval_rules.NewEngine(approval_rules.NewDefaultRegistry(approval_rules.Checkers{
+		GitHubOrg:   approval_rules.MembershipCheckerFunc(github.IsOrganizationMember),
+		GerritGroup: lfGroup,
+	}))
+
+	reconciliationService = gerrit_reconciliation.NewService(gerritRepo, signaturesRepo, usersRepo, lfGroup, approvalEngine, eventsService)
+
+	reconciliationOptions = gerrit_reconciliation.Options{
+		AutoFix:     os.Getenv("GERRIT_RECONCILIATION_AUTO_FIX") == "true",
+		GerritIDs:   splitList(os.Getenv("GERRIT_RECONCILIATION_GERRIT_IDS")),
+		MaxRemovals: intFromEnv("GERRIT_RECONCILIATION_MAX_REMOVALS", gerrit_reconciliation.DefaultMaxRemovals),
+	}
+}
+
+// splitList returns the values of the comma separated list
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// intFromEnv returns the number in the environment variable, or the default value when not set
+func intFromEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		log.Warnf("invalid %s value: %s - using the default value: %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return number
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	report, err := reconciliationService.Reconcile(ctx, reconciliationOptions)
+	if err != nil {
+		log.Fatalf("Unable to reconcile the gerrit groups. error = %s", err)
+	}
+	for _, group := range report.Groups {
+		if !group.HasDrift() && group.Error == "" && len(group.Unresolved) == 0 {
+			continue
+		}
+		// the drift report of each group, the usernames are listed so the drift can be fixed by hand
+		groupReport, jsonErr := json.Marshal(group)
+		if jsonErr != nil {
+			log.Warnf("unable to marshal the drift report of the group: %s - error: %v", group.GroupID, jsonErr)
+			continue
+		}
+		log.Infof("gerrit group drift: %s", string(groupReport))
+	}
+	log.Infof("gerrit group reconciliation - groups: %d, checked: %d, drifted: %d, added: %d, removed: %d, failed: %d, auto fix: %t",
+		len(report.Groups), report.Checked, report.Drifted, report.Added, report.Removed, report.Failed, reconciliationOptions.AutoFix)
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(utils.NewContext(), events.CloudWatchEvent{})
+	} else {
+		lambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
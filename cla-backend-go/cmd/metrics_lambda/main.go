@@ -6,6 +6,8 @@ package main
 import (
 	"context"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 
@@ -41,6 +43,7 @@ var (
 var awsSession = session.Must(session.NewSession(&aws.Config{}))
 var metricsRepo metrics.Repository
 var stage string
+var snapshotRetention = metrics.DefaultSnapshotRetention
 
 func init() {
 	stage = os.Getenv("STAGE")
@@ -56,13 +59,27 @@ func init() {
 	metricsRepo = metrics.NewRepository(awsSession, stage, configFile.APIGatewayURL, pcgRepo)
 	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
 	project_service.InitClient(configFile.APIGatewayURL)
+
+	// the snapshots are kept forever when the retention is zero
+	if value := os.Getenv("METRICS_SNAPSHOT_RETENTION_DAYS"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 {
+			log.Warnf("invalid METRICS_SNAPSHOT_RETENTION_DAYS value: %s - using the default retention: %s", value, snapshotRetention)
+		} else {
+			snapshotRetention = time.Duration(days) * 24 * time.Hour
+		}
+	}
 }
 
 func handler(ctx context.Context, event events.CloudWatchEvent) {
-	err := metricsRepo.CalculateAndSaveMetrics()
+	m, err := metricsRepo.CalculateAndSaveMetrics()
 	if err != nil {
 		log.Fatalf("Unable to save metrics in dynamodb. error = %s", err)
 	}
+	err = metricsRepo.SaveMetricsSnapshots(m, time.Now(), snapshotRetention)
+	if err != nil {
+		log.Fatalf("Unable to save metrics snapshots in dynamodb. error = %s", err)
+	}
 }
 
 func printBuildInfo() {
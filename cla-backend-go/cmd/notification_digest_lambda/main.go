@@ -0,0 +1,120 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/notification_digest"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/service"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/repositories"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+var digestService notification_digest.Service
+var weeklyDigestDay time.Weekday
+var dryRun bool
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+	if err := utils.SetEmailTransport(awsSession, stage, configFile); err != nil {
+		log.Panicf("Unable to configure the email transport - Error: %v", err)
+	}
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo := repository.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	projectService := service.NewService(projectRepo, repositoriesRepo, gerritRepo, projectClaGroupRepo, usersRepo)
+	emailTemplateService := emails.NewEmailTemplateService(projectRepo, projectClaGroupRepo, projectService,
+		emails.NewTemplateOverrideRepository(awsSession, stage), usersRepo, configFile.CorporateConsoleV1URL, configFile.CorporateConsoleV2URL)
+
+	digestService = notification_digest.NewService(notification_digest.NewRepository(awsSession, stage), usersRepo, emailTemplateService, configFile.CorporateConsoleV2URL)
+	weeklyDigestDay = weekdayFromEnv("WEEKLY_DIGEST_DAY", time.Monday)
+	dryRun = os.Getenv("DRY_RUN") == "true"
+}
+
+// weekdayFromEnv returns the day of the week in the environment variable, or the default value when not set
+func weekdayFromEnv(key string, defaultValue time.Weekday) time.Weekday {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), value) {
+			return day
+		}
+	}
+	log.Warnf("invalid %s value: %s - using the default value: %s", key, value, defaultValue)
+	return defaultValue
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	result, err := digestService.SendDigests(ctx, notification_digest.DigestOptions{
+		Now:             time.Now().UTC(),
+		WeeklyDigestDay: weeklyDigestDay,
+		DryRun:          dryRun,
+	})
+	if err != nil {
+		log.Fatalf("Unable to send the notification digests. error = %s", err)
+	}
+	log.Infof("notification digests - recipients: %d, sent: %d, deferred: %d, failed: %d, notifications: %d, dry run: %t",
+		result.Recipients, result.Sent, result.Deferred, result.Failed, result.Notifications, dryRun)
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(utils.NewContext(), events.CloudWatchEvent{})
+	} else {
+		lambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
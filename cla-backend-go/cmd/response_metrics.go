@@ -4,9 +4,12 @@
 package cmd
 
 import (
+	"net/http"
 	"sync"
 	"time"
 
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/linuxfoundation/easycla/cla-backend-go/telemetry"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 )
 
@@ -52,3 +55,22 @@ func getRequestMetrics(reqID string) *responseMetrics {
 func clearRequestMetrics(reqID string) {
 	reqMap.Delete(reqID)
 }
+
+// requestMetricsMiddleware records the latency of the request by swagger operation and response code, the route is
+// matched before the middleware runs
+func requestMetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		lrw := NewLoggingResponseWriter(w)
+		next.ServeHTTP(lrw, r)
+
+		api, operation := "unknown", "unknown"
+		if route := middleware.MatchedRouteFrom(r); route != nil {
+			api = route.BasePath
+			if route.Operation != nil && route.Operation.ID != "" {
+				operation = route.Operation.ID
+			}
+		}
+		telemetry.RequestDuration.WithLabelValues(api, operation, r.Method, telemetry.StatusCode(lrw.StatusCode)).Observe(time.Since(start).Seconds())
+	})
+}
@@ -19,7 +19,6 @@ import (
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/company"
 	"github.com/linuxfoundation/easycla/cla-backend-go/config"
-	"github.com/linuxfoundation/easycla/cla-backend-go/github_organizations"
 
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
 	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
@@ -37,8 +36,7 @@ var awsSession = session.Must(session.NewSession(&aws.Config{}))
 var companyRepo company.IRepository
 var usersRepo users.UserRepository
 
-var signService sign.Service
-var githubOrgService github_organizations.Service
+var docuSignProvider *sign.DocuSignProvider
 var report []ReportData
 var failed int = 0
 var success int = 0
@@ -52,12 +50,11 @@ func init() {
 	companyRepo = company.NewRepository(awsSession, stage)
 	usersRepo = users.NewRepository(awsSession, stage)
 	signatureRepo = signatures.NewRepository(awsSession, stage, companyRepo, usersRepo, nil, nil, nil, nil, nil)
-	githubOrgService = github_organizations.Service{}
 	configFile, err := config.LoadConfig("", awsSession, stage)
 	if err != nil {
 		log.Fatal(err)
 	}
-	signService = sign.NewService("", "", companyRepo, nil, nil, nil, nil, configFile.DocuSignPrivateKey, nil, nil, nil, nil, githubOrgService, nil, "", "", nil, nil, nil, nil, nil)
+	docuSignProvider = sign.NewDocuSignProvider(configFile.DocuSignPrivateKey)
 	// projectRepo = repository.NewRepository(awsSession, stage, nil, nil, nil)
 	utils.SetS3Storage(awsSession, configFile.SignatureFilesBucket)
 }
@@ -250,7 +247,7 @@ func main() { // nolint
 				log.WithFields(f).Debugf("no raw xml found for signature: %s", sig.SignatureID)
 				reportData.Comment = "No raw xml found"
 				// Fetch documentID
-				documents, docErr := signService.GetEnvelopeDocuments(ctx, sig.SignatureEnvelopeID)
+				documents, docErr := docuSignProvider.GetEnvelopeDocuments(ctx, sig.SignatureEnvelopeID)
 				if docErr != nil {
 					log.WithFields(f).WithError(err).Debugf("unable to get documents for signature: %s", sig.SignatureID)
 					reportData.Comment = docErr.Error()
@@ -297,7 +294,7 @@ func main() { // nolint
 			}
 
 			// get the document
-			document, docErr := signService.GetSignedDocument(ctx, envelopeID, documentID)
+			document, docErr := docuSignProvider.GetSignedDocument(ctx, envelopeID, documentID)
 			if docErr != nil {
 				log.WithFields(f).WithError(docErr).Debugf("unable to get document for signature: %s", sig.SignatureID)
 				reportData.Comment = docErr.Error()
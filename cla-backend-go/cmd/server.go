@@ -28,7 +28,9 @@ import (
 	gitlab "github.com/linuxfoundation/easycla/cla-backend-go/gitlab_api"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab_sign"
 
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
 	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	"github.com/linuxfoundation/easycla/cla-backend-go/notification_digest"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/dynamo_events"
 	v2GithubActivity "github.com/linuxfoundation/easycla/cla-backend-go/v2/github_activity"
@@ -50,9 +52,13 @@ import (
 	acs_service "github.com/linuxfoundation/easycla/cla-backend-go/v2/acs-service"
 	organization_service "github.com/linuxfoundation/easycla/cla-backend-go/v2/organization-service"
 
+	"github.com/linuxfoundation/easycla/cla-backend-go/github_membership"
 	"github.com/linuxfoundation/easycla/cla-backend-go/github_organizations"
+	v2BotAllowlist "github.com/linuxfoundation/easycla/cla-backend-go/v2/bot_allowlist"
+	v2EmailTemplates "github.com/linuxfoundation/easycla/cla-backend-go/v2/email_templates"
 	v2GithubOrganizations "github.com/linuxfoundation/easycla/cla-backend-go/v2/github_organizations"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/metrics"
+	v2Webhooks "github.com/linuxfoundation/easycla/cla-backend-go/v2/webhooks"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
 	v2Gerrits "github.com/linuxfoundation/easycla/cla-backend-go/v2/gerrits"
@@ -63,10 +69,12 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/docs"
 	v1Repositories "github.com/linuxfoundation/easycla/cla-backend-go/repositories"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	v2CoverageAudit "github.com/linuxfoundation/easycla/cla-backend-go/v2/coverage_audit"
 	v2Docs "github.com/linuxfoundation/easycla/cla-backend-go/v2/docs"
 	v2Events "github.com/linuxfoundation/easycla/cla-backend-go/v2/events"
 	v2Metrics "github.com/linuxfoundation/easycla/cla-backend-go/v2/metrics"
 	v2Repositories "github.com/linuxfoundation/easycla/cla-backend-go/v2/repositories"
+	v2UserMerge "github.com/linuxfoundation/easycla/cla-backend-go/v2/user_merge"
 	v2Version "github.com/linuxfoundation/easycla/cla-backend-go/v2/version"
 	"github.com/linuxfoundation/easycla/cla-backend-go/version"
 
@@ -305,11 +313,12 @@ func server(localMode bool) http.Handler {
 
 	v1ProjectClaGroupService := projects_cla_groups.NewService(v1ProjectClaGroupRepo)
 	usersService := users.NewService(usersRepo, eventsService)
-	healthService := health.New(Version, Commit, Branch, BuildDate)
 	templateService := template.NewService(stage, templateRepo, docraptorClient, awsSession)
 	v1ProjectService := service.NewService(v1CLAGroupRepo, gitV1Repository, gerritRepo, v1ProjectClaGroupRepo, usersRepo)
-	emailTemplateService := emails.NewEmailTemplateService(v1CLAGroupRepo, v1ProjectClaGroupRepo, v1ProjectService, configFile.CorporateConsoleV1URL, configFile.CorporateConsoleV2URL)
+	emailTemplateOverrideRepo := emails.NewTemplateOverrideRepository(awsSession, stage)
+	emailTemplateService := emails.NewEmailTemplateService(v1CLAGroupRepo, v1ProjectClaGroupRepo, v1ProjectService, emailTemplateOverrideRepo, usersRepo, configFile.CorporateConsoleV1URL, configFile.CorporateConsoleV2URL)
 	emailService := emails.NewService(emailTemplateService, v1ProjectService)
+	notificationDigestService := notification_digest.NewService(notification_digest.NewRepository(awsSession, stage), usersRepo, emailTemplateService, configFile.CorporateConsoleV2URL)
 	v2ProjectService := v2Project.NewService(v1ProjectService, v1CLAGroupRepo, v1ProjectClaGroupRepo)
 	v1CompanyService := v1Company.NewService(v1CompanyRepo, configFile.CorporateConsoleV1URL, userRepo, usersService)
 	v2CompanyService := v2Company.NewService(v1CompanyService, signaturesRepo, v1CLAGroupRepo, usersRepo, v1CompanyRepo, v1ProjectClaGroupRepo, eventsService)
@@ -319,27 +328,68 @@ func server(localMode bool) http.Handler {
 	v2RepositoriesService := v2Repositories.NewService(gitV1Repository, gitV2Repository, v1ProjectClaGroupRepo, githubOrganizationsRepo, gitlabOrganizationRepo, eventsService)
 	githubOrganizationsService := github_organizations.NewService(githubOrganizationsRepo, gitV1Repository, v1ProjectClaGroupRepo)
 	gitlabOrganizationsService := gitlab_organizations.NewService(gitlabOrganizationRepo, v2RepositoriesService, v1ProjectClaGroupRepo, storeRepository, usersService, signaturesRepo, v1CompanyRepo)
-	v1SignaturesService := signatures.NewService(signaturesRepo, v1CompanyService, usersService, eventsService, githubOrgValidation, v1RepositoriesService, githubOrganizationsService, v1ProjectService, gitlabApp, configFile.ClaV1ApiURL, configFile.CLALandingPage, configFile.CLALogoURL)
+	// GitHub organization membership results are shared by the approval list checks of every instance and the python
+	// backend, and invalidated by the GitHub webhooks
+	githubMembershipCache := github_membership.NewCache(github.IsOrganizationMember, github_membership.NewDynamoStore(awsSession, stage), github_membership.Config{})
+	v1SignaturesService := signatures.NewService(signaturesRepo, v1CompanyService, usersService, eventsService, githubOrgValidation, v1RepositoriesService, githubOrganizationsService, v1ProjectService, githubMembershipCache, gitlabApp, configFile.ClaV1ApiURL, configFile.CLALandingPage, configFile.CLALogoURL)
 	v2SignatureService := v2Signatures.NewService(awsSession, configFile.SignatureFilesBucket, v1ProjectService, v1CompanyService, v1SignaturesService, v1ProjectClaGroupRepo, signaturesRepo, usersService, approvalsRepo)
-	v1ClaManagerService := cla_manager.NewService(claManagerReqRepo, v1ProjectClaGroupRepo, v1CompanyService, v1ProjectService, usersService, v1SignaturesService, eventsService, emailTemplateService, configFile.CorporateConsoleV1URL)
-	v2ClaManagerService := v2ClaManager.NewService(emailTemplateService, v1CompanyService, v1ProjectService, v1ClaManagerService, usersService, v1RepositoriesService, v2CompanyService, eventsService, v1ProjectClaGroupRepo)
-	v1ApprovalListService := approval_list.NewService(approvalListRepo, v1ProjectClaGroupRepo, v1ProjectService, usersRepo, v1CompanyRepo, v1CLAGroupRepo, signaturesRepo, emailTemplateService, configFile.CorporateConsoleV2URL, http.DefaultClient)
+	v1ClaManagerService := cla_manager.NewService(claManagerReqRepo, v1ProjectClaGroupRepo, v1CompanyService, v1ProjectService, usersService, v1SignaturesService, eventsService, emailTemplateService, notificationDigestService, configFile.CorporateConsoleV1URL)
+	v2ClaManagerService := v2ClaManager.NewService(emailTemplateService, v1CompanyService, v1ProjectService, v1ClaManagerService, usersService, v1RepositoriesService, v2CompanyService, eventsService, v1ProjectClaGroupRepo, notificationDigestService)
+	v2TemplateService := v2Template.NewService(v1ProjectService, v1SignaturesService, usersService, emailTemplateService, eventsService, utils.NewDynamoEmailOutboxStore(awsSession, stage))
+	v1ApprovalListService := approval_list.NewService(approvalListRepo, v1ProjectClaGroupRepo, v1ProjectService, usersRepo, v1CompanyRepo, v1CLAGroupRepo, signaturesRepo, emailTemplateService, notificationDigestService, configFile.CorporateConsoleV2URL, http.DefaultClient)
 	authorizer := auth.NewAuthorizer(authValidator, userRepo)
 	v2MetricsService := metrics.NewService(metricsRepo, v1ProjectClaGroupRepo)
-	gitlabActivityService := gitlab_activity.NewService(gitV1Repository, gitV2Repository, usersRepo, signaturesRepo, v1ProjectClaGroupRepo, v1CompanyRepo, signaturesRepo, gitlabOrganizationsService)
+	v2CoverageAuditService := v2CoverageAudit.NewService(usersRepo, signaturesRepo, approvalsRepo, eventsRepo)
+	v2UserMergeService := v2UserMerge.NewService(usersRepo, signaturesRepo, approvalListRepo, eventsRepo, eventsService)
+	gitlabActivityService := gitlab_activity.NewService(gitV1Repository, gitV2Repository, usersRepo, signaturesRepo, v1ProjectClaGroupRepo, v1CompanyRepo, signaturesRepo, gitlabOrganizationsService, eventsService)
 	gitlabSignService := gitlab_sign.NewService(v2RepositoriesService, usersService, storeRepository, gitlabApp, gitlabOrganizationsService)
 	v2GithubOrganizationsService := v2GithubOrganizations.NewService(githubOrganizationsRepo, gitV1Repository, v1ProjectClaGroupRepo, githubOrganizationsService)
+	v2BotAllowlistService := v2BotAllowlist.NewService(githubOrganizationsRepo, gitlabOrganizationRepo)
+	v2EmailTemplatesService := v2EmailTemplates.NewService(emailTemplateOverrideRepo, v1ProjectClaGroupRepo)
+	v2WebhooksService := v2Webhooks.NewService(v2Webhooks.NewRepository(awsSession, stage))
 	autoEnableService := dynamo_events.NewAutoEnableService(v1RepositoriesService, gitV1Repository, githubOrganizationsRepo, v1ProjectClaGroupRepo, v1ProjectService)
-	v2GithubActivityService := v2GithubActivity.NewService(gitV1Repository, githubOrganizationsRepo, eventsService, autoEnableService, emailService)
+	v2GithubActivityService := v2GithubActivity.NewService(gitV1Repository, githubOrganizationsRepo, eventsService, autoEnableService, emailService, githubMembershipCache, v1SignaturesService)
 
 	v2ClaGroupService := cla_groups.NewService(v1ProjectService, templateService, v1ProjectClaGroupRepo, v1ClaManagerService, v1SignaturesService, metricsRepo, gerritService, v1RepositoriesService, eventsService)
-	v2SignService := sign.NewService(configFile.ClaAPIV4Base, configFile.ClaV1ApiURL, v1CompanyRepo, v1CLAGroupRepo, v1ProjectClaGroupRepo, v1CompanyService, v2ClaGroupService, configFile.DocuSignPrivateKey, usersService, v1SignaturesService, storeRepository, v1RepositoriesService, githubOrganizationsService, gitlabOrganizationsService, configFile.CLALandingPage, configFile.CLALogoURL, emailService, eventsService, gitlabActivityService, gitlabApp, gerritService)
+	// e-signature providers - DocuSign by default, click-through can be enabled globally or per CLA Group
+	claGroupSignProviders, err := sign.ParseCLAGroupProviders(viper.GetString("ESIGN_CLA_GROUP_PROVIDERS"))
+	if err != nil {
+		log.WithFields(f).WithError(err).Fatal("invalid ESIGN_CLA_GROUP_PROVIDERS value")
+	}
+	docuSignProvider := sign.NewDocuSignProvider(configFile.DocuSignPrivateKey)
+	signProviders, err := sign.NewProviders(viper.GetString("ESIGN_DEFAULT_PROVIDER"), claGroupSignProviders,
+		docuSignProvider,
+		sign.NewClickThroughProvider(configFile.ClaAPIV4Base, storeRepository))
+	if err != nil {
+		log.WithFields(f).WithError(err).Fatal("unable to configure the e-signature providers")
+	}
+
+	// Dependency probes of the readiness report - each probe has a timeout and reuses its result for a while
+	probeOptions := health.ProbeOptions{
+		Timeout:  viper.GetDuration("HEALTH_PROBE_TIMEOUT"),
+		CacheTTL: viper.GetDuration("HEALTH_PROBE_CACHE_TTL"),
+	}
+	healthService := health.New(Version, Commit, Branch, BuildDate,
+		health.NewProbe("EasyCLA - S3 - "+configFile.SignatureFilesBucket, health.S3BucketCheck(awsSession, configFile.SignatureFilesBucket), probeOptions),
+		health.NewProbe("EasyCLA - DocuSign - access token", docuSignProvider.CheckHealth, probeOptions),
+		health.NewProbe("EasyCLA - GitHub - app token", github.CheckAppToken, probeOptions),
+		health.NewProbe("EasyCLA - GitLab - app", func(ctx context.Context) error { return gitlab.CheckApp(ctx, gitlabApp) }, probeOptions),
+		health.NewProbe("EasyCLA - Auth0 - JWKS", authValidator.CheckJWKS, probeOptions),
+		health.NewProbe("EasyCLA - SSM - config", func(ctx context.Context) error { return config.CheckSSM(ctx, awsSession, stage) }, probeOptions),
+		health.NewProbe("EasyCLA - Platform - user service", health.PlatformServiceCheck(configFile.PlatformAPIGatewayURL, "user-service/v1"), probeOptions),
+		health.NewProbe("EasyCLA - Platform - organization service", health.PlatformServiceCheck(configFile.PlatformAPIGatewayURL, "organization-service"), probeOptions),
+		health.NewProbe("EasyCLA - Platform - project service", health.PlatformServiceCheck(configFile.PlatformAPIGatewayURL, "project-service"), probeOptions),
+		health.NewProbe("EasyCLA - Platform - access control service", health.PlatformServiceCheck(configFile.PlatformAPIGatewayURL, "acs/v1/api"), probeOptions),
+	)
+	v2SignService := sign.NewService(configFile.ClaAPIV4Base, configFile.ClaV1ApiURL, v1CompanyRepo, v1CLAGroupRepo, v1ProjectClaGroupRepo, v1CompanyService, v2ClaGroupService, signProviders, usersService, v1SignaturesService, storeRepository, v1RepositoriesService, githubOrganizationsService, gitlabOrganizationsService, configFile.CLALandingPage, configFile.CLALogoURL, emailService, eventsService, gitlabActivityService, gitlabApp, gerritService)
 
 	sessionStore, err := dynastore.New(dynastore.Path("/"), dynastore.HTTPOnly(), dynastore.TableName(configFile.SessionStoreTableName), dynastore.DynamoDB(dynamodb.New(awsSession)))
 	if err != nil {
 		log.WithFields(f).WithError(err).Panic("unable to create new Dynastore session")
 	}
-	utils.SetSnsEmailSender(awsSession, configFile.SNSEventTopicARN, configFile.SenderEmailAddress)
+	if err = utils.SetEmailTransport(awsSession, stage, configFile); err != nil {
+		log.WithFields(f).WithError(err).Fatal("unable to configure the email transport")
+	}
 	utils.SetS3Storage(awsSession, configFile.SignatureFilesBucket)
 
 	// Setup security handlers
@@ -353,7 +403,7 @@ func server(localMode bool) http.Handler {
 	health.Configure(api, healthService)
 	v2Health.Configure(v2API, healthService)
 	template.Configure(api, templateService, eventsService)
-	v2Template.Configure(v2API, templateService, v1ProjectClaGroupService, eventsService)
+	v2Template.Configure(v2API, templateService, v2TemplateService, v1ProjectClaGroupService, eventsService)
 	github.Configure(api, configFile.GitHub.ClientID, configFile.GitHub.ClientSecret, configFile.GitHub.AccessToken, sessionStore)
 	signatures.Configure(api, v1SignaturesService, sessionStore, eventsService)
 	v2Signatures.Configure(v2API, v1ProjectService, v1CLAGroupRepo, v1CompanyService, v1SignaturesService, sessionStore, eventsService, v2SignatureService, v1ProjectClaGroupRepo)
@@ -366,9 +416,14 @@ func server(localMode bool) http.Handler {
 	events.Configure(api, eventsService)
 	v2Events.Configure(v2API, eventsService, v1CompanyRepo, v1ProjectClaGroupRepo, v1ProjectService)
 	v2Metrics.Configure(v2API, v2MetricsService, v1CompanyRepo)
+	v2CoverageAudit.Configure(v2API, v2CoverageAuditService, v1ProjectClaGroupRepo)
+	v2UserMerge.Configure(v2API, v2UserMergeService)
 	github_organizations.Configure(api, githubOrganizationsService, eventsService)
 	v2GithubOrganizations.Configure(v2API, v2GithubOrganizationsService, eventsService)
 	gitlab_organizations.Configure(v2API, gitlabOrganizationsService, eventsService, sessionStore, configFile.CLAContributorv2Base)
+	v2BotAllowlist.Configure(v2API, v2BotAllowlistService, eventsService)
+	v2EmailTemplates.Configure(v2API, v2EmailTemplatesService, eventsService)
+	v2Webhooks.Configure(v2API, v2WebhooksService, eventsService)
 	gitlab_sign.Configure(v2API, gitlabSignService, eventsService, configFile.CLAContributorv2Base, sessionStore)
 	gitlab_activity.Configure(v2API, gitlabActivityService, gitlabOrganizationsService, eventsService, gitlabApp, gitlabSignService, configFile.CLAContributorv2Base, sessionStore)
 	v1Repositories.Configure(api, v1RepositoriesService, eventsService)
@@ -398,7 +453,7 @@ func server(localMode bool) http.Handler {
 	// The middleware configuration is for the handler executors. These do not apply to the swagger.json document.
 	// The middleware executes after routing but before authentication, binding and validation
 	middlewareSetupfunc := func(handler http.Handler) http.Handler {
-		return setRequestIDHandler(responseLoggingMiddleware(userCreaterMiddleware(handler)))
+		return setRequestIDHandler(requestMetricsMiddleware(responseLoggingMiddleware(userCreaterMiddleware(handler))))
 	}
 
 	v2API.CsvProducer = openapi_runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
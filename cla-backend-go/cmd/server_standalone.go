@@ -14,6 +14,7 @@ import (
 	"syscall"
 
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/telemetry"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -22,12 +23,15 @@ import (
 func runServer(cmd *cobra.Command, args []string) {
 	log.Info("Staring the HTTP server in local mode...")
 
-	handler := server(true)
+	// the operational metrics are exposed next to the API for the Prometheus scraper
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", telemetry.Handler())
+	mux.Handle("/", server(true))
 
 	errs := make(chan error, 2)
 	go func() {
 		log.Infof("Running http server on port: %d - set PORT environment variable to change port", viper.GetInt("PORT"))
-		errs <- http.ListenAndServe(fmt.Sprintf(":%d", viper.GetInt("PORT")), handler) // nolint gosec no support for setting timeouts
+		errs <- http.ListenAndServe(fmt.Sprintf(":%d", viper.GetInt("PORT")), mux) // nolint gosec no support for setting timeouts
 	}()
 	go func() {
 		c := make(chan os.Signal)
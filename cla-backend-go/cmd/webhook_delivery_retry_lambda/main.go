@@ -0,0 +1,85 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/webhooks"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+// defaultBatchSize is the number of pending deliveries retried per run
+const defaultBatchSize = 200
+
+var webhookService webhooks.Service
+var batchSize int64
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+
+	webhookService = webhooks.NewService(webhooks.NewRepository(awsSession, stage))
+
+	batchSize = defaultBatchSize
+	if value := os.Getenv("BATCH_SIZE"); value != "" {
+		size, parseErr := strconv.ParseInt(value, 10, 64)
+		if parseErr != nil || size <= 0 {
+			log.Warnf("invalid BATCH_SIZE value: %s - using the default value: %d", value, defaultBatchSize)
+		} else {
+			batchSize = size
+		}
+	}
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	result, err := webhookService.RetryPendingDeliveries(utils.NewContext(), batchSize)
+	if err != nil {
+		log.Fatalf("Unable to retry the pending webhook deliveries. error = %s", err)
+	}
+	log.Infof("pending webhook deliveries - sent: %d, requeued: %d, failed: %d", result.Sent, result.Requeued, result.Failed)
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(utils.NewContext(), events.CloudWatchEvent{})
+	} else {
+		lambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
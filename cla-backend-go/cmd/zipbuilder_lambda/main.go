@@ -53,7 +53,7 @@ func init() {
 		log.Fatal("CLA_SIGNATURE_FILES_BUCKET is not set in environment")
 	}
 	log.Infof("CLA_SIGNATURE_FILES_BUCKET : %s", signaturesFileBucket)
-	zipBuilder = signatures.NewZipBuilder(awsSession, signaturesFileBucket)
+	zipBuilder = signatures.NewZipBuilder(awsSession, signaturesFileBucket, stage)
 }
 
 func handler(ctx context.Context, event BuildZipEvent) error {
@@ -0,0 +1,54 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package commit_identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePolicy(t *testing.T) {
+	for value, expected := range map[string]Policy{
+		"":                   PolicyAuthors,
+		"authors":            PolicyAuthors,
+		" ALL ":              PolicyAll,
+		"authors_committers": PolicyAuthorsCommitters,
+		"authors_co_authors": PolicyAuthorsCoAuthors,
+	} {
+		policy, err := ParsePolicy(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, policy, value)
+	}
+
+	_, err := ParsePolicy("committers")
+	assert.Error(t, err)
+	assert.Equal(t, PolicyAuthors, PolicyOf("committers"))
+
+	assert.False(t, PolicyAuthors.IncludeCommitters())
+	assert.False(t, PolicyAuthors.IncludeCoAuthors())
+	assert.True(t, PolicyAuthorsCommitters.IncludeCommitters())
+	assert.False(t, PolicyAuthorsCommitters.IncludeCoAuthors())
+	assert.False(t, PolicyAuthorsCoAuthors.IncludeCommitters())
+	assert.True(t, PolicyAuthorsCoAuthors.IncludeCoAuthors())
+	assert.True(t, PolicyAll.IncludeCommitters())
+	assert.True(t, PolicyAll.IncludeCoAuthors())
+}
+
+func TestParseCoAuthors(t *testing.T) {
+	message := "Fix the build\r\n\r\nCo-authored-by: quoted in the body <body@example.org>\r\n\r\n" +
+		"Signed-off-by: Jane Doe <jane@example.org>\r\n" +
+		"Co-authored-by: John Smith <john@example.org>\r\n" +
+		"co-authored-by:Bot <bot@example.org>  \r\n" +
+		"Co-Authored-By: John S. <JOHN@example.org>\r\n" +
+		"Co-authored-by: no email\r\n"
+
+	assert.Equal(t, []Identity{
+		{Name: "John Smith", Email: "john@example.org"},
+		{Name: "Bot", Email: "bot@example.org"},
+	}, ParseCoAuthors(message))
+
+	assert.Empty(t, ParseCoAuthors("Co-authored-by: John Smith <john@example.org>"))
+	assert.Empty(t, ParseCoAuthors(""))
+}
@@ -0,0 +1,60 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package commit_identity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Policy is the commit_identity_policy of a GitHub organization or GitLab group, it selects the commit identities
+// that must be covered by a CLA for a pull/merge request to pass
+type Policy string
+
+// commit identity policies
+const (
+	// PolicyAuthors checks the commit authors only, the behavior when no policy is configured
+	PolicyAuthors Policy = "authors"
+	// PolicyAuthorsCommitters checks the commit authors and committers
+	PolicyAuthorsCommitters Policy = "authors_committers"
+	// PolicyAuthorsCoAuthors checks the commit authors and the Co-authored-by trailers of the commit messages
+	PolicyAuthorsCoAuthors Policy = "authors_co_authors"
+	// PolicyAll checks the commit authors, committers and co-authors
+	PolicyAll Policy = "all"
+)
+
+// Policies lists the valid policies
+var Policies = []Policy{PolicyAuthors, PolicyAuthorsCommitters, PolicyAuthorsCoAuthors, PolicyAll}
+
+// ParsePolicy validates the stored or requested policy value, the empty value is the authors only policy
+func ParsePolicy(value string) (Policy, error) {
+	if strings.TrimSpace(value) == "" {
+		return PolicyAuthors, nil
+	}
+	for _, policy := range Policies {
+		if strings.EqualFold(strings.TrimSpace(value), string(policy)) {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("invalid commit identity policy: %s - expecting one of: %s, %s, %s, %s", value, PolicyAuthors, PolicyAuthorsCommitters, PolicyAuthorsCoAuthors, PolicyAll)
+}
+
+// PolicyOf returns the policy of the stored value, invalid values fall back to the authors only policy
+func PolicyOf(value string) Policy {
+	policy, err := ParsePolicy(value)
+	if err != nil {
+		return PolicyAuthors
+	}
+	return policy
+}
+
+// IncludeCommitters returns true when the committers must be covered
+func (p Policy) IncludeCommitters() bool {
+	return p == PolicyAuthorsCommitters || p == PolicyAll
+}
+
+// IncludeCoAuthors returns true when the co-authors must be covered
+func (p Policy) IncludeCoAuthors() bool {
+	return p == PolicyAuthorsCoAuthors || p == PolicyAll
+}
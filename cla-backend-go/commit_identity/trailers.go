@@ -0,0 +1,44 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package commit_identity
+
+import (
+	"regexp"
+	"strings"
+)
+
+// coAuthorTrailer matches a "Co-authored-by: Name <email>" trailer line, the key is case insensitive as git accepts it
+var coAuthorTrailer = regexp.MustCompile(`(?i)^co-authored-by:\s*(.*?)\s*<([^<>\s]+)>\s*$`)
+
+// Identity is the git name and email of a commit author, committer or co-author
+type Identity struct {
+	Name  string
+	Email string
+}
+
+// ParseCoAuthors returns the co-authors of the commit message trailers, once per email address. Only the last
+// paragraph of the message holds trailers, so a Co-authored-by line quoted in the body doesn't add a co-author.
+func ParseCoAuthors(message string) []Identity {
+	paragraphs := strings.Split(strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n")), "\n\n")
+	if len(paragraphs) < 2 {
+		// a subject line only message has no trailers
+		return nil
+	}
+
+	var coAuthors []Identity
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		match := coAuthorTrailer.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		key := strings.ToLower(match[2])
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		coAuthors = append(coAuthors, Identity{Name: match[1], Email: match[2]})
+	}
+	return coAuthors
+}
@@ -99,6 +99,9 @@ type Config struct {
 
 	// DocuSignPrivateKey is the private key for the DocuSign API
 	DocuSignPrivateKey string `json:"docuSignPrivateKey"`
+
+	// Email has the transport config used to send the emails
+	Email Email `json:"email"`
 }
 
 // Auth0 model
@@ -165,6 +168,25 @@ type MetricsReport struct {
 	Enabled        bool   `json:"metrics_reporting_enabled"`
 }
 
+// Email keeps the config of the email transport and outbox, the SNS email pipeline is used by default
+type Email struct {
+	// Transport is one of sns, smtp or file
+	Transport string `json:"transport"`
+	SMTP      SMTP   `json:"smtp"`
+	// FileDirectory is the maildir directory the file transport writes the emails to
+	FileDirectory string `json:"file_directory"`
+	// OutboxEnabled sends the emails through the outbox table which retries and de-duplicates the sends
+	OutboxEnabled bool `json:"outbox_enabled"`
+}
+
+// SMTP keeps the SMTP server config of the smtp email transport
+type SMTP struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
 // GetConfig returns the current EasyCLA configuration
 func GetConfig() Config {
 	return easyCLAConfig
@@ -192,6 +214,9 @@ func LoadConfig(configFilePath string, awsSession *session.Session, awsStage str
 		return Config{}, err
 	}
 
+	// The email transport can be selected through the environment on the SSM based deployments
+	loadEmailEnvConfig(&easyCLAConfig.Email)
+
 	// Convert the allowed origins into an array of values
 	easyCLAConfig.AllowedOrigins = strings.Split(easyCLAConfig.AllowedOriginsCommaSeparated, ",")
 
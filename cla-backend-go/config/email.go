@@ -0,0 +1,45 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"strconv"
+
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+)
+
+// loadEmailEnvConfig overrides the email config with the EMAIL_* environment variables when they are set
+func loadEmailEnvConfig(email *Email) {
+	if value := os.Getenv("EMAIL_TRANSPORT"); value != "" {
+		email.Transport = value
+	}
+	if value := os.Getenv("EMAIL_FILE_DIRECTORY"); value != "" {
+		email.FileDirectory = value
+	}
+	if value := os.Getenv("EMAIL_OUTBOX_ENABLED"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Warnf("unable to parse EMAIL_OUTBOX_ENABLED value: %s - leaving the outbox disabled", value)
+		}
+		email.OutboxEnabled = enabled
+	}
+	if value := os.Getenv("EMAIL_SMTP_HOST"); value != "" {
+		email.SMTP.Host = value
+	}
+	if value := os.Getenv("EMAIL_SMTP_PORT"); value != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			log.Warnf("unable to parse EMAIL_SMTP_PORT value: %s", value)
+		} else {
+			email.SMTP.Port = port
+		}
+	}
+	if value := os.Getenv("EMAIL_SMTP_USERNAME"); value != "" {
+		email.SMTP.Username = value
+	}
+	if value := os.Getenv("EMAIL_SMTP_PASSWORD"); value != "" {
+		email.SMTP.Password = value
+	}
+}
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -37,6 +38,16 @@ func getSSMString(ssmClient *ssm.SSM, key string) (string, error) {
 	return strings.TrimSpace(*value.Parameter.Value), nil
 }
 
+// CheckSSM reads one of the configuration parameters of the stage, the configuration can't be loaded or reloaded
+// when SSM is not available
+func CheckSSM(ctx context.Context, awsSession *session.Session, stage string) error {
+	_, err := ssm.New(awsSession).GetParameterWithContext(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(fmt.Sprintf("cla-auth0-domain-%s", stage)),
+		WithDecryption: aws.Bool(false),
+	})
+	return err
+}
+
 // loadSSMConfig fetches all the configuration values and populates the response Config model
 func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 	f := logrus.Fields{
@@ -0,0 +1,132 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package domain_matcher
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultCacheSize is the default number of compiled signature approval lists kept by a cache
+const DefaultCacheSize = 1000
+
+// Matcher is a compiled domain approval list
+type Matcher struct {
+	patterns []*Pattern
+}
+
+// Compile compiles the domain approval list entries. Invalid entries are left out of the matcher and reported in the
+// returned error, so callers evaluating existing approval lists can still use the valid entries.
+func Compile(entries []string) (*Matcher, error) {
+	matcher := &Matcher{}
+	var errs []error
+	for _, entry := range entries {
+		pattern, err := ParsePattern(entry)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		matcher.patterns = append(matcher.patterns, pattern)
+	}
+	return matcher, errors.Join(errs...)
+}
+
+// IsEmpty returns true if the matcher has no valid patterns
+func (m *Matcher) IsEmpty() bool {
+	return len(m.patterns) == 0
+}
+
+// MatchDomain returns the first pattern matching the domain, if any
+func (m *Matcher) MatchDomain(domain string) (*Pattern, bool) {
+	normalized, err := NormalizeDomain(domain)
+	if err != nil {
+		return nil, false
+	}
+	for _, pattern := range m.patterns {
+		if pattern.Matches(normalized) {
+			return pattern, true
+		}
+	}
+	return nil, false
+}
+
+// MatchEmail returns the first pattern matching the domain of the email address, if any
+func (m *Matcher) MatchEmail(email string) (*Pattern, bool) {
+	domain, ok := EmailDomain(email)
+	if !ok {
+		return nil, false
+	}
+	return m.MatchDomain(domain)
+}
+
+// EmailDomain returns the domain part of the email address
+func EmailDomain(email string) (string, bool) {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return "", false
+	}
+	return email[at+1:], true
+}
+
+type cacheItem struct {
+	fingerprint string
+	matcher     *Matcher
+	err         error
+}
+
+// Cache keeps the compiled domain approval list of each CCLA signature. Items are keyed by signature ID and
+// recompiled whenever the approval list changes, so callers don't need to invalidate them.
+type Cache struct {
+	lock  sync.RWMutex
+	size  int
+	items map[string]*cacheItem
+}
+
+// NewCache creates a cache holding up to size compiled approval lists
+func NewCache(size int) *Cache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	return &Cache{
+		size:  size,
+		items: make(map[string]*cacheItem, size),
+	}
+}
+
+// Get returns the compiled domain approval list of the signature, compiling it if it isn't cached or has changed.
+// The error reports the invalid entries left out of the matcher, as returned by Compile.
+func (c *Cache) Get(signatureID string, entries []string) (*Matcher, error) {
+	if signatureID == "" {
+		return Compile(entries)
+	}
+
+	key := fingerprint(entries)
+	c.lock.RLock()
+	item, ok := c.items[signatureID]
+	c.lock.RUnlock()
+	if ok && item.fingerprint == key {
+		return item.matcher, item.err
+	}
+
+	matcher, err := Compile(entries)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, exists := c.items[signatureID]; !exists && len(c.items) >= c.size {
+		// evict an arbitrary item - approval lists are cheap to compile, so a simple bound is enough
+		for evicted := range c.items {
+			delete(c.items, evicted)
+			break
+		}
+	}
+	c.items[signatureID] = &cacheItem{fingerprint: key, matcher: matcher, err: err}
+	return matcher, err
+}
+
+// fingerprint identifies the content of an approval list
+func fingerprint(entries []string) string {
+	return fmt.Sprintf("%d:%s", len(entries), strings.Join(entries, "\n"))
+}
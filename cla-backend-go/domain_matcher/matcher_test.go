@@ -0,0 +1,112 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package domain_matcher
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePattern(t *testing.T) {
+	testCases := []struct {
+		entry  string
+		domain string
+		kind   string
+		err    error
+	}{
+		{entry: "Example.COM", domain: "example.com", kind: MatchExact},
+		{entry: " *.example.com ", domain: "example.com", kind: MatchDomainAndSubdomains},
+		{entry: "+.example.com", domain: "example.com", kind: MatchSubdomains},
+		{entry: ".example.com", domain: "example.com", kind: MatchDomainAndSubdomains},
+		{entry: "*example.com", domain: "example.com", kind: MatchDomainAndSubdomains},
+		{entry: "bücher.de", domain: "xn--bcher-kva.de", kind: MatchExact},
+		{entry: "*.xn--bcher-kva.de", domain: "xn--bcher-kva.de", kind: MatchDomainAndSubdomains},
+		{entry: "com", err: ErrPublicSuffix},
+		{entry: "*.co.uk", err: ErrPublicSuffix},
+		{entry: "*.github.io", err: ErrPublicSuffix},
+		{entry: "+.co.uk", err: ErrPublicSuffix},
+		{entry: "*", err: errors.New("empty")},
+		{entry: "mail.*.example.com", err: errors.New("wildcard")},
+		{entry: "+example.com", err: errors.New("invalid")},
+		{entry: "example_org.com", err: errors.New("invalid")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.entry, func(t *testing.T) {
+			pattern, err := ParsePattern(tc.entry)
+			if tc.err != nil {
+				assert.Error(t, err)
+				if errors.Is(tc.err, ErrPublicSuffix) {
+					assert.ErrorIs(t, err, ErrPublicSuffix)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.domain, pattern.Domain)
+			assert.Equal(t, tc.kind, pattern.Kind)
+		})
+	}
+}
+
+func TestMatcherMatchEmail(t *testing.T) {
+	matcher, err := Compile([]string{"example.com", "*.example.org", ".example.net", "+.example.edu", "bücher.de", "*.co.uk"})
+	assert.Error(t, err)
+	assert.False(t, matcher.IsEmpty())
+
+	testCases := []struct {
+		email   string
+		matched bool
+		entry   string
+	}{
+		{email: "user@example.com", matched: true, entry: "example.com"},
+		{email: "user@EXAMPLE.com.", matched: true, entry: "example.com"},
+		{email: "user@exampleXcom", matched: false},
+		{email: "user@badexample.com", matched: false},
+		{email: "user@mail.example.com", matched: false},
+		{email: "user@mail.example.org", matched: true, entry: "*.example.org"},
+		{email: "user@example.org", matched: true, entry: "*.example.org"},
+		{email: "user@badexample.org", matched: false},
+		{email: "user@example.net", matched: true, entry: ".example.net"},
+		{email: "user@a.b.example.net", matched: true, entry: ".example.net"},
+		{email: "user@mail.example.edu", matched: true, entry: "+.example.edu"},
+		{email: "user@example.edu", matched: false},
+		{email: "user@xn--bcher-kva.de", matched: true, entry: "bücher.de"},
+		{email: "user@BÜCHER.de", matched: true, entry: "bücher.de"},
+		{email: "user@company.co.uk", matched: false},
+		{email: "example.com", matched: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.email, func(t *testing.T) {
+			pattern, ok := matcher.MatchEmail(tc.email)
+			assert.Equal(t, tc.matched, ok)
+			if tc.matched {
+				assert.Equal(t, tc.entry, pattern.Entry)
+			}
+		})
+	}
+}
+
+func TestCacheGet(t *testing.T) {
+	cache := NewCache(1)
+
+	first, err := cache.Get("signature-1", []string{"example.com"})
+	assert.NoError(t, err)
+	cached, _ := cache.Get("signature-1", []string{"example.com"})
+	assert.Same(t, first, cached)
+
+	// an updated approval list is compiled again
+	updated, err := cache.Get("signature-1", []string{"example.org"})
+	assert.NoError(t, err)
+	assert.NotSame(t, first, updated)
+	_, ok := updated.MatchEmail("user@example.org")
+	assert.True(t, ok)
+
+	// the cache is bounded
+	_, err = cache.Get("signature-2", []string{"*.co.uk"})
+	assert.ErrorIs(t, err, ErrPublicSuffix)
+	assert.Len(t, cache.items, 1)
+}
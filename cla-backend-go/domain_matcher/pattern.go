@@ -0,0 +1,110 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package domain_matcher
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/net/publicsuffix"
+)
+
+// Match kinds of a domain approval list pattern
+const (
+	// MatchExact matches the domain only, e.g. "example.com"
+	MatchExact = "exact"
+	// MatchSubdomains matches the subdomains of the domain, but not the domain itself, e.g. "+.example.com"
+	MatchSubdomains = "subdomains"
+	// MatchDomainAndSubdomains matches the domain and its subdomains, e.g. "*.example.com", ".example.com" or
+	// "*example.com"
+	MatchDomainAndSubdomains = "domain_and_subdomains"
+)
+
+// ErrPublicSuffix is returned for patterns covering a whole public suffix, e.g. "com" or "*.co.uk", which would
+// approve anyone able to register a domain under it
+var ErrPublicSuffix = errors.New("domain is a public suffix")
+
+// profile converts internationalized domain names to their ASCII (punycode) form, as used for DNS lookups
+var profile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.VerifyDNSLength(true))
+
+// Pattern is a parsed domain approval list entry
+type Pattern struct {
+	// Entry is the approval list entry as stored
+	Entry string
+	// Domain is the normalized ASCII domain of the entry, without the wildcard prefix
+	Domain string
+	Kind   string
+}
+
+// ParsePattern parses a domain approval list entry. The entry is one of:
+//   - "example.com" which matches the domain only
+//   - "*.example.com", ".example.com" or "*example.com" which match example.com and its subdomains, as the
+//     python matcher does
+//   - "+.example.com" which matches the subdomains of example.com, but not example.com itself
+//
+// Internationalized domain names are accepted in both unicode and punycode form. Patterns covering a public suffix
+// return an error wrapping ErrPublicSuffix.
+func ParsePattern(entry string) (*Pattern, error) {
+	value := strings.TrimSpace(entry)
+	kind := MatchExact
+	switch {
+	case strings.HasPrefix(value, "+."):
+		kind, value = MatchSubdomains, value[2:]
+	case strings.HasPrefix(value, "*."):
+		kind, value = MatchDomainAndSubdomains, value[2:]
+	case strings.HasPrefix(value, "."):
+		kind, value = MatchDomainAndSubdomains, value[1:]
+	case strings.HasPrefix(value, "*"):
+		kind, value = MatchDomainAndSubdomains, value[1:]
+	}
+	if strings.Contains(value, "*") {
+		return nil, fmt.Errorf("invalid domain pattern %q: a wildcard is only allowed as the leading label", entry)
+	}
+
+	domain, err := NormalizeDomain(value)
+	if err != nil {
+		return nil, err
+	}
+	if suffix, _ := publicsuffix.PublicSuffix(domain); suffix == domain {
+		return nil, fmt.Errorf("invalid domain pattern %q: %w", entry, ErrPublicSuffix)
+	}
+
+	return &Pattern{
+		Entry:  entry,
+		Domain: domain,
+		Kind:   kind,
+	}, nil
+}
+
+// NormalizeDomain returns the lower case ASCII form of the domain, converting internationalized names to punycode
+func NormalizeDomain(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	if domain == "" {
+		return "", errors.New("domain is empty")
+	}
+	normalized, err := profile.ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+	return normalized, nil
+}
+
+// Matches returns true if the normalized domain matches the pattern
+func (p *Pattern) Matches(domain string) bool {
+	switch p.Kind {
+	case MatchSubdomains:
+		return strings.HasSuffix(domain, "."+p.Domain)
+	case MatchDomainAndSubdomains:
+		return domain == p.Domain || strings.HasSuffix(domain, "."+p.Domain)
+	default:
+		return domain == p.Domain
+	}
+}
+
+// String returns a human-readable version of the pattern
+func (p *Pattern) String() string {
+	return fmt.Sprintf("%s (%s: %s)", p.Entry, p.Kind, p.Domain)
+}
@@ -35,15 +35,15 @@ If you have further questions about this denial, please contact one of the exist
 )
 
 // RenderApprovalListRejectedTemplate renders RequestToAuthorizeTemplate
-func RenderApprovalListRejectedTemplate(svc EmailTemplateService, claGroupVersion string, projectSFID string, params ApprovalListRejectedTemplateParams) (string, error) {
+func RenderApprovalListRejectedTemplate(svc EmailTemplateService, subject, claGroupVersion string, projectSFID string, params ApprovalListRejectedTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(claGroupVersion, projectSFID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	// assign the prefilled struct
 	params.CLAGroupTemplateParams = claGroupParams
-	return RenderTemplate(claGroupVersion, ApprovalListRejectedTemplateName, ApprovalListRejectedTemplate,
+	return renderTemplate(svc, subject, claGroupVersion, ApprovalListRejectedTemplateName, ApprovalListRejectedTemplate,
 		params,
 	)
 
@@ -70,19 +70,19 @@ const (
 )
 
 // RenderApprovalListTemplate renders RenderApprovalListTemplate
-func RenderApprovalListTemplate(svc EmailTemplateService, projectSFIDs []string, params ApprovalListApprovedTemplateParams) (string, error) {
+func RenderApprovalListTemplate(svc EmailTemplateService, subject string, projectSFIDs []string, params ApprovalListApprovedTemplateParams) (string, string, error) {
 	if len(projectSFIDs) == 0 {
-		return "", errors.New("projectSFIDs list is empty")
+		return "", "", errors.New("projectSFIDs list is empty")
 	}
 
 	// prefill the projects data
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(utils.V2, projectSFIDs[0])
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
 
-	return RenderTemplate(utils.V2, ApprovalListApprovedTemplateName, ApprovalListApprovedTemplate, params)
+	return renderTemplate(svc, subject, utils.V2, ApprovalListApprovedTemplateName, ApprovalListApprovedTemplate, params)
 }
 
 // RequestToAuthorizeTemplateParams is email params for RequestToAuthorizeTemplate
@@ -121,13 +121,61 @@ repository. This will permit them to begin contributing to {{.Project.ExternalPr
 )
 
 // RenderRequestToAuthorizeTemplate renders RequestToAuthorizeTemplate
-func RenderRequestToAuthorizeTemplate(svc EmailTemplateService, claGroupVersion string, projectSFID string, params RequestToAuthorizeTemplateParams) (string, error) {
+func RenderRequestToAuthorizeTemplate(svc EmailTemplateService, subject, claGroupVersion string, projectSFID string, params RequestToAuthorizeTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(claGroupVersion, projectSFID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	// assign the prefilled struct
 	params.CLAGroupTemplateParams = claGroupParams
-	return RenderTemplate(claGroupVersion, RequestToAuthorizeTemplateName, RequestToAuthorizeTemplate, params)
+	return renderTemplate(svc, subject, claGroupVersion, RequestToAuthorizeTemplateName, RequestToAuthorizeTemplate, params)
+}
+
+// ApprovalListExpiryEntry is an approval list entry which expires soon
+type ApprovalListExpiryEntry struct {
+	// Criteria is the approval list of the entry, e.g. email or githubUsername
+	Criteria  string
+	Value     string
+	ExpiresAt string
+}
+
+// ApprovalListExpiryReminderTemplateParams is email params for ApprovalListExpiryReminderTemplate
+type ApprovalListExpiryReminderTemplateParams struct {
+	CommonEmailParams
+	CLAGroupTemplateParams
+	CompanyID string
+	Entries   []ApprovalListExpiryEntry
+}
+
+const (
+	// ApprovalListExpiryReminderTemplateName is email template name for ApprovalListExpiryReminderTemplate
+	ApprovalListExpiryReminderTemplateName = "ApprovalListExpiryReminderTemplate"
+	// ApprovalListExpiryReminderTemplate is email template for the reminder of the approval list entries which expire soon
+	ApprovalListExpiryReminderTemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the CLA Group {{.CLAGroupName}}.</p>
+<p>The following entries of the {{.CompanyName}} approval list for {{.CLAGroupName}} are about to expire. Once an entry
+expires it is removed from the approval list and the contributors it covers are no longer authorized to contribute on
+behalf of {{.CompanyName}}.</p>
+<ul>
+	{{range .Entries}}
+		<li>{{.Criteria}}: {{.Value}} expires at {{.ExpiresAt}}</li>
+	{{end}}
+</ul>
+<p>To keep an entry, please <a href="{{.CorporateConsole}}#/company/{{.CompanyID}}" target="_blank">log into the EasyCLA
+Corporate Console</a> and set a new expiry date for it in the 'Manage Approved List' section.</p>
+`
+)
+
+// RenderApprovalListExpiryReminderTemplate renders ApprovalListExpiryReminderTemplate
+func RenderApprovalListExpiryReminderTemplate(svc EmailTemplateService, subject, claGroupID string, params ApprovalListExpiryReminderTemplateParams) (string, string, error) {
+	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromCLAGroup(claGroupID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// assign the prefilled struct
+	params.CLAGroupTemplateParams = claGroupParams
+	return renderTemplate(svc, subject, utils.V2, ApprovalListExpiryReminderTemplateName, ApprovalListExpiryReminderTemplate, params)
 }
@@ -94,3 +94,29 @@ func TestRequestToAuthorizeTemplate(t *testing.T) {
 	assert.Contains(t, result, "<br/><p>OptionalMessageValue</p><br/>")
 
 }
+
+func TestApprovalListExpiryReminderTemplate(t *testing.T) {
+	params := ApprovalListExpiryReminderTemplateParams{
+		CommonEmailParams: CommonEmailParams{
+			RecipientName: "ClaManager",
+			CompanyName:   "CompanyFoo",
+		},
+		CLAGroupTemplateParams: CLAGroupTemplateParams{
+			CLAGroupName:     "CLAGroupFoo",
+			CorporateConsole: "http://CorporateConsole.com",
+		},
+		CompanyID: "company-1",
+		Entries: []ApprovalListExpiryEntry{
+			{Criteria: "email", Value: "contractor@example.org", ExpiresAt: "2021-09-01T00:00:00Z"},
+			{Criteria: "githubUsername", Value: "contractor", ExpiresAt: "2021-09-02T00:00:00Z"},
+		},
+	}
+
+	result, err := RenderTemplate(utils.V2, ApprovalListExpiryReminderTemplateName, ApprovalListExpiryReminderTemplate, params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "Hello ClaManager")
+	assert.Contains(t, result, "The following entries of the CompanyFoo approval list for CLAGroupFoo are about to expire.")
+	assert.Contains(t, result, "<li>email: contractor@example.org expires at 2021-09-01T00:00:00Z</li>")
+	assert.Contains(t, result, "<li>githubUsername: contractor expires at 2021-09-02T00:00:00Z</li>")
+	assert.Contains(t, result, `<a href="http://CorporateConsole.com#/company/company-1" target="_blank">`)
+}
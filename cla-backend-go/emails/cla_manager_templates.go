@@ -29,8 +29,8 @@ const (
 )
 
 // RenderRemovedCLAManagerTemplate renders the RemovedCLAManagerTemplate
-func RenderRemovedCLAManagerTemplate(svc EmailTemplateService, claGroupModelVersion string, params RemovedCLAManagerTemplateParams) (string, error) {
-	return RenderTemplate(claGroupModelVersion, RemovedCLAManagerTemplateName, RemovedCLAManagerTemplate, params)
+func RenderRemovedCLAManagerTemplate(svc EmailTemplateService, subject, claGroupModelVersion string, params RemovedCLAManagerTemplateParams) (string, string, error) {
+	return renderTemplate(svc, subject, claGroupModelVersion, RemovedCLAManagerTemplateName, RemovedCLAManagerTemplate, params)
 }
 
 // RequestAccessToCLAManagersTemplateParams is email params for RequestAccessToCLAManagersTemplate
@@ -60,14 +60,14 @@ additional CLA Manager.</p>
 )
 
 // RenderRequestAccessToCLAManagersTemplate renders the RemovedCLAManagerTemplate
-func RenderRequestAccessToCLAManagersTemplate(svc EmailTemplateService, claGroupModelVersion, projectSFID string, params RequestAccessToCLAManagersTemplateParams) (string, error) {
+func RenderRequestAccessToCLAManagersTemplate(svc EmailTemplateService, subject, claGroupModelVersion, projectSFID string, params RequestAccessToCLAManagersTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(claGroupModelVersion, projectSFID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
 
-	return RenderTemplate(claGroupModelVersion, RequestAccessToCLAManagersTemplateName, RequestAccessToCLAManagersTemplate, params)
+	return renderTemplate(svc, subject, claGroupModelVersion, RequestAccessToCLAManagersTemplateName, RequestAccessToCLAManagersTemplate, params)
 }
 
 // RequestApprovedToCLAManagersTemplateParams is email params for RequestApprovedToCLAManagersTemplate
@@ -95,14 +95,14 @@ list of company’s CLA Managers for {{.Project.ExternalProjectName}}.</p>
 )
 
 // RenderRequestApprovedToCLAManagersTemplate renders the RemovedCLAManagerTemplate
-func RenderRequestApprovedToCLAManagersTemplate(svc EmailTemplateService, claGroupModelVersion, projectSFID string, params RequestApprovedToCLAManagersTemplateParams) (string, error) {
+func RenderRequestApprovedToCLAManagersTemplate(svc EmailTemplateService, subject, claGroupModelVersion, projectSFID string, params RequestApprovedToCLAManagersTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(claGroupModelVersion, projectSFID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
 
-	return RenderTemplate(claGroupModelVersion, RequestApprovedToCLAManagersTemplateName, RequestApprovedToCLAManagersTemplate, params)
+	return renderTemplate(svc, subject, claGroupModelVersion, RequestApprovedToCLAManagersTemplateName, RequestApprovedToCLAManagersTemplate, params)
 }
 
 // RequestApprovedToRequesterTemplateParams email template params for RequestApprovedToRequesterTemplate
@@ -127,14 +127,14 @@ company and then the project {{.Project.ExternalProjectName}}. From here you wil
 )
 
 // RenderRequestApprovedToRequesterTemplate renders the RemovedCLAManagerTemplate
-func RenderRequestApprovedToRequesterTemplate(svc EmailTemplateService, claGroupModelVersion, projectSFID string, params RequestApprovedToRequesterTemplateParams) (string, error) {
+func RenderRequestApprovedToRequesterTemplate(svc EmailTemplateService, subject, claGroupModelVersion, projectSFID string, params RequestApprovedToRequesterTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(claGroupModelVersion, projectSFID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
 
-	return RenderTemplate(claGroupModelVersion, RequestApprovedToRequesterTemplateName, RequestApprovedToRequesterTemplate, params)
+	return renderTemplate(svc, subject, claGroupModelVersion, RequestApprovedToRequesterTemplateName, RequestApprovedToRequesterTemplate, params)
 }
 
 // RequestDeniedToCLAManagersTemplateParams is email params for RequestDeniedToCLAManagersTemplate
@@ -161,14 +161,14 @@ be able to maintain the list of employees allowed to contribute to {{.Project.Ex
 )
 
 // RenderRequestDeniedToCLAManagersTemplate renders the RemovedCLAManagerTemplate
-func RenderRequestDeniedToCLAManagersTemplate(svc EmailTemplateService, claGroupModelVersion, projectSFID string, params RequestDeniedToCLAManagersTemplateParams) (string, error) {
+func RenderRequestDeniedToCLAManagersTemplate(svc EmailTemplateService, subject, claGroupModelVersion, projectSFID string, params RequestDeniedToCLAManagersTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(claGroupModelVersion, projectSFID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
 
-	return RenderTemplate(claGroupModelVersion, RequestDeniedToCLAManagersTemplateName, RequestDeniedToCLAManagersTemplate, params)
+	return renderTemplate(svc, subject, claGroupModelVersion, RequestDeniedToCLAManagersTemplateName, RequestDeniedToCLAManagersTemplate, params)
 }
 
 // RequestDeniedToRequesterTemplateParams is email params for RequestDeniedToRequesterTemplate
@@ -190,14 +190,14 @@ list of employees allowed to contribute to {{.Project.ExternalProjectName}} on b
 )
 
 // RenderRequestDeniedToRequesterTemplate renders the RemovedCLAManagerTemplate
-func RenderRequestDeniedToRequesterTemplate(svc EmailTemplateService, claGroupModelVersion, projectSFID string, params RequestDeniedToRequesterTemplateParams) (string, error) {
+func RenderRequestDeniedToRequesterTemplate(svc EmailTemplateService, subject, claGroupModelVersion, projectSFID string, params RequestDeniedToRequesterTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(claGroupModelVersion, projectSFID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
 
-	return RenderTemplate(claGroupModelVersion, RequestDeniedToRequesterTemplateName, RequestDeniedToRequesterTemplate, params)
+	return renderTemplate(svc, subject, claGroupModelVersion, RequestDeniedToRequesterTemplateName, RequestDeniedToRequesterTemplate, params)
 }
 
 // ClaManagerAddedEToUserTemplateParams is email params
@@ -222,14 +222,14 @@ company and then the project {{.CLAGroupName}}. From here you will be able to ed
 )
 
 // RenderClaManagerAddedEToUserTemplate renders the RemovedCLAManagerTemplate
-func RenderClaManagerAddedEToUserTemplate(svc EmailTemplateService, claGroupModelVersion, projectSFID string, params ClaManagerAddedEToUserTemplateParams) (string, error) {
+func RenderClaManagerAddedEToUserTemplate(svc EmailTemplateService, subject, claGroupModelVersion, projectSFID string, params ClaManagerAddedEToUserTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(claGroupModelVersion, projectSFID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
 
-	return RenderTemplate(claGroupModelVersion, ClaManagerAddedEToUserTemplateName, ClaManagerAddedEToUserTemplate, params)
+	return renderTemplate(svc, subject, claGroupModelVersion, ClaManagerAddedEToUserTemplateName, ClaManagerAddedEToUserTemplate, params)
 }
 
 // ClaManagerAddedToCLAManagersTemplateParams is email params for ClaManagerAddedToCLAManagersTemplate
@@ -258,17 +258,18 @@ list of company’s CLA Managers for CLA Group {{.CLAGroupName}}.</p>
 )
 
 // RenderClaManagerAddedToCLAManagersTemplate renders the ClaManagerAddedToCLAManagersTemplate
-func RenderClaManagerAddedToCLAManagersTemplate(svc EmailTemplateService, claGroupModelVersion, claGroupName string, params ClaManagerAddedToCLAManagersTemplateParams) (string, error) {
+func RenderClaManagerAddedToCLAManagersTemplate(svc EmailTemplateService, subject, claGroupModelVersion, claGroupID, claGroupName string, params ClaManagerAddedToCLAManagersTemplateParams) (string, string, error) {
 	// claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(claGroupModelVersion, projectSFID)
 	// if err != nil {
 	// 	return "", err
 	// }
 	// params.CLAGroupTemplateParams = claGroupParams
 	params.CLAGroupTemplateParams = CLAGroupTemplateParams{
+		CLAGroupID:   claGroupID,
 		CLAGroupName: claGroupName,
 	}
 
-	return RenderTemplate(claGroupModelVersion, ClaManagerAddedToCLAManagersTemplateName, ClaManagerAddedToCLAManagersTemplate, params)
+	return renderTemplate(svc, subject, claGroupModelVersion, ClaManagerAddedToCLAManagersTemplateName, ClaManagerAddedToCLAManagersTemplate, params)
 }
 
 // ClaManagerDeletedToCLAManagersTemplateParams is template params for ClaManagerDeletedToCLAManagersTemplate
@@ -291,11 +292,11 @@ const (
 )
 
 // RenderClaManagerDeletedToCLAManagersTemplate renders the RemovedCLAManagerTemplate
-func RenderClaManagerDeletedToCLAManagersTemplate(svc EmailTemplateService, claGroupModelVersion, claGroupName string) (string, error) {
-
-	params := CLAGroupTemplateParams{
+func RenderClaManagerDeletedToCLAManagersTemplate(svc EmailTemplateService, subject, claGroupModelVersion, claGroupID, claGroupName string, params ClaManagerDeletedToCLAManagersTemplateParams) (string, string, error) {
+	params.CLAGroupTemplateParams = CLAGroupTemplateParams{
+		CLAGroupID:   claGroupID,
 		CLAGroupName: claGroupName,
 	}
 
-	return RenderTemplate(claGroupModelVersion, ClaManagerDeletedToCLAManagersTemplateName, ClaManagerDeletedToCLAManagersTemplate, params)
+	return renderTemplate(svc, subject, claGroupModelVersion, ClaManagerDeletedToCLAManagersTemplateName, ClaManagerDeletedToCLAManagersTemplate, params)
 }
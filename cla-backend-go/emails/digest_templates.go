@@ -0,0 +1,72 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+import (
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+)
+
+// CLAManagerDigestSection is the digest of the notifications of one company and CLA group
+type CLAManagerDigestSection struct {
+	CompanyName       string
+	CLAGroupName      string
+	ApprovalRequests  []string
+	Signatures        []string
+	CLAManagerChanges []string
+}
+
+// CLAManagerDigestTemplateParams is email params for CLAManagerDigestTemplate
+type CLAManagerDigestTemplateParams struct {
+	CommonEmailParams
+	// Frequency is daily or weekly
+	Frequency     string
+	Sections      []CLAManagerDigestSection
+	CorporateURL  string
+	Notifications int
+}
+
+const (
+	// CLAManagerDigestTemplateName is email template name for CLAManagerDigestTemplate
+	CLAManagerDigestTemplateName = "CLAManagerDigestTemplate"
+	// CLAManagerDigestTemplate is email template for the daily or weekly summary of the CLA manager notifications
+	CLAManagerDigestTemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is your {{.Frequency}} EasyCLA digest with {{.Notifications}} notifications for the companies you manage.</p>
+{{range .Sections}}
+<h3>{{.CompanyName}} - {{.CLAGroupName}}</h3>
+{{if .ApprovalRequests}}
+<p>Pending approval list requests:</p>
+<ul>
+	{{range .ApprovalRequests}}
+		<li>{{.}}</li>
+	{{end}}
+</ul>
+{{end}}
+{{if .Signatures}}
+<p>New signatures:</p>
+<ul>
+	{{range .Signatures}}
+		<li>{{.}}</li>
+	{{end}}
+</ul>
+{{end}}
+{{if .CLAManagerChanges}}
+<p>CLA Manager changes:</p>
+<ul>
+	{{range .CLAManagerChanges}}
+		<li>{{.}}</li>
+	{{end}}
+</ul>
+{{end}}
+{{end}}
+<p>To review the requests, please log into the <a href="{{.CorporateURL}}" target="_blank">EasyCLA Corporate Console</a>.
+You can change how you receive these notifications in your EasyCLA user profile.</p>
+`
+)
+
+// RenderCLAManagerDigestTemplate renders CLAManagerDigestTemplate, the digest covers several CLA groups so only the
+// default template is used
+func RenderCLAManagerDigestTemplate(svc EmailTemplateService, subject string, params CLAManagerDigestTemplateParams) (string, string, error) {
+	return renderTemplate(svc, subject, utils.V2, CLAManagerDigestTemplateName, CLAManagerDigestTemplate, params)
+}
@@ -29,10 +29,10 @@ const (
 )
 
 // RenderDocumentSignedTemplate renders RenderDocumentSignedTemplate
-func RenderDocumentSignedTemplate(svc EmailTemplateService, claGroupModelVersion, projectSFID string, params DocumentSignedTemplateParams) (string, error) {
+func RenderDocumentSignedTemplate(svc EmailTemplateService, subject, claGroupModelVersion, projectSFID string, params DocumentSignedTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(claGroupModelVersion, projectSFID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	params.CLAGroupTemplateParams = claGroupParams
@@ -43,5 +43,5 @@ func RenderDocumentSignedTemplate(svc EmailTemplateService, claGroupModelVersion
 		template = DocumentSignedCCLATemplate
 	}
 
-	return RenderTemplate(claGroupModelVersion, DocumentSignedTemplateName, template, params)
+	return renderTemplate(svc, subject, claGroupModelVersion, DocumentSignedTemplateName, template, params)
 }
@@ -28,15 +28,15 @@ const (
 )
 
 // RenderGithubRepositoryDisabledTemplate renders GithubRepositoryDisabledTemplate
-func RenderGithubRepositoryDisabledTemplate(svc EmailTemplateService, claGroupID string, params GithubRepositoryDisabledTemplateParams) (string, error) {
+func RenderGithubRepositoryDisabledTemplate(svc EmailTemplateService, subject, claGroupID string, params GithubRepositoryDisabledTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromCLAGroup(claGroupID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	// assign the prefilled struct
 	params.CLAGroupTemplateParams = claGroupParams
-	return RenderTemplate(params.CLAGroupTemplateParams.Version, GithubRepositoryDisabledTemplateName, GithubRepositoryDisabledTemplate, params)
+	return renderTemplate(svc, subject, params.CLAGroupTemplateParams.Version, GithubRepositoryDisabledTemplateName, GithubRepositoryDisabledTemplate, params)
 }
 
 // GithubRepositoryArchivedTemplateParams renders GithubRepositoryArchivedTemplate
@@ -56,15 +56,15 @@ const (
 )
 
 // RenderGithubRepositoryArchivedTemplate renders GithubRepositoryArchivedTemplate
-func RenderGithubRepositoryArchivedTemplate(svc EmailTemplateService, claGroupID string, params GithubRepositoryArchivedTemplateParams) (string, error) {
+func RenderGithubRepositoryArchivedTemplate(svc EmailTemplateService, subject, claGroupID string, params GithubRepositoryArchivedTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromCLAGroup(claGroupID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	// assign the prefilled struct
 	params.CLAGroupTemplateParams = claGroupParams
-	return RenderTemplate(params.CLAGroupTemplateParams.Version, GithubRepositoryArchivedTemplateName, GithubRepositoryArchivedTemplate, params)
+	return renderTemplate(svc, subject, params.CLAGroupTemplateParams.Version, GithubRepositoryArchivedTemplateName, GithubRepositoryArchivedTemplate, params)
 }
 
 // GithubRepositoryRenamedTemplateParams is email params for GithubRepositoryRenamedTemplate
@@ -86,15 +86,15 @@ const (
 )
 
 // RenderGithubRepositoryRenamedTemplate renders GithubRepositoryRenamedTemplate
-func RenderGithubRepositoryRenamedTemplate(svc EmailTemplateService, claGroupID string, params GithubRepositoryRenamedTemplateParams) (string, error) {
+func RenderGithubRepositoryRenamedTemplate(svc EmailTemplateService, subject, claGroupID string, params GithubRepositoryRenamedTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromCLAGroup(claGroupID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	// assign the prefilled struct
 	params.CLAGroupTemplateParams = claGroupParams
-	return RenderTemplate(params.CLAGroupTemplateParams.Version, GithubRepositoryRenamedTemplateName, GithubRepositoryRenamedTemplate, params)
+	return renderTemplate(svc, subject, params.CLAGroupTemplateParams.Version, GithubRepositoryRenamedTemplateName, GithubRepositoryRenamedTemplate, params)
 }
 
 // GithubRepositoryTransferredTemplateParams is email params GithubRepositoryTransferredTemplate
@@ -128,17 +128,17 @@ const (
 )
 
 // RenderGithubRepositoryTransferredTemplate renders GithubRepositoryTransferredFailedTemplate or GithubRepositoryTransferredTemplate
-func RenderGithubRepositoryTransferredTemplate(svc EmailTemplateService, claGroupID string, params GithubRepositoryTransferredTemplateParams, success bool) (string, error) {
+func RenderGithubRepositoryTransferredTemplate(svc EmailTemplateService, subject, claGroupID string, params GithubRepositoryTransferredTemplateParams, success bool) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromCLAGroup(claGroupID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	// assign the prefilled struct
 	params.CLAGroupTemplateParams = claGroupParams
 	if success {
-		return RenderTemplate(params.CLAGroupTemplateParams.Version, GithubRepositoryTransferredTemplateName, GithubRepositoryTransferredTemplate, params)
+		return renderTemplate(svc, subject, params.CLAGroupTemplateParams.Version, GithubRepositoryTransferredTemplateName, GithubRepositoryTransferredTemplate, params)
 	}
-	return RenderTemplate(params.CLAGroupTemplateParams.Version, GithubRepositoryTransferredFailedTemplateName, GithubRepositoryTransferredFailedTemplate, params)
+	return renderTemplate(svc, subject, params.CLAGroupTemplateParams.Version, GithubRepositoryTransferredFailedTemplateName, GithubRepositoryTransferredFailedTemplate, params)
 
 }
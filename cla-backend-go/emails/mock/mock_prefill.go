@@ -0,0 +1,99 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+//
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: emails/prefill.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	emails "github.com/linuxfoundation/easycla/cla-backend-go/emails"
+)
+
+// MockEmailTemplateService is a mock of EmailTemplateService interface.
+type MockEmailTemplateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailTemplateServiceMockRecorder
+}
+
+// MockEmailTemplateServiceMockRecorder is the mock recorder for MockEmailTemplateService.
+type MockEmailTemplateServiceMockRecorder struct {
+	mock *MockEmailTemplateService
+}
+
+// NewMockEmailTemplateService creates a new mock instance.
+func NewMockEmailTemplateService(ctrl *gomock.Controller) *MockEmailTemplateService {
+	mock := &MockEmailTemplateService{ctrl: ctrl}
+	mock.recorder = &MockEmailTemplateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailTemplateService) EXPECT() *MockEmailTemplateServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCLAGroupTemplateParamsFromCLAGroup mocks base method.
+func (m *MockEmailTemplateService) GetCLAGroupTemplateParamsFromCLAGroup(claGroupID string) (emails.CLAGroupTemplateParams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCLAGroupTemplateParamsFromCLAGroup", claGroupID)
+	ret0, _ := ret[0].(emails.CLAGroupTemplateParams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCLAGroupTemplateParamsFromCLAGroup indicates an expected call of GetCLAGroupTemplateParamsFromCLAGroup.
+func (mr *MockEmailTemplateServiceMockRecorder) GetCLAGroupTemplateParamsFromCLAGroup(claGroupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCLAGroupTemplateParamsFromCLAGroup", reflect.TypeOf((*MockEmailTemplateService)(nil).GetCLAGroupTemplateParamsFromCLAGroup), claGroupID)
+}
+
+// GetCLAGroupTemplateParamsFromProjectSFID mocks base method.
+func (m *MockEmailTemplateService) GetCLAGroupTemplateParamsFromProjectSFID(claGroupVersion, projectSFID string) (emails.CLAGroupTemplateParams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCLAGroupTemplateParamsFromProjectSFID", claGroupVersion, projectSFID)
+	ret0, _ := ret[0].(emails.CLAGroupTemplateParams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCLAGroupTemplateParamsFromProjectSFID indicates an expected call of GetCLAGroupTemplateParamsFromProjectSFID.
+func (mr *MockEmailTemplateServiceMockRecorder) GetCLAGroupTemplateParamsFromProjectSFID(claGroupVersion, projectSFID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCLAGroupTemplateParamsFromProjectSFID", reflect.TypeOf((*MockEmailTemplateService)(nil).GetCLAGroupTemplateParamsFromProjectSFID), claGroupVersion, projectSFID)
+}
+
+// GetTemplateOverride mocks base method.
+func (m *MockEmailTemplateService) GetTemplateOverride(scope emails.TemplateScope, templateName, recipientAddress, recipientLocale string) (*emails.TemplateOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateOverride", scope, templateName, recipientAddress, recipientLocale)
+	ret0, _ := ret[0].(*emails.TemplateOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateOverride indicates an expected call of GetTemplateOverride.
+func (mr *MockEmailTemplateServiceMockRecorder) GetTemplateOverride(scope, templateName, recipientAddress, recipientLocale interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateOverride", reflect.TypeOf((*MockEmailTemplateService)(nil).GetTemplateOverride), scope, templateName, recipientAddress, recipientLocale)
+}
+
+// PrefillV2CLAProjectParams mocks base method.
+func (m *MockEmailTemplateService) PrefillV2CLAProjectParams(projectSFIDs []string) ([]emails.CLAProjectParams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrefillV2CLAProjectParams", projectSFIDs)
+	ret0, _ := ret[0].([]emails.CLAProjectParams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrefillV2CLAProjectParams indicates an expected call of PrefillV2CLAProjectParams.
+func (mr *MockEmailTemplateServiceMockRecorder) PrefillV2CLAProjectParams(projectSFIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrefillV2CLAProjectParams", reflect.TypeOf((*MockEmailTemplateService)(nil).PrefillV2CLAProjectParams), projectSFIDs)
+}
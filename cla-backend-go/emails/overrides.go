@@ -0,0 +1,200 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	textTemplate "text/template"
+
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrUnknownTemplate is returned when the template name is not one of the email templates
+var ErrUnknownTemplate = errors.New("unknown email template")
+
+// TemplateOverrideError is returned when the subject or body of the template override doesn't render
+type TemplateOverrideError struct {
+	TemplateName string
+	Field        string
+	Err          error
+}
+
+// Error is an error string function for TemplateOverrideError
+func (e *TemplateOverrideError) Error() string {
+	return fmt.Sprintf("the %s of the %s template override doesn't render: %v", e.Field, e.TemplateName, e.Err)
+}
+
+// Unwrap method returns its contained error
+func (e *TemplateOverrideError) Unwrap() error {
+	return e.Err
+}
+
+// TemplateScope is the CLA group and foundation of an email, used to look up the template overrides
+type TemplateScope struct {
+	CLAGroupID     string
+	FoundationSFID string
+}
+
+// scopedTemplateParams is implemented by the params embedding CLAGroupTemplateParams
+type scopedTemplateParams interface {
+	emailTemplateScope() TemplateScope
+}
+
+// recipientTemplateParams is implemented by the params embedding CommonEmailParams
+type recipientTemplateParams interface {
+	emailRecipient() (string, string)
+}
+
+// NormalizeLocale returns the lower case language tag of the locale, e.g. pt_BR becomes pt-br, the empty locale
+// is the default locale
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(locale, "_", "-")))
+	if locale == "" {
+		return DefaultLocale
+	}
+	return locale
+}
+
+// localeCandidates returns the override locales to try for the recipient's language, from the most specific one
+// to the default locale, e.g. pt-br, pt and default
+func localeCandidates(locale string) []string {
+	locale = NormalizeLocale(locale)
+	if locale == DefaultLocale {
+		return []string{DefaultLocale}
+	}
+
+	candidates := []string{locale}
+	if i := strings.Index(locale, "-"); i > 0 {
+		candidates = append(candidates, locale[:i])
+	}
+	return append(candidates, DefaultLocale)
+}
+
+// selectTemplateOverride returns the override matching the recipient's language, the closest locale wins and for
+// the same locale the CLA group override takes precedence over the foundation override
+func selectTemplateOverride(overrides []*TemplateOverride, locale string) *TemplateOverride {
+	for _, candidate := range localeCandidates(locale) {
+		for _, scope := range []string{TemplateScopeCLAGroup, TemplateScopeFoundation} {
+			for _, override := range overrides {
+				if override.Scope == scope && NormalizeLocale(override.Locale) == candidate {
+					return override
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// renderTemplate renders the subject and the body of the template, the template override of the CLA group or
+// foundation is used when one exists for the recipient - a failing override falls back to the default template
+func renderTemplate(svc EmailTemplateService, subject, claGroupVersion, templateName, templateStr string, params interface{}) (string, string, error) {
+	f := logrus.Fields{
+		"functionName": "emails.overrides.renderTemplate",
+		"templateName": templateName,
+	}
+
+	var scope TemplateScope
+	if scoped, ok := params.(scopedTemplateParams); ok {
+		scope = scoped.emailTemplateScope()
+	}
+	var recipientAddress, recipientLocale string
+	if recipient, ok := params.(recipientTemplateParams); ok {
+		recipientAddress, recipientLocale = recipient.emailRecipient()
+	}
+
+	var override *TemplateOverride
+	if svc != nil && (scope.CLAGroupID != "" || scope.FoundationSFID != "") {
+		var err error
+		override, err = svc.GetTemplateOverride(scope, templateName, recipientAddress, recipientLocale)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load the template override - using the default template")
+		}
+	}
+
+	if override != nil {
+		f["scope"] = override.Scope
+		f["scopeID"] = override.ScopeID
+		f["locale"] = override.Locale
+		if override.Subject != "" {
+			overrideSubject, err := renderSubject(templateName, override.Subject, params)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("unable to render the template override subject - using the default subject")
+			} else {
+				subject = overrideSubject
+			}
+		}
+		if override.Body != "" {
+			body, err := RenderTemplate(claGroupVersion, templateName, override.Body, params)
+			if err == nil {
+				return subject, body, nil
+			}
+			log.WithFields(f).WithError(err).Warn("unable to render the template override body - using the default body")
+		}
+	}
+
+	body, err := RenderTemplate(claGroupVersion, templateName, templateStr, params)
+	if err != nil {
+		return "", "", err
+	}
+	return subject, body, nil
+}
+
+// renderSubject renders the subject template, the subject is plain text so it's not HTML escaped
+func renderSubject(templateName, subjectTemplate string, params interface{}) (string, error) {
+	t, err := textTemplate.New(templateName + "Subject").Parse(subjectTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var subject bytes.Buffer
+	if err := t.Execute(&subject, params); err != nil {
+		return "", err
+	}
+	// the subject is a single line
+	return strings.Join(strings.Fields(subject.String()), " "), nil
+}
+
+// TemplatePreview is the template override rendered with the sample params
+type TemplatePreview struct {
+	Subject string
+	Body    string
+}
+
+// ValidateTemplateOverride renders the subject and body of the template override with the sample params of the
+// named template, it returns an error when the template is unknown or the override doesn't render
+func ValidateTemplateOverride(templateName, subject, body string) (*TemplatePreview, error) {
+	definition, ok := templateDefinitions[templateName]
+	if !ok {
+		return nil, ErrUnknownTemplate
+	}
+	params := definition.sampleParams()
+
+	preview := &TemplatePreview{}
+	if subject != "" {
+		renderedSubject, err := renderSubject(templateName, subject, params)
+		if err != nil {
+			return nil, &TemplateOverrideError{TemplateName: templateName, Field: "subject", Err: err}
+		}
+		preview.Subject = renderedSubject
+	}
+
+	if body != "" {
+		t, err := template.New(templateName).Parse(body)
+		if err != nil {
+			return nil, &TemplateOverrideError{TemplateName: templateName, Field: "body", Err: err}
+		}
+		var renderedBody bytes.Buffer
+		if err := t.Execute(&renderedBody, params); err != nil {
+			return nil, &TemplateOverrideError{TemplateName: templateName, Field: "body", Err: err}
+		}
+		preview.Body = renderedBody.String()
+	}
+
+	return preview, nil
+}
@@ -0,0 +1,62 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	mock_emails "github.com/linuxfoundation/easycla/cla-backend-go/emails/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTemplateOverride(t *testing.T) {
+	params := emails.RemovedCLAManagerTemplateParams{
+		CommonEmailParams: emails.CommonEmailParams{
+			RecipientName:    "JohnsClaManager",
+			RecipientAddress: "john@example.com",
+			RecipientLocale:  "pt-BR",
+			CompanyName:      "JohnsCompany",
+		},
+		CLAGroupTemplateParams: emails.CLAGroupTemplateParams{
+			CLAGroupID:     "claGroupID",
+			FoundationSFID: "foundationSFID",
+			CLAGroupName:   "JohnsCLAGroupName",
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	override := &emails.TemplateOverride{
+		Scope:   emails.TemplateScopeCLAGroup,
+		Locale:  "pt",
+		Subject: "EasyCLA: Removido de {{.CLAGroupName}}",
+		Body:    "<p>Olá {{.RecipientName}},</p>",
+	}
+	svc := mock_emails.NewMockEmailTemplateService(ctrl)
+	svc.EXPECT().
+		GetTemplateOverride(emails.TemplateScope{CLAGroupID: "claGroupID", FoundationSFID: "foundationSFID"}, emails.RemovedCLAManagerTemplateName, "john@example.com", "pt-BR").
+		Return(override, nil).
+		Times(2)
+
+	subject, body, err := emails.RenderRemovedCLAManagerTemplate(svc, "EasyCLA: Removed", "v1", params)
+	assert.NoError(t, err)
+	assert.Equal(t, "EasyCLA: Removido de JohnsCLAGroupName", subject)
+	assert.Contains(t, body, "<p>Olá JohnsClaManager,</p>")
+
+	// a broken override falls back to the default template
+	override.Subject = "{{.NoSuchField}}"
+	override.Body = "{{.NoSuchField}}"
+	subject, body, err = emails.RenderRemovedCLAManagerTemplate(svc, "EasyCLA: Removed", "v1", params)
+	assert.NoError(t, err)
+	assert.Equal(t, "EasyCLA: Removed", subject)
+	assert.Contains(t, body, "You have been removed as a CLA Manager from JohnsCompany")
+
+	// no override uses the default template
+	subject, body, err = emails.RenderRemovedCLAManagerTemplate(nil, "EasyCLA: Removed", "v1", params)
+	assert.NoError(t, err)
+	assert.Equal(t, "EasyCLA: Removed", subject)
+	assert.Contains(t, body, "<p>Hello JohnsClaManager,</p>")
+}
@@ -0,0 +1,204 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// template override scopes - a CLA group override takes precedence over the foundation override
+const (
+	TemplateScopeCLAGroup   = "cla_group"
+	TemplateScopeFoundation = "foundation"
+)
+
+// DefaultLocale is the locale of the override used when no override matches the recipient's language
+const DefaultLocale = "default"
+
+// ErrTemplateOverrideNotFound is returned when the template override does not exist
+var ErrTemplateOverrideNotFound = errors.New("email template override not found")
+
+// TemplateOverride replaces the subject and body of the named email template for a CLA group or a foundation, the
+// scope key is {scope}#{scopeID} and the template key is {templateName}#{locale}
+type TemplateOverride struct {
+	ScopeKey     string `dynamodbav:"scope_key"`
+	TemplateKey  string `dynamodbav:"template_key"`
+	Scope        string `dynamodbav:"scope"`
+	ScopeID      string `dynamodbav:"scope_id"`
+	TemplateName string `dynamodbav:"template_name"`
+	Locale       string `dynamodbav:"locale"`
+	// Subject is a text template, the default subject is used when empty
+	Subject string `dynamodbav:"subject"`
+	// Body is an HTML template, the default body is used when empty
+	Body         string `dynamodbav:"body"`
+	CreatedBy    string `dynamodbav:"created_by"`
+	DateCreated  string `dynamodbav:"date_created"`
+	DateModified string `dynamodbav:"date_modified"`
+}
+
+// templateOverrideScopeKey returns the partition key of the overrides of the scope
+func templateOverrideScopeKey(scope, scopeID string) string {
+	return fmt.Sprintf("%s#%s", scope, scopeID)
+}
+
+// templateOverrideTemplateKey returns the sort key of the template override locale
+func templateOverrideTemplateKey(templateName, locale string) string {
+	return fmt.Sprintf("%s#%s", templateName, NormalizeLocale(locale))
+}
+
+// TemplateOverrideRepository stores the email template overrides
+type TemplateOverrideRepository interface {
+	// GetTemplateOverrides returns the overrides of all the locales of the template in the scope
+	GetTemplateOverrides(ctx context.Context, scope, scopeID, templateName string) ([]*TemplateOverride, error)
+	ListTemplateOverrides(ctx context.Context, scope, scopeID string) ([]*TemplateOverride, error)
+	SaveTemplateOverride(ctx context.Context, override *TemplateOverride) error
+	DeleteTemplateOverride(ctx context.Context, scope, scopeID, templateName, locale string) error
+}
+
+type templateOverrideRepository struct {
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewTemplateOverrideRepository creates the repository of the email template overrides
+func NewTemplateOverrideRepository(awsSession *session.Session, stage string) TemplateOverrideRepository {
+	return &templateOverrideRepository{
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-email-template-overrides", stage),
+	}
+}
+
+// GetTemplateOverrides returns the overrides of all the locales of the template in the scope
+func (repo *templateOverrideRepository) GetTemplateOverrides(ctx context.Context, scope, scopeID, templateName string) ([]*TemplateOverride, error) {
+	condition := expression.Key("scope_key").Equal(expression.Value(templateOverrideScopeKey(scope, scopeID))).
+		And(expression.Key("template_key").BeginsWith(templateName + "#"))
+	return repo.queryTemplateOverrides(ctx, "emails.overrides_repository.GetTemplateOverrides", condition)
+}
+
+// ListTemplateOverrides returns all the template overrides of the scope
+func (repo *templateOverrideRepository) ListTemplateOverrides(ctx context.Context, scope, scopeID string) ([]*TemplateOverride, error) {
+	condition := expression.Key("scope_key").Equal(expression.Value(templateOverrideScopeKey(scope, scopeID)))
+	return repo.queryTemplateOverrides(ctx, "emails.overrides_repository.ListTemplateOverrides", condition)
+}
+
+func (repo *templateOverrideRepository) queryTemplateOverrides(ctx context.Context, functionName string, condition expression.KeyConditionBuilder) ([]*TemplateOverride, error) {
+	f := logrus.Fields{
+		"functionName":   functionName,
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+	}
+
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to build template override query expression")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 aws.String(repo.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+
+	var overrides []*TemplateOverride
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to query template overrides")
+			return nil, err
+		}
+
+		var page []*TemplateOverride
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to unmarshal template overrides")
+			return nil, err
+		}
+		overrides = append(overrides, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return overrides, nil
+}
+
+// SaveTemplateOverride creates or replaces the template override, the keys are derived from the scope, template
+// name and locale
+func (repo *templateOverrideRepository) SaveTemplateOverride(ctx context.Context, override *TemplateOverride) error {
+	f := logrus.Fields{
+		"functionName":   "emails.overrides_repository.SaveTemplateOverride",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scope":          override.Scope,
+		"scopeID":        override.ScopeID,
+		"templateName":   override.TemplateName,
+		"locale":         override.Locale,
+	}
+
+	override.Locale = NormalizeLocale(override.Locale)
+	override.ScopeKey = templateOverrideScopeKey(override.Scope, override.ScopeID)
+	override.TemplateKey = templateOverrideTemplateKey(override.TemplateName, override.Locale)
+
+	av, err := dynamodbattribute.MarshalMap(override)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal template override")
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save template override")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteTemplateOverride deletes the template override of the locale
+func (repo *templateOverrideRepository) DeleteTemplateOverride(ctx context.Context, scope, scopeID, templateName, locale string) error {
+	f := logrus.Fields{
+		"functionName":   "emails.overrides_repository.DeleteTemplateOverride",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scope":          scope,
+		"scopeID":        scopeID,
+		"templateName":   templateName,
+		"locale":         locale,
+	}
+
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(repo.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"scope_key":    {S: aws.String(templateOverrideScopeKey(scope, scopeID))},
+			"template_key": {S: aws.String(templateOverrideTemplateKey(templateName, locale))},
+		},
+		ConditionExpression: aws.String("attribute_exists(scope_key)"),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrTemplateOverrideNotFound
+		}
+		log.WithFields(f).WithError(err).Warn("unable to delete template override")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleCandidates(t *testing.T) {
+	assert.Equal(t, []string{"pt-br", "pt", DefaultLocale}, localeCandidates("pt_BR"))
+	assert.Equal(t, []string{"fr", DefaultLocale}, localeCandidates("fr"))
+	assert.Equal(t, []string{DefaultLocale}, localeCandidates(""))
+}
+
+func TestSelectTemplateOverride(t *testing.T) {
+	foundationPT := &TemplateOverride{Scope: TemplateScopeFoundation, Locale: "pt"}
+	claGroupDefault := &TemplateOverride{Scope: TemplateScopeCLAGroup, Locale: DefaultLocale}
+	foundationDefault := &TemplateOverride{Scope: TemplateScopeFoundation, Locale: DefaultLocale}
+	claGroupPT := &TemplateOverride{Scope: TemplateScopeCLAGroup, Locale: "pt"}
+	overrides := []*TemplateOverride{foundationPT, claGroupDefault, foundationDefault}
+
+	assert.Equal(t, foundationPT, selectTemplateOverride(overrides, "pt-BR"))
+	assert.Equal(t, claGroupDefault, selectTemplateOverride(overrides, "de"))
+	assert.Equal(t, claGroupPT, selectTemplateOverride(append(overrides, claGroupPT), "pt"))
+	assert.Nil(t, selectTemplateOverride(nil, "pt"))
+}
+
+func TestDefaultTemplatesRenderWithSampleParams(t *testing.T) {
+	for _, name := range TemplateNames() {
+		body, ok := DefaultTemplateBody(name)
+		assert.True(t, ok, name)
+		preview, err := ValidateTemplateOverride(name, "EasyCLA: {{.RecipientName}}", body)
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, "EasyCLA: Jane Doe", preview.Subject, name)
+			assert.NotEmpty(t, preview.Body, name)
+		}
+	}
+}
+
+func TestValidateTemplateOverride(t *testing.T) {
+	_, err := ValidateTemplateOverride("NoSuchTemplate", "subject", "")
+	assert.True(t, errors.Is(err, ErrUnknownTemplate))
+
+	_, err = ValidateTemplateOverride(RemovedCLAManagerTemplateName, "{{.NoSuchField}}", "")
+	var overrideErr *TemplateOverrideError
+	if assert.True(t, errors.As(err, &overrideErr)) {
+		assert.Equal(t, "subject", overrideErr.Field)
+	}
+
+	_, err = ValidateTemplateOverride(RemovedCLAManagerTemplateName, "", "<p>{{.CLAGroupName</p>")
+	if assert.True(t, errors.As(err, &overrideErr)) {
+		assert.Equal(t, "body", overrideErr.Field)
+	}
+}
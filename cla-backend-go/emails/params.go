@@ -18,6 +18,14 @@ type CommonEmailParams struct {
 	RecipientName    string
 	RecipientAddress string
 	CompanyName      string
+	// RecipientLocale selects the template override locale, when empty the preferred language of the recipient's
+	// user record is used
+	RecipientLocale string
+}
+
+// emailRecipient returns the recipient address and locale used to select the template override
+func (p CommonEmailParams) emailRecipient() (string, string) {
+	return p.RecipientAddress, p.RecipientLocale
 }
 
 // ClaManagerInfoParams represents the CLAManagerInfo used inside of the Email Templates
@@ -68,7 +76,10 @@ func (p CLAProjectParams) GetProjectFullURL() template.HTML {
 // CLAGroupTemplateParams includes the params for the CLAGroupTemplateParams
 type CLAGroupTemplateParams struct {
 	CorporateConsole string
+	CLAGroupID       string
 	CLAGroupName     string
+	// FoundationSFID is the foundation of the CLA group, used with the CLA group ID to look up the template overrides
+	FoundationSFID string
 	// ChildProjectCount indicates how many childProjects are under this CLAGroup
 	// this is important for some of the email rendering knowing if claGroup has
 	// multiple children
@@ -77,6 +88,18 @@ type CLAGroupTemplateParams struct {
 	Version           string
 }
 
+// emailTemplateScope returns the CLA group and foundation used to look up the template overrides
+func (claParams CLAGroupTemplateParams) emailTemplateScope() TemplateScope {
+	scope := TemplateScope{
+		CLAGroupID:     claParams.CLAGroupID,
+		FoundationSFID: claParams.FoundationSFID,
+	}
+	if scope.FoundationSFID == "" && len(claParams.Projects) > 0 {
+		scope.FoundationSFID = claParams.Projects[0].FoundationSFID
+	}
+	return scope
+}
+
 // GetProjectNameOrFoundation returns if the foundationName is set it gets back
 // the foundation Name otherwise the ProjectName is  returned
 func (claParams CLAGroupTemplateParams) GetProjectNameOrFoundation() string {
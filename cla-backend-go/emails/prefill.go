@@ -11,7 +11,9 @@ import (
 	service2 "github.com/linuxfoundation/easycla/cla-backend-go/project/service"
 
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
 
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 	v2ProjectService "github.com/linuxfoundation/easycla/cla-backend-go/v2/project-service"
 
@@ -23,22 +25,27 @@ type EmailTemplateService interface {
 	PrefillV2CLAProjectParams(projectSFIDs []string) ([]CLAProjectParams, error)
 	GetCLAGroupTemplateParamsFromProjectSFID(claGroupVersion, projectSFID string) (CLAGroupTemplateParams, error)
 	GetCLAGroupTemplateParamsFromCLAGroup(claGroupID string) (CLAGroupTemplateParams, error)
+	GetTemplateOverride(scope TemplateScope, templateName, recipientAddress, recipientLocale string) (*TemplateOverride, error)
 }
 
 type emailTemplateServiceProvider struct {
 	claGroupRepository repository.ProjectRepository
 	repository         projects_cla_groups.Repository
 	projectService     service2.Service
+	overrideRepository TemplateOverrideRepository
+	userRepository     users.UserRepository
 	corporateConsoleV1 string
 	corporateConsoleV2 string
 }
 
 // NewEmailTemplateService creates a new instance of email template service
-func NewEmailTemplateService(claGroupRepository repository.ProjectRepository, repository projects_cla_groups.Repository, projectService service2.Service, corporateConsoleV1, corporateConsoleV2 string) EmailTemplateService {
+func NewEmailTemplateService(claGroupRepository repository.ProjectRepository, repository projects_cla_groups.Repository, projectService service2.Service, overrideRepository TemplateOverrideRepository, userRepository users.UserRepository, corporateConsoleV1, corporateConsoleV2 string) EmailTemplateService {
 	return &emailTemplateServiceProvider{
 		claGroupRepository: claGroupRepository,
 		repository:         repository,
 		projectService:     projectService,
+		overrideRepository: overrideRepository,
+		userRepository:     userRepository,
 		corporateConsoleV1: corporateConsoleV1,
 		corporateConsoleV2: corporateConsoleV2,
 	}
@@ -111,13 +118,74 @@ func (s *emailTemplateServiceProvider) GetCLAGroupTemplateParamsFromCLAGroup(cla
 	}
 
 	params := CLAGroupTemplateParams{}
+	params.CLAGroupID = claGroupModel.ProjectID
 	params.CLAGroupName = claGroupModel.ProjectName
+	params.FoundationSFID = claGroupModel.FoundationSFID
 	params.CorporateConsole = s.corporateConsoleV2
 	params.Version = claGroupModel.Version
 
 	return params, nil
 }
 
+// GetTemplateOverride returns the template override of the CLA group or its foundation for the recipient's locale,
+// the recipient's preferred language is used when the locale is not provided - nil is returned when the template
+// is not overridden
+func (s *emailTemplateServiceProvider) GetTemplateOverride(scope TemplateScope, templateName, recipientAddress, recipientLocale string) (*TemplateOverride, error) {
+	f := logrus.Fields{
+		"functionName":     "emails.prefill.GetTemplateOverride",
+		"claGroupID":       scope.CLAGroupID,
+		"foundationSFID":   scope.FoundationSFID,
+		"templateName":     templateName,
+		"recipientAddress": recipientAddress,
+	}
+
+	if s.overrideRepository == nil {
+		return nil, nil
+	}
+
+	ctx := context.Background()
+	if scope.FoundationSFID == "" && scope.CLAGroupID != "" {
+		claGroupModel, err := s.claGroupRepository.GetCLAGroupByID(ctx, scope.CLAGroupID, false)
+		if err != nil {
+			log.WithFields(f).WithError(err).Debug("unable to load the CLA group - looking up the CLA group overrides only")
+		} else {
+			scope.FoundationSFID = claGroupModel.FoundationSFID
+		}
+	}
+
+	var overrides []*TemplateOverride
+	for _, lookup := range []struct {
+		scope   string
+		scopeID string
+	}{
+		{TemplateScopeCLAGroup, scope.CLAGroupID},
+		{TemplateScopeFoundation, scope.FoundationSFID},
+	} {
+		if lookup.scopeID == "" {
+			continue
+		}
+		scopeOverrides, err := s.overrideRepository.GetTemplateOverrides(ctx, lookup.scope, lookup.scopeID, templateName)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, scopeOverrides...)
+	}
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+
+	if recipientLocale == "" && recipientAddress != "" && s.userRepository != nil {
+		user, err := s.userRepository.GetUserByEmail(recipientAddress)
+		if err != nil {
+			log.WithFields(f).WithError(err).Debug("unable to load the recipient user record - using the default locale")
+		} else if user != nil {
+			recipientLocale = user.PreferredLanguage
+		}
+	}
+
+	return selectTemplateOverride(overrides, recipientLocale), nil
+}
+
 func (s *emailTemplateServiceProvider) getV2CLAGroupTemplateParamsFromProjectSFID(projectSFID string) (CLAGroupTemplateParams, error) {
 	projectCLAGroup, err := s.repository.GetClaGroupIDForProject(context.Background(), projectSFID)
 	if err != nil {
@@ -125,7 +193,9 @@ func (s *emailTemplateServiceProvider) getV2CLAGroupTemplateParamsFromProjectSFI
 	}
 
 	params := &CLAGroupTemplateParams{}
+	params.CLAGroupID = projectCLAGroup.ClaGroupID
 	params.CLAGroupName = projectCLAGroup.ClaGroupName
+	params.FoundationSFID = projectCLAGroup.FoundationSFID
 	params.CorporateConsole = s.corporateConsoleV2
 	params.Version = projectCLAGroup.Version
 
@@ -170,7 +240,9 @@ func (s *emailTemplateServiceProvider) getV1CLAGroupTemplateParamsFromProjectSFI
 
 	return CLAGroupTemplateParams{
 		CorporateConsole:  s.corporateConsoleV1,
+		CLAGroupID:        claGroup.ProjectID,
 		CLAGroupName:      claGroup.ProjectName,
+		FoundationSFID:    claGroup.FoundationSFID,
 		Version:           claGroup.Version,
 		ChildProjectCount: 1,
 		Projects: []CLAProjectParams{
@@ -0,0 +1,56 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+// ResignRequiredTemplateParams is email params for ResignRequiredTemplate
+type ResignRequiredTemplateParams struct {
+	CommonEmailParams
+	CLAGroupTemplateParams
+	ICLA            bool
+	SignedVersion   string
+	RequiredVersion string
+	// GracePeriodEnds is the date the outdated signature stops passing the CLA checks, empty when it is already blocked
+	GracePeriodEnds string
+}
+
+const (
+	// ResignRequiredTemplateName is email template name for ResignRequiredTemplate
+	ResignRequiredTemplateName = "ResignRequiredTemplate"
+
+	// ResignRequiredICLATemplate is email template for contributors who signed an outdated ICLA version
+	ResignRequiredICLATemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the CLA Group {{.CLAGroupName}}.</p>
+<p>The Individual CLA was updated to version {{.RequiredVersion}}. You signed version {{.SignedVersion}}, which must be signed again to keep contributing to {{.GetProjectsOrProject}}.</p>
+{{if .GracePeriodEnds}}<p>Your current signature is accepted until {{.GracePeriodEnds}}, after which the CLA checks on your pull requests will fail until the new version is signed.</p>
+{{else}}<p>The CLA checks on your pull requests will fail until the new version is signed.</p>
+{{end}}<p>You can sign the new version from the link in the CLA check of your next pull request.</p>
+`
+
+	// ResignRequiredCCLATemplate is email template for CLA managers of companies which signed an outdated CCLA version
+	ResignRequiredCCLATemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the CLA Group {{.CLAGroupName}}.</p>
+<p>The Corporate CLA was updated to version {{.RequiredVersion}}. {{.CompanyName}} signed version {{.SignedVersion}}, which must be signed again by an authorized signatory for your employees to keep contributing to {{.GetProjectsOrProject}}.</p>
+{{if .GracePeriodEnds}}<p>The current signature is accepted until {{.GracePeriodEnds}}, after which the CLA checks on your employees' pull requests will fail until the new version is signed.</p>
+{{else}}<p>The CLA checks on your employees' pull requests will fail until the new version is signed.</p>
+{{end}}<p>To get started, please log into the <a href="{{.CorporateConsole}}" target="_blank">EasyCLA Corporate Console</a>, select your company and the CLA Group, then sign the new version or send it to an authorized signatory. The existing approval lists are kept.</p>
+`
+)
+
+// RenderResignRequiredTemplate renders the ResignRequiredTemplate
+func RenderResignRequiredTemplate(svc EmailTemplateService, subject, claGroupModelVersion, claGroupID string, params ResignRequiredTemplateParams) (string, string, error) {
+	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromCLAGroup(claGroupID)
+	if err != nil {
+		return "", "", err
+	}
+	params.CLAGroupTemplateParams = claGroupParams
+
+	template := ResignRequiredCCLATemplate
+	if params.ICLA {
+		template = ResignRequiredICLATemplate
+	}
+
+	return renderTemplate(svc, subject, claGroupModelVersion, ResignRequiredTemplateName, template, params)
+}
@@ -0,0 +1,64 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+import (
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResignRequiredICLATemplate(t *testing.T) {
+	params := ResignRequiredTemplateParams{
+		CommonEmailParams: CommonEmailParams{
+			RecipientName: "Contributor",
+		},
+		CLAGroupTemplateParams: CLAGroupTemplateParams{
+			CLAGroupName: "CLAGroupFoo",
+			Projects:     []CLAProjectParams{{ExternalProjectName: "Project1"}, {ExternalProjectName: "Project2"}},
+		},
+		ICLA:            true,
+		SignedVersion:   "2.0",
+		RequiredVersion: "2.1",
+		GracePeriodEnds: "2021-09-01T00:00:00Z",
+	}
+
+	result, err := RenderTemplate(utils.V2, ResignRequiredTemplateName, ResignRequiredICLATemplate, params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "Hello Contributor")
+	assert.Contains(t, result, "regarding the CLA Group CLAGroupFoo")
+	assert.Contains(t, result, "The Individual CLA was updated to version 2.1. You signed version 2.0, which must be signed again to keep contributing to Project1, Project2.")
+	assert.Contains(t, result, "Your current signature is accepted until 2021-09-01T00:00:00Z")
+
+	params.GracePeriodEnds = ""
+	result, err = RenderTemplate(utils.V2, ResignRequiredTemplateName, ResignRequiredICLATemplate, params)
+	assert.NoError(t, err)
+	assert.NotContains(t, result, "is accepted until")
+	assert.Contains(t, result, "The CLA checks on your pull requests will fail until the new version is signed.")
+}
+
+func TestResignRequiredCCLATemplate(t *testing.T) {
+	params := ResignRequiredTemplateParams{
+		CommonEmailParams: CommonEmailParams{
+			RecipientName: "ClaManager",
+			CompanyName:   "CompanyFoo",
+		},
+		CLAGroupTemplateParams: CLAGroupTemplateParams{
+			CLAGroupName:     "CLAGroupFoo",
+			CorporateConsole: "http://CorporateConsole.com",
+			Projects:         []CLAProjectParams{{ExternalProjectName: "Project1"}},
+		},
+		SignedVersion:   "1.0",
+		RequiredVersion: "2.0",
+	}
+
+	result, err := RenderTemplate(utils.V2, ResignRequiredTemplateName, ResignRequiredCCLATemplate, params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "Hello ClaManager")
+	assert.Contains(t, result, "The Corporate CLA was updated to version 2.0. CompanyFoo signed version 1.0")
+	assert.Contains(t, result, "keep contributing to Project1.")
+	assert.Contains(t, result, "fail until the new version is signed")
+	assert.Contains(t, result, `<a href="http://CorporateConsole.com" target="_blank">EasyCLA Corporate Console</a>`)
+}
@@ -0,0 +1,310 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+import (
+	"sort"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+)
+
+// templateDefinition is the default body of a named template and the sample params used to validate its overrides,
+// the sample params have the same type as the params the template is rendered with
+type templateDefinition struct {
+	body         string
+	sampleParams func() interface{}
+}
+
+// templateDefinitions are the templates which can be overridden - the templates with an ICLA and a CCLA variant
+// are combined, an override replaces both variants
+var templateDefinitions = map[string]templateDefinition{
+	ApprovalListRejectedTemplateName: {ApprovalListRejectedTemplate, func() interface{} {
+		return ApprovalListRejectedTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			CLAManagers:            sampleCLAManagers(),
+		}
+	}},
+	ApprovalListApprovedTemplateName: {ApprovalListApprovedTemplate, func() interface{} {
+		return ApprovalListApprovedTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			Approver:               "John Smith",
+		}
+	}},
+	ApprovalListExpiryReminderTemplateName: {ApprovalListExpiryReminderTemplate, func() interface{} {
+		return ApprovalListExpiryReminderTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			CompanyID:              "d1e86e5c-2f5a-4f44-b2b9-5e4a1bb6d5f3",
+			Entries: []ApprovalListExpiryEntry{
+				{Criteria: "email", Value: "alex.contributor@example.com", ExpiresAt: "2021-09-01T00:00:00Z"},
+			},
+		}
+	}},
+	CLAManagerDigestTemplateName: {CLAManagerDigestTemplate, func() interface{} {
+		return CLAManagerDigestTemplateParams{
+			CommonEmailParams: sampleCommonEmailParams(),
+			Frequency:         "daily",
+			Sections: []CLAManagerDigestSection{
+				{
+					CompanyName:       "Example Company",
+					CLAGroupName:      "Example CLA Group",
+					ApprovalRequests:  []string{"Alex Contributor (alex.contributor@example.com) requested to be added to the approval list"},
+					Signatures:        []string{"Alex Contributor (alex.contributor@example.com) acknowledged the corporate CLA"},
+					CLAManagerChanges: []string{"John Smith (john.smith@example.com) was added as a CLA Manager"},
+				},
+			},
+			CorporateURL:  "https://corporate.lfcla.com",
+			Notifications: 3,
+		}
+	}},
+	RequestToAuthorizeTemplateName: {RequestToAuthorizeTemplate, func() interface{} {
+		return RequestToAuthorizeTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			CLAManagers:            sampleCLAManagers(),
+			ContributorName:        "Alex Contributor",
+			ContributorEmail:       "alex.contributor@example.com",
+			OptionalMessage:        "Please approve my contributions",
+			CompanyID:              "d1e86e5c-2f5a-4f44-b2b9-5e4a1bb6d5f3",
+		}
+	}},
+	RemovedCLAManagerTemplateName: {RemovedCLAManagerTemplate, func() interface{} {
+		return RemovedCLAManagerTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			CLAManagers:            sampleCLAManagers(),
+		}
+	}},
+	RequestAccessToCLAManagersTemplateName: {RequestAccessToCLAManagersTemplate, func() interface{} {
+		return RequestAccessToCLAManagersTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			RequesterName:          "Alex Requester",
+			RequesterEmail:         "alex.requester@example.com",
+		}
+	}},
+	RequestApprovedToCLAManagersTemplateName: {RequestApprovedToCLAManagersTemplate, func() interface{} {
+		return RequestApprovedToCLAManagersTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			RequesterName:          "Alex Requester",
+			RequesterEmail:         "alex.requester@example.com",
+		}
+	}},
+	RequestApprovedToRequesterTemplateName: {RequestApprovedToRequesterTemplate, func() interface{} {
+		return RequestApprovedToRequesterTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+		}
+	}},
+	RequestDeniedToCLAManagersTemplateName: {RequestDeniedToCLAManagersTemplate, func() interface{} {
+		return RequestDeniedToCLAManagersTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			RequesterName:          "Alex Requester",
+			RequesterEmail:         "alex.requester@example.com",
+		}
+	}},
+	RequestDeniedToRequesterTemplateName: {RequestDeniedToRequesterTemplate, func() interface{} {
+		return RequestDeniedToRequesterTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+		}
+	}},
+	ClaManagerAddedEToUserTemplateName: {ClaManagerAddedEToUserTemplate, func() interface{} {
+		return ClaManagerAddedEToUserTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+		}
+	}},
+	ClaManagerAddedToCLAManagersTemplateName: {ClaManagerAddedToCLAManagersTemplate, func() interface{} {
+		return ClaManagerAddedToCLAManagersTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			Name:                   "Alex Manager",
+			Email:                  "alex.manager@example.com",
+			ProjectSFID:            sampleProjectSFID,
+		}
+	}},
+	ClaManagerDeletedToCLAManagersTemplateName: {ClaManagerDeletedToCLAManagersTemplate, func() interface{} {
+		return ClaManagerDeletedToCLAManagersTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			Name:                   "Alex Manager",
+			Email:                  "alex.manager@example.com",
+		}
+	}},
+	DocumentSignedTemplateName: {iclaOrCCLATemplate(DocumentSignedICLATemplate, DocumentSignedCCLATemplate), func() interface{} {
+		return DocumentSignedTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			ICLA:                   true,
+			PdfLink:                "https://api.example.org/v2/signatures/project/sample/user/sample/icla/pdf",
+		}
+	}},
+	ResignRequiredTemplateName: {iclaOrCCLATemplate(ResignRequiredICLATemplate, ResignRequiredCCLATemplate), func() interface{} {
+		return ResignRequiredTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			ICLA:                   true,
+			SignedVersion:          "1.0",
+			RequiredVersion:        "2.0",
+			GracePeriodEnds:        "2021-06-30",
+		}
+	}},
+	GithubRepositoryDisabledTemplateName: {GithubRepositoryDisabledTemplate, func() interface{} {
+		return GithubRepositoryDisabledTemplateParams{
+			GithubRepositoryActionTemplateParams: sampleGithubRepositoryActionTemplateParams(),
+			GithubAction:                         "deleted",
+		}
+	}},
+	GithubRepositoryArchivedTemplateName: {GithubRepositoryArchivedTemplate, func() interface{} {
+		return GithubRepositoryArchivedTemplateParams{
+			GithubRepositoryActionTemplateParams: sampleGithubRepositoryActionTemplateParams(),
+		}
+	}},
+	GithubRepositoryRenamedTemplateName: {GithubRepositoryRenamedTemplate, func() interface{} {
+		return GithubRepositoryRenamedTemplateParams{
+			GithubRepositoryActionTemplateParams: sampleGithubRepositoryActionTemplateParams(),
+			OldRepositoryName:                    "example-org/old-repository",
+			NewRepositoryName:                    "example-org/example-repository",
+		}
+	}},
+	GithubRepositoryTransferredTemplateName:       {GithubRepositoryTransferredTemplate, sampleGithubRepositoryTransferredTemplateParams},
+	GithubRepositoryTransferredFailedTemplateName: {GithubRepositoryTransferredFailedTemplate, sampleGithubRepositoryTransferredTemplateParams},
+	V2ContributorApprovalRequestTemplateName: {V2ContributorApprovalRequestTemplate, func() interface{} {
+		return V2ContributorApprovalRequestTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			SigningEntityName:      "Example Company",
+			UserDetails:            "<ul><li>GitHub Username: alexcontributor</li></ul>",
+		}
+	}},
+	V2OrgAdminTemplateName: {V2OrgAdminTemplate, func() interface{} {
+		return V2OrgAdminTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			SenderName:             "Alex Sender",
+			SenderEmail:            "alex.sender@example.com",
+		}
+	}},
+	V2ContributorToOrgAdminTemplateName: {V2ContributorToOrgAdminTemplate, func() interface{} {
+		return V2ContributorToOrgAdminTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			UserDetails:            "<ul><li>GitHub Username: alexcontributor</li></ul>",
+		}
+	}},
+	V2CLAManagerDesigneeCorporateTemplateName: {V2CLAManagerDesigneeCorporateTemplate, func() interface{} {
+		return V2CLAManagerDesigneeCorporateTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+			SenderName:             "Alex Sender",
+			SenderEmail:            "alex.sender@example.com",
+		}
+	}},
+	V2ToCLAManagerDesigneeTemplateName:     {V2ToCLAManagerDesigneeTemplate, sampleV2ToCLAManagerDesigneeTemplateParams},
+	V2DesigneeToUserWithNoLFIDTemplateName: {V2DesigneeToUserWithNoLFIDTemplate, sampleV2ToCLAManagerDesigneeTemplateParams},
+	V2CLAManagerToUserWithNoLFIDTemplateName: {V2CLAManagerToUserWithNoLFIDTemplate, func() interface{} {
+		claGroupParams := sampleCLAGroupTemplateParams()
+		return V2CLAManagerToUserWithNoLFIDTemplateParams{
+			CommonEmailParams:      sampleCommonEmailParams(),
+			CLAGroupTemplateParams: claGroupParams,
+			RequesterUserName:      "alexrequester",
+			RequesterEmail:         "alex.requester@example.com",
+			Projects:               claGroupParams.Projects,
+		}
+	}},
+}
+
+// TemplateNames returns the names of the templates which can be overridden
+func TemplateNames() []string {
+	names := make([]string, 0, len(templateDefinitions))
+	for name := range templateDefinitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultTemplateBody returns the default body of the named template
+func DefaultTemplateBody(templateName string) (string, bool) {
+	definition, ok := templateDefinitions[templateName]
+	if !ok {
+		return "", false
+	}
+	return definition.body, true
+}
+
+// iclaOrCCLATemplate combines the ICLA and CCLA variants of a template into a single template
+func iclaOrCCLATemplate(iclaTemplate, cclaTemplate string) string {
+	return "{{if .ICLA}}" + iclaTemplate + "{{else}}" + cclaTemplate + "{{end}}"
+}
+
+const sampleProjectSFID = "a092M00001IV4RaQAL"
+
+func sampleCommonEmailParams() CommonEmailParams {
+	return CommonEmailParams{
+		RecipientName:    "Jane Doe",
+		RecipientAddress: "jane.doe@example.com",
+		CompanyName:      "Example Company",
+	}
+}
+
+func sampleCLAGroupTemplateParams() CLAGroupTemplateParams {
+	return CLAGroupTemplateParams{
+		CorporateConsole:  "https://corporate.example.org",
+		CLAGroupID:        "8b6ec6a4-21c5-45f3-9d0f-1b2f12a7c5d4",
+		CLAGroupName:      "Example CLA Group",
+		FoundationSFID:    "a092M00001IV4RbQAL",
+		ChildProjectCount: 1,
+		Version:           utils.V2,
+		Projects: []CLAProjectParams{
+			{
+				ExternalProjectName: "Example Project",
+				ProjectSFID:         sampleProjectSFID,
+				FoundationName:      "Example Foundation",
+				FoundationSFID:      "a092M00001IV4RbQAL",
+				CorporateConsole:    "https://corporate.example.org",
+			},
+		},
+	}
+}
+
+func sampleCLAManagers() []ClaManagerInfoParams {
+	return []ClaManagerInfoParams{
+		{LfUsername: "alexmanager", Email: "alex.manager@example.com"},
+	}
+}
+
+func sampleGithubRepositoryActionTemplateParams() GithubRepositoryActionTemplateParams {
+	return GithubRepositoryActionTemplateParams{
+		CommonEmailParams:      sampleCommonEmailParams(),
+		CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+		RepositoryName:         "example-org/example-repository",
+	}
+}
+
+func sampleGithubRepositoryTransferredTemplateParams() interface{} {
+	return GithubRepositoryTransferredTemplateParams{
+		GithubRepositoryActionTemplateParams: sampleGithubRepositoryActionTemplateParams(),
+		OldGithubOrgName:                     "old-example-org",
+		NewGithubOrgName:                     "example-org",
+	}
+}
+
+func sampleV2ToCLAManagerDesigneeTemplateParams() interface{} {
+	return V2ToCLAManagerDesigneeTemplateParams{
+		CommonEmailParams:      sampleCommonEmailParams(),
+		CLAGroupTemplateParams: sampleCLAGroupTemplateParams(),
+		Contributor: Contributor{
+			Email:         "alex.contributor@example.com",
+			Username:      "alexcontributor",
+			EmailLabel:    "Email",
+			UsernameLabel: "GitHub Username",
+		},
+	}
+}
@@ -38,15 +38,15 @@ const (
 )
 
 // RenderV2ContributorApprovalRequestTemplate renders V2ContributorApprovalRequestTemplate
-func RenderV2ContributorApprovalRequestTemplate(svc EmailTemplateService, projectSFIDs []string, params V2ContributorApprovalRequestTemplateParams) (string, error) {
+func RenderV2ContributorApprovalRequestTemplate(svc EmailTemplateService, subject string, projectSFIDs []string, params V2ContributorApprovalRequestTemplateParams) (string, string, error) {
 
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(utils.V2, projectSFIDs[0])
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
 
-	return RenderTemplate(utils.V2, V2ContributorApprovalRequestTemplateName, V2ContributorApprovalRequestTemplate, params)
+	return renderTemplate(svc, subject, utils.V2, V2ContributorApprovalRequestTemplateName, V2ContributorApprovalRequestTemplate, params)
 }
 
 // V2OrgAdminTemplateParams is email params for V2OrgAdminTemplate
@@ -76,13 +76,13 @@ Either you or someone whom to designate from your company can login to the EasyC
 )
 
 // RenderV2OrgAdminTemplate renders V2OrgAdminTemplate
-func RenderV2OrgAdminTemplate(svc EmailTemplateService, projectSFID string, params V2OrgAdminTemplateParams) (string, error) {
+func RenderV2OrgAdminTemplate(svc EmailTemplateService, subject, projectSFID string, params V2OrgAdminTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(utils.V2, projectSFID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
-	return RenderTemplate(utils.V2, V2OrgAdminTemplateName, V2OrgAdminTemplate, params)
+	return renderTemplate(svc, subject, utils.V2, V2OrgAdminTemplateName, V2OrgAdminTemplate, params)
 }
 
 // V2ContributorToOrgAdminTemplateParams is email template params for V2ContributorToOrgAdminTemplate
@@ -107,15 +107,15 @@ const (
 )
 
 // RenderV2ContributorToOrgAdminTemplate renders V2ContributorToOrgAdminTemplate
-func RenderV2ContributorToOrgAdminTemplate(svc EmailTemplateService, projectSFIDs []string, params V2ContributorToOrgAdminTemplateParams) (string, error) {
+func RenderV2ContributorToOrgAdminTemplate(svc EmailTemplateService, subject string, projectSFIDs []string, params V2ContributorToOrgAdminTemplateParams) (string, string, error) {
 	// prefill the projects data
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(utils.V2, projectSFIDs[0])
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
 
-	return RenderTemplate(utils.V2, V2ContributorToOrgAdminTemplateName,
+	return renderTemplate(svc, subject, utils.V2, V2ContributorToOrgAdminTemplateName,
 		V2ContributorToOrgAdminTemplate, params)
 }
 
@@ -146,14 +146,14 @@ Either you or someone whom you designate from your company can login and <b>sign
 )
 
 // RenderV2CLAManagerDesigneeCorporateTemplate renders V2CLAManagerDesigneeCorporateTemplate
-func RenderV2CLAManagerDesigneeCorporateTemplate(emailSvc EmailTemplateService, projectSFID string, params V2CLAManagerDesigneeCorporateTemplateParams) (string, error) {
+func RenderV2CLAManagerDesigneeCorporateTemplate(emailSvc EmailTemplateService, subject, projectSFID string, params V2CLAManagerDesigneeCorporateTemplateParams) (string, string, error) {
 	claGroupParams, err := emailSvc.GetCLAGroupTemplateParamsFromProjectSFID(utils.V2, projectSFID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
 
-	return RenderTemplate(utils.V2, V2CLAManagerDesigneeCorporateTemplateName, V2CLAManagerDesigneeCorporateTemplate, params)
+	return renderTemplate(emailSvc, subject, utils.V2, V2CLAManagerDesigneeCorporateTemplateName, V2CLAManagerDesigneeCorporateTemplate, params)
 }
 
 // V2ToCLAManagerDesigneeTemplateParams is email params for V2ToCLAManagerDesigneeTemplate
@@ -182,14 +182,14 @@ const (
 )
 
 // RenderV2ToCLAManagerDesigneeTemplate renders V2ToCLAManagerDesigneeTemplate
-func RenderV2ToCLAManagerDesigneeTemplate(svc EmailTemplateService, projectSFIDs []string, params V2ToCLAManagerDesigneeTemplateParams, template string, templateName string) (string, error) {
+func RenderV2ToCLAManagerDesigneeTemplate(svc EmailTemplateService, subject string, projectSFIDs []string, params V2ToCLAManagerDesigneeTemplateParams, template string, templateName string) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(utils.V2, projectSFIDs[0])
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
 
-	return RenderTemplate(utils.V2, templateName,
+	return renderTemplate(svc, subject, utils.V2, templateName,
 		template, params)
 }
 
@@ -243,15 +243,14 @@ const (
 )
 
 // RenderV2CLAManagerToUserWithNoLFIDTemplate renders V2CLAManagerToUserWithNoLFIDTemplate
-func RenderV2CLAManagerToUserWithNoLFIDTemplate(svc EmailTemplateService, projectSFID string, params V2CLAManagerToUserWithNoLFIDTemplateParams) (string, error) {
+func RenderV2CLAManagerToUserWithNoLFIDTemplate(svc EmailTemplateService, subject, projectSFID string, params V2CLAManagerToUserWithNoLFIDTemplateParams) (string, string, error) {
 	claGroupParams, err := svc.GetCLAGroupTemplateParamsFromProjectSFID(utils.V2, projectSFID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	params.CLAGroupTemplateParams = claGroupParams
 
-	body, err := RenderTemplate(utils.V2, V2CLAManagerToUserWithNoLFIDTemplateName,
+	return renderTemplate(svc, subject, utils.V2, V2CLAManagerToUserWithNoLFIDTemplateName,
 		V2CLAManagerToUserWithNoLFIDTemplate,
 		params)
-	return body, err
 }
@@ -5,6 +5,7 @@ package events
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
@@ -146,6 +147,29 @@ type UserDeletedEventData struct {
 // UserUpdatedEventData data model
 type UserUpdatedEventData struct{}
 
+// UserMergeStartedEventData data model
+type UserMergeStartedEventData struct {
+	SurvivingUserID string
+	DuplicateUserID string
+	// MergeRecord is the JSON record of the merge, stored before the merge is applied to revert a partial merge
+	MergeRecord string
+}
+
+// UserMergedEventData data model
+type UserMergedEventData struct {
+	SurvivingUserID string
+	DuplicateUserID string
+	// MergeRecord is the JSON record of the merge, stored in the event details to revert the merge
+	MergeRecord string
+}
+
+// UserMergeRevertedEventData data model
+type UserMergeRevertedEventData struct {
+	SurvivingUserID string
+	DuplicateUserID string
+	MergeEventID    string
+}
+
 // CompanyACLRequestAddedEventData data model
 type CompanyACLRequestAddedEventData struct {
 	UserName  string
@@ -198,6 +222,8 @@ type GitHubOrganizationUpdatedEventData struct {
 	AutoEnabled             bool
 	AutoEnabledClaGroupID   string
 	BranchProtectionEnabled bool
+	CommitIdentityPolicy    string
+	CheckRunEnabled         *bool
 }
 
 // GitLabOrganizationAddedEventData data model
@@ -219,6 +245,7 @@ type GitLabOrganizationUpdatedEventData struct {
 	GitLabGroupID          int64
 	AutoEnabled            bool
 	AutoEnabledClaGroupID  string
+	CommitIdentityPolicy   string
 }
 
 // CCLAApprovalListRequestCreatedEventData data model
@@ -457,6 +484,43 @@ type CorporateSignatureSignedEventData struct {
 	SignatoryName string
 }
 
+// SignatureEnvelopeStatusEventData event data model
+type SignatureEnvelopeStatusEventData struct {
+	SignatureID string
+	EnvelopeID  string
+	ClaType     string
+	Status      string
+	Reason      string
+}
+
+// CLATemplateResignRequiredEventData event data model
+type CLATemplateResignRequiredEventData struct {
+	ClaType         string
+	Version         string
+	Enforcement     string
+	GracePeriodEnds string
+}
+
+// CLATemplateResignCampaignEventData event data model
+type CLATemplateResignCampaignEventData struct {
+	ClaType            string
+	Version            string
+	OutdatedSignatures int
+	Notified           int
+	Skipped            int
+	Failed             int
+}
+
+// CLATemplateUploadedEventData event data model
+type CLATemplateUploadedEventData struct {
+	TemplateID   string
+	TemplateName string
+	Version      string
+	// ICLADigest and CCLADigest are the SHA-256 digests of the uploaded legal text, empty when not uploaded
+	ICLADigest string
+	CCLADigest string
+}
+
 // BypassCLAEventData event data model
 type BypassCLAEventData struct {
 	Repo   string
@@ -464,6 +528,360 @@ type BypassCLAEventData struct {
 	Actor  string
 }
 
+// BotAllowlistUpdatedEventData event data model
+type BotAllowlistUpdatedEventData struct {
+	OrganizationType string
+	OrganizationName string
+	Action           string
+	Entries          []string
+}
+
+// GerritGroupDriftEventData event data model
+type GerritGroupDriftEventData struct {
+	GerritName string
+	GroupName  string
+	ClaType    string
+	// Unauthorized is the number of group members without a valid signature
+	Unauthorized int
+	// Missing is the number of signers who are not group members
+	Missing int
+}
+
+// GerritGroupMemberReconciledEventData event data model
+type GerritGroupMemberReconciledEventData struct {
+	GerritName string
+	GroupName  string
+	ClaType    string
+	Username   string
+	Action     string
+	Reason     string
+}
+
+// ApprovalListEntryExpiryEventData event data model
+type ApprovalListEntryExpiryEventData struct {
+	Criteria  string
+	Value     string
+	ExpiresAt string
+	// Action is one of set, reminder or expired
+	Action string
+}
+
+// WebhookSubscriptionEventData event data model
+type WebhookSubscriptionEventData struct {
+	Scope          string
+	ScopeID        string
+	SubscriptionID string
+	URL            string
+	EventTypes     []string
+	Action         string
+}
+
+// EmailTemplateOverrideEventData event data model
+type EmailTemplateOverrideEventData struct {
+	Scope        string
+	ScopeID      string
+	TemplateName string
+	Locale       string
+	Action       string
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *EmailTemplateOverrideEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s email template override of the %s %s for locale %s was %s", ed.TemplateName, strings.ReplaceAll(ed.Scope, "_", " "), ed.ScopeID, ed.Locale, ed.Action)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *EmailTemplateOverrideEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s email template override for locale %s was %s", ed.TemplateName, ed.Locale, ed.Action)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA group %s", args.CLAGroupName)
+	} else if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the foundation %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *GerritGroupDriftEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s gerrit group %s of the gerrit instance %s has %d members without a valid signature and %d signers missing from the group",
+		ed.ClaType, ed.GroupName, ed.GerritName, ed.Unauthorized, ed.Missing)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *GerritGroupDriftEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The gerrit group %s of %s has %d members without a valid signature and %d signers missing from the group",
+		ed.GroupName, ed.GerritName, ed.Unauthorized, ed.Missing)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *GerritGroupMemberReconciledEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The username %s was %s the %s gerrit group %s of the gerrit instance %s by the group reconciliation: %s",
+		ed.Username, reconciliationActionString(ed.Action), ed.ClaType, ed.GroupName, ed.GerritName, ed.Reason)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *GerritGroupMemberReconciledEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The username %s was %s the gerrit group %s of %s: %s",
+		ed.Username, reconciliationActionString(ed.Action), ed.GroupName, ed.GerritName, ed.Reason)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// reconciliationActionString returns the phrase of the gerrit group reconciliation action
+func reconciliationActionString(action string) string {
+	if action == "removed" {
+		return "removed from"
+	}
+	return "added to"
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *ApprovalListEntryExpiryEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := approvalListEntryExpiryString(ed)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
+	}
+	if args.UserName != "" && ed.Action == "set" {
+		data = data + fmt.Sprintf(" by the CLA Manager %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *ApprovalListEntryExpiryEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := approvalListEntryExpiryString(ed)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// approvalListEntryExpiryString returns the phrase of the approval list entry expiry action
+func approvalListEntryExpiryString(ed *ApprovalListEntryExpiryEventData) string {
+	switch ed.Action {
+	case "reminder":
+		return fmt.Sprintf("The CLA Managers were reminded that the %s approval list entry %s expires at %s", ed.Criteria, ed.Value, ed.ExpiresAt)
+	case "expired":
+		return fmt.Sprintf("The %s approval list entry %s expired at %s and was removed from the approval list", ed.Criteria, ed.Value, ed.ExpiresAt)
+	}
+	return fmt.Sprintf("The %s approval list entry %s was set to expire at %s", ed.Criteria, ed.Value, ed.ExpiresAt)
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *WebhookSubscriptionEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The webhook subscription %s of the %s %s to %s was %s", ed.SubscriptionID, ed.Scope, ed.ScopeID, ed.URL, ed.Action)
+	if len(ed.EventTypes) > 0 {
+		data = data + fmt.Sprintf(" with event types: %s", strings.Join(ed.EventTypes, ", "))
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *WebhookSubscriptionEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The webhook subscription to %s was %s", ed.URL, ed.Action)
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
+	} else if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the foundation %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *BotAllowlistUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The bot allowlist of the %s organization '%s' was updated (%s)", ed.OrganizationType, ed.OrganizationName, ed.Action)
+	if len(ed.Entries) > 0 {
+		data = data + fmt.Sprintf(" with entries: %s", strings.Join(ed.Entries, ", "))
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *BotAllowlistUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The bot allowlist of the %s organization '%s' was updated (%s)", ed.OrganizationType, ed.OrganizationName, ed.Action)
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *SignatureEnvelopeStatusEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s signature %s envelope %s is %s", ed.ClaType, ed.SignatureID, ed.EnvelopeID, strings.ToLower(ed.Status))
+	if ed.Reason != "" {
+		data = data + fmt.Sprintf(" (%s)", ed.Reason)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for project %s", args.ProjectName)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" and company %s", args.CompanyName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" for the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *SignatureEnvelopeStatusEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s signature envelope is %s", ed.ClaType, strings.ToLower(ed.Status))
+	if ed.Reason != "" {
+		data = data + fmt.Sprintf(" (%s)", ed.Reason)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for project %s", args.ProjectName)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" and company %s", args.CompanyName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *CLATemplateResignRequiredEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s document version %s was marked as requiring re-signature with the %s enforcement", ed.ClaType, ed.Version, ed.Enforcement)
+	if ed.GracePeriodEnds != "" {
+		data = data + fmt.Sprintf(" - outdated signatures are accepted until %s", ed.GracePeriodEnds)
+	}
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *CLATemplateResignRequiredEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s document version %s requires re-signature", ed.ClaType, ed.Version)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *CLATemplateResignCampaignEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("A re-sign campaign for the %s document version %s found %d outdated signatures, notified: %d, already notified: %d, failed: %d",
+		ed.ClaType, ed.Version, ed.OutdatedSignatures, ed.Notified, ed.Skipped, ed.Failed)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" started by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *CLATemplateResignCampaignEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("A re-sign campaign for the %s document version %s notified %d signatories", ed.ClaType, ed.Version, ed.Notified)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *CLATemplateUploadedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The custom CLA template %s (%s) was uploaded as document version %s", ed.TemplateName, ed.TemplateID, ed.Version)
+	if ed.ICLADigest != "" {
+		data = data + fmt.Sprintf(", icla sha256: %s", ed.ICLADigest)
+	}
+	if ed.CCLADigest != "" {
+		data = data + fmt.Sprintf(", ccla sha256: %s", ed.CCLADigest)
+	}
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *CLATemplateUploadedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The custom CLA template %s was uploaded", ed.TemplateName)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
 func (ed *BypassCLAEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("repo='%s', config='%s', actor='%s'", ed.Repo, ed.Config, ed.Actor)
 	return data, true
@@ -711,6 +1129,48 @@ func (ed *UserDeletedEventData) GetEventDetailsString(args *LogEventArgs) (strin
 	return data, true
 }
 
+// UserMergeRecordMarker precedes the merge record in the details of the user merged event
+const UserMergeRecordMarker = " Merge record: "
+
+// GetEventDetailsString returns the details string for this event
+func (ed *UserMergeStartedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The merge of the user ID: %s into the user ID: %s was started", ed.DuplicateUserID, ed.SurvivingUserID)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "." + UserMergeRecordMarker + ed.MergeRecord
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *UserMergedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User ID: %s was merged into the user ID: %s", ed.DuplicateUserID, ed.SurvivingUserID)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "." + UserMergeRecordMarker + ed.MergeRecord
+	return data, true
+}
+
+// UserMergeRecordFromEventData returns the merge record stored in the details of the user merged event
+func UserMergeRecordFromEventData(eventData string) (string, bool) {
+	index := strings.Index(eventData, UserMergeRecordMarker)
+	if index < 0 {
+		return "", false
+	}
+	return eventData[index+len(UserMergeRecordMarker):], true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *UserMergeRevertedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The merge of the user ID: %s into the user ID: %s (event ID: %s) was reverted", ed.DuplicateUserID, ed.SurvivingUserID, ed.MergeEventID)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, false
+}
+
 // GetEventDetailsString returns the details string for this event
 func (ed *CompanyACLRequestAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("User: %s added pending invite with ID: %s and Email: %s for Company: %s",
@@ -813,6 +1273,12 @@ func (ed *GitHubOrganizationUpdatedEventData) GetEventDetailsString(args *LogEve
 	if ed.AutoEnabledClaGroupID != "" {
 		data = data + fmt.Sprintf(" with auto-enabled-cla-group ID value of %s", ed.AutoEnabledClaGroupID)
 	}
+	if ed.CommitIdentityPolicy != "" {
+		data = data + fmt.Sprintf(" with commit identity policy %s", ed.CommitIdentityPolicy)
+	}
+	if ed.CheckRunEnabled != nil {
+		data = data + fmt.Sprintf(" with check run mode set to %t", *ed.CheckRunEnabled)
+	}
 	if args.ProjectName != "" {
 		data = data + fmt.Sprintf(" for project %s", args.ProjectName)
 	}
@@ -860,6 +1326,9 @@ func (ed *GitLabOrganizationUpdatedEventData) GetEventDetailsString(args *LogEve
 	if ed.AutoEnabledClaGroupID != "" {
 		data = fmt.Sprintf("%s with auto-enabled-cla-group: %s", data, ed.AutoEnabledClaGroupID)
 	}
+	if ed.CommitIdentityPolicy != "" {
+		data = fmt.Sprintf("%s with commit identity policy: %s", data, ed.CommitIdentityPolicy)
+	}
 	if args.ProjectName != "" {
 		data = fmt.Sprintf("%s for the project %s", data, args.ProjectName)
 	}
@@ -1788,6 +2257,36 @@ func (ed *UserDeletedEventData) GetEventSummaryString(args *LogEventArgs) (strin
 	return data, true
 }
 
+// GetEventSummaryString returns the summary string for this event
+func (ed *UserMergeStartedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The merge of the user ID %s into the user ID %s was started", ed.DuplicateUserID, ed.SurvivingUserID)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, false
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *UserMergedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The user ID %s was merged into the user ID %s", ed.DuplicateUserID, ed.SurvivingUserID)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, false
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *UserMergeRevertedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The merge of the user ID %s into the user ID %s was reverted", ed.DuplicateUserID, ed.SurvivingUserID)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, false
+}
+
 // GetEventSummaryString returns the summary string for this event
 func (ed *CompanyACLRequestAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("The user %s with ID %s and with the email %s requested a company invitation",
@@ -26,10 +26,16 @@ const (
 // events
 // naming convention : <resource>.<action>
 const (
-	CLATemplateCreated = "cla_template.created"
-	UserCreated        = "user.created"
-	UserUpdated        = "user.updated"
-	UserDeleted        = "user.deleted"
+	CLATemplateCreated        = "cla_template.created"
+	CLATemplateResignRequired = "cla_template.resign_required"
+	CLATemplateResignCampaign = "cla_template.resign_campaign"
+	CLATemplateUploaded       = "cla_template.uploaded"
+	UserCreated               = "user.created"
+	UserUpdated               = "user.updated"
+	UserDeleted               = "user.deleted"
+	UserMergeStarted          = "user.merge_started"
+	UserMerged                = "user.merged"
+	UserMergeReverted         = "user.merge_reverted"
 
 	RepositoryAdded                    = "repository.added"
 	RepositoryRenamed                  = "repository.renamed"
@@ -46,6 +52,9 @@ const (
 	GerritUserAdded         = "gerrit_user.added"
 	GerritUserRemoved       = "gerrit_user.deleted"
 
+	GerritGroupDriftDetected    = "gerrit_group.drift_detected"
+	GerritGroupMemberReconciled = "gerrit_group.member_reconciled"
+
 	GitHubOrganizationAdded   = "github_organization.added"
 	GitHubOrganizationDeleted = "github_organization.deleted"
 	GitHubOrganizationUpdated = "github_organization.updated"
@@ -54,6 +63,15 @@ const (
 	GitlabOrganizationDeleted = "gitlab_organization.deleted"
 	GitlabOrganizationUpdated = "gitlab_organization.updated"
 
+	BotAllowlistUpdated = "bot_allowlist.updated"
+
+	EmailTemplateOverrideSaved   = "email_template_override.saved"
+	EmailTemplateOverrideDeleted = "email_template_override.deleted"
+
+	WebhookSubscriptionCreated = "webhook_subscription.created"
+	WebhookSubscriptionUpdated = "webhook_subscription.updated"
+	WebhookSubscriptionDeleted = "webhook_subscription.deleted"
+
 	CompanyACLUserAdded       = "company_acl.user_added"
 	CompanyACLRequestAdded    = "company_acl.request_added"
 	CompanyACLRequestApproved = "company_acl.request_approved"
@@ -65,6 +83,9 @@ const (
 
 	ApprovalListGitHubOrganizationAdded   = "approval_list.github_organization_added"
 	ApprovalListGitHubOrganizationDeleted = "approval_list.github_organization_deleted"
+	ApprovalListEntryExpirySet            = "approval_list.entry_expiry_set"
+	ApprovalListEntryExpiryReminder       = "approval_list.entry_expiry_reminder"
+	ApprovalListEntryExpired              = "approval_list.entry_expired"
 
 	ClaManagerAccessRequestCreated  = "cla_manager.access_request_created"
 	ClaManagerAccessRequestApproved = "cla_manager.access_request_approved"
@@ -100,5 +121,9 @@ const (
 	IndividualSignatureSigned = "individual.signature.signed"
 	CorporateSignatureSigned  = "corporate.signature.signed"
 
+	SignatureEnvelopeDeclined = "signature.envelope.declined"
+	SignatureEnvelopeVoided   = "signature.envelope.voided"
+	SignatureEnvelopeStale    = "signature.envelope.stale"
+
 	BypassCLA = "Bypass CLA"
 )
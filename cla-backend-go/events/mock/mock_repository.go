@@ -67,6 +67,21 @@ func (mr *MockRepositoryMockRecorder) CreateEvent(event interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockRepository)(nil).CreateEvent), event)
 }
 
+// GetCCLAEvents mocks base method.
+func (m *MockRepository) GetCCLAEvents(claGroupId, companyID, searchTerm, eventType string, pageSize int64) ([]*models.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCCLAEvents", claGroupId, companyID, searchTerm, eventType, pageSize)
+	ret0, _ := ret[0].([]*models.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCCLAEvents indicates an expected call of GetCCLAEvents.
+func (mr *MockRepositoryMockRecorder) GetCCLAEvents(claGroupId, companyID, searchTerm, eventType, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCCLAEvents", reflect.TypeOf((*MockRepository)(nil).GetCCLAEvents), claGroupId, companyID, searchTerm, eventType, pageSize)
+}
+
 // GetClaGroupEvents mocks base method.
 func (m *MockRepository) GetClaGroupEvents(claGroupID string, nextKey *string, paramPageSize *int64, all bool, searchTerm *string) (*models.EventList, error) {
 	m.ctrl.T.Helper()
@@ -127,6 +142,36 @@ func (mr *MockRepositoryMockRecorder) GetCompanyFoundationEvents(companySFID, co
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyFoundationEvents", reflect.TypeOf((*MockRepository)(nil).GetCompanyFoundationEvents), companySFID, companyID, foundationSFID, nextKey, paramPageSize, searchTerm, all)
 }
 
+// GetEvent mocks base method.
+func (m *MockRepository) GetEvent(eventID string) (*models.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvent", eventID)
+	ret0, _ := ret[0].(*models.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvent indicates an expected call of GetEvent.
+func (mr *MockRepositoryMockRecorder) GetEvent(eventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockRepository)(nil).GetEvent), eventID)
+}
+
+// GetEventsByType mocks base method.
+func (m *MockRepository) GetEventsByType(eventType string, pageSize int64) ([]*models.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsByType", eventType, pageSize)
+	ret0, _ := ret[0].([]*models.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventsByType indicates an expected call of GetEventsByType.
+func (mr *MockRepositoryMockRecorder) GetEventsByType(eventType, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsByType", reflect.TypeOf((*MockRepository)(nil).GetEventsByType), eventType, pageSize)
+}
+
 // GetFoundationEvents mocks base method.
 func (m *MockRepository) GetFoundationEvents(foundationSFID string, nextKey *string, paramPageSize *int64, all bool, searchTerm *string) (*models.EventList, error) {
 	m.ctrl.T.Helper()
@@ -20,6 +20,11 @@ import (
 // mockRepository data model
 type mockRepository struct{}
 
+// GetEvent implements Repository.
+func (repo *mockRepository) GetEvent(eventID string) (*models.Event, error) {
+	panic("unimplemented")
+}
+
 // GetEventsByType implements Repository.
 func (repo *mockRepository) GetEventsByType(eventType string, pageSize int64) ([]*models.Event, error) {
 	panic("unimplemented")
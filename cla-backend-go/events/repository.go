@@ -38,6 +38,7 @@ import (
 var (
 	ErrUserIDRequired    = errors.New("UserID cannot be empty")    //nolint
 	ErrEventTypeRequired = errors.New("EventType cannot be empty") //nolint
+	ErrEventNotFound     = errors.New("event not found")           //nolint
 )
 
 // indexes
@@ -63,6 +64,7 @@ const (
 // Repository interface defines methods of event repository service
 type Repository interface {
 	CreateEvent(event *models.Event) error
+	GetEvent(eventID string) (*models.Event, error)
 	AddDataToEvent(eventID, parentProjectSFID, projectSFID, projectSFName, companySFID, projectID, claGroupID string) error
 	SearchEvents(params *eventOps.SearchEventsParams, pageSize int64) (*models.EventList, error)
 	GetCCLAEvents(claGroupId, companyID, searchTerm, eventType string, pageSize int64) ([]*models.Event, error)
@@ -174,6 +176,38 @@ func (repo *repository) CreateEvent(event *models.Event) error {
 	return nil
 }
 
+// GetEvent returns the event by ID
+func (repo *repository) GetEvent(eventID string) (*models.Event, error) {
+	f := logrus.Fields{
+		"functionName": "v1.events.repository.GetEvent",
+		"eventID":      eventID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.eventsTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"event_id": {
+				S: aws.String(eventID),
+			},
+		},
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load the event by ID: %s", eventID)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrEventNotFound
+	}
+
+	var item Event
+	err = dynamodbattribute.UnmarshalMap(result.Item, &item)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error unmarshalling the event from database")
+		return nil, err
+	}
+	return item.toEvent(), nil
+}
+
 func addAttribute(item map[string]*dynamodb.AttributeValue, key string, value string) {
 	if value != "" {
 		item[key] = &dynamodb.AttributeValue{S: aws.String(value)}
@@ -0,0 +1,13 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit_reconciliation
+
+import "context"
+
+// GroupClient manages the members of the LF LDAP groups used by Gerrit, implemented by gerrits.LFGroup
+type GroupClient interface {
+	GetGroupMembers(ctx context.Context, groupName string) ([]string, error)
+	AddGroupMember(ctx context.Context, groupName, userName string) error
+	RemoveGroupMember(ctx context.Context, groupName, userName string) error
+}
@@ -0,0 +1,97 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit_reconciliation
+
+// reconciliation actions
+const (
+	ActionAdded   = "added"
+	ActionRemoved = "removed"
+)
+
+// DefaultMaxRemovals is the default limit of removals from one group in a run
+const DefaultMaxRemovals = 25
+
+// Options controls the gerrit group reconciliation
+type Options struct {
+	// AutoFix adds the missing signers to the groups and removes the members without a valid signature, only the
+	// drift is reported otherwise
+	AutoFix bool
+	// GerritIDs limits the reconciliation to these gerrit instances, all the instances are reconciled when empty
+	GerritIDs []string
+	// MaxRemovals skips the removals from a group when it has more members to remove, a sign that the signatures
+	// couldn't be loaded properly - zero disables the limit
+	MaxRemovals int
+}
+
+// Report is the drift report of a reconciliation run
+type Report struct {
+	Groups []*GroupReport
+	// Checked is the number of groups compared with the signatures
+	Checked int
+	// Drifted is the number of groups with members to add or remove
+	Drifted int
+	Added   int
+	Removed int
+	// Failed is the number of groups which couldn't be checked plus the number of failed changes
+	Failed int
+}
+
+// GroupReport is the drift of one ICLA or CCLA LDAP group of a gerrit instance
+type GroupReport struct {
+	GerritID   string
+	GerritName string
+	CLAGroupID string
+	ClaType    string
+	GroupID    string
+	// Members is the number of group members
+	Members int
+	// Signers is the number of users with a valid signature for the group CLA type
+	Signers int
+	// Unauthorized lists the members without a valid signature
+	Unauthorized []string
+	// Missing lists the signers who are not members of the group
+	Missing []string
+	// SignersWithoutLFUsername is the number of signers who can't be members because they have no LF username
+	SignersWithoutLFUsername int
+	// Unresolved lists the user IDs of the signers whose user record couldn't be loaded, their LF username is
+	// unknown so the members without a valid signature are not removed
+	Unresolved []string
+	Added      []string
+	Removed    []string
+	Failed     []string
+	// RemovalsSkipped is true when the removals exceeded the maximum or signers were unresolved, the removals were
+	// not applied
+	RemovalsSkipped bool
+	Error           string
+}
+
+// HasDrift returns true when the group has members to add or remove
+func (g *GroupReport) HasDrift() bool {
+	return len(g.Unauthorized) > 0 || len(g.Missing) > 0
+}
+
+// signers is the set of users with a valid signature, keyed by the lower case LF username
+type signers struct {
+	usernames       map[string]string
+	withoutUsername int
+	unresolved      []string
+}
+
+func newSigners() *signers {
+	return &signers{usernames: map[string]string{}}
+}
+
+// add adds the LF username of a signer, the signers without an LF username are only counted
+func (s *signers) add(lfUsername string) {
+	if lfUsername == "" {
+		s.withoutUsername++
+		return
+	}
+	s.usernames[normalize(lfUsername)] = lfUsername
+}
+
+// addUnresolved records a signer whose user record couldn't be loaded
+func (s *signers) addUnresolved(userID string) {
+	s.unresolved = append(s.unresolved, userID)
+}
@@ -0,0 +1,356 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit_reconciliation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/linuxfoundation/easycla/cla-backend-go/approval_rules"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	signatureParams "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// systemUsername is the user of the events which are not about a specific user
+const systemUsername = "easycla system"
+
+// Service reconciles the ICLA and CCLA LDAP groups of the gerrit instances with the signatures
+type Service interface {
+	// Reconcile compares the members of every gerrit group with the users who have a valid signature, reports the
+	// drift and optionally fixes it
+	Reconcile(ctx context.Context, options Options) (*Report, error)
+}
+
+type service struct {
+	gerritRepo     gerrits.Repository
+	signatureRepo  signatures.SignatureRepository
+	usersRepo      users.UserRepository
+	groups         GroupClient
+	approvalEngine approval_rules.Engine
+	eventsService  events.Service
+}
+
+// NewService creates the gerrit group reconciliation service
+func NewService(gerritRepo gerrits.Repository, signatureRepo signatures.SignatureRepository, usersRepo users.UserRepository, groups GroupClient, approvalEngine approval_rules.Engine, eventsService events.Service) Service {
+	return &service{
+		gerritRepo:     gerritRepo,
+		signatureRepo:  signatureRepo,
+		usersRepo:      usersRepo,
+		groups:         groups,
+		approvalEngine: approvalEngine,
+		eventsService:  eventsService,
+	}
+}
+
+// Reconcile compares the groups of the gerrit instances with the signatures of their CLA groups
+func (s *service) Reconcile(ctx context.Context, options Options) (*Report, error) {
+	f := logrus.Fields{
+		"functionName":   "gerrit_reconciliation.service.Reconcile",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"autoFix":        options.AutoFix,
+		"gerritIDs":      strings.Join(options.GerritIDs, ","),
+		"maxRemovals":    options.MaxRemovals,
+	}
+
+	gerritList, err := s.gerritRepo.GetGerrits(ctx)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the gerrit instances")
+		return nil, err
+	}
+
+	report := &Report{}
+	// the signers are loaded once per CLA group and CLA type, several gerrit instances can share a CLA group
+	signersCache := map[string]*signers{}
+	for _, gerrit := range gerritList.List {
+		if len(options.GerritIDs) > 0 && !utils.StringInSlice(gerrit.GerritID.String(), options.GerritIDs) {
+			continue
+		}
+		for _, group := range []struct{ claType, groupID string }{
+			{utils.ClaTypeICLA, gerrit.GroupIDIcla},
+			{utils.ClaTypeCCLA, gerrit.GroupIDCcla},
+		} {
+			if group.groupID == "" {
+				continue
+			}
+			groupReport := &GroupReport{
+				GerritID:   gerrit.GerritID.String(),
+				GerritName: gerrit.GerritName,
+				CLAGroupID: gerrit.ProjectID,
+				ClaType:    group.claType,
+				GroupID:    group.groupID,
+			}
+			report.Groups = append(report.Groups, groupReport)
+
+			cacheKey := fmt.Sprintf("%s#%s", gerrit.ProjectID, group.claType)
+			groupSigners, ok := signersCache[cacheKey]
+			if !ok {
+				groupSigners, err = s.loadSigners(ctx, gerrit.ProjectID, group.claType)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warnf("unable to load the %s signers of the CLA group: %s", group.claType, gerrit.ProjectID)
+					groupReport.Error = err.Error()
+					report.Failed++
+					continue
+				}
+				signersCache[cacheKey] = groupSigners
+			}
+
+			if err := s.reconcileGroup(ctx, groupReport, groupSigners, options); err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to reconcile the %s group: %s of the gerrit instance: %s", group.claType, group.groupID, gerrit.GerritName)
+				groupReport.Error = err.Error()
+				report.Failed++
+				continue
+			}
+			report.Checked++
+			if groupReport.HasDrift() {
+				report.Drifted++
+			}
+			report.Added += len(groupReport.Added)
+			report.Removed += len(groupReport.Removed)
+			report.Failed += len(groupReport.Failed)
+		}
+	}
+
+	log.WithFields(f).Debugf("gerrit group reconciliation result - checked: %d, drifted: %d, added: %d, removed: %d, failed: %d",
+		report.Checked, report.Drifted, report.Added, report.Removed, report.Failed)
+	return report, nil
+}
+
+// reconcileGroup compares the group members with the signers and applies the changes when auto fix is enabled
+func (s *service) reconcileGroup(ctx context.Context, groupReport *GroupReport, groupSigners *signers, options Options) error {
+	f := logrus.Fields{
+		"functionName":   "gerrit_reconciliation.service.reconcileGroup",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"gerritName":     groupReport.GerritName,
+		"claGroupID":     groupReport.CLAGroupID,
+		"claType":        groupReport.ClaType,
+		"groupID":        groupReport.GroupID,
+	}
+
+	members, err := s.groups.GetGroupMembers(ctx, groupReport.GroupID)
+	if err != nil {
+		return err
+	}
+
+	memberSet := map[string]bool{}
+	for _, member := range members {
+		memberSet[normalize(member)] = true
+		if _, ok := groupSigners.usernames[normalize(member)]; !ok {
+			groupReport.Unauthorized = append(groupReport.Unauthorized, member)
+		}
+	}
+	for key, lfUsername := range groupSigners.usernames {
+		if !memberSet[key] {
+			groupReport.Missing = append(groupReport.Missing, lfUsername)
+		}
+	}
+	sort.Strings(groupReport.Unauthorized)
+	sort.Strings(groupReport.Missing)
+	groupReport.Members = len(memberSet)
+	groupReport.Signers = len(groupSigners.usernames)
+	groupReport.SignersWithoutLFUsername = groupSigners.withoutUsername
+	groupReport.Unresolved = append([]string{}, groupSigners.unresolved...)
+	sort.Strings(groupReport.Unresolved)
+
+	if !groupReport.HasDrift() {
+		return nil
+	}
+	log.WithFields(f).Debugf("the group has %d members without a valid signature and %d signers missing from the group",
+		len(groupReport.Unauthorized), len(groupReport.Missing))
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.GerritGroupDriftDetected,
+		LfUsername: systemUsername,
+		CLAGroupID: groupReport.CLAGroupID,
+		ProjectID:  groupReport.CLAGroupID,
+		EventData: &events.GerritGroupDriftEventData{
+			GerritName:   groupReport.GerritName,
+			GroupName:    groupReport.GroupID,
+			ClaType:      groupReport.ClaType,
+			Unauthorized: len(groupReport.Unauthorized),
+			Missing:      len(groupReport.Missing),
+		},
+	})
+
+	if !options.AutoFix {
+		return nil
+	}
+
+	for _, lfUsername := range groupReport.Missing {
+		if err := s.groups.AddGroupMember(ctx, groupReport.GroupID, lfUsername); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to add the user: %s to the group", lfUsername)
+			groupReport.Failed = append(groupReport.Failed, lfUsername)
+			continue
+		}
+		groupReport.Added = append(groupReport.Added, lfUsername)
+		s.logMemberReconciled(ctx, groupReport, lfUsername, ActionAdded)
+	}
+
+	if len(groupReport.Unresolved) > 0 {
+		// a member reported without a valid signature may be one of the unresolved signers
+		log.WithFields(f).Warnf("skipping the removal of %d members from the group, %d signers couldn't be resolved",
+			len(groupReport.Unauthorized), len(groupReport.Unresolved))
+		groupReport.RemovalsSkipped = true
+		return nil
+	}
+	if options.MaxRemovals > 0 && len(groupReport.Unauthorized) > options.MaxRemovals {
+		log.WithFields(f).Warnf("skipping the removal of %d members from the group, more than the maximum of %d",
+			len(groupReport.Unauthorized), options.MaxRemovals)
+		groupReport.RemovalsSkipped = true
+		return nil
+	}
+	for _, lfUsername := range groupReport.Unauthorized {
+		if err := s.groups.RemoveGroupMember(ctx, groupReport.GroupID, lfUsername); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to remove the user: %s from the group", lfUsername)
+			groupReport.Failed = append(groupReport.Failed, lfUsername)
+			continue
+		}
+		groupReport.Removed = append(groupReport.Removed, lfUsername)
+		s.logMemberReconciled(ctx, groupReport, lfUsername, ActionRemoved)
+	}
+
+	return nil
+}
+
+// logMemberReconciled logs the event of a member added to or removed from a group
+func (s *service) logMemberReconciled(ctx context.Context, groupReport *GroupReport, lfUsername, action string) {
+	reason := fmt.Sprintf("the user has a valid %s signature", strings.ToUpper(groupReport.ClaType))
+	if action == ActionRemoved {
+		reason = fmt.Sprintf("the user has no valid %s signature", strings.ToUpper(groupReport.ClaType))
+	}
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.GerritGroupMemberReconciled,
+		LfUsername: lfUsername,
+		CLAGroupID: groupReport.CLAGroupID,
+		ProjectID:  groupReport.CLAGroupID,
+		EventData: &events.GerritGroupMemberReconciledEventData{
+			GerritName: groupReport.GerritName,
+			GroupName:  groupReport.GroupID,
+			ClaType:    groupReport.ClaType,
+			Username:   lfUsername,
+			Action:     action,
+			Reason:     reason,
+		},
+	})
+}
+
+// loadSigners returns the users with a valid signature of the CLA type
+func (s *service) loadSigners(ctx context.Context, claGroupID, claType string) (*signers, error) {
+	if claType == utils.ClaTypeICLA {
+		return s.loadICLASigners(ctx, claGroupID)
+	}
+	return s.loadCCLASigners(ctx, claGroupID)
+}
+
+// loadICLASigners returns the users with a signed and approved ICLA, the LF username is taken from the user record
+// of the signature, the signers whose user can't be loaded are recorded as unresolved
+func (s *service) loadICLASigners(ctx context.Context, claGroupID string) (*signers, error) {
+	f := logrus.Fields{
+		"functionName":   "gerrit_reconciliation.service.loadICLASigners",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	result := newSigners()
+	params := signatureParams.GetProjectSignaturesParams{
+		ProjectID: claGroupID,
+		ClaType:   aws.String(utils.ClaTypeICLA),
+		Approved:  aws.Bool(true),
+		Signed:    aws.Bool(true),
+		PageSize:  aws.Int64(signatures.HugePageSize),
+	}
+	for {
+		page, err := s.signatureRepo.GetProjectSignatures(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, signature := range page.Signatures {
+			userModel, err := s.usersRepo.GetUser(signature.SignatureReferenceID)
+			if err != nil || userModel == nil {
+				log.WithFields(f).WithError(err).Warnf("unable to load the user: %s of the signature: %s",
+					signature.SignatureReferenceID, signature.SignatureID)
+				result.addUnresolved(signature.SignatureReferenceID)
+				continue
+			}
+			result.add(userModel.LfUsername)
+		}
+		if page.LastKeyScanned == "" {
+			break
+		}
+		params.NextKey = aws.String(page.LastKeyScanned)
+	}
+	return result, nil
+}
+
+// loadCCLASigners returns the employees who acknowledged the CCLA of a company and are still on its approval lists
+func (s *service) loadCCLASigners(ctx context.Context, claGroupID string) (*signers, error) {
+	f := logrus.Fields{
+		"functionName":   "gerrit_reconciliation.service.loadCCLASigners",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	companies, err := s.signatureRepo.GetCompanyIDsWithSignedCorporateSignatures(ctx, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newSigners()
+	for _, company := range companies {
+		cclaSignature, err := s.signatureRepo.GetCorporateSignature(ctx, claGroupID, company.CompanyID, aws.Bool(true), aws.Bool(true))
+		if err != nil {
+			return nil, err
+		}
+		if cclaSignature == nil {
+			continue
+		}
+
+		employeeSignatures, err := s.signatureRepo.GetProjectCompanyEmployeeSignatures(ctx, signatureParams.GetProjectCompanyEmployeeSignaturesParams{
+			ProjectID: claGroupID,
+			CompanyID: company.CompanyID,
+			PageSize:  aws.Int64(signatures.HugePageSize),
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		approvalLists := signatures.NewApprovalLists(cclaSignature)
+		for _, employeeSignature := range employeeSignatures.Signatures {
+			if !employeeSignature.SignatureSigned || !employeeSignature.SignatureApproved {
+				continue
+			}
+			userModel, err := s.usersRepo.GetUser(employeeSignature.SignatureReferenceID)
+			if err != nil || userModel == nil {
+				log.WithFields(f).WithError(err).Warnf("unable to load the user: %s of the employee signature: %s",
+					employeeSignature.SignatureReferenceID, employeeSignature.SignatureID)
+				result.addUnresolved(employeeSignature.SignatureReferenceID)
+				continue
+			}
+			if !s.isApproved(ctx, userModel, approvalLists) {
+				continue
+			}
+			result.add(userModel.LfUsername)
+		}
+	}
+	return result, nil
+}
+
+// isApproved returns true when the employee is still on the approval lists of the company CCLA
+func (s *service) isApproved(ctx context.Context, userModel *models.User, approvalLists *approval_rules.ApprovalLists) bool {
+	decision := s.approvalEngine.Evaluate(ctx, signatures.NewApprovalActor(userModel), approvalLists)
+	return decision != nil && decision.Approved
+}
+
+// normalize returns the key of an LF username, the usernames are case insensitive
+func normalize(lfUsername string) string {
+	return strings.ToLower(strings.TrimSpace(lfUsername))
+}
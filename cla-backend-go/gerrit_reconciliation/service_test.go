@@ -0,0 +1,300 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit_reconciliation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/approval_rules"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	mock_events "github.com/linuxfoundation/easycla/cla-backend-go/events/mock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	mock_gerrit_reconciliation "github.com/linuxfoundation/easycla/cla-backend-go/gerrit_reconciliation/mock"
+	mock_gerrits "github.com/linuxfoundation/easycla/cla-backend-go/gerrits/mocks"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	mock_signatures "github.com/linuxfoundation/easycla/cla-backend-go/signatures/mocks"
+	mock_users "github.com/linuxfoundation/easycla/cla-backend-go/users/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testCLAGroupID = "cla-group-id"
+	testCompanyID  = "company-id"
+	iclaGroupID    = "1901"
+	cclaGroupID    = "1902"
+)
+
+// the gerrit instance has the ICLA signers alice and bob and a company approving the employee carol, the ICLA
+// signatures have no user LF ID, the LF usernames come from the user records
+var (
+	alice = &models.User{UserID: "alice-id", LfUsername: "alice"}
+	bob   = &models.User{UserID: "bob-id", LfUsername: "bob"}
+	nolf  = &models.User{UserID: "nolf-id"}
+	carol = &models.User{UserID: "carol-id", LfUsername: "carol", Emails: []string{"carol@example.com"}}
+	dave  = &models.User{UserID: "dave-id", LfUsername: "dave", Emails: []string{"dave@example.com"}}
+)
+
+func expectSignatures(gerritRepo *mock_gerrits.MockRepository, signatureRepo *mock_signatures.MockSignatureRepository) {
+	gerritRepo.EXPECT().GetGerrits(gomock.Any()).Return(&models.GerritList{List: []*models.Gerrit{
+		{GerritID: "gerrit-id", GerritName: "ONAP", ProjectID: testCLAGroupID, GroupIDIcla: iclaGroupID, GroupIDCcla: cclaGroupID},
+		{GerritID: "no-groups", GerritName: "Other", ProjectID: testCLAGroupID},
+	}}, nil)
+
+	signatureRepo.EXPECT().GetProjectSignatures(gomock.Any(), gomock.Any()).Return(&models.Signatures{Signatures: []*models.Signature{
+		{SignatureID: "s1", SignatureReferenceID: "alice-id"},
+		{SignatureID: "s2", SignatureReferenceID: "bob-id"},
+		{SignatureID: "s3", SignatureReferenceID: "nolf-id"},
+	}}, nil)
+	signatureRepo.EXPECT().GetCompanyIDsWithSignedCorporateSignatures(gomock.Any(), testCLAGroupID).
+		Return([]signatures.SignatureCompanyID{{CompanyID: testCompanyID}}, nil)
+	signatureRepo.EXPECT().GetCorporateSignature(gomock.Any(), testCLAGroupID, testCompanyID, aws.Bool(true), aws.Bool(true)).
+		Return(&models.Signature{SignatureID: "ccla", EmailApprovalList: []string{"carol@example.com"}}, nil)
+	// dave is no longer on the approval list, the user of the unapproved signature of erin isn't loaded
+	signatureRepo.EXPECT().GetProjectCompanyEmployeeSignatures(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Signatures{Signatures: []*models.Signature{
+		{SignatureID: "e1", SignatureReferenceID: "carol-id", SignatureSigned: true, SignatureApproved: true},
+		{SignatureID: "e2", SignatureReferenceID: "dave-id", SignatureSigned: true, SignatureApproved: true},
+		{SignatureID: "e3", SignatureReferenceID: "erin-id", SignatureSigned: true, SignatureApproved: false},
+	}}, nil)
+}
+
+func expectUsers(usersRepo *mock_users.MockUserRepository, userModels ...*models.User) {
+	for _, userModel := range userModels {
+		usersRepo.EXPECT().GetUser(userModel.UserID).Return(userModel, nil)
+	}
+}
+
+func countEvents(logged []*events.LogEventArgs, eventType string) int {
+	count := 0
+	for _, args := range logged {
+		if args.EventType == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+func findGroup(report *Report, claType string) *GroupReport {
+	for _, group := range report.Groups {
+		if group.ClaType == claType {
+			return group
+		}
+	}
+	return nil
+}
+
+func TestReconcileReportsDrift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gerritRepo := mock_gerrits.NewMockRepository(ctrl)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	expectSignatures(gerritRepo, signatureRepo)
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	expectUsers(usersRepo, alice, bob, nolf, carol, dave)
+
+	groups := mock_gerrit_reconciliation.NewMockGroupClient(ctrl)
+	groups.EXPECT().GetGroupMembers(gomock.Any(), iclaGroupID).Return([]string{"Alice", "mallory"}, nil)
+	groups.EXPECT().GetGroupMembers(gomock.Any(), cclaGroupID).Return([]string{"dave"}, nil)
+
+	var logged []*events.LogEventArgs
+	eventsService := mock_events.NewMockService(ctrl)
+	eventsService.EXPECT().LogEventWithContext(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, args *events.LogEventArgs) {
+		logged = append(logged, args)
+	}).Times(2)
+
+	s := NewService(gerritRepo, signatureRepo, usersRepo, groups, approval_rules.NewEngine(approval_rules.NewDefaultRegistry(approval_rules.Checkers{})), eventsService)
+
+	// the drift is only reported, the mock fails on any group change
+	report, err := s.Reconcile(context.Background(), Options{})
+	assert.NoError(t, err)
+	assert.Len(t, report.Groups, 2)
+	assert.Equal(t, 2, report.Checked)
+	assert.Equal(t, 2, report.Drifted)
+
+	icla := findGroup(report, "icla")
+	assert.Equal(t, []string{"mallory"}, icla.Unauthorized)
+	assert.Equal(t, []string{"bob"}, icla.Missing)
+	assert.Equal(t, 2, icla.Signers)
+	assert.Equal(t, 1, icla.SignersWithoutLFUsername)
+	assert.Empty(t, icla.Unresolved)
+
+	ccla := findGroup(report, "ccla")
+	assert.Equal(t, []string{"dave"}, ccla.Unauthorized)
+	assert.Equal(t, []string{"carol"}, ccla.Missing)
+
+	assert.Equal(t, 2, countEvents(logged, events.GerritGroupDriftDetected))
+}
+
+func TestReconcileResolvesICLASignersWithoutUserLFID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gerritRepo := mock_gerrits.NewMockRepository(ctrl)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	expectSignatures(gerritRepo, signatureRepo)
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	expectUsers(usersRepo, alice, bob, nolf, carol, dave)
+
+	groups := mock_gerrit_reconciliation.NewMockGroupClient(ctrl)
+	groups.EXPECT().GetGroupMembers(gomock.Any(), iclaGroupID).Return([]string{"alice", "bob"}, nil)
+	groups.EXPECT().GetGroupMembers(gomock.Any(), cclaGroupID).Return([]string{"dave"}, nil)
+
+	var logged []*events.LogEventArgs
+	eventsService := mock_events.NewMockService(ctrl)
+	eventsService.EXPECT().LogEventWithContext(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, args *events.LogEventArgs) {
+		logged = append(logged, args)
+	}).Times(1)
+
+	s := NewService(gerritRepo, signatureRepo, usersRepo, groups, approval_rules.NewEngine(approval_rules.NewDefaultRegistry(approval_rules.Checkers{})), eventsService)
+
+	report, err := s.Reconcile(context.Background(), Options{})
+	assert.NoError(t, err)
+
+	// the signatures have no user LF ID, the members are matched through the user records
+	icla := findGroup(report, "icla")
+	assert.Empty(t, icla.Unauthorized)
+	assert.Empty(t, icla.Missing)
+	assert.Equal(t, 2, icla.Signers)
+	assert.False(t, icla.HasDrift())
+	assert.Equal(t, 1, countEvents(logged, events.GerritGroupDriftDetected))
+}
+
+func TestReconcileAutoFix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gerritRepo := mock_gerrits.NewMockRepository(ctrl)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	expectSignatures(gerritRepo, signatureRepo)
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	expectUsers(usersRepo, alice, bob, nolf, carol, dave)
+
+	groups := mock_gerrit_reconciliation.NewMockGroupClient(ctrl)
+	groups.EXPECT().GetGroupMembers(gomock.Any(), iclaGroupID).Return([]string{"Alice", "mallory"}, nil)
+	groups.EXPECT().GetGroupMembers(gomock.Any(), cclaGroupID).Return([]string{"dave"}, nil)
+
+	var logged []*events.LogEventArgs
+	eventsService := mock_events.NewMockService(ctrl)
+	eventsService.EXPECT().LogEventWithContext(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, args *events.LogEventArgs) {
+		logged = append(logged, args)
+	}).Times(5)
+
+	s := NewService(gerritRepo, signatureRepo, usersRepo, groups, approval_rules.NewEngine(approval_rules.NewDefaultRegistry(approval_rules.Checkers{})), eventsService)
+	groups.EXPECT().AddGroupMember(gomock.Any(), iclaGroupID, "bob").Return(nil)
+	groups.EXPECT().RemoveGroupMember(gomock.Any(), iclaGroupID, "mallory").Return(nil)
+	groups.EXPECT().AddGroupMember(gomock.Any(), cclaGroupID, "carol").Return(errors.New("group API error"))
+	groups.EXPECT().RemoveGroupMember(gomock.Any(), cclaGroupID, "dave").Return(nil)
+
+	report, err := s.Reconcile(context.Background(), Options{AutoFix: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bob"}, findGroup(report, "icla").Added)
+	assert.Equal(t, []string{"mallory"}, findGroup(report, "icla").Removed)
+	assert.Empty(t, findGroup(report, "ccla").Added)
+	assert.Equal(t, []string{"dave"}, findGroup(report, "ccla").Removed)
+	assert.Equal(t, []string{"carol"}, findGroup(report, "ccla").Failed)
+	assert.Equal(t, 1, report.Added)
+	assert.Equal(t, 2, report.Removed)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 3, countEvents(logged, events.GerritGroupMemberReconciled))
+}
+
+func TestReconcileUnresolvedSignersSkipRemovals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gerritRepo := mock_gerrits.NewMockRepository(ctrl)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	expectSignatures(gerritRepo, signatureRepo)
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	// the user record of alice can't be loaded, the member alice isn't removed as unauthorized
+	usersRepo.EXPECT().GetUser("alice-id").Return(nil, errors.New("user table error"))
+	expectUsers(usersRepo, bob, nolf, carol, dave)
+
+	groups := mock_gerrit_reconciliation.NewMockGroupClient(ctrl)
+	groups.EXPECT().GetGroupMembers(gomock.Any(), iclaGroupID).Return([]string{"Alice", "mallory"}, nil)
+	groups.EXPECT().GetGroupMembers(gomock.Any(), cclaGroupID).Return([]string{"dave"}, nil)
+
+	var logged []*events.LogEventArgs
+	eventsService := mock_events.NewMockService(ctrl)
+	eventsService.EXPECT().LogEventWithContext(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, args *events.LogEventArgs) {
+		logged = append(logged, args)
+	}).Times(5)
+
+	s := NewService(gerritRepo, signatureRepo, usersRepo, groups, approval_rules.NewEngine(approval_rules.NewDefaultRegistry(approval_rules.Checkers{})), eventsService)
+	groups.EXPECT().AddGroupMember(gomock.Any(), iclaGroupID, "bob").Return(nil)
+	groups.EXPECT().AddGroupMember(gomock.Any(), cclaGroupID, "carol").Return(nil)
+	groups.EXPECT().RemoveGroupMember(gomock.Any(), cclaGroupID, "dave").Return(nil)
+
+	report, err := s.Reconcile(context.Background(), Options{AutoFix: true})
+	assert.NoError(t, err)
+	icla := findGroup(report, "icla")
+	assert.Equal(t, []string{"alice-id"}, icla.Unresolved)
+	assert.Equal(t, []string{"Alice", "mallory"}, icla.Unauthorized)
+	assert.True(t, icla.RemovalsSkipped)
+	assert.Empty(t, icla.Removed)
+	assert.Equal(t, []string{"bob"}, icla.Added)
+	assert.False(t, findGroup(report, "ccla").RemovalsSkipped)
+}
+
+func TestReconcileMaxRemovals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gerritRepo := mock_gerrits.NewMockRepository(ctrl)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	expectSignatures(gerritRepo, signatureRepo)
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	expectUsers(usersRepo, alice, bob, nolf, carol, dave)
+
+	groups := mock_gerrit_reconciliation.NewMockGroupClient(ctrl)
+	groups.EXPECT().GetGroupMembers(gomock.Any(), iclaGroupID).Return([]string{"alice", "mallory", "oscar"}, nil)
+	groups.EXPECT().GetGroupMembers(gomock.Any(), cclaGroupID).Return([]string{"dave"}, nil)
+
+	var logged []*events.LogEventArgs
+	eventsService := mock_events.NewMockService(ctrl)
+	eventsService.EXPECT().LogEventWithContext(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, args *events.LogEventArgs) {
+		logged = append(logged, args)
+	}).Times(5)
+
+	s := NewService(gerritRepo, signatureRepo, usersRepo, groups, approval_rules.NewEngine(approval_rules.NewDefaultRegistry(approval_rules.Checkers{})), eventsService)
+	groups.EXPECT().AddGroupMember(gomock.Any(), iclaGroupID, "bob").Return(nil)
+	groups.EXPECT().AddGroupMember(gomock.Any(), cclaGroupID, "carol").Return(nil)
+	// one removal is within the limit
+	groups.EXPECT().RemoveGroupMember(gomock.Any(), cclaGroupID, "dave").Return(nil)
+
+	report, err := s.Reconcile(context.Background(), Options{AutoFix: true, MaxRemovals: 1, GerritIDs: []string{"gerrit-id"}})
+	assert.NoError(t, err)
+	assert.True(t, findGroup(report, "icla").RemovalsSkipped)
+	assert.Empty(t, findGroup(report, "icla").Removed)
+	assert.False(t, findGroup(report, "ccla").RemovalsSkipped)
+}
+
+func TestReconcileNoDrift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gerritRepo := mock_gerrits.NewMockRepository(ctrl)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	expectSignatures(gerritRepo, signatureRepo)
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	expectUsers(usersRepo, alice, bob, nolf, carol, dave)
+
+	groups := mock_gerrit_reconciliation.NewMockGroupClient(ctrl)
+	groups.EXPECT().GetGroupMembers(gomock.Any(), iclaGroupID).Return([]string{"ALICE", "bob"}, nil)
+	groups.EXPECT().GetGroupMembers(gomock.Any(), cclaGroupID).Return([]string{"carol"}, nil)
+
+	// there is no drift, the mock fails on any event
+	eventsService := mock_events.NewMockService(ctrl)
+
+	s := NewService(gerritRepo, signatureRepo, usersRepo, groups, approval_rules.NewEngine(approval_rules.NewDefaultRegistry(approval_rules.Checkers{})), eventsService)
+
+	report, err := s.Reconcile(context.Background(), Options{AutoFix: true, GerritIDs: []string{"gerrit-id"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Checked)
+	assert.Equal(t, 0, report.Drifted)
+}
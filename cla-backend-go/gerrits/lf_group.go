@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
@@ -230,6 +231,134 @@ func (lfg *LFGroup) GetUsersOfGroup(ctx context.Context, authUser *auth.User, cl
 	return nil, nil
 }
 
+// IsMember returns true if the specified LF username is a member of the group - used by the approval rule engine
+func (lfg *LFGroup) IsMember(ctx context.Context, groupName, userName string) (bool, error) {
+	members, err := lfg.GetGroupMembers(ctx, groupName)
+	if err != nil {
+		return false, err
+	}
+
+	for _, member := range members {
+		if strings.EqualFold(member, userName) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetGroupMembers returns the LF usernames of the group members
+func (lfg *LFGroup) GetGroupMembers(ctx context.Context, groupName string) ([]string, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.gerrits.lf_group.GetGroupMembers",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"groupName":      groupName,
+	}
+
+	body, err := lfg.groupRequest(ctx, http.MethodGet, groupName, nil, LongHTTPTimeout)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem fetching the group members")
+		return nil, err
+	}
+
+	var result v2Models.GerritGroupResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem unmarshalling the members of group: %s", groupName)
+		return nil, err
+	}
+
+	members := make([]string, 0, len(result.Members))
+	for _, member := range result.Members {
+		if member != nil && member.Username != "" {
+			members = append(members, member.Username)
+		}
+	}
+
+	return members, nil
+}
+
+// AddGroupMember adds the user to the group, unlike AddUserToGroup an unsuccessful response is returned as an error
+// and no event is logged
+func (lfg *LFGroup) AddGroupMember(ctx context.Context, groupName, userName string) error {
+	_, err := lfg.groupRequest(ctx, http.MethodPut, groupName, map[string]interface{}{"username": userName}, DefaultHTTPTimeout)
+	return err
+}
+
+// RemoveGroupMember removes the user from the group, unlike RemoveUserFromGroup an unsuccessful response is returned
+// as an error and no event is logged
+func (lfg *LFGroup) RemoveGroupMember(ctx context.Context, groupName, userName string) error {
+	_, err := lfg.groupRequest(ctx, http.MethodDelete, groupName, map[string]interface{}{"username": userName}, DefaultHTTPTimeout)
+	return err
+}
+
+// groupRequest invokes the group API with the optional payload and returns the response body, an unsuccessful
+// response status is returned as an error
+func (lfg *LFGroup) groupRequest(ctx context.Context, method, groupName string, payload interface{}, timeout time.Duration) ([]byte, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.gerrits.lf_group.groupRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"method":         method,
+		"groupName":      groupName,
+	}
+
+	// Fetch a token for authorization
+	accessToken, err := lfg.getAccessToken(ctx)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading access token")
+		return nil, err
+	}
+
+	// Build the URL path - can take the groupName or numeric value
+	url := fmt.Sprintf("%s/rest/auth0/og/%s", lfg.LfBaseURL, groupName)
+
+	var requestBody io.Reader
+	if payload != nil {
+		payloadBytes, marshalErr := json.Marshal(payload)
+		if marshalErr != nil {
+			log.WithFields(f).WithError(marshalErr).Warnf("unable to encode payload for the request to URL: %s", url)
+			return nil, marshalErr
+		}
+		requestBody = bytes.NewBuffer(payloadBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, requestBody)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem creating a new request to URL: %s", url)
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Authorization", "Bearer "+accessToken)
+	client := http.Client{
+		Timeout: timeout,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem invoking request to URL: %s", url)
+		return nil, err
+	}
+
+	defer func() {
+		closeErr := resp.Body.Close()
+		if closeErr != nil {
+			log.WithFields(f).WithError(closeErr).Warn("error closing response body")
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem reading response for url: %s", url)
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("error invoking %s on group: %s - response status: %d", method, groupName, resp.StatusCode)
+	}
+
+	return body, nil
+}
+
 // AddUserToGroup adds the specified user to the group
 func (lfg *LFGroup) AddUserToGroup(ctx context.Context, authUser *auth.User, claGroupID, groupName, userName string) error {
 	f := logrus.Fields{
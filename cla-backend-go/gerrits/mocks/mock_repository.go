@@ -112,6 +112,21 @@ func (mr *MockRepositoryMockRecorder) GetGerrit(ctx, gerritID interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGerrit", reflect.TypeOf((*MockRepository)(nil).GetGerrit), ctx, gerritID)
 }
 
+// GetGerrits mocks base method.
+func (m *MockRepository) GetGerrits(ctx context.Context) (*models.GerritList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGerrits", ctx)
+	ret0, _ := ret[0].(*models.GerritList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGerrits indicates an expected call of GetGerrits.
+func (mr *MockRepositoryMockRecorder) GetGerrits(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGerrits", reflect.TypeOf((*MockRepository)(nil).GetGerrits), ctx)
+}
+
 // GetGerritsByID mocks base method.
 func (m *MockRepository) GetGerritsByID(ctx context.Context, ID, IDType string) (*models.GerritList, error) {
 	m.ctrl.T.Helper()
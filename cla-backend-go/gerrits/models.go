@@ -33,6 +33,7 @@ func (g *Gerrit) toModel() *models.Gerrit {
 		GerritName:   g.GerritName,
 		GerritURL:    strfmt.URI(g.GerritURL),
 		GroupIDCcla:  g.GroupIDCcla,
+		GroupIDIcla:  g.GroupIDIcla,
 		ProjectID:    g.ProjectID,
 		Version:      g.Version,
 		ProjectSFID:  g.ProjectSFID,
@@ -41,6 +41,7 @@ type Repository interface {
 	GetGerritsByProjectSFID(ctx context.Context, projectSFID string) (*models.GerritList, error)
 	GetClaGroupGerrits(ctx context.Context, claGroupID string) (*models.GerritList, error)
 	ExistsByName(ctx context.Context, gerritName string) ([]*models.Gerrit, error)
+	GetGerrits(ctx context.Context) (*models.GerritList, error)
 	DeleteGerrit(ctx context.Context, gerritID string) error
 }
 
@@ -196,6 +197,48 @@ func (repo repo) GetGerritsByID(ctx context.Context, ID string, IDType string) (
 	return &models.GerritList{List: resultList}, nil
 }
 
+// GetGerrits returns all the gerrit instances
+func (repo repo) GetGerrits(ctx context.Context) (*models.GerritList, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.gerrits.repository.GetGerrits",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		TableName: aws.String(repo.tableName),
+	}
+
+	resultList := make([]*models.Gerrit, 0)
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.WithFields(f).Warnf("error retrieving gerrit instances, error: %v", err)
+			return nil, err
+		}
+
+		var gerrits []*Gerrit
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &gerrits)
+		if err != nil {
+			log.WithFields(f).Warnf("error unmarshalling gerrit from database. error: %v", err)
+			return nil, err
+		}
+
+		for _, g := range gerrits {
+			resultList = append(resultList, g.toModel())
+		}
+
+		if len(results.LastEvaluatedKey) != 0 {
+			scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+	sort.Slice(resultList, func(i, j int) bool {
+		return resultList[i].GerritName < resultList[j].GerritName
+	})
+	return &models.GerritList{List: resultList}, nil
+}
+
 func (repo repo) GetGerritsByProjectSFID(ctx context.Context, projectSFID string) (*models.GerritList, error) {
 	f := logrus.Fields{
 		"functionName":   "v1.gerrits.repository.GetGerritsByProjectSFID",
@@ -5,90 +5,16 @@ package github
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 
+	"github.com/linuxfoundation/easycla/cla-backend-go/bot_allowlist"
 	"github.com/linuxfoundation/easycla/cla-backend-go/events"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 	"github.com/sirupsen/logrus"
 )
 
-// propertyMatches returns true if value matches the pattern.
-// - "*" matches anything
-// - "" matches empty value
-// - "re:..." matches regex (value must be non-empty)
-// - otherwise, exact match
-func propertyMatches(pattern, value string) bool {
-	f := logrus.Fields{
-		"functionName": "github.propertyMatches",
-		"pattern":      pattern,
-		"value":        value,
-	}
-	if pattern == "*" {
-		return true
-	}
-	if pattern == "" && value == "" {
-		return true
-	}
-	if value == "" {
-		return false
-	}
-	if strings.HasPrefix(pattern, "re:") {
-		regex := pattern[3:]
-		re, err := regexp.Compile(regex)
-		if err != nil {
-			log.WithFields(f).Debugf("Error in propertyMatches: bad regexp: %s, error: %v", regex, err)
-			return false
-		}
-		return re.MatchString(value)
-	}
-	return value == pattern
-}
-
-// stripOrg removes the organization part from the repository name.
-// If input is "org/repo", returns "repo". If no "/", returns input unchanged.
-func stripOrg(repoFull string) string {
-	idx := strings.Index(repoFull, "/")
-	if idx >= 0 && idx+1 < len(repoFull) {
-		return repoFull[idx+1:]
-	}
-	return repoFull
-}
-
-// isActorSkipped returns true if the actor should be skipped according to ANY pattern in config.
-// Each config entry is "<login_pattern>;<email_pattern>;<name_pattern>"
-// Any missing pattern defaults to "" which is special and matches missing property, null property value or empty string property value
-func isActorSkipped(actor *UserCommitSummary, config []string) bool {
-	for _, pattern := range config {
-		parts := strings.Split(pattern, ";")
-		for len(parts) < 3 {
-			parts = append(parts, "")
-		}
-		loginPattern, emailPattern, namePattern := parts[0], parts[1], parts[2]
-
-		var login, email, name string
-		if actor != nil && actor.CommitAuthor != nil {
-			if actor.CommitAuthor.Login != nil {
-				login = *actor.CommitAuthor.Login
-			}
-			if actor.CommitAuthor.Email != nil {
-				email = *actor.CommitAuthor.Email
-			}
-			if actor.CommitAuthor.Name != nil {
-				name = *actor.CommitAuthor.Name
-			}
-		}
-
-		if propertyMatches(loginPattern, login) &&
-			propertyMatches(emailPattern, email) &&
-			propertyMatches(namePattern, name) {
-			return true
-		}
-	}
-	return false
-}
-
 // actorToString converts a UserCommitSummary actor to a string representation.
 func actorToString(actor *UserCommitSummary) string {
 	const nullStr = "(null)"
@@ -111,20 +37,35 @@ func actorToString(actor *UserCommitSummary) string {
 	return fmt.Sprintf("id='%v',login='%v',username='%v',email='%v'", id, login, username, email)
 }
 
-// parseConfigPatterns takes a config string and returns a slice of pattern strings.
-// If the config starts with '[' and ends with ']', splits by '||' inside; else returns []string{config}.
-// Trims whitespace from each pattern.
-func parseConfigPatterns(config string) []string {
-	config = strings.TrimSpace(config)
-	if len(config) >= 2 && strings.HasPrefix(config, "[") && strings.HasSuffix(config, "]") {
-		inner := config[1 : len(config)-1]
-		parts := strings.Split(inner, "||")
-		for i, p := range parts {
-			parts[i] = strings.TrimSpace(p)
-		}
-		return parts
+// actorToAllowlistActor converts a UserCommitSummary actor to the properties checked by the bot allowlist
+func actorToAllowlistActor(actor *UserCommitSummary) *bot_allowlist.Actor {
+	allowlistActor := &bot_allowlist.Actor{}
+	if actor != nil && actor.CommitAuthor != nil {
+		allowlistActor.Login = utils.StringValue(actor.CommitAuthor.Login)
+		allowlistActor.Email = utils.StringValue(actor.CommitAuthor.Email)
+		allowlistActor.Name = utils.StringValue(actor.CommitAuthor.Name)
+	}
+	return allowlistActor
+}
+
+// compileSkipCLA compiles the skip_cla configuration of the organization - invalid entries are logged and ignored,
+// they are rejected when the configuration is updated through the bot allowlist API
+func compileSkipCLA(f logrus.Fields, skipCLA map[string]string) *bot_allowlist.Allowlist {
+	allowlist, err := bot_allowlist.CompileSkipCLA(skipCLA)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid skip_cla entries found, ignoring them")
 	}
-	return []string{config}
+	return allowlist
+}
+
+// stripOrg removes the organization part from the repository name.
+// If input is "org/repo", returns "repo". If no "/", returns input unchanged.
+func stripOrg(repoFull string) string {
+	idx := strings.Index(repoFull, "/")
+	if idx >= 0 && idx+1 < len(repoFull) {
+		return repoFull[idx+1:]
+	}
+	return repoFull
 }
 
 // SkipAllowlistedBots- check if the actors are allowlisted based on the skip_cla configuration.
@@ -154,6 +95,8 @@ func parseConfigPatterns(config string) []string {
 //     The login, email and name patterns are separated by a semicolon (;). Email and name parts are optional.
 //     There can be an array of patterns for a single repository, separated by ||. It must start with a '[' and end with a ']': "[...||...||...]"
 //     If the skip_cla is not set, it will skip the allowlisted bots check.
+//
+// The configuration is parsed and evaluated by the bot_allowlist package, see bot_allowlist.Allowlist.
 func SkipAllowlistedBots(ev events.Service, orgModel *models.GithubOrganization, orgRepo, projectID string, actorsMissingCLA []*UserCommitSummary) ([]*UserCommitSummary, []*UserCommitSummary) {
 	repo := stripOrg(orgRepo)
 	f := logrus.Fields{
@@ -171,56 +114,18 @@ func SkipAllowlistedBots(ev events.Service, orgModel *models.GithubOrganization,
 		return actorsMissingCLA, []*UserCommitSummary{}
 	}
 
-	var config string
-	// 1. Exact match
-	if val, ok := skipCLA[repo]; ok {
-		config = val
-		log.WithFields(f).Debugf("skip_cla config found for repo (exact hit): '%s'", config)
-	}
-
-	// 2. Regex match (if no exact hit)
-	if config == "" {
-		log.WithFields(f).Debug("No skip_cla config found for repo, checking regex patterns")
-		for k, v := range skipCLA {
-			if !strings.HasPrefix(k, "re:") {
-				continue
-			}
-			pattern := k[3:]
-			re, err := regexp.Compile(pattern)
-			if err != nil {
-				log.WithFields(f).Warnf("Invalid regex in skip_cla: '%s': %+v", pattern, err)
-				continue
-			}
-			if re.MatchString(repo) {
-				config = v
-				log.WithFields(f).Debugf("Found skip_cla config for repo via regex pattern: '%s'", config)
-				break
-			}
-		}
-	}
-
-	// 3. Wildcard fallback
-	if config == "" {
-		if val, ok := skipCLA["*"]; ok {
-			config = val
-			log.WithFields(f).Debugf("No skip_cla config found for repo, using wildcard config: '%s'", config)
-		}
-	}
-
-	// 4. No match
-	if config == "" {
-		log.WithFields(f).Debug("No skip_cla config found for repo, skipping allowlisted bots check")
+	allowlist := compileSkipCLA(f, skipCLA)
+	if allowlist.IsEmpty() {
+		log.WithFields(f).Debug("No valid skip_cla config found, skipping allowlisted bots check")
 		return actorsMissingCLA, []*UserCommitSummary{}
 	}
 
-	configArray := parseConfigPatterns(config)
-
 	// Log full configuration
 	actorDebugData := make([]string, 0, len(actorsMissingCLA))
 	for _, a := range actorsMissingCLA {
 		actorDebugData = append(actorDebugData, actorToString(a))
 	}
-	log.WithFields(f).Debugf("final skip_cla config for repo %s is %+v; actorsMissingCLA: [%s]", orgRepo, configArray, strings.Join(actorDebugData, ", "))
+	log.WithFields(f).Debugf("skip_cla config for org is %+v; actorsMissingCLA: [%s]", skipCLA, strings.Join(actorDebugData, ", "))
 
 	seenActors := make(map[string]struct{})
 	for _, actor := range actorsMissingCLA {
@@ -228,19 +133,19 @@ func SkipAllowlistedBots(ev events.Service, orgModel *models.GithubOrganization,
 			continue
 		}
 		actorData := actorToString(actor)
-		log.WithFields(f).Debugf("Checking actor: %s for skip_cla config: %+v", actorData, configArray)
-		if isActorSkipped(actor, configArray) {
+		entry, skipped := allowlist.Match(repo, actorToAllowlistActor(actor))
+		if skipped {
 			_, seen := seenActors[actorData]
 			if !seen {
 				seenActors[actorData] = struct{}{}
 				msg := fmt.Sprintf(
-					"Skipping CLA check for repo='%s', actor: %s due to skip_cla config: %+v",
-					orgRepo, actorData, configArray,
+					"Skipping CLA check for repo='%s', actor: %s due to skip_cla config entry: %s",
+					orgRepo, actorData, entry,
 				)
 				log.WithFields(f).Info(msg)
 				eventData := events.BypassCLAEventData{
 					Repo:   orgRepo,
-					Config: config,
+					Config: entry.Repository + ": " + entry.ActorPattern(),
 					Actor:  actorData,
 				}
 				ev.LogEvent(&events.LogEventArgs{
@@ -258,3 +163,24 @@ func SkipAllowlistedBots(ev events.Service, orgModel *models.GithubOrganization,
 
 	return outActorsMissingCLA, allowlistedActors
 }
+
+// FindAllowlistedBotPattern returns the skip_cla pattern of the GitHub organization matching the actor for the
+// repository, if any. Unlike SkipAllowlistedBots, no events are logged and the actor is not modified.
+func FindAllowlistedBotPattern(orgModel *models.GithubOrganization, orgRepo string, actor *UserCommitSummary) (string, bool) {
+	repo := stripOrg(orgRepo)
+	f := logrus.Fields{
+		"functionName": "github.FindAllowlistedBotPattern",
+		"orgRepo":      orgRepo,
+		"repo":         repo,
+	}
+
+	if orgModel == nil || orgModel.SkipCla == nil || actor == nil {
+		return "", false
+	}
+
+	entry, ok := compileSkipCLA(f, orgModel.SkipCla).Match(repo, actorToAllowlistActor(actor))
+	if !ok {
+		return "", false
+	}
+	return entry.ActorPattern(), true
+}
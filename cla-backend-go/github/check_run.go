@@ -0,0 +1,309 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v37/github"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// checkRunName is the name of the EasyCLA check run, it is also the commit status context of the comment mode
+	checkRunName = "EasyCLA"
+	// CheckRunRerunAction is the identifier of the check run action which re-evaluates the pull request
+	CheckRunRerunAction = "rerun"
+	// maxCheckRunAnnotations is the maximum number of annotations GitHub accepts per check run request
+	maxCheckRunAnnotations = 50
+	// maxCheckRunSummaryLength is the maximum length of the check run summary GitHub accepts
+	maxCheckRunSummaryLength = 65535
+	// maxIdentityCommits is the number of commits listed per commit identity in the summary
+	maxIdentityCommits = 20
+	// summaryTruncationReserve is the room kept at the end of the summary for the note of the unlisted identities
+	summaryTruncationReserve = 256
+
+	checkRunStatusCompleted        = "completed"
+	checkRunConclusionSuccess      = "success"
+	checkRunConclusionActionNeeded = "action_required"
+	supportURL                     = "https://jira.linuxfoundation.org/servicedesk/customer/portal/4"
+)
+
+// blockingCommit is a commit with at least one identity which isn't covered by a CLA
+type blockingCommit struct {
+	SHA     string
+	Reasons []string
+}
+
+// UpdatePullRequestCheckRun publishes the CLA result of the pull request as a check run on the latest commit instead
+// of the comment and the commit status, the check run of a previous evaluation of the same commit is updated
+func UpdatePullRequestCheckRun(ctx context.Context, installationID int64, pullRequestID int, owner, repo string, repoID *int64, latestSHA string, signed []*UserCommitSummary, missing []*UserCommitSummary, CLABaseAPIURL, CLALandingPage, CLALogoURL string) error {
+	f := logrus.Fields{
+		"functionName":   "github.check_run.UpdatePullRequestCheckRun",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"installationID": installationID,
+		"owner":          owner,
+		"repo":           repo,
+		"SHA":            latestSHA,
+		"pullRequestID":  pullRequestID,
+	}
+
+	client, err := NewGithubAppClient(installationID)
+	if err != nil || client == nil {
+		log.WithFields(f).WithError(err).Warn("unable to create Github client")
+		return err
+	}
+
+	signURL := getFullSignURL("github", strconv.Itoa(int(installationID)), strconv.Itoa(int(*repoID)), strconv.Itoa(pullRequestID), CLABaseAPIURL)
+	output := checkRunOutput(signed, missing, signURL, CLALogoURL)
+	for _, commit := range blockingCommits(missing) {
+		if len(output.Annotations) == maxCheckRunAnnotations {
+			log.WithFields(f).Debugf("more than %d blocking commits, the remaining commits aren't annotated", maxCheckRunAnnotations)
+			break
+		}
+		annotation, annotationErr := commitAnnotation(ctx, client, owner, repo, commit)
+		if annotationErr != nil {
+			log.WithFields(f).WithError(annotationErr).Warnf("unable to annotate the blocking commit: %s", commit.SHA)
+			continue
+		}
+		if annotation != nil {
+			output.Annotations = append(output.Annotations, annotation)
+		}
+	}
+
+	conclusion, detailsURL := checkRunConclusionSuccess, fmt.Sprintf("%s/#/?version=2", CLALandingPage)
+	if len(missing) > 0 || len(signed) == 0 {
+		conclusion, detailsURL = checkRunConclusionActionNeeded, signURL
+	}
+	externalID := strconv.Itoa(pullRequestID)
+	actions := []*github.CheckRunAction{
+		{
+			Label:       "Re-run",
+			Description: "Check the CLA coverage again",
+			Identifier:  CheckRunRerunAction,
+		},
+	}
+	now := github.Timestamp{Time: time.Now()}
+
+	existing, err := findCheckRun(ctx, client, owner, repo, latestSHA)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to list the check runs of the commit")
+		return err
+	}
+
+	if existing != nil {
+		log.WithFields(f).Debugf("updating check run: %d with conclusion: %s", existing.GetID(), conclusion)
+		_, _, err = client.Checks.UpdateCheckRun(ctx, owner, repo, existing.GetID(), github.UpdateCheckRunOptions{
+			Name:        checkRunName,
+			DetailsURL:  &detailsURL,
+			ExternalID:  &externalID,
+			Status:      github.String(checkRunStatusCompleted),
+			Conclusion:  &conclusion,
+			CompletedAt: &now,
+			Output:      output,
+			Actions:     actions,
+		})
+	} else {
+		log.WithFields(f).Debugf("creating check run with conclusion: %s", conclusion)
+		_, _, err = client.Checks.CreateCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+			Name:        checkRunName,
+			HeadSHA:     latestSHA,
+			DetailsURL:  &detailsURL,
+			ExternalID:  &externalID,
+			Status:      github.String(checkRunStatusCompleted),
+			Conclusion:  &conclusion,
+			StartedAt:   &now,
+			CompletedAt: &now,
+			Output:      output,
+			Actions:     actions,
+		})
+	}
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to publish the check run")
+		return err
+	}
+
+	return nil
+}
+
+// IsEasyCLACheckRun returns true if the check run was created by this GitHub App
+func IsEasyCLACheckRun(checkRun *github.CheckRun) bool {
+	if checkRun == nil || checkRun.GetName() != checkRunName {
+		return false
+	}
+	return checkRun.GetApp().GetID() == int64(getGithubAppID())
+}
+
+// findCheckRun returns the EasyCLA check run of the commit, nil if the commit has none
+func findCheckRun(ctx context.Context, client *github.Client, owner, repo, sha string) (*github.CheckRun, error) {
+	results, _, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, sha, &github.ListCheckRunsOptions{
+		CheckName: github.String(checkRunName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, checkRun := range results.CheckRuns {
+		if IsEasyCLACheckRun(checkRun) {
+			return checkRun, nil
+		}
+	}
+	return nil, nil
+}
+
+// commitAnnotation annotates the first changed file of the blocking commit, GitHub only accepts annotations on a
+// file path. Returns nil when the commit changes no file, e.g. an empty commit.
+func commitAnnotation(ctx context.Context, client *github.Client, owner, repo string, commit *blockingCommit) (*github.CheckRunAnnotation, error) {
+	repositoryCommit, _, err := client.Repositories.GetCommit(ctx, owner, repo, commit.SHA)
+	if err != nil {
+		return nil, err
+	}
+	if len(repositoryCommit.Files) == 0 {
+		return nil, nil
+	}
+	return &github.CheckRunAnnotation{
+		Path:            repositoryCommit.Files[0].Filename,
+		StartLine:       github.Int(1),
+		EndLine:         github.Int(1),
+		AnnotationLevel: github.String(failureState),
+		Title:           github.String(fmt.Sprintf("Commit %s is missing CLA authorization", shortSHA(commit.SHA))),
+		Message:         github.String(strings.Join(commit.Reasons, "\n")),
+	}, nil
+}
+
+// checkRunOutput builds the check run title, the summary table of the commit identities and the help text
+func checkRunOutput(signed, missing []*UserCommitSummary, signURL, CLALogoURL string) *github.CheckRunOutput {
+	var title string
+	if len(missing) > 0 || len(signed) == 0 {
+		_, title = assembleCLAStatus(checkRunName, false)
+	} else {
+		_, title = assembleCLAStatus(checkRunName, true)
+	}
+
+	var summary strings.Builder
+	summary.WriteString(fmt.Sprintf("%d of %d commit identities are covered by a signed CLA.\n\n", len(signed), len(signed)+len(missing)))
+	if len(signed) > 0 || len(missing) > 0 {
+		summary.WriteString("| | Identity | Commits | Details |\n| --- | --- | --- | --- |\n")
+		var rows []string
+		for _, group := range groupByIdentity(missing) {
+			rows = append(rows, fmt.Sprintf("| :x: | %s | %s | %s [Sign the CLA](%s) |\n",
+				tableCell(group.identity), group.commits(), tableCell(missingReason(group.summaries[0])), signURL))
+		}
+		for _, group := range groupByIdentity(signed) {
+			rows = append(rows, fmt.Sprintf("| :white_check_mark: | %s | %s | Authorized |\n", tableCell(group.identity), group.commits()))
+		}
+		// GitHub rejects the check run when the summary is too long, the blocking identities are listed first
+		for i, row := range rows {
+			if summary.Len()+len(row) > maxCheckRunSummaryLength-summaryTruncationReserve {
+				summary.WriteString(fmt.Sprintf("\n%d more commit identities are not listed.\n", len(rows)-i))
+				break
+			}
+			summary.WriteString(row)
+		}
+	}
+
+	var text string
+	if len(missing) > 0 || len(signed) == 0 {
+		text = fmt.Sprintf("![CLA Not Signed](%s/cla-not-signed.svg%s)\n\n"+
+			"Every identity marked :x: must be covered by a signed CLA: [start the authorization process](%s). "+
+			"Commits whose author isn't linked to a GitHub account can be fixed by following [GitHub Help](%s). "+
+			"Once the CLA is signed, use the **Re-run** button to check the pull request again. "+
+			"For further assistance with EasyCLA, [please submit a support request ticket](%s).",
+			CLALogoURL, svgVersion, signURL, help, supportURL)
+	} else {
+		text = "The commit identities listed above are authorized under a signed CLA."
+	}
+
+	return &github.CheckRunOutput{
+		Title:   github.String(title),
+		Summary: github.String(summary.String()),
+		Text:    github.String(text),
+	}
+}
+
+// blockingCommits returns the commits of the missing identities in pull request order, with the reasons of every
+// identity of the commit which isn't covered
+func blockingCommits(missing []*UserCommitSummary) []*blockingCommit {
+	var commits []*blockingCommit
+	bySHA := make(map[string]*blockingCommit)
+	for _, summary := range missing {
+		commit, ok := bySHA[summary.SHA]
+		if !ok {
+			commit = &blockingCommit{SHA: summary.SHA}
+			bySHA[summary.SHA] = commit
+			commits = append(commits, commit)
+		}
+		commit.Reasons = append(commit.Reasons, fmt.Sprintf("%s: %s", identityLabel(summary), missingReason(summary)))
+	}
+	return commits
+}
+
+// missingReason explains why the commit identity isn't covered
+func missingReason(summary *UserCommitSummary) string {
+	if !summary.IsValid() {
+		return "the commit is missing the GitHub user ID, link the commit email to a GitHub account."
+	}
+	if summary.Affiliated {
+		return "associated with a company, but not on an approval list."
+	}
+	return "not authorized under a signed CLA."
+}
+
+// identityGroup is a commit identity with the commits it appears in
+type identityGroup struct {
+	identity  string
+	shas      []string
+	summaries []*UserCommitSummary
+}
+
+// groupByIdentity groups the summaries by commit identity in pull request order
+func groupByIdentity(summaries []*UserCommitSummary) []*identityGroup {
+	var groups []*identityGroup
+	byIdentity := make(map[string]*identityGroup)
+	for _, summary := range summaries {
+		identity := identityLabel(summary)
+		group, ok := byIdentity[identity]
+		if !ok {
+			group = &identityGroup{identity: identity}
+			byIdentity[identity] = group
+			groups = append(groups, group)
+		}
+		group.shas = append(group.shas, shortSHA(summary.SHA))
+		group.summaries = append(group.summaries, summary)
+	}
+	return groups
+}
+
+// commits returns the short SHAs of the identity commits, the first ones only when the identity has many commits
+func (g *identityGroup) commits() string {
+	if len(g.shas) <= maxIdentityCommits {
+		return strings.Join(g.shas, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(g.shas[:maxIdentityCommits], ", "), len(g.shas)-maxIdentityCommits)
+}
+
+func identityLabel(summary *UserCommitSummary) string {
+	label := strings.TrimSuffix(strings.TrimSpace(summary.getUserInfo(false)), " /")
+	label = strings.Replace(label, "/ (", "(", 1)
+	if label == "" {
+		return unknown
+	}
+	return label
+}
+
+func tableCell(value string) string {
+	return strings.ReplaceAll(value, "|", "\\|")
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,69 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRunOutput(t *testing.T) {
+	jane := &github.User{ID: github.Int64(1), Login: github.String("jane")}
+	signed := []*UserCommitSummary{
+		{SHA: "aaaaaaaaaa", CommitAuthor: jane, Role: CommitRoleAuthor, Affiliated: true, Authorized: true},
+		{SHA: "bbbbbbbbbb", CommitAuthor: jane, Role: CommitRoleAuthor, Affiliated: true, Authorized: true},
+	}
+	missing := []*UserCommitSummary{
+		{SHA: "cccccccccc", CommitAuthor: &github.User{ID: github.Int64(2), Login: github.String("john")}, Role: CommitRoleAuthor, Affiliated: true},
+		{SHA: "cccccccccc", CommitAuthor: &github.User{Name: github.String("Bob|B"), Email: github.String("bob@example.org")}, Role: CommitRoleCoAuthor},
+		{SHA: "dddddddddd", CommitAuthor: &github.User{Name: github.String("Carol"), Email: github.String("carol@example.org")}, Role: CommitRoleAuthor},
+	}
+
+	output := checkRunOutput(signed, missing, "https://sign", "https://logo")
+	assert.Equal(t, "Missing CLA Authorization.", output.GetTitle())
+	summary := output.GetSummary()
+	assert.True(t, strings.HasPrefix(summary, "2 of 5 commit identities are covered by a signed CLA."))
+	assert.Contains(t, summary, "| :white_check_mark: | login: jane | aaaaaaa, bbbbbbb | Authorized |")
+	assert.Contains(t, summary, "| :x: | login: john | ccccccc | associated with a company, but not on an approval list. [Sign the CLA](https://sign) |")
+	assert.Contains(t, summary, "| :x: | name: Bob\\|B / email: bob@example.org (co-author) | ccccccc | not authorized under a signed CLA. [Sign the CLA](https://sign) |")
+	assert.Contains(t, summary, "| :x: | name: Carol / email: carol@example.org | ddddddd | the commit is missing the GitHub user ID")
+	assert.Contains(t, output.GetText(), "Re-run")
+
+	output = checkRunOutput(signed, nil, "https://sign", "https://logo")
+	assert.Equal(t, "EasyCLA check passed. You are authorized to contribute.", output.GetTitle())
+	assert.NotContains(t, output.GetSummary(), ":x:")
+
+	commits := blockingCommits(missing)
+	assert.Len(t, commits, 2)
+	assert.Equal(t, "cccccccccc", commits[0].SHA)
+	assert.Equal(t, []string{
+		"login: john: associated with a company, but not on an approval list.",
+		"name: Bob|B / email: bob@example.org (co-author): not authorized under a signed CLA.",
+	}, commits[0].Reasons)
+	assert.Equal(t, "dddddddddd", commits[1].SHA)
+}
+
+func TestCheckRunOutputSummaryLength(t *testing.T) {
+	var missing []*UserCommitSummary
+	for i := 0; i < 2000; i++ {
+		login := fmt.Sprintf("contributor-with-a-long-login-%d", i)
+		missing = append(missing, &UserCommitSummary{SHA: fmt.Sprintf("%040d", i), CommitAuthor: &github.User{ID: github.Int64(int64(i + 1)), Login: github.String(login)}, Role: CommitRoleAuthor})
+	}
+	jane := &github.User{ID: github.Int64(10000), Login: github.String("jane")}
+	for i := 0; i < 30; i++ {
+		missing = append(missing, &UserCommitSummary{SHA: fmt.Sprintf("%040d", 10000+i), CommitAuthor: jane, Role: CommitRoleAuthor})
+	}
+
+	summary := checkRunOutput(nil, missing, "https://sign", "https://logo").GetSummary()
+	assert.True(t, len(summary) <= maxCheckRunSummaryLength)
+	assert.Contains(t, summary, "| :x: | login: contributor-with-a-long-login-0 |")
+	assert.Regexp(t, `\n\d+ more commit identities are not listed\.\n$`, summary)
+
+	summary = checkRunOutput(nil, missing[2000:], "https://sign", "https://logo").GetSummary()
+	assert.Contains(t, summary, "0000000, 0000000 and 10 more |")
+}
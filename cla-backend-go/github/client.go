@@ -14,6 +14,7 @@ import (
 	"github.com/shurcooL/githubv4"
 
 	"github.com/google/go-github/v37/github"
+	"github.com/linuxfoundation/easycla/cla-backend-go/telemetry"
 	"golang.org/x/oauth2"
 )
 
@@ -74,16 +75,36 @@ func CheckAndWrapForKnownErrors(resp *github.Response, err error) (bool, error)
 
 // NewGithubAppClient creates a new github client from the supplied installationID
 func NewGithubAppClient(installationID int64) (*github.Client, error) {
-	itr, err := ghinstallation.New(http.DefaultTransport, int64(getGithubAppID()), installationID, []byte(getGithubAppPrivateKey()))
+	itr, err := ghinstallation.New(telemetry.NewTransport(telemetry.IntegrationGitHub, http.DefaultTransport), int64(getGithubAppID()), installationID, []byte(getGithubAppPrivateKey()))
 	if err != nil {
 		return nil, err
 	}
 	return github.NewClient(&http.Client{Transport: itr}), nil
 }
 
+// CheckAppToken mints a GitHub App JWT and reads the app with it, an error means the app ID or the private key is
+// not accepted by GitHub and the installation tokens can't be minted either
+func CheckAppToken(ctx context.Context) error {
+	if getGithubAppID() == 0 || getGithubAppPrivateKey() == "" {
+		return errors.New("github app ID or private key is not configured")
+	}
+	appTransport, err := ghinstallation.NewAppsTransport(telemetry.NewTransport(telemetry.IntegrationGitHub, http.DefaultTransport), int64(getGithubAppID()), []byte(getGithubAppPrivateKey()))
+	if err != nil {
+		return err
+	}
+	app, _, err := github.NewClient(&http.Client{Transport: appTransport}).Apps.Get(ctx, "")
+	if err != nil {
+		return err
+	}
+	if app.GetID() != int64(getGithubAppID()) {
+		return fmt.Errorf("github returned app %d for the app ID %d", app.GetID(), getGithubAppID())
+	}
+	return nil
+}
+
 // NewGithubV4AppClient creates a new github v4 client from the supplied installationID
 func NewGithubV4AppClient(installationID int64) (*githubv4.Client, error) {
-	authTransport, err := ghinstallation.New(http.DefaultTransport, int64(getGithubAppID()), installationID, []byte(getGithubAppPrivateKey()))
+	authTransport, err := ghinstallation.New(telemetry.NewTransport(telemetry.IntegrationGitHub, http.DefaultTransport), int64(getGithubAppID()), installationID, []byte(getGithubAppPrivateKey()))
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +118,8 @@ func NewGithubOauthClient() *github.Client {
 
 // NewGithubOauthClientWithAccessToken creates github client from specified accessToken
 func NewGithubOauthClientWithAccessToken(accessToken string) *github.Client {
-	ctx := context.TODO()
+	// the oauth2 client uses the instrumented client of the context as its base client
+	ctx := context.WithValue(context.TODO(), oauth2.HTTPClient, telemetry.NewClient(telemetry.IntegrationGitHub))
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: accessToken},
 	)
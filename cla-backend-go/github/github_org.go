@@ -41,6 +41,18 @@ func GetMembership(ctx context.Context, user, organizationName string) (*github.
 	return membership, nil
 }
 
+// IsOrganizationMember returns true if the GitHub user is a member of the organization - a 404 from GitHub means the user is not a member
+func IsOrganizationMember(ctx context.Context, organizationName, user string) (bool, error) {
+	membership, err := GetMembership(ctx, user, organizationName)
+	if err != nil {
+		if errors.Is(err, ErrGithubOrganizationNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return membership != nil, nil
+}
+
 // GetOrganization gets github organization
 func GetOrganization(ctx context.Context, organizationName string) (*github.Organization, error) {
 	f := logrus.Fields{
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 	"github.com/sirupsen/logrus"
@@ -82,6 +83,8 @@ type UserCommitSummary struct {
 	CommitAuthor *github.User
 	Affiliated   bool
 	Authorized   bool
+	// Role is the commit identity role: author, committer or co-author
+	Role string
 }
 
 // GetCommitAuthorID commit author username ID (numeric value as a string) if available, otherwise returns empty string
@@ -99,7 +102,8 @@ func (u UserCommitSummary) GetCommitAuthorUsername() string {
 		if u.CommitAuthor.Login != nil {
 			return *u.CommitAuthor.Login
 		}
-		if u.CommitAuthor.Name != nil {
+		// the git name of an identity without a GitHub account isn't a GitHub username
+		if u.CommitAuthor.Name != nil && u.CommitAuthor.ID != nil {
 			return *u.CommitAuthor.Name
 		}
 	}
@@ -116,11 +120,15 @@ func (u UserCommitSummary) GetCommitAuthorEmail() string {
 	return ""
 }
 
-// IsValid returns true if the commit author information is available
+// IsValid returns true if the commit author information is available - committers and co-authors without a GitHub
+// account are checked by their email address
 func (u UserCommitSummary) IsValid() bool {
 	valid := false
 	if u.CommitAuthor != nil {
 		valid = u.CommitAuthor.ID != nil && (u.CommitAuthor.Login != nil || u.CommitAuthor.Name != nil)
+		if !valid && (u.Role == CommitRoleCommitter || u.Role == CommitRoleCoAuthor) {
+			valid = u.CommitAuthor.GetEmail() != ""
+		}
 	}
 	return valid
 }
@@ -158,24 +166,34 @@ func (u UserCommitSummary) getUserInfo(tagUser bool) string {
 		tagValue = "@"
 	}
 	if u.CommitAuthor != nil {
-		if *u.CommitAuthor.Login != "" {
+		if u.CommitAuthor.GetLogin() != "" {
 			sb.WriteString(fmt.Sprintf("login: %s%s / ", tagValue, *u.CommitAuthor.Login))
 		}
 
 		if u.CommitAuthor.Name != nil {
 			sb.WriteString(fmt.Sprintf("%sname: %s / ", userInfo, utils.StringValue(u.CommitAuthor.Name)))
 		}
+
+		if u.CommitAuthor.GetLogin() == "" && u.CommitAuthor.GetEmail() != "" {
+			sb.WriteString(fmt.Sprintf("email: %s / ", u.CommitAuthor.GetEmail()))
+		}
+	}
+
+	if u.Role == CommitRoleCommitter || u.Role == CommitRoleCoAuthor {
+		sb.WriteString(fmt.Sprintf("(%s) ", u.Role))
 	}
 
 	return strings.Replace(sb.String(), "/ $", "", -1)
 }
 
-func GetPullRequestCommitAuthors(ctx context.Context, installationID int64, pullRequestID int, owner, repo string) ([]*UserCommitSummary, *string, error) {
+// GetPullRequestCommitAuthors returns the commit identities of the pull request that must be covered according to
+// the commit identity policy of the organization, and the latest commit SHA
+func GetPullRequestCommitAuthors(ctx context.Context, installationID int64, pullRequestID int, owner, repo string, policy commit_identity.Policy) ([]*UserCommitSummary, *string, error) {
 	f := logrus.Fields{
 		"functionName":  "github.github_repository.GetPullRequestCommitAuthors",
 		"pullRequestID": pullRequestID,
+		"policy":        policy,
 	}
-	var userCommitSummary []*UserCommitSummary
 
 	client, err := NewGithubAppClient(installationID)
 	if err != nil {
@@ -183,40 +201,24 @@ func GetPullRequestCommitAuthors(ctx context.Context, installationID int64, pull
 		return nil, nil, err
 	}
 
-	commits, resp, comErr := client.PullRequests.ListCommits(ctx, owner, repo, pullRequestID, &github.ListOptions{})
+	commits, comErr := listPullRequestCommits(ctx, installationID, client, owner, repo, pullRequestID)
 	if comErr != nil {
 		log.WithFields(f).WithError(comErr).Warnf("problem listing commits for repo: %s/%s pull request: %d", owner, repo, pullRequestID)
 		return nil, nil, comErr
 	}
-	if resp.StatusCode != http.StatusOK {
-		msg := fmt.Sprintf("unexpected status code: %d - expected: %d", resp.StatusCode, http.StatusOK)
+	if len(commits) == 0 {
+		msg := fmt.Sprintf("no commits found for repo: %s/%s pull request: %d", owner, repo, pullRequestID)
 		log.WithFields(f).Warn(msg)
 		return nil, nil, errors.New(msg)
 	}
 
 	log.WithFields(f).Debugf("found %d commits for pull request: %d", len(commits), pullRequestID)
-	for _, commit := range commits {
-		log.WithFields(f).Debugf("loaded commit: %+v", commit)
-		commitAuthor := ""
-		if commit.Commit != nil && commit.Commit.Author != nil && commit.Commit.Author.Login != nil {
-			log.WithFields(f).Debugf("commit.Commit.Author: %s", utils.StringValue(commit.Commit.Author.Login))
-			commitAuthor = utils.StringValue(commit.Commit.Author.Login)
-		} else if commit.Author != nil && commit.Author.Login != nil {
-			log.WithFields(f).Debugf("commit.Author.Login: %s", utils.StringValue(commit.Author.Login))
-			commitAuthor = utils.StringValue(commit.Author.Login)
-		}
-		log.WithFields(f).Debugf("commitAuthor: %s", commitAuthor)
-		userCommitSummary = append(userCommitSummary, &UserCommitSummary{
-			SHA:          *commit.SHA,
-			CommitAuthor: commit.Author,
-			Affiliated:   false,
-			Authorized:   false,
-		})
-	}
+	userCommitSummary := commitActors(commits, policy)
+	log.WithFields(f).Debugf("found %d commit identities to check for pull request: %d", len(userCommitSummary), pullRequestID)
 
 	// get latest commit SHA
 	latestCommitSHA := commits[len(commits)-1].SHA
-	return userCommitSummary, latestCommitSHA, nil
+	return userCommitSummary, &latestCommitSHA, nil
 }
 
 func UpdatePullRequest(ctx context.Context, installationID int64, pullRequestID int, owner, repo string, repoID *int64, latestSHA string, signed []*UserCommitSummary, missing []*UserCommitSummary, CLABaseAPIURL, CLALandingPage, CLALogoURL string) error {
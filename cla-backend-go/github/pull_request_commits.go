@@ -0,0 +1,265 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+)
+
+// roles of the commit identities checked for a pull request
+const (
+	CommitRoleAuthor    = "author"
+	CommitRoleCommitter = "committer"
+	CommitRoleCoAuthor  = "co-author"
+)
+
+const (
+	// restCommitLimit is the maximum number of commits returned by the REST API for a pull request, larger pull
+	// requests are loaded with the GraphQL API
+	restCommitLimit = 250
+	commitsPageSize = 100
+	// webFlowLogin is the committer of the commits made on the GitHub web interface, e.g. merges and squashes
+	webFlowLogin = "web-flow"
+	webFlowEmail = "noreply@github.com"
+)
+
+// pullRequestCommit is a pull request commit loaded with the REST or the GraphQL API, the users are the GitHub
+// accounts of the git identities when they are linked, otherwise the git name and email
+type pullRequestCommit struct {
+	SHA            string
+	Message        string
+	Author         *github.User
+	AuthorEmail    string
+	Committer      *github.User
+	CommitterEmail string
+}
+
+// listPullRequestCommits loads every commit of the pull request, the REST API is paged and the GraphQL API is used
+// when the REST limit is reached
+func listPullRequestCommits(ctx context.Context, installationID int64, client *github.Client, owner, repo string, pullRequestID int) ([]*pullRequestCommit, error) {
+	f := logrus.Fields{
+		"functionName":  "github.pull_request_commits.listPullRequestCommits",
+		"owner":         owner,
+		"repo":          repo,
+		"pullRequestID": pullRequestID,
+	}
+
+	var commits []*pullRequestCommit
+	opts := &github.ListOptions{PerPage: commitsPageSize}
+	for {
+		page, resp, err := client.PullRequests.ListCommits(ctx, owner, repo, pullRequestID, opts)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem listing commits for repo: %s/%s pull request: %d", owner, repo, pullRequestID)
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			msg := fmt.Sprintf("unexpected status code: %d - expected: %d", resp.StatusCode, http.StatusOK)
+			log.WithFields(f).Warn(msg)
+			return nil, fmt.Errorf("listing commits for repo: %s/%s pull request: %d failed: %s", owner, repo, pullRequestID, msg)
+		}
+		for _, commit := range page {
+			commits = append(commits, fromRESTCommit(commit))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	if len(commits) < restCommitLimit {
+		return commits, nil
+	}
+
+	log.WithFields(f).Debugf("pull request has at least %d commits, loading the commits with the GraphQL API", restCommitLimit)
+	v4Client, err := NewGithubV4AppClient(installationID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create Github v4 client")
+		return nil, err
+	}
+	return listPullRequestCommitsV4(ctx, v4Client, owner, repo, pullRequestID)
+}
+
+// gitActorV4 is the git identity of a commit with the GitHub account it is linked to, if any
+type gitActorV4 struct {
+	Name  string
+	Email string
+	User  *struct {
+		DatabaseID int64 `graphql:"databaseId"`
+		Login      string
+	}
+}
+
+// pullRequestCommitsQuery loads a page of the pull request commits
+type pullRequestCommitsQuery struct {
+	Repository struct {
+		PullRequest struct {
+			Commits struct {
+				Nodes []struct {
+					Commit struct {
+						Oid       string
+						Message   string
+						Author    gitActorV4
+						Committer gitActorV4
+					}
+				}
+				PageInfo struct {
+					EndCursor   githubv4.String
+					HasNextPage bool
+				}
+			} `graphql:"commits(first: 100, after: $cursor)"`
+		} `graphql:"pullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// listPullRequestCommitsV4 loads every commit of the pull request with the GraphQL API, which has no commit limit
+func listPullRequestCommitsV4(ctx context.Context, client *githubv4.Client, owner, repo string, pullRequestID int) ([]*pullRequestCommit, error) {
+	variables := map[string]interface{}{
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(repo),
+		"number": githubv4.Int(pullRequestID),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	var commits []*pullRequestCommit
+	for {
+		var query pullRequestCommitsQuery
+		if err := client.Query(ctx, &query, variables); err != nil {
+			return nil, fmt.Errorf("fetching commits for repo: %s/%s pull request: %d failed : %v", owner, repo, pullRequestID, err)
+		}
+		for _, node := range query.Repository.PullRequest.Commits.Nodes {
+			commits = append(commits, &pullRequestCommit{
+				SHA:            node.Commit.Oid,
+				Message:        node.Commit.Message,
+				Author:         node.Commit.Author.toUser(),
+				AuthorEmail:    node.Commit.Author.Email,
+				Committer:      node.Commit.Committer.toUser(),
+				CommitterEmail: node.Commit.Committer.Email,
+			})
+		}
+		pageInfo := query.Repository.PullRequest.Commits.PageInfo
+		if !pageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = githubv4.NewString(pageInfo.EndCursor)
+	}
+	return commits, nil
+}
+
+func (a gitActorV4) toUser() *github.User {
+	if a.User != nil {
+		return &github.User{ID: github.Int64(a.User.DatabaseID), Login: github.String(a.User.Login)}
+	}
+	if a.Name == "" && a.Email == "" {
+		return nil
+	}
+	return &github.User{Name: github.String(a.Name), Email: github.String(a.Email)}
+}
+
+func fromRESTCommit(commit *github.RepositoryCommit) *pullRequestCommit {
+	result := &pullRequestCommit{
+		SHA:       commit.GetSHA(),
+		Author:    commit.Author,
+		Committer: commit.Committer,
+	}
+	if commit.Commit != nil {
+		result.Message = commit.Commit.GetMessage()
+		if commit.Commit.Author != nil {
+			result.AuthorEmail = commit.Commit.Author.GetEmail()
+		}
+		if commit.Commit.Committer != nil {
+			result.CommitterEmail = commit.Commit.Committer.GetEmail()
+			if result.Committer == nil {
+				result.Committer = &github.User{Name: commit.Commit.Committer.Name, Email: commit.Commit.Committer.Email}
+			}
+		}
+	}
+	return result
+}
+
+// commitActors returns the commit identities that must be covered according to the policy: the author of every
+// commit and, when the policy includes them, the committers and the co-authors that differ from the author
+func commitActors(commits []*pullRequestCommit, policy commit_identity.Policy) []*UserCommitSummary {
+	var summaries []*UserCommitSummary
+	for _, commit := range commits {
+		summaries = append(summaries, &UserCommitSummary{
+			SHA:          commit.SHA,
+			CommitAuthor: commit.Author,
+			Role:         CommitRoleAuthor,
+		})
+		seen := identityKeys(commit.Author, commit.AuthorEmail)
+
+		if policy.IncludeCommitters() && commit.Committer != nil && !isWebFlow(commit.Committer, commit.CommitterEmail) {
+			if addIdentity(seen, identityKeys(commit.Committer, commit.CommitterEmail)) {
+				summaries = append(summaries, &UserCommitSummary{
+					SHA:          commit.SHA,
+					CommitAuthor: commit.Committer,
+					Role:         CommitRoleCommitter,
+				})
+			}
+		}
+
+		if policy.IncludeCoAuthors() {
+			for _, coAuthor := range commit_identity.ParseCoAuthors(commit.Message) {
+				if !addIdentity(seen, identityKeys(nil, coAuthor.Email)) {
+					continue
+				}
+				summaries = append(summaries, &UserCommitSummary{
+					SHA:          commit.SHA,
+					CommitAuthor: &github.User{Name: github.String(coAuthor.Name), Email: github.String(coAuthor.Email)},
+					Role:         CommitRoleCoAuthor,
+				})
+			}
+		}
+	}
+	return summaries
+}
+
+// identityKeys returns the GitHub ID, the login and the email keys of a commit identity
+func identityKeys(user *github.User, email string) map[string]bool {
+	keys := make(map[string]bool)
+	if user != nil {
+		if user.ID != nil {
+			keys[fmt.Sprintf("id:%d", user.GetID())] = true
+		}
+		if user.GetLogin() != "" {
+			keys["login:"+strings.ToLower(user.GetLogin())] = true
+		}
+		if email == "" {
+			email = user.GetEmail()
+		}
+	}
+	if email != "" {
+		keys["email:"+strings.ToLower(email)] = true
+	}
+	return keys
+}
+
+// addIdentity adds the keys of the identity to the seen keys, returns false when the identity was already seen
+func addIdentity(seen, keys map[string]bool) bool {
+	if len(keys) == 0 {
+		return false
+	}
+	for key := range keys {
+		if seen[key] {
+			return false
+		}
+	}
+	for key := range keys {
+		seen[key] = true
+	}
+	return true
+}
+
+func isWebFlow(user *github.User, email string) bool {
+	return strings.EqualFold(user.GetLogin(), webFlowLogin) || strings.EqualFold(email, webFlowEmail) || strings.EqualFold(user.GetEmail(), webFlowEmail)
+}
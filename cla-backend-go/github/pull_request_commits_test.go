@@ -0,0 +1,67 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitActors(t *testing.T) {
+	jane := &github.User{ID: github.Int64(1), Login: github.String("jane")}
+	john := &github.User{ID: github.Int64(2), Login: github.String("john")}
+	webFlow := &github.User{ID: github.Int64(19864447), Login: github.String("web-flow")}
+	commits := []*pullRequestCommit{
+		{
+			SHA:            "sha1",
+			Message:        "Add feature\n\nCo-authored-by: Jane <jane@example.org>\nCo-authored-by: Alice <alice@example.org>",
+			Author:         jane,
+			AuthorEmail:    "jane@example.org",
+			Committer:      john,
+			CommitterEmail: "john@example.org",
+		},
+		{
+			SHA:            "sha2",
+			Message:        "Merge main",
+			Author:         john,
+			AuthorEmail:    "john@example.org",
+			Committer:      webFlow,
+			CommitterEmail: "noreply@github.com",
+		},
+		{
+			SHA:            "sha3",
+			Message:        "Fix typo",
+			Author:         &github.User{Name: github.String("Bob"), Email: github.String("bob@example.org")},
+			AuthorEmail:    "bob@example.org",
+			Committer:      &github.User{Name: github.String("Carol"), Email: github.String("carol@example.org")},
+			CommitterEmail: "carol@example.org",
+		},
+	}
+
+	roles := func(summaries []*UserCommitSummary) []string {
+		var result []string
+		for _, summary := range summaries {
+			result = append(result, summary.SHA+"/"+summary.Role+"/"+summary.GetCommitAuthorUsername()+summary.GetCommitAuthorEmail())
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"sha1/author/jane", "sha2/author/john", "sha3/author/bob@example.org"},
+		roles(commitActors(commits, commit_identity.PolicyAuthors)))
+	assert.Equal(t, []string{"sha1/author/jane", "sha1/committer/john", "sha2/author/john", "sha3/author/bob@example.org", "sha3/committer/carol@example.org"},
+		roles(commitActors(commits, commit_identity.PolicyAuthorsCommitters)))
+	// the co-author trailer of the commit author isn't checked twice
+	assert.Equal(t, []string{"sha1/author/jane", "sha1/co-author/alice@example.org", "sha2/author/john", "sha3/author/bob@example.org"},
+		roles(commitActors(commits, commit_identity.PolicyAuthorsCoAuthors)))
+
+	all := commitActors(commits, commit_identity.PolicyAll)
+	assert.Len(t, all, 6)
+	for _, summary := range all {
+		// the unlinked author isn't valid, unlinked committers and co-authors are checked by email
+		assert.Equal(t, summary.GetCommitAuthorEmail() != "bob@example.org", summary.IsValid(), summary.SHA+"/"+summary.Role)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_membership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Cache defaults
+const (
+	DefaultTTL         = 15 * time.Minute
+	DefaultNegativeTTL = 5 * time.Minute
+	DefaultSize        = 10000
+)
+
+// ErrMembershipUnknown is returned when the membership couldn't be determined, e.g. GitHub is unavailable or rate
+// limiting us - callers should treat the membership as unknown rather than as not a member
+var ErrMembershipUnknown = errors.New("github organization membership unknown")
+
+// LookupFunc queries GitHub for the membership of the user in the organization
+type LookupFunc func(ctx context.Context, organizationName, username string) (bool, error)
+
+// Config is the cache configuration - zero values are replaced by the defaults
+type Config struct {
+	// TTL is how long a positive membership result is kept
+	TTL time.Duration
+	// NegativeTTL is how long a "not a member" result is kept - usually shorter than TTL, so new members are picked up
+	// quickly even if the membership webhook is missed
+	NegativeTTL time.Duration
+}
+
+// Cache caches the GitHub organization membership results keyed by (organization, user). Lookup errors are never
+// cached. Entries are invalidated by the GitHub organization and membership webhooks.
+type Cache struct {
+	lookup LookupFunc
+	store  Store
+	config Config
+	now    func() time.Time
+}
+
+// NewCache creates a new membership cache using the lookup function to query GitHub and the store to keep the results
+func NewCache(lookup LookupFunc, store Store, config Config) *Cache {
+	if config.TTL <= 0 {
+		config.TTL = DefaultTTL
+	}
+	if config.NegativeTTL <= 0 {
+		config.NegativeTTL = DefaultNegativeTTL
+	}
+	return &Cache{
+		lookup: lookup,
+		store:  store,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// normalize returns the store key of the organization or the username - GitHub logins and organization names are
+// case-insensitive
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// IsMember returns true if the user is a member of the GitHub organization, using the cached result if it hasn't
+// expired. If GitHub can't be queried the returned error wraps ErrMembershipUnknown. A store failure only skips the
+// cache.
+func (c *Cache) IsMember(ctx context.Context, organizationName, username string) (bool, error) {
+	f := logrus.Fields{
+		"functionName":     "github_membership.Cache.IsMember",
+		"organizationName": organizationName,
+		"username":         username,
+	}
+
+	organization, user := normalize(organizationName), normalize(username)
+	entry, err := c.store.GetEntry(ctx, organization, user)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load cached membership, querying github")
+	} else if entry != nil && c.now().Unix() < entry.Expires {
+		log.WithFields(f).Debugf("using cached membership result: %t", entry.IsMember)
+		return entry.IsMember, nil
+	}
+
+	isMember, err := c.lookup(ctx, organizationName, username)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to query github organization membership")
+		return false, fmt.Errorf("%w: %w", ErrMembershipUnknown, err)
+	}
+
+	ttl := c.config.TTL
+	if !isMember {
+		ttl = c.config.NegativeTTL
+	}
+	err = c.store.PutEntry(ctx, &Entry{
+		Organization: organization,
+		Username:     user,
+		IsMember:     isMember,
+		Expires:      c.now().Add(ttl).Unix(),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to cache membership result")
+	}
+	return isMember, nil
+}
+
+// Invalidate removes the cached membership of the user in the organization
+func (c *Cache) Invalidate(ctx context.Context, organizationName, username string) error {
+	return c.store.DeleteEntry(ctx, normalize(organizationName), normalize(username))
+}
+
+// InvalidateOrganization removes the cached membership of every user in the organization
+func (c *Cache) InvalidateOrganization(ctx context.Context, organizationName string) error {
+	return c.store.DeleteOrganizationEntries(ctx, normalize(organizationName))
+}
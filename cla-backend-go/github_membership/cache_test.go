@@ -0,0 +1,132 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_membership
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheIsMember(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	cache := NewCache(func(_ context.Context, organizationName, username string) (bool, error) {
+		calls++
+		return organizationName == "org" && username == "member", nil
+	}, NewMemoryStore(0), Config{TTL: time.Hour, NegativeTTL: time.Minute})
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	isMember, err := cache.IsMember(ctx, "org", "member")
+	assert.NoError(t, err)
+	assert.True(t, isMember)
+	// cached, keys are case-insensitive
+	isMember, err = cache.IsMember(ctx, "ORG", "Member")
+	assert.NoError(t, err)
+	assert.True(t, isMember)
+	assert.Equal(t, 1, calls)
+
+	isMember, err = cache.IsMember(ctx, "org", "other")
+	assert.NoError(t, err)
+	assert.False(t, isMember)
+	assert.Equal(t, 2, calls)
+
+	// the negative result expires before the positive one
+	now = now.Add(2 * time.Minute)
+	_, _ = cache.IsMember(ctx, "org", "member")
+	_, _ = cache.IsMember(ctx, "org", "other")
+	assert.Equal(t, 3, calls)
+}
+
+func TestCacheErrorsAreUnknown(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	lookupErr := errors.New("502 bad gateway")
+	cache := NewCache(func(_ context.Context, organizationName, username string) (bool, error) {
+		calls++
+		return lookupErr == nil, lookupErr
+	}, NewMemoryStore(0), Config{})
+
+	isMember, err := cache.IsMember(ctx, "org", "member")
+	assert.False(t, isMember)
+	assert.ErrorIs(t, err, ErrMembershipUnknown)
+
+	// errors aren't cached
+	lookupErr = nil
+	isMember, err = cache.IsMember(ctx, "org", "member")
+	assert.NoError(t, err)
+	assert.True(t, isMember)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	ctx := context.Background()
+	members := map[string]bool{}
+	store := NewMemoryStore(0)
+	cache := NewCache(func(_ context.Context, organizationName, username string) (bool, error) {
+		return members[organizationName+"/"+username], nil
+	}, store, Config{})
+
+	_, _ = cache.IsMember(ctx, "org", "user-1")
+	_, _ = cache.IsMember(ctx, "org", "user-2")
+	_, _ = cache.IsMember(ctx, "other-org", "user-1")
+
+	// the user joins the organization
+	members["org/user-1"] = true
+	assert.NoError(t, cache.Invalidate(ctx, "Org", "USER-1"))
+	isMember, _ := cache.IsMember(ctx, "org", "user-1")
+	assert.True(t, isMember)
+
+	assert.NoError(t, cache.InvalidateOrganization(ctx, "ORG"))
+	assert.Len(t, store.(*memoryStore).items, 1)
+}
+
+func TestCacheStoreErrorsSkipTheCache(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	cache := NewCache(func(_ context.Context, organizationName, username string) (bool, error) {
+		calls++
+		return true, nil
+	}, failingStore{}, Config{})
+
+	isMember, err := cache.IsMember(ctx, "org", "member")
+	assert.NoError(t, err)
+	assert.True(t, isMember)
+	assert.Equal(t, 1, calls)
+}
+
+func TestMemoryStoreSize(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(2)
+	cache := NewCache(func(_ context.Context, organizationName, username string) (bool, error) {
+		return false, nil
+	}, store, Config{})
+
+	for _, username := range []string{"user-1", "user-2", "user-3"} {
+		_, _ = cache.IsMember(ctx, "org", username)
+	}
+	assert.Len(t, store.(*memoryStore).items, 2)
+}
+
+type failingStore struct{}
+
+func (failingStore) GetEntry(context.Context, string, string) (*Entry, error) {
+	return nil, errors.New("table not found")
+}
+
+func (failingStore) PutEntry(context.Context, *Entry) error {
+	return errors.New("table not found")
+}
+
+func (failingStore) DeleteEntry(context.Context, string, string) error {
+	return errors.New("table not found")
+}
+
+func (failingStore) DeleteOrganizationEntries(context.Context, string) error {
+	return errors.New("table not found")
+}
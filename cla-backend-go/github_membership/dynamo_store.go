@@ -0,0 +1,159 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_membership
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type dynamoStore struct {
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewDynamoStore creates the store of the membership results shared by the go and the python backends. The table
+// key is the organization and the username, the expires attribute is the table TTL so stale results are removed.
+func NewDynamoStore(awsSession *session.Session, stage string) Store {
+	return &dynamoStore{
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-github-membership-cache", stage),
+	}
+}
+
+func (s *dynamoStore) key(organization, username string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"organization": {S: aws.String(organization)},
+		"username":     {S: aws.String(username)},
+	}
+}
+
+func (s *dynamoStore) GetEntry(ctx context.Context, organization, username string) (*Entry, error) {
+	f := logrus.Fields{
+		"functionName":   "github_membership.dynamoStore.GetEntry",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organization":   organization,
+		"username":       username,
+	}
+
+	result, err := s.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key:       s.key(organization, username),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load cached membership")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, nil
+	}
+
+	var entry Entry
+	err = dynamodbattribute.UnmarshalMap(result.Item, &entry)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to unmarshal cached membership")
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (s *dynamoStore) PutEntry(ctx context.Context, entry *Entry) error {
+	f := logrus.Fields{
+		"functionName":   "github_membership.dynamoStore.PutEntry",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organization":   entry.Organization,
+		"username":       entry.Username,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(entry)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal cached membership")
+		return err
+	}
+	_, err = s.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      av,
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save cached membership")
+		return err
+	}
+	return nil
+}
+
+func (s *dynamoStore) DeleteEntry(ctx context.Context, organization, username string) error {
+	f := logrus.Fields{
+		"functionName":   "github_membership.dynamoStore.DeleteEntry",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organization":   organization,
+		"username":       username,
+	}
+
+	_, err := s.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(s.tableName),
+		Key:       s.key(organization, username),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to delete cached membership")
+		return err
+	}
+	return nil
+}
+
+func (s *dynamoStore) DeleteOrganizationEntries(ctx context.Context, organization string) error {
+	f := logrus.Fields{
+		"functionName":   "github_membership.dynamoStore.DeleteOrganizationEntries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organization":   organization,
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:              aws.String(s.tableName),
+		KeyConditionExpression: aws.String("#O = :organization"),
+		ProjectionExpression:   aws.String("#O, #U"),
+		ExpressionAttributeNames: map[string]*string{
+			"#O": aws.String("organization"),
+			"#U": aws.String("username"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":organization": {S: aws.String(organization)},
+		},
+	}
+
+	for {
+		results, err := s.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to query cached organization memberships")
+			return err
+		}
+
+		for _, item := range results.Items {
+			_, err = s.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+				TableName: aws.String(s.tableName),
+				Key: map[string]*dynamodb.AttributeValue{
+					"organization": item["organization"],
+					"username":     item["username"],
+				},
+			})
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("unable to delete cached membership")
+				return err
+			}
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return nil
+}
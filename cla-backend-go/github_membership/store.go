@@ -0,0 +1,107 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_membership
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Entry is a cached GitHub organization membership result, the organization and the username are lower case
+type Entry struct {
+	Organization string `dynamodbav:"organization"`
+	Username     string `dynamodbav:"username"`
+	IsMember     bool   `dynamodbav:"is_member"`
+	// Expires is the unix time the result expires at, also the TTL attribute of the DynamoDB table
+	Expires int64 `dynamodbav:"expires"`
+}
+
+// Store stores the cached membership results - shared by every instance of the service, so a webhook received by one
+// instance invalidates the results cached by the others
+type Store interface {
+	// GetEntry returns the cached result, or nil if there isn't one
+	GetEntry(ctx context.Context, organization, username string) (*Entry, error)
+	PutEntry(ctx context.Context, entry *Entry) error
+	DeleteEntry(ctx context.Context, organization, username string) error
+	// DeleteOrganizationEntries removes the cached results of every user in the organization
+	DeleteOrganizationEntries(ctx context.Context, organization string) error
+}
+
+type memoryStore struct {
+	size int
+	now  func() time.Time
+
+	lock  sync.RWMutex
+	items map[cacheKey]*Entry
+}
+
+type cacheKey struct {
+	organization string
+	username     string
+}
+
+// NewMemoryStore creates an in-process store holding at most size results, only suitable for a single instance
+func NewMemoryStore(size int) Store {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	return &memoryStore{
+		size:  size,
+		now:   time.Now,
+		items: make(map[cacheKey]*Entry),
+	}
+}
+
+func (m *memoryStore) GetEntry(_ context.Context, organization, username string) (*Entry, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.items[cacheKey{organization: organization, username: username}], nil
+}
+
+func (m *memoryStore) PutEntry(_ context.Context, entry *Entry) error {
+	key := cacheKey{organization: entry.Organization, username: entry.Username}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, exists := m.items[key]; !exists && len(m.items) >= m.size {
+		m.evict()
+	}
+	m.items[key] = entry
+	return nil
+}
+
+// evict removes the expired items, or an arbitrary one if none has expired - must be called with the lock held
+func (m *memoryStore) evict() {
+	now := m.now().Unix()
+	for key, item := range m.items {
+		if item.Expires <= now {
+			delete(m.items, key)
+		}
+	}
+	if len(m.items) < m.size {
+		return
+	}
+	for key := range m.items {
+		delete(m.items, key)
+		return
+	}
+}
+
+func (m *memoryStore) DeleteEntry(_ context.Context, organization, username string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.items, cacheKey{organization: organization, username: username})
+	return nil
+}
+
+func (m *memoryStore) DeleteOrganizationEntries(_ context.Context, organization string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for key := range m.items {
+		if key.organization == organization {
+			delete(m.items, key)
+		}
+	}
+	return nil
+}
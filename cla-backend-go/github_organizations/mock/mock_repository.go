@@ -155,3 +155,45 @@ func (mr *MockRepositoryInterfaceMockRecorder) UpdateGitHubOrganization(ctx, pro
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGitHubOrganization", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateGitHubOrganization), ctx, projectSFID, organizationName, autoEnabled, autoEnabledClaGroupID, branchProtectionEnabled, enabled)
 }
+
+// UpdateGitHubOrganizationCheckRunEnabled mocks base method.
+func (m *MockRepositoryInterface) UpdateGitHubOrganizationCheckRunEnabled(ctx context.Context, organizationName string, checkRunEnabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGitHubOrganizationCheckRunEnabled", ctx, organizationName, checkRunEnabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGitHubOrganizationCheckRunEnabled indicates an expected call of UpdateGitHubOrganizationCheckRunEnabled.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateGitHubOrganizationCheckRunEnabled(ctx, organizationName, checkRunEnabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGitHubOrganizationCheckRunEnabled", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateGitHubOrganizationCheckRunEnabled), ctx, organizationName, checkRunEnabled)
+}
+
+// UpdateGitHubOrganizationCommitIdentityPolicy mocks base method.
+func (m *MockRepositoryInterface) UpdateGitHubOrganizationCommitIdentityPolicy(ctx context.Context, organizationName, policy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGitHubOrganizationCommitIdentityPolicy", ctx, organizationName, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGitHubOrganizationCommitIdentityPolicy indicates an expected call of UpdateGitHubOrganizationCommitIdentityPolicy.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateGitHubOrganizationCommitIdentityPolicy(ctx, organizationName, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGitHubOrganizationCommitIdentityPolicy", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateGitHubOrganizationCommitIdentityPolicy), ctx, organizationName, policy)
+}
+
+// UpdateGitHubOrganizationSkipCLA mocks base method.
+func (m *MockRepositoryInterface) UpdateGitHubOrganizationSkipCLA(ctx context.Context, organizationName string, previousSkipCLA, skipCLA map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGitHubOrganizationSkipCLA", ctx, organizationName, previousSkipCLA, skipCLA)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGitHubOrganizationSkipCLA indicates an expected call of UpdateGitHubOrganizationSkipCLA.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateGitHubOrganizationSkipCLA(ctx, organizationName, previousSkipCLA, skipCLA interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGitHubOrganizationSkipCLA", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateGitHubOrganizationSkipCLA), ctx, organizationName, previousSkipCLA, skipCLA)
+}
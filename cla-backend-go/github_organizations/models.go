@@ -20,6 +20,8 @@ type GithubOrganization struct {
 	AutoEnabledClaGroupID      string            `json:"auto_enabled_cla_group_id,omitempty"`
 	Version                    string            `json:"version,omitempty"`
 	SkipCLA                    map[string]string `json:"skip_cla,omitempty"`
+	CommitIdentityPolicy       string            `json:"commit_identity_policy,omitempty"`
+	CheckRunEnabled            bool              `json:"check_run_enabled"`
 }
 
 // ToModel converts to models.GithubOrganization
@@ -37,6 +39,8 @@ func ToModel(in *GithubOrganization) *models.GithubOrganization {
 		BranchProtectionEnabled:    in.BranchProtectionEnabled,
 		ProjectSFID:                in.ProjectSFID,
 		SkipCla:                    in.SkipCLA,
+		CommitIdentityPolicy:       in.CommitIdentityPolicy,
+		CheckRunEnabled:            in.CheckRunEnabled,
 	}
 }
 
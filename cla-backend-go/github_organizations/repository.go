@@ -43,6 +43,9 @@ type RepositoryInterface interface {
 	GetGitHubOrganization(ctx context.Context, githubOrganizationName string) (*models.GithubOrganization, error)
 	GetGitHubOrganizationByName(ctx context.Context, githubOrganizationName string) (*models.GithubOrganizations, error)
 	UpdateGitHubOrganization(ctx context.Context, projectSFID string, organizationName string, autoEnabled bool, autoEnabledClaGroupID string, branchProtectionEnabled bool, enabled *bool) error
+	UpdateGitHubOrganizationSkipCLA(ctx context.Context, organizationName string, previousSkipCLA, skipCLA map[string]string) error
+	UpdateGitHubOrganizationCommitIdentityPolicy(ctx context.Context, organizationName string, policy string) error
+	UpdateGitHubOrganizationCheckRunEnabled(ctx context.Context, organizationName string, checkRunEnabled bool) error
 	DeleteGitHubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error
 	DeleteGitHubOrganizationByParent(ctx context.Context, parentProjectSFID string, githubOrgName string) error
 }
@@ -506,6 +509,202 @@ func (repo Repository) UpdateGitHubOrganization(ctx context.Context, projectSFID
 	return nil
 }
 
+// UpdateGitHubOrganizationSkipCLA updates the skip_cla bot allowlist configuration of the GitHub organization - an
+// empty configuration removes the attribute
+func (repo Repository) UpdateGitHubOrganizationSkipCLA(ctx context.Context, organizationName string, previousSkipCLA, skipCLA map[string]string) error {
+	f := logrus.Fields{
+		"functionName":     "v1.github_organizations.repository.UpdateGitHubOrganizationSkipCLA",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": organizationName,
+		"tableName":        repo.githubOrgTableName,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	githubOrg, lookupErr := repo.GetGitHubOrganization(ctx, organizationName)
+	if lookupErr != nil {
+		log.WithFields(f).Warnf("error looking up GitHub organization by name, error: %+v", lookupErr)
+		return lookupErr
+	}
+	if githubOrg == nil {
+		lookupErr := errors.New("unable to lookup GitHub organization by name")
+		log.WithFields(f).Warnf("error looking up GitHub organization, error: %+v", lookupErr)
+		return lookupErr
+	}
+
+	expressionAttributeNames := map[string]*string{
+		"#S": aws.String("skip_cla"),
+		"#M": aws.String("date_modified"),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":m": {
+			S: aws.String(currentTime),
+		},
+	}
+	updateExpression := "SET #M = :m REMOVE #S"
+
+	// the update only applies to the configuration it was computed from, so concurrent edits don't lose entries
+	conditionExpression := "attribute_not_exists(#S) OR size(#S) = :zero"
+	if len(previousSkipCLA) > 0 {
+		previousValue, marshalErr := dynamodbattribute.Marshal(previousSkipCLA)
+		if marshalErr != nil {
+			log.WithFields(f).Warnf("unable to marshal the previous skip_cla configuration, error: %+v", marshalErr)
+			return marshalErr
+		}
+		conditionExpression = "#S = :p"
+		expressionAttributeValues[":p"] = previousValue
+	} else {
+		expressionAttributeValues[":zero"] = &dynamodb.AttributeValue{N: aws.String("0")}
+	}
+
+	if len(skipCLA) > 0 {
+		skipCLAValue, marshalErr := dynamodbattribute.Marshal(skipCLA)
+		if marshalErr != nil {
+			log.WithFields(f).Warnf("unable to marshal skip_cla configuration, error: %+v", marshalErr)
+			return marshalErr
+		}
+		expressionAttributeValues[":s"] = skipCLAValue
+		updateExpression = "SET #M = :m, #S = :s"
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_name": {
+				S: aws.String(githubOrg.OrganizationName),
+			},
+		},
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		UpdateExpression:          &updateExpression,
+		ConditionExpression:       aws.String(conditionExpression),
+		TableName:                 aws.String(repo.githubOrgTableName),
+	}
+
+	log.WithFields(f).Debugf("updating github organization skip_cla configuration: %+v", skipCLA)
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		if aerr, ok := updateErr.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Warn("the skip_cla configuration changed since it was loaded")
+			return &utils.SkipCLAConflict{OrganizationName: githubOrg.OrganizationName, Err: updateErr}
+		}
+		log.WithFields(f).Warnf("unable to update GitHub organization skip_cla configuration, error: %+v", updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
+// UpdateGitHubOrganizationCommitIdentityPolicy updates the commit identity policy of the GitHub organization, which
+// selects the commit identities checked for the pull requests
+func (repo Repository) UpdateGitHubOrganizationCommitIdentityPolicy(ctx context.Context, organizationName string, policy string) error {
+	f := logrus.Fields{
+		"functionName":     "v1.github_organizations.repository.UpdateGitHubOrganizationCommitIdentityPolicy",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": organizationName,
+		"policy":           policy,
+		"tableName":        repo.githubOrgTableName,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	githubOrg, lookupErr := repo.GetGitHubOrganization(ctx, organizationName)
+	if lookupErr != nil {
+		log.WithFields(f).Warnf("error looking up GitHub organization by name, error: %+v", lookupErr)
+		return lookupErr
+	}
+	if githubOrg == nil {
+		lookupErr := errors.New("unable to lookup GitHub organization by name")
+		log.WithFields(f).Warnf("error looking up GitHub organization, error: %+v", lookupErr)
+		return lookupErr
+	}
+
+	updateExpression := "SET #P = :p, #M = :m"
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_name": {
+				S: aws.String(githubOrg.OrganizationName),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#P": aws.String("commit_identity_policy"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":p": {
+				S: aws.String(policy),
+			},
+			":m": {
+				S: aws.String(currentTime),
+			},
+		},
+		UpdateExpression: &updateExpression,
+		TableName:        aws.String(repo.githubOrgTableName),
+	}
+
+	log.WithFields(f).Debug("updating github organization commit identity policy")
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		log.WithFields(f).Warnf("unable to update GitHub organization commit identity policy, error: %+v", updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
+// UpdateGitHubOrganizationCheckRunEnabled updates the flag which publishes the CLA result of the pull requests as a
+// check run instead of a comment and a commit status
+func (repo Repository) UpdateGitHubOrganizationCheckRunEnabled(ctx context.Context, organizationName string, checkRunEnabled bool) error {
+	f := logrus.Fields{
+		"functionName":     "v1.github_organizations.repository.UpdateGitHubOrganizationCheckRunEnabled",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": organizationName,
+		"checkRunEnabled":  checkRunEnabled,
+		"tableName":        repo.githubOrgTableName,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	githubOrg, lookupErr := repo.GetGitHubOrganization(ctx, organizationName)
+	if lookupErr != nil {
+		log.WithFields(f).Warnf("error looking up GitHub organization by name, error: %+v", lookupErr)
+		return lookupErr
+	}
+	if githubOrg == nil {
+		lookupErr := errors.New("unable to lookup GitHub organization by name")
+		log.WithFields(f).Warnf("error looking up GitHub organization, error: %+v", lookupErr)
+		return lookupErr
+	}
+
+	updateExpression := "SET #C = :c, #M = :m"
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_name": {
+				S: aws.String(githubOrg.OrganizationName),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#C": aws.String("check_run_enabled"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":c": {
+				BOOL: aws.Bool(checkRunEnabled),
+			},
+			":m": {
+				S: aws.String(currentTime),
+			},
+		},
+		UpdateExpression: &updateExpression,
+		TableName:        aws.String(repo.githubOrgTableName),
+	}
+
+	log.WithFields(f).Debug("updating github organization check run flag")
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		log.WithFields(f).Warnf("unable to update GitHub organization check run flag, error: %+v", updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
 // DeleteGitHubOrganization deletes the github organization by project SFID
 func (repo Repository) DeleteGitHubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error {
 	f := logrus.Fields{
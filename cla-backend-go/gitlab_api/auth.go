@@ -4,6 +4,7 @@
 package gitlab
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -14,6 +15,7 @@ import (
 )
 
 const oauthURL = "https://gitlab.com/oauth/token"
+const oauthDiscoveryKeysURL = "https://gitlab.com/oauth/discovery/keys"
 
 // RefreshOauthToken common routine to refresh the GitLab token
 func RefreshOauthToken(refreshToken string) (*OauthSuccessResponse, error) {
@@ -121,3 +123,20 @@ func FetchOauthCredentials(code string) (*OauthSuccessResponse, error) {
 
 	return result, nil
 }
+
+// CheckApp checks the GitLab application is configured and the GitLab OAuth server answers, the contributors can't
+// authorize the application otherwise
+func CheckApp(ctx context.Context, app *App) error {
+	if app == nil || app.GetAppID() == "" || app.GetAppSecret() == "" || app.GetAppPrivateKey() == "" {
+		return errors.New("gitlab application ID, secret or private key is not set")
+	}
+
+	resp, err := resty.New().R().SetContext(ctx).Get(oauthDiscoveryKeysURL)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("gitlab oauth server returned status code: %d", resp.StatusCode())
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"io"
 
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/telemetry"
 
 	goGitLab "github.com/xanzy/go-gitlab"
 )
@@ -47,12 +48,12 @@ func NewGitlabOauthClient(authInfo string, gitLabApp *App) (*goGitLab.Client, er
 	}
 
 	log.Infof("creating oauth client with access token : %s", oauthResp.AccessToken)
-	return goGitLab.NewOAuthClient(oauthResp.AccessToken)
+	return goGitLab.NewOAuthClient(oauthResp.AccessToken, goGitLab.WithHTTPClient(telemetry.NewClient(telemetry.IntegrationGitLab)))
 }
 
 // NewGitlabOauthClientFromAccessToken creates a new gitlab client from the given access token
 func NewGitlabOauthClientFromAccessToken(accessToken string) (*goGitLab.Client, error) {
-	return goGitLab.NewOAuthClient(accessToken)
+	return goGitLab.NewOAuthClient(accessToken, goGitLab.WithHTTPClient(telemetry.NewClient(telemetry.IntegrationGitLab)))
 }
 
 // EncryptAuthInfo encrypts the oauth response into a string
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
@@ -44,20 +45,31 @@ func GetLatestCommit(client *gitlab.Client, projectID int, mergeID int) (*gitlab
 	return commits[0], nil
 }
 
-// FetchMrParticipants is responsible to get unique mr participants
-func FetchMrParticipants(client *gitlab.Client, projectID int, mergeID int) ([]*gitlab.User, error) {
+// FetchMrParticipants is responsible to get unique mr participants - the commit authors and, when the commit identity
+// policy of the group includes them, the committers and the Co-authored-by trailers of the commit messages
+func FetchMrParticipants(client *gitlab.Client, projectID int, mergeID int, policy commit_identity.Policy) ([]*gitlab.User, error) {
 	f := logrus.Fields{
 		"functionName": "gitlab_api.FetchMrParticipants",
 		"projectID":    projectID,
 		"mergeID":      mergeID,
+		"policy":       policy,
 	}
 	log.WithFields(f).Debug("fetching mr participants...")
-	commits, response, err := client.MergeRequests.GetMergeRequestCommits(projectID, mergeID, &gitlab.GetMergeRequestCommitsOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("fetching gitlab participants for project : %d and merge id : %d, failed : %v", projectID, mergeID, err)
-	}
-	if response.StatusCode != 200 {
-		return nil, fmt.Errorf("fetching gitlab participants for project : %d and merge id : %d, failed with status code : %d", projectID, mergeID, response.StatusCode)
+	var commits []*gitlab.Commit
+	opts := &gitlab.GetMergeRequestCommitsOptions{PerPage: 100}
+	for {
+		page, response, err := client.MergeRequests.GetMergeRequestCommits(projectID, mergeID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("fetching gitlab participants for project : %d and merge id : %d, failed : %v", projectID, mergeID, err)
+		}
+		if response.StatusCode != 200 {
+			return nil, fmt.Errorf("fetching gitlab participants for project : %d and merge id : %d, failed with status code : %d", projectID, mergeID, response.StatusCode)
+		}
+		commits = append(commits, page...)
+		if response.NextPage == 0 {
+			break
+		}
+		opts.Page = response.NextPage
 	}
 
 	if len(commits) == 0 {
@@ -67,13 +79,10 @@ func FetchMrParticipants(client *gitlab.Client, projectID int, mergeID int) ([]*
 
 	var results []*gitlab.User
 
-	for _, commit := range commits {
-		log.WithFields(f).Debugf("commit information: %v", commit)
-		// The author is the person who originally wrote the code. The committer, on the other hand, is assumed to be
-		// the person who committed the code on behalf of the original author.
-		authorEmail := commit.AuthorEmail
-		authorName := commit.AuthorName
-		log.WithFields(f).Debugf("extracted authorEmail: %s, user name: %s, from commit: %s. Searching GitLab API...", authorEmail, authorName, commit.ID)
+	for _, identity := range mrCommitIdentities(commits, policy) {
+		authorEmail := identity.Email
+		authorName := identity.Name
+		log.WithFields(f).Debugf("extracted email: %s, user name: %s. Searching GitLab API...", authorEmail, authorName)
 
 		// attempt to find additional user details - may or may not be able to enrich the user details by adding the GitLab user ID or username
 		user, getUserErr := getUser(client, &authorEmail, &authorName)
@@ -88,6 +97,36 @@ func FetchMrParticipants(client *gitlab.Client, projectID int, mergeID int) ([]*
 	return results, nil
 }
 
+// mrCommitIdentities returns the unique identities of the commits that must be covered according to the policy
+func mrCommitIdentities(commits []*gitlab.Commit, policy commit_identity.Policy) []commit_identity.Identity {
+	var identities []commit_identity.Identity
+	seen := make(map[string]bool)
+	add := func(identity commit_identity.Identity) {
+		key := strings.ToLower(identity.Email)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		identities = append(identities, identity)
+	}
+
+	for _, commit := range commits {
+		log.Debugf("commit information: %v", commit)
+		// The author is the person who originally wrote the code. The committer, on the other hand, is assumed to be
+		// the person who committed the code on behalf of the original author.
+		add(commit_identity.Identity{Name: commit.AuthorName, Email: commit.AuthorEmail})
+		if policy.IncludeCommitters() && commit.CommitterEmail != "" {
+			add(commit_identity.Identity{Name: commit.CommitterName, Email: commit.CommitterEmail})
+		}
+		if policy.IncludeCoAuthors() {
+			for _, coAuthor := range commit_identity.ParseCoAuthors(commit.Message) {
+				add(coAuthor)
+			}
+		}
+	}
+	return identities
+}
+
 // SetCommitStatus is responsible for setting the MR status for commit sha
 func SetCommitStatus(client *gitlab.Client, projectID int, commitSha string, state gitlab.BuildStateValue, message string, targetURL string) error {
 	f := logrus.Fields{
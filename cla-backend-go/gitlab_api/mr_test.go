@@ -0,0 +1,40 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestMrCommitIdentities(t *testing.T) {
+	commits := []*gitlab.Commit{
+		{
+			AuthorName:     "Jane",
+			AuthorEmail:    "jane@example.org",
+			CommitterName:  "John",
+			CommitterEmail: "john@example.org",
+			Message:        "Add feature\n\nCo-authored-by: Alice <alice@example.org>\nCo-authored-by: Jane <JANE@example.org>",
+		},
+		{
+			AuthorName:     "Jane",
+			AuthorEmail:    "jane@example.org",
+			CommitterName:  "Jane",
+			CommitterEmail: "jane@example.org",
+			Message:        "Fix typo",
+		},
+	}
+
+	jane := commit_identity.Identity{Name: "Jane", Email: "jane@example.org"}
+	john := commit_identity.Identity{Name: "John", Email: "john@example.org"}
+	alice := commit_identity.Identity{Name: "Alice", Email: "alice@example.org"}
+
+	assert.Equal(t, []commit_identity.Identity{jane}, mrCommitIdentities(commits, commit_identity.PolicyAuthors))
+	assert.Equal(t, []commit_identity.Identity{jane, john}, mrCommitIdentities(commits, commit_identity.PolicyAuthorsCommitters))
+	assert.Equal(t, []commit_identity.Identity{jane, alice}, mrCommitIdentities(commits, commit_identity.PolicyAuthorsCoAuthors))
+	assert.Equal(t, []commit_identity.Identity{jane, john, alice}, mrCommitIdentities(commits, commit_identity.PolicyAll))
+}
@@ -21,6 +21,27 @@ func Configure(api *operations.ClaAPI, service Service) {
 
 		return health.NewHealthCheckOK().WithPayload(result)
 	})
+
+	api.HealthHealthLivenessHandler = health.HealthLivenessHandlerFunc(func(params health.HealthLivenessParams) middleware.Responder {
+		result, err := service.Liveness(params.HTTPRequest.Context())
+		if err != nil {
+			return health.NewHealthLivenessBadRequest().WithPayload(errorResponse(err))
+		}
+
+		return health.NewHealthLivenessOK().WithPayload(result)
+	})
+
+	api.HealthHealthReadinessHandler = health.HealthReadinessHandlerFunc(func(params health.HealthReadinessParams) middleware.Responder {
+		result, err := service.Readiness(params.HTTPRequest.Context())
+		if err != nil {
+			return health.NewHealthReadinessBadRequest().WithPayload(errorResponse(err))
+		}
+
+		if !IsHealthy(result) {
+			return health.NewHealthReadinessServiceUnavailable().WithPayload(result)
+		}
+		return health.NewHealthReadinessOK().WithPayload(result)
+	})
 }
 
 type codedResponse interface {
@@ -0,0 +1,196 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	ini "github.com/linuxfoundation/easycla/cla-backend-go/init"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/token"
+)
+
+const (
+	// DefaultProbeTimeout is the time a probe is given to check its dependency
+	DefaultProbeTimeout = 5 * time.Second
+	// DefaultProbeCacheTTL is how long the result of a probe is reused - the health endpoints are polled and most of
+	// the dependencies are rate limited
+	DefaultProbeCacheTTL = 30 * time.Second
+)
+
+// CheckFunc checks a dependency, no error means the dependency is healthy
+type CheckFunc func(ctx context.Context) error
+
+// Probe checks the health of one dependency of the service
+type Probe interface {
+	Name() string
+	Check(ctx context.Context) *models.HealthStatus
+}
+
+// ProbeOptions configures the timeout and the result cache of a probe, the defaults are used for zero values
+type ProbeOptions struct {
+	Timeout  time.Duration
+	CacheTTL time.Duration
+}
+
+type cachedProbe struct {
+	name    string
+	check   CheckFunc
+	options ProbeOptions
+	now     func() time.Time
+
+	lock        sync.Mutex
+	lastResult  *models.HealthStatus
+	lastChecked time.Time
+}
+
+// NewProbe creates a probe which runs the check with a timeout and reuses the result until the cache expires
+func NewProbe(name string, check CheckFunc, options ProbeOptions) Probe {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultProbeTimeout
+	}
+	if options.CacheTTL <= 0 {
+		options.CacheTTL = DefaultProbeCacheTTL
+	}
+	return &cachedProbe{
+		name:    name,
+		check:   check,
+		options: options,
+		now:     time.Now,
+	}
+}
+
+// Name returns the name of the probe
+func (p *cachedProbe) Name() string {
+	return p.name
+}
+
+// Check returns the cached result of the probe, or checks the dependency when the cache has expired - concurrent
+// callers wait for the same check
+func (p *cachedProbe) Check(ctx context.Context) *models.HealthStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.lastResult != nil && p.now().Sub(p.lastChecked) < p.options.CacheTTL {
+		result := *p.lastResult
+		return &result
+	}
+
+	start := p.now()
+	err := p.runCheck(ctx)
+	result := &models.HealthStatus{
+		Name:      p.name,
+		Healthy:   err == nil,
+		Duration:  p.now().Sub(start).String(),
+		TimeStamp: start.UTC().Format(time.RFC3339),
+	}
+	if err != nil {
+		log.Warnf("health probe %s failed - error: %v", p.name, err)
+		result.Error = err.Error()
+	}
+
+	p.lastResult = result
+	p.lastChecked = start
+	copied := *result
+	return &copied
+}
+
+// runCheck runs the check with the probe timeout, the check is abandoned when it doesn't honor the context
+func (p *cachedProbe) runCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.options.Timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("health check panic: %v", r)
+			}
+		}()
+		done <- p.check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("health check timed out after %s", p.options.Timeout)
+	}
+}
+
+// DynamoDBTableCheck checks the table can be described
+func DynamoDBTableCheck(tableName string) CheckFunc {
+	return func(ctx context.Context) error {
+		awsSession, err := ini.GetAWSSession()
+		if err != nil {
+			return err
+		}
+		_, err = dynamodb.New(awsSession).DescribeTableWithContext(ctx, &dynamodb.DescribeTableInput{
+			TableName: aws.String(tableName),
+		})
+		return err
+	}
+}
+
+// S3BucketCheck checks the bucket exists and is accessible
+func S3BucketCheck(awsSession *session.Session, bucket string) CheckFunc {
+	return func(ctx context.Context) error {
+		if bucket == "" {
+			return errors.New("bucket name is not configured")
+		}
+		_, err := s3.New(awsSession).HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		return err
+	}
+}
+
+// PlatformServiceCheck checks a platform service behind the API gateway answers an authenticated request - the
+// platform token is minted and any response other than a server error means the service is up
+func PlatformServiceCheck(apiGatewayURL, basePath string) CheckFunc {
+	return func(ctx context.Context) error {
+		if apiGatewayURL == "" {
+			return errors.New("platform API gateway URL is not configured")
+		}
+		tok, err := token.GetToken()
+		if err != nil {
+			return fmt.Errorf("unable to get the platform token: %w", err)
+		}
+		host := strings.TrimSuffix(strings.TrimPrefix(apiGatewayURL, "https://"), "/")
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://%s/%s/", host, basePath), nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+tok)
+		return HTTPStatusCheck(req)
+	}
+}
+
+// HTTPStatusCheck sends the request and returns an error for the transport errors and the server errors
+func HTTPStatusCheck(req *http.Request) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.WithError(closeErr).Warn("problem closing response body")
+		}
+	}()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%s returned status code %d", req.URL.Host, resp.StatusCode)
+	}
+	return nil
+}
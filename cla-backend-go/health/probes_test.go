@@ -0,0 +1,100 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbeCachesResult(t *testing.T) {
+	calls := 0
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	probe := NewProbe("test", func(ctx context.Context) error {
+		calls++
+		return nil
+	}, ProbeOptions{CacheTTL: time.Minute}).(*cachedProbe)
+	probe.now = func() time.Time { return now }
+
+	status := probe.Check(context.Background())
+	assert.True(t, status.Healthy)
+	assert.Equal(t, "test", status.Name)
+
+	now = now.Add(30 * time.Second)
+	probe.Check(context.Background())
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(time.Minute)
+	probe.Check(context.Background())
+	assert.Equal(t, 2, calls)
+}
+
+func TestProbeReportsError(t *testing.T) {
+	probe := NewProbe("failing", func(ctx context.Context) error {
+		return errors.New("unable to connect")
+	}, ProbeOptions{})
+
+	status := probe.Check(context.Background())
+	assert.False(t, status.Healthy)
+	assert.Equal(t, "unable to connect", status.Error)
+}
+
+func TestProbeTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	probe := NewProbe("slow", func(ctx context.Context) error {
+		// ignores the context like the clients without context support
+		<-release
+		return nil
+	}, ProbeOptions{Timeout: 10 * time.Millisecond})
+
+	status := probe.Check(context.Background())
+	assert.False(t, status.Healthy)
+	assert.Contains(t, status.Error, "timed out")
+}
+
+func TestReadiness(t *testing.T) {
+	s := Service{
+		version: "v1.0.0",
+		tableProbes: []Probe{
+			NewProbe("table", func(ctx context.Context) error { return nil }, ProbeOptions{}),
+		},
+		probes: []Probe{
+			NewProbe("healthy", func(ctx context.Context) error { return nil }, ProbeOptions{}),
+			NewProbe("not healthy", func(ctx context.Context) error { return errors.New("down") }, ProbeOptions{}),
+		},
+	}
+
+	liveness, err := s.Liveness(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, IsHealthy(liveness))
+	assert.Len(t, liveness.Healths, 1)
+
+	// the health check reports each of the tables, not the other dependencies
+	healthCheck, err := s.HealthCheck(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, IsHealthy(healthCheck))
+	if assert.Len(t, healthCheck.Healths, 2) {
+		assert.Equal(t, "CLA", healthCheck.Healths[0].Name)
+		assert.Equal(t, "table", healthCheck.Healths[1].Name)
+	}
+
+	allStatus := checkProbes(context.Background(), s.probes)
+	if assert.Len(t, allStatus, 3) {
+		assert.Equal(t, "CLA", allStatus[0].Name)
+		assert.Equal(t, "healthy", allStatus[1].Name)
+		assert.False(t, allStatus[2].Healthy)
+	}
+
+	// the public readiness report doesn't include the dependencies or their errors
+	readiness, err := s.Readiness(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, IsHealthy(readiness))
+	assert.Empty(t, readiness.Healths)
+	assert.Empty(t, readiness.Version)
+}
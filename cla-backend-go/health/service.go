@@ -8,9 +8,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/aws/aws-sdk-go/service/dynamodb"
-	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
-
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	ini "github.com/linuxfoundation/easycla/cla-backend-go/init"
 )
@@ -21,48 +18,93 @@ type Service struct {
 	commit    string
 	branch    string
 	buildDate string
+	// tableProbes check the DynamoDB tables, reported per table by the health check
+	tableProbes []Probe
+	// probes check the other dependencies, only reported by the readiness probe
+	probes []Probe
 }
 
 // HealthService interface
 type HealthService interface { // nolint
 	HealthCheck(ctx context.Context) (*models.Health, error)
+	Liveness(ctx context.Context) (*models.Health, error)
+	Readiness(ctx context.Context) (*models.Health, error)
 }
 
-// New is a simple helper function to create a health service instance
-func New(version, commit, branch, buildDate string) Service {
+// New is a simple helper function to create a health service instance, the DynamoDB tables are always probed and
+// the additional probes check the other dependencies for the readiness report
+func New(version, commit, branch, buildDate string, probes ...Probe) Service {
 	return Service{
-		version:   version,
-		commit:    commit,
-		branch:    branch,
-		buildDate: buildDate,
+		version:     version,
+		commit:      commit,
+		branch:      branch,
+		buildDate:   buildDate,
+		tableProbes: dynamoTableProbes(),
+		probes:      probes,
 	}
 }
 
-// HealthCheck API call returns the current health of the service
+// HealthCheck API call returns the current health of the service and the status of each of the DynamoDB tables
 func (s Service) HealthCheck(ctx context.Context) (*models.Health, error) {
+	return s.healthResponse(checkProbes(ctx, s.tableProbes)), nil
+}
+
+// Liveness returns the health of the service process only, the dependencies are not checked
+func (s Service) Liveness(ctx context.Context) (*models.Health, error) {
+	return s.healthResponse([]*models.HealthStatus{serviceStatus()}), nil
+}
+
+// Readiness returns the status of the service, the service is not healthy when any dependency is not healthy - the
+// endpoint is public so the report only has the overall status, the failing probes log their errors
+func (s Service) Readiness(ctx context.Context) (*models.Health, error) {
+	health := s.healthResponse(checkProbes(ctx, append(append([]Probe{}, s.tableProbes...), s.probes...)))
+	return &models.Health{
+		Status:    health.Status,
+		TimeStamp: health.TimeStamp,
+	}, nil
+}
+
+// checkProbes returns the health of the service and of the dependencies checked by the probes
+func checkProbes(ctx context.Context, probes []Probe) []*models.HealthStatus {
+	allStatus := make([]*models.HealthStatus, len(probes)+1)
+	allStatus[0] = serviceStatus()
+
+	var wg sync.WaitGroup
+	wg.Add(len(probes))
+	for i, probe := range probes {
+		go func(i int, probe Probe) {
+			defer wg.Done()
+			allStatus[i+1] = probe.Check(ctx)
+		}(i, probe)
+	}
+	wg.Wait()
 
-	// General health
-	hs := models.HealthStatus{
-		TimeStamp: time.Now().UTC().Format(time.RFC3339),
+	return allStatus
+}
+
+// serviceStatus returns the health of the service itself
+func serviceStatus() *models.HealthStatus {
+	now := time.Now()
+	return &models.HealthStatus{
+		TimeStamp: now.UTC().Format(time.RFC3339),
 		Healthy:   true,
 		Name:      "CLA",
-		Duration:  time.Since(time.Now()).String(),
+		Duration:  time.Since(now).String(),
 	}
+}
 
-	var allStatus []*models.HealthStatus
-	allStatus = append(allStatus, &hs)
-	allStatus = append(allStatus, getDynamoTableStatus()...)
-
+// healthResponse returns the health report of the status list
+func (s Service) healthResponse(allStatus []*models.HealthStatus) *models.Health {
 	var status = "healthy"
 	for _, item := range allStatus {
-		// If any of our dynamodb tables are not healthy, then overall we are not healthy
+		// If any of our dependencies are not healthy, then overall we are not healthy
 		if !item.Healthy {
 			status = "not healthy"
 			break
 		}
 	}
 
-	response := models.Health{
+	return &models.Health{
 		Status:         status,
 		TimeStamp:      time.Now().UTC().Format(time.RFC3339),
 		Version:        s.version,
@@ -71,14 +113,15 @@ func (s Service) HealthCheck(ctx context.Context) (*models.Health, error) {
 		BuildTimeStamp: s.buildDate,
 		Healths:        allStatus,
 	}
-
-	return &response, nil
 }
 
-// getDynamoTableStatus queries the dynamodb tables and reports if it is healthy
-func getDynamoTableStatus() []*models.HealthStatus {
-	var allStatus []*models.HealthStatus
+// IsHealthy returns true when the health report status is healthy
+func IsHealthy(health *models.Health) bool {
+	return health != nil && health.Status == "healthy"
+}
 
+// dynamoTableProbes returns the probes of the dynamodb tables
+func dynamoTableProbes() []Probe {
 	tableNames := []string{
 		"cla-" + ini.GetStage() + "-ccla-whitelist-requests",
 		"cla-" + ini.GetStage() + "-cla-manager-requests",
@@ -98,46 +141,9 @@ func getDynamoTableStatus() []*models.HealthStatus {
 		"cla-" + ini.GetStage() + "-users",
 	}
 
-	var wg sync.WaitGroup
-	wg.Add(len(tableNames))
-
+	probes := make([]Probe, 0, len(tableNames))
 	for _, tableName := range tableNames {
-		go func(tableName string) {
-			defer wg.Done()
-
-			// Do a quick check to see if we have a database connection
-			dynamoNow := time.Now()
-			dynamoAlive := isDynamoAlive(tableName)
-			dynamoDuration := time.Since(dynamoNow)
-			dy := models.HealthStatus{TimeStamp: time.Now().UTC().Format(time.RFC3339),
-				Healthy:  dynamoAlive,
-				Name:     "EasyCLA - Dynamodb - " + tableName,
-				Duration: dynamoDuration.String()}
-
-			allStatus = append(allStatus, &dy)
-		}(tableName)
-	}
-
-	wg.Wait()
-
-	return allStatus
-}
-
-// isDynamoAlive runs a check to see if we have connectivity to the database for the given table - returns true if successful, false otherwise
-func isDynamoAlive(tableName string) bool {
-	// Grab the AWS session
-	awsSession, err := ini.GetAWSSession()
-	if err != nil {
-		log.Warnf("Unable to acquire AWS session - returning failed health, error: %v", err)
-		return false
+		probes = append(probes, NewProbe("EasyCLA - Dynamodb - "+tableName, DynamoDBTableCheck(tableName), ProbeOptions{}))
 	}
-
-	// Create a client and make a query - don't worry about the result - just check the error response
-	dynamoDBClient := dynamodb.New(awsSession)
-	_, err = dynamoDBClient.DescribeTable(&dynamodb.DescribeTableInput{
-		TableName: &tableName,
-	})
-
-	// No error is success
-	return err == nil
+	return probes
 }
@@ -0,0 +1,42 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notification_digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDigestDue(t *testing.T) {
+	monday := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	tuesday := monday.Add(24 * time.Hour)
+	options := DigestOptions{Now: monday, WeeklyDigestDay: time.Monday}
+
+	assert.True(t, isDigestDue(NotificationPreferenceWeekly, options))
+	assert.True(t, isDigestDue(NotificationPreferenceDaily, options))
+	options.Now = tuesday
+	assert.False(t, isDigestDue(NotificationPreferenceWeekly, options))
+	assert.True(t, isDigestDue(NotificationPreferenceDaily, options))
+	assert.True(t, isDigestDue(NotificationPreferenceImmediate, options))
+}
+
+func TestBuildDigestSections(t *testing.T) {
+	sections := buildDigestSections([]*Notification{
+		{NotificationID: "3", CompanyID: "c2", CompanyName: "Beta", CLAGroupID: "g1", CLAGroupName: "Group", NotificationType: NotificationTypeSignature, Message: "signed"},
+		{NotificationID: "2", CompanyID: "c1", CompanyName: "Alpha", CLAGroupID: "g1", CLAGroupName: "Group", NotificationType: NotificationTypeApprovalRequest, Message: "second request"},
+		{NotificationID: "1", CompanyID: "c1", CompanyName: "Alpha", CLAGroupID: "g1", CLAGroupName: "Group", NotificationType: NotificationTypeApprovalRequest, Message: "first request"},
+		{NotificationID: "4", CompanyID: "c1", CompanyName: "Alpha", CLAGroupID: "g1", CLAGroupName: "Group", NotificationType: NotificationTypeCLAManagerChange, Message: "manager added"},
+	})
+
+	if assert.Len(t, sections, 2) {
+		assert.Equal(t, "Alpha", sections[0].CompanyName)
+		assert.Equal(t, []string{"first request", "second request"}, sections[0].ApprovalRequests)
+		assert.Equal(t, []string{"manager added"}, sections[0].CLAManagerChanges)
+		assert.Empty(t, sections[0].Signatures)
+		assert.Equal(t, "Beta", sections[1].CompanyName)
+		assert.Equal(t, []string{"signed"}, sections[1].Signatures)
+	}
+}
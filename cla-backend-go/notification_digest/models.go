@@ -0,0 +1,54 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notification_digest
+
+import "time"
+
+// notification preferences of the CLA managers, stored in the user record
+const (
+	NotificationPreferenceImmediate = "immediate"
+	NotificationPreferenceDaily     = "daily"
+	NotificationPreferenceWeekly    = "weekly"
+)
+
+// notification types summarized in the digest
+const (
+	NotificationTypeApprovalRequest  = "approval_request"
+	NotificationTypeSignature        = "signature"
+	NotificationTypeCLAManagerChange = "cla_manager_change"
+)
+
+// Notification is a CLA manager notification waiting for the next digest of the recipient, the notification ID is
+// {date_created}#{uuid} so the notifications of the recipient are sorted by date
+type Notification struct {
+	RecipientEmail   string `dynamodbav:"recipient_email"`
+	NotificationID   string `dynamodbav:"notification_id"`
+	RecipientName    string `dynamodbav:"recipient_name"`
+	NotificationType string `dynamodbav:"notification_type"`
+	CompanyID        string `dynamodbav:"company_id"`
+	CompanyName      string `dynamodbav:"company_name"`
+	CLAGroupID       string `dynamodbav:"cla_group_id"`
+	CLAGroupName     string `dynamodbav:"cla_group_name"`
+	Message          string `dynamodbav:"message"`
+	DateCreated      string `dynamodbav:"date_created"`
+}
+
+// DigestOptions are the options of a digest run
+type DigestOptions struct {
+	// Now is the time of the run, the weekly digests are sent when it is the weekly digest day
+	Now time.Time
+	// WeeklyDigestDay is the day the weekly digests are sent
+	WeeklyDigestDay time.Weekday
+	// DryRun logs the digests without sending them
+	DryRun bool
+}
+
+// DigestResult is the summary of a digest run
+type DigestResult struct {
+	Recipients    int
+	Sent          int
+	Deferred      int
+	Failed        int
+	Notifications int
+}
@@ -0,0 +1,196 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notification_digest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/gofrs/uuid"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Repository stores the notifications waiting for the next digest of their recipient
+type Repository interface {
+	AddNotification(ctx context.Context, notification *Notification) error
+	// ListRecipients returns the recipients with pending notifications
+	ListRecipients(ctx context.Context) ([]string, error)
+	GetNotifications(ctx context.Context, recipientEmail string) ([]*Notification, error)
+	DeleteNotification(ctx context.Context, recipientEmail, notificationID string) error
+}
+
+type repository struct {
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewRepository creates the repository of the notification digests
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-notification-digests", stage),
+	}
+}
+
+// AddNotification adds the notification to the digest of the recipient
+func (repo *repository) AddNotification(ctx context.Context, notification *Notification) error {
+	f := logrus.Fields{
+		"functionName":     "notification_digest.repository.AddNotification",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"recipientEmail":   notification.RecipientEmail,
+		"notificationType": notification.NotificationType,
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate a UUID for the notification")
+		return err
+	}
+	if notification.DateCreated == "" {
+		_, notification.DateCreated = utils.CurrentTime()
+	}
+	notification.NotificationID = fmt.Sprintf("%s#%s", notification.DateCreated, id.String())
+
+	av, err := dynamodbattribute.MarshalMap(notification)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal the notification")
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to add the notification")
+		return err
+	}
+
+	return nil
+}
+
+// ListRecipients returns the recipients with pending notifications
+func (repo *repository) ListRecipients(ctx context.Context) ([]string, error) {
+	f := logrus.Fields{
+		"functionName":   "notification_digest.repository.ListRecipients",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	expr, err := expression.NewBuilder().WithProjection(expression.NamesList(expression.Name("recipient_email"))).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to build the scan expression")
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		TableName:                aws.String(repo.tableName),
+		ExpressionAttributeNames: expr.Names(),
+		ProjectionExpression:     expr.Projection(),
+	}
+
+	var recipients []string
+	seen := map[string]bool{}
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to scan the notifications")
+			return nil, err
+		}
+
+		var page []*Notification
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to unmarshal the notifications")
+			return nil, err
+		}
+		for _, notification := range page {
+			if !seen[notification.RecipientEmail] {
+				seen[notification.RecipientEmail] = true
+				recipients = append(recipients, notification.RecipientEmail)
+			}
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return recipients, nil
+}
+
+// GetNotifications returns the pending notifications of the recipient, oldest first
+func (repo *repository) GetNotifications(ctx context.Context, recipientEmail string) ([]*Notification, error) {
+	f := logrus.Fields{
+		"functionName":   "notification_digest.repository.GetNotifications",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"recipientEmail": recipientEmail,
+	}
+
+	condition := expression.Key("recipient_email").Equal(expression.Value(recipientEmail))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to build the query expression")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 aws.String(repo.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+
+	var notifications []*Notification
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to query the notifications")
+			return nil, err
+		}
+
+		var page []*Notification
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to unmarshal the notifications")
+			return nil, err
+		}
+		notifications = append(notifications, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return notifications, nil
+}
+
+// DeleteNotification deletes the notification once it was sent in a digest
+func (repo *repository) DeleteNotification(ctx context.Context, recipientEmail, notificationID string) error {
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(repo.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"recipient_email": {S: aws.String(recipientEmail)},
+			"notification_id": {S: aws.String(notificationID)},
+		},
+	})
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"functionName":   "notification_digest.repository.DeleteNotification",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"recipientEmail": recipientEmail,
+			"notificationID": notificationID,
+		}).WithError(err).Warn("unable to delete the notification")
+		return err
+	}
+	return nil
+}
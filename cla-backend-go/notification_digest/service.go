@@ -0,0 +1,250 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notification_digest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Service queues the CLA manager notifications of the recipients who prefer a digest and sends the digests
+type Service interface {
+	// QueueNotification adds the notification to the digest of the recipient, it returns false when the recipient
+	// wants the notifications immediately and the caller should send the email
+	QueueNotification(ctx context.Context, notification *Notification) bool
+	// GetNotificationPreference returns the notification preference of the recipient, immediate when not set
+	GetNotificationPreference(recipientEmail string) string
+	// SendDigests sends one summary email per recipient with the pending notifications
+	SendDigests(ctx context.Context, options DigestOptions) (*DigestResult, error)
+}
+
+type service struct {
+	repo                 Repository
+	userRepo             users.UserRepository
+	emailTemplateService emails.EmailTemplateService
+	corporateConsoleURL  string
+}
+
+// NewService creates the notification digest service
+func NewService(repo Repository, userRepo users.UserRepository, emailTemplateService emails.EmailTemplateService, corporateConsoleURL string) Service {
+	return &service{
+		repo:                 repo,
+		userRepo:             userRepo,
+		emailTemplateService: emailTemplateService,
+		corporateConsoleURL:  corporateConsoleURL,
+	}
+}
+
+// GetNotificationPreference returns the notification preference of the recipient, immediate when the recipient has
+// no user record or no preference
+func (s *service) GetNotificationPreference(recipientEmail string) string {
+	if recipientEmail == "" || s.userRepo == nil {
+		return NotificationPreferenceImmediate
+	}
+	userModel, err := s.userRepo.GetUserByEmail(recipientEmail)
+	if err != nil || userModel == nil {
+		return NotificationPreferenceImmediate
+	}
+	switch strings.ToLower(userModel.NotificationPreference) {
+	case NotificationPreferenceDaily:
+		return NotificationPreferenceDaily
+	case NotificationPreferenceWeekly:
+		return NotificationPreferenceWeekly
+	default:
+		return NotificationPreferenceImmediate
+	}
+}
+
+// QueueNotification adds the notification to the digest of the recipient when the recipient prefers a digest
+func (s *service) QueueNotification(ctx context.Context, notification *Notification) bool {
+	f := logrus.Fields{
+		"functionName":     "notification_digest.service.QueueNotification",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"recipientEmail":   notification.RecipientEmail,
+		"notificationType": notification.NotificationType,
+		"companyName":      notification.CompanyName,
+		"claGroupName":     notification.CLAGroupName,
+	}
+
+	preference := s.GetNotificationPreference(notification.RecipientEmail)
+	if preference == NotificationPreferenceImmediate {
+		return false
+	}
+
+	if err := s.repo.AddNotification(ctx, notification); err != nil {
+		// better one more email than a lost notification
+		log.WithFields(f).WithError(err).Warn("unable to queue the notification for the digest - sending it immediately")
+		return false
+	}
+	log.WithFields(f).Debugf("queued the notification for the %s digest", preference)
+	return true
+}
+
+// SendDigests sends the digests of the recipients who are due, the sent notifications are deleted - the weekly
+// digests are only sent on the weekly digest day, the notifications of the recipients who switched back to the
+// immediate notifications are flushed in a daily digest
+func (s *service) SendDigests(ctx context.Context, options DigestOptions) (*DigestResult, error) {
+	f := logrus.Fields{
+		"functionName":   "notification_digest.service.SendDigests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"dryRun":         options.DryRun,
+	}
+
+	recipients, err := s.repo.ListRecipients(ctx)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to list the digest recipients")
+		return nil, err
+	}
+
+	result := &DigestResult{Recipients: len(recipients)}
+	for _, recipient := range recipients {
+		preference := s.GetNotificationPreference(recipient)
+		if !isDigestDue(preference, options) {
+			result.Deferred++
+			continue
+		}
+
+		notifications, err := s.repo.GetNotifications(ctx, recipient)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to load the notifications of %s", recipient)
+			result.Failed++
+			continue
+		}
+		if len(notifications) == 0 {
+			continue
+		}
+
+		params := s.buildDigestParams(recipient, preference, notifications)
+		subject := fmt.Sprintf("EasyCLA: Your %s CLA Manager digest", params.Frequency)
+		subject, body, err := emails.RenderCLAManagerDigestTemplate(s.emailTemplateService, subject, params)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("rendering email template: %s failed", emails.CLAManagerDigestTemplateName)
+			result.Failed++
+			continue
+		}
+
+		if options.DryRun {
+			log.WithFields(f).Infof("dry run - would send the %s digest with %d notifications to %s", params.Frequency, len(notifications), recipient)
+			result.Sent++
+			result.Notifications += len(notifications)
+			continue
+		}
+
+		if err := utils.SendEmail(subject, body, []string{recipient}); err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem sending email with subject: %s to recipient: %s", subject, recipient)
+			result.Failed++
+			continue
+		}
+
+		for _, notification := range notifications {
+			if err := s.repo.DeleteNotification(ctx, recipient, notification.NotificationID); err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to delete the sent notification %s of %s", notification.NotificationID, recipient)
+			}
+		}
+		result.Sent++
+		result.Notifications += len(notifications)
+	}
+
+	return result, nil
+}
+
+// isDigestDue returns true when the digest of the recipient preference is sent in this run
+func isDigestDue(preference string, options DigestOptions) bool {
+	if preference == NotificationPreferenceWeekly {
+		return options.Now.Weekday() == options.WeeklyDigestDay
+	}
+	return true
+}
+
+// buildDigestParams groups the notifications by company and CLA group
+func (s *service) buildDigestParams(recipient, preference string, notifications []*Notification) emails.CLAManagerDigestTemplateParams {
+	frequency := NotificationPreferenceDaily
+	if preference == NotificationPreferenceWeekly {
+		frequency = NotificationPreferenceWeekly
+	}
+
+	return emails.CLAManagerDigestTemplateParams{
+		CommonEmailParams: emails.CommonEmailParams{
+			RecipientName:    recipientName(recipient, notifications),
+			RecipientAddress: recipient,
+		},
+		Frequency:     frequency,
+		Sections:      buildDigestSections(notifications),
+		CorporateURL:  s.corporateConsoleURL,
+		Notifications: len(notifications),
+	}
+}
+
+// recipientName returns the latest recipient name of the notifications, or the email address
+func recipientName(recipient string, notifications []*Notification) string {
+	for i := len(notifications) - 1; i >= 0; i-- {
+		if notifications[i].RecipientName != "" {
+			return notifications[i].RecipientName
+		}
+	}
+	return recipient
+}
+
+// buildDigestSections returns one section per company and CLA group sorted by name, the notifications of a section
+// are in date order
+func buildDigestSections(notifications []*Notification) []emails.CLAManagerDigestSection {
+	sorted := make([]*Notification, len(notifications))
+	copy(sorted, notifications)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].NotificationID < sorted[j].NotificationID
+	})
+
+	sectionIndex := map[string]int{}
+	var sections []emails.CLAManagerDigestSection
+	for _, notification := range sorted {
+		key := sectionKey(notification)
+		i, ok := sectionIndex[key]
+		if !ok {
+			i = len(sections)
+			sectionIndex[key] = i
+			sections = append(sections, emails.CLAManagerDigestSection{
+				CompanyName:  notification.CompanyName,
+				CLAGroupName: notification.CLAGroupName,
+			})
+		}
+
+		switch notification.NotificationType {
+		case NotificationTypeApprovalRequest:
+			sections[i].ApprovalRequests = append(sections[i].ApprovalRequests, notification.Message)
+		case NotificationTypeSignature:
+			sections[i].Signatures = append(sections[i].Signatures, notification.Message)
+		case NotificationTypeCLAManagerChange:
+			sections[i].CLAManagerChanges = append(sections[i].CLAManagerChanges, notification.Message)
+		}
+	}
+
+	sort.SliceStable(sections, func(i, j int) bool {
+		if sections[i].CompanyName != sections[j].CompanyName {
+			return sections[i].CompanyName < sections[j].CompanyName
+		}
+		return sections[i].CLAGroupName < sections[j].CLAGroupName
+	})
+	return sections
+}
+
+// sectionKey returns the company and CLA group of the notification, the names are used when the IDs are not known
+func sectionKey(notification *Notification) string {
+	company := notification.CompanyID
+	if company == "" {
+		company = notification.CompanyName
+	}
+	claGroup := notification.CLAGroupID
+	if claGroup == "" {
+		claGroup = notification.CLAGroupName
+	}
+	return company + "#" + claGroup
+}
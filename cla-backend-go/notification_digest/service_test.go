@@ -0,0 +1,84 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notification_digest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/notification_digest"
+	mock_notification_digest "github.com/linuxfoundation/easycla/cla-backend-go/notification_digest/mock"
+	mock_users "github.com/linuxfoundation/easycla/cla-backend-go/users/mocks"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	mock_utils "github.com/linuxfoundation/easycla/cla-backend-go/utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mock_users.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().GetUserByEmail("daily@example.com").
+		Return(&models.User{LfEmail: "daily@example.com", NotificationPreference: notification_digest.NotificationPreferenceDaily}, nil).Times(2)
+	userRepo.EXPECT().GetUserByEmail("weekly@example.com").
+		Return(&models.User{LfEmail: "weekly@example.com", NotificationPreference: notification_digest.NotificationPreferenceWeekly}, nil)
+	userRepo.EXPECT().GetUserByEmail("immediate@example.com").
+		Return(&models.User{LfEmail: "immediate@example.com", NotificationPreference: notification_digest.NotificationPreferenceImmediate}, nil)
+	userRepo.EXPECT().GetUserByEmail("unknown@example.com").Return(nil, errors.New("user not found"))
+
+	repo := mock_notification_digest.NewMockRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().AddNotification(gomock.Any(), &notification_digest.Notification{RecipientEmail: "daily@example.com"}).Return(nil),
+		repo.EXPECT().AddNotification(gomock.Any(), &notification_digest.Notification{RecipientEmail: "weekly@example.com"}).Return(nil),
+		// the notification is sent immediately when it can't be queued
+		repo.EXPECT().AddNotification(gomock.Any(), gomock.Any()).Return(errors.New("unavailable")),
+	)
+
+	s := notification_digest.NewService(repo, userRepo, nil, "https://corporate.lfcla.com")
+	ctx := context.Background()
+	assert.True(t, s.QueueNotification(ctx, &notification_digest.Notification{RecipientEmail: "daily@example.com"}))
+	assert.True(t, s.QueueNotification(ctx, &notification_digest.Notification{RecipientEmail: "weekly@example.com"}))
+	assert.False(t, s.QueueNotification(ctx, &notification_digest.Notification{RecipientEmail: "immediate@example.com"}))
+	assert.False(t, s.QueueNotification(ctx, &notification_digest.Notification{RecipientEmail: "unknown@example.com"}))
+	assert.False(t, s.QueueNotification(ctx, &notification_digest.Notification{RecipientEmail: "daily@example.com"}))
+}
+
+func TestSendDigests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mock_users.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().GetUserByEmail("daily@example.com").
+		Return(&models.User{LfEmail: "daily@example.com", NotificationPreference: notification_digest.NotificationPreferenceDaily}, nil)
+	userRepo.EXPECT().GetUserByEmail("weekly@example.com").
+		Return(&models.User{LfEmail: "weekly@example.com", NotificationPreference: notification_digest.NotificationPreferenceWeekly}, nil)
+
+	repo := mock_notification_digest.NewMockRepository(ctrl)
+	repo.EXPECT().ListRecipients(gomock.Any()).Return([]string{"daily@example.com", "weekly@example.com"}, nil)
+	// the weekly digest isn't due on a tuesday
+	repo.EXPECT().GetNotifications(gomock.Any(), "daily@example.com").Return([]*notification_digest.Notification{
+		{RecipientEmail: "daily@example.com", RecipientName: "Daily Manager", NotificationID: "1", CompanyName: "Alpha", CLAGroupName: "Group",
+			NotificationType: notification_digest.NotificationTypeApprovalRequest, Message: "Alex requested to be added to the approval list"},
+	}, nil)
+	repo.EXPECT().DeleteNotification(gomock.Any(), "daily@example.com", "1").Return(nil)
+
+	emailSender := mock_utils.NewMockEmailSender(ctrl)
+	emailSender.EXPECT().SendEmail(gomock.Any(), gomock.Any(), []string{"daily@example.com"}).DoAndReturn(func(subject, body string, recipients []string) error {
+		assert.Contains(t, body, "Hello Daily Manager")
+		assert.Contains(t, body, "Alex requested to be added to the approval list")
+		return nil
+	})
+	utils.SetEmailSender(emailSender)
+
+	s := notification_digest.NewService(repo, userRepo, nil, "https://corporate.lfcla.com")
+	tuesday := time.Date(2026, 10, 20, 6, 0, 0, 0, time.UTC)
+	result, err := s.SendDigests(context.Background(), notification_digest.DigestOptions{Now: tuesday, WeeklyDigestDay: time.Monday})
+	assert.NoError(t, err)
+	assert.Equal(t, &notification_digest.DigestResult{Recipients: 2, Sent: 1, Deferred: 1, Notifications: 1}, result)
+}
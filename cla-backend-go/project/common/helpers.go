@@ -68,6 +68,22 @@ func BuildCLAGroupDocumentModels(dbDocumentModels []models2.DBProjectDocumentMod
 	return response
 }
 
+// BuildResignPolicyModel builds the re-sign policy response model, returns nil when the CLA Group has no re-sign policy
+func BuildResignPolicyModel(dbModel *models2.DBResignPolicy) *models.ClaGroupResignPolicy {
+	if dbModel == nil {
+		return nil
+	}
+
+	return &models.ClaGroupResignPolicy{
+		DocumentMajorVersion: strconv.Itoa(dbModel.DocumentMajorVersion),
+		DocumentMinorVersion: strconv.Itoa(dbModel.DocumentMinorVersion),
+		Enforcement:          dbModel.Enforcement,
+		GracePeriodEnds:      dbModel.GracePeriodEnds,
+		DateCreated:          dbModel.DateCreated,
+		CreatedBy:            dbModel.CreatedBy,
+	}
+}
+
 // GetCurrentDocument returns the current document based on the version and date/time
 func GetCurrentDocument(ctx context.Context, docs []models.ClaGroupDocument) (models.ClaGroupDocument, error) {
 	f := logrus.Fields{
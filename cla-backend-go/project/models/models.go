@@ -28,6 +28,8 @@ type DBProjectModel struct {
 	ProjectIndividualDocuments       []DBProjectDocumentModel `dynamodbav:"project_individual_documents"`
 	ProjectMemberDocuments           []DBProjectDocumentModel `dynamodbav:"project_member_documents"`
 	ProjectACL                       []string                 `dynamodbav:"project_acl"`
+	ProjectIndividualResignPolicy    *DBResignPolicy          `dynamodbav:"project_individual_resign_policy,omitempty"`
+	ProjectCorporateResignPolicy     *DBResignPolicy          `dynamodbav:"project_corporate_resign_policy,omitempty"`
 }
 
 // DBProjectDocumentModel is a data model for the CLA Group Project documents
@@ -44,3 +46,13 @@ type DBProjectDocumentModel struct {
 	DocumentCreationDate    string                 `dynamodbav:"document_creation_date"`
 	DocumentTabs            []v1Models.DocumentTab `dynamodbav:"document_tabs"`
 }
+
+// DBResignPolicy is a data model for the CLA Group document version requiring re-signature
+type DBResignPolicy struct {
+	DocumentMajorVersion int    `dynamodbav:"document_major_version"`
+	DocumentMinorVersion int    `dynamodbav:"document_minor_version"`
+	Enforcement          string `dynamodbav:"enforcement"`
+	GracePeriodEnds      string `dynamodbav:"grace_period_ends,omitempty"`
+	DateCreated          string `dynamodbav:"date_created"`
+	CreatedBy            string `dynamodbav:"created_by"`
+}
@@ -910,25 +910,27 @@ func (repo *repo) buildCLAGroupModel(ctx context.Context, dbModel models2.DBProj
 	}
 
 	return &models.ClaGroup{
-		ProjectID:                    dbModel.ProjectID,
-		FoundationSFID:               dbModel.FoundationSFID,
-		RootProjectRepositoriesCount: dbModel.RootProjectRepositoriesCount,
-		ProjectExternalID:            dbModel.ProjectExternalID,
-		ProjectName:                  dbModel.ProjectName,
-		ProjectDescription:           dbModel.ProjectDescription,
-		ProjectACL:                   dbModel.ProjectACL,
-		ProjectCCLAEnabled:           dbModel.ProjectCclaEnabled,
-		ProjectICLAEnabled:           dbModel.ProjectIclaEnabled,
-		ProjectCCLARequiresICLA:      dbModel.ProjectCclaRequiresIclaSignature,
-		ProjectTemplateID:            dbModel.ProjectTemplateID,
-		ProjectLive:                  dbModel.ProjectLive,
-		ProjectCorporateDocuments:    common.BuildCLAGroupDocumentModels(dbModel.ProjectCorporateDocuments),
-		ProjectIndividualDocuments:   common.BuildCLAGroupDocumentModels(dbModel.ProjectIndividualDocuments),
-		ProjectMemberDocuments:       common.BuildCLAGroupDocumentModels(dbModel.ProjectMemberDocuments),
-		GithubRepositories:           ghOrgs,
-		Gerrits:                      gerrits,
-		DateCreated:                  dbModel.DateCreated,
-		DateModified:                 dbModel.DateModified,
-		Version:                      dbModel.Version,
+		ProjectID:                     dbModel.ProjectID,
+		FoundationSFID:                dbModel.FoundationSFID,
+		RootProjectRepositoriesCount:  dbModel.RootProjectRepositoriesCount,
+		ProjectExternalID:             dbModel.ProjectExternalID,
+		ProjectName:                   dbModel.ProjectName,
+		ProjectDescription:            dbModel.ProjectDescription,
+		ProjectACL:                    dbModel.ProjectACL,
+		ProjectCCLAEnabled:            dbModel.ProjectCclaEnabled,
+		ProjectICLAEnabled:            dbModel.ProjectIclaEnabled,
+		ProjectCCLARequiresICLA:       dbModel.ProjectCclaRequiresIclaSignature,
+		ProjectTemplateID:             dbModel.ProjectTemplateID,
+		ProjectLive:                   dbModel.ProjectLive,
+		ProjectCorporateDocuments:     common.BuildCLAGroupDocumentModels(dbModel.ProjectCorporateDocuments),
+		ProjectIndividualDocuments:    common.BuildCLAGroupDocumentModels(dbModel.ProjectIndividualDocuments),
+		ProjectMemberDocuments:        common.BuildCLAGroupDocumentModels(dbModel.ProjectMemberDocuments),
+		ProjectIndividualResignPolicy: common.BuildResignPolicyModel(dbModel.ProjectIndividualResignPolicy),
+		ProjectCorporateResignPolicy:  common.BuildResignPolicyModel(dbModel.ProjectCorporateResignPolicy),
+		GithubRepositories:            ghOrgs,
+		Gerrits:                       gerrits,
+		DateCreated:                   dbModel.DateCreated,
+		DateModified:                  dbModel.DateModified,
+		Version:                       dbModel.Version,
 	}
 }
@@ -0,0 +1,165 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+)
+
+// ApprovalExpiryTimeFormat is the format of the approval list entry expiry dates, UTC so the dates sort as strings
+const ApprovalExpiryTimeFormat = "2006-01-02T15:04:05Z"
+
+// ApprovalListEntryExpiry is the resolved expiry of an approval list entry
+type ApprovalListEntryExpiry struct {
+	Criteria  string
+	Value     string
+	ExpiresAt time.Time
+}
+
+// FormatExpiry returns the expiry as stored in the approvals table
+func (e *ApprovalListEntryExpiry) FormatExpiry() string {
+	return e.ExpiresAt.UTC().Format(ApprovalExpiryTimeFormat)
+}
+
+// ResolveApprovalListExpiry validates the expiry requests of the approval list update and resolves them to expiry
+// dates. The entry must be on the approval list of the signature after the update is applied.
+func ResolveApprovalListExpiry(cclaSignature *models.Signature, params *models.ApprovalList, now time.Time) ([]*ApprovalListEntryExpiry, error) {
+	if params == nil || len(params.ApprovalListExpiry) == 0 {
+		return nil, nil
+	}
+
+	var expiries []*ApprovalListEntryExpiry
+	for _, request := range params.ApprovalListExpiry {
+		if request == nil {
+			continue
+		}
+		value := strings.TrimSpace(request.Value)
+		if value == "" {
+			return nil, fmt.Errorf("approval list expiry is missing the entry value")
+		}
+
+		existing, add, remove, ok := approvalListsForCriteria(cclaSignature, params, request.Criteria)
+		if !ok {
+			return nil, fmt.Errorf("approval list expiry criteria: %s is not supported for entry: %s", request.Criteria, value)
+		}
+		if utils.StringInSlice(value, remove) || (!utils.StringInSlice(value, existing) && !utils.StringInSlice(value, add)) {
+			return nil, fmt.Errorf("approval list expiry entry: %s is not on the %s approval list", value, request.Criteria)
+		}
+
+		expiresAt, err := resolveExpiresAt(request.ExpiresAt, request.Duration, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiry for approval list entry: %s - %v", value, err)
+		}
+		if !expiresAt.After(now) {
+			return nil, fmt.Errorf("expiry for approval list entry: %s must be in the future", value)
+		}
+
+		expiries = append(expiries, &ApprovalListEntryExpiry{
+			Criteria:  request.Criteria,
+			Value:     value,
+			ExpiresAt: expiresAt.UTC(),
+		})
+	}
+
+	return expiries, nil
+}
+
+// ParseExpiryDuration parses an expiry duration - a number of days (90d), weeks (12w) or a Go duration such as 36h
+func ParseExpiryDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	var unit time.Duration
+	switch value[len(value)-1] {
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return time.ParseDuration(value)
+	}
+
+	count, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || count <= 0 {
+		return 0, fmt.Errorf("invalid duration: %s", value)
+	}
+	return time.Duration(count) * unit, nil
+}
+
+// resolveExpiresAt returns the expiry of the expiresAt date or of the duration relative to now, exactly one of them
+// must be provided
+func resolveExpiresAt(expiresAt, duration string, now time.Time) (time.Time, error) {
+	expiresAt, duration = strings.TrimSpace(expiresAt), strings.TrimSpace(duration)
+	switch {
+	case expiresAt != "" && duration != "":
+		return time.Time{}, fmt.Errorf("only one of expiresAt or duration may be provided")
+	case expiresAt != "":
+		if t, err := time.Parse(time.RFC3339, expiresAt); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse("2006-01-02", expiresAt)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("expiresAt: %s is neither an RFC3339 timestamp nor a YYYY-MM-DD date", expiresAt)
+		}
+		return t, nil
+	case duration != "":
+		d, err := ParseExpiryDuration(duration)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(d), nil
+	default:
+		return time.Time{}, fmt.Errorf("one of expiresAt or duration is required")
+	}
+}
+
+// approvalListsForCriteria returns the current, added and removed entries of the approval list of the criteria, the
+// email regex and gerrit group lists don't support expiry
+func approvalListsForCriteria(cclaSignature *models.Signature, params *models.ApprovalList, criteria string) ([]string, []string, []string, bool) {
+	switch criteria {
+	case utils.EmailApprovalCriteria:
+		return cclaSignature.EmailApprovalList, params.AddEmailApprovalList, params.RemoveEmailApprovalList, true
+	case utils.DomainApprovalCriteria:
+		return cclaSignature.DomainApprovalList, params.AddDomainApprovalList, params.RemoveDomainApprovalList, true
+	case utils.GithubUsernameApprovalCriteria:
+		return cclaSignature.GithubUsernameApprovalList, params.AddGithubUsernameApprovalList, params.RemoveGithubUsernameApprovalList, true
+	case utils.GithubOrgApprovalCriteria:
+		return cclaSignature.GithubOrgApprovalList, params.AddGithubOrgApprovalList, params.RemoveGithubOrgApprovalList, true
+	case utils.GitlabUsernameApprovalCriteria:
+		return cclaSignature.GitlabUsernameApprovalList, params.AddGitlabUsernameApprovalList, params.RemoveGitlabUsernameApprovalList, true
+	case utils.GitlabOrgApprovalCriteria:
+		return cclaSignature.GitlabOrgApprovalList, params.AddGitlabOrgApprovalList, params.RemoveGitlabOrgApprovalList, true
+	}
+	return nil, nil, nil, false
+}
+
+// RemovalRequestFor builds the approval list update which removes the expired entries
+func RemovalRequestFor(expired []*ApprovalListEntryExpiry) *models.ApprovalList {
+	params := &models.ApprovalList{}
+	for _, entry := range expired {
+		switch entry.Criteria {
+		case utils.EmailApprovalCriteria:
+			params.RemoveEmailApprovalList = append(params.RemoveEmailApprovalList, entry.Value)
+		case utils.DomainApprovalCriteria:
+			params.RemoveDomainApprovalList = append(params.RemoveDomainApprovalList, entry.Value)
+		case utils.GithubUsernameApprovalCriteria:
+			params.RemoveGithubUsernameApprovalList = append(params.RemoveGithubUsernameApprovalList, entry.Value)
+		case utils.GithubOrgApprovalCriteria:
+			params.RemoveGithubOrgApprovalList = append(params.RemoveGithubOrgApprovalList, entry.Value)
+		case utils.GitlabUsernameApprovalCriteria:
+			params.RemoveGitlabUsernameApprovalList = append(params.RemoveGitlabUsernameApprovalList, entry.Value)
+		case utils.GitlabOrgApprovalCriteria:
+			params.RemoveGitlabOrgApprovalList = append(params.RemoveGitlabOrgApprovalList, entry.Value)
+		}
+	}
+	return params
+}
@@ -0,0 +1,91 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpiryDuration(t *testing.T) {
+	d, err := ParseExpiryDuration("90d")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*24*time.Hour, d)
+
+	d, err = ParseExpiryDuration("2w")
+	assert.NoError(t, err)
+	assert.Equal(t, 14*24*time.Hour, d)
+
+	d, err = ParseExpiryDuration("36h")
+	assert.NoError(t, err)
+	assert.Equal(t, 36*time.Hour, d)
+
+	for _, value := range []string{"", "0d", "-1w", "xd", "soon"} {
+		_, err = ParseExpiryDuration(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestResolveApprovalListExpiry(t *testing.T) {
+	now := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	cclaSignature := &models.Signature{
+		EmailApprovalList:          []string{"existing@example.org", "removed@example.org"},
+		GithubUsernameApprovalList: []string{"contractor"},
+	}
+	params := &models.ApprovalList{
+		AddDomainApprovalList:   []string{"example.com"},
+		RemoveEmailApprovalList: []string{"removed@example.org"},
+		ApprovalListExpiry: []*models.ApprovalListExpiry{
+			{Criteria: "email", Value: " existing@example.org ", ExpiresAt: "2026-12-31"},
+			{Criteria: "domain", Value: "example.com", Duration: "90d"},
+			{Criteria: "githubUsername", Value: "contractor", ExpiresAt: "2026-11-01T12:00:00+02:00"},
+		},
+	}
+
+	expiries, err := ResolveApprovalListExpiry(cclaSignature, params, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []*ApprovalListEntryExpiry{
+		{Criteria: "email", Value: "existing@example.org", ExpiresAt: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{Criteria: "domain", Value: "example.com", ExpiresAt: time.Date(2027, 1, 17, 6, 0, 0, 0, time.UTC)},
+		{Criteria: "githubUsername", Value: "contractor", ExpiresAt: time.Date(2026, 11, 1, 10, 0, 0, 0, time.UTC)},
+	}, expiries)
+	assert.Equal(t, "2026-11-01T10:00:00Z", expiries[2].FormatExpiry())
+
+	invalid := []*models.ApprovalListExpiry{
+		{Criteria: "email", Value: "removed@example.org", Duration: "1d"},
+		{Criteria: "email", Value: "unknown@example.org", Duration: "1d"},
+		{Criteria: "emailRegex", Value: ".*", Duration: "1d"},
+		{Criteria: "email", Value: "existing@example.org"},
+		{Criteria: "email", Value: "existing@example.org", Duration: "1d", ExpiresAt: "2026-12-31"},
+		{Criteria: "email", Value: "existing@example.org", ExpiresAt: "2026-01-01"},
+		{Criteria: "email", Value: "existing@example.org", ExpiresAt: "31/12/2026"},
+		{Criteria: "email", Duration: "1d"},
+	}
+	for _, expiry := range invalid {
+		params.ApprovalListExpiry = []*models.ApprovalListExpiry{expiry}
+		_, err = ResolveApprovalListExpiry(cclaSignature, params, now)
+		assert.Error(t, err, "%+v", expiry)
+	}
+
+	expiries, err = ResolveApprovalListExpiry(cclaSignature, &models.ApprovalList{}, now)
+	assert.NoError(t, err)
+	assert.Empty(t, expiries)
+}
+
+func TestRemovalRequestFor(t *testing.T) {
+	params := RemovalRequestFor([]*ApprovalListEntryExpiry{
+		{Criteria: "email", Value: "a@example.org"},
+		{Criteria: "domain", Value: "example.com"},
+		{Criteria: "githubOrg", Value: "org"},
+		{Criteria: "gitlabUsername", Value: "user"},
+	})
+	assert.Equal(t, []string{"a@example.org"}, params.RemoveEmailApprovalList)
+	assert.Equal(t, []string{"example.com"}, params.RemoveDomainApprovalList)
+	assert.Equal(t, []string{"org"}, params.RemoveGithubOrgApprovalList)
+	assert.Equal(t, []string{"user"}, params.RemoveGitlabUsernameApprovalList)
+	assert.Empty(t, params.AddEmailApprovalList)
+}
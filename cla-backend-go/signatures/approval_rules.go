@@ -0,0 +1,41 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"github.com/linuxfoundation/easycla/cla-backend-go/approval_rules"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+)
+
+// NewApprovalActor converts the user model into an approval rule actor - the LF email is included in the list of emails
+func NewApprovalActor(user *models.User) *approval_rules.Actor {
+	emails := user.Emails
+	if user.LfEmail != "" {
+		emails = utils.RemoveDuplicates(append(emails, user.LfEmail.String()))
+	}
+
+	return &approval_rules.Actor{
+		UserID:         user.UserID,
+		GitHubUsername: user.GithubUsername,
+		GitLabUsername: user.GitlabUsername,
+		LFUsername:     user.LfUsername,
+		Emails:         emails,
+	}
+}
+
+// NewApprovalLists converts the CCLA signature approval lists into the approval rule model
+func NewApprovalLists(cclaSignature *models.Signature) *approval_rules.ApprovalLists {
+	return &approval_rules.ApprovalLists{
+		SignatureID:                cclaSignature.SignatureID,
+		EmailApprovalList:          cclaSignature.EmailApprovalList,
+		EmailRegexApprovalList:     cclaSignature.EmailRegexApprovalList,
+		DomainApprovalList:         cclaSignature.DomainApprovalList,
+		GitHubUsernameApprovalList: cclaSignature.GithubUsernameApprovalList,
+		GitHubOrgApprovalList:      cclaSignature.GithubOrgApprovalList,
+		GitLabUsernameApprovalList: cclaSignature.GitlabUsernameApprovalList,
+		GitLabGroupApprovalList:    cclaSignature.GitlabOrgApprovalList,
+		GerritGroupApprovalList:    cclaSignature.GerritGroupApprovalList,
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/approval_rules"
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// GetGitHubPullRequestCLAReport runs the CLA check of the pull request in dry-run mode - the same commit author lookup,
+// signature checks, approval list rules and skip_cla bot allowlist are evaluated as when the pull request is updated,
+// but no comments or statuses are posted and no events are logged
+func (s service) GetGitHubPullRequestCLAReport(ctx context.Context, projectSFID, repositoryID string, pullRequestID int64) (*v2Models.ChangeRequestReport, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.service.GetGitHubPullRequestCLAReport",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+		"repositoryID":   repositoryID,
+		"pullRequestID":  pullRequestID,
+	}
+
+	claRepository, repoErr := s.repositoryService.GetRepository(ctx, repositoryID)
+	if repoErr != nil {
+		log.WithFields(f).WithError(repoErr).Warnf("unable to fetch repository by ID: %s", repositoryID)
+		return nil, repoErr
+	}
+	if claRepository.RepositoryProjectSfid != projectSFID {
+		log.WithFields(f).Warnf("repository: %s is not associated with project: %s", repositoryID, projectSFID)
+		return nil, &utils.GitHubRepositoryNotFound{
+			Message:        fmt.Sprintf("repository not associated with project: %s", projectSFID),
+			RepositoryName: claRepository.RepositoryName,
+		}
+	}
+
+	ghOrg, ghOrgErr := s.githubOrgService.GetGitHubOrganizationByName(ctx, claRepository.RepositoryOrganizationName)
+	if ghOrgErr != nil {
+		log.WithFields(f).WithError(ghOrgErr).Warnf("unable to lookup GitHub organization by name: %s", claRepository.RepositoryOrganizationName)
+		return nil, ghOrgErr
+	}
+	if ghOrg == nil {
+		msg := fmt.Sprintf("unable to lookup GitHub organization by name: %s", claRepository.RepositoryOrganizationName)
+		log.WithFields(f).Warn(msg)
+		return nil, errors.New(msg)
+	}
+
+	claGroupID := claRepository.RepositoryClaGroupID
+	report := &v2Models.ChangeRequestReport{
+		RepositoryType:  v2Models.ChangeRequestReportRepositoryTypeGithub,
+		RepositoryID:    claRepository.RepositoryID,
+		RepositoryName:  claRepository.RepositoryName,
+		ChangeRequestID: pullRequestID,
+		ClaGroupID:      claGroupID,
+		Passed:          true,
+	}
+
+	githubRepository, ghErr := github.GetGitHubRepository(ctx, ghOrg.OrganizationInstallationID, claRepository.RepositoryExternalID)
+	if ghErr != nil {
+		log.WithFields(f).WithError(ghErr).Warn("unable to get github repository")
+		return nil, ghErr
+	}
+	if githubRepository == nil || githubRepository.Name == nil || githubRepository.Owner == nil || githubRepository.Owner.Login == nil {
+		msg := fmt.Sprintf("unable to get github repository - missing repository name or owner name for repository ID: %d", claRepository.RepositoryExternalID)
+		log.WithFields(f).Warn(msg)
+		return nil, errors.New(msg)
+	}
+
+	gitHubOrgName := utils.StringValue(githubRepository.Owner.Login)
+	gitHubRepoName := utils.StringValue(githubRepository.Name)
+
+	log.WithFields(f).Debugf("fetching commit authors for PR: %d using repository owner: %s, repo: %s", pullRequestID, gitHubOrgName, gitHubRepoName)
+	authors, latestSHA, authorsErr := github.GetPullRequestCommitAuthors(ctx, ghOrg.OrganizationInstallationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, commit_identity.PolicyOf(ghOrg.CommitIdentityPolicy))
+	if authorsErr != nil {
+		log.WithFields(f).WithError(authorsErr).Warnf("unable to get commit authors for %s/%s for PR: %d", gitHubOrgName, gitHubRepoName, pullRequestID)
+		return nil, authorsErr
+	}
+	report.LatestSHA = utils.StringValue(latestSHA)
+
+	for _, userSummary := range authors {
+		authorReport := s.getCommitAuthorCLAReport(ctx, f, claGroupID, userSummary)
+		if !authorReport.Signed {
+			// same as SkipAllowlistedBots, only the authors missing a CLA are checked against the bot allowlist
+			if pattern, ok := github.FindAllowlistedBotPattern(ghOrg, gitHubRepoName, userSummary); ok {
+				authorReport.BotAllowlisted = true
+				authorReport.BotAllowlistPattern = pattern
+				authorReport.Signed = true
+				authorReport.Reason = fmt.Sprintf("%s - skipped by the skip_cla bot allowlist pattern: %s", authorReport.Reason, pattern)
+			}
+		}
+		if !authorReport.Signed {
+			report.Passed = false
+		}
+		report.Authors = append(report.Authors, authorReport)
+	}
+
+	log.WithFields(f).Debugf("pull request: %d CLA check passed: %t for %d commit authors", pullRequestID, report.Passed, len(report.Authors))
+	return report, nil
+}
+
+// getCommitAuthorCLAReport evaluates the CLA status of a single commit author, recording each step of the check
+func (s service) getCommitAuthorCLAReport(ctx context.Context, f logrus.Fields, claGroupID string, userSummary *github.UserCommitSummary) *v2Models.ChangeRequestAuthorReport {
+	authorReport := &v2Models.ChangeRequestAuthorReport{
+		Sha:         userSummary.SHA,
+		AuthorID:    userSummary.GetCommitAuthorID(),
+		AuthorLogin: userSummary.GetCommitAuthorUsername(),
+		AuthorEmail: userSummary.GetCommitAuthorEmail(),
+		Role:        userSummary.Role,
+	}
+	if userSummary.CommitAuthor != nil {
+		authorReport.AuthorName = utils.StringValue(userSummary.CommitAuthor.Name)
+	}
+
+	if !userSummary.IsValid() {
+		authorReport.Reason = "commit author is missing the GitHub user ID or login"
+		return authorReport
+	}
+
+	user, userLookup := s.findCommitAuthorUser(f, userSummary)
+	if user == nil {
+		authorReport.Reason = "no EasyCLA user record found for the commit author GitHub ID, username or email"
+		return authorReport
+	}
+	authorReport.UserID = user.UserID
+	authorReport.UserLookup = userLookup
+
+	s.populateCLAReport(ctx, claGroupID, user, authorReport)
+	return authorReport
+}
+
+// populateCLAReport runs the ICLA, CCLA, ECLA, re-sign policy and approval list checks for the user, following the same order
+// as HasUserSigned, and records the outcome in the author report
+func (s service) populateCLAReport(ctx context.Context, claGroupID string, user *models.User, authorReport *v2Models.ChangeRequestAuthorReport) {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.service.populateCLAReport",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"userID":         user.UserID,
+	}
+	approved := true
+	signed := true
+
+	iclaSignature, iclaErr := s.GetIndividualSignature(ctx, claGroupID, user.UserID, &approved, &signed)
+	if iclaErr != nil {
+		log.WithFields(f).WithError(iclaErr).Warn("problem checking for ICLA signature")
+		authorReport.Reason = fmt.Sprintf("problem checking for ICLA signature: %v", iclaErr)
+		return
+	}
+	iclaReason := "user has not signed an ICLA"
+	if iclaSignature != nil {
+		authorReport.IclaSignatureID = iclaSignature.SignatureID
+		blocked, blockedErr := s.isICLASignatureBlocked(ctx, iclaSignature, claGroupID)
+		if blockedErr != nil {
+			log.WithFields(f).WithError(blockedErr).Warnf("problem checking the re-sign policy for ICLA signature: %s", iclaSignature.SignatureID)
+			authorReport.Reason = fmt.Sprintf("problem checking the re-sign policy for ICLA signature: %s - %v", iclaSignature.SignatureID, blockedErr)
+			return
+		}
+		if !blocked {
+			authorReport.Signed = true
+			authorReport.Reason = fmt.Sprintf("user has signed ICLA signature: %s", iclaSignature.SignatureID)
+			return
+		}
+		iclaReason = fmt.Sprintf("ICLA signature: %s was signed on an outdated document version: %s.%s",
+			iclaSignature.SignatureID, iclaSignature.SignatureDocumentMajorVersion, iclaSignature.SignatureDocumentMinorVersion)
+	}
+
+	if user.CompanyID == "" {
+		authorReport.Reason = fmt.Sprintf("%s and the user is not affiliated with a company", iclaReason)
+		return
+	}
+	authorReport.CompanyID = user.CompanyID
+
+	companyModel, companyErr := s.companyService.GetCompany(ctx, user.CompanyID)
+	if companyErr != nil {
+		log.WithFields(f).WithError(companyErr).Warnf("problem looking up company: %s", user.CompanyID)
+		authorReport.Reason = fmt.Sprintf("problem looking up company: %s - %v", user.CompanyID, companyErr)
+		return
+	}
+
+	claGroupModel, claGroupErr := s.claGroupService.GetCLAGroupByID(ctx, claGroupID)
+	if claGroupErr != nil {
+		log.WithFields(f).WithError(claGroupErr).Warnf("problem looking up CLA group: %s", claGroupID)
+		authorReport.Reason = fmt.Sprintf("problem looking up CLA group: %s - %v", claGroupID, claGroupErr)
+		return
+	}
+
+	cclaSignature, cclaErr := s.GetCorporateSignature(ctx, claGroupID, user.CompanyID, &approved, &signed)
+	if cclaErr != nil {
+		log.WithFields(f).WithError(cclaErr).Warnf("problem looking up CCLA signature for company: %s", user.CompanyID)
+		authorReport.Reason = fmt.Sprintf("problem looking up CCLA signature for company: %s - %v", user.CompanyID, cclaErr)
+		return
+	}
+	if cclaSignature == nil {
+		authorReport.Reason = fmt.Sprintf("company: %s has not signed a CCLA for the CLA group", companyModel.CompanyName)
+		return
+	}
+	authorReport.CclaSignatureID = cclaSignature.SignatureID
+	if IsSignatureBlocked(cclaSignature, ResignPolicyFor(claGroupModel, utils.ClaTypeCCLA), time.Now()) {
+		authorReport.Reason = fmt.Sprintf("CCLA signature: %s was signed on an outdated document version: %s.%s",
+			cclaSignature.SignatureID, cclaSignature.SignatureDocumentMajorVersion, cclaSignature.SignatureDocumentMinorVersion)
+		return
+	}
+
+	eclaSignature, eclaErr := s.getEmployeeSignature(ctx, companyModel, claGroupModel, user)
+	if eclaErr != nil {
+		log.WithFields(f).WithError(eclaErr).Warn("problem looking up employee signature")
+		authorReport.Reason = fmt.Sprintf("problem looking up employee acknowledgement: %v", eclaErr)
+		return
+	}
+	if eclaSignature != nil {
+		authorReport.EclaSignatureID = eclaSignature.SignatureID
+	}
+
+	decision := s.GetUserApprovalDecision(ctx, user, cclaSignature)
+	PopulateApprovalDecision(authorReport, decision)
+
+	switch {
+	case eclaSignature == nil:
+		authorReport.Reason = fmt.Sprintf("user has not confirmed the affiliation with company: %s (missing employee acknowledgement)", companyModel.CompanyName)
+	case !decision.Approved:
+		authorReport.Reason = fmt.Sprintf("user is not in the approval list of CCLA signature: %s", cclaSignature.SignatureID)
+	default:
+		authorReport.Signed = true
+		authorReport.Reason = fmt.Sprintf("user has acknowledged the CCLA of company: %s and is in the approval list", companyModel.CompanyName)
+	}
+}
+
+// PopulateApprovalDecision copies the approval rule decision into the author report
+func PopulateApprovalDecision(authorReport *v2Models.ChangeRequestAuthorReport, decision *approval_rules.Decision) {
+	authorReport.ApprovalListMatched = decision.Approved
+	authorReport.ApprovalListExplanation = decision.Explain()
+	if decision.MatchedRule != nil {
+		authorReport.ApprovalListRule = decision.MatchedRule.RuleType
+		authorReport.ApprovalListEntry = decision.MatchedRule.Entry
+	}
+}
+
+// getEmployeeSignature returns the employee acknowledgement signature of the user, if any
+func (s service) getEmployeeSignature(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, user *models.User) (*models.Signature, error) {
+	var wg sync.WaitGroup
+	resultChannel := make(chan *EmployeeModel, 1)
+	errorChannel := make(chan error, 1)
+
+	wg.Add(1)
+	go s.repo.GetProjectCompanyEmployeeSignature(ctx, companyModel, claGroupModel, user, &wg, resultChannel, errorChannel)
+	wg.Wait()
+	close(resultChannel)
+	close(errorChannel)
+
+	if err, ok := <-errorChannel; ok {
+		return nil, err
+	}
+	if result, ok := <-resultChannel; ok && result != nil {
+		return result.Signature, nil
+	}
+	return nil, nil
+}
@@ -21,8 +21,52 @@ const SignatureGitlabUsernameApprovalListColumn = "gitlab_username_approval_list
 // SignatureGitlabOrgApprovalListColumn is the name of the signature column for gitlab organization approval lists
 const SignatureGitlabOrgApprovalListColumn = "gitlab_org_approval_list" // nolint G101: Potential hardcoded credentials (gosec)
 
+// SignatureEmailRegexApprovalListColumn is the name of the signature column for email regex approval lists
+const SignatureEmailRegexApprovalListColumn = "email_regex_approval_list"
+
+// SignatureGerritGroupApprovalListColumn is the name of the signature column for LF LDAP (Gerrit) group approval lists
+const SignatureGerritGroupApprovalListColumn = "gerrit_group_approval_list"
+
+// SystemUsername is the user of the approval list updates made by the scheduled jobs, e.g. the expiry of entries
+const SystemUsername = "easycla system"
+
 // SignatureUserGitHubUsername is the name of the signature column for user gitlab username
 const SignatureUserGitHubUsername = "user_github_username"
 
 // SignatureUserGitlabUsername is the name of the signature column for user gitlab username
 const SignatureUserGitlabUsername = "user_gitlab_username"
+
+// User lookup methods reported by the change request CLA report
+const (
+	// UserLookupGitHubID indicates the user record was matched by GitHub user ID
+	UserLookupGitHubID = "github_id"
+	// UserLookupGitHubUsername indicates the user record was matched by GitHub username
+	UserLookupGitHubUsername = "github_username"
+	// UserLookupGitLabID indicates the user record was matched by GitLab user ID
+	UserLookupGitLabID = "gitlab_id"
+	// UserLookupGitLabUsername indicates the user record was matched by GitLab username
+	UserLookupGitLabUsername = "gitlab_username"
+	// UserLookupEmail indicates the user record was matched by email
+	UserLookupEmail = "email"
+)
+
+// Envelope statuses tracked on signatures awaiting a signatory
+const (
+	// EnvelopeStatusSent indicates the envelope was sent and is waiting for the signatory
+	EnvelopeStatusSent = "Sent"
+	// EnvelopeStatusDelivered indicates the signatory opened the envelope
+	EnvelopeStatusDelivered = "Delivered"
+	// EnvelopeStatusStale indicates the envelope has been waiting for the signatory longer than expected
+	EnvelopeStatusStale = "Stale"
+	// EnvelopeStatusCompleted indicates the envelope was signed
+	EnvelopeStatusCompleted = "Completed"
+	// EnvelopeStatusDeclined indicates the signatory declined to sign
+	EnvelopeStatusDeclined = "Declined"
+	// EnvelopeStatusVoided indicates the envelope was cancelled before it was signed
+	EnvelopeStatusVoided = "Voided"
+)
+
+// EnvelopePending is the signature_envelope_pending value of the signatures waiting on the signatory. The attribute is
+// the partition key of the sparse pending envelope index and is removed once the envelope is completed, declined or
+// voided.
+const EnvelopePending = "pending"
@@ -69,6 +69,7 @@ func (repo repository) buildProjectSignatureModels(ctx context.Context, results
 			ClaType:                       claType,
 			SignatureCreated:              dbSignature.DateCreated,
 			SignatureModified:             dbSignature.DateModified,
+			SignatureInvalidatedOn:        dbSignature.SignatureInvalidatedOn,
 			SignatureType:                 dbSignature.SignatureType,
 			SignatureReferenceID:          dbSignature.SignatureReferenceID,
 			SignatureReferenceName:        dbSignature.SignatureReferenceName,
@@ -89,6 +90,8 @@ func (repo repository) buildProjectSignatureModels(ctx context.Context, results
 			GithubOrgApprovalList:         utils.GetNilSliceIfEmpty(dbSignature.GitHubOrgApprovalList),
 			GitlabUsernameApprovalList:    utils.GetNilSliceIfEmpty(dbSignature.GitlabUsernameApprovalList),
 			GitlabOrgApprovalList:         utils.GetNilSliceIfEmpty(dbSignature.GitlabOrgApprovalList),
+			EmailRegexApprovalList:        utils.GetNilSliceIfEmpty(dbSignature.EmailRegexApprovalList),
+			GerritGroupApprovalList:       utils.GetNilSliceIfEmpty(dbSignature.GerritGroupApprovalList),
 			UserName:                      dbSignature.UserName,
 			UserLFID:                      dbSignature.UserLFUsername,
 			UserGHID:                      dbSignature.UserGithubID,
@@ -105,6 +108,10 @@ func (repo repository) buildProjectSignatureModels(ctx context.Context, results
 			SignatureReturnURL:            dbSignature.SignatureReturnURL,
 			SignatureReturnURLType:        dbSignature.SignatureReturnURLType,
 			SignatureEnvelopeID:           dbSignature.SignatureEnvelopeID,
+			SignatureEnvelopeSentOn:       dbSignature.SignatureEnvelopeSentOn,
+			SignatureEnvelopeStatus:       dbSignature.SignatureEnvelopeStatus,
+			SignatureEnvelopeStatusReason: dbSignature.SignatureEnvelopeStatusReason,
+			SignatureEnvelopeStatusDate:   dbSignature.SignatureEnvelopeStatusDate,
 		}
 
 		sigs = append(sigs, sig)
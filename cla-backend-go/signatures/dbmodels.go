@@ -31,6 +31,8 @@ type ItemSignature struct {
 	GitHubOrgApprovalList         []string `json:"github_org_whitelist,omitempty"`
 	GitlabUsernameApprovalList    []string `json:"gitlab_username_approval_list,omitempty"`
 	GitlabOrgApprovalList         []string `json:"gitlab_org_approval_list,omitempty"`
+	EmailRegexApprovalList        []string `json:"email_regex_approval_list,omitempty"`
+	GerritGroupApprovalList       []string `json:"gerrit_group_approval_list,omitempty"`
 	SignatureACL                  []string `json:"signature_acl,omitempty"`
 	UserGithubID                  string   `json:"user_github_id,omitempty"`
 	UserGithubUsername            string   `json:"user_github_username,omitempty"`
@@ -46,6 +48,12 @@ type ItemSignature struct {
 	UserDocusignDateSigned        string   `json:"user_docusign_date_signed,omitempty"`
 	AutoCreateECLA                bool     `json:"auto_create_ecla,omitempty"`
 	UserDocusignRawXML            string   `json:"user_docusign_raw_xml,omitempty"`
+	SignatureEnvelopeSentOn       string   `json:"signature_envelope_sent_on,omitempty"`
+	SignatureEnvelopeStatus       string   `json:"signature_envelope_status,omitempty"`
+	SignatureEnvelopeStatusReason string   `json:"signature_envelope_status_reason,omitempty"`
+	SignatureEnvelopeStatusDate   string   `json:"signature_envelope_status_date,omitempty"`
+	SignatureEnvelopePending      string   `json:"signature_envelope_pending,omitempty"`
+	SignatureInvalidatedOn        string   `json:"signature_invalidated_on,omitempty"`
 }
 
 // DBManagersModel is a database model for only the ACL/Manager column
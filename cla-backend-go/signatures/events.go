@@ -218,3 +218,26 @@ func (s service) createEventLogEntries(ctx context.Context, companyModel *models
 		})
 	}
 }
+
+// createApprovalListExpiryEventLogEntries logs an event for each approval list entry expiry
+func (s service) createApprovalListExpiryEventLogEntries(ctx context.Context, eventType, action string, companyModel *models.Company, claGroupModel *models.ClaGroup, userModel *models.User, expiries []*ApprovalListEntryExpiry, projectSFID string) {
+	for _, expiry := range expiries {
+		s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:     eventType,
+			ProjectID:     claGroupModel.ProjectExternalID,
+			ClaGroupModel: claGroupModel,
+			CompanyID:     companyModel.CompanyID,
+			CompanyModel:  companyModel,
+			LfUsername:    userModel.LfUsername,
+			UserID:        userModel.UserID,
+			UserModel:     userModel,
+			ProjectSFID:   projectSFID,
+			EventData: &events.ApprovalListEntryExpiryEventData{
+				Criteria:  expiry.Criteria,
+				Value:     expiry.Value,
+				ExpiresAt: expiry.FormatExpiry(),
+				Action:    action,
+			},
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	context "context"
 	reflect "reflect"
 	sync "sync"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	events "github.com/linuxfoundation/easycla/cla-backend-go/events"
@@ -128,6 +129,21 @@ func (mr *MockSignatureRepositoryMockRecorder) AddUsersDetails(ctx, signatureID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUsersDetails", reflect.TypeOf((*MockSignatureRepository)(nil).AddUsersDetails), ctx, signatureID, userID)
 }
 
+// BackfillPendingEnvelopeSignatures mocks base method.
+func (m *MockSignatureRepository) BackfillPendingEnvelopeSignatures(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillPendingEnvelopeSignatures", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillPendingEnvelopeSignatures indicates an expected call of BackfillPendingEnvelopeSignatures.
+func (mr *MockSignatureRepositoryMockRecorder) BackfillPendingEnvelopeSignatures(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillPendingEnvelopeSignatures", reflect.TypeOf((*MockSignatureRepository)(nil).BackfillPendingEnvelopeSignatures), ctx)
+}
+
 // CreateProjectCompanyEmployeeSignature mocks base method.
 func (m *MockSignatureRepository) CreateProjectCompanyEmployeeSignature(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, employeeUserModel *models.User) error {
 	m.ctrl.T.Helper()
@@ -395,6 +411,36 @@ func (mr *MockSignatureRepositoryMockRecorder) GetItemSignature(ctx, signatureID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemSignature", reflect.TypeOf((*MockSignatureRepository)(nil).GetItemSignature), ctx, signatureID)
 }
 
+// GetOutdatedSignatures mocks base method.
+func (m *MockSignatureRepository) GetOutdatedSignatures(ctx context.Context, claGroupID, claType string, policy *models.ClaGroupResignPolicy) ([]*models.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutdatedSignatures", ctx, claGroupID, claType, policy)
+	ret0, _ := ret[0].([]*models.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutdatedSignatures indicates an expected call of GetOutdatedSignatures.
+func (mr *MockSignatureRepositoryMockRecorder) GetOutdatedSignatures(ctx, claGroupID, claType, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutdatedSignatures", reflect.TypeOf((*MockSignatureRepository)(nil).GetOutdatedSignatures), ctx, claGroupID, claType, policy)
+}
+
+// GetPendingEnvelopeSignatures mocks base method.
+func (m *MockSignatureRepository) GetPendingEnvelopeSignatures(ctx context.Context, sentAfter time.Time, pageSize int64, nextKey *string) (*models.Signatures, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingEnvelopeSignatures", ctx, sentAfter, pageSize, nextKey)
+	ret0, _ := ret[0].(*models.Signatures)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingEnvelopeSignatures indicates an expected call of GetPendingEnvelopeSignatures.
+func (mr *MockSignatureRepositoryMockRecorder) GetPendingEnvelopeSignatures(ctx, sentAfter, pageSize, nextKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingEnvelopeSignatures", reflect.TypeOf((*MockSignatureRepository)(nil).GetPendingEnvelopeSignatures), ctx, sentAfter, pageSize, nextKey)
+}
+
 // GetProjectCompanyEmployeeSignature mocks base method.
 func (m *MockSignatureRepository) GetProjectCompanyEmployeeSignature(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, employeeUserModel *models.User, wg *sync.WaitGroup, resultChannel chan<- *signatures0.EmployeeModel, errorChannel chan<- error) {
 	m.ctrl.T.Helper()
@@ -497,6 +543,21 @@ func (mr *MockSignatureRepositoryMockRecorder) GetSignatureACL(ctx, signatureID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignatureACL", reflect.TypeOf((*MockSignatureRepository)(nil).GetSignatureACL), ctx, signatureID)
 }
 
+// GetSignatureIDsByReference mocks base method.
+func (m *MockSignatureRepository) GetSignatureIDsByReference(ctx context.Context, referenceID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignatureIDsByReference", ctx, referenceID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignatureIDsByReference indicates an expected call of GetSignatureIDsByReference.
+func (mr *MockSignatureRepositoryMockRecorder) GetSignatureIDsByReference(ctx, referenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignatureIDsByReference", reflect.TypeOf((*MockSignatureRepository)(nil).GetSignatureIDsByReference), ctx, referenceID)
+}
+
 // GetUserSignatures mocks base method.
 func (m *MockSignatureRepository) GetUserSignatures(ctx context.Context, params signatures.GetUserSignaturesParams, pageSize int64, projectID *string) (*models.Signatures, error) {
 	m.ctrl.T.Helper()
@@ -585,6 +646,20 @@ func (mr *MockSignatureRepositoryMockRecorder) UpdateApprovalList(ctx, claManage
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApprovalList", reflect.TypeOf((*MockSignatureRepository)(nil).UpdateApprovalList), ctx, claManager, claGroupModel, companyID, params, eventArgs)
 }
 
+// UpdateApprovalListExpiry mocks base method.
+func (m *MockSignatureRepository) UpdateApprovalListExpiry(ctx context.Context, cclaSignature *models.Signature, expiries []*signatures0.ApprovalListEntryExpiry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApprovalListExpiry", ctx, cclaSignature, expiries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApprovalListExpiry indicates an expected call of UpdateApprovalListExpiry.
+func (mr *MockSignatureRepositoryMockRecorder) UpdateApprovalListExpiry(ctx, cclaSignature, expiries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApprovalListExpiry", reflect.TypeOf((*MockSignatureRepository)(nil).UpdateApprovalListExpiry), ctx, cclaSignature, expiries)
+}
+
 // UpdateEnvelopeDetails mocks base method.
 func (m *MockSignatureRepository) UpdateEnvelopeDetails(ctx context.Context, signatureID, envelopeID string, signURL *string) (*models.Signature, error) {
 	m.ctrl.T.Helper()
@@ -600,6 +675,20 @@ func (mr *MockSignatureRepositoryMockRecorder) UpdateEnvelopeDetails(ctx, signat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvelopeDetails", reflect.TypeOf((*MockSignatureRepository)(nil).UpdateEnvelopeDetails), ctx, signatureID, envelopeID, signURL)
 }
 
+// UpdateEnvelopeStatus mocks base method.
+func (m *MockSignatureRepository) UpdateEnvelopeStatus(ctx context.Context, signatureID, envelopeID, status, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEnvelopeStatus", ctx, signatureID, envelopeID, status, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEnvelopeStatus indicates an expected call of UpdateEnvelopeStatus.
+func (mr *MockSignatureRepositoryMockRecorder) UpdateEnvelopeStatus(ctx, signatureID, envelopeID, status, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvelopeStatus", reflect.TypeOf((*MockSignatureRepository)(nil).UpdateEnvelopeStatus), ctx, signatureID, envelopeID, status, reason)
+}
+
 // UpdateSignature mocks base method.
 func (m *MockSignatureRepository) UpdateSignature(ctx context.Context, signatureID string, updates map[string]interface{}) error {
 	m.ctrl.T.Helper()
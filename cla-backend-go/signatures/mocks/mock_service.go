@@ -10,11 +10,14 @@ package mock_signatures
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	auth "github.com/LF-Engineering/lfx-kit/auth"
 	gomock "github.com/golang/mock/gomock"
+	approval_rules "github.com/linuxfoundation/easycla/cla-backend-go/approval_rules"
 	models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	signatures "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
+	models0 "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
 	signatures0 "github.com/linuxfoundation/easycla/cla-backend-go/signatures"
 )
 
@@ -130,6 +133,21 @@ func (mr *MockSignatureServiceMockRecorder) DeleteGithubOrganizationFromApproval
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGithubOrganizationFromApprovalList", reflect.TypeOf((*MockSignatureService)(nil).DeleteGithubOrganizationFromApprovalList), ctx, signatureID, approvalListParams, githubAccessToken)
 }
 
+// ExpireApprovalListEntries mocks base method.
+func (m *MockSignatureService) ExpireApprovalListEntries(ctx context.Context, signatureID string, expired []*signatures0.ApprovalListEntryExpiry) (*models.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireApprovalListEntries", ctx, signatureID, expired)
+	ret0, _ := ret[0].(*models.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireApprovalListEntries indicates an expected call of ExpireApprovalListEntries.
+func (mr *MockSignatureServiceMockRecorder) ExpireApprovalListEntries(ctx, signatureID, expired interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireApprovalListEntries", reflect.TypeOf((*MockSignatureService)(nil).ExpireApprovalListEntries), ctx, signatureID, expired)
+}
+
 // GetCCLASignatures mocks base method.
 func (m *MockSignatureService) GetCCLASignatures(ctx context.Context, signed, approved *bool) ([]*signatures0.ItemSignature, error) {
 	m.ctrl.T.Helper()
@@ -250,6 +268,21 @@ func (mr *MockSignatureServiceMockRecorder) GetCorporateSignatures(ctx, claGroup
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCorporateSignatures", reflect.TypeOf((*MockSignatureService)(nil).GetCorporateSignatures), ctx, claGroupID, companyID, approved, signed)
 }
 
+// GetGitHubPullRequestCLAReport mocks base method.
+func (m *MockSignatureService) GetGitHubPullRequestCLAReport(ctx context.Context, projectSFID, repositoryID string, pullRequestID int64) (*models0.ChangeRequestReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGitHubPullRequestCLAReport", ctx, projectSFID, repositoryID, pullRequestID)
+	ret0, _ := ret[0].(*models0.ChangeRequestReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGitHubPullRequestCLAReport indicates an expected call of GetGitHubPullRequestCLAReport.
+func (mr *MockSignatureServiceMockRecorder) GetGitHubPullRequestCLAReport(ctx, projectSFID, repositoryID, pullRequestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubPullRequestCLAReport", reflect.TypeOf((*MockSignatureService)(nil).GetGitHubPullRequestCLAReport), ctx, projectSFID, repositoryID, pullRequestID)
+}
+
 // GetGithubOrganizationsFromApprovalList mocks base method.
 func (m *MockSignatureService) GetGithubOrganizationsFromApprovalList(ctx context.Context, signatureID, githubAccessToken string) ([]models.GithubOrg, error) {
 	m.ctrl.T.Helper()
@@ -295,6 +328,36 @@ func (mr *MockSignatureServiceMockRecorder) GetIndividualSignatures(ctx, claGrou
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndividualSignatures", reflect.TypeOf((*MockSignatureService)(nil).GetIndividualSignatures), ctx, claGroupID, userID, approved, signed)
 }
 
+// GetOutdatedSignatures mocks base method.
+func (m *MockSignatureService) GetOutdatedSignatures(ctx context.Context, claGroupID, claType string, policy *models.ClaGroupResignPolicy) ([]*models.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutdatedSignatures", ctx, claGroupID, claType, policy)
+	ret0, _ := ret[0].([]*models.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutdatedSignatures indicates an expected call of GetOutdatedSignatures.
+func (mr *MockSignatureServiceMockRecorder) GetOutdatedSignatures(ctx, claGroupID, claType, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutdatedSignatures", reflect.TypeOf((*MockSignatureService)(nil).GetOutdatedSignatures), ctx, claGroupID, claType, policy)
+}
+
+// GetPendingEnvelopeSignatures mocks base method.
+func (m *MockSignatureService) GetPendingEnvelopeSignatures(ctx context.Context, sentAfter time.Time, pageSize int64, nextKey *string) (*models.Signatures, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingEnvelopeSignatures", ctx, sentAfter, pageSize, nextKey)
+	ret0, _ := ret[0].(*models.Signatures)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingEnvelopeSignatures indicates an expected call of GetPendingEnvelopeSignatures.
+func (mr *MockSignatureServiceMockRecorder) GetPendingEnvelopeSignatures(ctx, sentAfter, pageSize, nextKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingEnvelopeSignatures", reflect.TypeOf((*MockSignatureService)(nil).GetPendingEnvelopeSignatures), ctx, sentAfter, pageSize, nextKey)
+}
+
 // GetProjectCompanyEmployeeSignatures mocks base method.
 func (m *MockSignatureService) GetProjectCompanyEmployeeSignatures(ctx context.Context, params signatures.GetProjectCompanyEmployeeSignaturesParams, criteria *signatures0.ApprovalCriteria) (*models.Signatures, error) {
 	m.ctrl.T.Helper()
@@ -370,6 +433,20 @@ func (mr *MockSignatureServiceMockRecorder) GetSignature(ctx, signatureID interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignature", reflect.TypeOf((*MockSignatureService)(nil).GetSignature), ctx, signatureID)
 }
 
+// GetUserApprovalDecision mocks base method.
+func (m *MockSignatureService) GetUserApprovalDecision(ctx context.Context, user *models.User, cclaSignature *models.Signature) *approval_rules.Decision {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserApprovalDecision", ctx, user, cclaSignature)
+	ret0, _ := ret[0].(*approval_rules.Decision)
+	return ret0
+}
+
+// GetUserApprovalDecision indicates an expected call of GetUserApprovalDecision.
+func (mr *MockSignatureServiceMockRecorder) GetUserApprovalDecision(ctx, user, cclaSignature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserApprovalDecision", reflect.TypeOf((*MockSignatureService)(nil).GetUserApprovalDecision), ctx, user, cclaSignature)
+}
+
 // GetUserSignatures mocks base method.
 func (m *MockSignatureService) GetUserSignatures(ctx context.Context, params signatures.GetUserSignaturesParams, projectID *string) (*models.Signatures, error) {
 	m.ctrl.T.Helper()
@@ -431,6 +508,20 @@ func (mr *MockSignatureServiceMockRecorder) ProcessEmployeeSignature(ctx, compan
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessEmployeeSignature", reflect.TypeOf((*MockSignatureService)(nil).ProcessEmployeeSignature), ctx, companyModel, claGroupModel, user)
 }
 
+// RecheckGitHubPullRequest mocks base method.
+func (m *MockSignatureService) RecheckGitHubPullRequest(ctx context.Context, repositoryExternalID, pullRequestID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecheckGitHubPullRequest", ctx, repositoryExternalID, pullRequestID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecheckGitHubPullRequest indicates an expected call of RecheckGitHubPullRequest.
+func (mr *MockSignatureServiceMockRecorder) RecheckGitHubPullRequest(ctx, repositoryExternalID, pullRequestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecheckGitHubPullRequest", reflect.TypeOf((*MockSignatureService)(nil).RecheckGitHubPullRequest), ctx, repositoryExternalID, pullRequestID)
+}
+
 // RemoveCLAManager mocks base method.
 func (m *MockSignatureService) RemoveCLAManager(ctx context.Context, ignatureID, claManagerID string) (*models.Signature, error) {
 	m.ctrl.T.Helper()
@@ -490,6 +581,20 @@ func (mr *MockSignatureServiceMockRecorder) UpdateEnvelopeDetails(ctx, signature
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvelopeDetails", reflect.TypeOf((*MockSignatureService)(nil).UpdateEnvelopeDetails), ctx, signatureID, envelopeID, signURL)
 }
 
+// UpdateEnvelopeStatus mocks base method.
+func (m *MockSignatureService) UpdateEnvelopeStatus(ctx context.Context, signatureID, envelopeID, status, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEnvelopeStatus", ctx, signatureID, envelopeID, status, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEnvelopeStatus indicates an expected call of UpdateEnvelopeStatus.
+func (mr *MockSignatureServiceMockRecorder) UpdateEnvelopeStatus(ctx, signatureID, envelopeID, status, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvelopeStatus", reflect.TypeOf((*MockSignatureService)(nil).UpdateEnvelopeStatus), ctx, signatureID, envelopeID, status, reason)
+}
+
 // UpdateSignature mocks base method.
 func (m *MockSignatureService) UpdateSignature(ctx context.Context, signatureID string, updates map[string]interface{}) error {
 	m.ctrl.T.Helper()
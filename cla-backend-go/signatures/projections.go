@@ -40,6 +40,7 @@ func buildProjection() expression.ProjectionBuilder {
 		expression.Name("user_docusign_date_signed"),
 		expression.Name("user_docusign_name"),
 		expression.Name("auto_create_ecla"),
+		expression.Name("signature_invalidated_on"),
 	)
 }
 
@@ -41,6 +41,7 @@ import (
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
@@ -57,6 +58,7 @@ const (
 	SignatureProjectIDTypeIndex                    = "signature-project-id-type-index"
 	SignatureReferenceIndex                        = "reference-signature-index"
 	SignatureReferenceSearchIndex                  = "reference-signature-search-index"
+	SignatureEnvelopePendingIndex                  = "signature-envelope-pending-index"
 
 	HugePageSize    = 10000
 	DefaultPageSize = 100
@@ -94,8 +96,10 @@ type SignatureRepository interface {
 	GetCompanySignatures(ctx context.Context, params signatures.GetCompanySignaturesParams, pageSize int64, loadACL bool) (*models.Signatures, error)
 	GetCompanyIDsWithSignedCorporateSignatures(ctx context.Context, claGroupID string) ([]SignatureCompanyID, error)
 	GetUserSignatures(ctx context.Context, params signatures.GetUserSignaturesParams, pageSize int64, projectID *string) (*models.Signatures, error)
+	GetSignatureIDsByReference(ctx context.Context, referenceID string) ([]string, error)
 	ProjectSignatures(ctx context.Context, projectID string) (*models.Signatures, error)
 	UpdateApprovalList(ctx context.Context, claManager *models.User, claGroupModel *models.ClaGroup, companyID string, params *models.ApprovalList, eventArgs *events.LogEventArgs) (*models.Signature, error)
+	UpdateApprovalListExpiry(ctx context.Context, cclaSignature *models.Signature, expiries []*ApprovalListEntryExpiry) error
 	AddCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error)
 	RemoveCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error)
 	AddSigTypeSignedApprovedID(ctx context.Context, signatureID string, val string) error
@@ -106,6 +110,10 @@ type SignatureRepository interface {
 	EclaAutoCreate(ctx context.Context, signatureID string, autoCreateECLA bool) error
 	ActivateSignature(ctx context.Context, signatureID string) error
 	GetICLAByDate(ctx context.Context, startDate string) ([]ItemSignature, error)
+	GetPendingEnvelopeSignatures(ctx context.Context, sentAfter time.Time, pageSize int64, nextKey *string) (*models.Signatures, error)
+	BackfillPendingEnvelopeSignatures(ctx context.Context) (int, error)
+	UpdateEnvelopeStatus(ctx context.Context, signatureID, envelopeID, status, reason string) error
+	GetOutdatedSignatures(ctx context.Context, claGroupID, claType string, policy *models.ClaGroupResignPolicy) ([]*models.Signature, error)
 }
 
 type iclaSignatureWithDetails struct {
@@ -710,11 +718,22 @@ func (repo repository) UpdateEnvelopeDetails(ctx context.Context, signatureID, e
 
 	log.WithFields(f).Debugf("setting envelope details....")
 
-	updateExpression := "SET signature_envelope_id = :envelopeId "
+	// A new envelope restarts the envelope lifecycle tracked by the reconciliation job
+	_, currentTime := utils.CurrentTime()
+	updateExpression := "SET signature_envelope_id = :envelopeId, signature_envelope_sent_on = :sentOn, signature_envelope_status = :status, signature_envelope_status_date = :sentOn, signature_envelope_pending = :pending "
 	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
 		":envelopeId": {
 			S: aws.String(envelopeID),
 		},
+		":sentOn": {
+			S: aws.String(currentTime),
+		},
+		":status": {
+			S: aws.String(EnvelopeStatusSent),
+		},
+		":pending": {
+			S: aws.String(EnvelopePending),
+		},
 	}
 
 	if signURL != nil {
@@ -724,6 +743,8 @@ func (repo repository) UpdateEnvelopeDetails(ctx context.Context, signatureID, e
 		}
 	}
 
+	updateExpression += "REMOVE signature_envelope_status_reason"
+
 	// Create the update input
 	input := &dynamodb.UpdateItemInput{
 		TableName: aws.String(repo.signatureTableName),
@@ -761,6 +782,328 @@ func (repo repository) UpdateEnvelopeDetails(ctx context.Context, signatureID, e
 	}, nil
 }
 
+// GetPendingEnvelopeSignatures returns a page of the signatures with an envelope sent after the specified date which
+// has not been completed, declined or voided, from the pending envelope index. Signatures signed since the envelope was
+// sent are included so the reconciliation can remove them from the index. The page size limits the number of evaluated
+// records, use the last key scanned of the result to load the next page.
+func (repo repository) GetPendingEnvelopeSignatures(ctx context.Context, sentAfter time.Time, pageSize int64, nextKey *string) (*models.Signatures, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.repository.GetPendingEnvelopeSignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.signatureTableName,
+		"sentAfter":      sentAfter.String(),
+		"pageSize":       pageSize,
+		"nextKey":        aws.StringValue(nextKey),
+	}
+
+	// signatures sent before the index was added are added to it by BackfillPendingEnvelopeSignatures
+	keyCondition := expression.Key("signature_envelope_pending").Equal(expression.Value(EnvelopePending)).
+		And(expression.Key("signature_envelope_sent_on").GreaterThanEqual(expression.Value(utils.TimeToString(sentAfter))))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for pending envelope signatures query")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.signatureTableName),
+		IndexName:                 aws.String(SignatureEnvelopePendingIndex),
+		Limit:                     aws.Int64(pageSize),
+	}
+	if nextKey != nil && *nextKey != "" {
+		queryInput.ExclusiveStartKey, err = decodeNextKey(*nextKey)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decoding next key value")
+			return nil, err
+		}
+	}
+
+	result, queryErr := repo.dynamoDBClient.Query(queryInput)
+	if queryErr != nil {
+		log.WithFields(f).WithError(queryErr).Warn("error retrieving pending envelope signatures")
+		return nil, queryErr
+	}
+
+	var dbSignatures []ItemSignature
+	if unmarshallErr := dynamodbattribute.UnmarshalListOfMaps(result.Items, &dbSignatures); unmarshallErr != nil {
+		log.WithFields(f).WithError(unmarshallErr).Warn("error unmarshalling pending envelope signatures")
+		return nil, unmarshallErr
+	}
+
+	signatures := make([]*models.Signature, 0, len(dbSignatures))
+	for _, dbSignature := range dbSignatures {
+		signatures = append(signatures, &models.Signature{
+			SignatureID:                   dbSignature.SignatureID,
+			SignatureCreated:              dbSignature.DateCreated,
+			SignatureModified:             dbSignature.DateModified,
+			SignatureType:                 dbSignature.SignatureType,
+			SignatureReferenceID:          dbSignature.SignatureReferenceID,
+			SignatureReferenceName:        dbSignature.SignatureReferenceName,
+			SignatureReferenceType:        dbSignature.SignatureReferenceType,
+			ProjectID:                     dbSignature.SignatureProjectID,
+			SignatureSigned:               dbSignature.SignatureSigned,
+			SignatureEnvelopeID:           dbSignature.SignatureEnvelopeID,
+			SignatureEnvelopeSentOn:       dbSignature.SignatureEnvelopeSentOn,
+			SignatureEnvelopeStatus:       dbSignature.SignatureEnvelopeStatus,
+			SignatureEnvelopeStatusReason: dbSignature.SignatureEnvelopeStatusReason,
+			SignatureEnvelopeStatusDate:   dbSignature.SignatureEnvelopeStatusDate,
+		})
+	}
+
+	lastKeyScanned, err := encodeNextKey(result.LastEvaluatedKey)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to build nextKey")
+		return nil, err
+	}
+
+	log.WithFields(f).Debugf("found %d signatures with pending envelopes", len(signatures))
+	return &models.Signatures{
+		ResultCount:    int64(len(signatures)),
+		LastKeyScanned: lastKeyScanned,
+		Signatures:     signatures,
+	}, nil
+}
+
+// BackfillPendingEnvelopeSignatures adds the unsigned signatures with a pending envelope which are missing from the
+// pending envelope index - envelopes sent before the index was added - to the index. The envelope sent date falls back
+// to the signature creation date. Returns the number of signatures added.
+func (repo repository) BackfillPendingEnvelopeSignatures(ctx context.Context) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.repository.BackfillPendingEnvelopeSignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.signatureTableName,
+	}
+
+	filter := expression.Name("signature_signed").Equal(expression.Value(false)).
+		And(expression.Name("signature_envelope_id").AttributeExists()).
+		And(expression.Name("signature_envelope_pending").AttributeNotExists()).
+		And(expression.Name("signature_envelope_status").AttributeNotExists().
+			Or(expression.Name("signature_envelope_status").In(
+				expression.Value(EnvelopeStatusSent),
+				expression.Value(EnvelopeStatusDelivered),
+				expression.Value(EnvelopeStatusStale))))
+	projection := expression.NamesList(expression.Name("signature_id"), expression.Name("signature_envelope_id"), expression.Name("date_created"))
+
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(projection).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for pending envelope signatures scan")
+		return 0, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.signatureTableName),
+	}
+
+	_, currentTime := utils.CurrentTime()
+	added := 0
+	for {
+		result, scanErr := repo.dynamoDBClient.Scan(scanInput)
+		if scanErr != nil {
+			log.WithFields(f).WithError(scanErr).Warn("error scanning pending envelope signatures")
+			return added, scanErr
+		}
+
+		var dbSignatures []ItemSignature
+		if unmarshallErr := dynamodbattribute.UnmarshalListOfMaps(result.Items, &dbSignatures); unmarshallErr != nil {
+			log.WithFields(f).WithError(unmarshallErr).Warn("error unmarshalling pending envelope signatures")
+			return added, unmarshallErr
+		}
+
+		for _, dbSignature := range dbSignatures {
+			// the index sort key must use the same format as the envelopes sent by UpdateEnvelopeDetails
+			sentOn := currentTime
+			if created, parseErr := utils.ParseDateTime(dbSignature.DateCreated); parseErr == nil {
+				sentOn = utils.TimeToString(created)
+			}
+			_, updateErr := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+				TableName: aws.String(repo.signatureTableName),
+				Key: map[string]*dynamodb.AttributeValue{
+					"signature_id": {S: aws.String(dbSignature.SignatureID)},
+				},
+				// the envelope may have been replaced or completed since the scan
+				ConditionExpression: aws.String("signature_envelope_id = :envelopeId AND signature_signed = :false AND attribute_not_exists(signature_envelope_pending)"),
+				UpdateExpression:    aws.String("SET signature_envelope_pending = :pending, signature_envelope_sent_on = if_not_exists(signature_envelope_sent_on, :sentOn)"),
+				ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+					":envelopeId": {S: aws.String(dbSignature.SignatureEnvelopeID)},
+					":false":      {BOOL: aws.Bool(false)},
+					":pending":    {S: aws.String(EnvelopePending)},
+					":sentOn":     {S: aws.String(sentOn)},
+				},
+			})
+			if updateErr != nil {
+				if aerr, ok := updateErr.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+					log.WithFields(f).Debugf("signature: %s envelope changed, skipping", dbSignature.SignatureID)
+					continue
+				}
+				log.WithFields(f).WithError(updateErr).Warnf("unable to add signature: %s to the pending envelope index", dbSignature.SignatureID)
+				return added, updateErr
+			}
+			added++
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	log.WithFields(f).Debugf("added %d signatures to the pending envelope index", added)
+	return added, nil
+}
+
+// GetOutdatedSignatures returns the signed and approved ICLA or CCLA signatures of the CLA Group made on a document
+// version older than the re-sign policy version
+func (repo repository) GetOutdatedSignatures(ctx context.Context, claGroupID, claType string, policy *models.ClaGroupResignPolicy) ([]*models.Signature, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.repository.GetOutdatedSignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"claType":        claType,
+	}
+
+	condition := expression.Key("signature_project_id").Equal(expression.Value(claGroupID))
+	filter := expression.Name("signature_signed").Equal(expression.Value(true)).
+		And(expression.Name("signature_approved").Equal(expression.Value(true)))
+	switch claType {
+	case utils.ClaTypeICLA:
+		filter = filter.And(expression.Name("signature_type").Equal(expression.Value(utils.SignatureTypeCLA))).
+			And(expression.Name("signature_reference_type").Equal(expression.Value(utils.SignatureReferenceTypeUser))).
+			And(expression.Name("signature_user_ccla_company_id").AttributeNotExists())
+	case utils.ClaTypeCCLA:
+		filter = filter.And(expression.Name("signature_type").Equal(expression.Value(utils.SignatureTypeCCLA))).
+			And(expression.Name("signature_reference_type").Equal(expression.Value(utils.SignatureReferenceTypeCompany)))
+	default:
+		return nil, fmt.Errorf("not supported cla type supplied: %s", claType)
+	}
+
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(filter).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for outdated signatures query")
+		return nil, err
+	}
+
+	var signatures []*models.Signature
+	var lastEvaluatedKey map[string]*dynamodb.AttributeValue
+
+	for {
+		result, queryErr := repo.dynamoDBClient.Query(&dynamodb.QueryInput{
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			KeyConditionExpression:    expr.KeyCondition(),
+			FilterExpression:          expr.Filter(),
+			TableName:                 aws.String(repo.signatureTableName),
+			IndexName:                 aws.String(SignatureProjectIDIndex),
+			ExclusiveStartKey:         lastEvaluatedKey,
+		})
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("error retrieving the CLA group signatures")
+			return nil, queryErr
+		}
+
+		var dbSignatures []ItemSignature
+		if unmarshallErr := dynamodbattribute.UnmarshalListOfMaps(result.Items, &dbSignatures); unmarshallErr != nil {
+			log.WithFields(f).WithError(unmarshallErr).Warn("error unmarshalling the CLA group signatures")
+			return nil, unmarshallErr
+		}
+
+		for _, dbSignature := range dbSignatures {
+			signature := &models.Signature{
+				SignatureID:                   dbSignature.SignatureID,
+				SignatureCreated:              dbSignature.DateCreated,
+				SignatureModified:             dbSignature.DateModified,
+				SignatureType:                 dbSignature.SignatureType,
+				SignatureReferenceID:          dbSignature.SignatureReferenceID,
+				SignatureReferenceName:        dbSignature.SignatureReferenceName,
+				SignatureReferenceType:        dbSignature.SignatureReferenceType,
+				ProjectID:                     dbSignature.SignatureProjectID,
+				SignatureSigned:               dbSignature.SignatureSigned,
+				SignatureApproved:             dbSignature.SignatureApproved,
+				SignatureDocumentMajorVersion: strconv.Itoa(dbSignature.SignatureDocumentMajorVersion),
+				SignatureDocumentMinorVersion: strconv.Itoa(dbSignature.SignatureDocumentMinorVersion),
+				UserName:                      dbSignature.UserName,
+				UserLFID:                      dbSignature.UserLFUsername,
+			}
+			for _, lfUsername := range dbSignature.SignatureACL {
+				signature.SignatureACL = append(signature.SignatureACL, &models.User{LfUsername: lfUsername})
+			}
+			if IsSignatureOutdated(signature, policy) {
+				signatures = append(signatures, signature)
+			}
+		}
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	log.WithFields(f).Debugf("found %d outdated signatures", len(signatures))
+	return signatures, nil
+}
+
+// UpdateEnvelopeStatus records the envelope status and reason on the signature. The update is skipped when the
+// signature has since been assigned a different envelope.
+func (repo repository) UpdateEnvelopeStatus(ctx context.Context, signatureID, envelopeID, status, reason string) error {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.repository.UpdateEnvelopeStatus",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+		"envelopeID":     envelopeID,
+		"status":         status,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	update := expression.Set(expression.Name("signature_envelope_status"), expression.Value(status)).
+		Set(expression.Name("signature_envelope_status_date"), expression.Value(currentTime)).
+		Set(expression.Name("date_modified"), expression.Value(currentTime))
+	if reason != "" {
+		update = update.Set(expression.Name("signature_envelope_status_reason"), expression.Value(reason))
+	} else {
+		update = update.Remove(expression.Name("signature_envelope_status_reason"))
+	}
+	switch status {
+	case EnvelopeStatusCompleted, EnvelopeStatusDeclined, EnvelopeStatusVoided:
+		// the envelope is no longer waiting on the signatory, drop it from the pending envelope index
+		update = update.Remove(expression.Name("signature_envelope_pending"))
+	}
+	condition := expression.Name("signature_envelope_id").Equal(expression.Value(envelopeID))
+
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for envelope status update")
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {S: aws.String(signatureID)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Debug("signature envelope changed, skipping envelope status update")
+			return nil
+		}
+		log.WithFields(f).WithError(err).Warn("error updating signature envelope status")
+		return err
+	}
+
+	return nil
+}
+
 // GetIndividualSignature returns the signature record for the specified CLA Group and User
 func (repo repository) GetIndividualSignature(ctx context.Context, claGroupID, userID string, approved, signed *bool) (*models.Signature, error) {
 	f := logrus.Fields{
@@ -2084,7 +2427,17 @@ func (repo repository) InvalidateProjectRecord(ctx context.Context, signatureID,
 
 	expressionAttributeNames["#S"] = aws.String("note")
 	expressionAttributeValues[":s"] = &dynamodb.AttributeValue{S: aws.String(note)}
-	updateExpression = updateExpression + " #S = :s"
+	updateExpression = updateExpression + " #S = :s,"
+
+	// The invalidation date is recorded on its own, the modified date changes with any later update of the
+	// signature - used by the coverage audit
+	_, currentTime := utils.CurrentTime()
+	expressionAttributeNames["#M"] = aws.String("date_modified")
+	expressionAttributeValues[":m"] = &dynamodb.AttributeValue{S: aws.String(currentTime)}
+	updateExpression = updateExpression + " #M = :m,"
+
+	expressionAttributeNames["#I"] = aws.String("signature_invalidated_on")
+	updateExpression = updateExpression + " #I = :m"
 
 	input := &dynamodb.UpdateItemInput{
 		Key: map[string]*dynamodb.AttributeValue{
@@ -2135,6 +2488,10 @@ func (repo repository) ValidateProjectRecord(ctx context.Context, signatureID, n
 	expressionAttributeValues[":s"] = &dynamodb.AttributeValue{S: aws.String(note)}
 	updateExpression = updateExpression + " #S = :s"
 
+	// The signature is in effect again
+	expressionAttributeNames["#I"] = aws.String("signature_invalidated_on")
+	updateExpression = updateExpression + " REMOVE #I"
+
 	input := &dynamodb.UpdateItemInput{
 		Key: map[string]*dynamodb.AttributeValue{
 			"signature_id": {
@@ -3004,6 +3361,59 @@ func (repo repository) GetUserSignatures(ctx context.Context, params signatures.
 	}, nil
 }
 
+// GetSignatureIDsByReference returns the IDs of all the signatures referencing the user or company ID, including the employee acknowledgements
+func (repo repository) GetSignatureIDsByReference(ctx context.Context, referenceID string) ([]string, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.repository.GetSignatureIDsByReference",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"referenceID":    referenceID,
+	}
+
+	condition := expression.Key("signature_reference_id").Equal(expression.Value(referenceID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithProjection(expression.NamesList(expression.Name("signature_id"))).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for the signature reference query")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.signatureTableName),
+		IndexName:                 aws.String(SignatureReferenceIndex),
+	}
+
+	var signatureIDs []string
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.WithFields(f).WithError(errQuery).Warn("error retrieving the signatures by reference ID")
+			return nil, errQuery
+		}
+
+		var items []struct {
+			SignatureID string `dynamodbav:"signature_id"`
+		}
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &items)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("error unmarshalling the signature IDs")
+			return nil, err
+		}
+		for _, item := range items {
+			signatureIDs = append(signatureIDs, item.SignatureID)
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return signatureIDs, nil
+}
+
 func (repo repository) AddCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error) {
 	f := logrus.Fields{
 		"functionName":   "v1.signatures.repository.AddCLAManager",
@@ -3262,12 +3672,12 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 		log.WithFields(f).Debugf("updating approval list table")
 
 		if params.AddEmailApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddEmailApprovalList, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+			repo.updateApprovalTableEntries(ctx, params, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
 		}
 
 		// if email removal update signature approvals
 		if params.RemoveEmailApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddEmailApprovalList, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+			repo.updateApprovalTableEntries(ctx, params, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
 			log.WithFields(f).Debugf("removing email: %+v the approval list", params.RemoveDomainApprovalList)
 			var wg sync.WaitGroup
 			wg.Add(len(params.RemoveEmailApprovalList))
@@ -3387,7 +3797,7 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 
 		log.WithFields(f).Debugf("updating approval list table")
 		if params.AddDomainApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddDomainApprovalList, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+			repo.updateApprovalTableEntries(ctx, params, utils.DomainApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
 		}
 
 		if params.RemoveDomainApprovalList != nil {
@@ -3427,7 +3837,7 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 			}
 
 			repo.invalidateSignatures(ctx, &approvalList, claManager, eventArgs)
-			repo.updateApprovalTable(ctx, params.AddDomainApprovalList, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+			repo.updateApprovalTableEntries(ctx, params, utils.DomainApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
 		}
 	}
 
@@ -3452,11 +3862,11 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 		}
 
 		if params.AddGithubUsernameApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddGithubUsernameApprovalList, utils.GithubUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+			repo.updateApprovalTableEntries(ctx, params, utils.GithubUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
 		}
 		if params.RemoveGithubUsernameApprovalList != nil {
 
-			repo.updateApprovalTable(ctx, params.AddGithubUsernameApprovalList, utils.GithubUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+			repo.updateApprovalTableEntries(ctx, params, utils.GithubUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
 			// if email removal update signature approvals
 			if params.RemoveGithubUsernameApprovalList != nil {
 				var wg sync.WaitGroup
@@ -3541,7 +3951,7 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 		}
 
 		if params.AddGithubOrgApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddGithubOrgApprovalList, utils.GithubOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+			repo.updateApprovalTableEntries(ctx, params, utils.GithubOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
 		}
 
 		if params.RemoveGithubOrgApprovalList != nil {
@@ -3588,7 +3998,7 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 			approvalList.GitHubUsernames = utils.RemoveDuplicates(ghUsernames)
 
 			repo.invalidateSignatures(ctx, &approvalList, claManager, eventArgs)
-			repo.updateApprovalTable(ctx, params.AddGithubOrgApprovalList, utils.GithubOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+			repo.updateApprovalTableEntries(ctx, params, utils.GithubOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
 		}
 	}
 
@@ -3612,10 +4022,10 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 			updateExpression = updateExpression + " #GLU = :glu, "
 		}
 		if params.AddGitlabUsernameApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddGitlabUsernameApprovalList, utils.GitlabUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+			repo.updateApprovalTableEntries(ctx, params, utils.GitlabUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
 		}
 		if params.RemoveGitlabUsernameApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddGitlabUsernameApprovalList, utils.GitlabUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+			repo.updateApprovalTableEntries(ctx, params, utils.GitlabUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
 			// if email removal update signature approvals
 			if params.RemoveGitlabUsernameApprovalList != nil {
 				approvalList.Criteria = utils.GitlabUsernameCriteria
@@ -3702,11 +4112,11 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 		}
 
 		if params.AddGitlabOrgApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddGitlabOrgApprovalList, utils.GitlabOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+			repo.updateApprovalTableEntries(ctx, params, utils.GitlabOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
 		}
 
 		if params.RemoveGitlabOrgApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddGitlabOrgApprovalList, utils.GitlabOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+			repo.updateApprovalTableEntries(ctx, params, utils.GitlabOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
 			approvalList.Criteria = utils.GitlabOrgCriteria
 			approvalList.ApprovalList = params.RemoveGitlabOrgApprovalList
 			approvalList.Action = utils.RemoveApprovals
@@ -3752,6 +4162,62 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 			repo.invalidateSignatures(ctx, &approvalList, claManager, eventArgs)
 		}
 	}
+	if len(params.AddEmailRegexApprovalList) > 0 || len(params.RemoveEmailRegexApprovalList) > 0 {
+		columnName := SignatureEmailRegexApprovalListColumn
+		attrList := buildApprovalAttributeList(ctx, cclaSignature.EmailRegexApprovalList, params.AddEmailRegexApprovalList, params.RemoveEmailRegexApprovalList)
+		// If no entries after consolidating all the updates, we need to remove the column
+		if attrList == nil || attrList.L == nil {
+			var rmColErr error
+			cclaSignature, rmColErr = repo.removeColumn(ctx, cclaSignature.SignatureID, columnName)
+			if rmColErr != nil {
+				msg := fmt.Sprintf("unable to remove column %s for signature for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
+					columnName, companyID, projectID, true, true)
+				log.WithFields(f).Warn(msg)
+				return nil, errors.New(msg)
+			}
+		} else {
+			haveAdditions = true
+			expressionAttributeNames["#ER"] = aws.String(columnName)
+			expressionAttributeValues[":er"] = attrList
+			updateExpression = updateExpression + " #ER = :er, "
+		}
+
+		if params.AddEmailRegexApprovalList != nil {
+			repo.updateApprovalTableEntries(ctx, params, utils.EmailRegexApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+		}
+		if params.RemoveEmailRegexApprovalList != nil {
+			repo.updateApprovalTableEntries(ctx, params, utils.EmailRegexApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+		}
+	}
+
+	if len(params.AddGerritGroupApprovalList) > 0 || len(params.RemoveGerritGroupApprovalList) > 0 {
+		columnName := SignatureGerritGroupApprovalListColumn
+		attrList := buildApprovalAttributeList(ctx, cclaSignature.GerritGroupApprovalList, params.AddGerritGroupApprovalList, params.RemoveGerritGroupApprovalList)
+		// If no entries after consolidating all the updates, we need to remove the column
+		if attrList == nil || attrList.L == nil {
+			var rmColErr error
+			cclaSignature, rmColErr = repo.removeColumn(ctx, cclaSignature.SignatureID, columnName)
+			if rmColErr != nil {
+				msg := fmt.Sprintf("unable to remove column %s for signature for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
+					columnName, companyID, projectID, true, true)
+				log.WithFields(f).Warn(msg)
+				return nil, errors.New(msg)
+			}
+		} else {
+			haveAdditions = true
+			expressionAttributeNames["#GG"] = aws.String(columnName)
+			expressionAttributeValues[":gg"] = attrList
+			updateExpression = updateExpression + " #GG = :gg, "
+		}
+
+		if params.AddGerritGroupApprovalList != nil {
+			repo.updateApprovalTableEntries(ctx, params, utils.GerritGroupApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+		}
+		if params.RemoveGerritGroupApprovalList != nil {
+			repo.updateApprovalTableEntries(ctx, params, utils.GerritGroupApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+		}
+	}
+
 	// Ensure at least one value is set for us to update
 	if !haveAdditions {
 		log.WithFields(f).Debugf("no updates required to any of the approved list values company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t - expecting at least something to update",
@@ -3794,6 +4260,39 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 	return updatedSig, nil
 }
 
+// approvalTableEntries returns the added or the removed entries of the approval list request for the approvals table
+// criteria, so the entries are always recorded with the criteria of the list they come from
+func approvalTableEntries(params *models.ApprovalList, criteria string, add bool) []string {
+	var added, removed []string
+	switch criteria {
+	case utils.EmailApprovalCriteria:
+		added, removed = params.AddEmailApprovalList, params.RemoveEmailApprovalList
+	case utils.DomainApprovalCriteria:
+		added, removed = params.AddDomainApprovalList, params.RemoveDomainApprovalList
+	case utils.GithubUsernameApprovalCriteria:
+		added, removed = params.AddGithubUsernameApprovalList, params.RemoveGithubUsernameApprovalList
+	case utils.GithubOrgApprovalCriteria:
+		added, removed = params.AddGithubOrgApprovalList, params.RemoveGithubOrgApprovalList
+	case utils.GitlabUsernameApprovalCriteria:
+		added, removed = params.AddGitlabUsernameApprovalList, params.RemoveGitlabUsernameApprovalList
+	case utils.GitlabOrgApprovalCriteria:
+		added, removed = params.AddGitlabOrgApprovalList, params.RemoveGitlabOrgApprovalList
+	case utils.EmailRegexApprovalCriteria:
+		added, removed = params.AddEmailRegexApprovalList, params.RemoveEmailRegexApprovalList
+	case utils.GerritGroupApprovalCriteria:
+		added, removed = params.AddGerritGroupApprovalList, params.RemoveGerritGroupApprovalList
+	}
+	if add {
+		return added
+	}
+	return removed
+}
+
+// updateApprovalTableEntries records the added or the removed entries of the approval list request for the criteria
+func (repo *repository) updateApprovalTableEntries(ctx context.Context, params *models.ApprovalList, criteria, signatureID, projectID, companyID, companyName string, add bool) {
+	repo.updateApprovalTable(ctx, approvalTableEntries(params, criteria, add), criteria, signatureID, projectID, companyID, companyName, add)
+}
+
 func (repo *repository) updateApprovalTable(ctx context.Context, approvalList []string, criteria, signatureID, projectID, companyID, companyName string, add bool) {
 	f := logrus.Fields{
 		"functionName":   "v1.signatures.repository.addApprovalList",
@@ -3830,6 +4329,9 @@ func (repo *repository) updateApprovalTable(ctx context.Context, approvalList []
 				approvalItem.DateRemoved = currentTime
 				approvalItem.Active = false
 			}
+			// adding the entry again or removing it ends a previous expiry, a new one is set with the update request
+			approvalItem.DateExpires = ""
+			approvalItem.DateExpiryNotified = ""
 			err = repo.approvalRepo.UpdateApprovalItem(approvalItem)
 
 			if err != nil {
@@ -3872,6 +4374,68 @@ func (repo *repository) updateApprovalTable(ctx context.Context, approvalList []
 	}
 }
 
+// UpdateApprovalListExpiry records the expiry of the approval list entries of the signature, the entries without an
+// approval record (added before the approvals table existed) get one
+func (repo *repository) UpdateApprovalListExpiry(ctx context.Context, cclaSignature *models.Signature, expiries []*ApprovalListEntryExpiry) error {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.repository.UpdateApprovalListExpiry",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    cclaSignature.SignatureID,
+	}
+
+	for _, expiry := range expiries {
+		_, currentTime := utils.CurrentTime()
+		approvalItems, err := repo.approvalRepo.SearchApprovalList(expiry.Criteria, expiry.Value, cclaSignature.ProjectID, cclaSignature.SignatureReferenceID, cclaSignature.SignatureID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to search approval list for item: %s", expiry.Value)
+			return err
+		}
+
+		var approvalItem *approvals.ApprovalItem
+		for i := range approvalItems {
+			if approvalItems[i].Active {
+				approvalItem = &approvalItems[i]
+				break
+			}
+		}
+
+		if approvalItem == nil {
+			approvalID, uuidErr := uuid.NewV4()
+			if uuidErr != nil {
+				log.WithFields(f).WithError(uuidErr).Warnf("unable to generate UUID for item: %s", expiry.Value)
+				return uuidErr
+			}
+			err = repo.approvalRepo.AddApprovalList(approvals.ApprovalItem{
+				ApprovalID:          approvalID.String(),
+				SignatureID:         cclaSignature.SignatureID,
+				ApprovalName:        expiry.Value,
+				ProjectID:           cclaSignature.ProjectID,
+				CompanyID:           cclaSignature.SignatureReferenceID,
+				ApprovalCriteria:    expiry.Criteria,
+				DateCreated:         currentTime,
+				DateModified:        currentTime,
+				DateAdded:           currentTime,
+				ApprovalCompanyName: cclaSignature.SignatureReferenceName,
+				Note:                "Auto-Added",
+				Active:              true,
+				DateExpires:         expiry.FormatExpiry(),
+			})
+		} else {
+			approvalItem.DateModified = currentTime
+			approvalItem.DateExpires = expiry.FormatExpiry()
+			approvalItem.DateExpiryNotified = ""
+			err = repo.approvalRepo.UpdateApprovalItem(*approvalItem)
+		}
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to set the expiry of approval list item: %s with criteria: %s", expiry.Value, expiry.Criteria)
+			return err
+		}
+		log.WithFields(f).Debugf("approval list item: %s with criteria: %s expires at: %s", expiry.Value, expiry.Criteria, expiry.FormatExpiry())
+	}
+
+	return nil
+}
+
 // sendEmail is a helper function used to render email for (CCLA, ICLA, ECLA cases)
 func (repo repository) sendEmail(ctx context.Context, email string, approvalList *ApprovalList, iclas []*models.IclaSignature, eclas []*models.Signature) {
 	f := logrus.Fields{
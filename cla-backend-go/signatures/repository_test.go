@@ -0,0 +1,52 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApprovalTableEntries(t *testing.T) {
+	params := &models.ApprovalList{
+		AddEmailApprovalList:             []string{"added@example.org"},
+		RemoveEmailApprovalList:          []string{"removed@example.org"},
+		AddDomainApprovalList:            []string{"added.example.org"},
+		RemoveDomainApprovalList:         []string{"removed.example.org"},
+		AddGithubUsernameApprovalList:    []string{"added-github-user"},
+		RemoveGithubUsernameApprovalList: []string{"removed-github-user"},
+		AddGithubOrgApprovalList:         []string{"added-github-org"},
+		RemoveGithubOrgApprovalList:      []string{"removed-github-org"},
+		AddGitlabUsernameApprovalList:    []string{"added-gitlab-user"},
+		RemoveGitlabUsernameApprovalList: []string{"removed-gitlab-user"},
+		AddGitlabOrgApprovalList:         []string{"added-gitlab-org"},
+		RemoveGitlabOrgApprovalList:      []string{"removed-gitlab-org"},
+		AddEmailRegexApprovalList:        []string{"added-regex"},
+		RemoveEmailRegexApprovalList:     []string{"removed-regex"},
+		AddGerritGroupApprovalList:       []string{"added-gerrit-group"},
+		RemoveGerritGroupApprovalList:    []string{"removed-gerrit-group"},
+	}
+
+	// the removed entries used to be recorded from the added list, and the domains with the email criteria
+	expected := map[string][2]string{
+		utils.EmailApprovalCriteria:          {"added@example.org", "removed@example.org"},
+		utils.DomainApprovalCriteria:         {"added.example.org", "removed.example.org"},
+		utils.GithubUsernameApprovalCriteria: {"added-github-user", "removed-github-user"},
+		utils.GithubOrgApprovalCriteria:      {"added-github-org", "removed-github-org"},
+		utils.GitlabUsernameApprovalCriteria: {"added-gitlab-user", "removed-gitlab-user"},
+		utils.GitlabOrgApprovalCriteria:      {"added-gitlab-org", "removed-gitlab-org"},
+		utils.EmailRegexApprovalCriteria:     {"added-regex", "removed-regex"},
+		utils.GerritGroupApprovalCriteria:    {"added-gerrit-group", "removed-gerrit-group"},
+	}
+	for criteria, entries := range expected {
+		assert.Equal(t, []string{entries[0]}, approvalTableEntries(params, criteria, true), criteria)
+		assert.Equal(t, []string{entries[1]}, approvalTableEntries(params, criteria, false), criteria)
+	}
+
+	assert.Empty(t, approvalTableEntries(params, "unknown", true))
+	assert.Empty(t, approvalTableEntries(&models.ApprovalList{}, utils.DomainApprovalCriteria, false))
+}
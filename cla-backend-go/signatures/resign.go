@@ -0,0 +1,71 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+)
+
+// ResignPolicyFor returns the re-sign policy of the CLA Group for the signature CLA type, nil if none was set
+func ResignPolicyFor(claGroup *models.ClaGroup, claType string) *models.ClaGroupResignPolicy {
+	if claGroup == nil {
+		return nil
+	}
+	if claType == utils.ClaTypeCCLA {
+		return claGroup.ProjectCorporateResignPolicy
+	}
+	return claGroup.ProjectIndividualResignPolicy
+}
+
+// IsSignatureOutdated returns true if the signature was made on a document version older than the version of the
+// re-sign policy
+func IsSignatureOutdated(signature *models.Signature, policy *models.ClaGroupResignPolicy) bool {
+	if signature == nil || policy == nil {
+		return false
+	}
+
+	requiredMajor, majorErr := strconv.Atoi(policy.DocumentMajorVersion)
+	requiredMinor, minorErr := strconv.Atoi(policy.DocumentMinorVersion)
+	if majorErr != nil || minorErr != nil {
+		return false
+	}
+
+	// signatures without a valid version predate document versioning
+	major, err := strconv.Atoi(signature.SignatureDocumentMajorVersion)
+	if err != nil {
+		major = 0
+	}
+	minor, err := strconv.Atoi(signature.SignatureDocumentMinorVersion)
+	if err != nil {
+		minor = 0
+	}
+
+	return major < requiredMajor || (major == requiredMajor && minor < requiredMinor)
+}
+
+// IsSignatureBlocked returns true if the CLA checks must fail for the signature - the signature is outdated and
+// either the policy blocks outdated signatures or its grace period has ended
+func IsSignatureBlocked(signature *models.Signature, policy *models.ClaGroupResignPolicy, now time.Time) bool {
+	if !IsSignatureOutdated(signature, policy) {
+		return false
+	}
+
+	switch policy.Enforcement {
+	case utils.ResignEnforcementBlocked:
+		return true
+	case utils.ResignEnforcementGracePeriod:
+		gracePeriodEnds, err := utils.ParseDateTime(policy.GracePeriodEnds)
+		if err != nil {
+			// without a valid end date the grace period is treated as ended
+			return true
+		}
+		return !now.Before(gracePeriodEnds)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSignatureOutdated(t *testing.T) {
+	policy := &models.ClaGroupResignPolicy{DocumentMajorVersion: "2", DocumentMinorVersion: "1", Enforcement: utils.ResignEnforcementBlocked}
+
+	testCases := []struct {
+		name     string
+		major    string
+		minor    string
+		expected bool
+	}{
+		{name: "older major version", major: "1", minor: "5", expected: true},
+		{name: "older minor version", major: "2", minor: "0", expected: true},
+		{name: "same version", major: "2", minor: "1", expected: false},
+		{name: "newer minor version", major: "2", minor: "2", expected: false},
+		{name: "newer major version", major: "3", minor: "0", expected: false},
+		{name: "signature without a version", major: "", minor: "", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signature := &models.Signature{SignatureDocumentMajorVersion: tc.major, SignatureDocumentMinorVersion: tc.minor}
+			assert.Equal(t, tc.expected, IsSignatureOutdated(signature, policy))
+		})
+	}
+
+	assert.False(t, IsSignatureOutdated(&models.Signature{SignatureDocumentMajorVersion: "1"}, nil))
+	assert.False(t, IsSignatureOutdated(nil, policy))
+	assert.False(t, IsSignatureOutdated(&models.Signature{SignatureDocumentMajorVersion: "1"}, &models.ClaGroupResignPolicy{DocumentMajorVersion: "x"}))
+}
+
+func TestIsSignatureBlocked(t *testing.T) {
+	now := time.Date(2021, 8, 15, 0, 0, 0, 0, time.UTC)
+	outdated := &models.Signature{SignatureDocumentMajorVersion: "1", SignatureDocumentMinorVersion: "0"}
+	current := &models.Signature{SignatureDocumentMajorVersion: "2", SignatureDocumentMinorVersion: "0"}
+
+	blocked := &models.ClaGroupResignPolicy{DocumentMajorVersion: "2", DocumentMinorVersion: "0", Enforcement: utils.ResignEnforcementBlocked}
+	assert.True(t, IsSignatureBlocked(outdated, blocked, now))
+	assert.False(t, IsSignatureBlocked(current, blocked, now))
+
+	gracePeriod := &models.ClaGroupResignPolicy{DocumentMajorVersion: "2", DocumentMinorVersion: "0", Enforcement: utils.ResignEnforcementGracePeriod, GracePeriodEnds: "2021-09-01T00:00:00Z"}
+	assert.False(t, IsSignatureBlocked(outdated, gracePeriod, now))
+	assert.True(t, IsSignatureBlocked(outdated, gracePeriod, now.AddDate(0, 1, 0)))
+	assert.False(t, IsSignatureBlocked(current, gracePeriod, now.AddDate(0, 1, 0)))
+
+	gracePeriod.GracePeriodEnds = ""
+	assert.True(t, IsSignatureBlocked(outdated, gracePeriod, now))
+}
+
+func TestResignPolicyFor(t *testing.T) {
+	individual := &models.ClaGroupResignPolicy{DocumentMajorVersion: "2"}
+	corporate := &models.ClaGroupResignPolicy{DocumentMajorVersion: "3"}
+	claGroup := &models.ClaGroup{ProjectIndividualResignPolicy: individual, ProjectCorporateResignPolicy: corporate}
+
+	assert.Equal(t, individual, ResignPolicyFor(claGroup, utils.ClaTypeICLA))
+	assert.Equal(t, corporate, ResignPolicyFor(claGroup, utils.ClaTypeCCLA))
+	assert.Nil(t, ResignPolicyFor(nil, utils.ClaTypeICLA))
+}
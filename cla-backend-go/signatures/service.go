@@ -7,16 +7,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
 	"strconv"
-	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/linuxfoundation/easycla/cla-backend-go/approval_rules"
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	"github.com/linuxfoundation/easycla/cla-backend-go/domain_matcher"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
 	gitlab_api "github.com/linuxfoundation/easycla/cla-backend-go/gitlab_api"
 	service2 "github.com/linuxfoundation/easycla/cla-backend-go/project/service"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/github"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github_membership"
 	"github.com/linuxfoundation/easycla/cla-backend-go/github_organizations"
 	"github.com/linuxfoundation/easycla/cla-backend-go/repositories"
 
@@ -35,6 +40,7 @@ import (
 
 	githubpkg "github.com/google/go-github/v37/github"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
 	"golang.org/x/oauth2"
 )
 
@@ -75,9 +81,16 @@ type SignatureService interface {
 	// createOrGetEmployeeModels(ctx context.Context, claGroupModel *models.ClaGroup, companyModel *models.Company, corporateSignatureModel *models.Signature) ([]*models.User, error)
 	CreateOrUpdateEmployeeSignature(ctx context.Context, claGroupModel *models.ClaGroup, companyModel *models.Company, corporateSignatureModel *models.Signature) ([]*models.User, error)
 	UpdateEnvelopeDetails(ctx context.Context, signatureID, envelopeID string, signURL *string) (*models.Signature, error)
+	GetPendingEnvelopeSignatures(ctx context.Context, sentAfter time.Time, pageSize int64, nextKey *string) (*models.Signatures, error)
+	UpdateEnvelopeStatus(ctx context.Context, signatureID, envelopeID, status, reason string) error
+	GetOutdatedSignatures(ctx context.Context, claGroupID, claType string, policy *models.ClaGroupResignPolicy) ([]*models.Signature, error)
 	// handleGitHubStatusUpdate(ctx context.Context, employeeUserModel *models.User) error
 	ProcessEmployeeSignature(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, user *models.User) (*bool, error)
 	UserIsApproved(ctx context.Context, user *models.User, cclaSignature *models.Signature) (bool, error)
+	GetUserApprovalDecision(ctx context.Context, user *models.User, cclaSignature *models.Signature) *approval_rules.Decision
+	GetGitHubPullRequestCLAReport(ctx context.Context, projectSFID, repositoryID string, pullRequestID int64) (*v2Models.ChangeRequestReport, error)
+	RecheckGitHubPullRequest(ctx context.Context, repositoryExternalID, pullRequestID int64) error
+	ExpireApprovalListEntries(ctx context.Context, signatureID string, expired []*ApprovalListEntryExpiry) (*models.Signature, error)
 }
 
 type service struct {
@@ -93,10 +106,11 @@ type service struct {
 	claBaseAPIURL       string
 	claLandingPage      string
 	claLogoURL          string
+	approvalEngine      approval_rules.Engine
 }
 
 // NewService creates a new signature service
-func NewService(repo SignatureRepository, companyService company.IService, usersService users.Service, eventsService events.Service, githubOrgValidation bool, repositoryService repositories.Service, githubOrgService github_organizations.ServiceInterface, claGroupService service2.Service, gitLabApp *gitlab_api.App, CLABaseAPIURL, CLALandingPage, CLALogoURL string) SignatureService {
+func NewService(repo SignatureRepository, companyService company.IService, usersService users.Service, eventsService events.Service, githubOrgValidation bool, repositoryService repositories.Service, githubOrgService github_organizations.ServiceInterface, claGroupService service2.Service, githubMembership approval_rules.MembershipChecker, gitLabApp *gitlab_api.App, CLABaseAPIURL, CLALandingPage, CLALogoURL string) SignatureService {
 	return service{
 		repo,
 		companyService,
@@ -110,9 +124,30 @@ func NewService(repo SignatureRepository, companyService company.IService, users
 		CLABaseAPIURL,
 		CLALandingPage,
 		CLALogoURL,
+		newApprovalEngine(eventsService, githubMembership),
 	}
 }
 
+// newApprovalEngine creates the approval rule engine used by the GitHub flows - the GitHub organization membership
+// checks go through the membership cache, if provided, otherwise through an in-process one so lookup errors are still
+// reported as github_membership.ErrMembershipUnknown
+func newApprovalEngine(eventsService events.Service, githubMembership approval_rules.MembershipChecker) approval_rules.Engine {
+	if githubMembership == nil {
+		githubMembership = github_membership.NewCache(github.IsOrganizationMember, github_membership.NewMemoryStore(github_membership.DefaultSize), github_membership.Config{})
+	}
+	lfGroupConfig := config.GetConfig().LFGroup
+	return approval_rules.NewEngine(approval_rules.NewDefaultRegistry(approval_rules.Checkers{
+		GitHubOrg: githubMembership,
+		GerritGroup: &gerrits.LFGroup{
+			LfBaseURL:     lfGroupConfig.ClientURL,
+			ClientID:      lfGroupConfig.ClientID,
+			ClientSecret:  lfGroupConfig.ClientSecret,
+			RefreshToken:  lfGroupConfig.RefreshToken,
+			EventsService: eventsService,
+		},
+	}))
+}
+
 // GetSignature returns the signature associated with the specified signature ID
 func (s service) GetSignature(ctx context.Context, signatureID string) (*models.Signature, error) {
 	return s.repo.GetSignature(ctx, signatureID)
@@ -164,6 +199,22 @@ func (s service) UpdateEnvelopeDetails(ctx context.Context, signatureID, envelop
 	return s.repo.UpdateEnvelopeDetails(ctx, signatureID, envelopeID, signURL)
 }
 
+// GetPendingEnvelopeSignatures returns a page of the unsigned signatures with an envelope sent after the specified
+// date awaiting the signatory
+func (s service) GetPendingEnvelopeSignatures(ctx context.Context, sentAfter time.Time, pageSize int64, nextKey *string) (*models.Signatures, error) {
+	return s.repo.GetPendingEnvelopeSignatures(ctx, sentAfter, pageSize, nextKey)
+}
+
+// GetOutdatedSignatures returns the ICLA or CCLA signatures made on a document version older than the re-sign policy version
+func (s service) GetOutdatedSignatures(ctx context.Context, claGroupID, claType string, policy *models.ClaGroupResignPolicy) ([]*models.Signature, error) {
+	return s.repo.GetOutdatedSignatures(ctx, claGroupID, claType, policy)
+}
+
+// UpdateEnvelopeStatus records the envelope status and reason on the signature
+func (s service) UpdateEnvelopeStatus(ctx context.Context, signatureID, envelopeID, status, reason string) error {
+	return s.repo.UpdateEnvelopeStatus(ctx, signatureID, envelopeID, status, reason)
+}
+
 // CreateProjectSummaryReport generates a project summary report based on the specified input
 func (s service) CreateProjectSummaryReport(ctx context.Context, params signatures.CreateProjectSummaryReportParams) (*models.SignatureReport, error) {
 
@@ -473,6 +524,24 @@ func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, cl
 
 	log.WithFields(f).Debugf("processing update approval list request")
 
+	// Ensure the added domains are valid approval list patterns
+	for _, domain := range params.AddDomainApprovalList {
+		if _, err := domain_matcher.ParsePattern(domain); err != nil {
+			msg := fmt.Sprintf("invalid approval list domain: %s - %s", domain, err)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return nil, NewBadRequestError(msg)
+		}
+	}
+
+	// Ensure the added email regular expressions compile
+	for _, expression := range params.AddEmailRegexApprovalList {
+		if _, err := approval_rules.CompileEmailRegex(expression); err != nil {
+			msg := fmt.Sprintf("invalid approval list email regex: %s - %s", expression, err)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return nil, NewBadRequestError(msg)
+		}
+	}
+
 	// Lookup the project corporate signature - should have one
 	pageSize := int64(1)
 	signed, approved := true, true
@@ -501,6 +570,13 @@ func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, cl
 		return nil, NewForbiddenError(msg)
 	}
 
+	// Ensure the requested expiry dates are valid and refer to entries on the updated approval list
+	expiries, expiryErr := ResolveApprovalListExpiry(corporateSigModel, params, time.Now())
+	if expiryErr != nil {
+		log.WithFields(f).WithError(expiryErr).Warn("invalid approval list expiry")
+		return nil, NewBadRequestError(expiryErr.Error())
+	}
+
 	// Lookup the user making the request - should be the CLA Manager
 	userModel, userErr := s.usersService.GetUserByUserName(authUser.UserName, true)
 	if userErr != nil {
@@ -527,6 +603,14 @@ func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, cl
 		return updatedCorporateSignature, err
 	}
 
+	if len(expiries) > 0 {
+		log.WithFields(f).Debugf("setting the expiry of %d approval list entries", len(expiries))
+		if err = s.repo.UpdateApprovalListExpiry(ctx, updatedCorporateSignature, expiries); err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem setting the approval list expiry for company ID: %s, cla group ID: %s", companyModel.CompanyID, claGroupID)
+			return updatedCorporateSignature, err
+		}
+	}
+
 	// If auto create ECLA is enabled for this Corporate Agreement, then create an ECLA for each employee that was added to the approval list
 	// we get the complete user list as output from the processing of the approval list
 	var userModelList []*models.User
@@ -553,6 +637,7 @@ func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, cl
 	go func() {
 		defer wg.Done()
 		s.createEventLogEntries(ctx, companyModel, claGroupModel, userModel, params, projectSFID)
+		s.createApprovalListExpiryEventLogEntries(ctx, events.ApprovalListEntryExpirySet, "set", companyModel, claGroupModel, userModel, expiries, projectSFID)
 	}()
 
 	// Send an email to each of the CLA Managers - do it in a separate go routine
@@ -1022,7 +1107,7 @@ func (s service) updateChangeRequest(ctx context.Context, ghOrg *models.GithubOr
 
 	// Fetch committers
 	log.WithFields(f).Debugf("fetching commit authors for PR: %d using repository owner: %s, repo: %s", pullRequestID, gitHubOrgName, gitHubRepoName)
-	authors, latestSHA, authorsErr := github.GetPullRequestCommitAuthors(ctx, ghOrg.OrganizationInstallationID, int(pullRequestID), gitHubOrgName, gitHubRepoName)
+	authors, latestSHA, authorsErr := github.GetPullRequestCommitAuthors(ctx, ghOrg.OrganizationInstallationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, commit_identity.PolicyOf(ghOrg.CommitIdentityPolicy))
 	if authorsErr != nil {
 		log.WithFields(f).WithError(authorsErr).Warnf("unable to get commit authors for %s/%s for PR: %d", gitHubOrgName, gitHubRepoName, pullRequestID)
 		return authorsErr
@@ -1031,6 +1116,8 @@ func (s service) updateChangeRequest(ctx context.Context, ghOrg *models.GithubOr
 
 	signed := make([]*github.UserCommitSummary, 0)
 	unsigned := make([]*github.UserCommitSummary, 0)
+	// commit authors whose approval couldn't be determined, e.g. GitHub organization membership lookup failures
+	unknown := make([]*github.UserCommitSummary, 0)
 
 	// triage signed and unsigned users
 	log.WithFields(f).Debugf("triaging %d commit authors for PR: %d using repository %s/%s",
@@ -1050,40 +1137,7 @@ func (s service) updateChangeRequest(ctx context.Context, ghOrg *models.GithubOr
 		log.WithFields(f).Debugf("checking user - sha: %s, user ID: %s, username: %s, email: %s",
 			userSummary.SHA, commitAuthorID, commitAuthorUsername, commitAuthorEmail)
 
-		var user *models.User
-		var userErr error
-
-		if commitAuthorID != "" {
-			log.WithFields(f).Debugf("looking up user by ID: %s", commitAuthorID)
-			user, userErr = s.usersService.GetUserByGitHubID(commitAuthorID)
-			if userErr != nil {
-				log.WithFields(f).WithError(userErr).Warnf("unable to get user by github id: %s", commitAuthorID)
-			}
-			if user != nil {
-				log.WithFields(f).Debugf("found user by ID: %s", commitAuthorID)
-			}
-		}
-		if user == nil && commitAuthorUsername != "" {
-			log.WithFields(f).Debugf("looking up user by username: %s", commitAuthorUsername)
-			user, userErr = s.usersService.GetUserByGitHubUsername(commitAuthorUsername)
-			if userErr != nil {
-				log.WithFields(f).WithError(userErr).Warnf("unable to get user by github username: %s", commitAuthorUsername)
-			}
-			if user != nil {
-				log.WithFields(f).Debugf("found user by username: %s", commitAuthorUsername)
-			}
-		}
-		if user == nil && commitAuthorEmail != "" {
-			log.WithFields(f).Debugf("looking up user by email: %s", commitAuthorEmail)
-			user, userErr = s.usersService.GetUserByEmail(commitAuthorEmail)
-			if userErr != nil {
-				log.WithFields(f).WithError(userErr).Warnf("unable to get user by user email: %s", commitAuthorEmail)
-			}
-			if user != nil {
-				log.WithFields(f).Debugf("found user by email: %s", commitAuthorEmail)
-			}
-		}
-
+		user, _ := s.findCommitAuthorUser(f, userSummary)
 		if user == nil {
 			log.WithFields(f).Debugf("unable to find user for commit author - sha: %s, user ID: %s, username: %s, email: %s",
 				userSummary.SHA, commitAuthorID, commitAuthorUsername, commitAuthorEmail)
@@ -1095,6 +1149,10 @@ func (s service) updateChangeRequest(ctx context.Context, ghOrg *models.GithubOr
 		userSigned, companyAffiliation, signedErr := s.HasUserSigned(ctx, user, projectID)
 		if signedErr != nil {
 			log.WithFields(f).WithError(signedErr).Warnf("has user signed error - user: %+v, project: %s", user, projectID)
+			if errors.Is(signedErr, github_membership.ErrMembershipUnknown) {
+				unknown = append(unknown, userSummary)
+				continue
+			}
 			unsigned = append(unsigned, userSummary)
 			continue
 		}
@@ -1122,8 +1180,18 @@ func (s service) updateChangeRequest(ctx context.Context, ghOrg *models.GithubOr
 	}
 	log.WithFields(f).Debugf("commit authors status after allowlisting bots => signed: %+v, missing: %+v, allowlisted: %+v", signed, unsigned, allowlisted)
 
+	// don't flip the pull request status because of a transient failure - the next event re-evaluates it
+	if len(unknown) > 0 {
+		log.WithFields(f).Warnf("unable to determine the approval status of %d commit authors for PR: %d - leaving the pull request status unchanged", len(unknown), pullRequestID)
+		return fmt.Errorf("unable to determine the approval status of %d commit authors: %w", len(unknown), github_membership.ErrMembershipUnknown)
+	}
+
 	// update pull request
-	updateErr := github.UpdatePullRequest(ctx, ghOrg.OrganizationInstallationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, githubRepository.ID, *latestSHA, signed, unsigned, s.claBaseAPIURL, s.claLandingPage, s.claLogoURL)
+	updatePullRequest := github.UpdatePullRequest
+	if ghOrg.CheckRunEnabled {
+		updatePullRequest = github.UpdatePullRequestCheckRun
+	}
+	updateErr := updatePullRequest(ctx, ghOrg.OrganizationInstallationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, githubRepository.ID, *latestSHA, signed, unsigned, s.claBaseAPIURL, s.claLandingPage, s.claLogoURL)
 	if updateErr != nil {
 		log.WithFields(f).Debugf("unable to update PR: %d", pullRequestID)
 		return updateErr
@@ -1132,6 +1200,54 @@ func (s service) updateChangeRequest(ctx context.Context, ghOrg *models.GithubOr
 	return nil
 }
 
+// findCommitAuthorUser locates the user record for the commit author (by GitHub ID, GitHub username, or email), returning
+// the user and the lookup method that matched - the user is nil if no record was found
+func (s service) findCommitAuthorUser(f logrus.Fields, userSummary *github.UserCommitSummary) (*models.User, string) {
+	commitAuthorID := userSummary.GetCommitAuthorID()
+	commitAuthorUsername := userSummary.GetCommitAuthorUsername()
+	commitAuthorEmail := userSummary.GetCommitAuthorEmail()
+
+	var user *models.User
+	var userErr error
+	var userLookup string
+
+	if commitAuthorID != "" {
+		log.WithFields(f).Debugf("looking up user by ID: %s", commitAuthorID)
+		user, userErr = s.usersService.GetUserByGitHubID(commitAuthorID)
+		if userErr != nil {
+			log.WithFields(f).WithError(userErr).Warnf("unable to get user by github id: %s", commitAuthorID)
+		}
+		if user != nil {
+			log.WithFields(f).Debugf("found user by ID: %s", commitAuthorID)
+			userLookup = UserLookupGitHubID
+		}
+	}
+	if user == nil && commitAuthorUsername != "" {
+		log.WithFields(f).Debugf("looking up user by username: %s", commitAuthorUsername)
+		user, userErr = s.usersService.GetUserByGitHubUsername(commitAuthorUsername)
+		if userErr != nil {
+			log.WithFields(f).WithError(userErr).Warnf("unable to get user by github username: %s", commitAuthorUsername)
+		}
+		if user != nil {
+			log.WithFields(f).Debugf("found user by username: %s", commitAuthorUsername)
+			userLookup = UserLookupGitHubUsername
+		}
+	}
+	if user == nil && commitAuthorEmail != "" {
+		log.WithFields(f).Debugf("looking up user by email: %s", commitAuthorEmail)
+		user, userErr = s.usersService.GetUserByEmail(commitAuthorEmail)
+		if userErr != nil {
+			log.WithFields(f).WithError(userErr).Warnf("unable to get user by user email: %s", commitAuthorEmail)
+		}
+		if user != nil {
+			log.WithFields(f).Debugf("found user by email: %s", commitAuthorEmail)
+			userLookup = UserLookupEmail
+		}
+	}
+
+	return user, userLookup
+}
+
 // hasUserSigned checks to see if the user has signed an ICLA or ECLA for the project, returns:
 // false, false, nil if user is not authorized for ICLA or ECLA
 // false, false, some error if user is not authorized for ICLA or ECLA - we has some problem looking up stuff
@@ -1157,9 +1273,18 @@ func (s service) HasUserSigned(ctx context.Context, user *models.User, projectID
 		return &hasSigned, &companyAffiliation, sigErr
 	}
 	if signature != nil {
-		hasSigned = true
-		log.WithFields(f).Debugf("ICLA signature check passed for user: %+v on project : %s", user, projectID)
-		return &hasSigned, &companyAffiliation, nil // ICLA passes, no company affiliation
+		blocked, blockedErr := s.isICLASignatureBlocked(ctx, signature, projectID)
+		if blockedErr != nil {
+			log.WithFields(f).WithError(blockedErr).Warnf("problem checking the re-sign policy for ICLA signature: %s", signature.SignatureID)
+			return &hasSigned, &companyAffiliation, blockedErr
+		}
+		if !blocked {
+			hasSigned = true
+			log.WithFields(f).Debugf("ICLA signature check passed for user: %+v on project : %s", user, projectID)
+			return &hasSigned, &companyAffiliation, nil // ICLA passes, no company affiliation
+		}
+		log.WithFields(f).Debugf("ICLA signature check failed for user: %+v on project: %s - ICLA signed on an outdated document version: %s.%s",
+			user, projectID, signature.SignatureDocumentMajorVersion, signature.SignatureDocumentMinorVersion)
 	} else {
 		log.WithFields(f).Debugf("ICLA signature check failed for user: %+v on project: %s - ICLA not signed", user, projectID)
 	}
@@ -1205,6 +1330,21 @@ func (s service) HasUserSigned(ctx context.Context, user *models.User, projectID
 	return &hasSigned, &companyAffiliation, nil
 }
 
+// isICLASignatureBlocked returns true if the ICLA signature was signed on an outdated document version and the
+// CLA Group re-sign policy no longer accepts it
+func (s service) isICLASignatureBlocked(ctx context.Context, signature *models.Signature, claGroupID string) (bool, error) {
+	if s.claGroupService == nil {
+		return false, nil
+	}
+
+	claGroupModel, err := s.claGroupService.GetCLAGroupByID(ctx, claGroupID)
+	if err != nil {
+		return false, err
+	}
+
+	return IsSignatureBlocked(signature, ResignPolicyFor(claGroupModel, utils.ClaTypeICLA), time.Now()), nil
+}
+
 func (s service) ProcessEmployeeSignature(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, user *models.User) (*bool, error) {
 	f := logrus.Fields{
 		"functionName":   "v2.signatures.service.ProcessEmployeeSignature",
@@ -1245,7 +1385,10 @@ func (s service) ProcessEmployeeSignature(ctx context.Context, companyModel *mod
 					return &hasSigned, cclaErr
 				}
 
-				if cclaSignature != nil {
+				if cclaSignature != nil && IsSignatureBlocked(cclaSignature, ResignPolicyFor(claGroupModel, utils.ClaTypeCCLA), time.Now()) {
+					log.WithFields(f).Debugf("ECLA Signature check - CCLA signature: %s was signed on an outdated document version: %s.%s",
+						cclaSignature.SignatureID, cclaSignature.SignatureDocumentMajorVersion, cclaSignature.SignatureDocumentMinorVersion)
+				} else if cclaSignature != nil {
 					log.WithFields(f).Debug("found ccla signature")
 					userApproved, approvedErr := s.UserIsApproved(ctx, user, cclaSignature)
 					if approvedErr != nil {
@@ -1276,127 +1419,27 @@ func (s service) ProcessEmployeeSignature(ctx context.Context, companyModel *mod
 
 }
 
+// UserIsApproved returns true if the user matches one of the approval lists of the CCLA signature
 func (s service) UserIsApproved(ctx context.Context, user *models.User, cclaSignature *models.Signature) (bool, error) {
-	// add lf email to emails
-	f := logrus.Fields{
-		"functionName": "v1.signatures.service.UserIsApproved",
+	decision := s.GetUserApprovalDecision(ctx, user, cclaSignature)
+	if decision.Approved {
+		return true, nil
 	}
-
-	emails := user.Emails
-
-	if user.LfEmail != "" {
-		log.WithFields(f).Debugf("adding lf email: %s to emails", user.LfEmail)
-		emails = append(emails, string(user.LfEmail))
-		// remove duplicates
-		log.WithFields(f).Debug("removing duplicates")
-		emails = utils.RemoveDuplicates(emails)
-	}
-
-	// check GitHub username approval list
-	log.WithFields(f).Debug("checking if user is in the approval list")
-	gitHubUsernameApprovalList := cclaSignature.GithubUsernameApprovalList
-	if len(gitHubUsernameApprovalList) > 0 {
-		for _, gitHubUsername := range gitHubUsernameApprovalList {
-			if strings.EqualFold(gitHubUsername, strings.TrimSpace(user.GithubUsername)) {
-				return true, nil
-			}
-		}
-	} else {
-		log.WithFields(f).Debugf("no matching github username found in ccla: %s", cclaSignature.SignatureID)
-	}
-
-	// check GitLab username approval list
-	gitLabUsernameApprovalList := cclaSignature.GitlabUsernameApprovalList
-	if len(gitLabUsernameApprovalList) > 0 {
-		for _, gitLabUsername := range gitLabUsernameApprovalList {
-			if strings.EqualFold(gitLabUsername, strings.TrimSpace(user.GitlabUsername)) {
-				return true, nil
-			}
-		}
-	} else {
-		log.WithFields(f).Debugf("no matching gitlab username found in ccla: %s", cclaSignature.SignatureID)
-	}
-
-	// check email email approval list
-	emailApprovalList := cclaSignature.EmailApprovalList
-	log.WithFields(f).Debugf("checking if user is in the email approval list: %+v with emails :%v", emailApprovalList, emails)
-	if len(emailApprovalList) > 0 {
-		for _, email := range emails {
-			log.WithFields(f).Debugf("checking email: %s", email)
-			// case insensitive search
-			for _, emailApproval := range emailApprovalList {
-				if strings.EqualFold(email, emailApproval) {
-					return true, nil
-				}
-			}
-		}
-	} else {
-		log.WithFields(f).Debugf("no matching email found in ccla: %s", cclaSignature.SignatureID)
-	}
-
-	// check domain email approval list
-	domainApprovalList := cclaSignature.DomainApprovalList
-	if len(domainApprovalList) > 0 {
-		matched, err := s.processPattern(emails, domainApprovalList)
-		if err != nil {
-			return false, err
-		}
-		if matched != nil && *matched {
-			return true, nil
-		}
-	}
-
-	// check github org email ApprovalList
-	if user.GithubUsername != "" {
-		githubOrgApprovalList := cclaSignature.GithubOrgApprovalList
-		if len(githubOrgApprovalList) > 0 {
-			log.WithFields(f).Debugf("determining if github user :%s is associated with ant of the github orgs : %+v", user.GithubUsername, githubOrgApprovalList)
-		}
-
-		for _, org := range githubOrgApprovalList {
-			membership, err := github.GetMembership(ctx, user.GithubUsername, org)
-			if err != nil {
-				break
-			}
-			if membership != nil {
-				log.WithFields(f).Debugf("found matching github organization: %s for user: %s", org, user.GithubUsername)
-				return true, nil
-			} else {
-				log.WithFields(f).Debugf("user: %s is not in the organization: %s", user.GithubUsername, org)
-			}
-		}
-	}
-
-	return false, nil
+	return false, decision.Err()
 }
 
-func (s service) processPattern(emails []string, patterns []string) (*bool, error) {
-	matched := false
-
-	for _, pattern := range patterns {
-		if strings.HasPrefix(pattern, "*.") {
-			pattern = strings.Replace(pattern, "*.", ".*", -1)
-		} else if strings.HasPrefix(pattern, "*") {
-			pattern = strings.Replace(pattern, "*", ".*", -1)
-		} else if strings.HasPrefix(pattern, ".") {
-			pattern = strings.Replace(pattern, ".", ".*", -1)
-		}
-
-		preProcessedPattern := fmt.Sprintf("^.*@%s$", pattern)
-		compiled, err := regexp.Compile(preProcessedPattern)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, email := range emails {
-			if compiled.MatchString(email) {
-				matched = true
-				break
-			}
-		}
+// GetUserApprovalDecision evaluates the user against the approval lists of the CCLA signature, returning the decision and the explanation
+func (s service) GetUserApprovalDecision(ctx context.Context, user *models.User, cclaSignature *models.Signature) *approval_rules.Decision {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.service.GetUserApprovalDecision",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"userID":         user.UserID,
+		"signatureID":    cclaSignature.SignatureID,
 	}
 
-	return &matched, nil
+	decision := s.approvalEngine.Evaluate(ctx, NewApprovalActor(user), NewApprovalLists(cclaSignature))
+	log.WithFields(f).Debugf("approval decision for user: %s - %s", user.UserID, decision.Explain())
+	return decision
 }
 
 func (s service) handleGitHubStatusUpdate(ctx context.Context, employeeUserModel *models.User) error {
@@ -1465,3 +1508,137 @@ func (s service) handleGitHubStatusUpdate(ctx context.Context, employeeUserModel
 
 	return nil
 }
+
+// RecheckGitHubPullRequest evaluates the CLA coverage of the pull request again and updates its comment and status,
+// or its check run, e.g. when the Re-run action of the EasyCLA check run is requested
+func (s service) RecheckGitHubPullRequest(ctx context.Context, repositoryExternalID, pullRequestID int64) error {
+	f := logrus.Fields{
+		"functionName":         "v1.signatures.service.RecheckGitHubPullRequest",
+		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+		"repositoryExternalID": repositoryExternalID,
+		"pullRequestID":        pullRequestID,
+	}
+
+	claRepository, repoErr := s.repositoryService.GetRepositoryByExternalID(ctx, strconv.FormatInt(repositoryExternalID, 10))
+	if repoErr != nil {
+		log.WithFields(f).WithError(repoErr).Warnf("unable to fetch repository by ID: %d - unable to recheck the pull request", repositoryExternalID)
+		return repoErr
+	}
+
+	if !claRepository.Enabled {
+		log.WithFields(f).Debugf("repository: %s is NOT enabled - unable to recheck the pull request", claRepository.RepositoryURL)
+		return nil
+	}
+
+	githubOrg, githubOrgErr := s.githubOrgService.GetGitHubOrganizationByName(ctx, claRepository.RepositoryOrganizationName)
+	if githubOrgErr != nil {
+		log.WithFields(f).WithError(githubOrgErr).Warnf("unable to lookup GitHub organization by name: %s - unable to recheck the pull request", claRepository.RepositoryOrganizationName)
+		return githubOrgErr
+	}
+	if githubOrg == nil {
+		msg := fmt.Sprintf("GitHub organization: %s not found - unable to recheck the pull request", claRepository.RepositoryOrganizationName)
+		log.WithFields(f).Warn(msg)
+		return errors.New(msg)
+	}
+
+	log.WithFields(f).Debugf("rechecking pull request: %d of repository: %s", pullRequestID, claRepository.RepositoryURL)
+	return s.updateChangeRequest(ctx, githubOrg, repositoryExternalID, pullRequestID, claRepository.RepositoryClaGroupID)
+}
+
+// ExpireApprovalListEntries removes the expired entries from the approval list of the CCLA signature through the
+// regular approval list update, as the system user, and updates the open pull requests of the affected users. The
+// entries which are no longer on the approval list are ignored.
+func (s service) ExpireApprovalListEntries(ctx context.Context, signatureID string, expired []*ApprovalListEntryExpiry) (*models.Signature, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.service.ExpireApprovalListEntries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	cclaSignature, sigErr := s.repo.GetSignature(ctx, signatureID)
+	if sigErr != nil {
+		log.WithFields(f).WithError(sigErr).Warn("unable to load the signature")
+		return nil, sigErr
+	}
+	if cclaSignature == nil {
+		return nil, fmt.Errorf("signature: %s not found", signatureID)
+	}
+
+	var onList []*ApprovalListEntryExpiry
+	for _, entry := range expired {
+		existing, _, _, ok := approvalListsForCriteria(cclaSignature, &models.ApprovalList{}, entry.Criteria)
+		if ok && utils.StringInSlice(entry.Value, existing) {
+			onList = append(onList, entry)
+		} else {
+			log.WithFields(f).Debugf("expired entry: %s with criteria: %s is no longer on the approval list", entry.Value, entry.Criteria)
+		}
+	}
+	if len(onList) == 0 {
+		return cclaSignature, nil
+	}
+
+	companyModel, companyErr := s.companyService.GetCompany(ctx, cclaSignature.SignatureReferenceID)
+	if companyErr != nil {
+		log.WithFields(f).WithError(companyErr).Warnf("problem looking up company: %s", cclaSignature.SignatureReferenceID)
+		return nil, companyErr
+	}
+	claGroupModel, claGroupErr := s.claGroupService.GetCLAGroupByID(ctx, cclaSignature.ProjectID)
+	if claGroupErr != nil {
+		log.WithFields(f).WithError(claGroupErr).Warnf("problem looking up CLA group: %s", cclaSignature.ProjectID)
+		return nil, claGroupErr
+	}
+
+	systemUser := &models.User{
+		UserID:     SystemUsername,
+		LfUsername: SystemUsername,
+		Username:   SystemUsername,
+	}
+	eventArgs := &events.LogEventArgs{
+		EventType:     events.InvalidatedSignature,
+		ProjectID:     claGroupModel.ProjectExternalID,
+		ClaGroupModel: claGroupModel,
+		CompanyID:     companyModel.CompanyID,
+		CompanyModel:  companyModel,
+		LfUsername:    systemUser.LfUsername,
+		UserID:        systemUser.UserID,
+		UserModel:     systemUser,
+	}
+
+	params := RemovalRequestFor(onList)
+	log.WithFields(f).Debugf("removing %d expired entries from the approval list", len(onList))
+	updatedCorporateSignature, err := s.repo.UpdateApprovalList(ctx, systemUser, claGroupModel, companyModel.CompanyID, params, eventArgs)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem removing the expired entries from the approval list")
+		return updatedCorporateSignature, err
+	}
+
+	s.createEventLogEntries(ctx, companyModel, claGroupModel, systemUser, params, "")
+	s.createApprovalListExpiryEventLogEntries(ctx, events.ApprovalListEntryExpired, "expired", companyModel, claGroupModel, systemUser, onList, "")
+
+	// The users of the removed email and username entries are no longer approved, update their open pull requests.
+	// The open GitLab merge requests aren't found by user, as for a manual removal they are checked again on their
+	// next update or /easycla comment.
+	for _, entry := range onList {
+		var userModel *models.User
+		var userErr error
+		switch entry.Criteria {
+		case utils.EmailApprovalCriteria:
+			userModel, userErr = s.usersService.GetUserByEmail(entry.Value)
+		case utils.GithubUsernameApprovalCriteria:
+			userModel, userErr = s.usersService.GetUserByGitHubUsername(entry.Value)
+		case utils.GitlabUsernameApprovalCriteria:
+			userModel, userErr = s.usersService.GetUserByGitLabUsername(entry.Value)
+		default:
+			continue
+		}
+		if userErr != nil || userModel == nil {
+			log.WithFields(f).WithError(userErr).Debugf("no user found for the expired entry: %s - no pull requests to update", entry.Value)
+			continue
+		}
+		if statusErr := s.handleGitHubStatusUpdate(utils.NewContextFromParent(ctx), userModel); statusErr != nil {
+			log.WithFields(f).WithError(statusErr).Warnf("problem updating GitHub status for user: %s", userModel.UserID)
+		}
+	}
+
+	return updatedCorporateSignature, nil
+}
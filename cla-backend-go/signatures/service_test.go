@@ -7,6 +7,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/LF-Engineering/lfx-kit/auth"
 	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -84,7 +85,7 @@ func TestUserIsApproved(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			service := NewService(nil, nil, nil, nil, false, nil, nil, nil, nil, "", "", "")
+			service := NewService(nil, nil, nil, nil, false, nil, nil, nil, nil, nil, "", "", "")
 
 			isApproved, err := service.UserIsApproved(ctx, tc.user, tc.cclaSignature)
 
@@ -93,3 +94,13 @@ func TestUserIsApproved(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateApprovalListRejectsInvalidEmailRegex(t *testing.T) {
+	service := NewService(nil, nil, nil, nil, false, nil, nil, nil, nil, nil, "", "", "")
+
+	_, err := service.UpdateApprovalList(context.Background(), &auth.User{UserName: "manager"}, &v1Models.ClaGroup{ProjectID: "cla-group-id"},
+		&v1Models.Company{CompanyID: "company-id"}, "cla-group-id", &v1Models.ApprovalList{AddEmailRegexApprovalList: []string{`.*@example\.com`, `(`}}, "")
+
+	var badRequest *BadRequestError
+	assert.ErrorAs(t, err, &badRequest)
+}
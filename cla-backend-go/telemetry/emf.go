@@ -0,0 +1,99 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+)
+
+// EMFNamespace is the CloudWatch namespace of the metrics written in the embedded metric format
+const EMFNamespace = "EasyCLA"
+
+// dynamo stream handler outcomes
+const (
+	OutcomeSuccess    = "success"
+	OutcomeDeadLetter = "dead_letter"
+	OutcomeLost       = "lost"
+	OutcomeDuplicate  = "duplicate"
+)
+
+// EMFOutput receives the embedded metric format records - the Lambda runtime sends stdout to CloudWatch Logs, which
+// extracts the metrics from the records
+var EMFOutput io.Writer = os.Stdout
+
+var emfLock sync.Mutex
+
+type emfMetric struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit"`
+}
+
+type emfDirective struct {
+	Namespace  string      `json:"Namespace"`
+	Dimensions [][]string  `json:"Dimensions"`
+	Metrics    []emfMetric `json:"Metrics"`
+}
+
+type emfMetadata struct {
+	Timestamp         int64          `json:"Timestamp"`
+	CloudWatchMetrics []emfDirective `json:"CloudWatchMetrics"`
+}
+
+// EmitCount writes a count metric with its dimensions in the CloudWatch embedded metric format, the STAGE
+// environment variable is added as the Stage dimension when it is set
+func EmitCount(name string, value float64, dimensions map[string]string) {
+	record := map[string]interface{}{}
+	dimensionNames := make([]string, 0, len(dimensions)+1)
+	for dimension, dimensionValue := range dimensions {
+		record[dimension] = dimensionValue
+		dimensionNames = append(dimensionNames, dimension)
+	}
+	if stage := os.Getenv("STAGE"); stage != "" {
+		record["Stage"] = stage
+		dimensionNames = append(dimensionNames, "Stage")
+	}
+	sort.Strings(dimensionNames)
+
+	record[name] = value
+	record["_aws"] = emfMetadata{
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+		CloudWatchMetrics: []emfDirective{
+			{
+				Namespace:  EMFNamespace,
+				Dimensions: [][]string{dimensionNames},
+				Metrics:    []emfMetric{{Name: name, Unit: "Count"}},
+			},
+		},
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		log.WithError(err).Warnf("unable to marshal the %s metric", name)
+		return
+	}
+
+	emfLock.Lock()
+	defer emfLock.Unlock()
+	if _, err := EMFOutput.Write(append(line, '\n')); err != nil {
+		log.WithError(err).Warnf("unable to write the %s metric", name)
+	}
+}
+
+// RecordDynamoStreamEvent counts the outcome of a dynamo stream event handler - the handlers run in the dynamo events
+// Lambda, so the count goes to CloudWatch rather than the /metrics endpoint of the standalone server
+func RecordDynamoStreamEvent(tableName, eventName, handlerName, outcome string) {
+	EmitCount("DynamoStreamEvents", 1, map[string]string{
+		"Table":     tableName,
+		"EventName": eventName,
+		"Handler":   handlerName,
+		"Outcome":   outcome,
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordDynamoStreamEvent(t *testing.T) {
+	var buf bytes.Buffer
+	output := EMFOutput
+	EMFOutput = &buf
+	defer func() { EMFOutput = output }()
+	t.Setenv("STAGE", "dev")
+
+	RecordDynamoStreamEvent("cla-dev-signatures", "MODIFY", "UpdateCLAPermissions", OutcomeDeadLetter)
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "cla-dev-signatures", record["Table"])
+	assert.Equal(t, "MODIFY", record["EventName"])
+	assert.Equal(t, "UpdateCLAPermissions", record["Handler"])
+	assert.Equal(t, OutcomeDeadLetter, record["Outcome"])
+	assert.Equal(t, "dev", record["Stage"])
+	assert.Equal(t, float64(1), record["DynamoStreamEvents"])
+
+	metadata := record["_aws"].(map[string]interface{})
+	assert.NotZero(t, metadata["Timestamp"])
+	directive := metadata["CloudWatchMetrics"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, EMFNamespace, directive["Namespace"])
+	assert.Equal(t, []interface{}{[]interface{}{"EventName", "Handler", "Outcome", "Stage", "Table"}}, directive["Dimensions"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"Name": "DynamoStreamEvents", "Unit": "Count"}}, directive["Metrics"])
+}
@@ -0,0 +1,69 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// integrations of the outbound client metrics
+const (
+	IntegrationDocuSign = "docusign"
+	IntegrationGitHub   = "github"
+	IntegrationGitLab   = "gitlab"
+)
+
+// DefaultRegistry holds the operational metrics of the service, served by the standalone server on /metrics
+var DefaultRegistry = prometheus.NewRegistry()
+
+// operational metrics
+var (
+	// RequestDuration is the latency of the API requests per swagger operation
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "easycla_http_request_duration_seconds",
+		Help:    "Latency of the API requests by swagger operation and response code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"api", "operation", "method", "code"})
+
+	// IntegrationRequests counts the calls to the DocuSign, GitHub and GitLab APIs, the code is "error" when no
+	// response was received
+	IntegrationRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "easycla_integration_requests_total",
+		Help: "Calls to the DocuSign, GitHub and GitLab APIs by response code.",
+	}, []string{"integration", "method", "code"})
+
+	// IntegrationRequestDuration is the latency of the calls to the DocuSign, GitHub and GitLab APIs
+	IntegrationRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "easycla_integration_request_duration_seconds",
+		Help:    "Latency of the calls to the DocuSign, GitHub and GitLab APIs.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"integration"})
+
+	// IntegrationRateLimitRemaining is the remaining rate limit reported by the last response of each integration
+	IntegrationRateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "easycla_integration_rate_limit_remaining",
+		Help: "Remaining API rate limit reported by the last response of the integration.",
+	}, []string{"integration", "resource"})
+)
+
+func init() {
+	DefaultRegistry.MustRegister(RequestDuration, IntegrationRequests, IntegrationRequestDuration, IntegrationRateLimitRemaining)
+}
+
+// Handler returns the HTTP handler serving the default registry
+func Handler() http.Handler {
+	return promhttp.HandlerFor(DefaultRegistry, promhttp.HandlerOpts{})
+}
+
+// StatusCode returns the label value of the HTTP status code, zero means the handler didn't set it
+func StatusCode(code int) string {
+	if code == 0 {
+		code = http.StatusOK
+	}
+	return strconv.Itoa(code)
+}
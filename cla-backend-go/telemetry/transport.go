@@ -0,0 +1,66 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// rate limit response headers of the integrations, GitHub also reports the resource the limit applies to
+var rateLimitHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining"}
+
+const rateLimitResourceHeader = "X-RateLimit-Resource"
+
+// Transport records the calls, the latency and the rate limit headers of an integration
+type Transport struct {
+	Integration string
+	Base        http.RoundTripper
+}
+
+// NewTransport wraps the base transport, http.DefaultTransport is used when the base is nil
+func NewTransport(integration string, base http.RoundTripper) *Transport {
+	return &Transport{Integration: integration, Base: base}
+}
+
+// NewClient returns an HTTP client recording the calls of the integration
+func NewClient(integration string) *http.Client {
+	return &http.Client{Transport: NewTransport(integration, nil)}
+}
+
+// RoundTrip executes the request with the base transport and records the outcome
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	IntegrationRequestDuration.WithLabelValues(t.Integration).Observe(time.Since(start).Seconds())
+	if err != nil {
+		IntegrationRequests.WithLabelValues(t.Integration, req.Method, "error").Inc()
+		return resp, err
+	}
+
+	IntegrationRequests.WithLabelValues(t.Integration, req.Method, strconv.Itoa(resp.StatusCode)).Inc()
+	for _, header := range rateLimitHeaders {
+		value := resp.Header.Get(header)
+		if value == "" {
+			continue
+		}
+		remaining, parseErr := strconv.ParseFloat(value, 64)
+		if parseErr != nil {
+			continue
+		}
+		resource := resp.Header.Get(rateLimitResourceHeader)
+		if resource == "" {
+			resource = "default"
+		}
+		IntegrationRateLimitRemaining.WithLabelValues(t.Integration, resource).Set(remaining)
+		break
+	}
+	return resp, nil
+}
@@ -0,0 +1,55 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Header().Set("X-RateLimit-Resource", "core")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-integration")
+	for _, path := range []string{"/ok", "/ok", "/missing"} {
+		resp, err := client.Get(server.URL + path)
+		if assert.NoError(t, err) {
+			assert.NoError(t, resp.Body.Close())
+		}
+	}
+	assert.Equal(t, float64(2), testutil.ToFloat64(IntegrationRequests.WithLabelValues("test-integration", http.MethodGet, "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(IntegrationRequests.WithLabelValues("test-integration", http.MethodGet, "404")))
+	assert.Equal(t, float64(4999), testutil.ToFloat64(IntegrationRateLimitRemaining.WithLabelValues("test-integration", "core")))
+
+	// the connection errors are counted too
+	server.Close()
+	_, err := client.Get(server.URL + "/ok")
+	assert.Error(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(IntegrationRequests.WithLabelValues("test-integration", http.MethodGet, "error")))
+}
+
+func TestHandler(t *testing.T) {
+	IntegrationRequests.WithLabelValues("handler-integration", http.MethodGet, "200").Inc()
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, err := io.ReadAll(recorder.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, strings.Contains(string(body), `easycla_integration_requests_total{code="200",integration="handler-integration",method="GET"} 1`))
+}
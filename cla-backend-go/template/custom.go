@@ -0,0 +1,302 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aymerick/raymond"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+)
+
+// maxCustomTemplateSize limits the size of each uploaded template body, the template is stored on the CLA Group record
+const maxCustomTemplateSize = 100 * 1024
+
+var (
+	// templateVariableRegex matches the meta field variable names which can be used as template placeholders
+	templateVariableRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// placeholderRegex matches the double and triple stash template expressions
+	placeholderRegex = regexp.MustCompile(`{{{?([^{}]*)}?}}`)
+)
+
+// supportedFieldTypes are the field types converted into DocuSign tabs when the signature is requested
+var supportedFieldTypes = map[string]bool{
+	"text":          true,
+	"text_unlocked": true,
+	"text_optional": true,
+	"number":        true,
+	"sign":          true,
+	"sign_optional": true,
+	"date":          true,
+}
+
+// CustomTemplateInput contains the CLA template uploaded by the CLA Group
+type CustomTemplateInput struct {
+	Name                 string
+	Description          string
+	TemplateMajorVersion int64
+	IclaHTMLBody         string
+	CclaHTMLBody         string
+	// MetaFields declare the template placeholders and contain the values used to render the documents
+	MetaFields []*models.MetaField
+	// IclaFields and CclaFields are the DocuSign tabs, the Apache style tabs are used when not provided
+	IclaFields []*models.Field
+	CclaFields []*models.Field
+	CreatedBy  string
+}
+
+// CustomTemplateUpload is the result of uploading a custom CLA template
+type CustomTemplateUpload struct {
+	TemplateID     string
+	TemplateName   string
+	Version        string
+	TemplatePdfs   models.TemplatePdfs
+	IclaPreviewURL string
+	CclaPreviewURL string
+	IclaDigest     string
+	CclaDigest     string
+}
+
+// TemplateValidationError is returned when the uploaded template can't be used, it lists every problem found
+type TemplateValidationError struct {
+	Problems []string
+}
+
+func (e *TemplateValidationError) Error() string {
+	return fmt.Sprintf("invalid template: %s", strings.Join(e.Problems, "; "))
+}
+
+// buildCustomTemplate converts the uploaded template into the template model, the Apache style DocuSign tabs are used
+// when the tabs are not provided
+func buildCustomTemplate(templateID string, input *CustomTemplateInput) models.Template {
+	template := models.Template{
+		ID:                   templateID,
+		Name:                 strings.TrimSpace(input.Name),
+		Description:          input.Description,
+		TemplateMajorVersion: input.TemplateMajorVersion,
+		IclaHTMLBody:         input.IclaHTMLBody,
+		CclaHTMLBody:         input.CclaHTMLBody,
+		IclaFields:           input.IclaFields,
+		CclaFields:           input.CclaFields,
+	}
+	if template.TemplateMajorVersion <= 0 {
+		template.TemplateMajorVersion = 1
+	}
+
+	for _, metaField := range input.MetaFields {
+		template.MetaFields = append(template.MetaFields, &models.MetaField{
+			Name:             metaField.Name,
+			Description:      metaField.Description,
+			TemplateVariable: metaField.TemplateVariable,
+		})
+	}
+
+	defaultTemplate := templateMap[ApacheStyleTemplateID]
+	if template.IclaHTMLBody != "" && len(template.IclaFields) == 0 {
+		template.IclaFields = defaultTemplate.IclaFields
+	}
+	if template.CclaHTMLBody != "" && len(template.CclaFields) == 0 {
+		template.CclaFields = defaultTemplate.CclaFields
+	}
+
+	return template
+}
+
+// validateCustomTemplate checks the template placeholders resolve to the meta fields and the DocuSign tab anchors
+// are found in the template text, a body is required for each CLA type enabled on the CLA Group
+func validateCustomTemplate(template models.Template, iclaEnabled, cclaEnabled bool) error {
+	var problems []string
+
+	if template.Name == "" {
+		problems = append(problems, "the template name is required")
+	}
+	if template.IclaHTMLBody == "" && template.CclaHTMLBody == "" {
+		problems = append(problems, "an icla or ccla template body is required")
+	}
+	if iclaEnabled && template.IclaHTMLBody == "" {
+		problems = append(problems, "the icla template body is required as the CLA Group has the ICLA enabled")
+	}
+	if cclaEnabled && template.CclaHTMLBody == "" {
+		problems = append(problems, "the ccla template body is required as the CLA Group has the CCLA enabled")
+	}
+
+	variables := map[string]bool{}
+	names := map[string]bool{}
+	for _, metaField := range template.MetaFields {
+		if metaField.Name == "" {
+			problems = append(problems, fmt.Sprintf("the meta field with variable %s has no name", metaField.TemplateVariable))
+		} else if names[metaField.Name] {
+			problems = append(problems, fmt.Sprintf("the meta field name %s is declared more than once", metaField.Name))
+		}
+		names[metaField.Name] = true
+
+		if !templateVariableRegex.MatchString(metaField.TemplateVariable) {
+			problems = append(problems, fmt.Sprintf("the meta field %s has an invalid template variable: '%s'", metaField.Name, metaField.TemplateVariable))
+			continue
+		}
+		if variables[metaField.TemplateVariable] {
+			problems = append(problems, fmt.Sprintf("the template variable %s is declared more than once", metaField.TemplateVariable))
+		}
+		variables[metaField.TemplateVariable] = true
+	}
+
+	used := map[string]bool{}
+	for _, body := range []struct {
+		claType string
+		html    string
+		fields  []*models.Field
+	}{
+		{claType: claTypeICLA, html: template.IclaHTMLBody, fields: template.IclaFields},
+		{claType: claTypeCCLA, html: template.CclaHTMLBody, fields: template.CclaFields},
+	} {
+		if body.html == "" {
+			continue
+		}
+		problems = append(problems, validateTemplateBody(body.claType, body.html, variables, used)...)
+		problems = append(problems, validateTemplateFields(body.claType, body.html, body.fields)...)
+	}
+
+	for _, metaField := range template.MetaFields {
+		if variables[metaField.TemplateVariable] && !used[metaField.TemplateVariable] {
+			problems = append(problems, fmt.Sprintf("the template variable %s is not used by the template", metaField.TemplateVariable))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &TemplateValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// validateTemplateBody checks the template parses and every placeholder is a declared template variable, the
+// placeholders found are added to the used variables
+func validateTemplateBody(claType, body string, variables, used map[string]bool) []string {
+	if len(body) > maxCustomTemplateSize {
+		return []string{fmt.Sprintf("the %s template is larger than %d bytes", claType, maxCustomTemplateSize)}
+	}
+	if _, err := raymond.Parse(body); err != nil {
+		return []string{fmt.Sprintf("the %s template can't be parsed: %v", claType, err)}
+	}
+
+	var problems []string
+	for _, match := range placeholderRegex.FindAllStringSubmatch(body, -1) {
+		variable := strings.TrimSpace(match[1])
+		if !templateVariableRegex.MatchString(variable) {
+			problems = append(problems, fmt.Sprintf("the %s template expression %s is not supported, only meta field placeholders can be used", claType, match[0]))
+			continue
+		}
+		if !variables[variable] {
+			problems = append(problems, fmt.Sprintf("the %s template placeholder %s has no matching meta field", claType, match[0]))
+			continue
+		}
+		used[variable] = true
+	}
+	return problems
+}
+
+// validateTemplateFields checks the DocuSign tabs - a signature tab is required and the anchor of each required tab
+// must be in the template text, DocuSign only ignores the missing anchors of the optional tabs
+func validateTemplateFields(claType, body string, fields []*models.Field) []string {
+	lines, err := htmlToText(strings.NewReader(body))
+	if err != nil {
+		return []string{fmt.Sprintf("the %s template text can't be read: %v", claType, err)}
+	}
+
+	var problems []string
+	ids := map[string]bool{}
+	hasSignature := false
+	for _, field := range fields {
+		if field.ID == "" {
+			problems = append(problems, fmt.Sprintf("the %s field %s has no id", claType, field.Name))
+		} else if ids[field.ID] {
+			problems = append(problems, fmt.Sprintf("the %s field id %s is declared more than once", claType, field.ID))
+		}
+		ids[field.ID] = true
+
+		if !supportedFieldTypes[field.FieldType] {
+			problems = append(problems, fmt.Sprintf("the %s field %s has an unsupported type: '%s'", claType, field.ID, field.FieldType))
+		}
+		if field.FieldType == "sign" {
+			hasSignature = true
+		}
+		if field.AnchorString == "" {
+			problems = append(problems, fmt.Sprintf("the %s field %s has no anchor string", claType, field.ID))
+		} else if !field.IsOptional && !containsAnchor(lines, field.AnchorString) {
+			problems = append(problems, fmt.Sprintf("the anchor '%s' of the required %s field %s is not in the template text", field.AnchorString, claType, field.ID))
+		}
+	}
+
+	if !hasSignature {
+		problems = append(problems, fmt.Sprintf("the %s template requires a field of type sign", claType))
+	}
+	return problems
+}
+
+// containsAnchor returns true if the anchor is in one of the template text lines, DocuSign matches the anchors
+// ignoring the case but doesn't match them across lines
+func containsAnchor(lines []string, anchor string) bool {
+	anchor = strings.ToLower(strings.Join(strings.Fields(anchor), " "))
+	for _, line := range lines {
+		if strings.Contains(strings.ToLower(line), anchor) {
+			return true
+		}
+	}
+	return false
+}
+
+// templateDigest returns the SHA-256 digest of the template body recorded in the audit event, empty if there is none
+func templateDigest(body string) string {
+	if body == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// toDBCustomTemplate converts the template into the data model stored on the CLA Group
+func toDBCustomTemplate(template models.Template, createdBy, dateCreated string) *DBCustomTemplate {
+	customTemplate := &DBCustomTemplate{
+		TemplateID:           template.ID,
+		Name:                 template.Name,
+		Description:          template.Description,
+		TemplateMajorVersion: template.TemplateMajorVersion,
+		IclaHTMLBody:         template.IclaHTMLBody,
+		CclaHTMLBody:         template.CclaHTMLBody,
+		IclaFields:           toDBTemplateFields(template.IclaFields),
+		CclaFields:           toDBTemplateFields(template.CclaFields),
+		DateCreated:          dateCreated,
+		CreatedBy:            createdBy,
+	}
+	for _, metaField := range template.MetaFields {
+		customTemplate.MetaFields = append(customTemplate.MetaFields, DBTemplateMetaField{
+			Name:             metaField.Name,
+			Description:      metaField.Description,
+			TemplateVariable: metaField.TemplateVariable,
+		})
+	}
+	return customTemplate
+}
+
+func toDBTemplateFields(fields []*models.Field) []DBTemplateField {
+	var dbFields []DBTemplateField
+	for _, field := range fields {
+		dbFields = append(dbFields, DBTemplateField{
+			ID:           field.ID,
+			Name:         field.Name,
+			AnchorString: field.AnchorString,
+			FieldType:    field.FieldType,
+			IsOptional:   field.IsOptional,
+			IsEditable:   field.IsEditable,
+			Width:        field.Width,
+			Height:       field.Height,
+			OffsetX:      field.OffsetX,
+			OffsetY:      field.OffsetY,
+		})
+	}
+	return dbFields
+}
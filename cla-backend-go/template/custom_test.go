@@ -0,0 +1,238 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func signField(anchor string) *models.Field {
+	return &models.Field{ID: "sign", Name: "Signature", FieldType: "sign", AnchorString: anchor}
+}
+
+func TestValidateCustomTemplate(t *testing.T) {
+	metaFields := []*models.MetaField{{Name: "Project Name", TemplateVariable: "PROJECT_NAME"}}
+	iclaBody := "<p>{{PROJECT_NAME}} Individual CLA</p><p>Signature:</p>"
+	cclaBody := "<p>{{ PROJECT_NAME }} Corporate CLA</p><p>Signature:</p>"
+
+	testCases := []struct {
+		name        string
+		template    models.Template
+		iclaEnabled bool
+		cclaEnabled bool
+		problems    []string
+	}{
+		{
+			name: "valid template",
+			template: models.Template{
+				Name:         "custom",
+				MetaFields:   metaFields,
+				IclaHTMLBody: iclaBody,
+				IclaFields:   []*models.Field{signField("Signature")},
+				CclaHTMLBody: cclaBody,
+				CclaFields:   []*models.Field{signField("Signature")},
+			},
+			iclaEnabled: true,
+			cclaEnabled: true,
+		},
+		{
+			name:        "no name and no body",
+			template:    models.Template{},
+			iclaEnabled: true,
+			problems: []string{
+				"the template name is required",
+				"an icla or ccla template body is required",
+				"the icla template body is required as the CLA Group has the ICLA enabled",
+			},
+		},
+		{
+			name: "ccla body required by the CLA Group",
+			template: models.Template{
+				Name:         "custom",
+				MetaFields:   metaFields,
+				IclaHTMLBody: iclaBody,
+				IclaFields:   []*models.Field{signField("Signature")},
+			},
+			iclaEnabled: true,
+			cclaEnabled: true,
+			problems:    []string{"the ccla template body is required as the CLA Group has the CCLA enabled"},
+		},
+		{
+			name: "invalid meta fields",
+			template: models.Template{
+				Name: "custom",
+				MetaFields: []*models.MetaField{
+					{Name: "Project Name", TemplateVariable: "PROJECT_NAME"},
+					{Name: "Project Name", TemplateVariable: "PROJECT_NAME"},
+					{TemplateVariable: "CONTACT_EMAIL"},
+					{Name: "Invalid", TemplateVariable: "not-a-variable"},
+				},
+				IclaHTMLBody: iclaBody,
+				IclaFields:   []*models.Field{signField("Signature")},
+			},
+			problems: []string{
+				"the meta field name Project Name is declared more than once",
+				"the template variable PROJECT_NAME is declared more than once",
+				"the meta field with variable CONTACT_EMAIL has no name",
+				"the meta field Invalid has an invalid template variable: 'not-a-variable'",
+				"the template variable CONTACT_EMAIL is not used by the template",
+			},
+		},
+		{
+			name: "unused template variable",
+			template: models.Template{
+				Name:         "custom",
+				MetaFields:   append([]*models.MetaField{{Name: "Contact", TemplateVariable: "CONTACT_EMAIL"}}, metaFields...),
+				IclaHTMLBody: iclaBody,
+				IclaFields:   []*models.Field{signField("Signature")},
+			},
+			problems: []string{"the template variable CONTACT_EMAIL is not used by the template"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateCustomTemplate(tc.template, tc.iclaEnabled, tc.cclaEnabled)
+			if len(tc.problems) == 0 {
+				assert.Nil(t, err)
+				return
+			}
+			var validationErr *TemplateValidationError
+			if assert.True(t, errors.As(err, &validationErr)) {
+				assert.ElementsMatch(t, tc.problems, validationErr.Problems)
+			}
+		})
+	}
+}
+
+func TestValidateTemplateBody(t *testing.T) {
+	variables := map[string]bool{"PROJECT_NAME": true, "CONTACT_EMAIL": true}
+
+	testCases := []struct {
+		name     string
+		body     string
+		used     []string
+		problems []string
+	}{
+		{
+			name: "double and triple stash placeholders",
+			body: "<p>{{PROJECT_NAME}}</p><p>{{{ CONTACT_EMAIL }}}</p>",
+			used: []string{"CONTACT_EMAIL", "PROJECT_NAME"},
+		},
+		{
+			name:     "undeclared placeholder",
+			body:     "<p>{{PROJECT_NAME}} {{COMPANY}}</p>",
+			used:     []string{"PROJECT_NAME"},
+			problems: []string{"the icla template placeholder {{COMPANY}} has no matching meta field"},
+		},
+		{
+			name:     "helper expression",
+			body:     "<p>{{#if PROJECT_NAME}}yes{{/if}}</p>",
+			problems: []string{"the icla template expression {{#if PROJECT_NAME}} is not supported, only meta field placeholders can be used", "the icla template expression {{/if}} is not supported, only meta field placeholders can be used"},
+		},
+		{
+			name:     "unparsable template",
+			body:     "<p>{{#if PROJECT_NAME}}</p>",
+			problems: []string{"the icla template can't be parsed"},
+		},
+		{
+			name:     "template too large",
+			body:     strings.Repeat("a", maxCustomTemplateSize+1),
+			problems: []string{"the icla template is larger than 102400 bytes"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			used := map[string]bool{}
+			problems := validateTemplateBody(claTypeICLA, tc.body, variables, used)
+			assert.Len(t, problems, len(tc.problems))
+			for i, problem := range tc.problems {
+				if i < len(problems) {
+					assert.True(t, strings.HasPrefix(problems[i], problem), problems[i])
+				}
+			}
+			var usedVariables []string
+			for variable := range used {
+				usedVariables = append(usedVariables, variable)
+			}
+			assert.ElementsMatch(t, tc.used, usedVariables)
+		})
+	}
+}
+
+func TestValidateTemplateFields(t *testing.T) {
+	body := "<p>Full name:</p><p>Signature:</p><p>Date:</p>"
+
+	testCases := []struct {
+		name     string
+		fields   []*models.Field
+		problems []string
+	}{
+		{
+			name: "valid fields",
+			fields: []*models.Field{
+				{ID: "full_name", Name: "Full Name", FieldType: "text", AnchorString: "full  NAME:"},
+				signField("Signature:"),
+				{ID: "date", Name: "Date", FieldType: "date", AnchorString: "Date:"},
+				{ID: "mailing", Name: "Mailing Address", FieldType: "text_optional", AnchorString: "Mailing Address:", IsOptional: true},
+			},
+		},
+		{
+			name:     "signature field required",
+			fields:   []*models.Field{{ID: "date", Name: "Date", FieldType: "date", AnchorString: "Date:"}},
+			problems: []string{"the icla template requires a field of type sign"},
+		},
+		{
+			name: "invalid fields",
+			fields: []*models.Field{
+				signField("Signature:"),
+				signField("Signature:"),
+				{Name: "No ID", FieldType: "text", AnchorString: "Date:"},
+				{ID: "checkbox", Name: "Checkbox", FieldType: "checkbox", AnchorString: "Date:"},
+				{ID: "no_anchor", Name: "No Anchor", FieldType: "text"},
+				{ID: "mailing", Name: "Mailing Address", FieldType: "text", AnchorString: "Mailing Address:"},
+			},
+			problems: []string{
+				"the icla field id sign is declared more than once",
+				"the icla field No ID has no id",
+				"the icla field checkbox has an unsupported type: 'checkbox'",
+				"the icla field no_anchor has no anchor string",
+				"the anchor 'Mailing Address:' of the required icla field mailing is not in the template text",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tc.problems, validateTemplateFields(claTypeICLA, body, tc.fields))
+		})
+	}
+}
+
+func TestContainsAnchor(t *testing.T) {
+	lines := []string{"Full name: ____", "Signature: ____"}
+
+	testCases := []struct {
+		anchor   string
+		expected bool
+	}{
+		{anchor: "Full name:", expected: true},
+		{anchor: "SIGNATURE:", expected: true},
+		{anchor: " full   name: ", expected: true},
+		{anchor: "____ Signature", expected: false},
+		{anchor: "Date:", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.anchor, func(t *testing.T) {
+			assert.Equal(t, tc.expected, containsAnchor(lines, tc.anchor))
+		})
+	}
+}
@@ -3,6 +3,10 @@
 
 package template
 
+import (
+	models2 "github.com/linuxfoundation/easycla/cla-backend-go/project/models"
+)
+
 // DBProjectModel data model
 type DBProjectModel struct {
 	DateCreated                      string                   `dynamodbav:"date_created"`
@@ -18,6 +22,9 @@ type DBProjectModel struct {
 	ProjectIndividualDocuments       []DBProjectDocumentModel `dynamodbav:"project_individual_documents"`
 	ProjectMemberDocuments           []DBProjectDocumentModel `dynamodbav:"project_member_documents"`
 	ProjectACL                       []string                 `dynamodbav:"project_acl"`
+	ProjectIndividualResignPolicy    *models2.DBResignPolicy  `dynamodbav:"project_individual_resign_policy,omitempty"`
+	ProjectCorporateResignPolicy     *models2.DBResignPolicy  `dynamodbav:"project_corporate_resign_policy,omitempty"`
+	ProjectCustomTemplate            *DBCustomTemplate        `dynamodbav:"project_custom_template,omitempty"`
 }
 
 // DBProjectDocumentModel is a data model for the CLA Group Project documents
@@ -33,3 +40,39 @@ type DBProjectDocumentModel struct {
 	DocumentMinorVersion    string `dynamodbav:"document_minor_version"`
 	DocumentCreationDate    string `dynamodbav:"document_creation_date"`
 }
+
+// DBCustomTemplate is a data model for the CLA template uploaded by the CLA Group
+type DBCustomTemplate struct {
+	TemplateID           string                `dynamodbav:"template_id"`
+	Name                 string                `dynamodbav:"name"`
+	Description          string                `dynamodbav:"description"`
+	TemplateMajorVersion int64                 `dynamodbav:"template_major_version"`
+	IclaHTMLBody         string                `dynamodbav:"icla_html_body,omitempty"`
+	CclaHTMLBody         string                `dynamodbav:"ccla_html_body,omitempty"`
+	MetaFields           []DBTemplateMetaField `dynamodbav:"meta_fields"`
+	IclaFields           []DBTemplateField     `dynamodbav:"icla_fields,omitempty"`
+	CclaFields           []DBTemplateField     `dynamodbav:"ccla_fields,omitempty"`
+	DateCreated          string                `dynamodbav:"date_created"`
+	CreatedBy            string                `dynamodbav:"created_by"`
+}
+
+// DBTemplateMetaField is a data model for the meta-data fields of the CLA Group template
+type DBTemplateMetaField struct {
+	Name             string `dynamodbav:"name"`
+	Description      string `dynamodbav:"description"`
+	TemplateVariable string `dynamodbav:"template_variable"`
+}
+
+// DBTemplateField is a data model for the DocuSign tab fields of the CLA Group template
+type DBTemplateField struct {
+	ID           string `dynamodbav:"id"`
+	Name         string `dynamodbav:"name"`
+	AnchorString string `dynamodbav:"anchor_string"`
+	FieldType    string `dynamodbav:"field_type"`
+	IsOptional   bool   `dynamodbav:"is_optional"`
+	IsEditable   bool   `dynamodbav:"is_editable"`
+	Width        int64  `dynamodbav:"width"`
+	Height       int64  `dynamodbav:"height"`
+	OffsetX      int64  `dynamodbav:"offset_x"`
+	OffsetY      int64  `dynamodbav:"offset_y"`
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/common"
+	models2 "github.com/linuxfoundation/easycla/cla-backend-go/project/models"
 )
 
 var (
@@ -44,6 +46,8 @@ type RepositoryInterface interface {
 	GetCLAGroup(claGroupID string) (*models.ClaGroup, error)
 	GetCLADocuments(claGroupID string, claType string) ([]models.ClaGroupDocument, error)
 	UpdateDynamoContractGroupTemplates(ctx context.Context, ContractGroupID string, template models.Template, pdfUrls models.TemplatePdfs, projectCCLAEnabled, projectICLAEnabled bool) error
+	UpdateResignPolicy(ctx context.Context, claGroupID, claType string, policy *models2.DBResignPolicy) error
+	UpdateCustomTemplate(ctx context.Context, claGroupID string, customTemplate *DBCustomTemplate) error
 }
 
 // Repository object/struct
@@ -264,6 +268,9 @@ func (r Repository) buildProjectModel(dbModel DBProjectModel) *models.ClaGroup {
 		DateCreated:             dbModel.DateCreated,
 		DateModified:            dbModel.DateModified,
 		Version:                 dbModel.Version,
+
+		ProjectIndividualResignPolicy: common.BuildResignPolicyModel(dbModel.ProjectIndividualResignPolicy),
+		ProjectCorporateResignPolicy:  common.BuildResignPolicyModel(dbModel.ProjectCorporateResignPolicy),
 	}
 }
 
@@ -458,6 +465,100 @@ func (r Repository) UpdateDynamoContractGroupTemplates(ctx context.Context, claG
 	return nil
 }
 
+// UpdateResignPolicy saves the ICLA or CCLA document version requiring re-signature on the CLA Group
+func (r Repository) UpdateResignPolicy(ctx context.Context, claGroupID, claType string, policy *models2.DBResignPolicy) error {
+	f := logrus.Fields{
+		"functionName":   "v1.template.repository.UpdateResignPolicy",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"claType":        claType,
+	}
+	tableName := fmt.Sprintf("cla-%s-projects", r.stage)
+
+	var attributeName string
+	switch claType {
+	case utils.ClaTypeICLA:
+		attributeName = "project_individual_resign_policy"
+	case utils.ClaTypeCCLA:
+		attributeName = "project_corporate_resign_policy"
+	default:
+		return fmt.Errorf("not supported cla type supplied: %s", claType)
+	}
+
+	policyValue, err := dynamodbattribute.Marshal(policy)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal the re-sign policy")
+		return err
+	}
+
+	_, now := utils.CurrentTime()
+	_, err = r.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {S: aws.String(claGroupID)},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#project_id":    aws.String("project_id"),
+			"#resign_policy": aws.String(attributeName),
+			"#date_modified": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":resign_policy": policyValue,
+			":date_modified": {S: aws.String(now)},
+		},
+		ConditionExpression: aws.String("attribute_exists(#project_id)"),
+		UpdateExpression:    aws.String("SET #resign_policy = :resign_policy, #date_modified = :date_modified"),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to update the %s re-sign policy of CLA Group: %s", claType, claGroupID)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateCustomTemplate saves the template uploaded by the CLA Group, replacing any previously uploaded template
+func (r Repository) UpdateCustomTemplate(ctx context.Context, claGroupID string, customTemplate *DBCustomTemplate) error {
+	f := logrus.Fields{
+		"functionName":   "v1.template.repository.UpdateCustomTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"templateID":     customTemplate.TemplateID,
+	}
+	tableName := fmt.Sprintf("cla-%s-projects", r.stage)
+
+	templateValue, err := dynamodbattribute.Marshal(customTemplate)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal the custom template")
+		return err
+	}
+
+	_, now := utils.CurrentTime()
+	_, err = r.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {S: aws.String(claGroupID)},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#project_id":      aws.String("project_id"),
+			"#custom_template": aws.String("project_custom_template"),
+			"#date_modified":   aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":custom_template": templateValue,
+			":date_modified":   {S: aws.String(now)},
+		},
+		ConditionExpression: aws.String("attribute_exists(#project_id)"),
+		UpdateExpression:    aws.String("SET #custom_template = :custom_template, #date_modified = :date_modified"),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to update the custom template of CLA Group: %s", claGroupID)
+		return err
+	}
+
+	return nil
+}
+
 // templateMap contains a list of our template models
 var templateMap = map[string]models.Template{
 	ApacheStyleTemplateID: {
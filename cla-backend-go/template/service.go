@@ -4,11 +4,11 @@
 package template
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
-	"strconv"
 	"strings"
 	"time"
 
@@ -20,11 +20,16 @@ import (
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/docraptor"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/common"
+	models2 "github.com/linuxfoundation/easycla/cla-backend-go/project/models"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aymerick/raymond"
+	"github.com/gofrs/uuid"
 )
 
 const (
@@ -37,9 +42,13 @@ type ServiceInterface interface {
 	GetTemplates(ctx context.Context) ([]models.Template, error)
 	GetTemplateName(ctx context.Context, templateID string) (string, error)
 	CreateCLAGroupTemplate(ctx context.Context, claGroupID string, claGroupFields *models.CreateClaGroupTemplate) (models.TemplatePdfs, error)
+	UploadCLAGroupTemplate(ctx context.Context, claGroupID string, input *CustomTemplateInput) (*CustomTemplateUpload, error)
 	CreateTemplatePreview(ctx context.Context, claGroupFields *models.CreateClaGroupTemplate, templateFor string) ([]byte, error)
 	GetCLATemplatePreview(ctx context.Context, claGroupID, claType string, watermark bool) ([]byte, error)
 	CLAGroupTemplateExists(ctx context.Context, templateID string) bool
+	GetCLAGroupTemplateVersions(ctx context.Context, claGroupID, claType string) ([]*TemplateVersion, *models.ClaGroupResignPolicy, error)
+	GetCLAGroupTemplateDiff(ctx context.Context, claGroupID, claType, fromVersion, toVersion string) (*TemplateDiff, error)
+	RequireCLAGroupTemplateResign(ctx context.Context, claGroupID, claType string, input *ResignInput) (*models.ClaGroupResignPolicy, error)
 }
 
 // Service object/struct
@@ -186,11 +195,55 @@ func (s Service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		return models.TemplatePdfs{}, err
 	}
 
+	pdfUrls, _, err := s.createCLAGroupDocuments(ctx, claGroupID, claGroup, template, claGroupFields.MetaFields)
+	return pdfUrls, err
+}
+
+// createCLAGroupDocuments generates the CLA Group ICLA and CCLA documents from the template as a new document version
+// and makes them the current CLA Group documents, returns the document URLs and the new document version
+func (s Service) createCLAGroupDocuments(ctx context.Context, claGroupID string, claGroup *models.ClaGroup, template models.Template, metaFields []*models.MetaField) (models.TemplatePdfs, documentVersion, error) {
+	pdfUrls, template, newVersion, err := s.generateCLAGroupDocuments(ctx, claGroupID, claGroup, template, metaFields)
+	if err != nil {
+		return models.TemplatePdfs{}, documentVersion{}, err
+	}
+	err = s.saveCLAGroupDocuments(ctx, claGroupID, claGroup, template, pdfUrls)
+	if err != nil {
+		return models.TemplatePdfs{}, documentVersion{}, err
+	}
+	return pdfUrls, newVersion, nil
+}
+
+// generateCLAGroupDocuments generates the ICLA and CCLA PDFs from the template as a new document version and uploads
+// them to S3, the CLA Group documents are not changed until saveCLAGroupDocuments is called. Returns the PDF URLs,
+// the template with the rendered HTML and the new document version
+func (s Service) generateCLAGroupDocuments(ctx context.Context, claGroupID string, claGroup *models.ClaGroup, template models.Template, metaFields []*models.MetaField) (models.TemplatePdfs, models.Template, documentVersion, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.service.generateCLAGroupDocuments",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"templateID":     template.ID,
+	}
+
+	// New documents are added as a new version, the previous versions are kept for the existing signatures
+	var existingDocuments []models.ClaGroupDocument
+	for _, claType := range []string{claTypeICLA, claTypeCCLA} {
+		documents, docErr := s.templateRepo.GetCLADocuments(claGroupID, claType)
+		if docErr != nil {
+			log.WithFields(f).WithError(docErr).Warnf("Unable to fetch the %s documents of CLA group: %s - returning empty template PDFs", claType, claGroupID)
+			return models.TemplatePdfs{}, template, documentVersion{}, docErr
+		}
+		existingDocuments = append(existingDocuments, documents...)
+	}
+	newVersion := nextDocumentVersion(existingDocuments, template)
+	template.TemplateMajorVersion = int64(newVersion.major)
+	template.TemplateMinorVersion = int64(newVersion.minor)
+	f["documentVersion"] = newVersion.String()
+
 	// Apply template fields
-	iclaTemplateHTML, cclaTemplateHTML, err := s.InjectProjectInformationIntoTemplate(template, claGroupFields.MetaFields)
+	iclaTemplateHTML, cclaTemplateHTML, err := s.InjectProjectInformationIntoTemplate(template, metaFields)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warn("Unable to inject metadata details into template - returning empty template PDFs")
-		return models.TemplatePdfs{}, err
+		return models.TemplatePdfs{}, template, documentVersion{}, err
 	}
 
 	bucket := fmt.Sprintf("cla-signature-files-%s", s.stage)
@@ -211,7 +264,7 @@ func (s Service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 			ioReader, iclaErr := s.docRaptorClient.CreatePDF(iclaTemplateHTML, claTypeICLA)
 			if iclaErr != nil {
 				log.WithFields(f).WithError(iclaErr).Warn("Problem generating ICLA template via docraptor client - returning empty template PDFs")
-				return err
+				return iclaErr
 			}
 			defer func() {
 				closeErr := ioReader.Close()
@@ -220,11 +273,13 @@ func (s Service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 				}
 			}()
 			iclaFileName := s.generateTemplateS3FilePath(claGroupID, claTypeICLA)
-			iclaFileURL, err = s.SaveTemplateToS3(bucket, iclaFileName, ioReader)
-			if err != nil {
-				log.WithFields(f).WithError(err).Warnf("Problem uploading ICLA PDF: %s to s3 - returning empty template PDFs", iclaFileName)
-				return err
+			var saveErr error
+			iclaFileURL, saveErr = s.SaveTemplateToS3(bucket, iclaFileName, ioReader)
+			if saveErr != nil {
+				log.WithFields(f).WithError(saveErr).Warnf("Problem uploading ICLA PDF: %s to s3 - returning empty template PDFs", iclaFileName)
+				return saveErr
 			}
+			s.saveTemplateHTMLToS3(ctx, bucket, templateHTMLFilePath(iclaFileName), iclaTemplateHTML)
 
 			template.IclaHTMLBody = iclaTemplateHTML
 			return nil
@@ -238,7 +293,7 @@ func (s Service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 			ioReader, cclaErr := s.docRaptorClient.CreatePDF(cclaTemplateHTML, claTypeCCLA)
 			if cclaErr != nil {
 				log.WithFields(f).WithError(cclaErr).Warn("Problem generating CCLA template via docraptor client - returning empty template PDFs")
-				return err
+				return cclaErr
 			}
 			defer func() {
 				closeErr := ioReader.Close()
@@ -247,11 +302,13 @@ func (s Service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 				}
 			}()
 			cclaFileName := s.generateTemplateS3FilePath(claGroupID, claTypeCCLA)
-			cclaFileURL, err = s.SaveTemplateToS3(bucket, cclaFileName, ioReader)
-			if err != nil {
-				log.WithFields(f).Warnf("Problem uploading CCLA PDF: %s to s3, error: %v - returning empty template PDFs", cclaFileName, err)
-				return err
+			var saveErr error
+			cclaFileURL, saveErr = s.SaveTemplateToS3(bucket, cclaFileName, ioReader)
+			if saveErr != nil {
+				log.WithFields(f).Warnf("Problem uploading CCLA PDF: %s to s3, error: %v - returning empty template PDFs", cclaFileName, saveErr)
+				return saveErr
 			}
+			s.saveTemplateHTMLToS3(ctx, bucket, templateHTMLFilePath(cclaFileName), cclaTemplateHTML)
 
 			template.CclaHTMLBody = cclaTemplateHTML
 			return nil
@@ -261,7 +318,7 @@ func (s Service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 	// Wait for the go routines to finish
 	log.WithFields(f).Debug("Waiting for PDF generation to complete...")
 	if pdfErr := eg.Wait(); pdfErr != nil {
-		return models.TemplatePdfs{}, pdfErr
+		return models.TemplatePdfs{}, template, documentVersion{}, pdfErr
 	}
 
 	if claGroup.ProjectICLAEnabled && claGroup.ProjectCCLAEnabled {
@@ -279,17 +336,138 @@ func (s Service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		}
 	}
 
+	return pdfUrls, template, newVersion, nil
+}
+
+// saveCLAGroupDocuments adds the generated documents to the CLA Group, they become the current CLA Group documents
+func (s Service) saveCLAGroupDocuments(ctx context.Context, claGroupID string, claGroup *models.ClaGroup, template models.Template, pdfUrls models.TemplatePdfs) error {
+	f := logrus.Fields{
+		"functionName":   "v1.template.service.saveCLAGroupDocuments",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"templateID":     template.ID,
+		"cclaEnabled":    claGroup.ProjectCCLAEnabled,
+		"iclaEnabled":    claGroup.ProjectICLAEnabled,
+	}
+
 	// Save Template to DynamoDB
-	f["cclaEnabled"] = claGroup.ProjectCCLAEnabled
-	f["iclaEnabled"] = claGroup.ProjectICLAEnabled
 	log.WithFields(f).Debug("updating templates for the cla group")
-	err = s.templateRepo.UpdateDynamoContractGroupTemplates(ctx, claGroupID, template, pdfUrls, claGroup.ProjectCCLAEnabled, claGroup.ProjectICLAEnabled)
+	err := s.templateRepo.UpdateDynamoContractGroupTemplates(ctx, claGroupID, template, pdfUrls, claGroup.ProjectCCLAEnabled, claGroup.ProjectICLAEnabled)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("Problem updating the database with ICLA/CCLA new PDF details, error: %v - returning empty template PDFs", err)
-		return models.TemplatePdfs{}, err
+		return err
+	}
+	return nil
+}
+
+// UploadCLAGroupTemplate validates the template uploaded by the CLA Group, generates the CLA Group documents from it
+// as a new document version and stores watermarked previews of the generated documents
+func (s Service) UploadCLAGroupTemplate(ctx context.Context, claGroupID string, input *CustomTemplateInput) (*CustomTemplateUpload, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.service.UploadCLAGroupTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"templateName":   input.Name,
+		"createdBy":      input.CreatedBy,
+	}
+
+	claGroup, err := s.templateRepo.GetCLAGroup(claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to fetch CLA group by id: %s", claGroupID)
+		return nil, err
+	}
+
+	templateID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate the template ID")
+		return nil, err
+	}
+	template := buildCustomTemplate(templateID.String(), input)
+	f["templateID"] = template.ID
+
+	if validationErr := validateCustomTemplate(template, claGroup.ProjectICLAEnabled, claGroup.ProjectCCLAEnabled); validationErr != nil {
+		log.WithFields(f).WithError(validationErr).Debug("the uploaded template is not valid")
+		return nil, validationErr
+	}
+
+	// the uploaded template is kept as is, the documents are generated from a copy with the project details injected
+	pdfUrls, documentTemplate, version, err := s.generateCLAGroupDocuments(ctx, claGroupID, claGroup, template, input.MetaFields)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate the CLA group documents from the uploaded template")
+		return nil, err
+	}
+	f["documentVersion"] = version.String()
+
+	upload := &CustomTemplateUpload{
+		TemplateID:   template.ID,
+		TemplateName: template.Name,
+		Version:      version.String(),
+		TemplatePdfs: pdfUrls,
+	}
+
+	// The previews are rendered from the generated documents before anything is committed, so a failed upload
+	// leaves the current CLA Group documents unchanged
+	if claGroup.ProjectICLAEnabled {
+		upload.IclaDigest = templateDigest(template.IclaHTMLBody)
+		upload.IclaPreviewURL, err = s.saveTemplatePreview(ctx, claGroupID, claTypeICLA, pdfUrls.IndividualPDFURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if claGroup.ProjectCCLAEnabled {
+		upload.CclaDigest = templateDigest(template.CclaHTMLBody)
+		upload.CclaPreviewURL, err = s.saveTemplatePreview(ctx, claGroupID, claTypeCCLA, pdfUrls.CorporatePDFURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The template is saved before the generated documents become the documents of record, so every live document
+	// version has its template stored for reference and later edits
+	_, now := utils.CurrentTime()
+	err = s.templateRepo.UpdateCustomTemplate(ctx, claGroupID, toDBCustomTemplate(template, input.CreatedBy, now))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save the uploaded template")
+		return nil, err
+	}
+
+	err = s.saveCLAGroupDocuments(ctx, claGroupID, claGroup, documentTemplate, pdfUrls)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save the CLA group documents generated from the uploaded template")
+		return nil, err
 	}
 
-	return pdfUrls, nil
+	return upload, nil
+}
+
+// saveTemplatePreview stores the watermarked preview of the generated document and returns its download link
+func (s Service) saveTemplatePreview(ctx context.Context, claGroupID, claType, pdfS3URL string) (string, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.service.saveTemplatePreview",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"claType":        claType,
+	}
+
+	preview, err := s.downloadTemplatePDF(ctx, pdfS3URL, true)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to render the template preview")
+		return "", err
+	}
+
+	fileName := fmt.Sprintf("contract-group/%s/template/preview/%s-%s.pdf", claGroupID, claType,
+		strings.ReplaceAll(utils.CurrentSimpleDateTimeString(), ":", "-"))
+	if err = utils.PutToS3(fileName, preview); err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to upload the template preview: %s", fileName)
+		return "", err
+	}
+
+	downloadLink, err := utils.GetDownloadLink(fileName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to create the download link of the template preview: %s", fileName)
+		return "", err
+	}
+	return downloadLink, nil
 }
 
 // GetCLATemplatePreview returns a preview of the specified CLA Group and CLA type
@@ -345,7 +523,11 @@ func (s Service) GetCLATemplatePreview(ctx context.Context, claGroupID, claType
 		return nil, err
 	}
 
-	doc := getLatestDocument(ctx, claGroupDocuments)
+	doc, err := common.GetCurrentDocument(ctx, claGroupDocuments)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to determine the current document for groupID : %s", claGroupID)
+		return nil, err
+	}
 	pdfS3URL := doc.DocumentS3URL
 	if pdfS3URL == "" {
 		err = fmt.Errorf("s3 url is empty for groupID : %s and document %s", claGroupID, doc.DocumentFileID)
@@ -353,6 +535,18 @@ func (s Service) GetCLATemplatePreview(ctx context.Context, claGroupID, claType
 		return nil, err
 	}
 
+	return s.downloadTemplatePDF(ctx, pdfS3URL, watermark)
+}
+
+// downloadTemplatePDF downloads the document PDF from S3, watermarked as not for execution if requested
+func (s Service) downloadTemplatePDF(ctx context.Context, pdfS3URL string, watermark bool) ([]byte, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.service.downloadTemplatePDF",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"pdfS3URL":       pdfS3URL,
+		"watermark":      watermark,
+	}
+
 	// Convert:
 	//   https://cla-signature-files-dev.s3.amazonaws.com/contract-group/66b97366-a298-4625-965e-0c292c39f9a2/template/ccla-2020-09-25T22-37-51Z.pdf
 	// to:
@@ -360,7 +554,7 @@ func (s Service) GetCLATemplatePreview(ctx context.Context, claGroupID, claType
 	fileName, urlErr := utils.GetPathFromURL(pdfS3URL)
 	if urlErr != nil {
 		log.WithFields(f).WithError(urlErr).Warnf("problem obtaining path from URL: %s", pdfS3URL)
-		return nil, err
+		return nil, urlErr
 	}
 
 	// Strip any leading slashes...
@@ -385,81 +579,6 @@ func (s Service) GetCLATemplatePreview(ctx context.Context, claGroupID, claType
 	return b, nil
 }
 
-func getLatestDocument(ctx context.Context, documents []models.ClaGroupDocument) *models.ClaGroupDocument {
-	f := logrus.Fields{
-		"functionName":   "v1.template.service.getLatestDocument",
-		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
-	}
-	var latestDocument *models.ClaGroupDocument
-	var latestMajorVersion = 0
-	var latestMinorVersion = 0
-	var latestDateTime time.Time
-	for _, currentDocument := range documents {
-		if latestDocument == nil {
-			latestDocument = &currentDocument // nolint
-			// Grab and save the major version
-			major, convertErr := strconv.Atoi(latestDocument.DocumentMajorVersion)
-			if convertErr != nil {
-				log.WithFields(f).WithError(convertErr).Warnf("problem converting document major version to int: %s", latestDocument.DocumentMajorVersion)
-				major = 0
-			}
-			latestMajorVersion = major
-
-			// Grab and save the major version
-			minor, convertErr := strconv.Atoi(latestDocument.DocumentMinorVersion)
-			if convertErr != nil {
-				log.WithFields(f).WithError(convertErr).Warnf("problem converting document minor version to int: %s", latestDocument.DocumentMinorVersion)
-				minor = 0
-			}
-			latestMinorVersion = minor
-
-			dateTime, dateTimeErr := utils.ParseDateTime(latestDocument.DocumentCreationDate)
-			if dateTimeErr != nil {
-				log.WithFields(f).WithError(dateTimeErr).Warnf("problem converting document creation date to time object: %s", latestDocument.DocumentCreationDate)
-			}
-			latestDateTime = dateTime
-
-			continue
-		}
-
-		// Grab and save the major version
-		major, convertErr := strconv.Atoi(currentDocument.DocumentMajorVersion)
-		if convertErr != nil {
-			log.WithFields(f).WithError(convertErr).Warnf("problem converting document major version to int: %s", currentDocument.DocumentMajorVersion)
-			major = 0
-		}
-
-		// Grab and save the major version
-		minor, convertErr := strconv.Atoi(currentDocument.DocumentMinorVersion)
-		if convertErr != nil {
-			log.WithFields(f).WithError(convertErr).Warnf("problem converting document minor version to int: %s", currentDocument.DocumentMinorVersion)
-			minor = 0
-		}
-
-		dateTime, dateTimeErr := utils.ParseDateTime(currentDocument.DocumentCreationDate)
-		if dateTimeErr != nil {
-			log.WithFields(f).WithError(dateTimeErr).Warnf("problem converting document creation date to time object: %s", currentDocument.DocumentCreationDate)
-		}
-
-		if major > latestMajorVersion {
-			latestDocument = &currentDocument // nolint
-			continue
-		}
-
-		if minor > latestMinorVersion {
-			latestDocument = &currentDocument // nolint
-			continue
-		}
-
-		if dateTime.After(latestDateTime) {
-			latestDocument = &currentDocument // nolint
-			continue
-		}
-	}
-
-	return latestDocument
-}
-
 // InjectProjectInformationIntoTemplate service function
 func (s Service) InjectProjectInformationIntoTemplate(template models.Template, metaFields []*models.MetaField) (string, string, error) {
 	f := logrus.Fields{
@@ -556,3 +675,247 @@ func (s Service) SaveTemplateToS3(bucket, filepath string, template io.ReadClose
 func (s Service) CLAGroupTemplateExists(ctx context.Context, templateID string) bool {
 	return s.templateRepo.CLAGroupTemplateExists(ctx, templateID)
 }
+
+// saveTemplateHTMLToS3 stores the rendered template HTML next to the template PDF, the text is used to compare the
+// document versions - failures are only logged as the PDF is the document of record
+func (s Service) saveTemplateHTMLToS3(ctx context.Context, bucket, filepath, templateHTML string) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.service.saveTemplateHTMLToS3",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"bucket":         bucket,
+		"filepath":       filepath,
+	}
+
+	_, err := s.s3Client.Upload(&s3manager.UploadInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(filepath),
+		Body:        strings.NewReader(templateHTML),
+		ContentType: aws.String("text/html"),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to upload the template HTML - the document version can't be compared")
+	}
+}
+
+// getCLAGroupDocuments returns the CLA Group and its documents of the specified type sorted newest version first
+func (s Service) getCLAGroupDocuments(claGroupID, claType string) (*models.ClaGroup, []models.ClaGroupDocument, error) {
+	if claType != claTypeICLA && claType != claTypeCCLA {
+		return nil, nil, fmt.Errorf("not supported cla type provided : %s", claType)
+	}
+
+	claGroup, err := s.templateRepo.GetCLAGroup(claGroupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	documents, err := s.templateRepo.GetCLADocuments(claGroupID, claType)
+	if err != nil {
+		return nil, nil, err
+	}
+	sortDocumentsByVersion(documents)
+
+	return claGroup, documents, nil
+}
+
+// resignPolicyOf returns the re-sign policy of the specified CLA type, nil if none was set
+func resignPolicyOf(claGroup *models.ClaGroup, claType string) *models.ClaGroupResignPolicy {
+	if claType == claTypeICLA {
+		return claGroup.ProjectIndividualResignPolicy
+	}
+	return claGroup.ProjectCorporateResignPolicy
+}
+
+// GetCLAGroupTemplateVersions returns the ICLA or CCLA document versions of the CLA Group, newest first, along with
+// the re-sign policy
+func (s Service) GetCLAGroupTemplateVersions(ctx context.Context, claGroupID, claType string) ([]*TemplateVersion, *models.ClaGroupResignPolicy, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.service.GetCLAGroupTemplateVersions",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"claType":        claType,
+	}
+
+	claGroup, documents, err := s.getCLAGroupDocuments(claGroupID, claType)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA group documents")
+		return nil, nil, err
+	}
+
+	policy := resignPolicyOf(claGroup, claType)
+	var required *documentVersion
+	if policy != nil {
+		policyVersion, parseErr := parseDocumentVersion(policy.DocumentMajorVersion + "." + policy.DocumentMinorVersion)
+		if parseErr != nil {
+			log.WithFields(f).WithError(parseErr).Warn("invalid re-sign policy version - ignoring")
+		} else {
+			required = &policyVersion
+		}
+	}
+
+	versions := make([]*TemplateVersion, 0, len(documents))
+	for i, document := range documents {
+		version := versionOf(document)
+		versions = append(versions, &TemplateVersion{
+			Document:       document,
+			Version:        version.String(),
+			Latest:         i == 0,
+			RequiresResign: required != nil && version.before(*required),
+		})
+	}
+
+	return versions, policy, nil
+}
+
+// GetCLAGroupTemplateDiff returns the text difference between two ICLA or CCLA document versions of the CLA Group
+func (s Service) GetCLAGroupTemplateDiff(ctx context.Context, claGroupID, claType, fromVersion, toVersion string) (*TemplateDiff, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.service.GetCLAGroupTemplateDiff",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"claType":        claType,
+		"fromVersion":    fromVersion,
+		"toVersion":      toVersion,
+	}
+
+	from, err := parseDocumentVersion(fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDocumentVersion(toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	_, documents, err := s.getCLAGroupDocuments(claGroupID, claType)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA group documents")
+		return nil, err
+	}
+
+	fromLines, err := s.getDocumentText(ctx, documents, from)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load the text of version: %s", from)
+		return nil, err
+	}
+	toLines, err := s.getDocumentText(ctx, documents, to)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load the text of version: %s", to)
+		return nil, err
+	}
+
+	diff := diffLines(fromLines, toLines)
+	diff.FromVersion = from.String()
+	diff.ToVersion = to.String()
+	log.WithFields(f).Debugf("template diff - added: %d, removed: %d", diff.Added, diff.Removed)
+	return diff, nil
+}
+
+// getDocumentText downloads the rendered HTML of the document version and returns its lines of text
+func (s Service) getDocumentText(ctx context.Context, documents []models.ClaGroupDocument, version documentVersion) ([]string, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.service.getDocumentText",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"version":        version.String(),
+	}
+
+	// documents are sorted newest first, the most recent document of the version is used
+	var document *models.ClaGroupDocument
+	for i := range documents {
+		if versionOf(documents[i]) == version {
+			document = &documents[i]
+			break
+		}
+	}
+	if document == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateVersionNotFound, version)
+	}
+
+	pdfPath, err := utils.GetPathFromURL(document.DocumentS3URL)
+	if err != nil || document.DocumentS3URL == "" {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateVersionTextUnavailable, version)
+	}
+	fileName := templateHTMLFilePath(strings.TrimLeft(pdfPath, "/"))
+
+	b, err := utils.DownloadFromS3(fileName)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			log.WithFields(f).Debugf("no template HTML stored for document: %s", fileName)
+			return nil, fmt.Errorf("%w: %s", ErrTemplateVersionTextUnavailable, version)
+		}
+		return nil, err
+	}
+
+	lines, err := htmlToText(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) > maxDiffLines {
+		return nil, fmt.Errorf("version %s has %d lines, documents over %d lines can't be compared", version, len(lines), maxDiffLines)
+	}
+
+	return lines, nil
+}
+
+// RequireCLAGroupTemplateResign marks the ICLA or CCLA document version contributors must have signed, signatures on
+// older versions are outdated and are handled by the CLA checks according to the enforcement
+func (s Service) RequireCLAGroupTemplateResign(ctx context.Context, claGroupID, claType string, input *ResignInput) (*models.ClaGroupResignPolicy, error) {
+	f := logrus.Fields{
+		"functionName":    "v1.template.service.RequireCLAGroupTemplateResign",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"claGroupID":      claGroupID,
+		"claType":         claType,
+		"version":         input.Version,
+		"enforcement":     input.Enforcement,
+		"gracePeriodDays": input.GracePeriodDays,
+	}
+
+	version, err := parseDocumentVersion(input.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	_, documents, err := s.getCLAGroupDocuments(claGroupID, claType)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA group documents")
+		return nil, err
+	}
+
+	found := false
+	for _, document := range documents {
+		if versionOf(document) == version {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateVersionNotFound, version)
+	}
+
+	now := time.Now().UTC()
+	policy := &models2.DBResignPolicy{
+		DocumentMajorVersion: version.major,
+		DocumentMinorVersion: version.minor,
+		Enforcement:          input.Enforcement,
+		DateCreated:          utils.TimeToString(now),
+		CreatedBy:            input.CreatedBy,
+	}
+
+	switch input.Enforcement {
+	case utils.ResignEnforcementGracePeriod:
+		if input.GracePeriodDays <= 0 {
+			return nil, fmt.Errorf("%w: the grace period days are required for the %s enforcement", ErrInvalidResignEnforcement, input.Enforcement)
+		}
+		policy.GracePeriodEnds = utils.TimeToString(now.AddDate(0, 0, int(input.GracePeriodDays)))
+	case utils.ResignEnforcementBlocked:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidResignEnforcement, input.Enforcement)
+	}
+
+	err = s.templateRepo.UpdateResignPolicy(ctx, claGroupID, claType, policy)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save the re-sign policy")
+		return nil, err
+	}
+
+	return common.BuildResignPolicyModel(policy), nil
+}
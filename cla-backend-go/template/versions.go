@@ -0,0 +1,321 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"golang.org/x/net/html"
+)
+
+// diff line operations
+const (
+	DiffEqual   = "equal"
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+)
+
+// maxDiffLines limits the size of the documents compared, the diff takes O((N+M)·D) time for D changed lines
+const maxDiffLines = 5000
+
+var (
+	// ErrTemplateVersionNotFound is returned when the CLA Group has no document with the requested version
+	ErrTemplateVersionNotFound = errors.New("template version not found")
+	// ErrTemplateVersionTextUnavailable is returned when the document text was not stored, documents created before
+	// template versioning can't be compared
+	ErrTemplateVersionTextUnavailable = errors.New("template version text is not available")
+	// ErrInvalidTemplateVersion is returned when a version is not in the major.minor format
+	ErrInvalidTemplateVersion = errors.New("invalid template version")
+	// ErrInvalidResignEnforcement is returned when the re-sign enforcement or grace period is not valid
+	ErrInvalidResignEnforcement = errors.New("invalid re-sign enforcement")
+)
+
+// TemplateVersion is a version of the CLA Group ICLA or CCLA document
+type TemplateVersion struct {
+	Document       models.ClaGroupDocument
+	Version        string
+	Latest         bool
+	RequiresResign bool
+}
+
+// TemplateDiff is the line based text difference between two document versions
+type TemplateDiff struct {
+	FromVersion string
+	ToVersion   string
+	Added       int
+	Removed     int
+	Lines       []DiffLine
+}
+
+// DiffLine is a line of the template diff
+type DiffLine struct {
+	Operation string
+	Text      string
+}
+
+// ResignInput contains the details of the document version requiring re-signature
+type ResignInput struct {
+	Version         string
+	Enforcement     string
+	GracePeriodDays int64
+	CreatedBy       string
+}
+
+// documentVersion is the major.minor version of a CLA Group document
+type documentVersion struct {
+	major int
+	minor int
+}
+
+func (v documentVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+// before returns true if the version is older than the other version
+func (v documentVersion) before(other documentVersion) bool {
+	return v.major < other.major || (v.major == other.major && v.minor < other.minor)
+}
+
+// parseDocumentVersion parses a version in the major.minor or major format
+func parseDocumentVersion(version string) (documentVersion, error) {
+	parts := strings.Split(strings.TrimSpace(version), ".")
+	if len(parts) > 2 {
+		return documentVersion{}, fmt.Errorf("%w: %s", ErrInvalidTemplateVersion, version)
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil || major < 0 {
+		return documentVersion{}, fmt.Errorf("%w: %s", ErrInvalidTemplateVersion, version)
+	}
+
+	minor := 0
+	if len(parts) == 2 {
+		minor, err = strconv.Atoi(parts[1])
+		if err != nil || minor < 0 {
+			return documentVersion{}, fmt.Errorf("%w: %s", ErrInvalidTemplateVersion, version)
+		}
+	}
+
+	return documentVersion{major: major, minor: minor}, nil
+}
+
+// versionOf returns the document version, invalid version numbers are treated as zero
+func versionOf(document models.ClaGroupDocument) documentVersion {
+	major, majorErr := strconv.Atoi(document.DocumentMajorVersion)
+	if majorErr != nil {
+		major = 0
+	}
+	minor, minorErr := strconv.Atoi(document.DocumentMinorVersion)
+	if minorErr != nil {
+		minor = 0
+	}
+	return documentVersion{major: major, minor: minor}
+}
+
+// nextDocumentVersion returns the version of a new document generated from the template - the template version for
+// the first document or a newer template major version, otherwise the next minor version of the latest document
+func nextDocumentVersion(documents []models.ClaGroupDocument, template models.Template) documentVersion {
+	templateVersion := documentVersion{major: int(template.TemplateMajorVersion), minor: int(template.TemplateMinorVersion)}
+	if len(documents) == 0 {
+		return templateVersion
+	}
+
+	latest := versionOf(documents[0])
+	for _, document := range documents[1:] {
+		if version := versionOf(document); latest.before(version) {
+			latest = version
+		}
+	}
+
+	if templateVersion.major > latest.major {
+		return templateVersion
+	}
+	return documentVersion{major: latest.major, minor: latest.minor + 1}
+}
+
+// sortDocumentsByVersion sorts the documents newest first, documents with the same version by creation date
+func sortDocumentsByVersion(documents []models.ClaGroupDocument) {
+	sort.SliceStable(documents, func(i, j int) bool {
+		vi, vj := versionOf(documents[i]), versionOf(documents[j])
+		if vi == vj {
+			return documents[i].DocumentCreationDate > documents[j].DocumentCreationDate
+		}
+		return vj.before(vi)
+	})
+}
+
+// templateHTMLFilePath returns the path of the rendered template HTML stored next to the template PDF
+func templateHTMLFilePath(pdfFilePath string) string {
+	return strings.TrimSuffix(pdfFilePath, ".pdf") + ".html"
+}
+
+// blockElements are the HTML elements rendered on their own line in the template text
+var blockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "tr": true, "table": true, "ul": true, "ol": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "section": true, "hr": true,
+}
+
+// htmlToText converts the rendered template HTML into lines of text, one line per block element
+func htmlToText(r io.Reader) ([]string, error) {
+	var lines []string
+	var current strings.Builder
+	flush := func() {
+		line := strings.Join(strings.Fields(current.String()), " ")
+		if line != "" {
+			lines = append(lines, line)
+		}
+		current.Reset()
+	}
+
+	skip := 0
+	tokenizer := html.NewTokenizer(r)
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return nil, err
+			}
+			flush()
+			return lines, nil
+		case html.TextToken:
+			if skip == 0 {
+				current.WriteString(" ")
+				current.Write(tokenizer.Text())
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			switch tag := string(name); {
+			case tag == "script" || tag == "style":
+				skip++
+			case blockElements[tag]:
+				flush()
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch tag := string(name); {
+			case (tag == "script" || tag == "style") && skip > 0:
+				skip--
+			case blockElements[tag]:
+				flush()
+			}
+		}
+	}
+}
+
+// diffLines returns the line based difference between the from and to lines, a shortest edit script computed with
+// the linear space variant of the Myers diff algorithm
+func diffLines(from, to []string) *TemplateDiff {
+	diff := &TemplateDiff{}
+	diff.compare(from, to)
+	return diff
+}
+
+// compare appends the difference between the from and to lines, splitting them at the middle snake of the shortest
+// edit script until one side is empty
+func (d *TemplateDiff) compare(from, to []string) {
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+	d.appendLines(DiffEqual, from[:prefix])
+	from, to = from[prefix:], to[prefix:]
+
+	suffix := 0
+	for suffix < len(from) && suffix < len(to) && from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+	common := from[len(from)-suffix:]
+	from, to = from[:len(from)-suffix], to[:len(to)-suffix]
+
+	switch {
+	case len(from) == 0:
+		d.appendLines(DiffAdded, to)
+	case len(to) == 0:
+		d.appendLines(DiffRemoved, from)
+	default:
+		// both parts have fewer differences than the whole, so the recursion ends
+		x, y, u, v := middleSnake(from, to)
+		d.compare(from[:x], to[:y])
+		d.appendLines(DiffEqual, from[x:u])
+		d.compare(from[u:], to[v:])
+	}
+
+	d.appendLines(DiffEqual, common)
+}
+
+func (d *TemplateDiff) appendLines(operation string, lines []string) {
+	for _, line := range lines {
+		d.Lines = append(d.Lines, DiffLine{Operation: operation, Text: line})
+	}
+	switch operation {
+	case DiffAdded:
+		d.Added += len(lines)
+	case DiffRemoved:
+		d.Removed += len(lines)
+	}
+}
+
+// middleSnake returns the start (x, y) and the end (u, v) of the middle snake of the shortest edit script between
+// from and to, found by extending the furthest reaching paths from both ends until they overlap
+func middleSnake(from, to []string) (x, y, u, v int) {
+	n, m := len(from), len(to)
+	delta := n - m
+	odd := delta%2 != 0
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+
+	// forward[offset+k] and backward[offset+k] are the furthest x reached on diagonal k, the backward paths
+	// run on the reversed lines
+	forward := make([]int, 2*offset+1)
+	backward := make([]int, 2*offset+1)
+
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y = x - k
+			u, v = x, y
+			for u < n && v < m && from[u] == to[v] {
+				u++
+				v++
+			}
+			forward[offset+k] = u
+			if reverseK := delta - k; odd && reverseK >= -(d-1) && reverseK <= d-1 && u+backward[offset+reverseK] >= n {
+				return x, y, u, v
+			}
+		}
+
+		for k := -d; k <= d; k += 2 {
+			var rx int
+			if k == -d || (k != d && backward[offset+k-1] < backward[offset+k+1]) {
+				rx = backward[offset+k+1]
+			} else {
+				rx = backward[offset+k-1] + 1
+			}
+			ry := rx - k
+			startX, startY := rx, ry
+			for rx < n && ry < m && from[n-1-rx] == to[m-1-ry] {
+				rx++
+				ry++
+			}
+			backward[offset+k] = rx
+			if forwardK := delta - k; !odd && forwardK >= -d && forwardK <= d && rx+forward[offset+forwardK] >= n {
+				return n - rx, m - ry, n - startX, m - startY
+			}
+		}
+	}
+
+	// not reached, the paths overlap before d exceeds half of the longest edit script - removing every from line
+	// and adding every to line is still a valid difference
+	return n, 0, n, 0
+}
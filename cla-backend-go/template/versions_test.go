@@ -0,0 +1,194 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDocumentVersion(t *testing.T) {
+	version, err := parseDocumentVersion("2.1")
+	assert.Nil(t, err)
+	assert.Equal(t, documentVersion{major: 2, minor: 1}, version)
+
+	version, err = parseDocumentVersion(" 3 ")
+	assert.Nil(t, err)
+	assert.Equal(t, documentVersion{major: 3}, version)
+
+	for _, invalid := range []string{"", "a.b", "1.2.3", "-1.0", "1.-2"} {
+		_, err = parseDocumentVersion(invalid)
+		assert.ErrorIs(t, err, ErrInvalidTemplateVersion, invalid)
+	}
+}
+
+func TestNextDocumentVersion(t *testing.T) {
+	template := models.Template{TemplateMajorVersion: 2, TemplateMinorVersion: 0}
+	document := func(major, minor string) models.ClaGroupDocument {
+		return models.ClaGroupDocument{DocumentMajorVersion: major, DocumentMinorVersion: minor}
+	}
+
+	testCases := []struct {
+		name      string
+		documents []models.ClaGroupDocument
+		expected  string
+	}{
+		{
+			name:     "first document",
+			expected: "2.0",
+		},
+		{
+			name:      "next minor version of the latest document",
+			documents: []models.ClaGroupDocument{document("2", "0"), document("2", "3"), document("1", "9")},
+			expected:  "2.4",
+		},
+		{
+			name:      "newer template major version",
+			documents: []models.ClaGroupDocument{document("1", "4")},
+			expected:  "2.0",
+		},
+		{
+			name:      "documents newer than the template",
+			documents: []models.ClaGroupDocument{document("3", "1")},
+			expected:  "3.2",
+		},
+		{
+			name:      "invalid document version",
+			documents: []models.ClaGroupDocument{document("x", "y")},
+			expected:  "2.0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, nextDocumentVersion(tc.documents, template).String())
+		})
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	lines, err := htmlToText(strings.NewReader(`<html><head><style>p { color: red; }</style><script>var a = "<p>";</script></head>
+<body><h1>Individual   Contributor
+License Agreement</h1><p>You accept <b>and</b> agree.</p><ul><li>one</li><li>two</li></ul>first<br/>second<div></div></body></html>`))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"Individual Contributor License Agreement",
+		"You accept and agree.",
+		"one",
+		"two",
+		"first",
+		"second",
+	}, lines)
+
+	lines, err = htmlToText(strings.NewReader(""))
+	assert.Nil(t, err)
+	assert.Empty(t, lines)
+}
+
+func TestDiffLines(t *testing.T) {
+	diff := diffLines([]string{"a", "b", "c", "d"}, []string{"a", "c", "d", "e"})
+	assert.Equal(t, 1, diff.Added)
+	assert.Equal(t, 1, diff.Removed)
+	assert.Equal(t, []DiffLine{
+		{Operation: DiffEqual, Text: "a"},
+		{Operation: DiffRemoved, Text: "b"},
+		{Operation: DiffEqual, Text: "c"},
+		{Operation: DiffEqual, Text: "d"},
+		{Operation: DiffAdded, Text: "e"},
+	}, diff.Lines)
+
+	// a changed line is a removal and an addition
+	diff = diffLines([]string{"a", "b"}, []string{"a", "B"})
+	assert.Equal(t, []DiffLine{
+		{Operation: DiffEqual, Text: "a"},
+		{Operation: DiffRemoved, Text: "b"},
+		{Operation: DiffAdded, Text: "B"},
+	}, diff.Lines)
+
+	diff = diffLines(nil, []string{"a"})
+	assert.Equal(t, 1, diff.Added)
+	assert.Equal(t, 0, diff.Removed)
+
+	diff = diffLines([]string{"a"}, nil)
+	assert.Equal(t, 0, diff.Added)
+	assert.Equal(t, 1, diff.Removed)
+
+	diff = diffLines([]string{"a", "b"}, []string{"a", "b"})
+	assert.Equal(t, 0, diff.Added)
+	assert.Equal(t, 0, diff.Removed)
+	assert.Len(t, diff.Lines, 2)
+}
+
+// lcsLength is the reference longest common subsequence length of a and b
+func lcsLength(a, b []string) int {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	return lengths[0][0]
+}
+
+func randomLines(r *rand.Rand, n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", r.Intn(4))
+	}
+	return lines
+}
+
+func TestDiffLinesShortestEditScript(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		from, to := randomLines(r, r.Intn(20)), randomLines(r, r.Intn(20))
+		diff := diffLines(from, to)
+
+		var gotFrom, gotTo []string
+		for _, line := range diff.Lines {
+			if line.Operation != DiffAdded {
+				gotFrom = append(gotFrom, line.Text)
+			}
+			if line.Operation != DiffRemoved {
+				gotTo = append(gotTo, line.Text)
+			}
+		}
+		assert.Equal(t, len(from), len(gotFrom))
+		assert.Equal(t, len(to), len(gotTo))
+		if len(from) > 0 {
+			assert.Equal(t, from, gotFrom)
+		}
+		if len(to) > 0 {
+			assert.Equal(t, to, gotTo)
+		}
+		assert.Equal(t, len(from)+len(to)-2*lcsLength(from, to), diff.Added+diff.Removed, "from: %v, to: %v", from, to)
+	}
+}
+
+func TestDiffLinesLargeDocuments(t *testing.T) {
+	from := make([]string, maxDiffLines)
+	to := make([]string, maxDiffLines)
+	for i := range from {
+		from[i] = fmt.Sprintf("from %d", i)
+		to[i] = fmt.Sprintf("to %d", i)
+	}
+	diff := diffLines(from, to)
+	assert.Equal(t, maxDiffLines, diff.Added)
+	assert.Equal(t, maxDiffLines, diff.Removed)
+}
@@ -11,4 +11,25 @@ mockgen -copyright_file=copyright-header.txt -source=repositories/service.go -de
 mockgen -copyright_file=copyright-header.txt -source=repositories/repository.go -destination=repositories/mock/mock_repository.go -package=mock 
 mockgen -copyright_file=copyright-header.txt -source=github_organizations/repository.go -destination=github_organizations/mock/mock_repository.go -package=mock RepositoryInterface
 mockgen -copyright_file=copyright-header.txt -source=events/service.go -destination=events/mock/mock_service.go -package=mock Service
-mockgen -copyright_file=copyright-header.txt -source=events/repository.go -destination=events/mock/mock_repository.go -package=mock RepositoryInterface
\ No newline at end of file
+mockgen -copyright_file=copyright-header.txt -source=events/repository.go -destination=events/mock/mock_repository.go -package=mock RepositoryInterface
+mkdir -p utils/mock
+mockgen -copyright_file=copyright-header.txt -source=utils/email.go -destination=utils/mock/mock_email.go -package=mock
+mockgen -copyright_file=copyright-header.txt -source=utils/email_outbox.go -destination=utils/mock/mock_email_outbox.go -package=mock
+mkdir -p v2/webhooks/mock
+mockgen -copyright_file=copyright-header.txt -source=v2/webhooks/repository.go -destination=v2/webhooks/mock/mock_repository.go -package=mock
+mkdir -p gerrit_reconciliation/mock
+mockgen -copyright_file=copyright-header.txt -source=gerrit_reconciliation/groups.go -destination=gerrit_reconciliation/mock/mock_groups.go -package=mock
+mkdir -p v2/github_activity/mock
+mockgen -copyright_file=copyright-header.txt -source=v2/github_activity/check_run.go -destination=v2/github_activity/mock/mock_check_run.go -package=mock
+mkdir -p emails/mock
+mockgen -copyright_file=copyright-header.txt -source=emails/prefill.go -destination=emails/mock/mock_prefill.go -package=mock EmailTemplateService
+mkdir -p v2/approvals/mock
+mockgen -copyright_file=copyright-header.txt -source=v2/approvals/repository.go -destination=v2/approvals/mock/mock_repository.go -package=mock
+mkdir -p approval_expiry/mock
+mockgen -copyright_file=copyright-header.txt -source=approval_expiry/service.go -destination=approval_expiry/mock/mock_service.go -package=mock
+mkdir -p notification_digest/mock
+mockgen -copyright_file=copyright-header.txt -source=notification_digest/repository.go -destination=notification_digest/mock/mock_repository.go -package=mock
+mkdir -p v2/dynamo_events/mock
+mockgen -copyright_file=copyright-header.txt -source=v2/dynamo_events/dead_letter_repository.go -destination=v2/dynamo_events/mock/mock_dead_letter_repository.go -package=mock
+mkdir -p approval_list/mock
+mockgen -copyright_file=copyright-header.txt -source=approval_list/repository.go -destination=approval_list/mock/mock_repository.go -package=mock
@@ -12,6 +12,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	users "github.com/linuxfoundation/easycla/cla-backend-go/users"
 )
 
 // MockUserRepository is a mock of UserRepository interface.
@@ -66,6 +67,21 @@ func (mr *MockUserRepositoryMockRecorder) Delete(userID interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), userID)
 }
 
+// GetAllUsers mocks base method.
+func (m *MockUserRepository) GetAllUsers() ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUsers")
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUsers indicates an expected call of GetAllUsers.
+func (mr *MockUserRepositoryMockRecorder) GetAllUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockUserRepository)(nil).GetAllUsers))
+}
+
 // GetUser mocks base method.
 func (m *MockUserRepository) GetUser(userID string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -274,3 +290,18 @@ func (mr *MockUserRepositoryMockRecorder) UpdateUserCompanyID(userID, companyID,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserCompanyID", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserCompanyID), userID, companyID, note)
 }
+
+// UpdateUserIdentities mocks base method.
+func (m *MockUserRepository) UpdateUserIdentities(userID string, identities users.UserIdentities) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserIdentities", userID, identities)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserIdentities indicates an expected call of UpdateUserIdentities.
+func (mr *MockUserRepositoryMockRecorder) UpdateUserIdentities(userID, identities interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserIdentities", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserIdentities), userID, identities)
+}
@@ -5,23 +5,38 @@ package users
 
 // DBUser data model
 type DBUser struct {
-	UserID             string   `json:"user_id"`
-	UserExternalID     string   `json:"user_external_id"`
-	LFEmail            string   `json:"lf_email"`
-	Admin              bool     `json:"admin"`
-	LFUsername         string   `json:"lf_username"`
-	DateCreated        string   `json:"date_created"`
-	DateModified       string   `json:"date_modified"`
-	UserName           string   `json:"user_name"`
-	Version            string   `json:"version"`
-	UserEmails         []string `json:"user_emails"`
-	UserGithubID       string   `json:"user_github_id"`
-	UserGithubUsername string   `json:"user_github_username"`
-	UserGitlabID       string   `json:"user_gitlab_id"`
-	UserGitlabUsername string   `json:"user_gitlab_username"`
-	UserCompanyID      string   `json:"user_company_id"`
-	Note               string   `json:"note"`
-	LFSub              string   `json:"lf_sub"`
+	UserID                 string   `json:"user_id"`
+	UserExternalID         string   `json:"user_external_id"`
+	LFEmail                string   `json:"lf_email"`
+	Admin                  bool     `json:"admin"`
+	LFUsername             string   `json:"lf_username"`
+	DateCreated            string   `json:"date_created"`
+	DateModified           string   `json:"date_modified"`
+	UserName               string   `json:"user_name"`
+	Version                string   `json:"version"`
+	UserEmails             []string `json:"user_emails"`
+	UserGithubID           string   `json:"user_github_id"`
+	UserGithubUsername     string   `json:"user_github_username"`
+	UserGitlabID           string   `json:"user_gitlab_id"`
+	UserGitlabUsername     string   `json:"user_gitlab_username"`
+	UserCompanyID          string   `json:"user_company_id"`
+	Note                   string   `json:"note"`
+	LFSub                  string   `json:"lf_sub"`
+	PreferredLanguage      string   `json:"preferred_language"`
+	NotificationPreference string   `json:"notification_preference"`
+}
+
+// UserIdentities contains the identity attributes of a user record, empty values are removed from the record
+type UserIdentities struct {
+	LFEmail        string   `json:"lf_email,omitempty"`
+	LFUsername     string   `json:"lf_username,omitempty"`
+	Emails         []string `json:"user_emails,omitempty"`
+	GitHubID       string   `json:"user_github_id,omitempty"`
+	GitHubUsername string   `json:"user_github_username,omitempty"`
+	GitLabID       string   `json:"user_gitlab_id,omitempty"`
+	GitLabUsername string   `json:"user_gitlab_username,omitempty"`
+	CompanyID      string   `json:"user_company_id,omitempty"`
+	Note           string   `json:"note,omitempty"`
 }
 
 type UserEmails struct {
@@ -49,6 +49,8 @@ type UserRepository interface {
 	SearchUsers(searchField string, searchTerm string, fullMatch bool) (*models.Users, error)
 	UpdateUserCompanyID(userID, companyID, note string) error
 	GetUsersByEmail(userEmail string) ([]*models.User, error)
+	GetAllUsers() ([]*models.User, error)
+	UpdateUserIdentities(userID string, identities UserIdentities) (*models.User, error)
 }
 
 // repository data model
@@ -165,6 +167,18 @@ func (repo repository) CreateUser(user *models.User) (*models.User, error) {
 		}
 	}
 
+	if user.PreferredLanguage != "" {
+		attributes["preferred_language"] = &dynamodb.AttributeValue{
+			S: aws.String(user.PreferredLanguage),
+		}
+	}
+
+	if user.NotificationPreference != "" {
+		attributes["notification_preference"] = &dynamodb.AttributeValue{
+			S: aws.String(user.NotificationPreference),
+		}
+	}
+
 	now := time.Now().UTC().Format(time.RFC3339)
 
 	user.DateCreated = now
@@ -425,6 +439,20 @@ func (repo repository) Save(user *models.UserUpdate) (*models.User, error) {
 		updateExpression = updateExpression + " #GI = :gi, "
 	}
 
+	if user.PreferredLanguage != "" && oldUserModel.PreferredLanguage != user.PreferredLanguage {
+		log.WithFields(f).Debugf("building query - adding preferred_language: %s", user.PreferredLanguage)
+		expressionAttributeNames["#PL"] = aws.String("preferred_language")
+		expressionAttributeValues[":pl"] = &dynamodb.AttributeValue{S: aws.String(user.PreferredLanguage)}
+		updateExpression = updateExpression + " #PL = :pl, "
+	}
+
+	if user.NotificationPreference != "" && oldUserModel.NotificationPreference != user.NotificationPreference {
+		log.WithFields(f).Debugf("building query - adding notification_preference: %s", user.NotificationPreference)
+		expressionAttributeNames["#NP"] = aws.String("notification_preference")
+		expressionAttributeValues[":np"] = &dynamodb.AttributeValue{S: aws.String(user.NotificationPreference)}
+		updateExpression = updateExpression + " #NP = :np, "
+	}
+
 	log.Debugf("building query - updating date_modified: %s", updatedDateTime.Format(time.RFC3339))
 	expressionAttributeNames["#D"] = aws.String("date_modified")
 	expressionAttributeValues[":d"] = &dynamodb.AttributeValue{S: aws.String(updatedDateTime.Format(time.RFC3339))}
@@ -1319,26 +1347,165 @@ func (repo repository) UpdateUserCompanyID(userID, companyID, note string) error
 	return nil
 }
 
+// GetAllUsers returns all the user records
+func (repo repository) GetAllUsers() ([]*models.User, error) {
+	f := logrus.Fields{
+		"functionName": "users.repository.GetAllUsers",
+	}
+
+	expr, err := expression.NewBuilder().WithProjection(buildUserProjection()).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for the users scan")
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames: expr.Names(),
+		ProjectionExpression:     expr.Projection(),
+		TableName:                aws.String(repo.tableName),
+	}
+
+	users := make([]*models.User, 0)
+	for {
+		result, errScan := repo.dynamoDBClient.Scan(scanInput)
+		if errScan != nil {
+			log.WithFields(f).WithError(errScan).Warn("error scanning the users table")
+			return nil, errScan
+		}
+
+		var dbUserModels []DBUser
+		err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &dbUserModels)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("error unmarshalling user records from database")
+			return nil, err
+		}
+		for _, dbUser := range dbUserModels {
+			users = append(users, convertDBUserModel(dbUser))
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	return users, nil
+}
+
+// UpdateUserIdentities replaces the identity attributes of the user record - empty values are removed
+func (repo repository) UpdateUserIdentities(userID string, identities UserIdentities) (*models.User, error) {
+	f := logrus.Fields{
+		"functionName": "users.repository.UpdateUserIdentities",
+		"userID":       userID,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	expressionAttributeNames := map[string]*string{
+		"#M": aws.String("date_modified"),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":m": {S: aws.String(currentTime)},
+	}
+	setExpressions := []string{"#M = :m"}
+	var removeExpressions []string
+
+	attributes := []struct {
+		name  string
+		value *dynamodb.AttributeValue
+	}{
+		{"lf_email", stringAttributeValue(identities.LFEmail)},
+		{"lf_username", stringAttributeValue(identities.LFUsername)},
+		{"user_emails", stringSetAttributeValue(identities.Emails)},
+		{"user_github_id", numberAttributeValue(identities.GitHubID)},
+		{"user_github_username", stringAttributeValue(identities.GitHubUsername)},
+		{"user_gitlab_id", numberAttributeValue(identities.GitLabID)},
+		{"user_gitlab_username", stringAttributeValue(identities.GitLabUsername)},
+		{"user_company_id", stringAttributeValue(identities.CompanyID)},
+		{"note", stringAttributeValue(identities.Note)},
+	}
+
+	for i, attribute := range attributes {
+		namePlaceholder := fmt.Sprintf("#A%d", i)
+		expressionAttributeNames[namePlaceholder] = aws.String(attribute.name)
+		if attribute.value == nil {
+			removeExpressions = append(removeExpressions, namePlaceholder)
+			continue
+		}
+		valuePlaceholder := fmt.Sprintf(":v%d", i)
+		expressionAttributeValues[valuePlaceholder] = attribute.value
+		setExpressions = append(setExpressions, fmt.Sprintf("%s = %s", namePlaceholder, valuePlaceholder))
+	}
+
+	updateExpression := "SET " + strings.Join(setExpressions, ", ")
+	if len(removeExpressions) > 0 {
+		updateExpression = updateExpression + " REMOVE " + strings.Join(removeExpressions, ", ")
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"user_id": {S: aws.String(userID)},
+		},
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		UpdateExpression:          aws.String(updateExpression),
+		TableName:                 aws.String(repo.tableName),
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update the user identities")
+		return nil, err
+	}
+
+	return repo.GetUser(userID)
+}
+
+// stringAttributeValue returns the string attribute value, nil for an empty value
+func stringAttributeValue(value string) *dynamodb.AttributeValue {
+	if value == "" {
+		return nil
+	}
+	return &dynamodb.AttributeValue{S: aws.String(value)}
+}
+
+// stringSetAttributeValue returns the string set attribute value, nil for an empty set
+func stringSetAttributeValue(values []string) *dynamodb.AttributeValue {
+	if len(values) == 0 {
+		return nil
+	}
+	return &dynamodb.AttributeValue{SS: aws.StringSlice(values)}
+}
+
+// numberAttributeValue returns the number attribute value, nil for an empty value
+func numberAttributeValue(value string) *dynamodb.AttributeValue {
+	if value == "" {
+		return nil
+	}
+	return &dynamodb.AttributeValue{N: aws.String(value)}
+}
+
 // convertDBUserModel translates a dyanamoDB data model into a service response model
 func convertDBUserModel(user DBUser) *models.User {
 	return &models.User{
-		UserID:         user.UserID,
-		UserExternalID: user.UserExternalID,
-		Admin:          user.Admin,
-		LfEmail:        strfmt.Email(user.LFEmail),
-		LfSub:          user.LFSub,
-		LfUsername:     user.LFUsername,
-		DateCreated:    user.DateCreated,
-		DateModified:   user.DateModified,
-		Username:       user.UserName,
-		Version:        user.Version,
-		Emails:         user.UserEmails,
-		GithubID:       user.UserGithubID,
-		GithubUsername: user.UserGithubUsername,
-		GitlabID:       user.UserGitlabID,
-		GitlabUsername: user.UserGitlabUsername,
-		CompanyID:      user.UserCompanyID,
-		Note:           user.Note,
+		UserID:                 user.UserID,
+		UserExternalID:         user.UserExternalID,
+		Admin:                  user.Admin,
+		LfEmail:                strfmt.Email(user.LFEmail),
+		LfSub:                  user.LFSub,
+		LfUsername:             user.LFUsername,
+		DateCreated:            user.DateCreated,
+		DateModified:           user.DateModified,
+		Username:               user.UserName,
+		Version:                user.Version,
+		Emails:                 user.UserEmails,
+		GithubID:               user.UserGithubID,
+		GithubUsername:         user.UserGithubUsername,
+		GitlabID:               user.UserGitlabID,
+		GitlabUsername:         user.UserGitlabUsername,
+		CompanyID:              user.UserCompanyID,
+		Note:                   user.Note,
+		PreferredLanguage:      user.PreferredLanguage,
+		NotificationPreference: user.NotificationPreference,
 	}
 }
 
@@ -1362,6 +1529,8 @@ func buildUserProjection() expression.ProjectionBuilder {
 		expression.Name("date_modified"),
 		expression.Name("version"),
 		expression.Name("note"),
+		expression.Name("preferred_language"),
+		expression.Name("notification_preference"),
 	)
 }
 
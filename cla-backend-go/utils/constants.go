@@ -99,6 +99,12 @@ const ClaTypeECLA = "ecla"
 // ClaTypeCCLA represents corporate CLA records (includes approval lists)
 const ClaTypeCCLA = "ccla"
 
+// ResignEnforcementGracePeriod lets signatures on an outdated CLA document version pass the checks until the grace period ends
+const ResignEnforcementGracePeriod = "grace_period"
+
+// ResignEnforcementBlocked fails the checks for signatures on an outdated CLA document version
+const ResignEnforcementBlocked = "blocked"
+
 // SignatureTypeCLA is the cla signature type in the DB
 const SignatureTypeCLA = "cla"
 
@@ -236,3 +242,7 @@ const GitlabOrgApprovalCriteria = "gitlabOrg"
 const EmailApprovalCriteria = "email"
 
 const DomainApprovalCriteria = "domain"
+
+const EmailRegexApprovalCriteria = "emailRegex"
+
+const GerritGroupApprovalCriteria = "gerritGroup"
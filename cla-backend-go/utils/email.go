@@ -24,6 +24,11 @@ type EmailSender interface {
 	SendEmail(subject string, body string, recipients []string) error
 }
 
+// IdempotentEmailSender is implemented by the email senders which de-duplicate the sends by an idempotency key
+type IdempotentEmailSender interface {
+	SendEmailWithIdempotencyKey(idempotencyKey, subject, body string, recipients []string) error
+}
+
 var emailSender EmailSender
 
 // SetEmailSender sets up default email sender
@@ -99,6 +104,18 @@ func SendEmail(subject string, body string, recipients []string) error {
 	return emailSender.SendEmail(subject, body, recipients)
 }
 
+// SendEmailWithIdempotencyKey sends the email once per recipient for the idempotency key when the email sender
+// de-duplicates the sends, the email is sent as is otherwise
+func SendEmailWithIdempotencyKey(idempotencyKey, subject, body string, recipients []string) error {
+	if emailSender == nil {
+		return errors.New("email sender not set")
+	}
+	if sender, ok := emailSender.(IdempotentEmailSender); ok {
+		return sender.SendEmailWithIdempotencyKey(idempotencyKey, subject, body, recipients)
+	}
+	return emailSender.SendEmail(subject, body, recipients)
+}
+
 // GetCorporateURL returns the corporate URL based on the specified flag
 func GetCorporateURL(isV2Project bool) string {
 	if isV2Project {
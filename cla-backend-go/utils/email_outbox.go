@@ -0,0 +1,486 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// email outbox delivery status values
+const (
+	EmailOutboxStatusQueued  = "queued"
+	EmailOutboxStatusSending = "sending"
+	EmailOutboxStatusSent    = "sent"
+	EmailOutboxStatusFailed  = "failed"
+)
+
+// outbox send settings - a send still marked sending after the stale period is considered lost, its lease has expired
+// and it can be claimed again by a send or the outbox worker
+const (
+	emailOutboxMaxAttempts     = 3
+	emailOutboxRetryBaseDelay  = 500 * time.Millisecond
+	emailOutboxSendingStaleAge = 15 * time.Minute
+)
+
+// outbox de-duplication windows - the same email content is not sent again to a recipient for a day, a send with an
+// explicit idempotency key is de-duplicated for longer. The records expire after the window, the expiry is also the
+// TTL attribute of the table.
+const (
+	emailOutboxContentDedupeWindow = 24 * time.Hour
+	emailOutboxKeyDedupeWindow     = 90 * 24 * time.Hour
+)
+
+// emailOutboxStatusIndex is the outbox table index of the status and the creation date, used to list the queued emails
+const emailOutboxStatusIndex = "email-outbox-status-index"
+
+// EmailOutboxItem is the outbox record of the email sent to one recipient, the outbox ID is the de-duplication key
+// of the recipient and the idempotency key of the send, or the email content when the send has no key
+type EmailOutboxItem struct {
+	OutboxID       string `dynamodbav:"outbox_id"`
+	IdempotencyKey string `dynamodbav:"idempotency_key,omitempty"`
+	Recipient      string `dynamodbav:"recipient"`
+	Subject        string `dynamodbav:"subject"`
+	Body           string `dynamodbav:"body"`
+	Status         string `dynamodbav:"status"`
+	ErrorMessage   string `dynamodbav:"error_message"`
+	Attempts       int    `dynamodbav:"attempts"`
+	DateCreated    string `dynamodbav:"date_created"`
+	DateModified   string `dynamodbav:"date_modified"`
+	// ExpiresAt is the epoch time in seconds the record stops de-duplicating the send
+	ExpiresAt int64 `dynamodbav:"expires_at"`
+}
+
+// EmailOutboxStore persists the outbox records
+type EmailOutboxStore interface {
+	// ClaimEmail records the email as sending, it returns false when the email was already sent to the recipient or
+	// is being sent, and the record has not expired
+	ClaimEmail(item *EmailOutboxItem) (bool, error)
+	// UpdateEmailStatus records the delivery status of the email
+	UpdateEmailStatus(outboxID, status, errorMessage string, attempts int) error
+	// QueueEmail records the email as queued for the outbox worker, it returns false when the email was already
+	// queued or sent to the recipient, and the record has not expired
+	QueueEmail(item *EmailOutboxItem) (bool, error)
+	// ListQueuedEmails returns up to the limit of the queued emails and the emails whose sending lease has expired,
+	// the oldest first
+	ListQueuedEmails(limit int64) ([]*EmailOutboxItem, error)
+	// ClaimQueuedEmail records the queued email as sending, it returns false when the email is no longer queued or
+	// its sending lease has not expired
+	ClaimQueuedEmail(outboxID string) (bool, error)
+}
+
+// QueuedEmailsResult is the summary of an outbox worker run
+type QueuedEmailsResult struct {
+	Sent     int
+	Requeued int
+	Failed   int
+}
+
+// emailOutboxID returns the de-duplication key of the email sent to the recipient - the idempotency key of the send
+// when provided, otherwise the email content
+func emailOutboxID(idempotencyKey, recipient, subject, body string) string {
+	recipient = strings.ToLower(strings.TrimSpace(recipient))
+	if idempotencyKey != "" {
+		sum := sha256.Sum256([]byte(idempotencyKey + "\n" + recipient))
+		return hex.EncodeToString(sum[:])
+	}
+	sum := sha256.Sum256([]byte(recipient + "\n" + subject + "\n" + body))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewQueuedEmail returns the outbox record of the email queued for the recipient, the email is queued once per
+// recipient for the idempotency key
+func NewQueuedEmail(idempotencyKey, recipient, subject, body string) *EmailOutboxItem {
+	currentTime, now := CurrentTime()
+	return &EmailOutboxItem{
+		OutboxID:       emailOutboxID(idempotencyKey, recipient, subject, body),
+		IdempotencyKey: idempotencyKey,
+		Recipient:      recipient,
+		Subject:        subject,
+		Body:           body,
+		Status:         EmailOutboxStatusQueued,
+		DateCreated:    now,
+		DateModified:   now,
+		ExpiresAt:      currentTime.Add(emailOutboxKeyDedupeWindow).Unix(),
+	}
+}
+
+// SendQueuedEmails sends up to the limit of the queued emails through the transport - a failed send is queued again
+// until the maximum attempts, then recorded as failed. The worker sends each email once per run so the retries are
+// spread over the runs.
+func SendQueuedEmails(transport EmailSender, store EmailOutboxStore, limit int64) (*QueuedEmailsResult, error) {
+	f := logrus.Fields{
+		"functionName": "utils.SendQueuedEmails",
+		"limit":        limit,
+	}
+
+	items, err := store.ListQueuedEmails(limit)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to list the queued emails")
+		return nil, err
+	}
+
+	result := &QueuedEmailsResult{}
+	for _, item := range items {
+		claimed, claimErr := store.ClaimQueuedEmail(item.OutboxID)
+		if claimErr != nil {
+			log.WithFields(f).WithError(claimErr).Warnf("unable to claim the queued email: %s", item.OutboxID)
+			continue
+		}
+		if !claimed {
+			log.WithFields(f).Debugf("queued email: %s claimed by another worker - skipping", item.OutboxID)
+			continue
+		}
+
+		status, errorMessage := EmailOutboxStatusSent, ""
+		if sendErr := transport.SendEmail(item.Subject, item.Body, []string{item.Recipient}); sendErr != nil {
+			errorMessage = sendErr.Error()
+			if item.Attempts+1 < emailOutboxMaxAttempts {
+				status = EmailOutboxStatusQueued
+				result.Requeued++
+			} else {
+				status = EmailOutboxStatusFailed
+				result.Failed++
+			}
+			log.WithFields(f).WithError(sendErr).Warnf("unable to send the queued email: %s - recorded as %s", item.OutboxID, status)
+		} else {
+			result.Sent++
+		}
+		if err := store.UpdateEmailStatus(item.OutboxID, status, errorMessage, 1); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to update the outbox status of email %s to %s", item.OutboxID, status)
+		}
+	}
+
+	return result, nil
+}
+
+type outboxEmail struct {
+	transport EmailSender
+	store     EmailOutboxStore
+	sleep     func(time.Duration)
+}
+
+// NewOutboxEmailSender creates the email sender which records each recipient send in the outbox - the email is not
+// sent again to a recipient who received it within the de-duplication window, the failed sends are retried and the
+// delivery status is kept
+func NewOutboxEmailSender(transport EmailSender, store EmailOutboxStore) EmailSender {
+	return &outboxEmail{
+		transport: transport,
+		store:     store,
+		sleep:     time.Sleep,
+	}
+}
+
+// SendEmail sends the email through the transport to the recipients who have not received the same email within the
+// de-duplication window
+func (o *outboxEmail) SendEmail(subject string, body string, recipients []string) error {
+	return o.send("", subject, body, recipients)
+}
+
+// SendEmailWithIdempotencyKey sends the email through the transport to the recipients who have not received an email
+// with the same idempotency key
+func (o *outboxEmail) SendEmailWithIdempotencyKey(idempotencyKey, subject, body string, recipients []string) error {
+	return o.send(idempotencyKey, subject, body, recipients)
+}
+
+func (o *outboxEmail) send(idempotencyKey, subject, body string, recipients []string) error {
+	f := logrus.Fields{
+		"functionName":   "utils.outboxEmail.send",
+		"idempotencyKey": idempotencyKey,
+		"subject":        subject,
+		"recipients":     strings.Join(recipients, ","),
+	}
+
+	currentTime, now := CurrentTime()
+	window := emailOutboxContentDedupeWindow
+	if idempotencyKey != "" {
+		window = emailOutboxKeyDedupeWindow
+	}
+	var claimed []string
+	var outboxIDs []string
+	seen := map[string]bool{}
+	for _, recipient := range recipients {
+		outboxID := emailOutboxID(idempotencyKey, recipient, subject, body)
+		if seen[outboxID] {
+			continue
+		}
+		seen[outboxID] = true
+
+		ok, err := o.store.ClaimEmail(&EmailOutboxItem{
+			OutboxID:       outboxID,
+			IdempotencyKey: idempotencyKey,
+			Recipient:      recipient,
+			Subject:        subject,
+			Body:           body,
+			Status:         EmailOutboxStatusSending,
+			DateCreated:    now,
+			DateModified:   now,
+			ExpiresAt:      currentTime.Add(window).Unix(),
+		})
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to record the email to %s in the outbox", recipient)
+			return err
+		}
+		if !ok {
+			log.WithFields(f).Debugf("email already sent to %s - skipping", recipient)
+			continue
+		}
+		claimed = append(claimed, recipient)
+		outboxIDs = append(outboxIDs, outboxID)
+	}
+
+	if len(claimed) == 0 {
+		log.WithFields(f).Debug("email already sent to all the recipients")
+		return nil
+	}
+
+	var sendErr error
+	attempts := 0
+	for attempts < emailOutboxMaxAttempts {
+		attempts++
+		sendErr = o.transport.SendEmail(subject, body, claimed)
+		if sendErr == nil {
+			break
+		}
+		if attempts < emailOutboxMaxAttempts {
+			delay := emailOutboxRetryBaseDelay << uint(attempts-1)
+			log.WithFields(f).WithError(sendErr).Warnf("email send attempt %d of %d failed - retrying in %s", attempts, emailOutboxMaxAttempts, delay)
+			o.sleep(delay)
+		}
+	}
+
+	status, errorMessage := EmailOutboxStatusSent, ""
+	if sendErr != nil {
+		status, errorMessage = EmailOutboxStatusFailed, sendErr.Error()
+		log.WithFields(f).WithError(sendErr).Errorf("unable to send email after %d attempts - recorded as failed in the outbox", attempts)
+	}
+	for _, outboxID := range outboxIDs {
+		if err := o.store.UpdateEmailStatus(outboxID, status, errorMessage, attempts); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to update the outbox status of email %s to %s", outboxID, status)
+		}
+	}
+
+	return sendErr
+}
+
+type dynamoEmailOutboxStore struct {
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewDynamoEmailOutboxStore creates the outbox store backed by the email outbox table
+func NewDynamoEmailOutboxStore(awsSession *session.Session, stage string) EmailOutboxStore {
+	return &dynamoEmailOutboxStore{
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-email-outbox", stage),
+	}
+}
+
+// ClaimEmail records the email as sending unless it was sent or is being sent, a failed, stale or expired send is
+// claimed again
+func (s *dynamoEmailOutboxStore) ClaimEmail(item *EmailOutboxItem) (bool, error) {
+	currentTime := time.Now().UTC()
+	staleDate := TimeToString(currentTime.Add(-emailOutboxSendingStaleAge))
+	_, err := s.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"outbox_id": {S: aws.String(item.OutboxID)},
+		},
+		// the table TTL removes the expired records eventually, the condition doesn't rely on it
+		ConditionExpression: aws.String("attribute_not_exists(outbox_id) OR #S = :failed OR (#S = :sending AND #M < :stale) OR attribute_not_exists(#X) OR #X < :now"),
+		UpdateExpression:    aws.String("SET #S = :sending, #M = :modified, #C = if_not_exists(#C, :created), #R = :recipient, #T = :subject, #B = :body, #X = :expires, #K = :key"),
+		ExpressionAttributeNames: map[string]*string{
+			"#S": aws.String("status"),
+			"#M": aws.String("date_modified"),
+			"#C": aws.String("date_created"),
+			"#R": aws.String("recipient"),
+			"#T": aws.String("subject"),
+			"#B": aws.String("body"),
+			"#X": aws.String("expires_at"),
+			"#K": aws.String("idempotency_key"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":failed":    {S: aws.String(EmailOutboxStatusFailed)},
+			":sending":   {S: aws.String(EmailOutboxStatusSending)},
+			":stale":     {S: aws.String(staleDate)},
+			":now":       {N: aws.String(strconv.FormatInt(currentTime.Unix(), 10))},
+			":modified":  {S: aws.String(item.DateModified)},
+			":created":   {S: aws.String(item.DateCreated)},
+			":recipient": {S: aws.String(item.Recipient)},
+			":subject":   {S: aws.String(item.Subject)},
+			":body":      {S: aws.String(item.Body)},
+			":expires":   {N: aws.String(strconv.FormatInt(item.ExpiresAt, 10))},
+			":key":       {S: aws.String(item.IdempotencyKey)},
+		},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// UpdateEmailStatus records the delivery status and the send attempts of the email
+func (s *dynamoEmailOutboxStore) UpdateEmailStatus(outboxID, status, errorMessage string, attempts int) error {
+	_, now := CurrentTime()
+	_, err := s.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"outbox_id": {S: aws.String(outboxID)},
+		},
+		UpdateExpression: aws.String("SET #S = :status, #M = :modified, #E = :error ADD #A :attempts"),
+		ExpressionAttributeNames: map[string]*string{
+			"#S": aws.String("status"),
+			"#M": aws.String("date_modified"),
+			"#E": aws.String("error_message"),
+			"#A": aws.String("attempts"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":status":   {S: aws.String(status)},
+			":modified": {S: aws.String(now)},
+			":error":    {S: aws.String(errorMessage)},
+			":attempts": {N: aws.String(fmt.Sprintf("%d", attempts))},
+		},
+	})
+	return err
+}
+
+// QueueEmail records the email as queued unless it was queued or sent, a failed or expired send is queued again
+func (s *dynamoEmailOutboxStore) QueueEmail(item *EmailOutboxItem) (bool, error) {
+	av, err := dynamodbattribute.MarshalMap(item)
+	if err != nil {
+		return false, err
+	}
+	_, err = s.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		TableName:           aws.String(s.tableName),
+		Item:                av,
+		ConditionExpression: aws.String("attribute_not_exists(outbox_id) OR #S = :failed OR attribute_not_exists(#X) OR #X < :now"),
+		ExpressionAttributeNames: map[string]*string{
+			"#S": aws.String("status"),
+			"#X": aws.String("expires_at"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":failed": {S: aws.String(EmailOutboxStatusFailed)},
+			":now":    {N: aws.String(strconv.FormatInt(time.Now().UTC().Unix(), 10))},
+		},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ListQueuedEmails queries the status index for the queued emails, then for the emails still marked sending after the
+// stale period - the send was lost before its status was recorded. The oldest of each status first.
+func (s *dynamoEmailOutboxStore) ListQueuedEmails(limit int64) ([]*EmailOutboxItem, error) {
+	items, err := s.queryEmailsByStatus(&dynamodb.QueryInput{
+		TableName:              aws.String(s.tableName),
+		IndexName:              aws.String(emailOutboxStatusIndex),
+		KeyConditionExpression: aws.String("#S = :queued"),
+		ExpressionAttributeNames: map[string]*string{
+			"#S": aws.String("status"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":queued": {S: aws.String(EmailOutboxStatusQueued)},
+		},
+	}, limit)
+	if err != nil || int64(len(items)) >= limit {
+		return items, err
+	}
+
+	staleDate := TimeToString(time.Now().UTC().Add(-emailOutboxSendingStaleAge))
+	stale, err := s.queryEmailsByStatus(&dynamodb.QueryInput{
+		TableName:              aws.String(s.tableName),
+		IndexName:              aws.String(emailOutboxStatusIndex),
+		KeyConditionExpression: aws.String("#S = :sending"),
+		FilterExpression:       aws.String("#M < :stale"),
+		ExpressionAttributeNames: map[string]*string{
+			"#S": aws.String("status"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":sending": {S: aws.String(EmailOutboxStatusSending)},
+			":stale":   {S: aws.String(staleDate)},
+		},
+	}, limit-int64(len(items)))
+	if err != nil {
+		return nil, err
+	}
+	return append(items, stale...), nil
+}
+
+// queryEmailsByStatus pages through the status index query until the limit of the emails is returned
+func (s *dynamoEmailOutboxStore) queryEmailsByStatus(input *dynamodb.QueryInput, limit int64) ([]*EmailOutboxItem, error) {
+	var items []*EmailOutboxItem
+	var lastEvaluatedKey map[string]*dynamodb.AttributeValue
+	for int64(len(items)) < limit {
+		input.Limit = aws.Int64(limit - int64(len(items)))
+		input.ExclusiveStartKey = lastEvaluatedKey
+		output, err := s.dynamoDBClient.Query(input)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []*EmailOutboxItem
+		if err := dynamodbattribute.UnmarshalListOfMaps(output.Items, &page); err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+
+		if len(output.LastEvaluatedKey) == 0 {
+			break
+		}
+		lastEvaluatedKey = output.LastEvaluatedKey
+	}
+	return items, nil
+}
+
+// ClaimQueuedEmail records the queued email as sending, an email still marked sending after the stale period has lost
+// its lease and is claimed again. The date modified is the start of the new lease.
+func (s *dynamoEmailOutboxStore) ClaimQueuedEmail(outboxID string) (bool, error) {
+	currentTime, now := CurrentTime()
+	staleDate := TimeToString(currentTime.Add(-emailOutboxSendingStaleAge))
+	_, err := s.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(s.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"outbox_id": {S: aws.String(outboxID)},
+		},
+		ConditionExpression: aws.String("#S = :queued OR (#S = :sending AND #M < :stale)"),
+		UpdateExpression:    aws.String("SET #S = :sending, #M = :modified"),
+		ExpressionAttributeNames: map[string]*string{
+			"#S": aws.String("status"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":queued":   {S: aws.String(EmailOutboxStatusQueued)},
+			":sending":  {S: aws.String(EmailOutboxStatusSending)},
+			":stale":    {S: aws.String(staleDate)},
+			":modified": {S: aws.String(now)},
+		},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
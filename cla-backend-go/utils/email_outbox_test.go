@@ -0,0 +1,221 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	mock_utils "github.com/linuxfoundation/easycla/cla-backend-go/utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailOutboxID(t *testing.T) {
+	// the content key changes with the content and ignores the recipient case
+	assert.Equal(t, utils.EmailOutboxID("", "a@example.org", "subject", "body"), utils.EmailOutboxID("", " A@example.org", "subject", "body"))
+	assert.NotEqual(t, utils.EmailOutboxID("", "a@example.org", "subject", "body"), utils.EmailOutboxID("", "a@example.org", "subject", "other body"))
+	assert.NotEqual(t, utils.EmailOutboxID("", "a@example.org", "subject", "body"), utils.EmailOutboxID("", "b@example.org", "subject", "body"))
+
+	// the idempotency key replaces the content
+	assert.Equal(t, utils.EmailOutboxID("key-1", "a@example.org", "subject", "body"), utils.EmailOutboxID("key-1", "a@example.org", "subject", "other body"))
+	assert.NotEqual(t, utils.EmailOutboxID("key-1", "a@example.org", "subject", "body"), utils.EmailOutboxID("key-2", "a@example.org", "subject", "body"))
+	assert.NotEqual(t, utils.EmailOutboxID("key-1", "a@example.org", "subject", "body"), utils.EmailOutboxID("", "a@example.org", "subject", "body"))
+}
+
+func TestOutboxSendEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transport := mock_utils.NewMockEmailSender(ctrl)
+	store := mock_utils.NewMockEmailOutboxStore(ctrl)
+	sender := utils.NewOutboxEmailSenderWithSleep(transport, store, func(time.Duration) {})
+
+	before := time.Now().Add(24 * time.Hour).Unix()
+	var claimed []*utils.EmailOutboxItem
+	store.EXPECT().ClaimEmail(gomock.Any()).DoAndReturn(func(item *utils.EmailOutboxItem) (bool, error) {
+		claimed = append(claimed, item)
+		// b@example.org already received the email
+		return item.Recipient != "b@example.org", nil
+	}).Times(2)
+	transport.EXPECT().SendEmail("subject", "body", []string{"a@example.org"}).Return(nil)
+	store.EXPECT().UpdateEmailStatus(utils.EmailOutboxID("", "a@example.org", "subject", "body"), utils.EmailOutboxStatusSent, "", 1).Return(nil)
+
+	// the duplicate recipient is claimed once
+	err := sender.SendEmail("subject", "body", []string{"a@example.org", "b@example.org", "A@example.org"})
+	assert.Nil(t, err)
+
+	assert.Len(t, claimed, 2)
+	assert.Equal(t, utils.EmailOutboxStatusSending, claimed[0].Status)
+	assert.Empty(t, claimed[0].IdempotencyKey)
+	assert.GreaterOrEqual(t, claimed[0].ExpiresAt, before)
+	assert.Less(t, claimed[0].ExpiresAt, time.Now().Add(25*time.Hour).Unix())
+}
+
+func TestOutboxSendEmailAlreadySent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transport := mock_utils.NewMockEmailSender(ctrl)
+	store := mock_utils.NewMockEmailOutboxStore(ctrl)
+	sender := utils.NewOutboxEmailSenderWithSleep(transport, store, func(time.Duration) {})
+
+	store.EXPECT().ClaimEmail(gomock.Any()).Return(false, nil).Times(2)
+
+	err := sender.SendEmail("subject", "body", []string{"a@example.org", "b@example.org"})
+	assert.Nil(t, err)
+}
+
+func TestOutboxSendEmailClaimError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transport := mock_utils.NewMockEmailSender(ctrl)
+	store := mock_utils.NewMockEmailOutboxStore(ctrl)
+	sender := utils.NewOutboxEmailSenderWithSleep(transport, store, func(time.Duration) {})
+
+	claimErr := errors.New("throttled")
+	store.EXPECT().ClaimEmail(gomock.Any()).Return(false, claimErr)
+
+	err := sender.SendEmail("subject", "body", []string{"a@example.org"})
+	assert.Equal(t, claimErr, err)
+}
+
+func TestOutboxSendEmailRetries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transport := mock_utils.NewMockEmailSender(ctrl)
+	store := mock_utils.NewMockEmailOutboxStore(ctrl)
+	var delays []time.Duration
+	sender := utils.NewOutboxEmailSenderWithSleep(transport, store, func(delay time.Duration) {
+		delays = append(delays, delay)
+	})
+
+	outboxID := utils.EmailOutboxID("", "a@example.org", "subject", "body")
+	store.EXPECT().ClaimEmail(gomock.Any()).Return(true, nil)
+	gomock.InOrder(
+		transport.EXPECT().SendEmail("subject", "body", []string{"a@example.org"}).Return(errors.New("unavailable")).Times(2),
+		transport.EXPECT().SendEmail("subject", "body", []string{"a@example.org"}).Return(nil),
+	)
+	store.EXPECT().UpdateEmailStatus(outboxID, utils.EmailOutboxStatusSent, "", 3).Return(nil)
+
+	err := sender.SendEmail("subject", "body", []string{"a@example.org"})
+	assert.Nil(t, err)
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, time.Second}, delays)
+}
+
+func TestOutboxSendEmailFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transport := mock_utils.NewMockEmailSender(ctrl)
+	store := mock_utils.NewMockEmailOutboxStore(ctrl)
+	sender := utils.NewOutboxEmailSenderWithSleep(transport, store, func(time.Duration) {})
+
+	sendErr := errors.New("unavailable")
+	outboxID := utils.EmailOutboxID("", "a@example.org", "subject", "body")
+	store.EXPECT().ClaimEmail(gomock.Any()).Return(true, nil)
+	transport.EXPECT().SendEmail("subject", "body", []string{"a@example.org"}).Return(sendErr).Times(3)
+	store.EXPECT().UpdateEmailStatus(outboxID, utils.EmailOutboxStatusFailed, "unavailable", 3).Return(nil)
+
+	err := sender.SendEmail("subject", "body", []string{"a@example.org"})
+	assert.Equal(t, sendErr, err)
+}
+
+func TestOutboxSendEmailWithIdempotencyKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transport := mock_utils.NewMockEmailSender(ctrl)
+	store := mock_utils.NewMockEmailOutboxStore(ctrl)
+	sender := utils.NewOutboxEmailSenderWithSleep(transport, store, func(time.Duration) {})
+
+	before := time.Now().Add(90 * 24 * time.Hour).Unix()
+	var claimed *utils.EmailOutboxItem
+	store.EXPECT().ClaimEmail(gomock.Any()).DoAndReturn(func(item *utils.EmailOutboxItem) (bool, error) {
+		claimed = item
+		return true, nil
+	})
+	transport.EXPECT().SendEmail("subject", "body", []string{"a@example.org"}).Return(nil)
+	store.EXPECT().UpdateEmailStatus(utils.EmailOutboxID("key-1", "a@example.org", "", ""), utils.EmailOutboxStatusSent, "", 1).Return(nil)
+
+	idempotentSender, ok := sender.(utils.IdempotentEmailSender)
+	assert.True(t, ok)
+	err := idempotentSender.SendEmailWithIdempotencyKey("key-1", "subject", "body", []string{"a@example.org"})
+	assert.Nil(t, err)
+	assert.Equal(t, "key-1", claimed.IdempotencyKey)
+	assert.GreaterOrEqual(t, claimed.ExpiresAt, before)
+}
+
+func TestSendEmailWithIdempotencyKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	previous := utils.GetEmailSender()
+	defer utils.SetEmailSender(previous)
+
+	// the senders which don't de-duplicate send the email as is
+	sender := mock_utils.NewMockEmailSender(ctrl)
+	sender.EXPECT().SendEmail("subject", "body", []string{"a@example.org"}).Return(nil)
+	utils.SetEmailSender(sender)
+	assert.Nil(t, utils.SendEmailWithIdempotencyKey("key-1", "subject", "body", []string{"a@example.org"}))
+
+	utils.SetEmailSender(nil)
+	assert.NotNil(t, utils.SendEmailWithIdempotencyKey("key-1", "subject", "body", []string{"a@example.org"}))
+}
+
+func TestNewQueuedEmail(t *testing.T) {
+	item := utils.NewQueuedEmail("key-1", "a@example.org", "subject", "body")
+	assert.Equal(t, utils.EmailOutboxID("key-1", "a@example.org", "subject", "body"), item.OutboxID)
+	assert.Equal(t, utils.EmailOutboxStatusQueued, item.Status)
+	assert.Equal(t, "key-1", item.IdempotencyKey)
+	assert.GreaterOrEqual(t, item.ExpiresAt, time.Now().Add(89*24*time.Hour).Unix())
+}
+
+func TestSendQueuedEmails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transport := mock_utils.NewMockEmailSender(ctrl)
+	store := mock_utils.NewMockEmailOutboxStore(ctrl)
+
+	sent := &utils.EmailOutboxItem{OutboxID: "sent", Recipient: "a@example.org", Subject: "subject", Body: "body"}
+	claimedElsewhere := &utils.EmailOutboxItem{OutboxID: "claimed", Recipient: "b@example.org", Subject: "subject", Body: "body"}
+	requeued := &utils.EmailOutboxItem{OutboxID: "requeued", Recipient: "c@example.org", Subject: "subject", Body: "body", Attempts: 1}
+	failed := &utils.EmailOutboxItem{OutboxID: "failed", Recipient: "d@example.org", Subject: "subject", Body: "body", Attempts: 2}
+
+	store.EXPECT().ListQueuedEmails(int64(10)).Return([]*utils.EmailOutboxItem{sent, claimedElsewhere, requeued, failed}, nil)
+	store.EXPECT().ClaimQueuedEmail("sent").Return(true, nil)
+	store.EXPECT().ClaimQueuedEmail("claimed").Return(false, nil)
+	store.EXPECT().ClaimQueuedEmail("requeued").Return(true, nil)
+	store.EXPECT().ClaimQueuedEmail("failed").Return(true, nil)
+
+	transport.EXPECT().SendEmail("subject", "body", []string{"a@example.org"}).Return(nil)
+	transport.EXPECT().SendEmail("subject", "body", []string{"c@example.org"}).Return(errors.New("unavailable"))
+	transport.EXPECT().SendEmail("subject", "body", []string{"d@example.org"}).Return(errors.New("unavailable"))
+
+	store.EXPECT().UpdateEmailStatus("sent", utils.EmailOutboxStatusSent, "", 1).Return(nil)
+	store.EXPECT().UpdateEmailStatus("requeued", utils.EmailOutboxStatusQueued, "unavailable", 1).Return(nil)
+	store.EXPECT().UpdateEmailStatus("failed", utils.EmailOutboxStatusFailed, "unavailable", 1).Return(nil)
+
+	result, err := utils.SendQueuedEmails(transport, store, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, &utils.QueuedEmailsResult{Sent: 1, Requeued: 1, Failed: 1}, result)
+}
+
+func TestSendQueuedEmailsListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transport := mock_utils.NewMockEmailSender(ctrl)
+	store := mock_utils.NewMockEmailOutboxStore(ctrl)
+
+	store.EXPECT().ListQueuedEmails(int64(10)).Return(nil, errors.New("throttled"))
+
+	_, err := utils.SendQueuedEmails(transport, store, 10)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,222 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// email transport names
+const (
+	EmailTransportSNS  = "sns"
+	EmailTransportSMTP = "smtp"
+	EmailTransportFile = "file"
+)
+
+// defaultSMTPPort is the SMTP submission port
+const defaultSMTPPort = 587
+
+// SetEmailTransport sets up the email sender from the email config - the SNS pipeline is used unless the SMTP or
+// file transport is configured, the sends go through the outbox when it is enabled
+func SetEmailTransport(awsSession *session.Session, stage string, configFile config.Config) error {
+	f := logrus.Fields{
+		"functionName": "utils.SetEmailTransport",
+		"transport":    configFile.Email.Transport,
+		"outbox":       configFile.Email.OutboxEnabled,
+	}
+
+	transport, err := NewEmailTransport(awsSession, configFile)
+	if err != nil {
+		return err
+	}
+
+	if configFile.Email.OutboxEnabled {
+		transport = NewOutboxEmailSender(transport, NewDynamoEmailOutboxStore(awsSession, stage))
+	}
+
+	log.WithFields(f).Info("email transport configured")
+	SetEmailSender(transport)
+	return nil
+}
+
+// NewEmailTransport creates the email transport of the email config without the outbox - the SNS pipeline is used
+// unless the SMTP or file transport is configured
+func NewEmailTransport(awsSession *session.Session, configFile config.Config) (EmailSender, error) {
+	switch strings.ToLower(configFile.Email.Transport) {
+	case "", EmailTransportSNS:
+		return &snsEmail{
+			snsClient:          sns.New(awsSession),
+			snsEventTopicARN:   configFile.SNSEventTopicARN,
+			senderEmailAddress: configFile.SenderEmailAddress,
+		}, nil
+	case EmailTransportSMTP:
+		return NewSMTPEmailSender(configFile.Email.SMTP, configFile.SenderEmailAddress)
+	case EmailTransportFile:
+		return NewFileEmailSender(configFile.Email.FileDirectory, configFile.SenderEmailAddress)
+	default:
+		return nil, fmt.Errorf("unsupported email transport: %s - expecting one of %s, %s or %s", configFile.Email.Transport, EmailTransportSNS, EmailTransportSMTP, EmailTransportFile)
+	}
+}
+
+// buildEmailMessage returns the RFC 5322 HTML message
+func buildEmailMessage(from string, recipients []string, subject, body string, date time.Time) []byte {
+	// the header values can't contain new lines, they would inject additional headers
+	clean := strings.NewReplacer("\r", " ", "\n", " ")
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", clean.Replace(from))
+	fmt.Fprintf(&msg, "To: %s\r\n", clean.Replace(strings.Join(recipients, ", ")))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", clean.Replace(subject)))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=\"utf-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+	return msg.Bytes()
+}
+
+// validateRecipients checks the recipient addresses before they are passed to the transport
+func validateRecipients(recipients []string) error {
+	if len(recipients) == 0 {
+		return errors.New("no email recipients")
+	}
+	for _, recipient := range recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return fmt.Errorf("invalid email recipient %s: %w", recipient, err)
+		}
+	}
+	return nil
+}
+
+type smtpEmail struct {
+	address            string
+	auth               smtp.Auth
+	senderEmailAddress string
+}
+
+// NewSMTPEmailSender creates the SMTP email transport, the connection is upgraded with STARTTLS when the server
+// supports it and the credentials are only sent over TLS
+func NewSMTPEmailSender(smtpConfig config.SMTP, senderEmailAddress string) (EmailSender, error) {
+	if smtpConfig.Host == "" {
+		return nil, errors.New("the smtp host is required by the smtp email transport")
+	}
+	if senderEmailAddress == "" {
+		return nil, errors.New("the sender email address is required by the smtp email transport")
+	}
+
+	port := smtpConfig.Port
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+
+	sender := &smtpEmail{
+		address:            net.JoinHostPort(smtpConfig.Host, strconv.Itoa(port)),
+		senderEmailAddress: senderEmailAddress,
+	}
+	if smtpConfig.Username != "" {
+		sender.auth = smtp.PlainAuth("", smtpConfig.Username, smtpConfig.Password, smtpConfig.Host)
+	}
+	return sender, nil
+}
+
+// SendEmail sends the email to the specified recipients through the SMTP server
+func (s *smtpEmail) SendEmail(subject string, body string, recipients []string) error {
+	f := logrus.Fields{
+		"functionName": "utils.smtpEmail.SendEmail",
+		"subject":      subject,
+		"recipients":   strings.Join(recipients, ","),
+		"address":      s.address,
+	}
+
+	if err := validateRecipients(recipients); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to send email")
+		return err
+	}
+
+	msg := buildEmailMessage(s.senderEmailAddress, recipients, subject, body, time.Now())
+	if err := smtp.SendMail(s.address, s.auth, s.senderEmailAddress, recipients, msg); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to send email through the smtp server")
+		return err
+	}
+
+	log.WithFields(f).Debug("email sent through the smtp server")
+	return nil
+}
+
+type fileEmail struct {
+	directory          string
+	senderEmailAddress string
+}
+
+// NewFileEmailSender creates the file email transport used for development, the emails are written to the maildir
+// directory so they can be read with any maildir mail client
+func NewFileEmailSender(directory, senderEmailAddress string) (EmailSender, error) {
+	if directory == "" {
+		return nil, errors.New("the file directory is required by the file email transport")
+	}
+	for _, dir := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(directory, dir), 0750); err != nil {
+			return nil, err
+		}
+	}
+	return &fileEmail{
+		directory:          directory,
+		senderEmailAddress: senderEmailAddress,
+	}, nil
+}
+
+// SendEmail writes the email to the maildir new directory
+func (s *fileEmail) SendEmail(subject string, body string, recipients []string) error {
+	f := logrus.Fields{
+		"functionName": "utils.fileEmail.SendEmail",
+		"subject":      subject,
+		"recipients":   strings.Join(recipients, ","),
+		"directory":    s.directory,
+	}
+
+	if err := validateRecipients(recipients); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to write email")
+		return err
+	}
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+	now := time.Now()
+	// maildir delivery - the message is written to tmp and moved to new once complete
+	fileName := fmt.Sprintf("%d.%s.easycla", now.UnixNano(), hex.EncodeToString(suffix))
+	tmpFile := filepath.Join(s.directory, "tmp", fileName)
+	if err := os.WriteFile(tmpFile, buildEmailMessage(s.senderEmailAddress, recipients, subject, body, now), 0600); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to write email file")
+		return err
+	}
+	newFile := filepath.Join(s.directory, "new", fileName)
+	if err := os.Rename(tmpFile, newFile); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to move email file")
+		return err
+	}
+
+	log.WithFields(f).Debugf("email written to %s", newFile)
+	return nil
+}
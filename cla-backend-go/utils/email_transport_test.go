@@ -0,0 +1,98 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildEmailMessage(t *testing.T) {
+	date := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	msg := string(utils.BuildEmailMessage("easycla@example.org", []string{"a@example.org", "b@example.org"}, "Hello\r\nBcc: c@example.org", "<p>body</p>", date))
+
+	headers, body, found := strings.Cut(msg, "\r\n\r\n")
+	assert.True(t, found)
+	assert.Equal(t, "<p>body</p>", body)
+	assert.Contains(t, headers, "From: easycla@example.org\r\n")
+	assert.Contains(t, headers, "To: a@example.org, b@example.org\r\n")
+	assert.Contains(t, headers, "Date: Fri, 02 Jan 2026 03:04:05 +0000\r\n")
+	assert.Contains(t, headers, "Content-Type: text/html; charset=\"utf-8\"")
+	// the new lines of the subject can't start a new header
+	assert.NotContains(t, headers, "\r\nBcc:")
+	assert.Contains(t, headers, "Subject: Hello  Bcc: c@example.org\r\n")
+}
+
+func TestNewSMTPEmailSender(t *testing.T) {
+	_, err := utils.NewSMTPEmailSender(config.SMTP{}, "easycla@example.org")
+	assert.NotNil(t, err)
+
+	_, err = utils.NewSMTPEmailSender(config.SMTP{Host: "smtp.example.org"}, "")
+	assert.NotNil(t, err)
+
+	sender, err := utils.NewSMTPEmailSender(config.SMTP{Host: "smtp.example.org"}, "easycla@example.org")
+	assert.Nil(t, err)
+	assert.NotNil(t, sender)
+
+	// the recipients are checked before connecting to the server
+	assert.NotNil(t, sender.SendEmail("subject", "body", nil))
+	assert.NotNil(t, sender.SendEmail("subject", "body", []string{"not an address"}))
+}
+
+func TestFileEmailSender(t *testing.T) {
+	_, err := utils.NewFileEmailSender("", "easycla@example.org")
+	assert.NotNil(t, err)
+
+	directory := t.TempDir()
+	sender, err := utils.NewFileEmailSender(directory, "easycla@example.org")
+	assert.Nil(t, err)
+
+	assert.NotNil(t, sender.SendEmail("subject", "body", nil))
+	assert.NotNil(t, sender.SendEmail("subject", "body", []string{"not an address"}))
+
+	assert.Nil(t, sender.SendEmail("subject", "<p>body</p>", []string{"a@example.org"}))
+
+	// the message is moved from tmp to new once written
+	tmpFiles, err := os.ReadDir(filepath.Join(directory, "tmp"))
+	assert.Nil(t, err)
+	assert.Empty(t, tmpFiles)
+	newFiles, err := os.ReadDir(filepath.Join(directory, "new"))
+	assert.Nil(t, err)
+	if assert.Len(t, newFiles, 1) {
+		msg, err := os.ReadFile(filepath.Join(directory, "new", newFiles[0].Name()))
+		assert.Nil(t, err)
+		assert.Contains(t, string(msg), "To: a@example.org\r\n")
+		assert.Contains(t, string(msg), "Subject: subject\r\n")
+		assert.True(t, strings.HasSuffix(string(msg), "\r\n\r\n<p>body</p>"))
+	}
+}
+
+func TestSetEmailTransport(t *testing.T) {
+	previous := utils.GetEmailSender()
+	defer utils.SetEmailSender(previous)
+
+	err := utils.SetEmailTransport(nil, "dev", config.Config{Email: config.Email{Transport: "pigeon"}})
+	assert.NotNil(t, err)
+
+	err = utils.SetEmailTransport(nil, "dev", config.Config{Email: config.Email{Transport: utils.EmailTransportSMTP}})
+	assert.NotNil(t, err)
+
+	directory := t.TempDir()
+	err = utils.SetEmailTransport(nil, "dev", config.Config{
+		SenderEmailAddress: "easycla@example.org",
+		Email:              config.Email{Transport: "FILE", FileDirectory: directory},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, utils.SendEmail("subject", "body", []string{"a@example.org"}))
+	newFiles, err := os.ReadDir(filepath.Join(directory, "new"))
+	assert.Nil(t, err)
+	assert.Len(t, newFiles, 1)
+}
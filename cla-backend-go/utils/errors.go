@@ -207,6 +207,40 @@ func (e *GitHubOrgNotFound) Unwrap() error {
 	return e.Err
 }
 
+// GitLabOrgNotFound is an error model for GitLab Group/Organization not found errors
+type GitLabOrgNotFound struct {
+	ProjectSFID   string
+	GitLabGroupID int64
+	Err           error
+}
+
+// Error is an error string function for GitLab Group/Organization not found errors
+func (e *GitLabOrgNotFound) Error() string {
+	return fmt.Sprintf("gitlab group with ID: %d and projectSFID: %s not found: %+v", e.GitLabGroupID, e.ProjectSFID, e.Err)
+}
+
+// Unwrap method returns its contained error
+func (e *GitLabOrgNotFound) Unwrap() error {
+	return e.Err
+}
+
+// SkipCLAConflict is an error model for skip_cla updates rejected because the configuration changed since it was
+// loaded
+type SkipCLAConflict struct {
+	OrganizationName string
+	Err              error
+}
+
+// Error is an error string function for the SkipCLAConflict model
+func (e *SkipCLAConflict) Error() string {
+	return fmt.Sprintf("the skip_cla configuration of organization: %s was updated concurrently, reload it and retry: %+v", e.OrganizationName, e.Err)
+}
+
+// Unwrap method returns its contained error
+func (e *SkipCLAConflict) Unwrap() error {
+	return e.Err
+}
+
 // CompanyAdminNotFound is an error model for Salesforce Project not found errors
 type CompanyAdminNotFound struct {
 	CompanySFID string
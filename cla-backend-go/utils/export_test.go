@@ -0,0 +1,21 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import "time"
+
+// test hooks for the utils_test package
+
+// EmailOutboxID exposes the outbox de-duplication key
+var EmailOutboxID = emailOutboxID
+
+// BuildEmailMessage exposes the email message builder
+var BuildEmailMessage = buildEmailMessage
+
+// NewOutboxEmailSenderWithSleep creates the outbox email sender with the retry sleep replaced
+func NewOutboxEmailSenderWithSleep(transport EmailSender, store EmailOutboxStore, sleep func(time.Duration)) EmailSender {
+	sender := NewOutboxEmailSender(transport, store).(*outboxEmail)
+	sender.sleep = sleep
+	return sender
+}
@@ -0,0 +1,89 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+//
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: utils/email.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEmailSender is a mock of EmailSender interface.
+type MockEmailSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailSenderMockRecorder
+}
+
+// MockEmailSenderMockRecorder is the mock recorder for MockEmailSender.
+type MockEmailSenderMockRecorder struct {
+	mock *MockEmailSender
+}
+
+// NewMockEmailSender creates a new mock instance.
+func NewMockEmailSender(ctrl *gomock.Controller) *MockEmailSender {
+	mock := &MockEmailSender{ctrl: ctrl}
+	mock.recorder = &MockEmailSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailSender) EXPECT() *MockEmailSenderMockRecorder {
+	return m.recorder
+}
+
+// SendEmail mocks base method.
+func (m *MockEmailSender) SendEmail(subject, body string, recipients []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmail", subject, body, recipients)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmail indicates an expected call of SendEmail.
+func (mr *MockEmailSenderMockRecorder) SendEmail(subject, body, recipients interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmail", reflect.TypeOf((*MockEmailSender)(nil).SendEmail), subject, body, recipients)
+}
+
+// MockIdempotentEmailSender is a mock of IdempotentEmailSender interface.
+type MockIdempotentEmailSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotentEmailSenderMockRecorder
+}
+
+// MockIdempotentEmailSenderMockRecorder is the mock recorder for MockIdempotentEmailSender.
+type MockIdempotentEmailSenderMockRecorder struct {
+	mock *MockIdempotentEmailSender
+}
+
+// NewMockIdempotentEmailSender creates a new mock instance.
+func NewMockIdempotentEmailSender(ctrl *gomock.Controller) *MockIdempotentEmailSender {
+	mock := &MockIdempotentEmailSender{ctrl: ctrl}
+	mock.recorder = &MockIdempotentEmailSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotentEmailSender) EXPECT() *MockIdempotentEmailSenderMockRecorder {
+	return m.recorder
+}
+
+// SendEmailWithIdempotencyKey mocks base method.
+func (m *MockIdempotentEmailSender) SendEmailWithIdempotencyKey(idempotencyKey, subject, body string, recipients []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailWithIdempotencyKey", idempotencyKey, subject, body, recipients)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailWithIdempotencyKey indicates an expected call of SendEmailWithIdempotencyKey.
+func (mr *MockIdempotentEmailSenderMockRecorder) SendEmailWithIdempotencyKey(idempotencyKey, subject, body, recipients interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailWithIdempotencyKey", reflect.TypeOf((*MockIdempotentEmailSender)(nil).SendEmailWithIdempotencyKey), idempotencyKey, subject, body, recipients)
+}
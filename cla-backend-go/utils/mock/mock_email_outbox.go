@@ -0,0 +1,113 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+//
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: utils/email_outbox.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	utils "github.com/linuxfoundation/easycla/cla-backend-go/utils"
+)
+
+// MockEmailOutboxStore is a mock of EmailOutboxStore interface.
+type MockEmailOutboxStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailOutboxStoreMockRecorder
+}
+
+// MockEmailOutboxStoreMockRecorder is the mock recorder for MockEmailOutboxStore.
+type MockEmailOutboxStoreMockRecorder struct {
+	mock *MockEmailOutboxStore
+}
+
+// NewMockEmailOutboxStore creates a new mock instance.
+func NewMockEmailOutboxStore(ctrl *gomock.Controller) *MockEmailOutboxStore {
+	mock := &MockEmailOutboxStore{ctrl: ctrl}
+	mock.recorder = &MockEmailOutboxStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailOutboxStore) EXPECT() *MockEmailOutboxStoreMockRecorder {
+	return m.recorder
+}
+
+// ClaimEmail mocks base method.
+func (m *MockEmailOutboxStore) ClaimEmail(item *utils.EmailOutboxItem) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEmail", item)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEmail indicates an expected call of ClaimEmail.
+func (mr *MockEmailOutboxStoreMockRecorder) ClaimEmail(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEmail", reflect.TypeOf((*MockEmailOutboxStore)(nil).ClaimEmail), item)
+}
+
+// ClaimQueuedEmail mocks base method.
+func (m *MockEmailOutboxStore) ClaimQueuedEmail(outboxID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimQueuedEmail", outboxID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimQueuedEmail indicates an expected call of ClaimQueuedEmail.
+func (mr *MockEmailOutboxStoreMockRecorder) ClaimQueuedEmail(outboxID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimQueuedEmail", reflect.TypeOf((*MockEmailOutboxStore)(nil).ClaimQueuedEmail), outboxID)
+}
+
+// ListQueuedEmails mocks base method.
+func (m *MockEmailOutboxStore) ListQueuedEmails(limit int64) ([]*utils.EmailOutboxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueuedEmails", limit)
+	ret0, _ := ret[0].([]*utils.EmailOutboxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueuedEmails indicates an expected call of ListQueuedEmails.
+func (mr *MockEmailOutboxStoreMockRecorder) ListQueuedEmails(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueuedEmails", reflect.TypeOf((*MockEmailOutboxStore)(nil).ListQueuedEmails), limit)
+}
+
+// QueueEmail mocks base method.
+func (m *MockEmailOutboxStore) QueueEmail(item *utils.EmailOutboxItem) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueEmail", item)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueEmail indicates an expected call of QueueEmail.
+func (mr *MockEmailOutboxStoreMockRecorder) QueueEmail(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueEmail", reflect.TypeOf((*MockEmailOutboxStore)(nil).QueueEmail), item)
+}
+
+// UpdateEmailStatus mocks base method.
+func (m *MockEmailOutboxStore) UpdateEmailStatus(outboxID, status, errorMessage string, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmailStatus", outboxID, status, errorMessage, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmailStatus indicates an expected call of UpdateEmailStatus.
+func (mr *MockEmailOutboxStoreMockRecorder) UpdateEmailStatus(outboxID, status, errorMessage, attempts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmailStatus", reflect.TypeOf((*MockEmailOutboxStore)(nil).UpdateEmailStatus), outboxID, status, errorMessage, attempts)
+}
@@ -32,3 +32,31 @@ func WatermarkPdf(pdf []byte, text string) ([]byte, error) {
 
 	return b.Bytes(), nil
 }
+
+// StampPdf stamps the text at the bottom of the last page and records the properties in the
+// document information dictionary. Use \n in the text to separate lines.
+func StampPdf(pdf []byte, text string, properties map[string]string) ([]byte, error) {
+	onTop := true
+	wm, err := pdfcpu.ParseTextWatermarkDetails(text, "font:Helvetica, points:8, position:bl, offset:36 24, scale:1 abs, rotation:0, opacity:1, align:l", onTop)
+	if err != nil {
+		return nil, err
+	}
+
+	var stamped bytes.Buffer
+	err = api.AddWatermarks(bytes.NewReader(pdf), &stamped, []string{"l"}, wm, nil)
+	if err != nil {
+		return nil, fmt.Errorf("applying stamp failed : %w", err)
+	}
+
+	if len(properties) == 0 {
+		return stamped.Bytes(), nil
+	}
+
+	var out bytes.Buffer
+	err = api.AddProperties(bytes.NewReader(stamped.Bytes()), &out, properties, nil)
+	if err != nil {
+		return nil, fmt.Errorf("adding properties failed : %w", err)
+	}
+
+	return out.Bytes(), nil
+}
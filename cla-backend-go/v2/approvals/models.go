@@ -17,4 +17,8 @@ type ApprovalItem struct {
 	ApprovalCompanyName string `dynamodbav:"approval_company_name"`
 	Note                string `dynamodbav:"note"`
 	Active              bool   `dynamodbav:"active"`
+	// DateExpires is when the entry is removed from the approval list, empty when the entry doesn't expire
+	DateExpires string `dynamodbav:"date_expires"`
+	// DateExpiryNotified is when the CLA managers were reminded of the upcoming expiry
+	DateExpiryNotified string `dynamodbav:"date_expiry_notified"`
 }
@@ -32,6 +32,7 @@ type IRepository interface {
 	SearchApprovalList(criteria, approvalListName, claGroupID, companyID, signatureID string) ([]ApprovalItem, error)
 	BatchAddApprovalList(approvalItems []ApprovalItem) error
 	BatchDeleteApprovalList() error
+	GetExpiringApprovalItems(before string) ([]ApprovalItem, error)
 }
 
 type repository struct {
@@ -610,3 +611,53 @@ func (repo *repository) SearchApprovalList(criteria, approvalListName, claGroupI
 	return results, nil
 
 }
+
+// GetExpiringApprovalItems returns the active approval list entries which expire at or before the specified time
+func (repo *repository) GetExpiringApprovalItems(before string) ([]ApprovalItem, error) {
+	f := logrus.Fields{
+		"functionName": "v2.approvals.repository.GetExpiringApprovalItems",
+		"before":       before,
+		"tableName":    repo.tableName,
+	}
+
+	filter := expression.Name("active").Equal(expression.Value(true)).
+		And(expression.Name("date_expires").LessThanEqual(expression.Value(before)))
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression, error: %+v", err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		TableName:                 aws.String(repo.tableName),
+		FilterExpression:          expr.Filter(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Limit:                     aws.Int64(100),
+	}
+
+	var results []ApprovalItem
+	for {
+		result, scanErr := repo.dynamoDBClient.Scan(scanInput)
+		if scanErr != nil {
+			log.WithFields(f).Warnf("unable to scan table, error: %+v", scanErr)
+			return nil, scanErr
+		}
+
+		var items []ApprovalItem
+		err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &items)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to unmarshal data from table, error: %+v", err)
+			return nil, err
+		}
+		results = append(results, items...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		scanInput.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	log.WithFields(f).Debugf("found %d expiring approval list entries", len(results))
+	return results, nil
+}
@@ -0,0 +1,502 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bot_allowlist
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/bot_allowlist"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service, eventService events.Service) {
+	api.BotAllowlistGetGitHubOrganizationBotAllowlistHandler = bot_allowlist.GetGitHubOrganizationBotAllowlistHandlerFunc(func(params bot_allowlist.GetGitHubOrganizationBotAllowlistParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.bot_allowlist.handlers.BotAllowlistGetGitHubOrganizationBotAllowlistHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"projectSFID":    params.ProjectSFID,
+			"orgName":        params.OrgName,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to get the bot allowlist of GitHub organization: %s for project: %s",
+				authUser.UserName, params.OrgName, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return bot_allowlist.NewGetGitHubOrganizationBotAllowlistForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.GetGitHubOrganizationBotAllowlist(ctx, params.ProjectSFID, params.OrgName)
+		if err != nil {
+			msg := fmt.Sprintf("unable to get the bot allowlist of GitHub organization: %s", params.OrgName)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if isNotFound(err) {
+				return bot_allowlist.NewGetGitHubOrganizationBotAllowlistNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return bot_allowlist.NewGetGitHubOrganizationBotAllowlistBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		return bot_allowlist.NewGetGitHubOrganizationBotAllowlistOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.BotAllowlistUpdateGitHubOrganizationBotAllowlistHandler = bot_allowlist.UpdateGitHubOrganizationBotAllowlistHandlerFunc(func(params bot_allowlist.UpdateGitHubOrganizationBotAllowlistParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.bot_allowlist.handlers.BotAllowlistUpdateGitHubOrganizationBotAllowlistHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"projectSFID":    params.ProjectSFID,
+			"orgName":        params.OrgName,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to update the bot allowlist of GitHub organization: %s for project: %s",
+				authUser.UserName, params.OrgName, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return bot_allowlist.NewUpdateGitHubOrganizationBotAllowlistForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.UpdateGitHubOrganizationBotAllowlist(ctx, params.ProjectSFID, params.OrgName, params.Body)
+		if err != nil {
+			msg := fmt.Sprintf("unable to update the bot allowlist of GitHub organization: %s", params.OrgName)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if isNotFound(err) {
+				return bot_allowlist.NewUpdateGitHubOrganizationBotAllowlistNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			if isConflict(err) {
+				return bot_allowlist.NewUpdateGitHubOrganizationBotAllowlistConflict().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseConflictWithError(reqID, msg, err))
+			}
+			return bot_allowlist.NewUpdateGitHubOrganizationBotAllowlistBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+			LfUsername:  authUser.UserName,
+			EventType:   events.BotAllowlistUpdated,
+			ProjectSFID: params.ProjectSFID,
+			EventData: &events.BotAllowlistUpdatedEventData{
+				OrganizationType: models.BotAllowlistOrganizationTypeGithub,
+				OrganizationName: result.OrganizationName,
+				Action:           "entries replaced",
+				Entries:          entryStrings(result.Entries),
+			},
+		})
+
+		return bot_allowlist.NewUpdateGitHubOrganizationBotAllowlistOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.BotAllowlistAddGitHubOrganizationBotAllowlistEntryHandler = bot_allowlist.AddGitHubOrganizationBotAllowlistEntryHandlerFunc(func(params bot_allowlist.AddGitHubOrganizationBotAllowlistEntryParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.bot_allowlist.handlers.BotAllowlistAddGitHubOrganizationBotAllowlistEntryHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"projectSFID":    params.ProjectSFID,
+			"orgName":        params.OrgName,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to add a bot allowlist entry to GitHub organization: %s for project: %s",
+				authUser.UserName, params.OrgName, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return bot_allowlist.NewAddGitHubOrganizationBotAllowlistEntryForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.AddGitHubOrganizationBotAllowlistEntry(ctx, params.ProjectSFID, params.OrgName, params.Body)
+		if err != nil {
+			msg := fmt.Sprintf("unable to add a bot allowlist entry to GitHub organization: %s", params.OrgName)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if isNotFound(err) {
+				return bot_allowlist.NewAddGitHubOrganizationBotAllowlistEntryNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			if isConflict(err) {
+				return bot_allowlist.NewAddGitHubOrganizationBotAllowlistEntryConflict().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseConflictWithError(reqID, msg, err))
+			}
+			return bot_allowlist.NewAddGitHubOrganizationBotAllowlistEntryBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+			LfUsername:  authUser.UserName,
+			EventType:   events.BotAllowlistUpdated,
+			ProjectSFID: params.ProjectSFID,
+			EventData: &events.BotAllowlistUpdatedEventData{
+				OrganizationType: models.BotAllowlistOrganizationTypeGithub,
+				OrganizationName: result.OrganizationName,
+				Action:           "entry added",
+				Entries:          entryStrings([]*models.BotAllowlistEntry{params.Body}),
+			},
+		})
+
+		return bot_allowlist.NewAddGitHubOrganizationBotAllowlistEntryOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.BotAllowlistDeleteGitHubOrganizationBotAllowlistEntryHandler = bot_allowlist.DeleteGitHubOrganizationBotAllowlistEntryHandlerFunc(func(params bot_allowlist.DeleteGitHubOrganizationBotAllowlistEntryParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.bot_allowlist.handlers.BotAllowlistDeleteGitHubOrganizationBotAllowlistEntryHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"projectSFID":    params.ProjectSFID,
+			"orgName":        params.OrgName,
+			"entryID":        params.EntryID,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to delete a bot allowlist entry from GitHub organization: %s for project: %s",
+				authUser.UserName, params.OrgName, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return bot_allowlist.NewDeleteGitHubOrganizationBotAllowlistEntryForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		err := service.DeleteGitHubOrganizationBotAllowlistEntry(ctx, params.ProjectSFID, params.OrgName, params.EntryID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to delete a bot allowlist entry from GitHub organization: %s", params.OrgName)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if isNotFound(err) {
+				return bot_allowlist.NewDeleteGitHubOrganizationBotAllowlistEntryNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			if isConflict(err) {
+				return bot_allowlist.NewDeleteGitHubOrganizationBotAllowlistEntryConflict().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseConflictWithError(reqID, msg, err))
+			}
+			return bot_allowlist.NewDeleteGitHubOrganizationBotAllowlistEntryBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+			LfUsername:  authUser.UserName,
+			EventType:   events.BotAllowlistUpdated,
+			ProjectSFID: params.ProjectSFID,
+			EventData: &events.BotAllowlistUpdatedEventData{
+				OrganizationType: models.BotAllowlistOrganizationTypeGithub,
+				OrganizationName: params.OrgName,
+				Action:           "entry deleted",
+				Entries:          []string{params.EntryID},
+			},
+		})
+
+		return bot_allowlist.NewDeleteGitHubOrganizationBotAllowlistEntryNoContent().WithXRequestID(reqID)
+	})
+
+	api.BotAllowlistTestGitHubOrganizationBotAllowlistHandler = bot_allowlist.TestGitHubOrganizationBotAllowlistHandlerFunc(func(params bot_allowlist.TestGitHubOrganizationBotAllowlistParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.bot_allowlist.handlers.BotAllowlistTestGitHubOrganizationBotAllowlistHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"projectSFID":    params.ProjectSFID,
+			"orgName":        params.OrgName,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to test the bot allowlist of GitHub organization: %s for project: %s",
+				authUser.UserName, params.OrgName, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return bot_allowlist.NewTestGitHubOrganizationBotAllowlistForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.TestGitHubOrganizationBotAllowlist(ctx, params.ProjectSFID, params.OrgName, params.Body)
+		if err != nil {
+			msg := fmt.Sprintf("unable to test the bot allowlist of GitHub organization: %s", params.OrgName)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if isNotFound(err) {
+				return bot_allowlist.NewTestGitHubOrganizationBotAllowlistNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return bot_allowlist.NewTestGitHubOrganizationBotAllowlistBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		return bot_allowlist.NewTestGitHubOrganizationBotAllowlistOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.BotAllowlistGetGitLabOrganizationBotAllowlistHandler = bot_allowlist.GetGitLabOrganizationBotAllowlistHandlerFunc(func(params bot_allowlist.GetGitLabOrganizationBotAllowlistParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.bot_allowlist.handlers.BotAllowlistGetGitLabOrganizationBotAllowlistHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"projectSFID":    params.ProjectSFID,
+			"gitLabGroupID":  params.GitLabGroupID,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to get the bot allowlist of GitLab group: %d for project: %s",
+				authUser.UserName, params.GitLabGroupID, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return bot_allowlist.NewGetGitLabOrganizationBotAllowlistForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.GetGitLabOrganizationBotAllowlist(ctx, params.ProjectSFID, params.GitLabGroupID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to get the bot allowlist of GitLab group: %d", params.GitLabGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if isNotFound(err) {
+				return bot_allowlist.NewGetGitLabOrganizationBotAllowlistNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return bot_allowlist.NewGetGitLabOrganizationBotAllowlistBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		return bot_allowlist.NewGetGitLabOrganizationBotAllowlistOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.BotAllowlistUpdateGitLabOrganizationBotAllowlistHandler = bot_allowlist.UpdateGitLabOrganizationBotAllowlistHandlerFunc(func(params bot_allowlist.UpdateGitLabOrganizationBotAllowlistParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.bot_allowlist.handlers.BotAllowlistUpdateGitLabOrganizationBotAllowlistHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"projectSFID":    params.ProjectSFID,
+			"gitLabGroupID":  params.GitLabGroupID,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to update the bot allowlist of GitLab group: %d for project: %s",
+				authUser.UserName, params.GitLabGroupID, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return bot_allowlist.NewUpdateGitLabOrganizationBotAllowlistForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.UpdateGitLabOrganizationBotAllowlist(ctx, params.ProjectSFID, params.GitLabGroupID, params.Body)
+		if err != nil {
+			msg := fmt.Sprintf("unable to update the bot allowlist of GitLab group: %d", params.GitLabGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if isNotFound(err) {
+				return bot_allowlist.NewUpdateGitLabOrganizationBotAllowlistNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			if isConflict(err) {
+				return bot_allowlist.NewUpdateGitLabOrganizationBotAllowlistConflict().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseConflictWithError(reqID, msg, err))
+			}
+			return bot_allowlist.NewUpdateGitLabOrganizationBotAllowlistBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+			LfUsername:  authUser.UserName,
+			EventType:   events.BotAllowlistUpdated,
+			ProjectSFID: params.ProjectSFID,
+			EventData: &events.BotAllowlistUpdatedEventData{
+				OrganizationType: models.BotAllowlistOrganizationTypeGitlab,
+				OrganizationName: result.OrganizationName,
+				Action:           "entries replaced",
+				Entries:          entryStrings(result.Entries),
+			},
+		})
+
+		return bot_allowlist.NewUpdateGitLabOrganizationBotAllowlistOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.BotAllowlistAddGitLabOrganizationBotAllowlistEntryHandler = bot_allowlist.AddGitLabOrganizationBotAllowlistEntryHandlerFunc(func(params bot_allowlist.AddGitLabOrganizationBotAllowlistEntryParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.bot_allowlist.handlers.BotAllowlistAddGitLabOrganizationBotAllowlistEntryHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"projectSFID":    params.ProjectSFID,
+			"gitLabGroupID":  params.GitLabGroupID,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to add a bot allowlist entry to GitLab group: %d for project: %s",
+				authUser.UserName, params.GitLabGroupID, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return bot_allowlist.NewAddGitLabOrganizationBotAllowlistEntryForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.AddGitLabOrganizationBotAllowlistEntry(ctx, params.ProjectSFID, params.GitLabGroupID, params.Body)
+		if err != nil {
+			msg := fmt.Sprintf("unable to add a bot allowlist entry to GitLab group: %d", params.GitLabGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if isNotFound(err) {
+				return bot_allowlist.NewAddGitLabOrganizationBotAllowlistEntryNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			if isConflict(err) {
+				return bot_allowlist.NewAddGitLabOrganizationBotAllowlistEntryConflict().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseConflictWithError(reqID, msg, err))
+			}
+			return bot_allowlist.NewAddGitLabOrganizationBotAllowlistEntryBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+			LfUsername:  authUser.UserName,
+			EventType:   events.BotAllowlistUpdated,
+			ProjectSFID: params.ProjectSFID,
+			EventData: &events.BotAllowlistUpdatedEventData{
+				OrganizationType: models.BotAllowlistOrganizationTypeGitlab,
+				OrganizationName: result.OrganizationName,
+				Action:           "entry added",
+				Entries:          entryStrings([]*models.BotAllowlistEntry{params.Body}),
+			},
+		})
+
+		return bot_allowlist.NewAddGitLabOrganizationBotAllowlistEntryOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.BotAllowlistDeleteGitLabOrganizationBotAllowlistEntryHandler = bot_allowlist.DeleteGitLabOrganizationBotAllowlistEntryHandlerFunc(func(params bot_allowlist.DeleteGitLabOrganizationBotAllowlistEntryParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.bot_allowlist.handlers.BotAllowlistDeleteGitLabOrganizationBotAllowlistEntryHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"projectSFID":    params.ProjectSFID,
+			"gitLabGroupID":  params.GitLabGroupID,
+			"entryID":        params.EntryID,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to delete a bot allowlist entry from GitLab group: %d for project: %s",
+				authUser.UserName, params.GitLabGroupID, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return bot_allowlist.NewDeleteGitLabOrganizationBotAllowlistEntryForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		err := service.DeleteGitLabOrganizationBotAllowlistEntry(ctx, params.ProjectSFID, params.GitLabGroupID, params.EntryID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to delete a bot allowlist entry from GitLab group: %d", params.GitLabGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if isNotFound(err) {
+				return bot_allowlist.NewDeleteGitLabOrganizationBotAllowlistEntryNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			if isConflict(err) {
+				return bot_allowlist.NewDeleteGitLabOrganizationBotAllowlistEntryConflict().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseConflictWithError(reqID, msg, err))
+			}
+			return bot_allowlist.NewDeleteGitLabOrganizationBotAllowlistEntryBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+			LfUsername:  authUser.UserName,
+			EventType:   events.BotAllowlistUpdated,
+			ProjectSFID: params.ProjectSFID,
+			EventData: &events.BotAllowlistUpdatedEventData{
+				OrganizationType: models.BotAllowlistOrganizationTypeGitlab,
+				OrganizationName: strconv.FormatInt(params.GitLabGroupID, 10),
+				Action:           "entry deleted",
+				Entries:          []string{params.EntryID},
+			},
+		})
+
+		return bot_allowlist.NewDeleteGitLabOrganizationBotAllowlistEntryNoContent().WithXRequestID(reqID)
+	})
+
+	api.BotAllowlistTestGitLabOrganizationBotAllowlistHandler = bot_allowlist.TestGitLabOrganizationBotAllowlistHandlerFunc(func(params bot_allowlist.TestGitLabOrganizationBotAllowlistParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.bot_allowlist.handlers.BotAllowlistTestGitLabOrganizationBotAllowlistHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"projectSFID":    params.ProjectSFID,
+			"gitLabGroupID":  params.GitLabGroupID,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to test the bot allowlist of GitLab group: %d for project: %s",
+				authUser.UserName, params.GitLabGroupID, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return bot_allowlist.NewTestGitLabOrganizationBotAllowlistForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.TestGitLabOrganizationBotAllowlist(ctx, params.ProjectSFID, params.GitLabGroupID, params.Body)
+		if err != nil {
+			msg := fmt.Sprintf("unable to test the bot allowlist of GitLab group: %d", params.GitLabGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if isNotFound(err) {
+				return bot_allowlist.NewTestGitLabOrganizationBotAllowlistNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return bot_allowlist.NewTestGitLabOrganizationBotAllowlistBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		return bot_allowlist.NewTestGitLabOrganizationBotAllowlistOK().WithXRequestID(reqID).WithPayload(result)
+	})
+}
+
+// isNotFound returns true if the error is a missing organization or allowlist entry error
+func isNotFound(err error) bool {
+	var gitHubOrgNotFound *utils.GitHubOrgNotFound
+	var gitLabOrgNotFound *utils.GitLabOrgNotFound
+	return errors.As(err, &gitHubOrgNotFound) || errors.As(err, &gitLabOrgNotFound) || errors.Is(err, ErrEntryNotFound)
+}
+
+func isConflict(err error) bool {
+	var skipCLAConflict *utils.SkipCLAConflict
+	return errors.As(err, &skipCLAConflict)
+}
+
+// entryStrings returns the entries in the skip_cla "<repository>: <login>;<email>;<name>" format, for the events
+func entryStrings(entries []*models.BotAllowlistEntry) []string {
+	out := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, fmt.Sprintf("%s: %s;%s;%s", entry.Repository, entry.Login, entry.Email, entry.Name))
+	}
+	return out
+}
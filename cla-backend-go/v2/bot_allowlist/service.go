@@ -0,0 +1,339 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bot_allowlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	botAllowlist "github.com/linuxfoundation/easycla/cla-backend-go/bot_allowlist"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	v1GithubOrg "github.com/linuxfoundation/easycla/cla-backend-go/github_organizations"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab_organizations"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrEntryNotFound is returned when the bot allowlist entry does not exist
+var ErrEntryNotFound = errors.New("bot allowlist entry not found")
+
+// Service contains the functions to manage the skip_cla bot allowlist of the GitHub organizations and GitLab groups
+type Service interface {
+	GetGitHubOrganizationBotAllowlist(ctx context.Context, projectSFID, orgName string) (*models.BotAllowlist, error)
+	UpdateGitHubOrganizationBotAllowlist(ctx context.Context, projectSFID, orgName string, input *models.BotAllowlistInput) (*models.BotAllowlist, error)
+	AddGitHubOrganizationBotAllowlistEntry(ctx context.Context, projectSFID, orgName string, input *models.BotAllowlistEntry) (*models.BotAllowlist, error)
+	DeleteGitHubOrganizationBotAllowlistEntry(ctx context.Context, projectSFID, orgName, entryID string) error
+	TestGitHubOrganizationBotAllowlist(ctx context.Context, projectSFID, orgName string, input *models.BotAllowlistTestInput) (*models.BotAllowlistTestResult, error)
+
+	GetGitLabOrganizationBotAllowlist(ctx context.Context, projectSFID string, gitLabGroupID int64) (*models.BotAllowlist, error)
+	UpdateGitLabOrganizationBotAllowlist(ctx context.Context, projectSFID string, gitLabGroupID int64, input *models.BotAllowlistInput) (*models.BotAllowlist, error)
+	AddGitLabOrganizationBotAllowlistEntry(ctx context.Context, projectSFID string, gitLabGroupID int64, input *models.BotAllowlistEntry) (*models.BotAllowlist, error)
+	DeleteGitLabOrganizationBotAllowlistEntry(ctx context.Context, projectSFID string, gitLabGroupID int64, entryID string) error
+	TestGitLabOrganizationBotAllowlist(ctx context.Context, projectSFID string, gitLabGroupID int64, input *models.BotAllowlistTestInput) (*models.BotAllowlistTestResult, error)
+}
+
+type service struct {
+	githubOrgRepo v1GithubOrg.RepositoryInterface
+	gitlabOrgRepo gitlab_organizations.RepositoryInterface
+}
+
+// NewService creates a new bot allowlist service
+func NewService(githubOrgRepo v1GithubOrg.RepositoryInterface, gitlabOrgRepo gitlab_organizations.RepositoryInterface) Service {
+	return service{
+		githubOrgRepo: githubOrgRepo,
+		gitlabOrgRepo: gitlabOrgRepo,
+	}
+}
+
+// organization is the GitHub organization or GitLab group owning the bot allowlist
+type organization struct {
+	organizationType string
+	organizationName string
+	skipCLA          map[string]string
+	// save stores the updated configuration, it fails with a conflict when the stored one no longer matches skipCLA
+	save func(ctx context.Context, skipCLA map[string]string) error
+}
+
+// GetGitHubOrganizationBotAllowlist returns the bot allowlist of the GitHub organization
+func (s service) GetGitHubOrganizationBotAllowlist(ctx context.Context, projectSFID, orgName string) (*models.BotAllowlist, error) {
+	org, err := s.getGitHubOrganization(ctx, projectSFID, orgName)
+	if err != nil {
+		return nil, err
+	}
+	return toBotAllowlistModel(org, botAllowlist.ParseSkipCLA(org.skipCLA)), nil
+}
+
+// UpdateGitHubOrganizationBotAllowlist replaces the bot allowlist of the GitHub organization
+func (s service) UpdateGitHubOrganizationBotAllowlist(ctx context.Context, projectSFID, orgName string, input *models.BotAllowlistInput) (*models.BotAllowlist, error) {
+	org, err := s.getGitHubOrganization(ctx, projectSFID, orgName)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateBotAllowlist(ctx, org, input)
+}
+
+// AddGitHubOrganizationBotAllowlistEntry adds an entry to the bot allowlist of the GitHub organization
+func (s service) AddGitHubOrganizationBotAllowlistEntry(ctx context.Context, projectSFID, orgName string, input *models.BotAllowlistEntry) (*models.BotAllowlist, error) {
+	org, err := s.getGitHubOrganization(ctx, projectSFID, orgName)
+	if err != nil {
+		return nil, err
+	}
+	return s.addBotAllowlistEntry(ctx, org, input)
+}
+
+// DeleteGitHubOrganizationBotAllowlistEntry deletes an entry from the bot allowlist of the GitHub organization
+func (s service) DeleteGitHubOrganizationBotAllowlistEntry(ctx context.Context, projectSFID, orgName, entryID string) error {
+	org, err := s.getGitHubOrganization(ctx, projectSFID, orgName)
+	if err != nil {
+		return err
+	}
+	return s.deleteBotAllowlistEntry(ctx, org, entryID)
+}
+
+// TestGitHubOrganizationBotAllowlist checks the sample actor against the bot allowlist of the GitHub organization
+func (s service) TestGitHubOrganizationBotAllowlist(ctx context.Context, projectSFID, orgName string, input *models.BotAllowlistTestInput) (*models.BotAllowlistTestResult, error) {
+	org, err := s.getGitHubOrganization(ctx, projectSFID, orgName)
+	if err != nil {
+		return nil, err
+	}
+	return testBotAllowlist(org, input), nil
+}
+
+// GetGitLabOrganizationBotAllowlist returns the bot allowlist of the GitLab group
+func (s service) GetGitLabOrganizationBotAllowlist(ctx context.Context, projectSFID string, gitLabGroupID int64) (*models.BotAllowlist, error) {
+	org, err := s.getGitLabOrganization(ctx, projectSFID, gitLabGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return toBotAllowlistModel(org, botAllowlist.ParseSkipCLA(org.skipCLA)), nil
+}
+
+// UpdateGitLabOrganizationBotAllowlist replaces the bot allowlist of the GitLab group
+func (s service) UpdateGitLabOrganizationBotAllowlist(ctx context.Context, projectSFID string, gitLabGroupID int64, input *models.BotAllowlistInput) (*models.BotAllowlist, error) {
+	org, err := s.getGitLabOrganization(ctx, projectSFID, gitLabGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateBotAllowlist(ctx, org, input)
+}
+
+// AddGitLabOrganizationBotAllowlistEntry adds an entry to the bot allowlist of the GitLab group
+func (s service) AddGitLabOrganizationBotAllowlistEntry(ctx context.Context, projectSFID string, gitLabGroupID int64, input *models.BotAllowlistEntry) (*models.BotAllowlist, error) {
+	org, err := s.getGitLabOrganization(ctx, projectSFID, gitLabGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return s.addBotAllowlistEntry(ctx, org, input)
+}
+
+// DeleteGitLabOrganizationBotAllowlistEntry deletes an entry from the bot allowlist of the GitLab group
+func (s service) DeleteGitLabOrganizationBotAllowlistEntry(ctx context.Context, projectSFID string, gitLabGroupID int64, entryID string) error {
+	org, err := s.getGitLabOrganization(ctx, projectSFID, gitLabGroupID)
+	if err != nil {
+		return err
+	}
+	return s.deleteBotAllowlistEntry(ctx, org, entryID)
+}
+
+// TestGitLabOrganizationBotAllowlist checks the sample actor against the bot allowlist of the GitLab group
+func (s service) TestGitLabOrganizationBotAllowlist(ctx context.Context, projectSFID string, gitLabGroupID int64, input *models.BotAllowlistTestInput) (*models.BotAllowlistTestResult, error) {
+	org, err := s.getGitLabOrganization(ctx, projectSFID, gitLabGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return testBotAllowlist(org, input), nil
+}
+
+// getGitHubOrganization loads the GitHub organization, which must belong to the project or its parent project
+func (s service) getGitHubOrganization(ctx context.Context, projectSFID, orgName string) (*organization, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bot_allowlist.service.getGitHubOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+		"orgName":        orgName,
+	}
+
+	githubOrg, err := s.githubOrgRepo.GetGitHubOrganization(ctx, orgName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load GitHub organization by name: %s", orgName)
+		if errors.Is(err, v1GithubOrg.ErrOrganizationDoesNotExist) {
+			return nil, &utils.GitHubOrgNotFound{ProjectSFID: projectSFID, OrganizationName: orgName, Err: err}
+		}
+		return nil, err
+	}
+	if githubOrg == nil || (githubOrg.ProjectSFID != projectSFID && githubOrg.OrganizationSfid != projectSFID) {
+		log.WithFields(f).Warnf("GitHub organization: %s is not associated with project: %s", orgName, projectSFID)
+		return nil, &utils.GitHubOrgNotFound{ProjectSFID: projectSFID, OrganizationName: orgName}
+	}
+
+	return &organization{
+		organizationType: models.BotAllowlistOrganizationTypeGithub,
+		organizationName: githubOrg.OrganizationName,
+		skipCLA:          githubOrg.SkipCla,
+		save: func(ctx context.Context, skipCLA map[string]string) error {
+			return s.githubOrgRepo.UpdateGitHubOrganizationSkipCLA(ctx, githubOrg.OrganizationName, githubOrg.SkipCla, skipCLA)
+		},
+	}, nil
+}
+
+// getGitLabOrganization loads the GitLab group, which must belong to the project
+func (s service) getGitLabOrganization(ctx context.Context, projectSFID string, gitLabGroupID int64) (*organization, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bot_allowlist.service.getGitLabOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+		"gitLabGroupID":  gitLabGroupID,
+	}
+
+	gitlabOrg, err := s.gitlabOrgRepo.GetGitLabOrganizationByExternalID(ctx, gitLabGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load GitLab group by ID: %d", gitLabGroupID)
+		return nil, err
+	}
+	if gitlabOrg == nil || gitlabOrg.ProjectSFID != projectSFID {
+		log.WithFields(f).Warnf("GitLab group: %d is not associated with project: %s", gitLabGroupID, projectSFID)
+		return nil, &utils.GitLabOrgNotFound{ProjectSFID: projectSFID, GitLabGroupID: gitLabGroupID}
+	}
+
+	organizationName := gitlabOrg.OrganizationFullPath
+	if organizationName == "" {
+		organizationName = gitlabOrg.OrganizationName
+	}
+	return &organization{
+		organizationType: models.BotAllowlistOrganizationTypeGitlab,
+		organizationName: organizationName,
+		skipCLA:          gitlabOrg.SkipCLA,
+		save: func(ctx context.Context, skipCLA map[string]string) error {
+			return s.gitlabOrgRepo.UpdateGitLabOrganizationSkipCLA(ctx, gitlabOrg.OrganizationID, gitlabOrg.SkipCLA, skipCLA)
+		},
+	}, nil
+}
+
+// updateBotAllowlist validates and stores the entries, replacing the existing allowlist
+func (s service) updateBotAllowlist(ctx context.Context, org *organization, input *models.BotAllowlistInput) (*models.BotAllowlist, error) {
+	var entries []*botAllowlist.Entry
+	if input != nil {
+		for _, entryModel := range input.Entries {
+			entries = append(entries, toEntry(entryModel))
+		}
+	}
+	if err := botAllowlist.Validate(entries); err != nil {
+		return nil, fmt.Errorf("invalid bot allowlist: %w", err)
+	}
+
+	if err := org.save(ctx, botAllowlist.ToSkipCLA(entries)); err != nil {
+		return nil, err
+	}
+	return toBotAllowlistModel(org, entries), nil
+}
+
+// addBotAllowlistEntry validates and appends the entry to the existing allowlist - existing entries are kept as-is,
+// so a previously stored invalid entry doesn't prevent adding new ones
+func (s service) addBotAllowlistEntry(ctx context.Context, org *organization, input *models.BotAllowlistEntry) (*models.BotAllowlist, error) {
+	if input == nil {
+		return nil, errors.New("missing bot allowlist entry")
+	}
+	entry := toEntry(input)
+	if err := botAllowlist.Validate([]*botAllowlist.Entry{entry}); err != nil {
+		return nil, fmt.Errorf("invalid bot allowlist entry: %w", err)
+	}
+
+	entries := botAllowlist.ParseSkipCLA(org.skipCLA)
+	for _, existing := range entries {
+		if existing.ID == entry.ID {
+			return nil, fmt.Errorf("bot allowlist entry already exists: %s", entry)
+		}
+	}
+	entries = append(entries, entry)
+
+	if err := org.save(ctx, botAllowlist.ToSkipCLA(entries)); err != nil {
+		return nil, err
+	}
+	return toBotAllowlistModel(org, entries), nil
+}
+
+// deleteBotAllowlistEntry removes the entry from the allowlist
+func (s service) deleteBotAllowlistEntry(ctx context.Context, org *organization, entryID string) error {
+	existing := botAllowlist.ParseSkipCLA(org.skipCLA)
+	entries := make([]*botAllowlist.Entry, 0, len(existing))
+	for _, entry := range existing {
+		if entry.ID != entryID {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == len(existing) {
+		return ErrEntryNotFound
+	}
+
+	return org.save(ctx, botAllowlist.ToSkipCLA(entries))
+}
+
+// testBotAllowlist checks the sample actor against the stored allowlist, the same way the CLA checks do
+func testBotAllowlist(org *organization, input *models.BotAllowlistTestInput) *models.BotAllowlistTestResult {
+	if input == nil {
+		input = &models.BotAllowlistTestInput{}
+	}
+	// invalid entries are part of the allowlist and reported in the result
+	allowlist, _ := botAllowlist.CompileSkipCLA(org.skipCLA) // nolint
+	testResult := allowlist.Test(input.Repository, &botAllowlist.Actor{
+		Login: input.Login,
+		Email: input.Email,
+		Name:  input.Name,
+	})
+
+	response := &models.BotAllowlistTestResult{
+		Matched:            testResult.Matched,
+		SelectedRepository: testResult.SelectedRepository,
+		Results:            []*models.BotAllowlistEntryTestResult{},
+	}
+	if testResult.MatchedEntry != nil {
+		response.MatchedEntry = toEntryModel(testResult.MatchedEntry)
+	}
+	for _, result := range testResult.Results {
+		response.Results = append(response.Results, &models.BotAllowlistEntryTestResult{
+			Entry:             toEntryModel(result.Entry),
+			RepositoryMatched: result.RepositoryMatched,
+			Selected:          result.Selected,
+			LoginMatched:      result.LoginMatched,
+			EmailMatched:      result.EmailMatched,
+			NameMatched:       result.NameMatched,
+			ActorMatched:      result.ActorMatched,
+			Matched:           result.Matched,
+			InvalidReason:     result.InvalidReason,
+		})
+	}
+	return response
+}
+
+func toEntry(in *models.BotAllowlistEntry) *botAllowlist.Entry {
+	return botAllowlist.NewEntry(in.Repository, in.Login, in.Email, in.Name)
+}
+
+func toEntryModel(in *botAllowlist.Entry) *models.BotAllowlistEntry {
+	return &models.BotAllowlistEntry{
+		ID:         in.ID,
+		Repository: in.Repository,
+		Login:      in.Login,
+		Email:      in.Email,
+		Name:       in.Name,
+	}
+}
+
+func toBotAllowlistModel(org *organization, entries []*botAllowlist.Entry) *models.BotAllowlist {
+	response := &models.BotAllowlist{
+		OrganizationType: org.organizationType,
+		OrganizationName: org.organizationName,
+		Entries:          []*models.BotAllowlistEntry{},
+	}
+	for _, entry := range entries {
+		entryModel := toEntryModel(entry)
+		if err := botAllowlist.Validate([]*botAllowlist.Entry{entry}); err != nil {
+			entryModel.InvalidReason = err.Error()
+		}
+		response.Entries = append(response.Entries, entryModel)
+	}
+	return response
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
 	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/notification_digest"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 	"github.com/sirupsen/logrus"
 )
@@ -23,6 +24,7 @@ type EmailToCLAManagerModel struct {
 	CLAManagerName      string
 	CLAManagerEmail     string
 	CompanyName         string
+	CLAGroupID          string
 	CLAGroupName        string
 	CorporateConsoleURL string
 }
@@ -112,12 +114,26 @@ func (s *service) SendEmailToCLAManager(ctx context.Context, input *EmailToCLAMa
 		"claGroupName":              input.CLAGroupName,
 	}
 
+	if s.digestService != nil && s.digestService.QueueNotification(ctx, &notification_digest.Notification{
+		RecipientEmail:   input.CLAManagerEmail,
+		RecipientName:    input.CLAManagerName,
+		NotificationType: notification_digest.NotificationTypeApprovalRequest,
+		CompanyName:      input.CompanyName,
+		CLAGroupID:       input.CLAGroupID,
+		CLAGroupName:     input.CLAGroupName,
+		Message:          fmt.Sprintf("%s requested to be added to the approval list", getBestUserName(input.Contributor)),
+	}) {
+		log.WithFields(f).Debug("added the approval request to the CLA manager digest")
+		return
+	}
+
 	subject := fmt.Sprintf("EasyCLA: Approval Request for contributor: %s", getBestUserName(input.Contributor))
 	recipients := []string{input.CLAManagerEmail}
-	body, err := emails.RenderV2ContributorApprovalRequestTemplate(s.emailTemplateService, projectSFIDs, emails.V2ContributorApprovalRequestTemplateParams{
+	subject, body, err := emails.RenderV2ContributorApprovalRequestTemplate(s.emailTemplateService, subject, projectSFIDs, emails.V2ContributorApprovalRequestTemplateParams{
 		CommonEmailParams: emails.CommonEmailParams{
-			RecipientName: input.CLAManagerName,
-			CompanyName:   input.CompanyName,
+			RecipientName:    input.CLAManagerName,
+			RecipientAddress: input.CLAManagerEmail,
+			CompanyName:      input.CompanyName,
 		},
 		SigningEntityName: input.CompanyName,
 		UserDetails:       getFormattedUserDetails(input.Contributor),
@@ -152,10 +168,11 @@ func (s *service) SendEmailToOrgAdmin(ctx context.Context, input EmailToOrgAdmin
 
 	subject := fmt.Sprintf("EasyCLA:  Invitation to Sign the %s Corporate CLA ", input.companyName)
 	recipients := []string{input.adminEmail}
-	body, err := emails.RenderV2OrgAdminTemplate(s.emailTemplateService, input.projectSFID, emails.V2OrgAdminTemplateParams{
+	subject, body, err := emails.RenderV2OrgAdminTemplate(s.emailTemplateService, subject, input.projectSFID, emails.V2OrgAdminTemplateParams{
 		CommonEmailParams: emails.CommonEmailParams{
-			RecipientName: input.adminName,
-			CompanyName:   input.companyName,
+			RecipientName:    input.adminName,
+			RecipientAddress: input.adminEmail,
+			CompanyName:      input.companyName,
 		},
 		SenderName:  input.senderName,
 		SenderEmail: input.senderEmail,
@@ -189,10 +206,11 @@ func (s *service) ContributorEmailToOrgAdmin(ctx context.Context, input Contribu
 
 	subject := fmt.Sprintf("EasyCLA:  Invitation to Sign the %s Corporate CLA and add to approved list %s ", input.companyName, getBestUserName(input.contributor))
 	recipients := []string{input.adminEmail}
-	body, err := emails.RenderV2ContributorToOrgAdminTemplate(s.emailTemplateService, input.projectSFIDs, emails.V2ContributorToOrgAdminTemplateParams{
+	subject, body, err := emails.RenderV2ContributorToOrgAdminTemplate(s.emailTemplateService, subject, input.projectSFIDs, emails.V2ContributorToOrgAdminTemplateParams{
 		CommonEmailParams: emails.CommonEmailParams{
-			RecipientName: input.adminName,
-			CompanyName:   input.companyName,
+			RecipientName:    input.adminName,
+			RecipientAddress: input.adminEmail,
+			CompanyName:      input.companyName,
 		},
 		UserDetails: input.userDetails,
 	})
@@ -222,10 +240,11 @@ func (s *service) SendEmailToCLAManagerDesigneeCorporate(ctx context.Context, in
 
 	subject := fmt.Sprintf("EasyCLA:  Invitation to Sign the %s Corporate CLA ", input.companyName)
 	recipients := []string{input.designeeEmail}
-	body, err := emails.RenderV2CLAManagerDesigneeCorporateTemplate(s.emailTemplateService, input.projectSFID, emails.V2CLAManagerDesigneeCorporateTemplateParams{
+	subject, body, err := emails.RenderV2CLAManagerDesigneeCorporateTemplate(s.emailTemplateService, subject, input.projectSFID, emails.V2CLAManagerDesigneeCorporateTemplateParams{
 		CommonEmailParams: emails.CommonEmailParams{
-			RecipientName: input.designeeName,
-			CompanyName:   input.companyName,
+			RecipientName:    input.designeeName,
+			RecipientAddress: input.designeeEmail,
+			CompanyName:      input.companyName,
 		},
 		SenderName:  input.senderName,
 		SenderEmail: input.senderEmail,
@@ -257,11 +276,12 @@ func (s *service) SendEmailToCLAManagerDesignee(ctx context.Context, input ToCLA
 	subject := fmt.Sprintf("EasyCLA:  Invitation to Sign the %s Corporate CLA and add to approved list %s ",
 		input.companyName, input.contributorModel.Email)
 	recipients := []string{input.designeeEmail}
-	body, err := emails.RenderV2ToCLAManagerDesigneeTemplate(s.emailTemplateService, input.projectSFIDs,
+	subject, body, err := emails.RenderV2ToCLAManagerDesigneeTemplate(s.emailTemplateService, subject, input.projectSFIDs,
 		emails.V2ToCLAManagerDesigneeTemplateParams{
 			CommonEmailParams: emails.CommonEmailParams{
-				RecipientName: input.designeeName,
-				CompanyName:   input.companyName,
+				RecipientName:    input.designeeName,
+				RecipientAddress: input.designeeEmail,
+				CompanyName:      input.companyName,
 			},
 			Contributor: input.contributorModel,
 		}, emails.V2ToCLAManagerDesigneeTemplate, emails.V2ToCLAManagerDesigneeTemplateName)
@@ -293,11 +313,12 @@ func (s *service) SendDesigneeEmailToUserWithNoLFID(ctx context.Context, input D
 
 	subject := "EasyCLA: Invitation to create LF Login and complete process of becoming CLA Manager"
 
-	body, err := emails.RenderV2ToCLAManagerDesigneeTemplate(s.emailTemplateService, input.projectSFIDs,
+	subject, body, err := emails.RenderV2ToCLAManagerDesigneeTemplate(s.emailTemplateService, subject, input.projectSFIDs,
 		emails.V2ToCLAManagerDesigneeTemplateParams{
 			CommonEmailParams: emails.CommonEmailParams{
-				RecipientName: input.userWithNoLFIDName,
-				CompanyName:   input.companyName,
+				RecipientName:    input.userWithNoLFIDName,
+				RecipientAddress: input.userWithNoLFIDEmail,
+				CompanyName:      input.companyName,
 			},
 			Contributor: input.contributorModel,
 		}, emails.V2DesigneeToUserWithNoLFIDTemplate, emails.V2DesigneeToUserWithNoLFIDTemplateName)
@@ -345,10 +366,11 @@ func (s *service) SendEmailToUserWithNoLFID(ctx context.Context, input EmailToUs
 
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: Invitation to create LF Login and complete process of becoming CLA Manager with %s role", input.role)
-	body, err := emails.RenderV2CLAManagerToUserWithNoLFIDTemplate(s.emailTemplateService, input.projectID, emails.V2CLAManagerToUserWithNoLFIDTemplateParams{
+	subject, body, err := emails.RenderV2CLAManagerToUserWithNoLFIDTemplate(s.emailTemplateService, subject, input.projectID, emails.V2CLAManagerToUserWithNoLFIDTemplateParams{
 		CommonEmailParams: emails.CommonEmailParams{
-			RecipientName: input.userWithNoLFIDName,
-			CompanyName:   input.companyName,
+			RecipientName:    input.userWithNoLFIDName,
+			RecipientAddress: input.userWithNoLFIDEmail,
+			CompanyName:      input.companyName,
 		},
 		RequesterUserName: input.requesterUsername,
 		RequesterEmail:    input.requesterEmail,
@@ -21,6 +21,7 @@ import (
 	"github.com/LF-Engineering/lfx-kit/auth"
 	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
 	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/notification_digest"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/company"
@@ -80,6 +81,7 @@ type service struct {
 	v2CompanyService     v2Company.Service
 	eventService         events.Service
 	projectCGRepo        projects_cla_groups.Repository
+	digestService        notification_digest.Service
 }
 
 // Service interface
@@ -107,7 +109,7 @@ type Service interface {
 // NewService returns instance of CLA Manager service
 func NewService(emailTemplateService emails.EmailTemplateService, compService company.IService, projService service2.Service, mgrService v1ClaManager.IService, claUserService easyCLAUser.Service,
 	repoService repositories.Service, v2CompService v2Company.Service,
-	evService events.Service, projectCGroupRepo projects_cla_groups.Repository) Service {
+	evService events.Service, projectCGroupRepo projects_cla_groups.Repository, digestService notification_digest.Service) Service {
 	return &service{
 		emailTemplateService: emailTemplateService,
 		companyService:       compService,
@@ -118,6 +120,7 @@ func NewService(emailTemplateService emails.EmailTemplateService, compService co
 		v2CompanyService:     v2CompService,
 		eventService:         evService,
 		projectCGRepo:        projectCGroupRepo,
+		digestService:        digestService,
 	}
 }
 
@@ -1181,8 +1184,10 @@ func (s *service) NotifyCLAManagers(ctx context.Context, notifyCLAManagers *mode
 		return pcgErr
 	}
 
+	var claGroupName string
 	for _, pcg := range pcgs {
 		projectSFIDs = append(projectSFIDs, pcg.ProjectSFID)
+		claGroupName = pcg.ClaGroupName
 	}
 
 	log.Debugf("Sending notification emails to CLA Managers: %+v", notifyCLAManagers.List)
@@ -1192,6 +1197,8 @@ func (s *service) NotifyCLAManagers(ctx context.Context, notifyCLAManagers *mode
 			CLAManagerName:      claManager.Name,
 			CLAManagerEmail:     claManager.Email.String(),
 			CompanyName:         notifyCLAManagers.CompanyName,
+			CLAGroupID:          notifyCLAManagers.ClaGroupID,
+			CLAGroupName:        claGroupName,
 			CorporateConsoleURL: CorporateConsoleV2URL,
 		}, projectSFIDs)
 	}
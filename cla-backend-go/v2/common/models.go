@@ -28,6 +28,10 @@ type GitLabOrganization struct {
 	Note                    string `json:"note,omitempty"`
 	AuthExpirationTime      int    `json:"auth_expiry_time,omitempty"`
 	Version                 string `json:"version,omitempty"`
+	// SkipCLA is the bot allowlist configuration, using the same format as the GitHub organization skip_cla attribute
+	SkipCLA map[string]string `json:"skip_cla,omitempty"`
+	// CommitIdentityPolicy selects the commit identities checked for the merge requests, see the commit_identity package
+	CommitIdentityPolicy string `json:"commit_identity_policy,omitempty"`
 }
 
 // ToModel converts to models.GitlabOrganization
@@ -50,6 +54,8 @@ func ToModel(in *GitLabOrganization) *models2.GitlabOrganization {
 		OrganizationExternalID:  int64(in.ExternalGroupID),
 		AuthState:               in.AuthState,
 		AuthExpiryTime:          int64(in.AuthExpirationTime),
+		SkipCla:                 in.SkipCLA,
+		CommitIdentityPolicy:    in.CommitIdentityPolicy,
 	}
 }
 
@@ -73,6 +79,8 @@ func ToCommonModel(in *models2.GitlabOrganization) *GitLabOrganization {
 		ExternalGroupID:         int(in.OrganizationExternalID),
 		AuthState:               in.AuthState,
 		AuthExpirationTime:      int(in.AuthExpiryTime),
+		SkipCLA:                 in.SkipCla,
+		CommitIdentityPolicy:    in.CommitIdentityPolicy,
 	}
 }
 
@@ -105,6 +113,8 @@ type GitLabAddOrganization struct {
 	AuthInfo                string `json:"auth_info"`
 	AuthState               string `json:"auth_state"`
 	Version                 string `json:"version,omitempty"`
+	// CommitIdentityPolicy is only applied by the updates when set, an empty value keeps the current policy
+	CommitIdentityPolicy string `json:"commit_identity_policy,omitempty"`
 }
 
 // ExternalGroupIDAsInt returns the external group ID as an integer value
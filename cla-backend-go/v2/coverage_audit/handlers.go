@@ -0,0 +1,86 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package coverage_audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/signatures"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service, projectClaGroupsRepo projects_cla_groups.Repository) {
+	api.SignaturesGetCLACoverageAuditHandler = signatures.GetCLACoverageAuditHandlerFunc(
+		func(params signatures.GetCLACoverageAuditParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "v2.coverage_audit.handlers.SignaturesGetCLACoverageAuditHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"claGroupID":     params.ClaGroupID,
+				"identityType":   params.IdentityType,
+				"timestamp":      params.Timestamp,
+			}
+
+			if !isUserHaveAccessToCLAGroup(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to the coverage audit of the CLA group: %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return signatures.NewGetCLACoverageAuditForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			at, err := ParseTimestamp(params.Timestamp)
+			if err != nil {
+				return signatures.NewGetCLACoverageAuditBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "invalid timestamp", err))
+			}
+			query := Query{
+				IdentityType: params.IdentityType,
+				Identity:     params.Identity,
+				At:           at,
+				CompanyID:    utils.StringValue(params.CompanyID),
+			}
+			if err = query.Validate(); err != nil {
+				return signatures.NewGetCLACoverageAuditBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "invalid coverage audit query", err))
+			}
+
+			result, err := service.GetCoverage(ctx, params.ClaGroupID, query)
+			if err != nil {
+				msg := "unable to audit the CLA coverage"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return signatures.NewGetCLACoverageAuditInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+			return signatures.NewGetCLACoverageAuditOK().WithXRequestID(reqID).WithPayload(result)
+		})
+}
+
+// isUserHaveAccessToCLAGroup returns true for admins and for the users with access to the foundation of the CLA group
+func isUserHaveAccessToCLAGroup(ctx context.Context, authUser *auth.User, claGroupID string, projectClaGroupsRepo projects_cla_groups.Repository) bool {
+	f := logrus.Fields{
+		"functionName":   "v2.coverage_audit.handlers.isUserHaveAccessToCLAGroup",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"userName":       authUser.UserName,
+	}
+
+	if utils.IsUserAdmin(authUser) {
+		return true
+	}
+
+	projectCLAGroups, err := projectClaGroupsRepo.GetProjectsIdsForClaGroup(ctx, claGroupID)
+	if err != nil || len(projectCLAGroups) == 0 {
+		log.WithFields(f).WithError(err).Warn("unable to load the projects of the CLA group - failed permission check")
+		return false
+	}
+	return utils.IsUserAuthorizedForProjectTree(ctx, authUser, projectCLAGroups[0].FoundationSFID, utils.ALLOW_ADMIN_SCOPE)
+}
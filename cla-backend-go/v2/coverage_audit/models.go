@@ -0,0 +1,67 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package coverage_audit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// identity types of the coverage audit query
+const (
+	IdentityTypeEmail          = "email"
+	IdentityTypeGitHubUsername = "githubUsername"
+	IdentityTypeGitLabUsername = "gitlabUsername"
+)
+
+// coverage types of the coverage audit result
+const (
+	CoveredByICLA = "icla"
+	CoveredByCCLA = "ccla"
+	CoveredByNone = "none"
+)
+
+// ApprovalListHistoryReliableFrom is the date from which the approval list history is reliable. Before it the
+// entries removed from the approval lists were not deactivated in the history, and the domain entries were recorded
+// with the email criteria.
+var ApprovalListHistoryReliableFrom = time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+// Query identifies the contributor and the point in time to audit
+type Query struct {
+	IdentityType string
+	Identity     string
+	At           time.Time
+	// CompanyID is the company of the corporate coverage, defaults to the company of the user
+	CompanyID string
+}
+
+// Validate checks the identity of the query
+func (q Query) Validate() error {
+	switch q.IdentityType {
+	case IdentityTypeEmail, IdentityTypeGitHubUsername, IdentityTypeGitLabUsername:
+	default:
+		return fmt.Errorf("unsupported identity type: %s - expecting one of %s, %s or %s", q.IdentityType, IdentityTypeEmail, IdentityTypeGitHubUsername, IdentityTypeGitLabUsername)
+	}
+	if strings.TrimSpace(q.Identity) == "" {
+		return fmt.Errorf("identity is required")
+	}
+	if q.At.IsZero() {
+		return fmt.Errorf("timestamp is required")
+	}
+	return nil
+}
+
+// ParseTimestamp parses the audited point in time - a RFC3339 timestamp or a YYYY-MM-DD date, midnight UTC
+func ParseTimestamp(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("timestamp: %s is neither a RFC3339 timestamp nor a YYYY-MM-DD date", value)
+	}
+	return t, nil
+}
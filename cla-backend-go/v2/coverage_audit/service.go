@@ -0,0 +1,445 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package coverage_audit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/linuxfoundation/easycla/cla-backend-go/domain_matcher"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	v1SignatureParams "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	"github.com/sirupsen/logrus"
+)
+
+// Service answers whether a contributor was covered by a CLA at a point in time
+type Service interface {
+	// GetCoverage returns the ICLA, the employee acknowledgement, the CCLA and the approval list rule in effect for
+	// the contributor identity at the query time
+	GetCoverage(ctx context.Context, claGroupID string, query Query) (*models.CoverageAudit, error)
+}
+
+type service struct {
+	usersRepo     users.UserRepository
+	signatureRepo signatures.SignatureRepository
+	approvalRepo  approvals.IRepository
+	eventsRepo    events.Repository
+}
+
+// NewService creates the coverage audit service
+func NewService(usersRepo users.UserRepository, signatureRepo signatures.SignatureRepository, approvalRepo approvals.IRepository, eventsRepo events.Repository) Service {
+	return &service{
+		usersRepo:     usersRepo,
+		signatureRepo: signatureRepo,
+		approvalRepo:  approvalRepo,
+		eventsRepo:    eventsRepo,
+	}
+}
+
+// identities are the emails and usernames of the contributor matched against the approval list
+type identities struct {
+	emails          []string
+	githubUsernames []string
+	gitlabUsernames []string
+}
+
+// GetCoverage audits the CLA coverage of the contributor identity at the query time
+func (s *service) GetCoverage(ctx context.Context, claGroupID string, query Query) (*models.CoverageAudit, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.coverage_audit.service.GetCoverage",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"identityType":   query.IdentityType,
+		"identity":       query.Identity,
+		"at":             utils.TimeToString(query.At),
+		"companyID":      query.CompanyID,
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	identity := strings.TrimSpace(query.Identity)
+	result := &models.CoverageAudit{
+		ClaGroupID:   claGroupID,
+		IdentityType: query.IdentityType,
+		Identity:     identity,
+		Timestamp:    utils.TimeToString(query.At),
+		CoveredBy:    CoveredByNone,
+	}
+
+	userModel, err := s.lookupUser(query.IdentityType, identity)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup the user of the identity")
+		return nil, err
+	}
+	if userModel == nil {
+		result.Notes = append(result.Notes, "no EasyCLA user matches the identity, only the approval list was audited")
+	} else {
+		result.UserID = userModel.UserID
+		iclas, iclaErr := s.signatureRepo.GetIndividualSignatures(ctx, claGroupID, userModel.UserID, nil, aws.Bool(true))
+		if iclaErr != nil {
+			log.WithFields(f).WithError(iclaErr).Warn("unable to load the individual signatures of the user")
+			return nil, iclaErr
+		}
+		result.Icla = selectSignature(iclas, utils.ClaTypeICLA, claGroupID, query.At, result)
+	}
+
+	result.CompanyID = query.CompanyID
+	if result.CompanyID == "" && userModel != nil {
+		result.CompanyID = userModel.CompanyID
+	}
+	if result.CompanyID == "" {
+		result.Notes = append(result.Notes, "no company is associated with the identity, the corporate coverage was not audited")
+	} else if err = s.auditCorporateCoverage(ctx, claGroupID, query.At, userModel, collectIdentities(query.IdentityType, identity, userModel), result); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to audit the corporate coverage")
+		return nil, err
+	}
+
+	result.Events, err = s.identityEvents(claGroupID, identity, query.At)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the events of the identity")
+		return nil, err
+	}
+
+	switch {
+	case result.Icla != nil && result.Icla.InEffect:
+		result.Covered, result.CoveredBy = true, CoveredByICLA
+	case result.Ccla != nil && result.Ccla.InEffect && result.ApprovalListRule != nil && result.Ecla != nil && result.Ecla.InEffect:
+		result.Covered, result.CoveredBy = true, CoveredByCCLA
+	}
+
+	log.WithFields(f).Debugf("identity covered: %t by: %s", result.Covered, result.CoveredBy)
+	return result, nil
+}
+
+// auditCorporateCoverage sets the CCLA of the company, the matching approval list rule and the employee
+// acknowledgement of the user in effect at the query time
+func (s *service) auditCorporateCoverage(ctx context.Context, claGroupID string, at time.Time, userModel *v1Models.User, ids identities, result *models.CoverageAudit) error {
+	cclas, err := s.signatureRepo.GetCorporateSignatures(ctx, claGroupID, result.CompanyID, nil, aws.Bool(true))
+	if err != nil {
+		return err
+	}
+	result.Ccla = selectSignature(cclas, utils.ClaTypeCCLA, claGroupID, at, result)
+	if result.Ccla == nil {
+		result.Notes = append(result.Notes, fmt.Sprintf("company: %s has no signed CCLA for the CLA group", result.CompanyID))
+		return nil
+	}
+
+	items, err := s.approvalRepo.GetApprovalListBySignature(result.Ccla.SignatureID)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		result.Notes = append(result.Notes, fmt.Sprintf("CCLA: %s has no approval list history records", result.Ccla.SignatureID))
+	} else if hasUnreliableApprovalHistory(items) {
+		result.Notes = append(result.Notes, fmt.Sprintf("CCLA: %s has approval list history records from before %s, the entries removed before then may still be shown in effect",
+			result.Ccla.SignatureID, ApprovalListHistoryReliableFrom.Format("2006-01-02")))
+	}
+	rule, orgRules := matchApprovalRule(items, ids, at)
+	result.ApprovalListRule = rule
+	if rule == nil && len(orgRules) > 0 {
+		result.Notes = append(result.Notes, fmt.Sprintf("organization rules in effect: %s - the organization membership at the time is not recorded",
+			strings.Join(orgRules, ", ")))
+	}
+
+	if userModel == nil {
+		return nil
+	}
+	employeeSignatures, err := s.signatureRepo.GetProjectCompanyEmployeeSignatures(ctx, v1SignatureParams.GetProjectCompanyEmployeeSignaturesParams{
+		CompanyID: result.CompanyID,
+		ProjectID: claGroupID,
+		PageSize:  aws.Int64(signatures.HugePageSize),
+	}, nil)
+	if err != nil {
+		return err
+	}
+	var eclas []*v1Models.Signature
+	if employeeSignatures != nil {
+		for _, sig := range employeeSignatures.Signatures {
+			if sig.SignatureReferenceID == userModel.UserID {
+				eclas = append(eclas, sig)
+			}
+		}
+	}
+	result.Ecla = selectSignature(eclas, utils.ClaTypeECLA, claGroupID, at, result)
+	return nil
+}
+
+// lookupUser returns the user matching the identity, nil when no user matches
+func (s *service) lookupUser(identityType, identity string) (*v1Models.User, error) {
+	var userModel *v1Models.User
+	var err error
+	switch identityType {
+	case IdentityTypeEmail:
+		userModel, err = s.usersRepo.GetUserByEmail(identity)
+	case IdentityTypeGitHubUsername:
+		userModel, err = s.usersRepo.GetUserByGitHubUsername(identity)
+	case IdentityTypeGitLabUsername:
+		userModel, err = s.usersRepo.GetUserByGitLabUsername(identity)
+	}
+	if err != nil {
+		if _, ok := err.(*utils.UserNotFound); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return userModel, nil
+}
+
+// identityEvents returns the events of the CLA group mentioning the identity up to the query time, oldest first
+func (s *service) identityEvents(claGroupID, identity string, at time.Time) ([]*models.CoverageAuditEvent, error) {
+	eventList, err := s.eventsRepo.GetClaGroupEvents(claGroupID, nil, nil, true, aws.String(identity))
+	if err != nil {
+		return nil, err
+	}
+	var auditEvents []*models.CoverageAuditEvent
+	if eventList == nil {
+		return auditEvents, nil
+	}
+	sort.SliceStable(eventList.Events, func(i, j int) bool {
+		return eventList.Events[i].EventTimeEpoch < eventList.Events[j].EventTimeEpoch
+	})
+	for _, event := range eventList.Events {
+		if time.Unix(event.EventTimeEpoch, 0).After(at) {
+			continue
+		}
+		auditEvents = append(auditEvents, &models.CoverageAuditEvent{
+			EventID:      event.EventID,
+			EventType:    event.EventType,
+			EventTime:    event.EventTime,
+			EventSummary: event.EventSummary,
+		})
+	}
+	return auditEvents, nil
+}
+
+// collectIdentities returns the queried identity and the emails and usernames of the user
+func collectIdentities(identityType, identity string, userModel *v1Models.User) identities {
+	var ids identities
+	switch identityType {
+	case IdentityTypeEmail:
+		ids.emails = append(ids.emails, identity)
+	case IdentityTypeGitHubUsername:
+		ids.githubUsernames = append(ids.githubUsernames, identity)
+	case IdentityTypeGitLabUsername:
+		ids.gitlabUsernames = append(ids.gitlabUsernames, identity)
+	}
+	if userModel == nil {
+		return ids
+	}
+	ids.emails = append(ids.emails, userModel.Emails...)
+	if userModel.LfEmail != "" {
+		ids.emails = append(ids.emails, string(userModel.LfEmail))
+	}
+	if userModel.GithubUsername != "" {
+		ids.githubUsernames = append(ids.githubUsernames, userModel.GithubUsername)
+	}
+	if userModel.GitlabUsername != "" {
+		ids.gitlabUsernames = append(ids.gitlabUsernames, userModel.GitlabUsername)
+	}
+	return ids
+}
+
+// matchApprovalRule returns the first approval list entry added to the CCLA which matched the identities at the
+// query time, and the organization entries in effect which can't be matched
+func matchApprovalRule(items []approvals.ApprovalItem, ids identities, at time.Time) (*models.CoverageAuditApprovalRule, []string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DateAdded < items[j].DateAdded
+	})
+
+	var orgRules []string
+	for _, item := range items {
+		if !approvalInEffect(item, at) {
+			continue
+		}
+		var matched bool
+		switch item.ApprovalCriteria {
+		case utils.EmailApprovalCriteria:
+			// domain entries were recorded with the email criteria before ApprovalListHistoryReliableFrom
+			if strings.Contains(item.ApprovalName, "@") {
+				matched = containsFold(ids.emails, item.ApprovalName)
+			} else {
+				matched = domainMatches(item.ApprovalName, ids.emails)
+			}
+		case utils.DomainApprovalCriteria:
+			matched = domainMatches(item.ApprovalName, ids.emails)
+		case utils.GithubUsernameApprovalCriteria:
+			matched = containsFold(ids.githubUsernames, item.ApprovalName)
+		case utils.GitlabUsernameApprovalCriteria:
+			matched = containsFold(ids.gitlabUsernames, item.ApprovalName)
+		case utils.GithubOrgApprovalCriteria, utils.GitlabOrgApprovalCriteria:
+			orgRules = append(orgRules, fmt.Sprintf("%s: %s", item.ApprovalCriteria, item.ApprovalName))
+		}
+		if matched {
+			return &models.CoverageAuditApprovalRule{
+				ApprovalID:  item.ApprovalID,
+				SignatureID: item.SignatureID,
+				Criteria:    item.ApprovalCriteria,
+				Value:       item.ApprovalName,
+				DateAdded:   item.DateAdded,
+				DateRemoved: item.DateRemoved,
+				DateExpires: item.DateExpires,
+			}, nil
+		}
+	}
+	return nil, orgRules
+}
+
+// hasUnreliableApprovalHistory returns true if some of the approval list records were written before
+// ApprovalListHistoryReliableFrom
+func hasUnreliableApprovalHistory(items []approvals.ApprovalItem) bool {
+	for _, item := range items {
+		added, err := utils.ParseDateTime(item.DateAdded)
+		if err != nil || added.Before(ApprovalListHistoryReliableFrom) {
+			return true
+		}
+	}
+	return false
+}
+
+// approvalInEffect returns true if the entry was on the approval list at the query time. Entries deactivated without
+// a removal date are considered removed at their modified date.
+func approvalInEffect(item approvals.ApprovalItem, at time.Time) bool {
+	added, err := utils.ParseDateTime(item.DateAdded)
+	if err != nil || added.After(at) {
+		return false
+	}
+	removedDate := item.DateRemoved
+	if removedDate == "" && !item.Active {
+		removedDate = item.DateModified
+	}
+	if removedDate == "" {
+		return true
+	}
+	removed, err := utils.ParseDateTime(removedDate)
+	if err != nil {
+		return false
+	}
+	return at.Before(removed)
+}
+
+func domainMatches(entry string, emails []string) bool {
+	matcher, err := domain_matcher.Compile([]string{entry})
+	if err != nil {
+		return false
+	}
+	for _, email := range emails {
+		if _, ok := matcher.MatchEmail(email); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectSignature returns the signature in effect at the query time, otherwise the most recently signed signature
+// so the auditor can see when the coverage started or ended
+func selectSignature(sigs []*v1Models.Signature, claType, claGroupID string, at time.Time, result *models.CoverageAudit) *models.CoverageAuditSignature {
+	var selected *models.CoverageAuditSignature
+	var selectedNote string
+	for _, sig := range sigs {
+		if sig == nil || !sig.SignatureSigned {
+			continue
+		}
+		auditSignature, note := toAuditSignature(sig, claType, claGroupID, at)
+		switch {
+		case selected == nil,
+			auditSignature.InEffect && !selected.InEffect,
+			auditSignature.InEffect == selected.InEffect && auditSignature.EffectiveFrom > selected.EffectiveFrom:
+			selected, selectedNote = auditSignature, note
+		}
+	}
+	if selectedNote != "" {
+		result.Notes = append(result.Notes, selectedNote)
+	}
+	return selected
+}
+
+// toAuditSignature converts the signature, it returns a note when the invalidation date is taken from the signature
+// modified date
+func toAuditSignature(sig *v1Models.Signature, claType, claGroupID string, at time.Time) (*models.CoverageAuditSignature, string) {
+	auditSignature := &models.CoverageAuditSignature{
+		SignatureID:            sig.SignatureID,
+		ClaType:                claType,
+		SignatureReferenceID:   sig.SignatureReferenceID,
+		SignatureReferenceName: sig.SignatureReferenceName,
+		SignatureApproved:      sig.SignatureApproved,
+	}
+	if sig.SignatureDocumentMajorVersion != "" {
+		auditSignature.DocumentVersion = fmt.Sprintf("%s.%s", sig.SignatureDocumentMajorVersion, sig.SignatureDocumentMinorVersion)
+	}
+	switch claType {
+	case utils.ClaTypeICLA, utils.ClaTypeCCLA:
+		auditSignature.EvidenceLinks = []string{
+			fmt.Sprintf("/v4/signatures/%s/signed-document", sig.SignatureID),
+			fmt.Sprintf("/v4/signatures/project/%s/%s/%s/pdf", claGroupID, claType, sig.SignatureID),
+		}
+	}
+
+	signedAt, signedOK := signedDate(sig)
+	if !signedOK {
+		return auditSignature, fmt.Sprintf("%s: %s has no valid signed date", claType, sig.SignatureID)
+	}
+	auditSignature.EffectiveFrom = utils.TimeToString(signedAt)
+	if signedAt.After(at) {
+		return auditSignature, ""
+	}
+	if sig.SignatureApproved {
+		auditSignature.InEffect = true
+		return auditSignature, ""
+	}
+
+	if sig.SignatureInvalidatedOn != "" {
+		invalidatedAt, err := utils.ParseDateTime(sig.SignatureInvalidatedOn)
+		if err != nil {
+			return auditSignature, fmt.Sprintf("%s: %s is invalidated and has no valid invalidation date", claType, sig.SignatureID)
+		}
+		auditSignature.EffectiveUntil = utils.TimeToString(invalidatedAt)
+		auditSignature.InEffect = at.Before(invalidatedAt)
+		return auditSignature, ""
+	}
+
+	// signatures invalidated before the invalidation date was recorded only have the modified date, which any
+	// later update of the signature moves
+	invalidatedAt, err := utils.ParseDateTime(sig.SignatureModified)
+	if err != nil {
+		return auditSignature, fmt.Sprintf("%s: %s is invalidated and has no valid modified date", claType, sig.SignatureID)
+	}
+	auditSignature.EffectiveUntil = utils.TimeToString(invalidatedAt)
+	auditSignature.InEffect = at.Before(invalidatedAt)
+	return auditSignature, fmt.Sprintf("%s: %s is invalidated without a recorded invalidation date, effectiveUntil is the signature modified date and may be later than the invalidation", claType, sig.SignatureID)
+}
+
+// signedDate returns the docusign signed date, the signed on date or the created date of the signature, older
+// signatures do not have the docusign date
+func signedDate(sig *v1Models.Signature) (time.Time, bool) {
+	for _, value := range []string{sig.UserDocusignDateSigned, sig.SignedOn, sig.SignatureCreated} {
+		if value == "" {
+			continue
+		}
+		if t, err := utils.ParseDateTime(value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,249 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package coverage_audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	mock_events "github.com/linuxfoundation/easycla/cla-backend-go/events/mock"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	v1SignatureParams "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	mock_signatures "github.com/linuxfoundation/easycla/cla-backend-go/signatures/mocks"
+	mock_users "github.com/linuxfoundation/easycla/cla-backend-go/users/mocks"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	mock_approvals "github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testUser = &v1Models.User{
+		UserID:         "user-id",
+		CompanyID:      "company-id",
+		Emails:         []string{"dev@example.com"},
+		GithubUsername: "dev",
+	}
+	testICLAs = []*v1Models.Signature{
+		{SignatureID: "icla-id", SignatureSigned: true, SignatureApproved: false, UserDocusignDateSigned: "2025-01-10T10:00:00Z", SignatureModified: "2025-09-01T00:00:00Z", SignatureInvalidatedOn: "2025-06-01T00:00:00Z", SignatureDocumentMajorVersion: "2", SignatureDocumentMinorVersion: "1"},
+	}
+	testCCLAs = []*v1Models.Signature{
+		{SignatureID: "ccla-id", SignatureSigned: true, SignatureApproved: true, SignatureReferenceID: "company-id", SignedOn: "2025-02-01T00:00:00Z"},
+	}
+	testECLAs = []*v1Models.Signature{
+		{SignatureID: "ecla-other", SignatureSigned: true, SignatureApproved: true, SignatureReferenceID: "other-user-id", SignatureCreated: "2025-01-01T00:00:00Z"},
+		{SignatureID: "ecla-id", SignatureSigned: true, SignatureApproved: true, SignatureReferenceID: "user-id", SignatureCreated: "2025-03-05T00:00:00Z"},
+	}
+	testApprovalItems = []approvals.ApprovalItem{
+		{ApprovalID: "domain", SignatureID: "ccla-id", ApprovalCriteria: "domain", ApprovalName: "*.example.com", DateAdded: "2025-04-01T00:00:00Z", Active: true},
+		{ApprovalID: "email", SignatureID: "ccla-id", ApprovalCriteria: "email", ApprovalName: "Dev@example.com", DateAdded: "2025-03-01T00:00:00Z", DateRemoved: "2025-05-01T00:00:00Z"},
+		{ApprovalID: "org", SignatureID: "ccla-id", ApprovalCriteria: "githubOrg", ApprovalName: "example", DateAdded: "2025-01-15T00:00:00Z", Active: true},
+	}
+	testEvents = []*v1Models.Event{
+		{EventID: "later", EventType: "approval_list.added", EventTimeEpoch: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC).Unix()},
+		{EventID: "earlier", EventType: "individual.signature.signed", EventTimeEpoch: time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC).Unix()},
+	}
+)
+
+func TestGetCoverageICLA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	usersRepo.EXPECT().GetUserByGitHubUsername("dev").Return(testUser, nil)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	signatureRepo.EXPECT().GetIndividualSignatures(gomock.Any(), "cla-group-id", "user-id", nil, aws.Bool(true)).Return(testICLAs, nil)
+	signatureRepo.EXPECT().GetCorporateSignatures(gomock.Any(), "cla-group-id", "company-id", nil, aws.Bool(true)).Return(testCCLAs, nil)
+	signatureRepo.EXPECT().GetProjectCompanyEmployeeSignatures(gomock.Any(), gomock.Any(), nil).Return(&v1Models.Signatures{Signatures: testECLAs}, nil)
+	approvalRepo := mock_approvals.NewMockIRepository(ctrl)
+	approvalRepo.EXPECT().GetApprovalListBySignature("ccla-id").Return(testApprovalItems, nil)
+	eventsRepo := mock_events.NewMockRepository(ctrl)
+	eventsRepo.EXPECT().GetClaGroupEvents("cla-group-id", nil, nil, true, aws.String("dev")).Return(&v1Models.EventList{Events: testEvents}, nil)
+
+	result, err := NewService(usersRepo, signatureRepo, approvalRepo, eventsRepo).GetCoverage(context.Background(), "cla-group-id", Query{
+		IdentityType: IdentityTypeGitHubUsername,
+		Identity:     "dev",
+		At:           time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.Covered)
+	assert.Equal(t, CoveredByICLA, result.CoveredBy)
+	assert.Equal(t, "user-id", result.UserID)
+	if assert.NotNil(t, result.Icla) {
+		assert.True(t, result.Icla.InEffect)
+		assert.Equal(t, "2025-01-10T10:00:00Z", result.Icla.EffectiveFrom)
+		assert.Equal(t, "2025-06-01T00:00:00Z", result.Icla.EffectiveUntil)
+		assert.Equal(t, "2.1", result.Icla.DocumentVersion)
+		assert.Equal(t, []string{
+			"/v4/signatures/icla-id/signed-document",
+			"/v4/signatures/project/cla-group-id/icla/icla-id/pdf",
+		}, result.Icla.EvidenceLinks)
+	}
+	for _, note := range result.Notes {
+		assert.NotContains(t, note, "icla:")
+	}
+	assert.Nil(t, result.ApprovalListRule)
+	if assert.Len(t, result.Events, 1) {
+		assert.Equal(t, "earlier", result.Events[0].EventID)
+	}
+}
+
+func TestGetCoverageCCLA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	usersRepo.EXPECT().GetUserByEmail("dev@example.com").Return(testUser, nil).Times(3)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	signatureRepo.EXPECT().GetIndividualSignatures(gomock.Any(), "cla-group-id", "user-id", nil, aws.Bool(true)).Return(testICLAs, nil).Times(3)
+	signatureRepo.EXPECT().GetCorporateSignatures(gomock.Any(), "cla-group-id", "company-id", nil, aws.Bool(true)).Return(testCCLAs, nil).Times(3)
+	signatureRepo.EXPECT().GetProjectCompanyEmployeeSignatures(gomock.Any(), v1SignatureParams.GetProjectCompanyEmployeeSignaturesParams{
+		CompanyID: "company-id",
+		ProjectID: "cla-group-id",
+		PageSize:  aws.Int64(signatures.HugePageSize),
+	}, nil).Return(&v1Models.Signatures{Signatures: testECLAs}, nil).Times(3)
+	approvalRepo := mock_approvals.NewMockIRepository(ctrl)
+	approvalRepo.EXPECT().GetApprovalListBySignature("ccla-id").Return(testApprovalItems, nil).Times(3)
+	eventsRepo := mock_events.NewMockRepository(ctrl)
+	eventsRepo.EXPECT().GetClaGroupEvents("cla-group-id", nil, nil, true, aws.String("dev@example.com")).Return(&v1Models.EventList{Events: testEvents}, nil).Times(3)
+	s := NewService(usersRepo, signatureRepo, approvalRepo, eventsRepo)
+
+	// the email entry was removed, the domain entry covers the user
+	result, err := s.GetCoverage(context.Background(), "cla-group-id", Query{
+		IdentityType: IdentityTypeEmail,
+		Identity:     "dev@example.com",
+		At:           time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.Covered)
+	assert.Equal(t, CoveredByCCLA, result.CoveredBy)
+	assert.False(t, result.Icla.InEffect)
+	assert.Equal(t, "company-id", result.CompanyID)
+	assert.True(t, result.Ccla.InEffect)
+	assert.Equal(t, "2025-02-01T00:00:00Z", result.Ccla.EffectiveFrom)
+	assert.Equal(t, "/v4/signatures/project/cla-group-id/ccla/ccla-id/pdf", result.Ccla.EvidenceLinks[1])
+	if assert.NotNil(t, result.Ecla) {
+		assert.Equal(t, "ecla-id", result.Ecla.SignatureID)
+		assert.True(t, result.Ecla.InEffect)
+		assert.Empty(t, result.Ecla.EvidenceLinks)
+	}
+	if assert.NotNil(t, result.ApprovalListRule) {
+		assert.Equal(t, "domain", result.ApprovalListRule.ApprovalID)
+	}
+	assert.Contains(t, result.Notes, "CCLA: ccla-id has approval list history records from before 2026-10-18, the entries removed before then may still be shown in effect")
+
+	// the email entry was in effect
+	result, err = s.GetCoverage(context.Background(), "cla-group-id", Query{
+		IdentityType: IdentityTypeEmail,
+		Identity:     "dev@example.com",
+		At:           time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.Covered)
+	assert.Equal(t, "email", result.ApprovalListRule.ApprovalID)
+
+	// before the acknowledgement only the organization rule was in effect
+	result, err = s.GetCoverage(context.Background(), "cla-group-id", Query{
+		IdentityType: IdentityTypeEmail,
+		Identity:     "dev@example.com",
+		At:           time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, CoveredByICLA, result.CoveredBy)
+	assert.Nil(t, result.ApprovalListRule)
+	assert.False(t, result.Ecla.InEffect)
+	assert.Contains(t, result.Notes, "organization rules in effect: githubOrg: example - the organization membership at the time is not recorded")
+}
+
+func TestGetCoverageUnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	usersRepo.EXPECT().GetUserByEmail("contractor@example.com").
+		Return(nil, &utils.UserNotFound{Message: "user not found", UserEmail: "contractor@example.com"}).Times(2)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	signatureRepo.EXPECT().GetCorporateSignatures(gomock.Any(), "cla-group-id", "company-id", nil, aws.Bool(true)).Return(testCCLAs, nil)
+	approvalRepo := mock_approvals.NewMockIRepository(ctrl)
+	approvalRepo.EXPECT().GetApprovalListBySignature("ccla-id").Return(testApprovalItems, nil)
+	eventsRepo := mock_events.NewMockRepository(ctrl)
+	eventsRepo.EXPECT().GetClaGroupEvents("cla-group-id", nil, nil, true, aws.String("contractor@example.com")).Return(&v1Models.EventList{}, nil).Times(2)
+	s := NewService(usersRepo, signatureRepo, approvalRepo, eventsRepo)
+
+	result, err := s.GetCoverage(context.Background(), "cla-group-id", Query{
+		IdentityType: IdentityTypeEmail,
+		Identity:     "contractor@example.com",
+		At:           time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.False(t, result.Covered)
+	assert.Equal(t, CoveredByNone, result.CoveredBy)
+	assert.Empty(t, result.UserID)
+	assert.Nil(t, result.Ccla)
+	assert.Contains(t, result.Notes, "no company is associated with the identity, the corporate coverage was not audited")
+
+	// the approval list is audited for the requested company, the employee acknowledgement is missing
+	result, err = s.GetCoverage(context.Background(), "cla-group-id", Query{
+		IdentityType: IdentityTypeEmail,
+		Identity:     "contractor@example.com",
+		At:           time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		CompanyID:    "company-id",
+	})
+	assert.NoError(t, err)
+	assert.False(t, result.Covered)
+	assert.Equal(t, "domain", result.ApprovalListRule.ApprovalID)
+	assert.Nil(t, result.Ecla)
+
+	_, err = s.GetCoverage(context.Background(), "cla-group-id", Query{IdentityType: "lfid", Identity: "dev", At: time.Now()})
+	assert.Error(t, err)
+}
+
+func TestToAuditSignatureWithoutInvalidationDate(t *testing.T) {
+	sig := &v1Models.Signature{SignatureID: "icla-id", SignatureApproved: false, SignedOn: "2025-01-10T10:00:00Z", SignatureModified: "2025-06-01T00:00:00Z"}
+
+	auditSignature, note := toAuditSignature(sig, utils.ClaTypeICLA, "cla-group-id", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, auditSignature.InEffect)
+	assert.Equal(t, "2025-06-01T00:00:00Z", auditSignature.EffectiveUntil)
+	assert.Equal(t, "icla: icla-id is invalidated without a recorded invalidation date, effectiveUntil is the signature modified date and may be later than the invalidation", note)
+
+	sig.SignatureInvalidatedOn = "2025-03-01T00:00:00Z"
+	auditSignature, note = toAuditSignature(sig, utils.ClaTypeICLA, "cla-group-id", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC))
+	assert.False(t, auditSignature.InEffect)
+	assert.Equal(t, "2025-03-01T00:00:00Z", auditSignature.EffectiveUntil)
+	assert.Empty(t, note)
+}
+
+func TestMatchApprovalRuleHistory(t *testing.T) {
+	ids := identities{emails: []string{"dev@example.com"}}
+	at := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	// domain entries were recorded with the email criteria
+	items := []approvals.ApprovalItem{
+		{ApprovalID: "legacy-domain", ApprovalCriteria: "email", ApprovalName: "example.com", DateAdded: "2025-04-01T00:00:00Z", Active: true},
+	}
+	rule, _ := matchApprovalRule(items, ids, at)
+	if assert.NotNil(t, rule) {
+		assert.Equal(t, "legacy-domain", rule.ApprovalID)
+	}
+	assert.True(t, hasUnreliableApprovalHistory(items))
+
+	items = []approvals.ApprovalItem{
+		{ApprovalID: "domain", ApprovalCriteria: "domain", ApprovalName: "example.com", DateAdded: "2026-11-01T00:00:00Z", Active: true},
+	}
+	assert.False(t, hasUnreliableApprovalHistory(items))
+}
+
+func TestParseTimestamp(t *testing.T) {
+	at, err := ParseTimestamp("2026-03-02T15:00:00+01:00")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC), at)
+
+	at, err = ParseTimestamp("2026-03-02")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), at)
+
+	_, err = ParseTimestamp("yesterday")
+	assert.Error(t, err)
+}
@@ -0,0 +1,261 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/telemetry"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// handler retry settings - the delay doubles after each failed attempt
+const (
+	maxHandlerAttempts    = 3
+	handlerRetryBaseDelay = 250 * time.Millisecond
+	handlerRetryMaxDelay  = 5 * time.Second
+)
+
+// processedEventTTL is how long the keys of the processed records are kept, longer than the 24 hour retention of the
+// DynamoDB streams so a record can't be delivered again once its key expires
+const processedEventTTL = 48 * time.Hour
+
+// eventHandler is a registered handler with the name used to dead letter and replay its records
+type eventHandler struct {
+	name string
+	fn   EventHandlerFunc
+}
+
+// ReplayResult is the result of replaying the dead letter records of a handler
+type ReplayResult struct {
+	Replayed int
+	Failed   int
+}
+
+// eventHandlerName returns the name of the handler method, e.g. UpdateCLAPermissions
+func eventHandlerName(f EventHandlerFunc) string {
+	name := runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// idempotencyKey returns the key of the event record processed by the handler, the stream event ID is unique
+// per record so a record delivered again by the stream maps to the same key
+func idempotencyKey(eventID, handlerName string) string {
+	return fmt.Sprintf("%s:%s", eventID, handlerName)
+}
+
+// retryDelay returns the delay before the next attempt
+func retryDelay(attempt int) time.Duration {
+	delay := handlerRetryBaseDelay << uint(attempt-1)
+	if delay > handlerRetryMaxDelay || delay <= 0 {
+		return handlerRetryMaxDelay
+	}
+	return delay
+}
+
+// invokeWithRetry invokes the handler until it succeeds or the attempts are exhausted, it returns the number of
+// attempts and the error of the last attempt
+func (s *service) invokeWithRetry(f logrus.Fields, handler eventHandler, event events.DynamoDBEventRecord) (int, error) {
+	var err error
+	for attempt := 1; attempt <= maxHandlerAttempts; attempt++ {
+		err = handler.fn(event)
+		if err == nil {
+			return attempt, nil
+		}
+
+		if attempt < maxHandlerAttempts {
+			delay := retryDelay(attempt)
+			log.WithFields(f).WithError(err).Warnf("handler attempt %d of %d failed - retrying in %s", attempt, maxHandlerAttempts, delay)
+			s.sleep(delay)
+		}
+	}
+	return maxHandlerAttempts, err
+}
+
+// processRecord invokes the handler with retries and dead letters the record when the handler still fails
+func (s *service) processRecord(ctx context.Context, tableName string, handler eventHandler, event events.DynamoDBEventRecord) {
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.dead_letter.processRecord",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      tableName,
+		"eventID":        event.EventID,
+		"eventName":      event.EventName,
+		"handlerName":    handler.name,
+	}
+
+	key := idempotencyKey(event.EventID, handler.name)
+	if s.deadLetterRepo != nil {
+		// a failed lookup only skips the check, the handler runs at least once
+		processed, processedErr := s.deadLetterRepo.IsEventProcessed(ctx, key)
+		if processedErr != nil {
+			log.WithFields(f).WithError(processedErr).Warn("unable to check if the event was already processed - invoking handler")
+		} else if processed {
+			log.WithFields(f).Debug("event already processed by the handler - skipping")
+			telemetry.RecordDynamoStreamEvent(tableName, event.EventName, handler.name, telemetry.OutcomeDuplicate)
+			return
+		}
+	}
+
+	log.WithFields(f).Debug("invoking handler")
+	attempts, err := s.invokeWithRetry(f, handler, event)
+	if err == nil {
+		log.WithFields(f).Debug("done with handler")
+		s.markEventProcessed(ctx, f, key)
+		telemetry.RecordDynamoStreamEvent(tableName, event.EventName, handler.name, telemetry.OutcomeSuccess)
+		return
+	}
+
+	log.WithFields(f).WithError(err).Errorf("unable to process event after %d attempts - saving dead letter record", attempts)
+	if s.deadLetterRepo == nil {
+		telemetry.RecordDynamoStreamEvent(tableName, event.EventName, handler.name, telemetry.OutcomeLost)
+		return
+	}
+
+	record, marshalErr := json.Marshal(event)
+	if marshalErr != nil {
+		log.WithFields(f).WithError(marshalErr).Error("unable to marshal event record - record lost")
+		telemetry.RecordDynamoStreamEvent(tableName, event.EventName, handler.name, telemetry.OutcomeLost)
+		return
+	}
+
+	_, now := utils.CurrentTime()
+	deadLetterErr := s.deadLetterRepo.AddDeadLetter(ctx, &DeadLetterRecord{
+		DeadLetterID: key,
+		EventID:      event.EventID,
+		HandlerName:  handler.name,
+		TableName:    tableName,
+		EventName:    event.EventName,
+		Record:       string(record),
+		ErrorMessage: err.Error(),
+		Attempts:     attempts,
+		Status:       DeadLetterStatusPending,
+		DateCreated:  now,
+		DateModified: now,
+	})
+	if deadLetterErr != nil {
+		log.WithFields(f).WithError(deadLetterErr).WithField("record", string(record)).Error("unable to save dead letter record - record lost")
+		telemetry.RecordDynamoStreamEvent(tableName, event.EventName, handler.name, telemetry.OutcomeLost)
+		return
+	}
+	telemetry.RecordDynamoStreamEvent(tableName, event.EventName, handler.name, telemetry.OutcomeDeadLetter)
+}
+
+// markEventProcessed records that the handler processed the record, failures are only logged - the record would be
+// processed again if the stream delivers it again
+func (s *service) markEventProcessed(ctx context.Context, f logrus.Fields, key string) {
+	if s.deadLetterRepo == nil {
+		return
+	}
+	if err := s.deadLetterRepo.MarkEventProcessed(ctx, key, time.Now().Add(processedEventTTL)); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to record the event as processed")
+	}
+}
+
+// findHandler returns the handler registered for the table and event with the given name
+func (s *service) findHandler(tableName, eventName, handlerName string) (eventHandler, bool) {
+	for _, handler := range s.functions[fmt.Sprintf("%s:%s", tableName, eventName)] {
+		if handler.name == handlerName {
+			return handler, true
+		}
+	}
+	return eventHandler{}, false
+}
+
+// ListDeadLetters returns the dead letter records, filtered by status and handler name when provided
+func (s *service) ListDeadLetters(ctx context.Context, status, handlerName string) ([]*DeadLetterRecord, error) {
+	return s.deadLetterRepo.ListDeadLetters(ctx, status, handlerName)
+}
+
+// ReplayDeadLetter invokes the handler of the dead letter record again, the record is marked replayed when the
+// handler succeeds and returns to pending otherwise
+func (s *service) ReplayDeadLetter(ctx context.Context, deadLetterID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.dead_letter.ReplayDeadLetter",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"deadLetterID":   deadLetterID,
+	}
+
+	deadLetter, err := s.deadLetterRepo.GetDeadLetter(ctx, deadLetterID)
+	if err != nil {
+		return err
+	}
+	if deadLetter.Status != DeadLetterStatusPending {
+		return ErrDeadLetterNotPending
+	}
+	f["handlerName"] = deadLetter.HandlerName
+
+	handler, ok := s.findHandler(deadLetter.TableName, deadLetter.EventName, deadLetter.HandlerName)
+	if !ok {
+		return fmt.Errorf("handler %s is not registered for %s events of table %s", deadLetter.HandlerName, deadLetter.EventName, deadLetter.TableName)
+	}
+
+	var event events.DynamoDBEventRecord
+	err = json.Unmarshal([]byte(deadLetter.Record), &event)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to unmarshal dead letter event record")
+		return err
+	}
+
+	err = s.deadLetterRepo.ClaimDeadLetter(ctx, deadLetterID)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(f).Info("replaying dead letter record")
+	_, handlerErr := s.invokeWithRetry(f, handler, event)
+	if handlerErr != nil {
+		log.WithFields(f).WithError(handlerErr).Warn("replay failed - dead letter record returned to pending")
+		if err := s.deadLetterRepo.UpdateDeadLetterStatus(ctx, deadLetterID, DeadLetterStatusPending, handlerErr.Error()); err != nil {
+			return err
+		}
+		return handlerErr
+	}
+
+	s.markEventProcessed(ctx, f, deadLetterID)
+	return s.deadLetterRepo.UpdateDeadLetterStatus(ctx, deadLetterID, DeadLetterStatusReplayed, "")
+}
+
+// ReplayDeadLetters replays the pending dead letter records of the handler
+func (s *service) ReplayDeadLetters(ctx context.Context, handlerName string) (*ReplayResult, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.dead_letter.ReplayDeadLetters",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"handlerName":    handlerName,
+	}
+
+	if handlerName == "" {
+		return nil, fmt.Errorf("the handler name is required")
+	}
+
+	deadLetters, err := s.deadLetterRepo.ListDeadLetters(ctx, DeadLetterStatusPending, handlerName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReplayResult{}
+	for _, deadLetter := range deadLetters {
+		if err := s.ReplayDeadLetter(ctx, deadLetter.DeadLetterID); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to replay dead letter record %s", deadLetter.DeadLetterID)
+			result.Failed++
+			continue
+		}
+		result.Replayed++
+	}
+
+	log.WithFields(f).Infof("replayed %d dead letter records, %d failed", result.Replayed, result.Failed)
+	return result, nil
+}
@@ -0,0 +1,171 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/golang/mock/gomock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/dynamo_events"
+	mock_dynamo_events "github.com/linuxfoundation/easycla/cla-backend-go/v2/dynamo_events/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSignaturesTable = "cla-test-signatures"
+
+func testEvent(eventID string) events.DynamoDBEvent {
+	return events.DynamoDBEvent{
+		Records: []events.DynamoDBEventRecord{
+			{
+				EventID:        eventID,
+				EventName:      dynamo_events.Modify,
+				EventSourceArn: "arn:aws:dynamodb:us-east-1:123456789012:table/" + testSignaturesTable + "/stream/2021-01-01T00:00:00.000",
+				Change: events.DynamoDBStreamRecord{
+					NewImage: map[string]events.DynamoDBAttributeValue{
+						"signature_id": events.NewStringAttribute("sig-1"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestProcessEventsRetriesFailedHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deadLetterRepo := mock_dynamo_events.NewMockDeadLetterRepository(ctrl)
+	gomock.InOrder(
+		deadLetterRepo.EXPECT().IsEventProcessed(gomock.Any(), "event-1:UpdateCLAPermissions").Return(false, nil),
+		deadLetterRepo.EXPECT().MarkEventProcessed(gomock.Any(), "event-1:UpdateCLAPermissions", gomock.Any()).Return(nil),
+	)
+
+	calls := 0
+	var delays []time.Duration
+	// the handler succeeds before the attempts are exhausted, nothing is dead lettered
+	s := dynamo_events.NewTestService(testSignaturesTable, "UpdateCLAPermissions", func(event events.DynamoDBEventRecord) error {
+		calls++
+		if calls < dynamo_events.MaxHandlerAttempts {
+			return errors.New("transient error")
+		}
+		return nil
+	}, deadLetterRepo, func(d time.Duration) { delays = append(delays, d) })
+
+	s.ProcessEvents(testEvent("event-1"))
+
+	assert.Equal(t, dynamo_events.MaxHandlerAttempts, calls)
+	assert.Equal(t, []time.Duration{dynamo_events.HandlerRetryBaseDelay, 2 * dynamo_events.HandlerRetryBaseDelay}, delays)
+}
+
+func TestProcessEventsSkipsProcessedRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deadLetterRepo := mock_dynamo_events.NewMockDeadLetterRepository(ctrl)
+	gomock.InOrder(
+		deadLetterRepo.EXPECT().IsEventProcessed(gomock.Any(), "event-1:UpdateCLAPermissions").Return(false, nil),
+		deadLetterRepo.EXPECT().MarkEventProcessed(gomock.Any(), "event-1:UpdateCLAPermissions", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key string, expires time.Time) error {
+				assert.True(t, expires.After(time.Now().Add(24*time.Hour)), "the key must outlive the stream retention")
+				return nil
+			}),
+		// the stream delivers the processed record again
+		deadLetterRepo.EXPECT().IsEventProcessed(gomock.Any(), "event-1:UpdateCLAPermissions").Return(true, nil),
+		// the check fails, the handler runs anyway
+		deadLetterRepo.EXPECT().IsEventProcessed(gomock.Any(), "event-2:UpdateCLAPermissions").Return(false, errors.New("throttled")),
+		deadLetterRepo.EXPECT().MarkEventProcessed(gomock.Any(), "event-2:UpdateCLAPermissions", gomock.Any()).Return(errors.New("throttled")),
+	)
+
+	var processed []string
+	s := dynamo_events.NewTestService(testSignaturesTable, "UpdateCLAPermissions", func(event events.DynamoDBEventRecord) error {
+		processed = append(processed, event.EventID)
+		return nil
+	}, deadLetterRepo, func(time.Duration) {})
+
+	s.ProcessEvents(testEvent("event-1"))
+	s.ProcessEvents(testEvent("event-1"))
+	s.ProcessEvents(testEvent("event-2"))
+
+	assert.Equal(t, []string{"event-1", "event-2"}, processed)
+}
+
+func TestProcessEventsDeadLettersAndReplays(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	deadLetterID := "event-1:UpdateCLAPermissions"
+
+	var record *dynamo_events.DeadLetterRecord
+	getDeadLetter := func(status string) func(ctx context.Context, deadLetterID string) (*dynamo_events.DeadLetterRecord, error) {
+		return func(ctx context.Context, deadLetterID string) (*dynamo_events.DeadLetterRecord, error) {
+			copied := *record
+			copied.Status = status
+			return &copied, nil
+		}
+	}
+
+	deadLetterRepo := mock_dynamo_events.NewMockDeadLetterRepository(ctrl)
+	gomock.InOrder(
+		// the stream delivers the same record again, the repository keeps the first record
+		deadLetterRepo.EXPECT().IsEventProcessed(gomock.Any(), deadLetterID).Return(false, nil),
+		deadLetterRepo.EXPECT().AddDeadLetter(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, deadLetter *dynamo_events.DeadLetterRecord) error {
+			record = deadLetter
+			return nil
+		}),
+		deadLetterRepo.EXPECT().IsEventProcessed(gomock.Any(), deadLetterID).Return(false, nil),
+		deadLetterRepo.EXPECT().AddDeadLetter(gomock.Any(), gomock.Any()).Return(nil),
+
+		// the replay fails again - the record returns to pending
+		deadLetterRepo.EXPECT().GetDeadLetter(gomock.Any(), deadLetterID).DoAndReturn(getDeadLetter(dynamo_events.DeadLetterStatusPending)),
+		deadLetterRepo.EXPECT().ClaimDeadLetter(gomock.Any(), deadLetterID).Return(nil),
+		deadLetterRepo.EXPECT().UpdateDeadLetterStatus(gomock.Any(), deadLetterID, dynamo_events.DeadLetterStatusPending, "permanent error").Return(nil),
+
+		deadLetterRepo.EXPECT().ListDeadLetters(gomock.Any(), dynamo_events.DeadLetterStatusPending, "UpdateCLAPermissions").
+			DoAndReturn(func(ctx context.Context, status, handlerName string) ([]*dynamo_events.DeadLetterRecord, error) {
+				return []*dynamo_events.DeadLetterRecord{record}, nil
+			}),
+		deadLetterRepo.EXPECT().GetDeadLetter(gomock.Any(), deadLetterID).DoAndReturn(getDeadLetter(dynamo_events.DeadLetterStatusPending)),
+		deadLetterRepo.EXPECT().ClaimDeadLetter(gomock.Any(), deadLetterID).Return(nil),
+		deadLetterRepo.EXPECT().MarkEventProcessed(gomock.Any(), deadLetterID, gomock.Any()).Return(nil),
+		deadLetterRepo.EXPECT().UpdateDeadLetterStatus(gomock.Any(), deadLetterID, dynamo_events.DeadLetterStatusReplayed, "").Return(nil),
+
+		// a replayed record is not replayed again
+		deadLetterRepo.EXPECT().GetDeadLetter(gomock.Any(), deadLetterID).DoAndReturn(getDeadLetter(dynamo_events.DeadLetterStatusReplayed)),
+	)
+
+	fail := true
+	var replayed events.DynamoDBEventRecord
+	s := dynamo_events.NewTestService(testSignaturesTable, "UpdateCLAPermissions", func(event events.DynamoDBEventRecord) error {
+		if fail {
+			return errors.New("permanent error")
+		}
+		replayed = event
+		return nil
+	}, deadLetterRepo, func(time.Duration) {})
+
+	s.ProcessEvents(testEvent("event-1"))
+	s.ProcessEvents(testEvent("event-1"))
+	if assert.NotNil(t, record) {
+		assert.Equal(t, deadLetterID, record.DeadLetterID)
+		assert.Equal(t, dynamo_events.DeadLetterStatusPending, record.Status)
+		assert.Equal(t, dynamo_events.MaxHandlerAttempts, record.Attempts)
+		assert.Equal(t, "permanent error", record.ErrorMessage)
+		assert.Equal(t, testSignaturesTable, record.TableName)
+	}
+
+	assert.Error(t, s.ReplayDeadLetter(ctx, deadLetterID))
+
+	fail = false
+	result, err := s.ReplayDeadLetters(ctx, "UpdateCLAPermissions")
+	assert.NoError(t, err)
+	assert.Equal(t, &dynamo_events.ReplayResult{Replayed: 1}, result)
+	assert.Equal(t, "event-1", replayed.EventID)
+	assert.Equal(t, "sig-1", replayed.Change.NewImage["signature_id"].String())
+
+	assert.Equal(t, dynamo_events.ErrDeadLetterNotPending, s.ReplayDeadLetter(ctx, deadLetterID))
+}
@@ -0,0 +1,339 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// dead letter record status values
+const (
+	DeadLetterStatusPending   = "pending"
+	DeadLetterStatusReplaying = "replaying"
+	DeadLetterStatusReplayed  = "replayed"
+)
+
+// ErrDeadLetterNotFound is returned when the dead letter record does not exist
+var ErrDeadLetterNotFound = errors.New("dead letter record not found")
+
+// ErrDeadLetterNotPending is returned when the dead letter record is already replayed or is being replayed
+var ErrDeadLetterNotPending = errors.New("dead letter record is not pending")
+
+// DeadLetterRecord is a stream record which a handler failed to process after all the retries, the dead letter ID is
+// the idempotency key of the record - the stream event ID and the handler name
+type DeadLetterRecord struct {
+	DeadLetterID string `dynamodbav:"dead_letter_id"`
+	EventID      string `dynamodbav:"event_id"`
+	HandlerName  string `dynamodbav:"handler_name"`
+	TableName    string `dynamodbav:"table_name"`
+	EventName    string `dynamodbav:"event_name"`
+	// Record is the JSON of the DynamoDB stream record
+	Record       string `dynamodbav:"record"`
+	ErrorMessage string `dynamodbav:"error_message"`
+	Attempts     int    `dynamodbav:"attempts"`
+	ReplayCount  int    `dynamodbav:"replay_count"`
+	Status       string `dynamodbav:"status"`
+	DateCreated  string `dynamodbav:"date_created"`
+	DateModified string `dynamodbav:"date_modified"`
+}
+
+// DeadLetterRepository stores the stream records the handlers failed to process, and the idempotency keys of the
+// records they processed so a record delivered again by the stream isn't processed twice
+type DeadLetterRepository interface {
+	AddDeadLetter(ctx context.Context, record *DeadLetterRecord) error
+	GetDeadLetter(ctx context.Context, deadLetterID string) (*DeadLetterRecord, error)
+	ListDeadLetters(ctx context.Context, status, handlerName string) ([]*DeadLetterRecord, error)
+	ClaimDeadLetter(ctx context.Context, deadLetterID string) error
+	UpdateDeadLetterStatus(ctx context.Context, deadLetterID, status, errorMessage string) error
+	IsEventProcessed(ctx context.Context, idempotencyKey string) (bool, error)
+	MarkEventProcessed(ctx context.Context, idempotencyKey string, expires time.Time) error
+}
+
+type deadLetterRepository struct {
+	dynamoDBClient     *dynamodb.DynamoDB
+	tableName          string
+	processedTableName string
+}
+
+// NewDeadLetterRepository creates the repository of the DynamoDB stream dead letter records and processed record keys
+func NewDeadLetterRepository(awsSession *session.Session, stage string) DeadLetterRepository {
+	return &deadLetterRepository{
+		dynamoDBClient:     dynamodb.New(awsSession),
+		tableName:          fmt.Sprintf("cla-%s-dynamo-events-dead-letters", stage),
+		processedTableName: fmt.Sprintf("cla-%s-dynamo-events-processed", stage),
+	}
+}
+
+// AddDeadLetter saves the dead letter record, a record already saved for the same idempotency key - the stream
+// delivered the record again - is left unchanged
+func (repo *deadLetterRepository) AddDeadLetter(ctx context.Context, record *DeadLetterRecord) error {
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.dead_letter_repository.AddDeadLetter",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"deadLetterID":   record.DeadLetterID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(record)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal dead letter record")
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.tableName),
+		ConditionExpression: aws.String("attribute_not_exists(dead_letter_id)"),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Debug("dead letter record already saved for the event and handler")
+			return nil
+		}
+		log.WithFields(f).WithError(err).Warn("unable to save dead letter record")
+		return err
+	}
+
+	return nil
+}
+
+// GetDeadLetter returns the dead letter record
+func (repo *deadLetterRepository) GetDeadLetter(ctx context.Context, deadLetterID string) (*DeadLetterRecord, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.dead_letter_repository.GetDeadLetter",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"deadLetterID":   deadLetterID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"dead_letter_id": {S: aws.String(deadLetterID)},
+		},
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load dead letter record")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrDeadLetterNotFound
+	}
+
+	var record DeadLetterRecord
+	err = dynamodbattribute.UnmarshalMap(result.Item, &record)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to unmarshal dead letter record")
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// ListDeadLetters returns the dead letter records, filtered by status and handler name when provided
+func (repo *deadLetterRepository) ListDeadLetters(ctx context.Context, status, handlerName string) ([]*DeadLetterRecord, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.dead_letter_repository.ListDeadLetters",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"status":         status,
+		"handlerName":    handlerName,
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		TableName: aws.String(repo.tableName),
+	}
+
+	var filter expression.ConditionBuilder
+	var filterAdded bool
+	if status != "" {
+		filter = expression.Name("status").Equal(expression.Value(status))
+		filterAdded = true
+	}
+	if handlerName != "" {
+		handlerFilter := expression.Name("handler_name").Equal(expression.Value(handlerName))
+		if filterAdded {
+			filter = filter.And(handlerFilter)
+		} else {
+			filter = handlerFilter
+			filterAdded = true
+		}
+	}
+	if filterAdded {
+		expr, err := expression.NewBuilder().WithFilter(filter).Build()
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to build dead letter scan expression")
+			return nil, err
+		}
+		scanInput.ExpressionAttributeNames = expr.Names()
+		scanInput.ExpressionAttributeValues = expr.Values()
+		scanInput.FilterExpression = expr.Filter()
+	}
+
+	var records []*DeadLetterRecord
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to scan dead letter records")
+			return nil, err
+		}
+
+		var page []*DeadLetterRecord
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to unmarshal dead letter records")
+			return nil, err
+		}
+		records = append(records, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return records, nil
+}
+
+// ClaimDeadLetter moves the pending dead letter record to replaying, the record can only be claimed by one replay
+func (repo *deadLetterRepository) ClaimDeadLetter(ctx context.Context, deadLetterID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.dead_letter_repository.ClaimDeadLetter",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"deadLetterID":   deadLetterID,
+	}
+
+	_, now := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"dead_letter_id": {S: aws.String(deadLetterID)},
+		},
+		ConditionExpression: aws.String("#S = :pending"),
+		UpdateExpression:    aws.String("SET #S = :replaying, #M = :modified ADD #R :one"),
+		ExpressionAttributeNames: map[string]*string{
+			"#S": aws.String("status"),
+			"#M": aws.String("date_modified"),
+			"#R": aws.String("replay_count"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":pending":   {S: aws.String(DeadLetterStatusPending)},
+			":replaying": {S: aws.String(DeadLetterStatusReplaying)},
+			":modified":  {S: aws.String(now)},
+			":one":       {N: aws.String("1")},
+		},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrDeadLetterNotPending
+		}
+		log.WithFields(f).WithError(err).Warn("unable to claim dead letter record")
+		return err
+	}
+
+	return nil
+}
+
+// UpdateDeadLetterStatus updates the status of the dead letter record, the error message is kept when empty
+func (repo *deadLetterRepository) UpdateDeadLetterStatus(ctx context.Context, deadLetterID, status, errorMessage string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.dead_letter_repository.UpdateDeadLetterStatus",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"deadLetterID":   deadLetterID,
+		"status":         status,
+	}
+
+	_, now := utils.CurrentTime()
+	updateExpression := "SET #S = :status, #M = :modified"
+	names := map[string]*string{
+		"#S": aws.String("status"),
+		"#M": aws.String("date_modified"),
+	}
+	values := map[string]*dynamodb.AttributeValue{
+		":status":   {S: aws.String(status)},
+		":modified": {S: aws.String(now)},
+	}
+	if errorMessage != "" {
+		updateExpression += ", #E = :error"
+		names["#E"] = aws.String("error_message")
+		values[":error"] = &dynamodb.AttributeValue{S: aws.String(errorMessage)}
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"dead_letter_id": {S: aws.String(deadLetterID)},
+		},
+		UpdateExpression:          aws.String(updateExpression),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update dead letter record status")
+		return err
+	}
+
+	return nil
+}
+
+// IsEventProcessed returns true if the stream record of the idempotency key - the stream event ID and the handler
+// name - was already processed by the handler
+func (repo *deadLetterRepository) IsEventProcessed(ctx context.Context, idempotencyKey string) (bool, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.dead_letter_repository.IsEventProcessed",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"idempotencyKey": idempotencyKey,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.processedTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"idempotency_key": {S: aws.String(idempotencyKey)},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load processed event record")
+		return false, err
+	}
+
+	return len(result.Item) != 0, nil
+}
+
+// MarkEventProcessed records that the handler processed the stream record of the idempotency key, the record is
+// removed by the table TTL once it expires
+func (repo *deadLetterRepository) MarkEventProcessed(ctx context.Context, idempotencyKey string, expires time.Time) error {
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.dead_letter_repository.MarkEventProcessed",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"idempotencyKey": idempotencyKey,
+	}
+
+	_, now := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(repo.processedTableName),
+		Item: map[string]*dynamodb.AttributeValue{
+			"idempotency_key": {S: aws.String(idempotencyKey)},
+			"date_created":    {S: aws.String(now)},
+			"expires":         {N: aws.String(strconv.FormatInt(expires.Unix(), 10))},
+		},
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save processed event record")
+		return err
+	}
+
+	return nil
+}
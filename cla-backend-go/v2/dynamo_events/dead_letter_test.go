@@ -0,0 +1,23 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventHandlerName(t *testing.T) {
+	s := &service{}
+	assert.Equal(t, "UpdateCLAPermissions", eventHandlerName(s.UpdateCLAPermissions))
+	assert.Equal(t, "EventAddedEvent", eventHandlerName(s.EventAddedEvent))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, handlerRetryBaseDelay, retryDelay(1))
+	assert.Equal(t, 4*handlerRetryBaseDelay, retryDelay(3))
+	assert.Equal(t, handlerRetryMaxDelay, retryDelay(20))
+	assert.Equal(t, handlerRetryMaxDelay, retryDelay(100))
+}
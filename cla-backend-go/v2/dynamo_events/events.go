@@ -8,6 +8,7 @@ import (
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 	v2ProjectService "github.com/linuxfoundation/easycla/cla-backend-go/v2/project-service"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/webhooks"
 	"github.com/sirupsen/logrus"
 )
 
@@ -17,6 +18,15 @@ type Event struct {
 	EventProjectID  string `json:"event_project_id"`
 	EventCompanyID  string `json:"event_company_id"`
 	EventCLAGroupID string `json:"event_cla_group_id"`
+
+	EventType         string `json:"event_type"`
+	EventTime         string `json:"event_time"`
+	EventSummary      string `json:"event_summary"`
+	EventData         string `json:"event_data"`
+	EventCLAGroupName string `json:"event_cla_group_name"`
+	EventCompanyName  string `json:"event_company_name"`
+	EventUserName     string `json:"event_user_name"`
+	EventLfUsername   string `json:"event_lf_username"`
 }
 
 // should be called when we insert Event
@@ -59,5 +69,29 @@ func (s *service) EventAddedEvent(event events.DynamoDBEventRecord) error {
 	if err != nil {
 		return err
 	}
+
+	if s.webhookService != nil {
+		// the deliveries are logged by the webhook service and derived from the stream record, a failure to load the
+		// subscriptions or to save a delivery retries the record
+		return s.webhookService.DispatchEvent(ctx, &webhooks.Event{
+			RecordID:       event.EventID,
+			RecordTime:     event.Change.ApproximateCreationDateTime.Time,
+			EventID:        newEvent.EventID,
+			EventType:      newEvent.EventType,
+			EventTime:      newEvent.EventTime,
+			EventSummary:   newEvent.EventSummary,
+			EventData:      newEvent.EventData,
+			FoundationSFID: foundationSFID,
+			ProjectSFID:    projectSFID,
+			ProjectName:    projectSFName,
+			CLAGroupID:     newEvent.EventCLAGroupID,
+			CLAGroupName:   newEvent.EventCLAGroupName,
+			CompanyID:      newEvent.EventCompanyID,
+			CompanySFID:    companySFID,
+			CompanyName:    newEvent.EventCompanyName,
+			UserName:       newEvent.EventUserName,
+			LfUsername:     newEvent.EventLfUsername,
+		})
+	}
 	return nil
 }
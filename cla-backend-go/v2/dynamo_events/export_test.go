@@ -0,0 +1,26 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"time"
+)
+
+// handler retry settings exposed to the tests
+const (
+	MaxHandlerAttempts    = maxHandlerAttempts
+	HandlerRetryBaseDelay = handlerRetryBaseDelay
+)
+
+// NewTestService returns the service with the handler registered for the modify events of the table, the dead letter
+// repository and the sleep of the tests
+func NewTestService(tableName, handlerName string, handler EventHandlerFunc, deadLetterRepo DeadLetterRepository, sleep func(time.Duration)) Service {
+	return &service{
+		functions: map[string][]eventHandler{
+			tableName + ":" + Modify: {{name: handlerName, fn: handler}},
+		},
+		deadLetterRepo: deadLetterRepo,
+		sleep:          sleep,
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/notification_digest"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// SignatureDigestEvent adds the new employee acknowledgement to the digest of the CLA managers of the company who
+// prefer the daily or weekly digest - there is no immediate email for the new signatures
+func (s *service) SignatureDigestEvent(event events.DynamoDBEventRecord) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "SignatureDigestEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	if s.digestService == nil {
+		return nil
+	}
+
+	var newSignature, oldSignature Signature
+	err := unmarshalStreamImage(event.Change.NewImage, &newSignature)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem decoding post-update signature")
+		return err
+	}
+	if event.EventName == Modify {
+		err = unmarshalStreamImage(event.Change.OldImage, &oldSignature)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decoding pre-update signature")
+			return err
+		}
+	}
+
+	// only the employee acknowledgements which are now signed and approved
+	if newSignature.SignatureType != CLASignatureType || newSignature.SignatureUserCompanyID == "" ||
+		!newSignature.SignatureSigned || !newSignature.SignatureApproved || oldSignature.SignatureSigned {
+		return nil
+	}
+
+	f["signatureID"] = newSignature.SignatureID
+	f["companyID"] = newSignature.SignatureUserCompanyID
+	f["claGroupID"] = newSignature.SignatureProjectID
+
+	approved, signed := true, true
+	cclaSignature, err := s.signatureRepo.GetProjectCompanySignature(ctx, newSignature.SignatureUserCompanyID, newSignature.SignatureProjectID, &approved, &signed, nil, aws.Int64(5))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the corporate signature of the company")
+		return err
+	}
+	if cclaSignature == nil || len(cclaSignature.SignatureACL) == 0 {
+		log.WithFields(f).Debug("no CLA managers for the company - nothing to add to the digests")
+		return nil
+	}
+
+	companyModel, err := s.companyRepo.GetCompany(ctx, newSignature.SignatureUserCompanyID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the company of the signature")
+		return err
+	}
+	claGroupModel, err := s.projectRepo.GetCLAGroupByID(ctx, newSignature.SignatureProjectID, false)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA group of the signature")
+		return err
+	}
+
+	message := fmt.Sprintf("%s acknowledged the corporate CLA", signatureUserDisplayName(newSignature))
+	for _, manager := range cclaSignature.SignatureACL {
+		if manager.LfEmail == "" {
+			continue
+		}
+		s.digestService.QueueNotification(ctx, &notification_digest.Notification{
+			RecipientEmail:   manager.LfEmail.String(),
+			RecipientName:    manager.Username,
+			NotificationType: notification_digest.NotificationTypeSignature,
+			CompanyID:        companyModel.CompanyID,
+			CompanyName:      companyModel.CompanyName,
+			CLAGroupID:       claGroupModel.ProjectID,
+			CLAGroupName:     claGroupModel.ProjectName,
+			Message:          message,
+		})
+	}
+
+	return nil
+}
+
+// signatureUserDisplayName returns the best name of the signer for the digest
+func signatureUserDisplayName(signature Signature) string {
+	name := signature.UserName
+	if name == "" {
+		name = signature.SignatureReferenceName
+	}
+	for _, id := range []string{signature.UserEmail, signature.UserGithubUsername, signature.UserLFUsername} {
+		if id != "" {
+			if name == "" {
+				return id
+			}
+			return fmt.Sprintf("%s (%s)", name, id)
+		}
+	}
+	if name == "" {
+		return signature.SignatureReferenceID
+	}
+	return name
+}
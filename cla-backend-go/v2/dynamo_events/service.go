@@ -4,12 +4,12 @@
 package dynamo_events
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"reflect"
-	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
 	service2 "github.com/linuxfoundation/easycla/cla-backend-go/project/service"
@@ -32,9 +32,12 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/notification_digest"
 	v2Company "github.com/linuxfoundation/easycla/cla-backend-go/v2/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/webhooks"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 
 	"github.com/sirupsen/logrus"
 
@@ -57,7 +60,7 @@ type EventHandlerFunc func(event events.DynamoDBEventRecord) error
 
 type service struct {
 	// key : tablename:action
-	functions                map[string][]EventHandlerFunc
+	functions                map[string][]eventHandler
 	signatureRepo            signatures.SignatureRepository
 	companyRepo              company.IRepository
 	companyService           v2Company.Service
@@ -75,11 +78,18 @@ type service struct {
 	claManagerRequestsRepo   cla_manager.IRepository
 	approvalListRequestsRepo approval_list.IRepository
 	gitLabApp                *gitlab_api.App
+	deadLetterRepo           DeadLetterRepository
+	digestService            notification_digest.Service
+	webhookService           webhooks.Service
+	sleep                    func(time.Duration)
 }
 
 // Service implements DynamoDB stream event handler service
 type Service interface {
 	ProcessEvents(event events.DynamoDBEvent)
+	ListDeadLetters(ctx context.Context, status, handlerName string) ([]*DeadLetterRecord, error)
+	ReplayDeadLetter(ctx context.Context, deadLetterID string) error
+	ReplayDeadLetters(ctx context.Context, handlerName string) (*ReplayResult, error)
 }
 
 // NewService creates DynamoDB stream event handler service
@@ -99,7 +109,10 @@ func NewService(stage string,
 	claManagerRequestsRepo cla_manager.IRepository,
 	approvalListRequestsRepo approval_list.IRepository,
 	gitLabApp *gitlab_api.App,
-	gitlabOrgService gitlab_organizations.ServiceInterface) Service {
+	gitlabOrgService gitlab_organizations.ServiceInterface,
+	deadLetterRepo DeadLetterRepository,
+	digestService notification_digest.Service,
+	webhookService webhooks.Service) Service {
 
 	signaturesTable := fmt.Sprintf("cla-%s-signatures", stage)
 	eventsTable := fmt.Sprintf("cla-%s-events", stage)
@@ -111,7 +124,7 @@ func NewService(stage string,
 	claGroupsTable := fmt.Sprintf("cla-%s-projects", stage)
 
 	s := &service{
-		functions:                make(map[string][]EventHandlerFunc),
+		functions:                make(map[string][]eventHandler),
 		signatureRepo:            signatureRepo,
 		companyRepo:              companyRepo,
 		companyService:           companyService,
@@ -129,6 +142,10 @@ func NewService(stage string,
 		approvalListRequestsRepo: approvalListRequestsRepo,
 		gitLabApp:                gitLabApp,
 		gitLabOrgService:         gitlabOrgService,
+		deadLetterRepo:           deadLetterRepo,
+		digestService:            digestService,
+		webhookService:           webhookService,
+		sleep:                    time.Sleep,
 	}
 
 	s.registerCallback(signaturesTable, Modify, s.SignatureSignedEvent)
@@ -138,6 +155,9 @@ func NewService(stage string,
 	s.registerCallback(signaturesTable, Insert, s.SignatureAddUsersDetails)
 	// Add or Remove any CLA Permissions
 	s.registerCallback(signaturesTable, Modify, s.UpdateCLAPermissions)
+	// Add the new employee acknowledgements to the CLA manager digests
+	s.registerCallback(signaturesTable, Insert, s.SignatureDigestEvent)
+	s.registerCallback(signaturesTable, Modify, s.SignatureDigestEvent)
 
 	s.registerCallback(eventsTable, Insert, s.EventAddedEvent)
 
@@ -179,19 +199,23 @@ func NewService(stage string,
 func (s *service) registerCallback(tableName, eventName string, callbackFunction EventHandlerFunc) {
 	key := fmt.Sprintf("%s:%s", tableName, eventName)
 	funcArr := s.functions[key]
-	funcArr = append(funcArr, callbackFunction)
+	funcArr = append(funcArr, eventHandler{name: eventHandlerName(callbackFunction), fn: callbackFunction})
 	s.functions[key] = funcArr
 }
 
+// ProcessEvents invokes the handlers registered for each event record, the failed handlers are retried and the
+// record is saved to the dead letter table when the handler still fails so that it can be replayed
 func (s *service) ProcessEvents(dynamoDBEvents events.DynamoDBEvent) {
+	ctx := utils.NewContext()
 	for _, event := range dynamoDBEvents.Records {
 		tableName := strings.Split(event.EventSourceArn, "/")[1]
 		fields := logrus.Fields{
-			"functionName": "dynamo_events.ProcessEvents",
-			"table_name":   tableName,
-			"eventID":      event.EventID,
-			"eventName":    event.EventName,
-			"eventSource":  event.EventSource,
+			"functionName":   "dynamo_events.ProcessEvents",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"table_name":     tableName,
+			"eventID":        event.EventID,
+			"eventName":      event.EventName,
+			"eventSource":    event.EventSource,
 			// Dumping the event is super verbose
 			// "event":      event,
 		}
@@ -207,31 +231,11 @@ func (s *service) ProcessEvents(dynamoDBEvents events.DynamoDBEvent) {
 			wg.Add(len(s.functions[key]))
 
 			// For each function handler...
-			for _, eventHandlerFunction := range s.functions[key] {
-				go func(f EventHandlerFunc, e events.DynamoDBEventRecord) {
+			for _, handler := range s.functions[key] {
+				go func(h eventHandler, e events.DynamoDBEventRecord) {
 					defer wg.Done()
-
-					fnType := runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
-					log.WithFields(fields).
-						WithField("key", key).
-						WithField("functionType", fnType).
-						Debug("invoking handler")
-
-					err := f(event)
-					if err != nil {
-						log.WithFields(fields).
-							WithField("key", key).
-							WithField("functionType", fnType).
-							WithError(err).
-							WithField("event", e).
-							Error("unable to process event")
-					}
-
-					log.WithFields(fields).
-						WithField("key", key).
-						WithField("functionType", fnType).
-						Debug("done with handler")
-				}(eventHandlerFunction, event)
+					s.processRecord(ctx, tableName, h, e)
+				}(handler, event)
 			}
 
 			// Wait until the registered handlers/functions have completed for this event type...
@@ -0,0 +1,244 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package email_templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/email_templates"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service, eventService events.Service) {
+	api.EmailTemplatesListEmailTemplatesHandler = email_templates.ListEmailTemplatesHandlerFunc(func(params email_templates.ListEmailTemplatesParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		return email_templates.NewListEmailTemplatesOK().WithXRequestID(reqID).WithPayload(service.ListEmailTemplates(ctx))
+	})
+
+	api.EmailTemplatesValidateEmailTemplateOverrideHandler = email_templates.ValidateEmailTemplateOverrideHandlerFunc(func(params email_templates.ValidateEmailTemplateOverrideParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.email_templates.handlers.EmailTemplatesValidateEmailTemplateOverrideHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"templateName":   params.Body.TemplateName,
+		}
+
+		result, err := service.ValidateEmailTemplateOverride(ctx, params.Body)
+		if err != nil {
+			msg := fmt.Sprintf("invalid override of the email template: %s", params.Body.TemplateName)
+			log.WithFields(f).WithError(err).Debug(msg)
+			return email_templates.NewValidateEmailTemplateOverrideBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		return email_templates.NewValidateEmailTemplateOverrideOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.EmailTemplatesListEmailTemplateOverridesHandler = email_templates.ListEmailTemplateOverridesHandlerFunc(func(params email_templates.ListEmailTemplateOverridesParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.email_templates.handlers.EmailTemplatesListEmailTemplateOverridesHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"scope":          params.Scope,
+			"scopeID":        params.ScopeID,
+		}
+
+		scopeProjects, err := service.GetScopeProjects(ctx, params.Scope, params.ScopeID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the %s: %s", params.Scope, params.ScopeID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return email_templates.NewListEmailTemplateOverridesBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+		if !isUserAuthorizedForScope(ctx, authUser, scopeProjects) {
+			msg := fmt.Sprintf("user %s does not have access to list the email template overrides of the %s: %s",
+				authUser.UserName, params.Scope, params.ScopeID)
+			log.WithFields(f).Debug(msg)
+			return email_templates.NewListEmailTemplateOverridesForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.ListEmailTemplateOverrides(ctx, params.Scope, params.ScopeID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to list the email template overrides of the %s: %s", params.Scope, params.ScopeID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return email_templates.NewListEmailTemplateOverridesBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		return email_templates.NewListEmailTemplateOverridesOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.EmailTemplatesSaveEmailTemplateOverrideHandler = email_templates.SaveEmailTemplateOverrideHandlerFunc(func(params email_templates.SaveEmailTemplateOverrideParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.email_templates.handlers.EmailTemplatesSaveEmailTemplateOverrideHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"scope":          params.Scope,
+			"scopeID":        params.ScopeID,
+			"templateName":   params.TemplateName,
+		}
+
+		scopeProjects, err := service.GetScopeProjects(ctx, params.Scope, params.ScopeID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the %s: %s", params.Scope, params.ScopeID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrCLAGroupNotFound) {
+				return email_templates.NewSaveEmailTemplateOverrideNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return email_templates.NewSaveEmailTemplateOverrideBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+		if !isUserAuthorizedForScope(ctx, authUser, scopeProjects) {
+			msg := fmt.Sprintf("user %s does not have access to save the email template override of the %s: %s",
+				authUser.UserName, params.Scope, params.ScopeID)
+			log.WithFields(f).Debug(msg)
+			return email_templates.NewSaveEmailTemplateOverrideForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.SaveEmailTemplateOverride(ctx, params.Scope, params.ScopeID, params.TemplateName, authUser.UserName, params.Body)
+		if err != nil {
+			msg := fmt.Sprintf("unable to save the override of the email template: %s", params.TemplateName)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, emails.ErrUnknownTemplate) {
+				return email_templates.NewSaveEmailTemplateOverrideNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return email_templates.NewSaveEmailTemplateOverrideBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		eventService.LogEventWithContext(ctx, scopeEventArgs(authUser.UserName, events.EmailTemplateOverrideSaved, params.Scope, params.ScopeID,
+			&events.EmailTemplateOverrideEventData{
+				Scope:        params.Scope,
+				ScopeID:      params.ScopeID,
+				TemplateName: params.TemplateName,
+				Locale:       result.Locale,
+				Action:       "saved",
+			}))
+
+		return email_templates.NewSaveEmailTemplateOverrideOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.EmailTemplatesDeleteEmailTemplateOverrideHandler = email_templates.DeleteEmailTemplateOverrideHandlerFunc(func(params email_templates.DeleteEmailTemplateOverrideParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		var locale string
+		if params.Locale != nil {
+			locale = *params.Locale
+		}
+		locale = emails.NormalizeLocale(locale)
+		f := logrus.Fields{
+			"functionName":   "v2.email_templates.handlers.EmailTemplatesDeleteEmailTemplateOverrideHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"scope":          params.Scope,
+			"scopeID":        params.ScopeID,
+			"templateName":   params.TemplateName,
+			"locale":         locale,
+		}
+
+		scopeProjects, err := service.GetScopeProjects(ctx, params.Scope, params.ScopeID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the %s: %s", params.Scope, params.ScopeID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrCLAGroupNotFound) {
+				return email_templates.NewDeleteEmailTemplateOverrideNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return email_templates.NewDeleteEmailTemplateOverrideBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+		if !isUserAuthorizedForScope(ctx, authUser, scopeProjects) {
+			msg := fmt.Sprintf("user %s does not have access to delete the email template override of the %s: %s",
+				authUser.UserName, params.Scope, params.ScopeID)
+			log.WithFields(f).Debug(msg)
+			return email_templates.NewDeleteEmailTemplateOverrideForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		err = service.DeleteEmailTemplateOverride(ctx, params.Scope, params.ScopeID, params.TemplateName, locale)
+		if err != nil {
+			msg := fmt.Sprintf("unable to delete the override of the email template: %s", params.TemplateName)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, emails.ErrTemplateOverrideNotFound) {
+				return email_templates.NewDeleteEmailTemplateOverrideNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return email_templates.NewDeleteEmailTemplateOverrideBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		eventService.LogEventWithContext(ctx, scopeEventArgs(authUser.UserName, events.EmailTemplateOverrideDeleted, params.Scope, params.ScopeID,
+			&events.EmailTemplateOverrideEventData{
+				Scope:        params.Scope,
+				ScopeID:      params.ScopeID,
+				TemplateName: params.TemplateName,
+				Locale:       locale,
+				Action:       "deleted",
+			}))
+
+		return email_templates.NewDeleteEmailTemplateOverrideNoContent().WithXRequestID(reqID)
+	})
+}
+
+// scopeEventArgs returns the event args of the CLA group or the foundation of the override
+func scopeEventArgs(lfUsername, eventType, scope, scopeID string, eventData events.EventData) *events.LogEventArgs {
+	args := &events.LogEventArgs{
+		LfUsername: lfUsername,
+		EventType:  eventType,
+		EventData:  eventData,
+	}
+	if scope == emails.TemplateScopeCLAGroup {
+		args.CLAGroupID = scopeID
+	} else {
+		args.ProjectSFID = scopeID
+	}
+	return args
+}
+
+// isUserAuthorizedForScope returns true if the user is authorized for the foundation tree of the scope, or for every
+// project of the CLA group - the overrides apply to all the projects of the CLA group
+func isUserAuthorizedForScope(ctx context.Context, authUser *auth.User, scopeProjects *ScopeProjects) bool {
+	if scopeProjects.FoundationSFID != "" && utils.IsUserAuthorizedForProjectTree(ctx, authUser, scopeProjects.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+		return true
+	}
+	if len(scopeProjects.ProjectSFIDs) == 0 {
+		return false
+	}
+	for _, projectSFID := range scopeProjects.ProjectSFIDs {
+		if !utils.IsUserAuthorizedForProject(ctx, authUser, projectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			return false
+		}
+	}
+	return true
+}
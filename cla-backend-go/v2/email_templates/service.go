@@ -0,0 +1,201 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package email_templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrCLAGroupNotFound is returned when the CLA group of the override scope has no projects
+var ErrCLAGroupNotFound = errors.New("cla group not found")
+
+// Service contains the functions to manage the email template overrides of the CLA groups and foundations
+type Service interface {
+	ListEmailTemplates(ctx context.Context) *models.EmailTemplateList
+	ValidateEmailTemplateOverride(ctx context.Context, input *models.EmailTemplateValidateInput) (*models.EmailTemplatePreview, error)
+	GetScopeProjects(ctx context.Context, scope, scopeID string) (*ScopeProjects, error)
+	ListEmailTemplateOverrides(ctx context.Context, scope, scopeID string) (*models.EmailTemplateOverrideList, error)
+	SaveEmailTemplateOverride(ctx context.Context, scope, scopeID, templateName, createdBy string, input *models.EmailTemplateOverrideInput) (*models.EmailTemplateOverride, error)
+	DeleteEmailTemplateOverride(ctx context.Context, scope, scopeID, templateName, locale string) error
+}
+
+// ScopeProjects are the projects of the override scope - the foundation of the scope and the projects of the CLA
+// group, the projects are empty for the foundation scope
+type ScopeProjects struct {
+	FoundationSFID string
+	ProjectSFIDs   []string
+}
+
+type service struct {
+	overrideRepo        emails.TemplateOverrideRepository
+	projectClaGroupRepo projects_cla_groups.Repository
+}
+
+// NewService creates a new email template service
+func NewService(overrideRepo emails.TemplateOverrideRepository, projectClaGroupRepo projects_cla_groups.Repository) Service {
+	return service{
+		overrideRepo:        overrideRepo,
+		projectClaGroupRepo: projectClaGroupRepo,
+	}
+}
+
+// ListEmailTemplates returns the names and default bodies of the email templates which can be overridden
+func (s service) ListEmailTemplates(ctx context.Context) *models.EmailTemplateList {
+	result := &models.EmailTemplateList{Templates: []*models.EmailTemplate{}}
+	for _, name := range emails.TemplateNames() {
+		body, _ := emails.DefaultTemplateBody(name)
+		result.Templates = append(result.Templates, &models.EmailTemplate{
+			TemplateName: name,
+			DefaultBody:  body,
+		})
+	}
+	return result
+}
+
+// ValidateEmailTemplateOverride renders the subject and body with the sample params of the template
+func (s service) ValidateEmailTemplateOverride(ctx context.Context, input *models.EmailTemplateValidateInput) (*models.EmailTemplatePreview, error) {
+	if input.Subject == "" && input.Body == "" {
+		return nil, errors.New("the subject or the body of the template override is required")
+	}
+
+	preview, err := emails.ValidateTemplateOverride(input.TemplateName, input.Subject, input.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.EmailTemplatePreview{
+		TemplateName: input.TemplateName,
+		Subject:      preview.Subject,
+		Body:         preview.Body,
+	}, nil
+}
+
+// GetScopeProjects returns the projects the user must be authorized for to manage the overrides of the scope, the
+// foundation and the projects of the CLA group or the foundation itself
+func (s service) GetScopeProjects(ctx context.Context, scope, scopeID string) (*ScopeProjects, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.email_templates.service.GetScopeProjects",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scope":          scope,
+		"scopeID":        scopeID,
+	}
+
+	switch scope {
+	case emails.TemplateScopeFoundation:
+		return &ScopeProjects{FoundationSFID: scopeID}, nil
+	case emails.TemplateScopeCLAGroup:
+		pcgs, err := s.projectClaGroupRepo.GetProjectsIdsForClaGroup(ctx, scopeID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load the projects of the CLA group")
+			return nil, err
+		}
+		if len(pcgs) == 0 {
+			return nil, ErrCLAGroupNotFound
+		}
+		scopeProjects := &ScopeProjects{
+			FoundationSFID: pcgs[0].FoundationSFID,
+			ProjectSFIDs:   make([]string, 0, len(pcgs)),
+		}
+		for _, pcg := range pcgs {
+			scopeProjects.ProjectSFIDs = append(scopeProjects.ProjectSFIDs, pcg.ProjectSFID)
+		}
+		return scopeProjects, nil
+	default:
+		return nil, fmt.Errorf("invalid email template override scope: %s", scope)
+	}
+}
+
+// ListEmailTemplateOverrides returns the template overrides of the CLA group or foundation
+func (s service) ListEmailTemplateOverrides(ctx context.Context, scope, scopeID string) (*models.EmailTemplateOverrideList, error) {
+	overrides, err := s.overrideRepo.ListTemplateOverrides(ctx, scope, scopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.EmailTemplateOverrideList{Overrides: []*models.EmailTemplateOverride{}}
+	for _, override := range overrides {
+		result.Overrides = append(result.Overrides, toEmailTemplateOverrideModel(override))
+	}
+	return result, nil
+}
+
+// SaveEmailTemplateOverride validates and saves the template override of the locale, an existing override of the
+// locale is replaced
+func (s service) SaveEmailTemplateOverride(ctx context.Context, scope, scopeID, templateName, createdBy string, input *models.EmailTemplateOverrideInput) (*models.EmailTemplateOverride, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.email_templates.service.SaveEmailTemplateOverride",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scope":          scope,
+		"scopeID":        scopeID,
+		"templateName":   templateName,
+		"locale":         input.Locale,
+	}
+
+	if input.Subject == "" && input.Body == "" {
+		return nil, errors.New("the subject or the body of the template override is required")
+	}
+	if _, err := emails.ValidateTemplateOverride(templateName, input.Subject, input.Body); err != nil {
+		log.WithFields(f).WithError(err).Debug("template override validation failed")
+		return nil, err
+	}
+
+	locale := emails.NormalizeLocale(input.Locale)
+	_, now := utils.CurrentTime()
+	override := &emails.TemplateOverride{
+		Scope:        scope,
+		ScopeID:      scopeID,
+		TemplateName: templateName,
+		Locale:       locale,
+		Subject:      input.Subject,
+		Body:         input.Body,
+		CreatedBy:    createdBy,
+		DateCreated:  now,
+		DateModified: now,
+	}
+
+	existing, err := s.overrideRepo.GetTemplateOverrides(ctx, scope, scopeID, templateName)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range existing {
+		if e.Locale == locale {
+			override.CreatedBy = e.CreatedBy
+			override.DateCreated = e.DateCreated
+		}
+	}
+
+	if err := s.overrideRepo.SaveTemplateOverride(ctx, override); err != nil {
+		return nil, err
+	}
+
+	return toEmailTemplateOverrideModel(override), nil
+}
+
+// DeleteEmailTemplateOverride deletes the template override of the locale
+func (s service) DeleteEmailTemplateOverride(ctx context.Context, scope, scopeID, templateName, locale string) error {
+	return s.overrideRepo.DeleteTemplateOverride(ctx, scope, scopeID, templateName, locale)
+}
+
+func toEmailTemplateOverrideModel(override *emails.TemplateOverride) *models.EmailTemplateOverride {
+	return &models.EmailTemplateOverride{
+		Scope:        override.Scope,
+		ScopeID:      override.ScopeID,
+		TemplateName: override.TemplateName,
+		Locale:       override.Locale,
+		Subject:      override.Subject,
+		Body:         override.Body,
+		CreatedBy:    override.CreatedBy,
+		DateCreated:  override.DateCreated,
+		DateModified: override.DateModified,
+	}
+}
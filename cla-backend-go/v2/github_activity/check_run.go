@@ -0,0 +1,82 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_activity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/go-github/v37/github"
+	easyclaGithub "github.com/linuxfoundation/easycla/cla-backend-go/github"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// PullRequestChecker evaluates the CLA coverage of a pull request again, it is implemented by the signatures service
+type PullRequestChecker interface {
+	RecheckGitHubPullRequest(ctx context.Context, repositoryExternalID, pullRequestID int64) error
+}
+
+// ProcessCheckRunEvent re-evaluates the pull request of the EasyCLA check run when a contributor clicks its Re-run
+// action or re-runs the check from the GitHub checks tab
+func (s *eventHandlerService) ProcessCheckRunEvent(event *github.CheckRunEvent) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "v2.github_activity.service.ProcessCheckRunEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"action":         event.GetAction(),
+	}
+
+	switch event.GetAction() {
+	case "rerequested":
+	case "requested_action":
+		if event.GetRequestedAction().Identifier != easyclaGithub.CheckRunRerunAction {
+			log.WithFields(f).Debugf("ignoring check run action: %s", event.GetRequestedAction().Identifier)
+			return nil
+		}
+	default:
+		// the completed and created actions are sent for the check runs published by EasyCLA itself
+		return nil
+	}
+
+	if !easyclaGithub.IsEasyCLACheckRun(event.CheckRun) {
+		log.WithFields(f).Debugf("ignoring check run: %s of another app", event.GetCheckRun().GetName())
+		return nil
+	}
+	if event.Repo == nil || event.Repo.ID == nil {
+		return fmt.Errorf("missing repository object in event payload")
+	}
+	if s.pullRequestChecker == nil {
+		log.WithFields(f).Debug("pull request checker not configured, ignoring check run event")
+		return nil
+	}
+
+	pullRequestID, err := checkRunPullRequestID(event.CheckRun)
+	if err != nil {
+		return err
+	}
+
+	f["repositoryID"] = event.Repo.GetID()
+	f["pullRequestID"] = pullRequestID
+	log.WithFields(f).Debugf("re-run requested by: %s, rechecking the pull request", event.GetSender().GetLogin())
+	return s.pullRequestChecker.RecheckGitHubPullRequest(ctx, event.Repo.GetID(), pullRequestID)
+}
+
+// checkRunPullRequestID returns the pull request number of the check run. The external ID holds it since the pull
+// requests of the check run payload are empty when the pull request comes from a fork.
+func checkRunPullRequestID(checkRun *github.CheckRun) (int64, error) {
+	if checkRun.GetExternalID() != "" {
+		pullRequestID, err := strconv.ParseInt(checkRun.GetExternalID(), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid pull request number: %s in the check run external ID", checkRun.GetExternalID())
+		}
+		return pullRequestID, nil
+	}
+	if len(checkRun.PullRequests) > 0 && checkRun.PullRequests[0].Number != nil {
+		return int64(checkRun.PullRequests[0].GetNumber()), nil
+	}
+	return 0, fmt.Errorf("no pull request found for check run: %d", checkRun.GetID())
+}
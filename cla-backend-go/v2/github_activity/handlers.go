@@ -72,6 +72,12 @@ func Configure(api *operations.EasyclaAPI, service Service) {
 				processError = service.ProcessInstallationRepositoriesEvent(event)
 			case *github.RepositoryEvent:
 				processError = service.ProcessRepositoryEvent(event)
+			case *github.OrganizationEvent:
+				processError = service.ProcessOrganizationEvent(event)
+			case *github.MembershipEvent:
+				processError = service.ProcessMembershipEvent(event)
+			case *github.CheckRunEvent:
+				processError = service.ProcessCheckRunEvent(event)
 			default:
 				log.Warnf("unsupported event sent : %s", githubEvent)
 			}
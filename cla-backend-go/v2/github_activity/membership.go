@@ -0,0 +1,85 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_activity
+
+import (
+	"fmt"
+
+	"github.com/google/go-github/v37/github"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// ProcessOrganizationEvent invalidates the cached organization membership of the user added to or removed from the
+// GitHub organization, or of every user if the organization was renamed or deleted
+func (s *eventHandlerService) ProcessOrganizationEvent(event *github.OrganizationEvent) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "v2.github_activity.service.ProcessOrganizationEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	if event.Action == nil {
+		return fmt.Errorf("no action found in event payload")
+	}
+	if event.Organization == nil || event.Organization.Login == nil {
+		return fmt.Errorf("no organization found in event payload")
+	}
+	if s.membershipCache == nil {
+		log.WithFields(f).Debug("membership cache not enabled, ignoring organization event")
+		return nil
+	}
+
+	organizationName := event.Organization.GetLogin()
+	f["action"] = event.GetAction()
+	f["organizationName"] = organizationName
+	switch event.GetAction() {
+	case "member_added", "member_removed":
+		if event.Membership == nil || event.Membership.User == nil {
+			return fmt.Errorf("no membership user found in event payload")
+		}
+		username := event.Membership.User.GetLogin()
+		log.WithFields(f).Debugf("invalidating cached organization membership of user: %s", username)
+		if err := s.membershipCache.Invalidate(ctx, organizationName, username); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to invalidate cached organization membership of user: %s", username)
+			return err
+		}
+	case "deleted", "renamed":
+		log.WithFields(f).Debug("invalidating cached organization membership of all users")
+		if err := s.membershipCache.InvalidateOrganization(ctx, organizationName); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to invalidate cached organization membership of all users")
+			return err
+		}
+	default:
+		// invitations don't change the membership until they are accepted, which triggers member_added
+		log.WithFields(f).Debugf("ignoring organization event action: %s", event.GetAction())
+	}
+	return nil
+}
+
+// ProcessMembershipEvent invalidates the cached organization membership of the user added to or removed from one of
+// the organization teams
+func (s *eventHandlerService) ProcessMembershipEvent(event *github.MembershipEvent) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "v2.github_activity.service.ProcessMembershipEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	if event.Org == nil || event.Org.Login == nil || event.Member == nil {
+		return fmt.Errorf("no organization or member found in event payload")
+	}
+	if s.membershipCache == nil {
+		log.WithFields(f).Debug("membership cache not enabled, ignoring membership event")
+		return nil
+	}
+
+	log.WithFields(f).Debugf("invalidating cached organization membership of user: %s in organization: %s", event.Member.GetLogin(), event.Org.GetLogin())
+	if err := s.membershipCache.Invalidate(ctx, event.Org.GetLogin(), event.Member.GetLogin()); err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to invalidate cached organization membership of user: %s", event.Member.GetLogin())
+		return err
+	}
+	return nil
+}
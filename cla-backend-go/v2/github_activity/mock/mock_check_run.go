@@ -0,0 +1,53 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+//
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: v2/github_activity/check_run.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPullRequestChecker is a mock of PullRequestChecker interface.
+type MockPullRequestChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockPullRequestCheckerMockRecorder
+}
+
+// MockPullRequestCheckerMockRecorder is the mock recorder for MockPullRequestChecker.
+type MockPullRequestCheckerMockRecorder struct {
+	mock *MockPullRequestChecker
+}
+
+// NewMockPullRequestChecker creates a new mock instance.
+func NewMockPullRequestChecker(ctrl *gomock.Controller) *MockPullRequestChecker {
+	mock := &MockPullRequestChecker{ctrl: ctrl}
+	mock.recorder = &MockPullRequestCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPullRequestChecker) EXPECT() *MockPullRequestCheckerMockRecorder {
+	return m.recorder
+}
+
+// RecheckGitHubPullRequest mocks base method.
+func (m *MockPullRequestChecker) RecheckGitHubPullRequest(ctx context.Context, repositoryExternalID, pullRequestID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecheckGitHubPullRequest", ctx, repositoryExternalID, pullRequestID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecheckGitHubPullRequest indicates an expected call of RecheckGitHubPullRequest.
+func (mr *MockPullRequestCheckerMockRecorder) RecheckGitHubPullRequest(ctx, repositoryExternalID, pullRequestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecheckGitHubPullRequest", reflect.TypeOf((*MockPullRequestChecker)(nil).RecheckGitHubPullRequest), ctx, repositoryExternalID, pullRequestID)
+}
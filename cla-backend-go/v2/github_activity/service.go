@@ -11,6 +11,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github_membership"
 	v1GithubOrg "github.com/linuxfoundation/easycla/cla-backend-go/github_organizations"
 
 	"github.com/sirupsen/logrus"
@@ -33,15 +34,20 @@ import (
 type Service interface {
 	ProcessInstallationRepositoriesEvent(event *github.InstallationRepositoriesEvent) error
 	ProcessRepositoryEvent(*github.RepositoryEvent) error
+	ProcessOrganizationEvent(event *github.OrganizationEvent) error
+	ProcessMembershipEvent(event *github.MembershipEvent) error
+	ProcessCheckRunEvent(event *github.CheckRunEvent) error
 }
 
 type eventHandlerService struct {
-	gitV1Repository   repositories.RepositoryInterface
-	githubOrgRepo     v1GithubOrg.RepositoryInterface
-	eventService      events.Service
-	autoEnableService dynamo_events.AutoEnableService
-	emailService      emails.Service
-	sendEmail         bool
+	gitV1Repository    repositories.RepositoryInterface
+	githubOrgRepo      v1GithubOrg.RepositoryInterface
+	eventService       events.Service
+	autoEnableService  dynamo_events.AutoEnableService
+	emailService       emails.Service
+	membershipCache    *github_membership.Cache
+	pullRequestChecker PullRequestChecker
+	sendEmail          bool
 }
 
 // NewService creates a new instance of the Event Handler Service
@@ -49,9 +55,11 @@ func NewService(gitV1Repository repositories.RepositoryInterface,
 	githubOrgRepo v1GithubOrg.RepositoryInterface,
 	eventService events.Service,
 	autoEnableService dynamo_events.AutoEnableService,
-	emailService emails.Service) Service {
+	emailService emails.Service,
+	membershipCache *github_membership.Cache,
+	pullRequestChecker PullRequestChecker) Service {
 
-	return newService(gitV1Repository, githubOrgRepo, eventService, autoEnableService, emailService, true)
+	return newService(gitV1Repository, githubOrgRepo, eventService, autoEnableService, emailService, membershipCache, pullRequestChecker, true)
 }
 
 func newService(gitV1Repository repositories.RepositoryInterface,
@@ -59,14 +67,18 @@ func newService(gitV1Repository repositories.RepositoryInterface,
 	eventService events.Service,
 	autoEnableService dynamo_events.AutoEnableService,
 	emailService emails.Service,
+	membershipCache *github_membership.Cache,
+	pullRequestChecker PullRequestChecker,
 	sendEmail bool) Service {
 	return &eventHandlerService{
-		gitV1Repository:   gitV1Repository,
-		githubOrgRepo:     githubOrgRepo,
-		eventService:      eventService,
-		autoEnableService: autoEnableService,
-		emailService:      emailService,
-		sendEmail:         sendEmail,
+		gitV1Repository:    gitV1Repository,
+		githubOrgRepo:      githubOrgRepo,
+		eventService:       eventService,
+		autoEnableService:  autoEnableService,
+		emailService:       emailService,
+		membershipCache:    membershipCache,
+		pullRequestChecker: pullRequestChecker,
+		sendEmail:          sendEmail,
 	}
 }
 
@@ -206,7 +218,7 @@ func (s *eventHandlerService) handleRepositoryRemovedAction(ctx context.Context,
 
 	if s.sendEmail {
 		subject := fmt.Sprintf("EasyCLA: Github Repository Was Removed")
-		body, err := emails.RenderGithubRepositoryDisabledTemplate(s.emailService, repoModel.RepositoryClaGroupID, emails.GithubRepositoryDisabledTemplateParams{
+		subject, body, err := emails.RenderGithubRepositoryDisabledTemplate(s.emailService, subject, repoModel.RepositoryClaGroupID, emails.GithubRepositoryDisabledTemplateParams{
 			GithubRepositoryActionTemplateParams: emails.GithubRepositoryActionTemplateParams{
 				CommonEmailParams: emails.CommonEmailParams{
 					RecipientName: "CLA Manager",
@@ -278,7 +290,7 @@ func (s *eventHandlerService) handleRepositoryRenamedAction(ctx context.Context,
 
 	if s.sendEmail {
 		subject := fmt.Sprintf("EasyCLA: Github Repository Was Renamed")
-		body, err := emails.RenderGithubRepositoryRenamedTemplate(s.emailService, repoModel.RepositoryClaGroupID, emails.GithubRepositoryRenamedTemplateParams{
+		subject, body, err := emails.RenderGithubRepositoryRenamedTemplate(s.emailService, subject, repoModel.RepositoryClaGroupID, emails.GithubRepositoryRenamedTemplateParams{
 			GithubRepositoryActionTemplateParams: emails.GithubRepositoryActionTemplateParams{
 				CommonEmailParams: emails.CommonEmailParams{
 					RecipientName: "CLA Manager",
@@ -431,7 +443,7 @@ func (s *eventHandlerService) disableFailedTransferRepo(ctx context.Context, sen
 
 func (s *eventHandlerService) notifyForGithubRepositoryTransferred(ctx context.Context, repoModel *models.GithubRepository, oldGithubOrg *models.GithubOrganization, newGithubOrg *models.GithubOrganization, success bool) error {
 	subject := fmt.Sprintf("EasyCLA: Github Repository Was Transferred")
-	body, err := emails.RenderGithubRepositoryTransferredTemplate(s.emailService, repoModel.RepositoryClaGroupID, emails.GithubRepositoryTransferredTemplateParams{
+	subject, body, err := emails.RenderGithubRepositoryTransferredTemplate(s.emailService, subject, repoModel.RepositoryClaGroupID, emails.GithubRepositoryTransferredTemplateParams{
 		GithubRepositoryActionTemplateParams: emails.GithubRepositoryActionTemplateParams{
 			CommonEmailParams: emails.CommonEmailParams{
 				RecipientName: "CLA Manager",
@@ -473,7 +485,7 @@ func (s *eventHandlerService) handleRepositoryArchivedAction(ctx context.Context
 
 	if s.sendEmail {
 		subject := fmt.Sprintf("EasyCLA: Github Repository Was Archived")
-		body, err := emails.RenderGithubRepositoryArchivedTemplate(s.emailService, repoModel.RepositoryClaGroupID, emails.GithubRepositoryArchivedTemplateParams{
+		subject, body, err := emails.RenderGithubRepositoryArchivedTemplate(s.emailService, subject, repoModel.RepositoryClaGroupID, emails.GithubRepositoryArchivedTemplateParams{
 			GithubRepositoryActionTemplateParams: emails.GithubRepositoryActionTemplateParams{
 				CommonEmailParams: emails.CommonEmailParams{
 					RecipientName: "CLA Manager",
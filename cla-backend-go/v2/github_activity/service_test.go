@@ -13,8 +13,10 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/events"
 	eventsMock "github.com/linuxfoundation/easycla/cla-backend-go/events/mock"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	easyclaGithub "github.com/linuxfoundation/easycla/cla-backend-go/github"
 	githubOrgMock "github.com/linuxfoundation/easycla/cla-backend-go/github_organizations/mock"
 	"github.com/linuxfoundation/easycla/cla-backend-go/repositories/mock"
+	githubActivityMock "github.com/linuxfoundation/easycla/cla-backend-go/v2/github_activity/mock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -55,7 +57,7 @@ func TestEventHandlerService_ProcessRepositoryEvent_HandleRepositoryRenamedActio
 			},
 		}).Return()
 
-	activityService := newService(githubRepo, githubOrganizationRepo, eventsService, nil, nil, false)
+	activityService := newService(githubRepo, githubOrganizationRepo, eventsService, nil, nil, nil, nil, false)
 	err := activityService.ProcessRepositoryEvent(&github.RepositoryEvent{
 		Action: aws.String("renamed"),
 		Repo: &github.Repository{
@@ -162,7 +164,7 @@ func TestEventHandlerService_ProcessRepositoryEvent_HandleRepositoryTransferredA
 					}).Return()
 			}
 
-			activityService := newService(githubRepo, githubOrganizationRepo, eventsService, nil, nil, false)
+			activityService := newService(githubRepo, githubOrganizationRepo, eventsService, nil, nil, nil, nil, false)
 			err := activityService.ProcessRepositoryEvent(&github.RepositoryEvent{
 				Action: aws.String("transferred"),
 				Repo: &github.Repository{
@@ -187,3 +189,43 @@ func TestEventHandlerService_ProcessRepositoryEvent_HandleRepositoryTransferredA
 		})
 	}
 }
+
+func TestEventHandlerService_ProcessCheckRunEvent(t *testing.T) {
+	easyclaGithub.Init(1234, "", "")
+	checkRunEvent := func(action, identifier, name string, appID int64) *github.CheckRunEvent {
+		return &github.CheckRunEvent{
+			Action: aws.String(action),
+			CheckRun: &github.CheckRun{
+				ID:         aws.Int64(1),
+				Name:       aws.String(name),
+				ExternalID: aws.String("42"),
+				App:        &github.App{ID: aws.Int64(appID)},
+			},
+			RequestedAction: &github.RequestedAction{Identifier: identifier},
+			Repo:            &github.Repository{ID: aws.Int64(7)},
+			Sender:          &github.User{Login: aws.String("githubLoginValue")},
+		}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	checker := githubActivityMock.NewMockPullRequestChecker(ctrl)
+	checker.EXPECT().RecheckGitHubPullRequest(gomock.Any(), int64(7), int64(42)).Return(nil).Times(2)
+	checker.EXPECT().RecheckGitHubPullRequest(gomock.Any(), int64(7), int64(43)).Return(nil)
+	activityService := newService(nil, nil, nil, nil, nil, nil, checker, false)
+
+	assert.NoError(t, activityService.ProcessCheckRunEvent(checkRunEvent("requested_action", easyclaGithub.CheckRunRerunAction, "EasyCLA", 1234)))
+	assert.NoError(t, activityService.ProcessCheckRunEvent(checkRunEvent("rerequested", "", "EasyCLA", 1234)))
+	// the other actions, the other identifiers and the check runs of other apps are ignored
+	assert.NoError(t, activityService.ProcessCheckRunEvent(checkRunEvent("completed", "", "EasyCLA", 1234)))
+	assert.NoError(t, activityService.ProcessCheckRunEvent(checkRunEvent("requested_action", "fix", "EasyCLA", 1234)))
+	assert.NoError(t, activityService.ProcessCheckRunEvent(checkRunEvent("rerequested", "", "EasyCLA", 5678)))
+	assert.NoError(t, activityService.ProcessCheckRunEvent(checkRunEvent("rerequested", "", "build", 1234)))
+
+	// fork pull requests aren't listed in the check run payload, the external ID is required
+	event := checkRunEvent("rerequested", "", "EasyCLA", 1234)
+	event.CheckRun.ExternalID = nil
+	assert.Error(t, activityService.ProcessCheckRunEvent(event))
+	event.CheckRun.PullRequests = []*github.PullRequest{{Number: github.Int(43)}}
+	assert.NoError(t, activityService.ProcessCheckRunEvent(event))
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/LF-Engineering/lfx-kit/auth"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
 	"github.com/linuxfoundation/easycla/cla-backend-go/events"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/github_organizations"
@@ -219,6 +220,16 @@ func Configure(api *operations.EasyclaAPI, service Service, eventService events.
 				return github_organizations.NewUpdateProjectGithubOrganizationConfigBadRequest().WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
 			}
 
+			var commitIdentityPolicy commit_identity.Policy
+			if params.Body.CommitIdentityPolicy != "" {
+				var policyErr error
+				commitIdentityPolicy, policyErr = commit_identity.ParsePolicy(params.Body.CommitIdentityPolicy)
+				if policyErr != nil {
+					log.WithFields(f).Debug(policyErr.Error())
+					return github_organizations.NewUpdateProjectGithubOrganizationConfigBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, policyErr.Error(), policyErr))
+				}
+			}
+
 			err := service.UpdateGithubOrganization(ctx, params.ProjectSFID, params.OrgName, *params.Body.AutoEnabled, params.Body.AutoEnabledClaGroupID, params.Body.BranchProtectionEnabled)
 			if err != nil {
 				msg := fmt.Sprintf("problem updating GitHub Organization for project SFID: %s for organization: %s", params.ProjectSFID, params.OrgName)
@@ -226,6 +237,24 @@ func Configure(api *operations.EasyclaAPI, service Service, eventService events.
 				return github_organizations.NewUpdateProjectGithubOrganizationConfigBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
 			}
 
+			if commitIdentityPolicy != "" {
+				err = service.UpdateGithubOrganizationCommitIdentityPolicy(ctx, params.OrgName, commitIdentityPolicy)
+				if err != nil {
+					msg := fmt.Sprintf("problem updating the commit identity policy of the GitHub Organization for project SFID: %s for organization: %s", params.ProjectSFID, params.OrgName)
+					log.WithFields(f).Debug(msg)
+					return github_organizations.NewUpdateProjectGithubOrganizationConfigBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				}
+			}
+
+			if params.Body.CheckRunEnabled != nil {
+				err = service.UpdateGithubOrganizationCheckRunEnabled(ctx, params.OrgName, *params.Body.CheckRunEnabled)
+				if err != nil {
+					msg := fmt.Sprintf("problem updating the check run flag of the GitHub Organization for project SFID: %s for organization: %s", params.ProjectSFID, params.OrgName)
+					log.WithFields(f).Debug(msg)
+					return github_organizations.NewUpdateProjectGithubOrganizationConfigBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				}
+			}
+
 			// Log the event
 			eventService.LogEventWithContext(ctx, &events.LogEventArgs{
 				LfUsername:  authUser.UserName,
@@ -236,6 +265,8 @@ func Configure(api *operations.EasyclaAPI, service Service, eventService events.
 					AutoEnabled:             utils.BoolValue(params.Body.AutoEnabled),
 					AutoEnabledClaGroupID:   params.Body.AutoEnabledClaGroupID,
 					BranchProtectionEnabled: params.Body.BranchProtectionEnabled,
+					CommitIdentityPolicy:    string(commitIdentityPolicy),
+					CheckRunEnabled:         params.Body.CheckRunEnabled,
 				},
 			})
 
@@ -20,6 +20,7 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 
 	"github.com/jinzhu/copier"
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
 	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
 	v1GithubOrg "github.com/linuxfoundation/easycla/cla-backend-go/github_organizations"
@@ -42,6 +43,8 @@ type Service interface {
 	AddGithubOrganization(ctx context.Context, projectSFID string, input *models.GithubCreateOrganization) (*models.GithubOrganization, error)
 	DeleteGithubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error
 	UpdateGithubOrganization(ctx context.Context, projectSFID string, organizationName string, autoEnabled bool, autoEnabledClaGroupID string, branchProtectionEnabled bool) error
+	UpdateGithubOrganizationCommitIdentityPolicy(ctx context.Context, organizationName string, policy commit_identity.Policy) error
+	UpdateGithubOrganizationCheckRunEnabled(ctx context.Context, organizationName string, checkRunEnabled bool) error
 }
 
 type service struct {
@@ -344,6 +347,17 @@ func (s service) UpdateGithubOrganization(ctx context.Context, projectSFID strin
 	return s.repo.UpdateGitHubOrganization(ctx, projectSFID, organizationName, autoEnabled, autoEnabledClaGroupID, branchProtectionEnabled, nil)
 }
 
+// UpdateGithubOrganizationCommitIdentityPolicy updates the commit identities checked for the pull requests of the organization
+func (s service) UpdateGithubOrganizationCommitIdentityPolicy(ctx context.Context, organizationName string, policy commit_identity.Policy) error {
+	return s.repo.UpdateGitHubOrganizationCommitIdentityPolicy(ctx, organizationName, string(policy))
+}
+
+// UpdateGithubOrganizationCheckRunEnabled switches the pull requests of the organization between the check run and
+// the comment mode
+func (s service) UpdateGithubOrganizationCheckRunEnabled(ctx context.Context, organizationName string, checkRunEnabled bool) error {
+	return s.repo.UpdateGitHubOrganizationCheckRunEnabled(ctx, organizationName, checkRunEnabled)
+}
+
 func (s service) DeleteGithubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error {
 	f := logrus.Fields{
 		"functionName":   "v2.github_organizations.service.DeleteGitHubOrganization",
@@ -0,0 +1,83 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_activity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/bot_allowlist"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
+	"github.com/xanzy/go-gitlab"
+)
+
+// skipAllowlistedBots checks the merge request participants missing a CLA against the skip_cla bot allowlist of the
+// GitLab group. Returns the participants still missing a CLA and the allowlisted participants.
+func (s *service) skipAllowlistedBots(ctx context.Context, f logrus.Fields, gitlabOrg *v2Models.GitlabOrganization, projectPath string, missingUsers []*gatedGitlabUser) ([]*gatedGitlabUser, []*gitlab.User) {
+	if len(gitlabOrg.SkipCla) == 0 || len(missingUsers) == 0 {
+		return missingUsers, nil
+	}
+
+	var outMissingUsers []*gatedGitlabUser
+	var allowlistedUsers []*gitlab.User
+	for _, missingUser := range missingUsers {
+		entry, skipped := findAllowlistedBotEntry(f, gitlabOrg, projectPath, missingUser.User)
+		if !skipped {
+			outMissingUsers = append(outMissingUsers, missingUser)
+			continue
+		}
+
+		actorData := gitlabActorToString(missingUser.User)
+		log.WithFields(f).Infof("Skipping CLA check for repo='%s', actor: %s due to skip_cla config entry: %s", projectPath, actorData, entry)
+		s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:   events.BypassCLA,
+			ProjectSFID: gitlabOrg.ProjectSfid,
+			EventData: &events.BypassCLAEventData{
+				Repo:   projectPath,
+				Config: entry.Repository + ": " + entry.ActorPattern(),
+				Actor:  actorData,
+			},
+		})
+		allowlistedUsers = append(allowlistedUsers, missingUser.User)
+	}
+
+	return outMissingUsers, allowlistedUsers
+}
+
+// findAllowlistedBotEntry returns the skip_cla entry of the GitLab group matching the participant for the project, if any
+func findAllowlistedBotEntry(f logrus.Fields, gitlabOrg *v2Models.GitlabOrganization, projectPath string, gitlabUser *gitlab.User) (*bot_allowlist.Entry, bool) {
+	if gitlabOrg == nil || len(gitlabOrg.SkipCla) == 0 || gitlabUser == nil {
+		return nil, false
+	}
+
+	allowlist, err := bot_allowlist.CompileSkipCLA(gitlabOrg.SkipCla)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid skip_cla entries found, ignoring them")
+	}
+
+	return allowlist.Match(relativeProjectPath(gitlabOrg, projectPath), &bot_allowlist.Actor{
+		Login: gitlabUser.Username,
+		Email: gitlabUser.Email,
+		Name:  gitlabUser.Name,
+	})
+}
+
+// relativeProjectPath returns the project path relative to the GitLab group full path, which is the repository name
+// used by the skip_cla configuration - the same way GitHub repository names don't include the organization
+func relativeProjectPath(gitlabOrg *v2Models.GitlabOrganization, projectPath string) string {
+	prefix := strings.ToLower(gitlabOrg.OrganizationFullPath) + "/"
+	if gitlabOrg.OrganizationFullPath != "" && strings.HasPrefix(strings.ToLower(projectPath), prefix) {
+		return projectPath[len(prefix):]
+	}
+	return projectPath
+}
+
+// gitlabActorToString converts the GitLab user to a string representation
+func gitlabActorToString(gitlabUser *gitlab.User) string {
+	return fmt.Sprintf("id='%d',login='%s',username='%s',email='%s'", gitlabUser.ID, gitlabUser.Username, gitlabUser.Name, gitlabUser.Email)
+}
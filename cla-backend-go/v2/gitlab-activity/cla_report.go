@@ -0,0 +1,160 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_activity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
+	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	gitlab_api "github.com/linuxfoundation/easycla/cla-backend-go/gitlab_api"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/common"
+	"github.com/sirupsen/logrus"
+	"github.com/xanzy/go-gitlab"
+)
+
+// GetMergeRequestCLAReport runs the CLA check of the merge request in dry-run mode - the participants are evaluated
+// the same way as ProcessMergeActivity, but no commit status or merge request comment is set
+func (s *service) GetMergeRequestCLAReport(ctx context.Context, projectSFID, repositoryID string, mergeRequestID int) (*v2Models.ChangeRequestReport, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab-activity.service.GetMergeRequestCLAReport",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+		"repositoryID":   repositoryID,
+		"mergeRequestID": mergeRequestID,
+	}
+
+	gitlabRepo, err := s.gitV2Repository.GitLabGetRepository(ctx, repositoryID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to fetch repository by ID: %s", repositoryID)
+		return nil, err
+	}
+	if gitlabRepo.ProjectSFID != projectSFID {
+		log.WithFields(f).Warnf("repository: %s is not associated with project: %s", repositoryID, projectSFID)
+		return nil, &utils.GitLabRepositoryNotFound{
+			RepositoryName: gitlabRepo.RepositoryName,
+			ProjectSFID:    projectSFID,
+		}
+	}
+
+	gitlabProjectID, err := strconv.Atoi(gitlabRepo.RepositoryExternalID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to convert repository external ID: %s to integer", gitlabRepo.RepositoryExternalID)
+		return nil, err
+	}
+
+	gitlabOrg, err := s.getGitlabOrganizationFromProjectPath(ctx, gitlabRepo.RepositoryName, gitlabRepo.RepositoryOrganizationName)
+	if err != nil {
+		return nil, fmt.Errorf("fetching internal gitlab org for following path : %s failed : %v", gitlabRepo.RepositoryName, err)
+	}
+
+	oauthResponse, err := s.gitlabOrgService.RefreshGitLabOrganizationAuth(ctx, common.ToCommonModel(gitlabOrg))
+	if err != nil {
+		return nil, fmt.Errorf("refreshing gitlab org auth info failed : %v", err)
+	}
+
+	gitlabClient, err := gitlab_api.NewGitlabOauthClient(*oauthResponse, s.gitLabApp)
+	if err != nil {
+		return nil, fmt.Errorf("initializing gitlab client : %v", err)
+	}
+
+	lastCommit, err := gitlab_api.GetLatestCommit(gitlabClient, gitlabProjectID, mergeRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching info for mr : %d and project : %d: %s, failed : %v", mergeRequestID, gitlabProjectID, gitlabRepo.RepositoryName, err)
+	}
+
+	participants, err := gitlab_api.FetchMrParticipants(gitlabClient, gitlabProjectID, mergeRequestID, commit_identity.PolicyOf(gitlabOrg.CommitIdentityPolicy))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem loading GitLab merge request participants for merge request: %d", mergeRequestID)
+		return nil, fmt.Errorf("problem loading GitLab merge request participants for merge request: %d - error: %+v", mergeRequestID, err)
+	}
+
+	claGroup, err := s.projectsCLAGroupsRepository.GetClaGroupIDForProject(ctx, gitlabOrg.ProjectSfid)
+	if err != nil {
+		return nil, fmt.Errorf("fetching claGroup id for gitlabOrg project sfid : %s, failed : %v", gitlabOrg.ProjectSfid, err)
+	}
+
+	report := &v2Models.ChangeRequestReport{
+		RepositoryType:  v2Models.ChangeRequestReportRepositoryTypeGitlab,
+		RepositoryID:    gitlabRepo.RepositoryID,
+		RepositoryName:  gitlabRepo.RepositoryName,
+		ChangeRequestID: int64(mergeRequestID),
+		ClaGroupID:      claGroup.ClaGroupID,
+		LatestSHA:       lastCommit.ID,
+		Passed:          len(participants) > 0,
+	}
+
+	for _, gitlabUser := range participants {
+		authorReport := s.getParticipantCLAReport(ctx, f, claGroup.ClaGroupID, gitlabUser)
+		if !authorReport.Signed {
+			// same as ProcessMergeActivity, only the participants missing a CLA are checked against the bot allowlist
+			if entry, ok := findAllowlistedBotEntry(f, gitlabOrg, gitlabRepo.RepositoryName, gitlabUser); ok {
+				authorReport.BotAllowlisted = true
+				authorReport.BotAllowlistPattern = entry.ActorPattern()
+				authorReport.Signed = true
+				authorReport.Reason = fmt.Sprintf("%s - skipped by the skip_cla bot allowlist pattern: %s", authorReport.Reason, entry.ActorPattern())
+			}
+		}
+		if !authorReport.Signed {
+			report.Passed = false
+		}
+		report.Authors = append(report.Authors, authorReport)
+	}
+
+	log.WithFields(f).Debugf("merge request: %d CLA check passed: %t for %d participants", mergeRequestID, report.Passed, len(report.Authors))
+	return report, nil
+}
+
+// getParticipantCLAReport evaluates the CLA status of a single merge request participant - when several user records
+// match the participant, the report of the first signed record is returned, otherwise the report of the last record
+func (s *service) getParticipantCLAReport(ctx context.Context, f logrus.Fields, claGroupID string, gitlabUser *gitlab.User) *v2Models.ChangeRequestAuthorReport {
+	newAuthorReport := func() *v2Models.ChangeRequestAuthorReport {
+		return &v2Models.ChangeRequestAuthorReport{
+			AuthorID:    strconv.Itoa(gitlabUser.ID),
+			AuthorLogin: gitlabUser.Username,
+			AuthorEmail: gitlabUser.Email,
+			AuthorName:  gitlabUser.Name,
+		}
+	}
+
+	userModels, userLookup, lookupErr := s.findUserModelForGitlabUser(f, gitlabUser)
+	if lookupErr != nil {
+		authorReport := newAuthorReport()
+		authorReport.Reason = fmt.Sprintf("problem looking up the EasyCLA user record: %v", lookupErr)
+		return authorReport
+	}
+	if len(userModels) == 0 {
+		authorReport := newAuthorReport()
+		authorReport.Reason = missingID.Error()
+		return authorReport
+	}
+
+	var authorReport *v2Models.ChangeRequestAuthorReport
+	for _, userModel := range userModels {
+		authorReport = newAuthorReport()
+		authorReport.UserID = userModel.UserID
+		authorReport.UserLookup = userLookup
+
+		signed, signedErr := s.checkUserSigned(ctx, userModel, claGroupID, gitlabUser, authorReport)
+		if signedErr != nil {
+			authorReport.Reason = signedErr.Error()
+			continue
+		}
+		if signed {
+			authorReport.Signed = true
+			if authorReport.IclaSignatureID != "" {
+				authorReport.Reason = fmt.Sprintf("user has signed ICLA signature: %s", authorReport.IclaSignatureID)
+			} else {
+				authorReport.Reason = fmt.Sprintf("user has acknowledged CCLA signature: %s and is in the approval list", authorReport.CclaSignatureID)
+			}
+			return authorReport
+		}
+	}
+
+	return authorReport
+}
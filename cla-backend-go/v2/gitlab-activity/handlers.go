@@ -14,6 +14,7 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab_organizations"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab_sign"
 
+	"github.com/LF-Engineering/lfx-kit/auth"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/gofrs/uuid"
@@ -292,4 +293,46 @@ func Configure(api *operations.EasyclaAPI, service Service, gitlabOrgService git
 				})
 		})
 
+	api.GitlabActivityGetGitLabMergeRequestCLAReportHandler = gitlab_activity.GetGitLabMergeRequestCLAReportHandlerFunc(func(params gitlab_activity.GetGitLabMergeRequestCLAReportParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "gitlab_activity.handlers.GitlabActivityGetGitLabMergeRequestCLAReportHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"projectSFID":    params.ProjectSFID,
+			"repositoryID":   params.RepositoryID,
+			"mergeRequestID": params.MergeRequestID,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to get the CLA report of merge request: %d for project: %s",
+				authUser.UserName, params.MergeRequestID, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return gitlab_activity.NewGetGitLabMergeRequestCLAReportForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		log.WithFields(f).Debug("generating merge request CLA report...")
+		report, err := service.GetMergeRequestCLAReport(ctx, params.ProjectSFID, params.RepositoryID, int(params.MergeRequestID))
+		if err != nil {
+			var gitHubNotFound *utils.GitHubRepositoryNotFound
+			var gitLabNotFound *utils.GitLabRepositoryNotFound
+			if errors.As(err, &gitHubNotFound) || errors.As(err, &gitLabNotFound) {
+				msg := fmt.Sprintf("repository: %s not found for projectSFID: %s", params.RepositoryID, params.ProjectSFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitlab_activity.NewGetGitLabMergeRequestCLAReportNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFound(reqID, msg))
+			}
+
+			msg := fmt.Sprintf("problem generating the CLA report for merge request: %d", params.MergeRequestID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return gitlab_activity.NewGetGitLabMergeRequestCLAReportBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		return gitlab_activity.NewGetGitLabMergeRequestCLAReportOK().WithXRequestID(reqID).WithPayload(report)
+	})
 }
@@ -11,9 +11,12 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/linuxfoundation/easycla/cla-backend-go/approval_rules"
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
 	"github.com/linuxfoundation/easycla/cla-backend-go/config"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
 	signatures1 "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -66,6 +69,7 @@ type Service interface {
 	ProcessMergeOpenedActivity(ctx context.Context, secretToken string, mergeEvent *gitlab.MergeEvent) error
 	ProcessMergeActivity(ctx context.Context, secretToken string, input *ProcessMergeActivityInput) error
 	IsUserApprovedForSignature(ctx context.Context, f logrus.Fields, corporateSignature *models.Signature, user *models.User, gitlabUser *gitlab.User) bool
+	GetMergeRequestCLAReport(ctx context.Context, projectSFID, repositoryID string, mergeRequestID int) (*v2Models.ChangeRequestReport, error)
 }
 
 type service struct {
@@ -78,11 +82,13 @@ type service struct {
 	companyRepository           company.IRepository
 	signatureRepository         signatures.SignatureRepository
 	gitLabApp                   *gitlab_api.App
+	approvalEngine              approval_rules.Engine
+	eventsService               events.Service
 }
 
 func NewService(gitRepository repositories.RepositoryInterface, gitV2Repository gitV2Repositories.RepositoryInterface, usersRepository users.UserRepository, signaturesRepository signatures.SignatureRepository, projectsCLAGroupsRepository projects_cla_groups.Repository,
-	companyRepository company.IRepository, signatureRepository signatures.SignatureRepository, gitlabOrgService gitlab_organizations.ServiceInterface) Service {
-	return &service{
+	companyRepository company.IRepository, signatureRepository signatures.SignatureRepository, gitlabOrgService gitlab_organizations.ServiceInterface, eventsService events.Service) Service {
+	s := &service{
 		gitRepository:               gitRepository,
 		gitV2Repository:             gitV2Repository,
 		usersRepository:             usersRepository,
@@ -92,7 +98,14 @@ func NewService(gitRepository repositories.RepositoryInterface, gitV2Repository
 		signatureRepository:         signatureRepository,
 		gitLabApp:                   gitlab_api.Init(config.GetConfig().Gitlab.AppClientID, config.GetConfig().Gitlab.AppClientSecret, config.GetConfig().Gitlab.AppPrivateKey),
 		gitlabOrgService:            gitlabOrgService,
-	}
+		eventsService:               eventsService,
+	}
+	s.approvalEngine = approval_rules.NewEngine(approval_rules.NewDefaultRegistry(approval_rules.Checkers{
+		GitLabGroup: approval_rules.MembershipCheckerFunc(func(ctx context.Context, group, username string) (bool, error) {
+			return s.checkGitLabGroupApproval(ctx, username, group)
+		}),
+	}))
+	return s
 }
 
 func (s *service) ProcessMergeOpenedActivity(ctx context.Context, secretToken string, mergeEvent *gitlab.MergeEvent) error {
@@ -231,7 +244,7 @@ func (s *service) ProcessMergeActivity(ctx context.Context, secretToken string,
 	}
 
 	log.WithFields(f).Debugf("loading GitLab merge request participatants for merge request: %d", mergeID)
-	participants, err := gitlab_api.FetchMrParticipants(gitlabClient, projectID, mergeID)
+	participants, err := gitlab_api.FetchMrParticipants(gitlabClient, projectID, mergeID, commit_identity.PolicyOf(gitlabOrg.CommitIdentityPolicy))
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem loading GitLab merge request participants for merge request: %d", mergeID)
 		return fmt.Errorf("problem loading GitLab merge request participants for merge request: %d - error: %+v", mergeID, err)
@@ -278,6 +291,10 @@ func (s *service) ProcessMergeActivity(ctx context.Context, secretToken string,
 		}
 	}
 
+	var allowlistedUsers []*gitlab.User
+	missingUsers, allowlistedUsers = s.skipAllowlistedBots(ctx, f, gitlabOrg, repositoryPath, missingUsers)
+	signedUsers = append(signedUsers, allowlistedUsers...)
+
 	signURL := GetFullSignURL(gitlabOrg.OrganizationID, strconv.Itoa(int(gitlabRepo.RepositoryExternalID)), strconv.Itoa(mergeID))
 	mrCommentContent := PrepareMrCommentContent(missingUsers, signedUsers, signURL)
 	if len(missingUsers) > 0 {
@@ -459,7 +476,7 @@ func (s *service) hasUserSigned(ctx context.Context, claGroupID string, gitlabUs
 		"gitlabUserEmail": gitlabUser.Email,
 	}
 
-	userModels, lookUpErr := s.findUserModelForGitlabUser(f, gitlabUser)
+	userModels, _, lookUpErr := s.findUserModelForGitlabUser(f, gitlabUser)
 	if lookUpErr != nil {
 		log.WithFields(f).WithError(lookUpErr).Warnf("unable to find user model for gitlab user: %v", gitlabUser)
 		return false, lookUpErr
@@ -488,8 +505,14 @@ func (s *service) hasUserSigned(ctx context.Context, claGroupID string, gitlabUs
 }
 
 func (s *service) isSigned(ctx context.Context, userModel *models.User, claGroupID string, gitlabUser *gitlab.User) (bool, error) {
+	return s.checkUserSigned(ctx, userModel, claGroupID, gitlabUser, &v2Models.ChangeRequestAuthorReport{})
+}
+
+// checkUserSigned checks if the user has signed an ICLA or has an approved employee acknowledgement, recording each
+// step of the check in the author report
+func (s *service) checkUserSigned(ctx context.Context, userModel *models.User, claGroupID string, gitlabUser *gitlab.User, authorReport *v2Models.ChangeRequestAuthorReport) (bool, error) {
 	f := logrus.Fields{
-		"functionName":    "v2.gitlab-activity.service.isSigned",
+		"functionName":    "v2.gitlab-activity.service.checkUserSigned",
 		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
 		"gitlabUserID":    gitlabUser.ID,
 		"gitlabUserName":  gitlabUser.Username,
@@ -505,6 +528,7 @@ func (s *service) isSigned(ctx context.Context, userModel *models.User, claGroup
 
 	if icla != nil {
 		log.WithFields(f).Infof("user has signed the following signature (ICLA): %s, passing", icla.SignatureID)
+		authorReport.IclaSignatureID = icla.SignatureID
 		return true, nil
 	}
 
@@ -514,6 +538,7 @@ func (s *service) isSigned(ctx context.Context, userModel *models.User, claGroup
 	}
 
 	companyID := userModel.CompanyID
+	authorReport.CompanyID = companyID
 	_, err = s.companyRepository.GetCompany(ctx, companyID)
 	if err != nil {
 		msg := fmt.Sprintf("can't load company record: %s for user: %s (%s), error: %v", companyID, userModel.Username, userModel.UserID, err)
@@ -535,6 +560,7 @@ func (s *service) isSigned(ctx context.Context, userModel *models.User, claGroup
 	}
 
 	log.WithFields(f).Debugf("loaded corporate signature id: %s for claGroupID: %s and companyID: %s", corporateSignature.SignatureID, claGroupID, companyID)
+	authorReport.CclaSignatureID = corporateSignature.SignatureID
 
 	approvalCriteria := &signatures.ApprovalCriteria{}
 	if gitlabUser.Email != "" {
@@ -547,8 +573,10 @@ func (s *service) isSigned(ctx context.Context, userModel *models.User, claGroup
 		return false, fmt.Errorf("%s", msg)
 	}
 
-	if !s.IsUserApprovedForSignature(ctx, f, corporateSignature, userModel, gitlabUser) {
-		log.WithFields(f).Debugf("user is not approved in signature : %s", corporateSignature.SignatureID)
+	decision := s.getApprovalDecision(ctx, corporateSignature, userModel, gitlabUser)
+	signatures.PopulateApprovalDecision(authorReport, decision)
+	if !decision.Approved {
+		log.WithFields(f).Debugf("user is not approved in signature : %s - %s", corporateSignature.SignatureID, decision.Explain())
 		return false, fmt.Errorf("user is not approved in signature : %s", corporateSignature.SignatureID)
 	}
 
@@ -571,11 +599,13 @@ func (s *service) isSigned(ctx context.Context, userModel *models.User, claGroup
 	}
 
 	log.WithFields(f).Warnf("is in signature approval list : %s and has employee signature", corporateSignature.SignatureID)
+	authorReport.EclaSignatureID = employeeSignatures.Signatures[0].SignatureID
 	return true, nil
 }
 
-// findUserModelForGitlabUser locates the user model in our users table for the given GitLab user (by GitLab ID, GitLab username, or email)
-func (s *service) findUserModelForGitlabUser(f logrus.Fields, gitlabUser *gitlab.User) ([]*models.User, error) {
+// findUserModelForGitlabUser locates the user model in our users table for the given GitLab user (by GitLab ID, GitLab username, or email), along
+// with the lookup method that matched
+func (s *service) findUserModelForGitlabUser(f logrus.Fields, gitlabUser *gitlab.User) ([]*models.User, string, error) {
 
 	if gitlabUser.ID != 0 {
 		log.WithFields(f).Debugf("Looking up GitLab user via ID: %d", gitlabUser.ID)
@@ -584,7 +614,7 @@ func (s *service) findUserModelForGitlabUser(f logrus.Fields, gitlabUser *gitlab
 			log.WithFields(f).WithError(lookupErr).Warnf("problem locating GitLab user via GitLab ID : %d", gitlabUser.ID)
 		} else if userModel != nil {
 			log.WithFields(f).Debugf("located GitLab user via ID: %d", gitlabUser.ID)
-			return []*models.User{userModel}, nil
+			return []*models.User{userModel}, signatures.UserLookupGitLabID, nil
 		}
 	}
 
@@ -595,7 +625,7 @@ func (s *service) findUserModelForGitlabUser(f logrus.Fields, gitlabUser *gitlab
 			log.WithFields(f).WithError(lookupErr).Warnf("problem locating GitLab user via GitLab username : %s", gitlabUser.Username)
 		} else if userModel != nil {
 			log.WithFields(f).Debugf("located GitLab user via username: %s", gitlabUser.Username)
-			return []*models.User{userModel}, nil
+			return []*models.User{userModel}, signatures.UserLookupGitLabUsername, nil
 		}
 	}
 
@@ -609,93 +639,36 @@ func (s *service) findUserModelForGitlabUser(f logrus.Fields, gitlabUser *gitlab
 		} else if len(users) > 0 {
 			log.WithFields(f).Debugf("located GitLab user via email: %s", gitlabUser.Email)
 			gitlabUsers = append(gitlabUsers, users...)
-			return gitlabUsers, nil
+			return gitlabUsers, signatures.UserLookupEmail, nil
 		}
 	}
 
 	// Didn't find it
-	return nil, nil
+	return nil, "", nil
 }
 
+// IsUserApprovedForSignature returns true if the GitLab user matches one of the approval lists of the corporate signature
 func (s *service) IsUserApprovedForSignature(ctx context.Context, f logrus.Fields, corporateSignature *models.Signature, user *models.User, gitlabUser *gitlab.User) bool {
 	log.WithFields(f).Debugf("checking if user : %s is approved for corporate signature : %s", user.UserID, corporateSignature.SignatureID)
-	userEmails := user.Emails
-	if string(user.LfEmail) != "" {
-		userEmails = append(userEmails, string(user.LfEmail))
-	}
-
-	emailApprovalList := corporateSignature.EmailApprovalList
-	domainApprovalList := corporateSignature.DomainApprovalList
-	log.WithFields(f).Debugf("checking if user : %s is approved for corporate signature : %s, email approval list : %+v", user.UserID, corporateSignature.SignatureID, emailApprovalList)
-
-	if len(userEmails) > 0 && len(emailApprovalList) > 0 {
-		for _, email := range userEmails {
-			for _, approvalEmail := range emailApprovalList {
-				if email == approvalEmail {
-					log.WithFields(f).Debugf("found user email : %s in email approval list ", email)
-					return true
-				}
-			}
-		}
-	} else {
-		log.WithFields(f).Warnf("no match for user in signature email approval list")
-	}
-
-	if len(domainApprovalList) > 0 && len(userEmails) > 0 {
-		log.WithFields(f).Debugf("checking if emails : %+v are approved for corporate signature : %s, domain approval list : %+v", userEmails, corporateSignature.SignatureID, domainApprovalList)
-		for _, userEmail := range userEmails {
-			for _, domainApprovalPattern := range domainApprovalList {
-				if strings.HasPrefix(domainApprovalPattern, "*.") {
-					domainApprovalPattern = strings.Replace(domainApprovalPattern, "*.", ".*", 1)
-				} else if strings.HasPrefix(domainApprovalPattern, "*") {
-					domainApprovalPattern = strings.Replace(domainApprovalPattern, "*", ".*", 1)
-				} else if strings.HasPrefix(domainApprovalPattern, ".") {
-					domainApprovalPattern = strings.Replace(domainApprovalPattern, ".", ".*", 1)
-				}
-				regexpApprovalPattern := "^.*@" + domainApprovalPattern + "$"
-				if ok, err := regexp.MatchString(regexpApprovalPattern, userEmail); ok && err == nil {
-					log.WithFields(f).Debugf("found user email : %s in email approval list : %s", userEmail, domainApprovalPattern)
-					return true
-				}
-			}
-		}
-	}
-
-	gitlabUserName := gitlabUser.Username
-	gitlabUsernameApprovalList := corporateSignature.GitlabUsernameApprovalList
-	if gitlabUserName != "" && len(gitlabUsernameApprovalList) > 0 {
-		log.WithFields(f).Debugf("checking gitlab username : %s for gitlab approval list : %+v", gitlabUserName, gitlabUsernameApprovalList)
-		for _, gitlabApproval := range gitlabUsernameApprovalList {
-			if gitlabApproval == gitlabUserName {
-				log.WithFields(f).Debugf("found gitlab username : %s in gitlab approval list ", gitlabUserName)
-				return true
-			}
-		}
-
-	} else {
-		log.WithFields(f).Warnf("no match found for gitlabUser : %s in gitlab approval list : %+v", gitlabUserName, gitlabUsernameApprovalList)
+	decision := s.getApprovalDecision(ctx, corporateSignature, user, gitlabUser)
+	if !decision.Approved {
+		log.WithFields(f).Warnf("unable to find user in any approval list - %s", decision.Explain())
+		return false
 	}
 
-	gitlabGroupApprovalList := corporateSignature.GitlabOrgApprovalList
-	if gitlabUserName != "" && len(gitlabGroupApprovalList) > 0 {
-		log.WithFields(f).Debugf("checking gitlab username : %s for gitlab org approval list : %+v ", gitlabUserName, gitlabGroupApprovalList)
+	log.WithFields(f).Debugf("user : %s is approved for corporate signature : %s - %s", user.UserID, corporateSignature.SignatureID, decision.Explain())
+	return true
+}
 
-		for _, gitlabGroupApproval := range gitlabGroupApprovalList {
-			isApproved, err := s.checkGitLabGroupApproval(ctx, gitlabUserName, gitlabGroupApproval)
-			if err != nil {
-				log.WithFields(f).WithError(err).Warn("unable to get username")
-				break
-			}
-			if isApproved == true {
-				log.WithFields(f).Debugf(" found gitlab username : %s in gitlab org approval list : %+v", gitlabUserName, gitlabGroupApprovalList)
-				return true
-			}
-		}
+// getApprovalDecision evaluates the GitLab user against the approval lists of the corporate signature
+func (s *service) getApprovalDecision(ctx context.Context, corporateSignature *models.Signature, user *models.User, gitlabUser *gitlab.User) *approval_rules.Decision {
+	actor := signatures.NewApprovalActor(user)
+	if gitlabUser != nil && gitlabUser.Username != "" {
+		// the merge request author is the identity being checked
+		actor.GitLabUsername = gitlabUser.Username
 	}
 
-	log.WithFields(f).Errorf("unable to find user in any approval list")
-	return false
-
+	return s.approvalEngine.Evaluate(ctx, actor, signatures.NewApprovalLists(corporateSignature))
 }
 
 /**
@@ -94,7 +94,7 @@ func TestIsUserApprovedForSignature(t *testing.T) {
 				expected: true,
 			},
 		}
-		activityService := NewService(nil, nil, nil, nil, nil, nil, nil, nil)
+		activityService := NewService(nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(tt *testing.T) {
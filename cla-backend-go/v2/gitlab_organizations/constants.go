@@ -38,4 +38,8 @@ const (
 	GitLabOrganizationsExternalGitLabGroupIDColumn = "external_gitlab_group_id"
 	// GitLabOrganizationsAuthExpiryTimeColumn constant
 	GitLabOrganizationsAuthExpiryTimeColumn = "auth_expiry_time"
+	// GitLabOrganizationsSkipCLAColumn constant
+	GitLabOrganizationsSkipCLAColumn = "skip_cla"
+	// GitLabOrganizationsCommitIdentityPolicyColumn constant
+	GitLabOrganizationsCommitIdentityPolicyColumn = "commit_identity_policy"
 )
@@ -29,6 +29,7 @@ import (
 
 	"github.com/LF-Engineering/lfx-kit/auth"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
 	"github.com/linuxfoundation/easycla/cla-backend-go/events"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/gitlab_organizations"
@@ -303,6 +304,15 @@ func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventServic
 			return gitlab_organizations.NewUpdateProjectGitlabGroupConfigBadRequest().WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
 		}
 
+		var commitIdentityPolicy commit_identity.Policy
+		if params.Body.CommitIdentityPolicy != "" {
+			var policyErr error
+			commitIdentityPolicy, policyErr = commit_identity.ParsePolicy(params.Body.CommitIdentityPolicy)
+			if policyErr != nil {
+				return gitlab_organizations.NewUpdateProjectGitlabGroupConfigBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, policyErr.Error(), policyErr))
+			}
+		}
+
 		inputModel := &common.GitLabAddOrganization{
 			ProjectSFID:             params.ProjectSFID,
 			AutoEnabled:             params.Body.AutoEnabled,
@@ -310,6 +320,7 @@ func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventServic
 			BranchProtectionEnabled: params.Body.BranchProtectionEnabled,
 			ExternalGroupID:         params.GitLabGroupID,
 			Enabled:                 true,
+			CommitIdentityPolicy:    string(commitIdentityPolicy),
 		}
 
 		if parentProjectModel != nil {
@@ -337,6 +348,7 @@ func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventServic
 				GitLabGroupID:         params.GitLabGroupID,
 				AutoEnabledClaGroupID: params.Body.AutoEnabledClaGroupID,
 				AutoEnabled:           params.Body.AutoEnabled,
+				CommitIdentityPolicy:  string(commitIdentityPolicy),
 			},
 		})
 
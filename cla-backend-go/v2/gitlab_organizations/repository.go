@@ -57,6 +57,7 @@ type RepositoryInterface interface {
 	GetGitLabOrganizationByURL(ctx context.Context, url string) (*common.GitLabOrganization, error)
 	UpdateGitLabOrganizationAuth(ctx context.Context, organizationID string, gitLabGroupID int, authExpiryTime int64, authInfo, groupName, groupFullPath, organizationURL string) error
 	UpdateGitLabOrganization(ctx context.Context, input *common.GitLabAddOrganization, enabled bool) error
+	UpdateGitLabOrganizationSkipCLA(ctx context.Context, organizationID string, previousSkipCLA, skipCLA map[string]string) error
 	DeleteGitLabOrganizationByFullPath(ctx context.Context, projectSFID, gitlabOrgFullPath string) error
 }
 
@@ -89,6 +90,7 @@ func (repo *Repository) AddGitLabOrganization(ctx context.Context, input *common
 		"autoEnabled":             input.AutoEnabled,
 		"autoEnabledClaGroupID":   input.AutoEnabledClaGroupID,
 		"branchProtectionEnabled": input.BranchProtectionEnabled,
+		"commitIdentityPolicy":    input.CommitIdentityPolicy,
 		"enabled":                 enabled,
 	}
 
@@ -562,6 +564,89 @@ func (repo *Repository) UpdateGitLabOrganizationAuth(ctx context.Context, organi
 	return nil
 }
 
+// UpdateGitLabOrganizationSkipCLA updates the skip_cla bot allowlist configuration of the GitLab group - an empty
+// configuration removes the attribute
+func (repo *Repository) UpdateGitLabOrganizationSkipCLA(ctx context.Context, organizationID string, previousSkipCLA, skipCLA map[string]string) error {
+	f := logrus.Fields{
+		"functionName":   "gitlab_organizations.repository.UpdateGitLabOrganizationSkipCLA",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+		"tableName":      repo.gitlabOrgTableName,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	gitlabOrg, lookupErr := repo.GetGitLabOrganization(ctx, organizationID)
+	if lookupErr != nil {
+		log.WithFields(f).WithError(lookupErr).Warnf("error looking up Gitlab organization by id: %s, error: %+v", organizationID, lookupErr)
+		return lookupErr
+	}
+	if gitlabOrg == nil {
+		log.WithFields(f).Warnf("unable to locate Gitlab organization by id: %s", organizationID)
+		return &utils.GitLabOrgNotFound{Err: fmt.Errorf("gitlab organization with ID: %s not found", organizationID)}
+	}
+
+	expressionAttributeNames := map[string]*string{
+		"#S": aws.String(GitLabOrganizationsSkipCLAColumn),
+		"#M": aws.String(GitLabOrganizationsDateModifiedColumn),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":m": {
+			S: aws.String(currentTime),
+		},
+	}
+	updateExpression := "SET #M = :m REMOVE #S"
+
+	// the update only applies to the configuration it was computed from, so concurrent edits don't lose entries
+	conditionExpression := "attribute_not_exists(#S) OR size(#S) = :zero"
+	if len(previousSkipCLA) > 0 {
+		previousValue, marshalErr := dynamodbattribute.Marshal(previousSkipCLA)
+		if marshalErr != nil {
+			log.WithFields(f).WithError(marshalErr).Warn("unable to marshal the previous skip_cla configuration")
+			return marshalErr
+		}
+		conditionExpression = "#S = :p"
+		expressionAttributeValues[":p"] = previousValue
+	} else {
+		expressionAttributeValues[":zero"] = &dynamodb.AttributeValue{N: aws.String("0")}
+	}
+
+	if len(skipCLA) > 0 {
+		skipCLAValue, marshalErr := dynamodbattribute.Marshal(skipCLA)
+		if marshalErr != nil {
+			log.WithFields(f).WithError(marshalErr).Warn("unable to marshal skip_cla configuration")
+			return marshalErr
+		}
+		expressionAttributeValues[":s"] = skipCLAValue
+		updateExpression = "SET #M = :m, #S = :s"
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			GitLabOrganizationsOrganizationIDColumn: {
+				S: aws.String(gitlabOrg.OrganizationID),
+			},
+		},
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		UpdateExpression:          &updateExpression,
+		ConditionExpression:       aws.String(conditionExpression),
+		TableName:                 aws.String(repo.gitlabOrgTableName),
+	}
+
+	log.WithFields(f).Debugf("updating gitlab organization skip_cla configuration: %+v", skipCLA)
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		if aerr, ok := updateErr.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Warn("the skip_cla configuration changed since it was loaded")
+			return &utils.SkipCLAConflict{OrganizationName: gitlabOrg.OrganizationName, Err: updateErr}
+		}
+		log.WithFields(f).WithError(updateErr).Warnf("unable to update Gitlab organization skip_cla configuration, error: %+v", updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
 // UpdateGitLabOrganization updates the GitLab group based on the specified values
 func (repo *Repository) UpdateGitLabOrganization(ctx context.Context, input *common.GitLabAddOrganization, enabled bool) error {
 	f := logrus.Fields{
@@ -640,6 +725,12 @@ func (repo *Repository) UpdateGitLabOrganization(ctx context.Context, input *com
 	}
 	updateExpression := "SET #AE = :ae, #AECLA = :aecla, #BP = :bp, #M = :m, #E = :e, #N = :n "
 
+	if input.CommitIdentityPolicy != "" {
+		expressionAttributeNames["#CIP"] = aws.String(GitLabOrganizationsCommitIdentityPolicyColumn)
+		expressionAttributeValues[":cip"] = &dynamodb.AttributeValue{S: aws.String(input.CommitIdentityPolicy)}
+		updateExpression = fmt.Sprintf("%s, #CIP = :cip ", updateExpression)
+	}
+
 	if input.OrganizationName != "" {
 		expressionAttributeNames["#N"] = aws.String(GitLabOrganizationsOrganizationNameColumn)
 		expressionAttributeValues[":n"] = &dynamodb.AttributeValue{S: aws.String(input.OrganizationName)}
@@ -29,6 +29,37 @@ func Configure(api *operations.EasyclaAPI, service v1Health.Service) {
 		}
 		return health.NewHealthCheckOK().WithXRequestID(reqID).WithPayload(&response)
 	})
+
+	api.HealthHealthLivenessHandler = health.HealthLivenessHandlerFunc(func(params health.HealthLivenessParams) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		result, err := service.Liveness(params.HTTPRequest.Context())
+		if err != nil {
+			return health.NewHealthLivenessBadRequest().WithPayload(errorResponse(err))
+		}
+		var response models.Health
+		err = copier.Copy(&response, result)
+		if err != nil {
+			return health.NewHealthLivenessBadRequest().WithPayload(errorResponse(err))
+		}
+		return health.NewHealthLivenessOK().WithXRequestID(reqID).WithPayload(&response)
+	})
+
+	api.HealthHealthReadinessHandler = health.HealthReadinessHandlerFunc(func(params health.HealthReadinessParams) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		result, err := service.Readiness(params.HTTPRequest.Context())
+		if err != nil {
+			return health.NewHealthReadinessBadRequest().WithPayload(errorResponse(err))
+		}
+		var response models.Health
+		err = copier.Copy(&response, result)
+		if err != nil {
+			return health.NewHealthReadinessBadRequest().WithPayload(errorResponse(err))
+		}
+		if !v1Health.IsHealthy(result) {
+			return health.NewHealthReadinessServiceUnavailable().WithXRequestID(reqID).WithPayload(&response)
+		}
+		return health.NewHealthReadinessOK().WithXRequestID(reqID).WithPayload(&response)
+	})
 }
 
 type codedResponse interface {
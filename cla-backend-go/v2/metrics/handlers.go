@@ -126,6 +126,59 @@ func Configure(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Comp
 			}
 			return metrics.NewListCompanyProjectMetricsOK().WithXRequestID(reqID).WithPayload(result)
 		})
+
+	api.MetricsGetProjectMetricsTrendHandler = metrics.GetProjectMetricsTrendHandlerFunc(
+		func(params metrics.GetProjectMetricsTrendParams, user *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			result, err := service.GetMetricsTrend(MetricTypeProject, params.ClaGroupID, params.Granularity, params.From, params.To)
+			if err != nil {
+				return metrics.NewGetProjectMetricsTrendBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+			return metrics.NewGetProjectMetricsTrendOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.MetricsGetCompanyMetricsTrendHandler = metrics.GetCompanyMetricsTrendHandlerFunc(
+		func(params metrics.GetCompanyMetricsTrendParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "MetricsGetCompanyMetricsTrendHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companyID":      params.CompanyID,
+			}
+			// Lookup the company by internal ID
+			log.WithFields(f).Debugf("looking up company by internal ID...")
+			company, compErr := v1CompanyRepo.GetCompany(ctx, params.CompanyID)
+			if compErr != nil {
+				log.WithFields(f).Warnf("unable to fetch company by ID:%s ", params.CompanyID)
+				return metrics.NewGetCompanyMetricsTrendBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, compErr))
+			}
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForOrganization(ctx, authUser, company.CompanyExternalID, utils.ALLOW_ADMIN_SCOPE) {
+				return metrics.NewGetCompanyMetricsTrendForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Get Company Metrics Trend with Organization scope of %s",
+						authUser.UserName, company.CompanyExternalID),
+					XRequestID: reqID,
+				})
+			}
+
+			result, err := service.GetMetricsTrend(MetricTypeCompany, params.CompanyID, params.Granularity, params.From, params.To)
+			if err != nil {
+				return metrics.NewGetCompanyMetricsTrendBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+			return metrics.NewGetCompanyMetricsTrendOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.MetricsGetFoundationMetricsTrendHandler = metrics.GetFoundationMetricsTrendHandlerFunc(
+		func(params metrics.GetFoundationMetricsTrendParams, user *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			result, err := service.GetMetricsTrend(MetricTypeFoundation, params.FoundationSFID, params.Granularity, params.From, params.To)
+			if err != nil {
+				return metrics.NewGetFoundationMetricsTrendBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+			return metrics.NewGetFoundationMetricsTrendOK().WithXRequestID(reqID).WithPayload(result)
+		})
 }
 
 type codedResponse interface {
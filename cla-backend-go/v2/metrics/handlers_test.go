@@ -0,0 +1,45 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/golang/mock/gomock"
+	mock_company "github.com/linuxfoundation/easycla/cla-backend-go/company/mocks"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCompanyMetricsTrendForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	companyRepo := mock_company.NewMockIRepository(ctrl)
+	companyRepo.EXPECT().GetCompany(gomock.Any(), "company-1").Return(&v1Models.Company{
+		CompanyID:         "company-1",
+		CompanyExternalID: "company-sfid-1",
+	}, nil)
+
+	// the service is not called when the user has no access to the company
+	api := &operations.EasyclaAPI{}
+	Configure(api, nil, companyRepo)
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/metrics/trends/company/company-1", nil)
+	assert.NoError(t, err)
+	response := api.MetricsGetCompanyMetricsTrendHandler.Handle(metrics.GetCompanyMetricsTrendParams{
+		HTTPRequest: request,
+		CompanyID:   "company-1",
+	}, &auth.User{UserName: "contributor"})
+
+	forbidden, ok := response.(*metrics.GetCompanyMetricsTrendForbidden)
+	if assert.True(t, ok, "expected a forbidden response, got %T", response) {
+		assert.Equal(t, "403", forbidden.Payload.Code)
+	}
+}
@@ -44,7 +44,9 @@ const (
 
 // Repository provides methods for calculation,storage and retrieval of metrics
 type Repository interface {
-	CalculateAndSaveMetrics() error
+	CalculateAndSaveMetrics() (*Metrics, error)
+	SaveMetricsSnapshots(m *Metrics, snapshotTime time.Time, retention time.Duration) error
+	GetMetricsSnapshots(metricType, id, fromDate, toDate string) ([]*Snapshot, error)
 	GetClaManagerDistribution() (*ClaManagersDistribution, error)
 	GetTotalCountMetrics() (*TotalCountMetrics, error)
 	GetCompanyMetrics() ([]*CompanyMetric, error)
@@ -57,6 +59,7 @@ type Repository interface {
 
 type repo struct {
 	metricTableName       string
+	snapshotTableName     string
 	dynamoDBClient        *dynamodb.DynamoDB
 	stage                 string
 	apiGatewayURL         string
@@ -68,6 +71,7 @@ func NewRepository(awsSession *session.Session, stage string, apiGwURL string, p
 	return &repo{
 		dynamoDBClient:        dynamodb.New(awsSession),
 		metricTableName:       fmt.Sprintf("cla-%s-metrics", stage),
+		snapshotTableName:     fmt.Sprintf("cla-%s-metrics-snapshots", stage),
 		stage:                 stage,
 		apiGatewayURL:         apiGwURL,
 		projectsClaGroupsRepo: pcgRepo,
@@ -1095,23 +1099,90 @@ func (repo *repo) projectHelperMap(in *CompanyProjectMetrics, claGroupMapping ma
 	return filterProjectMap
 }
 
-func (repo *repo) CalculateAndSaveMetrics() error {
+// CalculateAndSaveMetrics calculates the metrics and replaces the current metrics, the calculated metrics are
+// returned so they can be saved as a snapshot
+func (repo *repo) CalculateAndSaveMetrics() (*Metrics, error) {
 	timeBeforeStartingMetricsCalculation := time.Now()
 	m, err := repo.calculateMetrics()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	err = repo.saveMetrics(m)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	err = repo.clearOldMetrics(timeBeforeStartingMetricsCalculation)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SaveMetricsSnapshots saves the metrics as the snapshots of the day, a later snapshot of the same day replaces the
+// previous one - the snapshots expire after the retention period, they are kept forever when the retention is zero
+func (repo *repo) SaveMetricsSnapshots(m *Metrics, snapshotTime time.Time, retention time.Duration) error {
+	t := time.Now()
+	claGroupMapping, err := repo.getClaGroupProjectsMapping()
 	if err != nil {
 		return err
 	}
+
+	snapshots := newSnapshots(m, claGroupMapping, snapshotTime, retention)
+	log.Printf("saving %d metrics snapshots", len(snapshots))
+	for _, snapshot := range snapshots {
+		av, err := dynamodbattribute.MarshalMap(snapshot)
+		if err != nil {
+			return err
+		}
+		_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+			Item:      av,
+			TableName: aws.String(repo.snapshotTableName),
+		})
+		if err != nil {
+			log.Printf("cannot put metrics snapshot in dynamodb, key = %s, error = %s\n", snapshot.SnapshotKey, err.Error())
+			return err
+		}
+	}
+	log.Printf("saving metrics snapshots took :%s \n", time.Since(t).String())
 	return nil
 }
 
+// GetMetricsSnapshots returns the daily snapshots of the metric between the dates, both dates are included
+func (repo *repo) GetMetricsSnapshots(metricType, id, fromDate, toDate string) ([]*Snapshot, error) {
+	condition := expression.Key("snapshot_key").Equal(expression.Value(snapshotKey(metricType, id))).
+		And(expression.Key("snapshot_date").Between(expression.Value(fromDate), expression.Value(toDate)))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.snapshotTableName),
+	}
+
+	out := make([]*Snapshot, 0)
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.Warnf("error retrieving metrics snapshots of %s. error = %s", snapshotKey(metricType, id), err.Error())
+			return nil, err
+		}
+		var page []*Snapshot
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, page...)
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return out, nil
+}
+
 func (repo *repo) GetClaManagerDistribution() (*ClaManagersDistribution, error) {
 	var out ClaManagersDistribution
 	err := repo.getMetricByID(IDClaManagerDistribution, MetricTypeClaManagerDistribution, &out)
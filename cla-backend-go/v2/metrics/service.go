@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 
@@ -37,6 +38,7 @@ type Service interface {
 	GetTopProjects() (*models.TopProjects, error)
 	ListProjectMetrics(paramPageSize *int64, paramNextKey *string) (*models.ListProjectMetric, error)
 	ListCompanyProjectMetrics(ctx context.Context, companyID string, projectSFID string) (*models.CompanyProjectMetrics, error)
+	GetMetricsTrend(metricType, id string, granularity, fromDate, toDate *string) (*models.MetricsTrend, error)
 }
 
 type service struct {
@@ -326,3 +328,48 @@ func (s *service) ListCompanyProjectMetrics(ctx context.Context, companyID strin
 	})
 	return out, nil
 }
+
+// GetMetricsTrend returns the weekly or monthly time series of the project, company or foundation metrics, the trend
+// covers the last year when the dates are not set
+func (s *service) GetMetricsTrend(metricType, id string, granularity, fromDate, toDate *string) (*models.MetricsTrend, error) {
+	trendGranularity := GranularityMonth
+	if granularity != nil && *granularity != "" {
+		trendGranularity = *granularity
+	}
+	if trendGranularity != GranularityWeek && trendGranularity != GranularityMonth {
+		return nil, errors.New("invalid granularity")
+	}
+
+	to := time.Now().UTC()
+	if toDate != nil && *toDate != "" {
+		parsed, err := time.Parse(SnapshotDateFormat, *toDate)
+		if err != nil {
+			return nil, errors.New("invalid to date")
+		}
+		to = parsed
+	}
+	from := to.AddDate(-1, 0, 1)
+	if fromDate != nil && *fromDate != "" {
+		parsed, err := time.Parse(SnapshotDateFormat, *fromDate)
+		if err != nil {
+			return nil, errors.New("invalid from date")
+		}
+		from = parsed
+	}
+	if from.After(to) {
+		return nil, errors.New("the from date is after the to date")
+	}
+
+	snapshots, err := s.metricsRepo.GetMetricsSnapshots(metricType, id, from.Format(SnapshotDateFormat), to.Format(SnapshotDateFormat))
+	if err != nil {
+		return nil, err
+	}
+	return &models.MetricsTrend{
+		ID:          id,
+		MetricType:  metricType,
+		Granularity: trendGranularity,
+		From:        from.Format(SnapshotDateFormat),
+		To:          to.Format(SnapshotDateFormat),
+		Points:      buildTrend(snapshots, trendGranularity),
+	}, nil
+}
@@ -0,0 +1,218 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+)
+
+// MetricTypeFoundation is the metric type of the foundation snapshots, the rollup of the metrics of the foundation CLA groups
+const MetricTypeFoundation = "foundation"
+
+// snapshot date and trend constants
+const (
+	SnapshotDateFormat = "2006-01-02"
+
+	// DefaultSnapshotRetention is the default period the daily metrics snapshots are kept
+	DefaultSnapshotRetention = 2 * 365 * 24 * time.Hour
+
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+// Snapshot contains the metrics of a project, a company, a foundation or the totals on a day
+type Snapshot struct {
+	SnapshotKey                 string `json:"snapshot_key"`
+	SnapshotDate                string `json:"snapshot_date"`
+	MetricType                  string `json:"metric_type"`
+	ID                          string `json:"id"`
+	Name                        string `json:"name,omitempty"`
+	CompaniesCount              int64  `json:"companies_count"`
+	ClaManagersCount            int64  `json:"cla_managers_count"`
+	CorporateContributorsCount  int64  `json:"corporate_contributors_count"`
+	IndividualContributorsCount int64  `json:"individual_contributors_count"`
+	TotalContributorsCount      int64  `json:"total_contributors_count"`
+	RepositoriesCount           int64  `json:"repositories_count"`
+	ProjectsCount               int64  `json:"projects_count"`
+	CreatedAt                   string `json:"created_at"`
+	// Expires is the DynamoDB TTL of the snapshot, zero when the snapshots are kept forever
+	Expires int64 `json:"expires,omitempty"`
+}
+
+func snapshotKey(metricType, id string) string {
+	return fmt.Sprintf("%s#%s", metricType, id)
+}
+
+// newSnapshots creates the snapshots of the calculated metrics, the foundation snapshots roll up the project metrics
+// of the CLA groups of each foundation
+func newSnapshots(m *Metrics, claGroups map[string]*claGroup, snapshotTime time.Time, retention time.Duration) []*Snapshot {
+	var snapshots []*Snapshot
+	add := func(s *Snapshot) {
+		s.SnapshotKey = snapshotKey(s.MetricType, s.ID)
+		s.SnapshotDate = snapshotTime.UTC().Format(SnapshotDateFormat)
+		s.CreatedAt = snapshotTime.UTC().Format(time.RFC3339)
+		if retention > 0 {
+			s.Expires = snapshotTime.Add(retention).Unix()
+		}
+		snapshots = append(snapshots, s)
+	}
+
+	if tcm := m.TotalCountMetrics; tcm != nil {
+		add(&Snapshot{
+			MetricType:                  MetricTypeTotalCount,
+			ID:                          IDTotalCount,
+			CompaniesCount:              tcm.CompaniesCount,
+			ClaManagersCount:            tcm.ClaManagersCount,
+			CorporateContributorsCount:  tcm.CorporateContributorsCount,
+			IndividualContributorsCount: tcm.IndividualContributorsCount,
+			TotalContributorsCount:      tcm.ContributorsCount,
+			RepositoriesCount:           tcm.GithubRepositoriesCount + tcm.GerritRepositoriesCount,
+			ProjectsCount:               tcm.ProjectsCount,
+		})
+	}
+
+	if m.CompanyMetrics != nil {
+		for id, cm := range m.CompanyMetrics.CompanyMetrics {
+			add(&Snapshot{
+				MetricType:                 MetricTypeCompany,
+				ID:                         id,
+				Name:                       cm.CompanyName,
+				ClaManagersCount:           cm.ClaManagersCount,
+				CorporateContributorsCount: cm.CorporateContributorsCount,
+				TotalContributorsCount:     cm.CorporateContributorsCount,
+				ProjectsCount:              cm.ProjectCount,
+			})
+		}
+	}
+
+	if m.ProjectMetrics != nil {
+		foundations := make(map[string]*foundationRollup)
+		for id, pm := range m.ProjectMetrics.ProjectMetrics {
+			add(&Snapshot{
+				MetricType:                  MetricTypeProject,
+				ID:                          id,
+				Name:                        pm.ProjectName,
+				CompaniesCount:              pm.CompaniesCount,
+				ClaManagersCount:            pm.ClaManagersCount,
+				CorporateContributorsCount:  pm.CorporateContributorsCount,
+				IndividualContributorsCount: pm.IndividualContributorsCount,
+				TotalContributorsCount:      pm.TotalContributorsCount,
+				RepositoriesCount:           pm.RepositoriesCount,
+				ProjectsCount:               1,
+			})
+
+			cg, ok := claGroups[id]
+			if !ok || cg.foundationSFID == "" {
+				continue
+			}
+			fr, ok := foundations[cg.foundationSFID]
+			if !ok {
+				fr = newFoundationRollup(cg.foundationSFID)
+				foundations[cg.foundationSFID] = fr
+			}
+			fr.addProject(pm)
+		}
+		for _, fr := range foundations {
+			add(fr.snapshot)
+		}
+	}
+
+	return snapshots
+}
+
+// foundationRollup accumulates the metrics of the CLA groups of a foundation, the companies, CLA managers and
+// contributors are counted once by ID even when they signed for several CLA groups of the foundation
+type foundationRollup struct {
+	snapshot               *Snapshot
+	companies              map[string]interface{}
+	claManagers            map[string]interface{}
+	corporateContributors  map[string]interface{}
+	individualContributors map[string]interface{}
+	contributors           map[string]interface{}
+}
+
+func newFoundationRollup(foundationSFID string) *foundationRollup {
+	return &foundationRollup{
+		snapshot:               &Snapshot{MetricType: MetricTypeFoundation, ID: foundationSFID},
+		companies:              make(map[string]interface{}),
+		claManagers:            make(map[string]interface{}),
+		corporateContributors:  make(map[string]interface{}),
+		individualContributors: make(map[string]interface{}),
+		contributors:           make(map[string]interface{}),
+	}
+}
+
+// addProject adds the metrics of a CLA group of the foundation, the total contributors are the users with an
+// individual or a corporate signature in any CLA group of the foundation
+func (fr *foundationRollup) addProject(pm *ProjectMetric) {
+	fs := fr.snapshot
+	for companyID := range pm.companies {
+		increaseCountIfNotPresent(fr.companies, &fs.CompaniesCount, companyID)
+	}
+	for lfUsername := range pm.claManagers {
+		increaseCountIfNotPresent(fr.claManagers, &fs.ClaManagersCount, lfUsername)
+	}
+	for userID := range pm.corporateContributors {
+		increaseCountIfNotPresent(fr.corporateContributors, &fs.CorporateContributorsCount, userID)
+		increaseCountIfNotPresent(fr.contributors, &fs.TotalContributorsCount, userID)
+	}
+	for userID := range pm.individualContributors {
+		increaseCountIfNotPresent(fr.individualContributors, &fs.IndividualContributorsCount, userID)
+		increaseCountIfNotPresent(fr.contributors, &fs.TotalContributorsCount, userID)
+	}
+	fs.RepositoriesCount += pm.RepositoriesCount
+	fs.ProjectsCount++
+}
+
+// periodOf returns the label and the first day of the week or the month of the date
+func periodOf(date time.Time, granularity string) (string, time.Time) {
+	if granularity == GranularityWeek {
+		year, week := date.ISOWeek()
+		// the ISO weeks start on Monday
+		offset := (int(date.Weekday()) + 6) % 7
+		return fmt.Sprintf("%d-W%02d", year, week), date.AddDate(0, 0, -offset)
+	}
+	return date.Format("2006-01"), time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// buildTrend groups the daily snapshots by week or month, each point has the metrics of the last snapshot of its period
+func buildTrend(snapshots []*Snapshot, granularity string) []*models.MetricsTrendPoint {
+	sorted := make([]*Snapshot, 0, len(snapshots))
+	sorted = append(sorted, snapshots...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].SnapshotDate < sorted[j].SnapshotDate
+	})
+
+	points := make([]*models.MetricsTrendPoint, 0)
+	for _, s := range sorted {
+		date, err := time.Parse(SnapshotDateFormat, s.SnapshotDate)
+		if err != nil {
+			continue
+		}
+		period, periodStart := periodOf(date, granularity)
+		point := &models.MetricsTrendPoint{
+			Period:                      period,
+			PeriodStart:                 periodStart.Format(SnapshotDateFormat),
+			SnapshotDate:                s.SnapshotDate,
+			CompaniesCount:              s.CompaniesCount,
+			ClaManagersCount:            s.ClaManagersCount,
+			CorporateContributorsCount:  s.CorporateContributorsCount,
+			IndividualContributorsCount: s.IndividualContributorsCount,
+			TotalContributorsCount:      s.TotalContributorsCount,
+			RepositoriesCount:           s.RepositoriesCount,
+			ProjectsCount:               s.ProjectsCount,
+		}
+		// the snapshots are sorted by date, a later snapshot of the same period replaces the previous point
+		if len(points) > 0 && points[len(points)-1].Period == period {
+			points[len(points)-1] = point
+			continue
+		}
+		points = append(points, point)
+	}
+	return points
+}
@@ -0,0 +1,114 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func keySet(keys ...string) map[string]interface{} {
+	set := make(map[string]interface{})
+	for _, key := range keys {
+		set[key] = nil
+	}
+	return set
+}
+
+func TestNewSnapshots(t *testing.T) {
+	m := &Metrics{
+		TotalCountMetrics: &TotalCountMetrics{CompaniesCount: 3, ContributorsCount: 10, GithubRepositoriesCount: 4, GerritRepositoriesCount: 1},
+		CompanyMetrics: &CompanyMetrics{CompanyMetrics: map[string]*CompanyMetric{
+			"company-1": {CompanyName: "Acme", ProjectCount: 2, CorporateContributorsCount: 5, ClaManagersCount: 1},
+		}},
+		ProjectMetrics: &ProjectMetrics{ProjectMetrics: map[string]*ProjectMetric{
+			"cla-group-1": {ProjectName: "One", CompaniesCount: 2, TotalContributorsCount: 4, RepositoriesCount: 3,
+				companies:              keySet("company-1", "company-2"),
+				claManagers:            keySet("manager-1"),
+				corporateContributors:  keySet("user-1", "user-2"),
+				individualContributors: keySet("user-3", "user-4"),
+			},
+			// the second CLA group shares a company, a CLA manager and contributors with the first one
+			"cla-group-2": {ProjectName: "Two", CompaniesCount: 1, TotalContributorsCount: 6, RepositoriesCount: 1,
+				companies:              keySet("company-1"),
+				claManagers:            keySet("manager-1", "manager-2"),
+				corporateContributors:  keySet("user-1", "user-5", "user-6"),
+				individualContributors: keySet("user-2", "user-3", "user-7"),
+			},
+			"cla-group-3": {ProjectName: "Standalone", CompaniesCount: 7},
+		}},
+	}
+	claGroups := map[string]*claGroup{
+		"cla-group-1": {claGroupID: "cla-group-1", foundationSFID: "foundation-1"},
+		"cla-group-2": {claGroupID: "cla-group-2", foundationSFID: "foundation-1"},
+	}
+	snapshotTime := time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC)
+
+	snapshots := newSnapshots(m, claGroups, snapshotTime, 48*time.Hour)
+	byKey := make(map[string]*Snapshot)
+	for _, s := range snapshots {
+		assert.Equal(t, "2026-10-18", s.SnapshotDate)
+		assert.Equal(t, snapshotTime.Add(48*time.Hour).Unix(), s.Expires)
+		byKey[s.SnapshotKey] = s
+	}
+	assert.Len(t, snapshots, 6)
+
+	total := byKey["total_count#total_count"]
+	assert.Equal(t, int64(5), total.RepositoriesCount)
+	assert.Equal(t, int64(10), total.TotalContributorsCount)
+
+	company := byKey["company#company-1"]
+	assert.Equal(t, "Acme", company.Name)
+	assert.Equal(t, int64(2), company.ProjectsCount)
+
+	// the companies, CLA managers and contributors of both CLA groups are counted once
+	foundation := byKey["foundation#foundation-1"]
+	assert.Equal(t, int64(2), foundation.CompaniesCount)
+	assert.Equal(t, int64(2), foundation.ClaManagersCount)
+	assert.Equal(t, int64(4), foundation.CorporateContributorsCount)
+	assert.Equal(t, int64(4), foundation.IndividualContributorsCount)
+	assert.Equal(t, int64(7), foundation.TotalContributorsCount)
+	assert.Equal(t, int64(4), foundation.RepositoriesCount)
+	assert.Equal(t, int64(2), foundation.ProjectsCount)
+
+	// no expiry when the snapshots are kept forever
+	for _, s := range newSnapshots(m, claGroups, snapshotTime, 0) {
+		assert.Zero(t, s.Expires)
+	}
+}
+
+func TestBuildTrend(t *testing.T) {
+	snapshots := []*Snapshot{
+		{SnapshotDate: "2026-10-13", CompaniesCount: 12},
+		{SnapshotDate: "2026-09-30", CompaniesCount: 10},
+		{SnapshotDate: "2026-10-05", CompaniesCount: 11},
+		{SnapshotDate: "2026-09-01", CompaniesCount: 8},
+		{SnapshotDate: "2026-10-04", CompaniesCount: 10},
+	}
+
+	monthly := buildTrend(snapshots, GranularityMonth)
+	if assert.Len(t, monthly, 2) {
+		assert.Equal(t, "2026-09", monthly[0].Period)
+		assert.Equal(t, "2026-09-01", monthly[0].PeriodStart)
+		assert.Equal(t, "2026-09-30", monthly[0].SnapshotDate)
+		assert.Equal(t, int64(10), monthly[0].CompaniesCount)
+		assert.Equal(t, "2026-10", monthly[1].Period)
+		assert.Equal(t, int64(12), monthly[1].CompaniesCount)
+	}
+
+	weekly := buildTrend(snapshots, GranularityWeek)
+	if assert.Len(t, weekly, 4) {
+		// 2026-10-04 is a Sunday, the last day of the week which started on Monday 2026-09-28
+		assert.Equal(t, "2026-W40", weekly[1].Period)
+		assert.Equal(t, "2026-09-28", weekly[1].PeriodStart)
+		assert.Equal(t, "2026-10-04", weekly[1].SnapshotDate)
+		assert.Equal(t, "2026-W41", weekly[2].Period)
+		assert.Equal(t, "2026-10-05", weekly[2].PeriodStart)
+		assert.Equal(t, "2026-W42", weekly[3].Period)
+	}
+
+	assert.Empty(t, buildTrend(nil, GranularityMonth))
+}
@@ -0,0 +1,504 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/store"
+	"github.com/sirupsen/logrus"
+)
+
+// click-through session status values
+const (
+	ClickThroughStatusSent      = "sent"
+	ClickThroughStatusCompleted = "completed"
+	ClickThroughStatusVoided    = "voided"
+)
+
+// DefaultClickThroughSessionTTL is how long a click-through envelope can be signed after it was created
+const DefaultClickThroughSessionTTL = 7 * 24 * time.Hour
+
+const clickThroughEnvelopePrefix = "click-through-"
+
+// errors
+var (
+	ErrClickThroughSessionNotFound = errors.New("click-through signing session not found or expired")
+	ErrClickThroughNotPending      = errors.New("click-through signing session is no longer pending")
+	ErrClickThroughConsentRequired = errors.New("consent and the typed full name are required to sign")
+)
+
+// ClickThroughEvidence is the consent evidence captured when the signer accepts the agreement
+type ClickThroughEvidence struct {
+	TypedName   string `json:"typed_name"`
+	Email       string `json:"email"`
+	IPAddress   string `json:"ip_address"`
+	UserAgent   string `json:"user_agent"`
+	ConsentedAt string `json:"consented_at"`
+}
+
+// ClickThroughSession is the state of a click-through envelope
+type ClickThroughSession struct {
+	EnvelopeID   string                `json:"envelope_id"`
+	SignatureID  string                `json:"signature_id"`
+	ProjectID    string                `json:"project_id"`
+	DocumentID   string                `json:"document_id"`
+	DocumentName string                `json:"document_name"`
+	SignerName   string                `json:"signer_name"`
+	SignerEmail  string                `json:"signer_email"`
+	CallbackURL  string                `json:"callback_url"`
+	ReturnURL    string                `json:"return_url"`
+	Status       string                `json:"status"`
+	Created      string                `json:"created"`
+	Expires      int64                 `json:"expires"`
+	Evidence     *ClickThroughEvidence `json:"evidence,omitempty"`
+}
+
+// clickThroughNotification is the minimal envelope information document posted to the signed callback
+type clickThroughNotification struct {
+	XMLName    xml.Name `xml:"DocuSignEnvelopeInformation"`
+	EnvelopeID string   `xml:"EnvelopeStatus>EnvelopeID"`
+	Status     string   `xml:"EnvelopeStatus>Status"`
+}
+
+// ClickThroughProvider is a built-in e-signature provider which renders the CLA PDF, captures the typed
+// name consent of the signer with IP address and timestamp evidence and stores the signed PDF in S3
+type ClickThroughProvider struct {
+	apiURL     string
+	store      store.Repository
+	sessionTTL time.Duration
+	httpClient *http.Client
+}
+
+// NewClickThroughProvider returns a click-through provider serving the sign pages from the v4 API URL
+func NewClickThroughProvider(apiURL string, storeRepository store.Repository) *ClickThroughProvider {
+	return &ClickThroughProvider{
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		store:      storeRepository,
+		sessionTTL: DefaultClickThroughSessionTTL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name returns the provider name
+func (p *ClickThroughProvider) Name() string {
+	return ProviderClickThrough
+}
+
+// OwnsEnvelope returns true for envelope IDs issued by the click-through provider
+func (p *ClickThroughProvider) OwnsEnvelope(envelopeID string) bool {
+	return strings.HasPrefix(envelopeID, clickThroughEnvelopePrefix)
+}
+
+// SignURL returns the page where the signer reviews and accepts the envelope
+func (p *ClickThroughProvider) SignURL(envelopeID string) string {
+	return fmt.Sprintf("%s/v4/sign/click-through/%s", p.apiURL, envelopeID)
+}
+
+// CreateEnvelope stores the unsigned document and the signing session, emailing the sign URL for non-embedded requests
+func (p *ClickThroughProvider) CreateEnvelope(ctx context.Context, request *EnvelopeRequest) (*Envelope, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.ClickThroughProvider.CreateEnvelope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    request.SignatureID,
+		"embedded":       request.Embedded,
+	}
+
+	if len(request.PDF) == 0 {
+		return nil, errors.New("missing document to sign")
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	envelopeID := clickThroughEnvelopePrefix + id.String()
+	f["envelopeID"] = envelopeID
+
+	log.WithFields(f).Debug("storing unsigned document...")
+	if err = utils.PutToS3(clickThroughDocumentKey(envelopeID, false), request.PDF); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to store unsigned document")
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	session := &ClickThroughSession{
+		EnvelopeID:   envelopeID,
+		SignatureID:  request.SignatureID,
+		ProjectID:    request.ProjectID,
+		DocumentID:   request.DocumentID,
+		DocumentName: request.DocumentName,
+		SignerName:   request.SignerName,
+		SignerEmail:  request.SignerEmail,
+		CallbackURL:  request.CallbackURL,
+		ReturnURL:    request.ReturnURL,
+		Status:       ClickThroughStatusSent,
+		Created:      now.Format(time.RFC3339),
+		Expires:      now.Add(p.sessionTTL).Unix(),
+	}
+	if err = p.saveSession(ctx, session); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save click-through session")
+		return nil, err
+	}
+
+	envelope := &Envelope{
+		EnvelopeID: envelopeID,
+		SignURL:    p.SignURL(envelopeID),
+	}
+
+	if !request.Embedded {
+		body := request.EmailBody + fmt.Sprintf("<p>Please review and sign the document at <a href=\"%s\">%s</a>.</p>", envelope.SignURL, envelope.SignURL)
+		log.WithFields(f).Debugf("emailing sign url to: %s", request.SignerEmail)
+		if err = utils.SendEmail(request.EmailSubject, body, []string{request.SignerEmail}); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to email sign url to: %s", request.SignerEmail)
+			return nil, err
+		}
+		envelope.SignURL = ""
+	}
+
+	return envelope, nil
+}
+
+// VoidEnvelope marks a pending session as voided so it can no longer be signed
+func (p *ClickThroughProvider) VoidEnvelope(ctx context.Context, envelopeID, message string) error {
+	session, err := p.GetSession(ctx, envelopeID)
+	if err != nil {
+		if errors.Is(err, ErrClickThroughSessionNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if session.Status != ClickThroughStatusSent {
+		return nil
+	}
+
+	log.WithFields(logrus.Fields{
+		"functionName":   "v2.ClickThroughProvider.VoidEnvelope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"envelopeID":     envelopeID,
+	}).Debugf("voiding envelope: %s", message)
+	session.Status = ClickThroughStatusVoided
+	return p.saveSession(ctx, session)
+}
+
+// ParseCompletion resolves the envelope ID in the notification against the stored session - the status,
+// signer and evidence always come from the session so a forged payload cannot complete an unsigned envelope
+func (p *ClickThroughProvider) ParseCompletion(ctx context.Context, payload []byte) (*EnvelopeCompletion, error) {
+	var notification clickThroughNotification
+	if err := xml.Unmarshal(payload, &notification); err != nil {
+		return nil, err
+	}
+
+	session, err := p.GetSession(ctx, notification.EnvelopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	completion := &EnvelopeCompletion{
+		EnvelopeID:      session.EnvelopeID,
+		SignatureID:     session.SignatureID,
+		DocumentID:      session.DocumentID,
+		Status:          session.Status,
+		RecipientStatus: session.Status,
+	}
+	if session.Status == ClickThroughStatusCompleted && session.Evidence != nil {
+		completion.Status = EnvelopeCompleted
+		completion.RecipientStatus = EnvelopeCompleted
+		completion.SignedDate = session.Evidence.ConsentedAt
+		completion.FullName = session.Evidence.TypedName
+	}
+
+	return completion, nil
+}
+
+// GetSignedDocument returns the stamped PDF stored when the signer consented
+func (p *ClickThroughProvider) GetSignedDocument(ctx context.Context, envelopeID, documentID string) ([]byte, error) {
+	return utils.DownloadFromS3(clickThroughDocumentKey(envelopeID, true))
+}
+
+// GetDocument returns the unsigned PDF for review on the sign page
+func (p *ClickThroughProvider) GetDocument(ctx context.Context, envelopeID string) ([]byte, error) {
+	if _, err := p.GetSession(ctx, envelopeID); err != nil {
+		return nil, err
+	}
+	return utils.DownloadFromS3(clickThroughDocumentKey(envelopeID, false))
+}
+
+// GetEnvelopeState returns the status of the click-through session, sessions which expired before the
+// signer consented are reported as voided
+func (p *ClickThroughProvider) GetEnvelopeState(ctx context.Context, envelopeID string) (*EnvelopeState, error) {
+	session, err := p.GetSession(ctx, envelopeID)
+	if err != nil {
+		if errors.Is(err, ErrClickThroughSessionNotFound) {
+			return &EnvelopeState{
+				EnvelopeID: envelopeID,
+				Status:     EnvelopeVoided,
+				Reason:     "click-through session expired",
+			}, nil
+		}
+		return nil, err
+	}
+
+	state := &EnvelopeState{
+		EnvelopeID:    envelopeID,
+		Status:        normalizeEnvelopeStatus(session.Status),
+		StatusChanged: session.Created,
+	}
+	if session.Evidence != nil {
+		state.StatusChanged = session.Evidence.ConsentedAt
+	}
+
+	return state, nil
+}
+
+// GetSession loads the click-through session for the envelope
+func (p *ClickThroughProvider) GetSession(ctx context.Context, envelopeID string) (*ClickThroughSession, error) {
+	if !p.OwnsEnvelope(envelopeID) {
+		return nil, ErrClickThroughSessionNotFound
+	}
+
+	value, err := p.store.GetValue(ctx, clickThroughSessionKey(envelopeID))
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, ErrClickThroughSessionNotFound
+	}
+
+	var session ClickThroughSession
+	if err = json.Unmarshal([]byte(value), &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// Consent records the signer consent, stores the signed PDF stamped with the evidence and notifies the
+// signed callback. Consenting to an already completed session re-sends the notification.
+func (p *ClickThroughProvider) Consent(ctx context.Context, envelopeID string, evidence *ClickThroughEvidence) (*ClickThroughSession, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.ClickThroughProvider.Consent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"envelopeID":     envelopeID,
+	}
+
+	session, err := p.GetSession(ctx, envelopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch session.Status {
+	case ClickThroughStatusCompleted:
+		log.WithFields(f).Debug("session already completed - re-sending notification")
+		return session, p.notify(ctx, session)
+	case ClickThroughStatusSent:
+	default:
+		return nil, ErrClickThroughNotPending
+	}
+
+	evidence.TypedName = strings.Join(strings.Fields(evidence.TypedName), " ")
+	if evidence.TypedName == "" {
+		return nil, ErrClickThroughConsentRequired
+	}
+	evidence.Email = session.SignerEmail
+	evidence.ConsentedAt = time.Now().UTC().Format(time.RFC3339)
+
+	unsigned, err := utils.DownloadFromS3(clickThroughDocumentKey(envelopeID, false))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load unsigned document")
+		return nil, err
+	}
+
+	signed, err := utils.StampPdf(unsigned, clickThroughStamp(session, evidence), map[string]string{
+		"EasyCLAEnvelopeID":  envelopeID,
+		"EasyCLASignatureID": session.SignatureID,
+		"EasyCLASignerName":  evidence.TypedName,
+		"EasyCLASignerEmail": evidence.Email,
+		"EasyCLASignerIP":    evidence.IPAddress,
+		"EasyCLASignerAgent": evidence.UserAgent,
+		"EasyCLASignedAt":    evidence.ConsentedAt,
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to stamp signed document")
+		return nil, err
+	}
+
+	if err = utils.PutToS3(clickThroughDocumentKey(envelopeID, true), signed); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to store signed document")
+		return nil, err
+	}
+
+	session.Status = ClickThroughStatusCompleted
+	session.Evidence = evidence
+	if err = p.saveSession(ctx, session); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save completed session")
+		return nil, err
+	}
+
+	return session, p.notify(ctx, session)
+}
+
+// notify posts the completion notification to the signed callback, mirroring DocuSign Connect
+func (p *ClickThroughProvider) notify(ctx context.Context, session *ClickThroughSession) error {
+	f := logrus.Fields{
+		"functionName":   "v2.ClickThroughProvider.notify",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"envelopeID":     session.EnvelopeID,
+		"callbackURL":    session.CallbackURL,
+	}
+
+	if session.CallbackURL == "" {
+		log.WithFields(f).Warn("no callback url for session - skipping notification")
+		return nil
+	}
+
+	payload, err := xml.Marshal(clickThroughNotification{
+		EnvelopeID: session.EnvelopeID,
+		Status:     EnvelopeCompleted,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, session.CallbackURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem posting completion notification")
+		return err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.WithFields(f).WithError(closeErr).Warn("problem closing the response body")
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body) // nolint
+		log.WithFields(f).Warnf("completion notification failed - status code: %d - response: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("completion notification failed with status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (p *ClickThroughProvider) saveSession(ctx context.Context, session *ClickThroughSession) error {
+	value, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return p.store.SetValue(ctx, clickThroughSessionKey(session.EnvelopeID), session.Expires, string(value))
+}
+
+func clickThroughSessionKey(envelopeID string) string {
+	return fmt.Sprintf("click_through:%s", envelopeID)
+}
+
+func clickThroughDocumentKey(envelopeID string, signed bool) string {
+	name := "unsigned.pdf"
+	if signed {
+		name = "signed.pdf"
+	}
+	return strings.Join([]string{"click-through", envelopeID, name}, "/")
+}
+
+// clickThroughStamp returns the evidence text stamped on the last page of the signed document
+func clickThroughStamp(session *ClickThroughSession, evidence *ClickThroughEvidence) string {
+	lines := []string{
+		fmt.Sprintf("Electronically signed via EasyCLA by %s <%s>", evidence.TypedName, evidence.Email),
+		fmt.Sprintf("Signed: %s   IP address: %s", evidence.ConsentedAt, evidence.IPAddress),
+		fmt.Sprintf("Envelope: %s   Signature: %s", session.EnvelopeID, session.SignatureID),
+	}
+	return strings.Join(lines, "\n")
+}
+
+// clickThroughProvider returns the configured click-through provider
+func (s *service) clickThroughProvider() (*ClickThroughProvider, error) {
+	provider, ok := s.providers.Get(ProviderClickThrough)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, ProviderClickThrough)
+	}
+	clickThrough, ok := provider.(*ClickThroughProvider)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, ProviderClickThrough)
+	}
+	return clickThrough, nil
+}
+
+// GetClickThroughSession returns the click-through signing session for the envelope
+func (s *service) GetClickThroughSession(ctx context.Context, envelopeID string) (*ClickThroughSession, error) {
+	provider, err := s.clickThroughProvider()
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetSession(ctx, envelopeID)
+}
+
+// GetClickThroughDocument returns the unsigned document of the click-through envelope
+func (s *service) GetClickThroughDocument(ctx context.Context, envelopeID string) ([]byte, error) {
+	provider, err := s.clickThroughProvider()
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetDocument(ctx, envelopeID)
+}
+
+// ConsentClickThrough records the signer consent for the click-through envelope
+func (s *service) ConsentClickThrough(ctx context.Context, envelopeID string, evidence *ClickThroughEvidence) (*ClickThroughSession, error) {
+	provider, err := s.clickThroughProvider()
+	if err != nil {
+		return nil, err
+	}
+	return provider.Consent(ctx, envelopeID, evidence)
+}
+
+// renderClickThroughPage renders the sign page showing the document with the consent form
+func renderClickThroughPage(session *ClickThroughSession, message string) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html><html><head><meta charset=\"utf-8\"><title>EasyCLA - Sign ")
+	b.WriteString(html.EscapeString(session.DocumentName))
+	b.WriteString("</title><style>body{font-family:sans-serif;margin:2em}iframe{width:100%;height:70vh;border:1px solid #ccc}.error{color:#b00}</style></head><body>")
+	b.WriteString("<h1>")
+	b.WriteString(html.EscapeString(session.DocumentName))
+	b.WriteString("</h1>")
+	if message != "" {
+		b.WriteString("<p class=\"error\">")
+		b.WriteString(html.EscapeString(message))
+		b.WriteString("</p>")
+	}
+	envelopeID := html.EscapeString(session.EnvelopeID)
+	b.WriteString("<iframe src=\"" + envelopeID + "/document\"></iframe>")
+	switch session.Status {
+	case ClickThroughStatusSent:
+		b.WriteString("<form method=\"post\" action=\"" + envelopeID + "\">")
+		b.WriteString("<p>Signing as " + html.EscapeString(session.SignerEmail) + "</p>")
+		b.WriteString("<p><label>Type your full name <input type=\"text\" name=\"full_name\" required value=\"" + html.EscapeString(session.SignerName) + "\"></label></p>")
+		b.WriteString("<p><label><input type=\"checkbox\" name=\"consent\" value=\"true\" required> I have read and agree to the terms of this agreement and intend my typed name to be my electronic signature.</label></p>")
+		b.WriteString("<p><button type=\"submit\">Sign</button></p></form>")
+	case ClickThroughStatusCompleted:
+		b.WriteString("<p>This document has been signed.</p>")
+	default:
+		b.WriteString("<p>This signing request is no longer valid. Please start the signing process again.</p>")
+	}
+	b.WriteString("</body></html>")
+	return b.String()
+}
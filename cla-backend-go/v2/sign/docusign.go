@@ -4,29 +4,210 @@
 package sign
 
 import (
-	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"strings"
 
+	"github.com/gofrs/uuid"
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/telemetry"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 	"github.com/sirupsen/logrus"
 )
 
+// DocuSignProvider is the DocuSign implementation of the e-signature Provider
+type DocuSignProvider struct {
+	privateKey string
+}
+
+// NewDocuSignProvider returns a DocuSign provider which authenticates using the specified RSA private key
+func NewDocuSignProvider(privateKey string) *DocuSignProvider {
+	return &DocuSignProvider{
+		privateKey: privateKey,
+	}
+}
+
+// Name returns the provider name
+func (p *DocuSignProvider) Name() string {
+	return ProviderDocuSign
+}
+
+// OwnsEnvelope returns true if the envelope ID looks like a DocuSign envelope GUID
+func (p *DocuSignProvider) OwnsEnvelope(envelopeID string) bool {
+	_, err := uuid.FromString(envelopeID)
+	return err == nil
+}
+
+// CreateEnvelope creates and sends a DocuSign envelope for the request, returning the embedded signing URL when requested
+func (p *DocuSignProvider) CreateEnvelope(ctx context.Context, request *EnvelopeRequest) (*Envelope, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.DocuSignProvider.CreateEnvelope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    request.SignatureID,
+		"documentID":     request.DocumentID,
+		"embedded":       request.Embedded,
+	}
+
+	signer := DocuSignRecipient{
+		Email:       request.SignerEmail,
+		Name:        request.SignerName,
+		Tabs:        getTabsFromDocument(request.Document, request.DocumentID, request.DefaultValues),
+		RecipientId: "1",
+		RoleName:    "signer",
+	}
+	if request.Embedded {
+		// Assigning a clientUserId does not send an email - the user opens the document from the sign URL
+		signer.ClientUserId = request.SignatureID
+	}
+
+	envelopeRequest := DocuSignEnvelopeRequest{
+		Documents: []DocuSignDocument{
+			{
+				Name:           request.DocumentName,
+				DocumentId:     request.DocumentID,
+				FileExtension:  "pdf",
+				FileFormatHint: "pdf",
+				Order:          "1",
+				DocumentBase64: base64.StdEncoding.EncodeToString(request.PDF),
+			},
+		},
+		EmailSubject: request.EmailSubject,
+		EmailBlurb:   request.EmailBody,
+		Status:       "sent",
+		Recipients: DocuSignRecipientType{
+			Signers: []DocuSignRecipient{
+				signer,
+			},
+		},
+	}
+
+	if request.CallbackURL != "" {
+		// Webhook properties for callbacks after the user signs the document.
+		// Ensure that a webhook is returned on the status "Completed" where
+		// all signers on a document finish signing the document.
+		log.WithFields(f).Debugf("setting up webhook properties with callback url: %s", request.CallbackURL)
+		envelopeRequest.EventNotification = DocuSignEventNotification{
+			URL:            request.CallbackURL,
+			LoggingEnabled: true,
+			EnvelopeEvents: []DocuSignRecipientEvent{
+				{
+					EnvelopeEventStatusCode: EnvelopeCompleted,
+				},
+			},
+		}
+	}
+
+	envelopeResponse, err := p.PrepareSignRequest(ctx, &envelopeRequest)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create envelope")
+		return nil, err
+	}
+
+	log.WithFields(f).Debugf("envelopeID: %s", envelopeResponse.EnvelopeId)
+	envelope := &Envelope{
+		EnvelopeID: envelopeResponse.EnvelopeId,
+	}
+
+	if !request.Embedded {
+		return envelope, nil
+	}
+
+	recipients, err := p.getEnvelopeRecipients(ctx, envelope.EnvelopeID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to fetch recipients for envelope: %s", envelope.EnvelopeID)
+		return nil, err
+	}
+	if len(recipients) == 0 {
+		log.WithFields(f).Warnf("no envelope recipients found : %s", envelope.EnvelopeID)
+		return nil, errors.New("no envelope recipients found")
+	}
+
+	log.WithFields(f).Debugf("generating signature sign_url, using return-url as: %s", request.ReturnURL)
+	envelope.SignURL, err = p.GetSignURL(signer.Email, signer.RecipientId, signer.Name, recipients[0].ClientUserId, envelope.EnvelopeID, request.ReturnURL)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to get sign url for envelope: %s", envelope.EnvelopeID)
+		return nil, err
+	}
+
+	return envelope, nil
+}
+
+// ParseCompletion parses the DocuSign Connect envelope information XML posted to the signed callbacks
+func (p *DocuSignProvider) ParseCompletion(ctx context.Context, payload []byte) (*EnvelopeCompletion, error) {
+	var info DocuSignEnvelopeInformation
+	if err := xml.Unmarshal(payload, &info); err != nil {
+		return nil, err
+	}
+
+	if len(info.EnvelopeStatus.RecipientStatuses) == 0 || len(info.EnvelopeStatus.DocumentStatuses) == 0 {
+		return nil, fmt.Errorf("envelope %s is missing recipient or document status", info.EnvelopeStatus.EnvelopeID)
+	}
+
+	recipient := info.EnvelopeStatus.RecipientStatuses[0]
+	return &EnvelopeCompletion{
+		EnvelopeID:      info.EnvelopeStatus.EnvelopeID,
+		SignatureID:     recipient.ClientUserId,
+		DocumentID:      info.EnvelopeStatus.DocumentStatuses[0].ID,
+		Status:          info.EnvelopeStatus.Status,
+		RecipientStatus: recipient.Status,
+		SignedDate:      recipient.Signed,
+		FullName:        fetchFullName(info),
+	}, nil
+}
+
+// fetchFullName returns the signer name entered in the full_name or signatory_name tabs
+func fetchFullName(info DocuSignEnvelopeInformation) string {
+	var fullName string
+	for _, tabStatus := range info.EnvelopeStatus.RecipientStatuses[0].TabStatuses {
+		if tabStatus.TabLabel == "full_name" {
+			if tabStatus.TabValue != "" {
+				fullName = tabStatus.TabValue
+			}
+		} else if tabStatus.TabLabel == "signatory_name" {
+			if tabStatus.TabValue != "" {
+				fullName = tabStatus.TabValue
+			}
+		}
+	}
+	return fullName
+}
+
+// docuSignAuthURL returns the base URL of the authentication server. The server is normally configured as
+// a host name, a scheme may be included to point the backend at a local DocuSign simulator.
+func docuSignAuthURL(authServer string) string {
+	if strings.HasPrefix(authServer, "http://") || strings.HasPrefix(authServer, "https://") {
+		return strings.TrimSuffix(authServer, "/")
+	}
+	return "https://" + authServer
+}
+
+// CheckHealth checks an access token can be minted with the configured private key, the sign links can't be
+// created without it
+func (p *DocuSignProvider) CheckHealth(ctx context.Context) error {
+	accessToken, err := p.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+	if accessToken == "" {
+		return errors.New("empty DocuSign access token")
+	}
+	return nil
+}
+
 // getAccessToken retrieves an access token for the DocuSign API using a JWT assertion.
-func (s *service) getAccessToken(ctx context.Context) (string, error) {
+func (p *DocuSignProvider) getAccessToken(ctx context.Context) (string, error) {
 	f := logrus.Fields{
 		"functionName":   "v2.getAccessToken",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
 	}
 
-	jwtAssertion, err := jwtToken(s.docsignPrivateKey)
+	jwtAssertion, err := jwtToken(p.privateKey)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem generating the JWT token")
 		return "", err
@@ -44,7 +225,7 @@ func (s *service) getAccessToken(ctx context.Context) (string, error) {
 		return "", err
 	}
 
-	url := fmt.Sprintf("https://%s/oauth/token", utils.GetProperty("DOCUSIGN_AUTH_SERVER"))
+	url := fmt.Sprintf("%s/oauth/token", docuSignAuthURL(utils.GetProperty("DOCUSIGN_AUTH_SERVER")))
 	req, err := http.NewRequest("POST", url, strings.NewReader(string(tokenRequestBodyJSON)))
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem creating the HTTP request")
@@ -55,7 +236,7 @@ func (s *service) getAccessToken(ctx context.Context) (string, error) {
 	req.Header.Add("Accept", "application/json")
 
 	// Make the request
-	client := &http.Client{}
+	client := telemetry.NewClient(telemetry.IntegrationDocuSign)
 	resp, err := client.Do(req)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem making the HTTP request")
@@ -92,8 +273,8 @@ func (s *service) getAccessToken(ctx context.Context) (string, error) {
 
 }
 
-// Void envelope
-func (s *service) VoidEnvelope(ctx context.Context, envelopeID, message string) error {
+// VoidEnvelope voids the DocuSign envelope with the specified reason
+func (p *DocuSignProvider) VoidEnvelope(ctx context.Context, envelopeID, message string) error {
 	f := logrus.Fields{
 		"functionName":   "v2.VoidEnvelope",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -101,7 +282,7 @@ func (s *service) VoidEnvelope(ctx context.Context, envelopeID, message string)
 		"message":        message,
 	}
 
-	accessToken, err := s.getAccessToken(ctx)
+	accessToken, err := p.getAccessToken(ctx)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem getting the access token")
 		return err
@@ -132,7 +313,7 @@ func (s *service) VoidEnvelope(ctx context.Context, envelopeID, message string)
 	req.Header.Add("Content-Type", "application/json")
 
 	// Make the request
-	client := &http.Client{}
+	client := telemetry.NewClient(telemetry.IntegrationDocuSign)
 
 	resp, err := client.Do(req)
 
@@ -160,34 +341,29 @@ func (s *service) VoidEnvelope(ctx context.Context, envelopeID, message string)
 
 }
 
-func (s *service) createEnvelope(ctx context.Context, payload *DocuSignEnvelopeRequest) (string, error) {
+func (p *DocuSignProvider) getEnvelopeRecipients(ctx context.Context, envelopeID string) ([]Signer, error) {
 	f := logrus.Fields{
-		"functionName":   "v2.createEnvelope",
-		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
-	}
-
-	// Serialize the signRequest into JSON
-	requestJSON, err := json.Marshal(payload)
-	if err != nil {
-		return "", err
+		"functionName": "v2.getEnvelopeRecipients",
+		"envelopeID":   envelopeID,
 	}
 
-	log.WithFields(f).Debugf("sign request: %+v", string(requestJSON))
-
 	// Get the access token
-	accessToken, err := s.getAccessToken(ctx)
+	accessToken, err := p.getAccessToken(ctx)
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
+	log.WithFields(f).Debugf("access token: %s", accessToken)
+
 	// Create the request
-	url := fmt.Sprintf("%s/accounts/%s/envelopes", utils.GetProperty("DOCUSIGN_ROOT_URL"), utils.GetProperty("DOCUSIGN_ACCOUNT_ID"))
+	url := fmt.Sprintf("%s/accounts/%s/envelopes/%s/recipients", utils.GetProperty("DOCUSIGN_ROOT_URL"), utils.GetProperty("DOCUSIGN_ACCOUNT_ID"), envelopeID)
 
-	req, err := http.NewRequest("POST", url, strings.NewReader(string(requestJSON)))
+	req, err := http.NewRequest("GET", url, nil)
 
 	if err != nil {
-		return "", err
+		log.WithFields(f).Debugf("%+v", err)
+		return nil, err
 	}
 
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
@@ -195,13 +371,12 @@ func (s *service) createEnvelope(ctx context.Context, payload *DocuSignEnvelopeR
 	req.Header.Add("Accept", "application/json")
 
 	// Make the request
-	client := &http.Client{}
+	client := telemetry.NewClient(telemetry.IntegrationDocuSign)
 
 	resp, err := client.Do(req)
-
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem making the HTTP request")
-		return "", err
+		return nil, err
 	}
 
 	defer func() {
@@ -214,153 +389,52 @@ func (s *service) createEnvelope(ctx context.Context, payload *DocuSignEnvelopeR
 
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem reading the response body")
-		return "", err
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusCreated {
-		log.WithFields(f).Warnf("problem making the HTTP request - status code: %d - response : %s", resp.StatusCode, string(responsePayload))
-		return "", errors.New("problem making the HTTP request")
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("problem getting getting recipients ")
 	}
 
-	var response DocuSignEnvelopeResponse
+	var response *DocusignRecipientResponse
 
 	err = json.Unmarshal(responsePayload, &response)
 
 	if err != nil {
-		log.WithFields(f).WithError(err).Warnf("problem unmarshalling the response body")
-		return "", err
-	}
-
-	return response.EnvelopeId, nil
-
-}
-
-func (s *service) addDocumentToEnvelope(ctx context.Context, envelopeID, documentName string, document []byte) error {
-	f := logrus.Fields{
-		"functionName": "v2.addDocumentToEnvelope",
-	}
-
-	const method = "PUT"
-
-	// Get the access token
-	accessToken, err := s.getAccessToken(ctx)
-
-	if err != nil {
-		return err
-	}
-
-	url := fmt.Sprintf("%s/accounts/%s/envelopes/%s/documents/1", utils.GetProperty("DOCUSIGN_ROOT_URL"), utils.GetProperty("DOCUSIGN_ACCOUNT_ID"), envelopeID)
-
-	log.WithFields(f).Debugf("url: %s", url)
-
-	body := &bytes.Buffer{}
-
-	writer := multipart.NewWriter(body)
-
-	part, partErr := writer.CreateFormFile("file", documentName)
-	if partErr != nil {
-		return partErr
-	}
-
-	_, copyErr := io.Copy(part, bytes.NewReader(document))
-
-	if copyErr != nil {
-		return copyErr
-	}
-
-	closeErr := writer.Close()
-	if closeErr != nil {
-		return closeErr
-	}
-
-	// create the http request
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
-		return err
-	}
-
-	// Set headers
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
-	req.Header.Set("Content-Disposition", fmt.Sprintf("filename=\"%s\"", documentName))
-	req.Header.Set("Content-Type", "application/pdf")
-	req.Header.Set("Accept", "application/json")
-
-	log.WithFields(f).Debugf("adding document to envelope with url: %s %s", method, url)
-
-	// Send HTTP request
-	client := &http.Client{}
-	resp, clientErr := client.Do(req)
-	if clientErr != nil {
-		log.WithFields(f).WithError(clientErr).Warnf("problem invoking envelope document upload request to %s %s", method, url)
-		return clientErr
-	}
-
-	//log.WithFields(f).Debugf("response: %+v", resp)
-	responsePayload, readErr := io.ReadAll(resp.Body)
-	if readErr != nil {
-		log.WithFields(f).WithError(readErr).Warnf("problem reading response body %+v", resp.Body)
-		return readErr
-	}
-
-	// Expecting a 200 response
-	if resp.StatusCode != 200 {
-		msg := fmt.Sprintf("problem invoking http %s request to %s - response status code is not 200: %d - response is: %+v", method, url, resp.StatusCode, string(responsePayload))
-		log.WithFields(f).Warn(msg)
-		return errors.New(msg)
-	}
-
-	defer func() {
-		closeErr = resp.Body.Close()
-		if closeErr != nil {
-			log.WithFields(f).WithError(closeErr).Warnf("problem closing response body")
-		}
-	}()
-
-	var documentUpdateResponseModel DocuSignUpdateDocumentResponse
-	unmarshalErr := json.Unmarshal(responsePayload, &documentUpdateResponseModel)
-	if unmarshalErr != nil {
-		log.WithFields(f).WithError(unmarshalErr).Warnf("problem unmarshalling document update to the envelope response model JSON data")
-		return unmarshalErr
+		log.WithFields(f).Debugf("unable to unmarshall response: %+v", err)
+		return nil, err
 	}
 
-	log.WithFields(f).Debugf("successfully added document to envelope response body, uri: %s, documentGuid: %s, response: %+v", documentUpdateResponseModel.Uri, documentUpdateResponseModel.DocumentIdGuid, documentUpdateResponseModel)
-
-	return nil
+	log.WithFields(f).Debugf("got %d recipients", len(response.Signers))
 
+	return response.Signers, nil
 }
 
-func (s *service) getEnvelopeRecipients(ctx context.Context, envelopeID string) ([]Signer, error) {
+// GetEnvelopeState returns the status of the DocuSign envelope along with the void or decline reason
+func (p *DocuSignProvider) GetEnvelopeState(ctx context.Context, envelopeID string) (*EnvelopeState, error) {
 	f := logrus.Fields{
-		"functionName": "v2.getEnvelopeRecipients",
-		"envelopeID":   envelopeID,
+		"functionName":   "v2.DocuSignProvider.GetEnvelopeState",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"envelopeID":     envelopeID,
 	}
 
-	// Get the access token
-	accessToken, err := s.getAccessToken(ctx)
-
+	accessToken, err := p.getAccessToken(ctx)
 	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem getting the access token")
 		return nil, err
 	}
 
-	log.WithFields(f).Debugf("access token: %s", accessToken)
-
-	// Create the request
-	url := fmt.Sprintf("%s/accounts/%s/envelopes/%s/recipients", utils.GetProperty("DOCUSIGN_ROOT_URL"), utils.GetProperty("DOCUSIGN_ACCOUNT_ID"), envelopeID)
-
+	url := fmt.Sprintf("%s/accounts/%s/envelopes/%s", utils.GetProperty("DOCUSIGN_ROOT_URL"), utils.GetProperty("DOCUSIGN_ACCOUNT_ID"), envelopeID)
 	req, err := http.NewRequest("GET", url, nil)
-
 	if err != nil {
-		log.WithFields(f).Debugf("%+v", err)
+		log.WithFields(f).WithError(err).Warnf("problem creating the HTTP request")
 		return nil, err
 	}
 
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
-	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
 
-	// Make the request
-	client := &http.Client{}
-
+	client := telemetry.NewClient(telemetry.IntegrationDocuSign)
 	resp, err := client.Do(req)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem making the HTTP request")
@@ -374,32 +448,49 @@ func (s *service) getEnvelopeRecipients(ctx context.Context, envelopeID string)
 	}()
 
 	responsePayload, err := io.ReadAll(resp.Body)
-
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem reading the response body")
 		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("problem getting getting recipients ")
+		log.WithFields(f).Warnf("problem making the HTTP request - status code: %d - response : %s", resp.StatusCode, string(responsePayload))
+		return nil, errors.New("problem getting the envelope status")
 	}
 
-	var response *DocusignRecipientResponse
-
-	err = json.Unmarshal(responsePayload, &response)
-
-	if err != nil {
-		log.WithFields(f).Debugf("unable to unmarshall response: %+v", err)
+	var response DocuSignEnvelopeResponseModel
+	if err = json.Unmarshal(responsePayload, &response); err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem unmarshalling the response body")
 		return nil, err
 	}
 
-	log.WithFields(f).Debugf("got %d recipients", len(response.Signers))
+	state := &EnvelopeState{
+		EnvelopeID:    envelopeID,
+		Status:        normalizeEnvelopeStatus(response.Status),
+		StatusChanged: response.StatusChangedDateTime,
+		Reason:        response.VoidedReason,
+	}
 
-	return response.Signers, nil
+	// the decline reason is only reported on the recipient
+	if state.Status == EnvelopeDeclined {
+		recipients, recipientErr := p.getEnvelopeRecipients(ctx, envelopeID)
+		if recipientErr != nil {
+			log.WithFields(f).WithError(recipientErr).Warn("unable to fetch the envelope recipients for the decline reason")
+			return state, nil
+		}
+		for _, recipient := range recipients {
+			if recipient.DeclinedReason != "" {
+				state.Reason = recipient.DeclinedReason
+				break
+			}
+		}
+	}
+
+	return state, nil
 }
 
 // Function to create a DocuSign envelope
-func (s *service) PrepareSignRequest(ctx context.Context, signRequest *DocuSignEnvelopeRequest) (*DocusignEnvelopeResponse, error) {
+func (p *DocuSignProvider) PrepareSignRequest(ctx context.Context, signRequest *DocuSignEnvelopeRequest) (*DocusignEnvelopeResponse, error) {
 	f := logrus.Fields{
 		"functionName":   "v2.PrepareSignRequest",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -412,7 +503,7 @@ func (s *service) PrepareSignRequest(ctx context.Context, signRequest *DocuSignE
 	}
 
 	// Get the access token
-	accessToken, err := s.getAccessToken(ctx)
+	accessToken, err := p.getAccessToken(ctx)
 
 	if err != nil {
 		return nil, err
@@ -434,7 +525,7 @@ func (s *service) PrepareSignRequest(ctx context.Context, signRequest *DocuSignE
 	req.Header.Add("Accept", "application/json")
 
 	// Make the request
-	client := &http.Client{}
+	client := telemetry.NewClient(telemetry.IntegrationDocuSign)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -479,8 +570,7 @@ type RecipientViewResponse struct {
 }
 
 // GetSignURL fetches the signing URL for the specified envelope and recipient
-
-func (s *service) GetSignURL(email, recipientID, userName, clientUserId, envelopeID, returnURL string) (string, error) {
+func (p *DocuSignProvider) GetSignURL(email, recipientID, userName, clientUserId, envelopeID, returnURL string) (string, error) {
 
 	f := logrus.Fields{
 		"functionName": "v2.GetSignURL",
@@ -489,7 +579,7 @@ func (s *service) GetSignURL(email, recipientID, userName, clientUserId, envelop
 	}
 
 	// Get the access token
-	accessToken, err := s.getAccessToken(context.Background())
+	accessToken, err := p.getAccessToken(context.Background())
 
 	if err != nil {
 		return "", err
@@ -529,7 +619,7 @@ func (s *service) GetSignURL(email, recipientID, userName, clientUserId, envelop
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 	req.Header.Add("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := telemetry.NewClient(telemetry.IntegrationDocuSign)
 
 	resp, err := client.Do(req)
 
@@ -566,14 +656,15 @@ func (s *service) GetSignURL(email, recipientID, userName, clientUserId, envelop
 	return viewResponse.URL, nil
 }
 
-func (s service) GetSignedDocument(ctx context.Context, envelopeID, documentID string) ([]byte, error) {
+// GetSignedDocument downloads the signed document from the DocuSign envelope
+func (p *DocuSignProvider) GetSignedDocument(ctx context.Context, envelopeID, documentID string) ([]byte, error) {
 	f := logrus.Fields{
 		"functionName": "v2.getSignedDocument",
 		"envelopeID":   envelopeID,
 	}
 
 	// Get the access token
-	accessToken, err := s.getAccessToken(ctx)
+	accessToken, err := p.getAccessToken(ctx)
 
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem getting the access token")
@@ -593,7 +684,7 @@ func (s service) GetSignedDocument(ctx context.Context, envelopeID, documentID s
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 
 	// Make the request
-	client := &http.Client{}
+	client := telemetry.NewClient(telemetry.IntegrationDocuSign)
 
 	resp, err := client.Do(req)
 
@@ -624,14 +715,15 @@ func (s service) GetSignedDocument(ctx context.Context, envelopeID, documentID s
 
 }
 
-func (s *service) GetEnvelopeDocuments(ctx context.Context, envelopeID string) ([]DocuSignDocument, error) {
+// GetEnvelopeDocuments returns the list of documents in the DocuSign envelope
+func (p *DocuSignProvider) GetEnvelopeDocuments(ctx context.Context, envelopeID string) ([]DocuSignDocument, error) {
 	f := logrus.Fields{
 		"functionName": "v2.GetEnvelopeDocuments",
 		"envelopeID":   envelopeID,
 	}
 
 	// Get the access token
-	accessToken, err := s.getAccessToken(ctx)
+	accessToken, err := p.getAccessToken(ctx)
 
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem getting the access token")
@@ -651,7 +743,7 @@ func (s *service) GetEnvelopeDocuments(ctx context.Context, envelopeID string) (
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 
 	// Make the request
-	client := &http.Client{}
+	client := telemetry.NewClient(telemetry.IntegrationDocuSign)
 
 	resp, err := client.Do(req)
 
@@ -6,12 +6,15 @@ package sign
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
 
+	lambdaEvents "github.com/aws/aws-lambda-go/events"
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
 	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
 	"github.com/linuxfoundation/easycla/cla-backend-go/users"
@@ -278,6 +281,138 @@ func Configure(api *operations.EasyclaAPI, service Service, userService users.Se
 			}
 			return sign.NewGetUserActiveSignatureOK().WithPayload(resp)
 		})
+
+	api.SignClickThroughSignPageHandler = sign.ClickThroughSignPageHandlerFunc(
+		func(params sign.ClickThroughSignPageParams) middleware.Responder {
+			reqId := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTIDKey, reqId)
+			f := logrus.Fields{
+				"functionName":   "v2.sign.handlers.SignClickThroughSignPageHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"envelopeID":     params.EnvelopeID,
+			}
+
+			session, err := service.GetClickThroughSession(ctx, params.EnvelopeID)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("unable to load click-through session")
+				return clickThroughErrorResponder(err)
+			}
+			return clickThroughPageResponder(http.StatusOK, session, "")
+		})
+
+	api.SignClickThroughDocumentHandler = sign.ClickThroughDocumentHandlerFunc(
+		func(params sign.ClickThroughDocumentParams) middleware.Responder {
+			reqId := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTIDKey, reqId)
+			f := logrus.Fields{
+				"functionName":   "v2.sign.handlers.SignClickThroughDocumentHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"envelopeID":     params.EnvelopeID,
+			}
+
+			document, err := service.GetClickThroughDocument(ctx, params.EnvelopeID)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("unable to load click-through document")
+				return clickThroughErrorResponder(err)
+			}
+			return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+				w.Header().Set("Content-Type", "application/pdf")
+				w.Header().Set("Content-Disposition", "inline")
+				w.WriteHeader(http.StatusOK)
+				if _, err := w.Write(document); err != nil {
+					log.WithFields(f).WithError(err).Warn("failed to write document response")
+				}
+			})
+		})
+
+	api.SignClickThroughConsentHandler = sign.ClickThroughConsentHandlerFunc(
+		func(params sign.ClickThroughConsentParams) middleware.Responder {
+			reqId := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTIDKey, reqId)
+			f := logrus.Fields{
+				"functionName":   "v2.sign.handlers.SignClickThroughConsentHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"envelopeID":     params.EnvelopeID,
+			}
+
+			session, err := service.GetClickThroughSession(ctx, params.EnvelopeID)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("unable to load click-through session")
+				return clickThroughErrorResponder(err)
+			}
+
+			if !params.Consent || strings.TrimSpace(params.FullName) == "" {
+				return clickThroughPageResponder(http.StatusBadRequest, session, ErrClickThroughConsentRequired.Error())
+			}
+
+			evidence := &ClickThroughEvidence{
+				TypedName: params.FullName,
+				IPAddress: clientIPAddress(params.HTTPRequest),
+				UserAgent: params.HTTPRequest.UserAgent(),
+			}
+			session, err = service.ConsentClickThrough(ctx, params.EnvelopeID, evidence)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("unable to record click-through consent")
+				return clickThroughErrorResponder(err)
+			}
+
+			log.WithFields(f).Debugf("click-through envelope signed - redirecting to: %s", session.ReturnURL)
+			return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+				w.Header().Set("Location", session.ReturnURL)
+				w.WriteHeader(http.StatusSeeOther)
+			})
+		})
+}
+
+// clickThroughPageResponder renders the click-through sign page
+func clickThroughPageResponder(status int, session *ClickThroughSession, message string) middleware.Responder {
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(status)
+		if _, err := w.Write([]byte(renderClickThroughPage(session, message))); err != nil {
+			log.WithError(err).Warn("failed to write click-through page")
+		}
+	})
+}
+
+// clickThroughErrorResponder maps click-through errors to plain text responses
+func clickThroughErrorResponder(err error) middleware.Responder {
+	status, message := http.StatusInternalServerError, "unable to process the signing request"
+	switch {
+	case errors.Is(err, ErrClickThroughSessionNotFound), errors.Is(err, ErrProviderNotConfigured):
+		status, message = http.StatusNotFound, err.Error()
+	case errors.Is(err, ErrClickThroughNotPending), errors.Is(err, ErrClickThroughConsentRequired):
+		status, message = http.StatusBadRequest, err.Error()
+	}
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		http.Error(w, message, status)
+	})
+}
+
+// apiGatewayContextHeader is the header the lambda proxy uses to pass the API Gateway request context to the handlers
+const apiGatewayContextHeader = "X-GoLambdaProxy-ApiGw-Context"
+
+// clientIPAddress returns the originating client address. The source IP of the API Gateway request context is used
+// when available, otherwise the right-most X-Forwarded-For entry, which is appended by the proxy in front of the
+// service - the left-most entries are sent by the client and can't be trusted.
+func clientIPAddress(r *http.Request) string {
+	// the lambda proxy adds its header after the client headers, so the last value is the one set by the proxy
+	if apiGatewayContexts := r.Header.Values(apiGatewayContextHeader); len(apiGatewayContexts) > 0 {
+		var requestContext lambdaEvents.APIGatewayProxyRequestContext
+		if err := json.Unmarshal([]byte(apiGatewayContexts[len(apiGatewayContexts)-1]), &requestContext); err == nil && requestContext.Identity.SourceIP != "" {
+			return requestContext.Identity.SourceIP
+		}
+	}
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(forwarded[len(forwarded)-1], ",")
+		if hop := strings.TrimSpace(hops[len(hops)-1]); hop != "" {
+			return hop
+		}
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
 }
 
 type codedResponse interface {
@@ -0,0 +1,67 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIPAddress(t *testing.T) {
+	testCases := []struct {
+		name       string
+		headers    map[string][]string
+		remoteAddr string
+		expected   string
+	}{
+		{
+			name:       "remote address",
+			remoteAddr: "10.0.0.1:4321",
+			expected:   "10.0.0.1",
+		},
+		{
+			name:       "right-most forwarded hop",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.1.1.1, 2.2.2.2, 3.3.3.3"}},
+			remoteAddr: "10.0.0.1:4321",
+			expected:   "3.3.3.3",
+		},
+		{
+			name: "api gateway source ip",
+			headers: map[string][]string{
+				"X-Forwarded-For":       {"1.1.1.1, 3.3.3.3"},
+				apiGatewayContextHeader: {`{"identity":{"sourceIp":"4.4.4.4"}}`},
+			},
+			expected: "4.4.4.4",
+		},
+		{
+			name: "api gateway context sent by the client",
+			headers: map[string][]string{
+				apiGatewayContextHeader: {`{"identity":{"sourceIp":"1.1.1.1"}}`, `{"identity":{"sourceIp":"4.4.4.4"}}`},
+			},
+			expected: "4.4.4.4",
+		},
+		{
+			name: "invalid api gateway context",
+			headers: map[string][]string{
+				"X-Forwarded-For":       {"3.3.3.3"},
+				apiGatewayContextHeader: {"invalid"},
+			},
+			expected: "3.3.3.3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &http.Request{Header: http.Header{}, RemoteAddr: tc.remoteAddr}
+			for name, values := range tc.headers {
+				for _, value := range values {
+					r.Header.Add(name, value)
+				}
+			}
+			assert.Equal(t, tc.expected, clientIPAddress(r))
+		})
+	}
+}
@@ -8,8 +8,10 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/linuxfoundation/easycla/cla-backend-go/commit_identity"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	"github.com/linuxfoundation/easycla/cla-backend-go/github"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github_membership"
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 	"github.com/sirupsen/logrus"
@@ -53,9 +55,14 @@ func (s service) updateChangeRequest(ctx context.Context, installationID, reposi
 	gitHubOrgName := utils.StringValue(githubRepository.Owner.Login)
 	gitHubRepoName := utils.StringValue(githubRepository.Name)
 
+	commitIdentityPolicy := commit_identity.PolicyAuthors
+	if ghOrg != nil {
+		commitIdentityPolicy = commit_identity.PolicyOf(ghOrg.CommitIdentityPolicy)
+	}
+
 	// Fetch committers
 	log.WithFields(f).Debugf("fetching commit authors for PR: %d using repository owner: %s, repo: %s", pullRequestID, gitHubOrgName, gitHubRepoName)
-	authors, latestSHA, authorsErr := github.GetPullRequestCommitAuthors(ctx, installationID, int(pullRequestID), gitHubOrgName, gitHubRepoName)
+	authors, latestSHA, authorsErr := github.GetPullRequestCommitAuthors(ctx, installationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, commitIdentityPolicy)
 	if authorsErr != nil {
 		log.WithFields(f).WithError(authorsErr).Warnf("unable to get commit authors for %s/%s for PR: %d", gitHubOrgName, gitHubRepoName, pullRequestID)
 		return authorsErr
@@ -64,6 +71,8 @@ func (s service) updateChangeRequest(ctx context.Context, installationID, reposi
 
 	signed := make([]*github.UserCommitSummary, 0)
 	unsigned := make([]*github.UserCommitSummary, 0)
+	// commit authors whose approval couldn't be determined, e.g. GitHub organization membership lookup failures
+	unknown := make([]*github.UserCommitSummary, 0)
 
 	// triage signed and unsigned users
 	log.WithFields(f).Debugf("triaging %d commit authors for PR: %d using repository %s/%s",
@@ -128,6 +137,10 @@ func (s service) updateChangeRequest(ctx context.Context, installationID, reposi
 		userSigned, companyAffiliation, signedErr := s.hasUserSigned(ctx, user, projectID)
 		if signedErr != nil {
 			log.WithFields(f).WithError(signedErr).Warnf("has user signed error - user: %+v, project: %s", user, projectID)
+			if errors.Is(signedErr, github_membership.ErrMembershipUnknown) {
+				unknown = append(unknown, userSummary)
+				continue
+			}
 			unsigned = append(unsigned, userSummary)
 			continue
 		}
@@ -157,8 +170,18 @@ func (s service) updateChangeRequest(ctx context.Context, installationID, reposi
 		log.WithFields(f).Debugf("commit authors status after allowlisting bots => signed: %+v, missing: %+v, allowlisted: %+v", signed, unsigned, allowlisted)
 	}
 
+	// don't flip the pull request status because of a transient failure - the next event re-evaluates it
+	if len(unknown) > 0 {
+		log.WithFields(f).Warnf("unable to determine the approval status of %d commit authors for PR: %d - leaving the pull request status unchanged", len(unknown), pullRequestID)
+		return fmt.Errorf("unable to determine the approval status of %d commit authors: %w", len(unknown), github_membership.ErrMembershipUnknown)
+	}
+
 	// update pull request
-	updateErr := github.UpdatePullRequest(ctx, installationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, githubRepository.ID, *latestSHA, signed, unsigned, s.ClaV1ApiURL, s.claLandingPage, s.claLogoURL)
+	updatePullRequest := github.UpdatePullRequest
+	if ghOrg != nil && ghOrg.CheckRunEnabled {
+		updatePullRequest = github.UpdatePullRequestCheckRun
+	}
+	updateErr := updatePullRequest(ctx, installationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, githubRepository.ID, *latestSHA, signed, unsigned, s.ClaV1ApiURL, s.claLandingPage, s.claLogoURL)
 	if updateErr != nil {
 		log.WithFields(f).Debugf("unable to update PR: %d", pullRequestID)
 		return updateErr
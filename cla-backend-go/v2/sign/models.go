@@ -303,6 +303,7 @@ type DocuSignEnvelopeResponseModel struct {
 	Status                      string `json:"status,omitempty"`
 	StatusChangedDateTime       string `json:"statusChangedDateTime,omitempty"`
 	TemplatesUri                string `json:"templatesUri,omitempty"`
+	VoidedReason                string `json:"voidedReason,omitempty"`
 }
 
 // IndividualMembershipDocuSignDBSummaryModel is the data model for an individual membership DocuSign database summary models
@@ -390,6 +391,7 @@ type Signer struct {
 	RoutingOrder    string `json:"routingOrder"`
 	RoleName        string `json:"roleName"`
 	Status          string `json:"status"`
+	DeclinedReason  string `json:"declinedReason,omitempty"`
 }
 
 type DocusignRecipientResponse struct {
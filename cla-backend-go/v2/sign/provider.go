@@ -0,0 +1,213 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+)
+
+// e-signature provider names
+const (
+	ProviderDocuSign     = "docusign"
+	ProviderClickThrough = "click-through"
+)
+
+// envelope statuses reported by the providers - EnvelopeCompleted is the envelope and recipient status
+// reported once the signer has finished signing
+const (
+	EnvelopeSent      = "Sent"
+	EnvelopeDelivered = "Delivered"
+	EnvelopeCompleted = "Completed"
+	EnvelopeDeclined  = "Declined"
+	EnvelopeVoided    = "Voided"
+)
+
+// errors
+var (
+	ErrProviderNotConfigured = errors.New("e-signature provider not configured")
+)
+
+// Provider is an e-signature backend used to collect CLA signatures. Providers create an
+// envelope for a signer, notify the signed callback URL once the envelope is completed
+// and serve the signed document for archival in S3.
+type Provider interface {
+	// Name returns the unique provider name used in the configuration
+	Name() string
+	// OwnsEnvelope returns true if the envelope ID was issued by this provider
+	OwnsEnvelope(envelopeID string) bool
+	// CreateEnvelope creates a new envelope for the signer and returns its ID and, for embedded requests, the sign URL
+	CreateEnvelope(ctx context.Context, request *EnvelopeRequest) (*Envelope, error)
+	// VoidEnvelope cancels a pending envelope
+	VoidEnvelope(ctx context.Context, envelopeID, message string) error
+	// ParseCompletion converts the payload posted to the signed callback into a completion record
+	ParseCompletion(ctx context.Context, payload []byte) (*EnvelopeCompletion, error)
+	// GetSignedDocument returns the signed PDF for the envelope
+	GetSignedDocument(ctx context.Context, envelopeID, documentID string) ([]byte, error)
+	// GetEnvelopeState returns the current status of the envelope
+	GetEnvelopeState(ctx context.Context, envelopeID string) (*EnvelopeState, error)
+}
+
+// EnvelopeRequest contains the provider independent details of a signing request
+type EnvelopeRequest struct {
+	SignatureID   string
+	ProjectID     string
+	Document      *v1Models.ClaGroupDocument
+	DocumentID    string
+	DocumentName  string
+	PDF           []byte
+	DefaultValues map[string]interface{}
+	SignerName    string
+	SignerEmail   string
+	// Embedded requests are signed immediately by the current user from the returned sign URL,
+	// otherwise the provider emails the signer
+	Embedded     bool
+	EmailSubject string
+	EmailBody    string
+	CallbackURL  string
+	ReturnURL    string
+}
+
+// Envelope is the result of creating a signing request
+type Envelope struct {
+	EnvelopeID string
+	SignURL    string
+}
+
+// EnvelopeCompletion is the provider independent content of a signed callback payload
+type EnvelopeCompletion struct {
+	EnvelopeID      string
+	SignatureID     string
+	DocumentID      string
+	Status          string
+	RecipientStatus string
+	SignedDate      string
+	FullName        string
+}
+
+// EnvelopeState is the provider independent status of an envelope
+type EnvelopeState struct {
+	EnvelopeID    string
+	Status        string
+	StatusChanged string
+	// Reason is the decline or void reason reported by the provider
+	Reason string
+}
+
+// normalizeEnvelopeStatus converts a provider status such as "declined" into the envelope status constants
+func normalizeEnvelopeStatus(status string) string {
+	for _, known := range []string{EnvelopeSent, EnvelopeDelivered, EnvelopeCompleted, EnvelopeDeclined, EnvelopeVoided} {
+		if strings.EqualFold(status, known) {
+			return known
+		}
+	}
+	return status
+}
+
+// Providers is the registry of configured e-signature providers
+type Providers struct {
+	defaultProvider   Provider
+	claGroupProviders map[string]Provider
+	providers         []Provider
+}
+
+// NewProviders creates the provider registry. The default provider is used for every CLA Group
+// without an entry in the claGroupProviders map of CLA Group ID to provider name.
+func NewProviders(defaultProvider string, claGroupProviders map[string]string, providers ...Provider) (*Providers, error) {
+	if defaultProvider == "" {
+		defaultProvider = ProviderDocuSign
+	}
+
+	registry := &Providers{
+		claGroupProviders: make(map[string]Provider, len(claGroupProviders)),
+		providers:         providers,
+	}
+
+	var ok bool
+	if registry.defaultProvider, ok = registry.Get(defaultProvider); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, defaultProvider)
+	}
+
+	for claGroupID, name := range claGroupProviders {
+		provider, found := registry.Get(name)
+		if !found {
+			return nil, fmt.Errorf("%w: %s for CLA Group %s", ErrProviderNotConfigured, name, claGroupID)
+		}
+		registry.claGroupProviders[claGroupID] = provider
+	}
+
+	return registry, nil
+}
+
+// ParseCLAGroupProviders parses a comma separated list of claGroupID=provider entries
+func ParseCLAGroupProviders(value string) (map[string]string, error) {
+	claGroupProviders := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid CLA Group provider entry: %s", entry)
+		}
+		claGroupProviders[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return claGroupProviders, nil
+}
+
+// Get returns the provider registered with the specified name
+func (r *Providers) Get(name string) (Provider, bool) {
+	for _, provider := range r.providers {
+		if provider.Name() == name {
+			return provider, true
+		}
+	}
+	return nil, false
+}
+
+// ForCLAGroup returns the provider used to sign the CLA Group documents
+func (r *Providers) ForCLAGroup(claGroupID string) Provider {
+	if provider, ok := r.claGroupProviders[claGroupID]; ok {
+		return provider
+	}
+	return r.defaultProvider
+}
+
+// ForEnvelope returns the provider which issued the envelope, falling back to the default provider
+func (r *Providers) ForEnvelope(envelopeID string) Provider {
+	for _, provider := range r.providers {
+		if provider.OwnsEnvelope(envelopeID) {
+			return provider
+		}
+	}
+	return r.defaultProvider
+}
+
+// ParseCompletion routes a signed callback payload to the provider which issued the envelope. All providers
+// post the DocuSign Connect envelope information document, the envelope ID selects the provider.
+func (r *Providers) ParseCompletion(ctx context.Context, payload []byte) (Provider, *EnvelopeCompletion, error) {
+	var info DocuSignEnvelopeInformation
+	if err := xml.Unmarshal(payload, &info); err != nil {
+		return nil, nil, err
+	}
+
+	envelopeID := info.EnvelopeStatus.EnvelopeID
+	if envelopeID == "" {
+		return nil, nil, errors.New("missing envelope ID in callback payload")
+	}
+
+	provider := r.ForEnvelope(envelopeID)
+	completion, err := provider.ParseCompletion(ctx, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return provider, completion, nil
+}
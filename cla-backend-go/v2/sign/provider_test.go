@@ -0,0 +1,170 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubProvider is a local provider which signs every envelope it creates
+type stubProvider struct {
+	name      string
+	prefix    string
+	envelopes map[string]*EnvelopeRequest
+	voided    []string
+	// states overrides the reported envelope status, envelopes default to sent
+	states map[string]string
+}
+
+func newStubProvider(name string) *stubProvider {
+	return &stubProvider{
+		name:      name,
+		prefix:    name + "-",
+		envelopes: make(map[string]*EnvelopeRequest),
+		states:    make(map[string]string),
+	}
+}
+
+func (p *stubProvider) Name() string {
+	return p.name
+}
+
+func (p *stubProvider) OwnsEnvelope(envelopeID string) bool {
+	return strings.HasPrefix(envelopeID, p.prefix)
+}
+
+func (p *stubProvider) CreateEnvelope(ctx context.Context, request *EnvelopeRequest) (*Envelope, error) {
+	envelopeID := p.prefix + request.SignatureID
+	p.envelopes[envelopeID] = request
+	return &Envelope{EnvelopeID: envelopeID, SignURL: "https://sign.example.org/" + envelopeID}, nil
+}
+
+func (p *stubProvider) VoidEnvelope(ctx context.Context, envelopeID, message string) error {
+	p.voided = append(p.voided, envelopeID)
+	return nil
+}
+
+func (p *stubProvider) ParseCompletion(ctx context.Context, payload []byte) (*EnvelopeCompletion, error) {
+	for envelopeID, request := range p.envelopes {
+		if strings.Contains(string(payload), "<EnvelopeID>"+envelopeID+"</EnvelopeID>") {
+			return &EnvelopeCompletion{
+				EnvelopeID:      envelopeID,
+				SignatureID:     request.SignatureID,
+				DocumentID:      request.DocumentID,
+				Status:          EnvelopeCompleted,
+				RecipientStatus: EnvelopeCompleted,
+				FullName:        request.SignerName,
+			}, nil
+		}
+	}
+	return nil, errors.New("unknown envelope")
+}
+
+func (p *stubProvider) GetSignedDocument(ctx context.Context, envelopeID, documentID string) ([]byte, error) {
+	return p.envelopes[envelopeID].PDF, nil
+}
+
+func (p *stubProvider) GetEnvelopeState(ctx context.Context, envelopeID string) (*EnvelopeState, error) {
+	if _, ok := p.envelopes[envelopeID]; !ok {
+		return nil, errors.New("unknown envelope")
+	}
+	if status, ok := p.states[envelopeID]; ok {
+		return &EnvelopeState{EnvelopeID: envelopeID, Status: status}, nil
+	}
+	return &EnvelopeState{EnvelopeID: envelopeID, Status: EnvelopeSent}, nil
+}
+
+func completionPayload(envelopeID string) []byte {
+	return []byte("<DocuSignEnvelopeInformation><EnvelopeStatus><EnvelopeID>" + envelopeID + "</EnvelopeID><Status>Completed</Status></EnvelopeStatus></DocuSignEnvelopeInformation>")
+}
+
+func TestNewProviders(t *testing.T) {
+	docusign := newStubProvider(ProviderDocuSign)
+	clickThrough := newStubProvider(ProviderClickThrough)
+
+	providers, err := NewProviders("", nil, docusign, clickThrough)
+	assert.Nil(t, err)
+	assert.Equal(t, docusign, providers.ForCLAGroup("cla-group"))
+
+	_, err = NewProviders("unknown", nil, docusign)
+	assert.True(t, errors.Is(err, ErrProviderNotConfigured))
+
+	_, err = NewProviders(ProviderDocuSign, map[string]string{"cla-group": ProviderClickThrough}, docusign)
+	assert.True(t, errors.Is(err, ErrProviderNotConfigured))
+}
+
+func TestProvidersForCLAGroup(t *testing.T) {
+	docusign := newStubProvider(ProviderDocuSign)
+	clickThrough := newStubProvider(ProviderClickThrough)
+
+	providers, err := NewProviders(ProviderDocuSign, map[string]string{"small-project": ProviderClickThrough}, docusign, clickThrough)
+	assert.Nil(t, err)
+	assert.Equal(t, clickThrough, providers.ForCLAGroup("small-project"))
+	assert.Equal(t, docusign, providers.ForCLAGroup("other-project"))
+}
+
+func TestProvidersForEnvelope(t *testing.T) {
+	docusign := newStubProvider(ProviderDocuSign)
+	clickThrough := newStubProvider(ProviderClickThrough)
+
+	providers, err := NewProviders(ProviderDocuSign, nil, docusign, clickThrough)
+	assert.Nil(t, err)
+	assert.Equal(t, clickThrough, providers.ForEnvelope("click-through-1234"))
+	assert.Equal(t, docusign, providers.ForEnvelope("docusign-1234"))
+	// unknown envelopes fall back to the default provider
+	assert.Equal(t, docusign, providers.ForEnvelope("1234"))
+}
+
+func TestProvidersParseCompletion(t *testing.T) {
+	ctx := context.Background()
+	docusign := newStubProvider(ProviderDocuSign)
+	clickThrough := newStubProvider(ProviderClickThrough)
+
+	providers, err := NewProviders(ProviderDocuSign, map[string]string{"small-project": ProviderClickThrough}, docusign, clickThrough)
+	assert.Nil(t, err)
+
+	envelope, err := providers.ForCLAGroup("small-project").CreateEnvelope(ctx, &EnvelopeRequest{
+		SignatureID: "signature-1",
+		ProjectID:   "small-project",
+		DocumentID:  "42",
+		SignerName:  "Jane Doe",
+		PDF:         []byte("%PDF"),
+		Embedded:    true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "click-through-signature-1", envelope.EnvelopeID)
+
+	provider, completion, err := providers.ParseCompletion(ctx, completionPayload(envelope.EnvelopeID))
+	assert.Nil(t, err)
+	assert.Equal(t, clickThrough, provider)
+	assert.Equal(t, "signature-1", completion.SignatureID)
+	assert.Equal(t, "42", completion.DocumentID)
+	assert.Equal(t, EnvelopeCompleted, completion.RecipientStatus)
+	assert.Equal(t, "Jane Doe", completion.FullName)
+
+	document, err := provider.GetSignedDocument(ctx, completion.EnvelopeID, completion.DocumentID)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("%PDF"), document)
+
+	_, _, err = providers.ParseCompletion(ctx, []byte("<DocuSignEnvelopeInformation></DocuSignEnvelopeInformation>"))
+	assert.NotNil(t, err)
+}
+
+func TestParseCLAGroupProviders(t *testing.T) {
+	claGroupProviders, err := ParseCLAGroupProviders(" a = click-through, b=docusign ,")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"a": ProviderClickThrough, "b": ProviderDocuSign}, claGroupProviders)
+
+	claGroupProviders, err = ParseCLAGroupProviders("")
+	assert.Nil(t, err)
+	assert.Empty(t, claGroupProviders)
+
+	_, err = ParseCLAGroupProviders("a")
+	assert.NotNil(t, err)
+}
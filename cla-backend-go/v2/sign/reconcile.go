@@ -0,0 +1,303 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// envelope reconciliation defaults
+const (
+	DefaultEnvelopeStaleAfter = 14 * 24 * time.Hour
+	DefaultEnvelopeMaxAge     = 90 * 24 * time.Hour
+	DefaultEnvelopeLimit      = 500
+	envelopePageSize          = 100
+)
+
+// ReconcileOptions controls the envelope reconciliation
+type ReconcileOptions struct {
+	// StaleAfter flags envelopes pending for longer than this duration as stale, zero disables the check
+	StaleAfter time.Duration
+	// VoidAfter voids envelopes pending for longer than this duration as abandoned, zero disables voiding
+	VoidAfter time.Duration
+	// MaxAge skips envelopes sent longer ago than this duration, defaults to DefaultEnvelopeMaxAge
+	MaxAge time.Duration
+	// Limit is the maximum number of envelopes checked in a run, defaults to DefaultEnvelopeLimit
+	Limit int
+	// DryRun reports the changes without updating signatures, voiding envelopes or logging events
+	DryRun bool
+}
+
+// ReconcileResult summarizes an envelope reconciliation run
+type ReconcileResult struct {
+	Checked   int
+	Unchanged int
+	// Unprocessed counts the envelopes completed with the provider whose signed callback was not processed, the
+	// signature stays unsigned and pending until the provider callback is replayed
+	Unprocessed int
+	// Completed counts the signatures signed since the envelope was sent, recorded as completed
+	Completed int
+	Declined  int
+	Voided    int
+	Abandoned int
+	Stale     int
+	Failed    int
+}
+
+// envelopeOutcome is the reconciliation decision for a pending envelope
+type envelopeOutcome struct {
+	// Status is the signature envelope status to record, empty when nothing changed
+	Status string
+	Reason string
+	// Void requests the envelope is voided with the provider before the status is recorded
+	Void bool
+	// EventType is the event to log, empty when no event is logged
+	EventType string
+	// Unprocessed reports a completed envelope whose signed callback was not processed
+	Unprocessed bool
+}
+
+// ReconcileEnvelopes polls the provider for the signatures waiting on the signatory, records declined and voided
+// envelopes, flags stale envelopes and optionally voids abandoned ones. Only the envelopes sent within the maximum age
+// are checked, up to the limit of the options.
+func (s *service) ReconcileEnvelopes(ctx context.Context, options ReconcileOptions) (*ReconcileResult, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.sign.ReconcileEnvelopes",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"staleAfter":     options.StaleAfter.String(),
+		"voidAfter":      options.VoidAfter.String(),
+		"dryRun":         options.DryRun,
+	}
+
+	maxAge, limit := options.MaxAge, options.Limit
+	if maxAge <= 0 {
+		maxAge = DefaultEnvelopeMaxAge
+	}
+	if limit <= 0 {
+		limit = DefaultEnvelopeLimit
+	}
+	f["maxAge"] = maxAge.String()
+	f["limit"] = limit
+
+	now := time.Now().UTC()
+	result := &ReconcileResult{}
+	var nextKey *string
+	for result.Checked < limit {
+		page, err := s.signatureService.GetPendingEnvelopeSignatures(ctx, now.Add(-maxAge), envelopePageSize, nextKey)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load the signatures with pending envelopes")
+			return nil, err
+		}
+		log.WithFields(f).Debugf("reconciling %d pending envelopes", len(page.Signatures))
+
+		for _, signature := range page.Signatures {
+			if result.Checked >= limit {
+				log.WithFields(f).Infof("reached the limit of %d envelopes, the remaining envelopes are reconciled by the next run", limit)
+				break
+			}
+			s.reconcileSignatureEnvelope(ctx, signature, now, options, result)
+		}
+
+		if page.LastKeyScanned == "" {
+			break
+		}
+		nextKey = &page.LastKeyScanned
+	}
+
+	log.WithFields(f).Debugf("envelope reconciliation result: %+v", *result)
+	return result, nil
+}
+
+// reconcileSignatureEnvelope polls the provider for the signature envelope and records the outcome in the result
+func (s *service) reconcileSignatureEnvelope(ctx context.Context, signature *v1Models.Signature, now time.Time, options ReconcileOptions, result *ReconcileResult) {
+	f := logrus.Fields{
+		"functionName":   "v2.sign.reconcileSignatureEnvelope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signature.SignatureID,
+		"dryRun":         options.DryRun,
+	}
+
+	result.Checked++
+	envelopeID := signature.SignatureEnvelopeID
+	if signature.SignatureSigned {
+		// signed by the provider callback - record the completed envelope so it leaves the pending envelope index
+		log.WithFields(f).Debugf("signature: %s is signed - recording the completed envelope: %s", signature.SignatureID, envelopeID)
+		if !options.DryRun {
+			if updateErr := s.signatureService.UpdateEnvelopeStatus(ctx, signature.SignatureID, envelopeID, signatures.EnvelopeStatusCompleted, ""); updateErr != nil {
+				log.WithFields(f).WithError(updateErr).Warnf("unable to record the completed envelope for signature: %s", signature.SignatureID)
+				result.Failed++
+				return
+			}
+		}
+		result.Completed++
+		return
+	}
+	provider := s.providers.ForEnvelope(envelopeID)
+
+	state, stateErr := provider.GetEnvelopeState(ctx, envelopeID)
+	if stateErr != nil {
+		log.WithFields(f).WithError(stateErr).Warnf("unable to get the %s envelope status for signature: %s", provider.Name(), signature.SignatureID)
+		result.Failed++
+		return
+	}
+
+	outcome := reconcileEnvelope(signature.SignatureEnvelopeStatus, state, now.Sub(envelopeSentOn(signature, now)), options)
+	if outcome.Unprocessed {
+		// the signature is only signed by the provider callback, the status is left pending so the envelope is
+		// reported again until the callback is replayed
+		log.WithFields(f).Warnf("%s envelope: %s of signature: %s is completed but the signed callback was not processed - replay the provider callback",
+			provider.Name(), envelopeID, signature.SignatureID)
+		result.record(outcome)
+		return
+	}
+	if outcome.Status == "" {
+		result.Unchanged++
+		return
+	}
+	log.WithFields(f).Debugf("signature: %s envelope: %s provider status: %s - recording: %s %s", signature.SignatureID, envelopeID, state.Status, outcome.Status, outcome.Reason)
+	if options.DryRun {
+		result.record(outcome)
+		return
+	}
+
+	if outcome.Void {
+		if voidErr := provider.VoidEnvelope(ctx, envelopeID, outcome.Reason); voidErr != nil {
+			log.WithFields(f).WithError(voidErr).Warnf("unable to void abandoned envelope: %s", envelopeID)
+			result.Failed++
+			return
+		}
+	}
+
+	if updateErr := s.signatureService.UpdateEnvelopeStatus(ctx, signature.SignatureID, envelopeID, outcome.Status, outcome.Reason); updateErr != nil {
+		log.WithFields(f).WithError(updateErr).Warnf("unable to record the envelope status for signature: %s", signature.SignatureID)
+		result.Failed++
+		return
+	}
+	result.record(outcome)
+
+	if outcome.EventType != "" {
+		s.logEnvelopeEvent(ctx, signature, outcome)
+	}
+}
+
+// logEnvelopeEvent logs the envelope status change against the CLA Group and the company or user
+func (s *service) logEnvelopeEvent(ctx context.Context, signature *v1Models.Signature, outcome envelopeOutcome) {
+	claType := utils.ClaTypeICLA
+	args := &events.LogEventArgs{
+		EventType:  outcome.EventType,
+		CLAGroupID: signature.ProjectID,
+		ProjectID:  signature.ProjectID,
+	}
+	if signature.SignatureReferenceType == utils.SignatureReferenceTypeCompany {
+		claType = utils.ClaTypeCCLA
+		args.CompanyID = signature.SignatureReferenceID
+	} else {
+		args.UserID = signature.SignatureReferenceID
+	}
+	args.EventData = &events.SignatureEnvelopeStatusEventData{
+		SignatureID: signature.SignatureID,
+		EnvelopeID:  signature.SignatureEnvelopeID,
+		ClaType:     claType,
+		Status:      outcome.Status,
+		Reason:      outcome.Reason,
+	}
+
+	s.eventsService.LogEventWithContext(ctx, args)
+}
+
+// reconcileEnvelope decides which status to record for an envelope which has been pending for the specified duration
+func reconcileEnvelope(currentStatus string, state *EnvelopeState, pendingFor time.Duration, options ReconcileOptions) envelopeOutcome {
+	switch state.Status {
+	case EnvelopeCompleted:
+		// the signed callback was not received or failed, the signature stays unsigned until it is replayed
+		return envelopeOutcome{Unprocessed: true}
+	case EnvelopeDeclined:
+		return envelopeOutcome{
+			Status:    signatures.EnvelopeStatusDeclined,
+			Reason:    reasonOrDefault(state.Reason, "declined by the signatory"),
+			EventType: events.SignatureEnvelopeDeclined,
+		}
+	case EnvelopeVoided:
+		return envelopeOutcome{
+			Status:    signatures.EnvelopeStatusVoided,
+			Reason:    reasonOrDefault(state.Reason, "voided"),
+			EventType: events.SignatureEnvelopeVoided,
+		}
+	}
+
+	days := int(pendingFor.Hours() / 24)
+	if options.VoidAfter > 0 && pendingFor >= options.VoidAfter {
+		return envelopeOutcome{
+			Status:    signatures.EnvelopeStatusVoided,
+			Reason:    fmt.Sprintf("abandoned - pending signatory for %d days", days),
+			Void:      true,
+			EventType: events.SignatureEnvelopeVoided,
+		}
+	}
+
+	if options.StaleAfter > 0 && pendingFor >= options.StaleAfter {
+		outcome := envelopeOutcome{
+			Status: signatures.EnvelopeStatusStale,
+			Reason: fmt.Sprintf("pending signatory for %d days", days),
+		}
+		// the event is only logged the first time the envelope is flagged
+		if currentStatus != signatures.EnvelopeStatusStale {
+			outcome.EventType = events.SignatureEnvelopeStale
+		}
+		return outcome
+	}
+
+	if state.Status != "" && state.Status != currentStatus {
+		return envelopeOutcome{Status: state.Status}
+	}
+
+	return envelopeOutcome{}
+}
+
+// envelopeSentOn returns the date the envelope was sent, older signatures without the sent date fall back to the
+// signature modified and created dates
+func envelopeSentOn(signature *v1Models.Signature, now time.Time) time.Time {
+	for _, value := range []string{signature.SignatureEnvelopeSentOn, signature.SignatureModified, signature.SignatureCreated} {
+		if value == "" {
+			continue
+		}
+		if sentOn, err := utils.ParseDateTime(value); err == nil {
+			return sentOn
+		}
+	}
+	return now
+}
+
+func reasonOrDefault(reason, defaultReason string) string {
+	if reason == "" {
+		return defaultReason
+	}
+	return reason
+}
+
+func (r *ReconcileResult) record(outcome envelopeOutcome) {
+	switch {
+	case outcome.Unprocessed:
+		r.Unprocessed++
+	case outcome.Void:
+		r.Abandoned++
+	case outcome.Status == signatures.EnvelopeStatusDeclined:
+		r.Declined++
+	case outcome.Status == signatures.EnvelopeStatusVoided:
+		r.Voided++
+	case outcome.Status == signatures.EnvelopeStatusStale:
+		r.Stale++
+	default:
+		r.Unchanged++
+	}
+}
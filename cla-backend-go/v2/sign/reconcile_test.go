@@ -0,0 +1,239 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	mock_events "github.com/linuxfoundation/easycla/cla-backend-go/events/mock"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	mock_signatures "github.com/linuxfoundation/easycla/cla-backend-go/signatures/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const day = 24 * time.Hour
+
+func TestReconcileEnvelope(t *testing.T) {
+	options := ReconcileOptions{StaleAfter: 14 * day, VoidAfter: 60 * day}
+
+	testCases := []struct {
+		name          string
+		currentStatus string
+		state         EnvelopeState
+		pendingFor    time.Duration
+		options       ReconcileOptions
+		expected      envelopeOutcome
+	}{
+		{
+			name:          "pending envelope is unchanged",
+			currentStatus: signatures.EnvelopeStatusSent,
+			state:         EnvelopeState{Status: EnvelopeSent},
+			pendingFor:    2 * day,
+			options:       options,
+			expected:      envelopeOutcome{},
+		},
+		{
+			name:          "delivered envelope records the status without an event",
+			currentStatus: signatures.EnvelopeStatusSent,
+			state:         EnvelopeState{Status: EnvelopeDelivered},
+			pendingFor:    2 * day,
+			options:       options,
+			expected:      envelopeOutcome{Status: signatures.EnvelopeStatusDelivered},
+		},
+		{
+			name:          "declined envelope records the provider reason",
+			currentStatus: signatures.EnvelopeStatusSent,
+			state:         EnvelopeState{Status: EnvelopeDeclined, Reason: "not my employer"},
+			pendingFor:    2 * day,
+			options:       options,
+			expected: envelopeOutcome{
+				Status:    signatures.EnvelopeStatusDeclined,
+				Reason:    "not my employer",
+				EventType: events.SignatureEnvelopeDeclined,
+			},
+		},
+		{
+			name:          "declined envelope without a reason",
+			currentStatus: signatures.EnvelopeStatusStale,
+			state:         EnvelopeState{Status: EnvelopeDeclined},
+			pendingFor:    20 * day,
+			options:       options,
+			expected: envelopeOutcome{
+				Status:    signatures.EnvelopeStatusDeclined,
+				Reason:    "declined by the signatory",
+				EventType: events.SignatureEnvelopeDeclined,
+			},
+		},
+		{
+			name:          "voided envelope",
+			currentStatus: signatures.EnvelopeStatusSent,
+			state:         EnvelopeState{Status: EnvelopeVoided, Reason: "session expired"},
+			pendingFor:    2 * day,
+			options:       options,
+			expected: envelopeOutcome{
+				Status:    signatures.EnvelopeStatusVoided,
+				Reason:    "session expired",
+				EventType: events.SignatureEnvelopeVoided,
+			},
+		},
+		{
+			name:          "completed envelope is reported as unprocessed without recording a status",
+			currentStatus: signatures.EnvelopeStatusSent,
+			state:         EnvelopeState{Status: EnvelopeCompleted},
+			pendingFor:    2 * day,
+			options:       options,
+			expected:      envelopeOutcome{Unprocessed: true},
+		},
+		{
+			name:          "pending envelope becomes stale",
+			currentStatus: signatures.EnvelopeStatusDelivered,
+			state:         EnvelopeState{Status: EnvelopeDelivered},
+			pendingFor:    15 * day,
+			options:       options,
+			expected: envelopeOutcome{
+				Status:    signatures.EnvelopeStatusStale,
+				Reason:    "pending signatory for 15 days",
+				EventType: events.SignatureEnvelopeStale,
+			},
+		},
+		{
+			name:          "stale envelope only logs the event once",
+			currentStatus: signatures.EnvelopeStatusStale,
+			state:         EnvelopeState{Status: EnvelopeSent},
+			pendingFor:    30 * day,
+			options:       options,
+			expected: envelopeOutcome{
+				Status: signatures.EnvelopeStatusStale,
+				Reason: "pending signatory for 30 days",
+			},
+		},
+		{
+			name:          "abandoned envelope is voided",
+			currentStatus: signatures.EnvelopeStatusStale,
+			state:         EnvelopeState{Status: EnvelopeSent},
+			pendingFor:    61 * day,
+			options:       options,
+			expected: envelopeOutcome{
+				Status:    signatures.EnvelopeStatusVoided,
+				Reason:    "abandoned - pending signatory for 61 days",
+				Void:      true,
+				EventType: events.SignatureEnvelopeVoided,
+			},
+		},
+		{
+			name:          "abandoned envelope is not voided when disabled",
+			currentStatus: signatures.EnvelopeStatusStale,
+			state:         EnvelopeState{Status: EnvelopeSent},
+			pendingFor:    61 * day,
+			options:       ReconcileOptions{StaleAfter: 14 * day},
+			expected: envelopeOutcome{
+				Status: signatures.EnvelopeStatusStale,
+				Reason: "pending signatory for 61 days",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := tc.state
+			assert.Equal(t, tc.expected, reconcileEnvelope(tc.currentStatus, &state, tc.pendingFor, tc.options))
+		})
+	}
+}
+
+func TestEnvelopeSentOn(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	sentOn := envelopeSentOn(&v1Models.Signature{
+		SignatureEnvelopeSentOn: "2021-05-01T00:00:00Z",
+		SignatureModified:       "2021-05-20T00:00:00Z",
+		SignatureCreated:        "2021-04-01T00:00:00Z",
+	}, now)
+	assert.Equal(t, "2021-05-01", sentOn.Format("2006-01-02"))
+
+	// signatures created before the sent date was recorded use the modified date
+	sentOn = envelopeSentOn(&v1Models.Signature{
+		SignatureModified: "2021-05-20T00:00:00Z",
+		SignatureCreated:  "2021-04-01T00:00:00Z",
+	}, now)
+	assert.Equal(t, "2021-05-20", sentOn.Format("2006-01-02"))
+
+	assert.Equal(t, now, envelopeSentOn(&v1Models.Signature{}, now))
+}
+
+func TestReconcileEnvelopes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := newStubProvider(ProviderDocuSign)
+	for _, signatureID := range []string{"sent", "completed", "declined", "over-limit"} {
+		provider.envelopes[provider.prefix+signatureID] = &EnvelopeRequest{SignatureID: signatureID}
+	}
+	provider.states[provider.prefix+"completed"] = EnvelopeCompleted
+	provider.states[provider.prefix+"declined"] = EnvelopeDeclined
+	providers, err := NewProviders(ProviderDocuSign, nil, provider)
+	assert.NoError(t, err)
+
+	pendingSignature := func(signatureID string) *v1Models.Signature {
+		return &v1Models.Signature{
+			SignatureID:             signatureID,
+			SignatureEnvelopeID:     provider.prefix + signatureID,
+			SignatureEnvelopeStatus: signatures.EnvelopeStatusSent,
+			SignatureEnvelopeSentOn: time.Now().UTC().Format(time.RFC3339),
+		}
+	}
+
+	signatureService := mock_signatures.NewMockSignatureService(ctrl)
+	nextKey := "next-key"
+	signatureService.EXPECT().GetPendingEnvelopeSignatures(gomock.Any(), gomock.Any(), int64(envelopePageSize), (*string)(nil)).
+		Return(&v1Models.Signatures{Signatures: []*v1Models.Signature{pendingSignature("sent"), pendingSignature("completed")}, LastKeyScanned: nextKey}, nil)
+	signatureService.EXPECT().GetPendingEnvelopeSignatures(gomock.Any(), gomock.Any(), int64(envelopePageSize), &nextKey).
+		Return(&v1Models.Signatures{Signatures: []*v1Models.Signature{pendingSignature("declined"), pendingSignature("over-limit")}, LastKeyScanned: "last-key"}, nil)
+	// the completed envelope is left pending, only the declined envelope status is recorded
+	signatureService.EXPECT().UpdateEnvelopeStatus(gomock.Any(), "declined", provider.prefix+"declined", signatures.EnvelopeStatusDeclined, "declined by the signatory").
+		Return(nil)
+
+	eventsService := mock_events.NewMockService(ctrl)
+	eventsService.EXPECT().LogEventWithContext(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, args *events.LogEventArgs) {
+			assert.Equal(t, events.SignatureEnvelopeDeclined, args.EventType)
+		})
+
+	s := &service{providers: providers, signatureService: signatureService, eventsService: eventsService}
+	result, err := s.ReconcileEnvelopes(context.Background(), ReconcileOptions{StaleAfter: 14 * day, Limit: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, ReconcileResult{Checked: 3, Unchanged: 1, Unprocessed: 1, Declined: 1}, *result)
+}
+
+func TestReconcileEnvelopesRecordsSignedSignatures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := newStubProvider(ProviderDocuSign)
+	providers, err := NewProviders(ProviderDocuSign, nil, provider)
+	assert.NoError(t, err)
+
+	// the signature was signed by the provider callback, the provider isn't polled
+	signed := &v1Models.Signature{
+		SignatureID:             "signed",
+		SignatureSigned:         true,
+		SignatureEnvelopeID:     provider.prefix + "signed",
+		SignatureEnvelopeStatus: signatures.EnvelopeStatusSent,
+	}
+	signatureService := mock_signatures.NewMockSignatureService(ctrl)
+	signatureService.EXPECT().GetPendingEnvelopeSignatures(gomock.Any(), gomock.Any(), int64(envelopePageSize), (*string)(nil)).
+		Return(&v1Models.Signatures{Signatures: []*v1Models.Signature{signed}}, nil)
+	signatureService.EXPECT().UpdateEnvelopeStatus(gomock.Any(), "signed", provider.prefix+"signed", signatures.EnvelopeStatusCompleted, "").
+		Return(nil)
+
+	s := &service{providers: providers, signatureService: signatureService, eventsService: mock_events.NewMockService(ctrl)}
+	result, err := s.ReconcileEnvelopes(context.Background(), ReconcileOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, ReconcileResult{Checked: 1, Completed: 1}, *result)
+}
@@ -5,8 +5,6 @@ package sign
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
@@ -75,14 +73,6 @@ type ProjectRepo interface {
 
 // Service interface defines the sign service methods
 type Service interface {
-	VoidEnvelope(ctx context.Context, envelopeID, message string) error
-	PrepareSignRequest(ctx context.Context, signRequest *DocuSignEnvelopeRequest) (*DocusignEnvelopeResponse, error)
-	GetSignURL(email, recipientID, userName, clientUserId, envelopeID, returnURL string) (string, error)
-	createEnvelope(ctx context.Context, payload *DocuSignEnvelopeRequest) (string, error)
-	addDocumentToEnvelope(ctx context.Context, envelopeID, documentName string, document []byte) error
-	GetSignedDocument(ctx context.Context, envelopeID, documentID string) ([]byte, error)
-	GetEnvelopeDocuments(ctx context.Context, envelopeID string) ([]DocuSignDocument, error)
-
 	RequestCorporateSignature(ctx context.Context, lfUsername string, authorizationHeader string, input *models.CorporateSignatureInput) (*models.CorporateSignatureOutput, error)
 	RequestIndividualSignature(ctx context.Context, input *models.IndividualSignatureInput, preferredEmail string) (*models.IndividualSignatureOutput, error)
 	RequestIndividualSignatureGerrit(ctx context.Context, input *models.IndividualSignatureInput) (*models.IndividualSignatureOutput, error)
@@ -91,6 +81,12 @@ type Service interface {
 	SignedIndividualCallbackGerrit(ctx context.Context, payload []byte, userID string) error
 	SignedCorporateCallback(ctx context.Context, payload []byte, companyID, projectID string) error
 	GetUserActiveSignature(ctx context.Context, userID string) (*models.UserActiveSignature, error)
+
+	GetClickThroughSession(ctx context.Context, envelopeID string) (*ClickThroughSession, error)
+	GetClickThroughDocument(ctx context.Context, envelopeID string) ([]byte, error)
+	ConsentClickThrough(ctx context.Context, envelopeID string, evidence *ClickThroughEvidence) (*ClickThroughSession, error)
+
+	ReconcileEnvelopes(ctx context.Context, options ReconcileOptions) (*ReconcileResult, error)
 }
 
 // service
@@ -102,7 +98,7 @@ type service struct {
 	projectClaGroupsRepo  projects_cla_groups.Repository
 	companyService        company.IService
 	claGroupService       cla_groups.Service
-	docsignPrivateKey     string
+	providers             *Providers
 	userService           users.Service
 	signatureService      signatures.SignatureService
 	storeRepository       store.Repository
@@ -119,7 +115,7 @@ type service struct {
 }
 
 // NewService returns an instance of v2 project service
-func NewService(apiURL, v1API string, compRepo company.IRepository, projectRepo ProjectRepo, pcgRepo projects_cla_groups.Repository, compService company.IService, claGroupService cla_groups.Service, docsignPrivateKey string, userService users.Service, signatureService signatures.SignatureService, storeRepository store.Repository,
+func NewService(apiURL, v1API string, compRepo company.IRepository, projectRepo ProjectRepo, pcgRepo projects_cla_groups.Repository, compService company.IService, claGroupService cla_groups.Service, providers *Providers, userService users.Service, signatureService signatures.SignatureService, storeRepository store.Repository,
 	repositoryService repositories.Service, githubOrgService github_organizations.Service, gitlabOrgService gitlab_organizations.ServiceInterface, claLandingPage string, claLogoURL string, emailTemplateService emails.EmailTemplateService, eventsService events.Service, gitlabActivityService gitlab_activity.Service, gitlabApp *gitlab_api.App,
 	gerritService gerrits.Service) Service {
 	return &service{
@@ -130,7 +126,7 @@ func NewService(apiURL, v1API string, compRepo company.IRepository, projectRepo
 		projectClaGroupsRepo:  pcgRepo,
 		companyService:        compService,
 		claGroupService:       claGroupService,
-		docsignPrivateKey:     docsignPrivateKey,
+		providers:             providers,
 		userService:           userService,
 		signatureService:      signatureService,
 		storeRepository:       storeRepository,
@@ -400,20 +396,18 @@ func (s *service) SignedIndividualCallbackGithub(ctx context.Context, payload []
 
 	log.WithFields(f).Debug("processing signed individual callback...")
 
-	var info DocuSignEnvelopeInformation
-
-	err := xml.Unmarshal(payload, &info)
+	provider, completion, err := s.providers.ParseCompletion(ctx, payload)
 	if err != nil {
-		log.WithFields(f).WithError(err).Warn("unable to unmarshal xml payload")
+		log.WithFields(f).WithError(err).Warn("unable to parse the signed callback payload")
 		return err
 	}
 
-	envelopeID := info.EnvelopeStatus.EnvelopeID
-	signatureID := info.EnvelopeStatus.RecipientStatuses[0].ClientUserId
-	status := info.EnvelopeStatus.RecipientStatuses[0].Status
-	signedDate := info.EnvelopeStatus.RecipientStatuses[0].Signed
-	documentID := info.EnvelopeStatus.DocumentStatuses[0].ID
-	fullName := fetchFullName(info)
+	envelopeID := completion.EnvelopeID
+	signatureID := completion.SignatureID
+	status := completion.RecipientStatus
+	signedDate := completion.SignedDate
+	documentID := completion.DocumentID
+	fullName := completion.FullName
 
 	log.WithFields(f).Debugf("envelopeID: %s, signatureID: %s, status: %s, signedDate: %s, fullName: %s", envelopeID, signatureID, status, signedDate, fullName)
 
@@ -508,7 +502,7 @@ func (s *service) SignedIndividualCallbackGithub(ctx context.Context, payload []
 
 		//Get signed document
 		log.WithFields(f).Debugf("getting signed document for envelope ID: %s", envelopeID)
-		signedDocument, err := s.GetSignedDocument(ctx, envelopeID, documentID)
+		signedDocument, err := provider.GetSignedDocument(ctx, envelopeID, documentID)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warnf("unable to get signed document for envelope ID: %s", envelopeID)
 			return err
@@ -538,8 +532,9 @@ func (s *service) SignedIndividualCallbackGithub(ctx context.Context, payload []
 		}
 
 		recipients := []string{utils.GetBestEmail(claUser)}
+		emailParams.RecipientAddress = email
 
-		body, err := emails.RenderDocumentSignedTemplate(s.emailTemplateService, claGroup.Version, claGroup.ProjectExternalID, emailParams)
+		subject, body, err := emails.RenderDocumentSignedTemplate(s.emailTemplateService, subject, claGroup.Version, claGroup.ProjectExternalID, emailParams)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warnf("unable to render document signed template for project version: %s, project ID: %s", claGroup.Version, claGroup.ProjectID)
 			return err
@@ -609,22 +604,6 @@ func (s *service) SignedIndividualCallbackGithub(ctx context.Context, payload []
 
 }
 
-func fetchFullName(info DocuSignEnvelopeInformation) string {
-	var fullName string
-	for _, tabStatus := range info.EnvelopeStatus.RecipientStatuses[0].TabStatuses {
-		if tabStatus.TabLabel == "full_name" {
-			if tabStatus.TabValue != "" {
-				fullName = tabStatus.TabValue
-			}
-		} else if tabStatus.TabLabel == "signatory_name" {
-			if tabStatus.TabValue != "" {
-				fullName = tabStatus.TabValue
-			}
-		}
-	}
-	return fullName
-}
-
 func (s *service) SignedIndividualCallbackGitlab(ctx context.Context, payload []byte, userID, organizationID, repositoryID, mergeRequestID string) error {
 	f := logrus.Fields{
 		"functionName":   "sign.SignedIndividualCallbackGitlab",
@@ -636,20 +615,18 @@ func (s *service) SignedIndividualCallbackGitlab(ctx context.Context, payload []
 	}
 
 	log.WithFields(f).Debug("processing signed individual callback...")
-	var info DocuSignEnvelopeInformation
-
-	err := xml.Unmarshal(payload, &info)
+	provider, completion, err := s.providers.ParseCompletion(ctx, payload)
 	if err != nil {
-		log.WithFields(f).WithError(err).Warn("unable to unmarshal xml payload")
+		log.WithFields(f).WithError(err).Warn("unable to parse the signed callback payload")
 		return err
 	}
 
-	envelopeID := info.EnvelopeStatus.EnvelopeID
-	signatureID := info.EnvelopeStatus.RecipientStatuses[0].ClientUserId
-	status := info.EnvelopeStatus.RecipientStatuses[0].Status
-	signedDate := info.EnvelopeStatus.RecipientStatuses[0].Signed
-	documentID := info.EnvelopeStatus.DocumentStatuses[0].ID
-	fullName := fetchFullName(info)
+	envelopeID := completion.EnvelopeID
+	signatureID := completion.SignatureID
+	status := completion.RecipientStatus
+	signedDate := completion.SignedDate
+	documentID := completion.DocumentID
+	fullName := completion.FullName
 
 	log.WithFields(f).Debugf("envelopeID: %s, signatureID: %s, status: %s, signedDate: %s, fullName: %s", envelopeID, signatureID, status, signedDate, fullName)
 
@@ -781,7 +758,7 @@ func (s *service) SignedIndividualCallbackGitlab(ctx context.Context, payload []
 
 		//Get signed document
 		log.WithFields(f).Debugf("getting signed document for envelope ID: %s", envelopeID)
-		signedDocument, err := s.GetSignedDocument(ctx, envelopeID, documentID)
+		signedDocument, err := provider.GetSignedDocument(ctx, envelopeID, documentID)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warnf("unable to get signed document for envelope ID: %s", envelopeID)
 			return err
@@ -812,8 +789,9 @@ func (s *service) SignedIndividualCallbackGitlab(ctx context.Context, payload []
 		}
 
 		recipients := []string{utils.GetBestEmail(claUser)}
+		emailParams.RecipientAddress = email
 
-		body, err := emails.RenderDocumentSignedTemplate(s.emailTemplateService, claGroup.Version, claGroup.ProjectExternalID, emailParams)
+		subject, body, err := emails.RenderDocumentSignedTemplate(s.emailTemplateService, subject, claGroup.Version, claGroup.ProjectExternalID, emailParams)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warnf("unable to render document signed template for project version: %s, project ID: %s", claGroup.Version, claGroup.ProjectID)
 			return err
@@ -870,20 +848,18 @@ func (s *service) SignedIndividualCallbackGerrit(ctx context.Context, payload []
 	}
 
 	log.WithFields(f).Debug("processing signed individual callback...")
-	var info DocuSignEnvelopeInformation
-
-	err := xml.Unmarshal(payload, &info)
+	provider, completion, err := s.providers.ParseCompletion(ctx, payload)
 	if err != nil {
-		log.WithFields(f).WithError(err).Warn("unable to unmarshal xml payload")
+		log.WithFields(f).WithError(err).Warn("unable to parse the signed callback payload")
 		return err
 	}
 
-	envelopeID := info.EnvelopeStatus.EnvelopeID
-	signatureID := info.EnvelopeStatus.RecipientStatuses[0].ClientUserId
-	status := info.EnvelopeStatus.RecipientStatuses[0].Status
-	signedDate := info.EnvelopeStatus.RecipientStatuses[0].Signed
-	documentID := info.EnvelopeStatus.DocumentStatuses[0].ID
-	fullName := fetchFullName(info)
+	envelopeID := completion.EnvelopeID
+	signatureID := completion.SignatureID
+	status := completion.RecipientStatus
+	signedDate := completion.SignedDate
+	documentID := completion.DocumentID
+	fullName := completion.FullName
 
 	log.WithFields(f).Debugf("envelopeID: %s, signatureID: %s, status: %s, signedDate: %s, fullName: %s", envelopeID, signatureID, status, signedDate, fullName)
 
@@ -942,7 +918,7 @@ func (s *service) SignedIndividualCallbackGerrit(ctx context.Context, payload []
 
 		//Get signed document
 		log.WithFields(f).Debugf("getting signed document for envelope ID: %s", envelopeID)
-		signedDocument, err := s.GetSignedDocument(ctx, envelopeID, documentID)
+		signedDocument, err := provider.GetSignedDocument(ctx, envelopeID, documentID)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warnf("unable to get signed document for envelope ID: %s", envelopeID)
 			return err
@@ -975,8 +951,9 @@ func (s *service) SignedIndividualCallbackGerrit(ctx context.Context, payload []
 		}
 
 		recipients := []string{utils.GetBestEmail(claUser)}
+		emailParams.RecipientAddress = email
 
-		body, err := emails.RenderDocumentSignedTemplate(s.emailTemplateService, claGroup.Version, claGroup.ProjectExternalID, emailParams)
+		subject, body, err := emails.RenderDocumentSignedTemplate(s.emailTemplateService, subject, claGroup.Version, claGroup.ProjectExternalID, emailParams)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warnf("unable to render document signed template for project version: %s, project ID: %s", claGroup.Version, claGroup.ProjectID)
 			return err
@@ -1050,15 +1027,13 @@ func (s *service) SignedCorporateCallback(ctx context.Context, payload []byte, c
 	}
 
 	log.WithFields(f).Debug("processing signed corporate callback...")
-	var info DocuSignEnvelopeInformation
-
-	err := xml.Unmarshal(payload, &info)
+	provider, completion, err := s.providers.ParseCompletion(ctx, payload)
 	if err != nil {
-		log.WithFields(f).WithError(err).Warn("unable to unmarshal xml payload")
+		log.WithFields(f).WithError(err).Warn("unable to parse the signed callback payload")
 		return err
 	}
 
-	envelopeID := info.EnvelopeStatus.EnvelopeID
+	envelopeID := completion.EnvelopeID
 
 	log.WithFields(f).Debugf("envelopeID: %s", envelopeID)
 
@@ -1087,7 +1062,7 @@ func (s *service) SignedCorporateCallback(ctx context.Context, payload []byte, c
 	// Assumme only one signature per company/project
 	var signatureID string
 	var signature *v1Models.Signature
-	clientUserID := info.EnvelopeStatus.RecipientStatuses[0].ClientUserId
+	clientUserID := completion.SignatureID
 	if clientUserID == "" {
 		approved := true
 		var sigErr error
@@ -1149,7 +1124,7 @@ func (s *service) SignedCorporateCallback(ctx context.Context, payload []byte, c
 	}
 
 	// Update the signature status if changed
-	status := info.EnvelopeStatus.Status
+	status := completion.Status
 	if status == DocusignCompleted && !signature.SignatureSigned {
 		_, currentTime := utils.CurrentTime()
 		updates := map[string]interface{}{
@@ -1159,7 +1134,7 @@ func (s *service) SignedCorporateCallback(ctx context.Context, payload []byte, c
 			"signed_on":               currentTime,
 		}
 
-		userSignedDate := info.EnvelopeStatus.RecipientStatuses[0].Signed
+		userSignedDate := completion.SignedDate
 		if userSignedDate != "" {
 			updates["user_docusign_date_signed"] = userSignedDate
 		}
@@ -1179,7 +1154,7 @@ func (s *service) SignedCorporateCallback(ctx context.Context, payload []byte, c
 
 	// store document on S3
 	log.WithFields(f).Debugf("storing signed document on S3...")
-	signedDocument, err := s.GetSignedDocument(ctx, envelopeID, info.EnvelopeStatus.DocumentStatuses[0].ID)
+	signedDocument, err := provider.GetSignedDocument(ctx, envelopeID, completion.DocumentID)
 
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("unable to get signed document for envelope ID: %s", envelopeID)
@@ -1370,7 +1345,9 @@ func (s *service) RequestIndividualSignature(ctx context.Context, input *models.
 
 	if latestSignature != nil {
 		log.WithFields(f).Debugf("comparing latest signature document version: %s to latest document version: %s", latestSignature.SignatureDocumentMajorVersion, latestDocument.DocumentMajorVersion)
-		if latestDocument.DocumentMajorVersion == latestSignature.SignatureDocumentMajorVersion {
+		// signatures outdated by the re-sign policy are not reused, the user signs the latest document again
+		if latestDocument.DocumentMajorVersion == latestSignature.SignatureDocumentMajorVersion &&
+			!signatures.IsSignatureOutdated(latestSignature, signatures.ResignPolicyFor(claGroup, utils.ClaTypeICLA)) {
 
 			log.WithFields(f).Warnf("user: already has a signature with this project: %s", *input.ProjectID)
 
@@ -1678,7 +1655,6 @@ func (s *service) populateSignURL(ctx context.Context,
 	var project *v1Models.ClaGroup
 	var companyModel *v1Models.Company
 	var err error
-	var signer DocuSignRecipient
 	var emailBody string
 	var emailSubject string
 
@@ -1726,11 +1702,11 @@ func (s *service) populateSignURL(ctx context.Context,
 	// Void the existing envelope to prevent multiple envelopes pending for a signer
 	envelopeID := latestSignature.SignatureEnvelopeID
 	if envelopeID != "" {
-		message := fmt.Sprintf("You are getting this message because your DocuSign Session for project %s expired. A new session will be in place for your signing process.", project.ProjectName)
+		message := fmt.Sprintf("You are getting this message because your signing session for project %s expired. A new session will be in place for your signing process.", project.ProjectName)
 		log.WithFields(f).Debug(message)
-		err = s.VoidEnvelope(ctx, envelopeID, message)
+		err = s.providers.ForEnvelope(envelopeID).VoidEnvelope(ctx, envelopeID, message)
 		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("DocuSign error while voiding the envelope - regardless, continuing on..., error: %s", err)
+			log.WithFields(f).WithError(err).Warnf("error while voiding the envelope - regardless, continuing on..., error: %s", err)
 		}
 	}
 
@@ -1741,14 +1717,13 @@ func (s *service) populateSignURL(ctx context.Context,
 	randomInteger := r.Intn(1000000) //nolint:gosec
 	documentID := strconv.Itoa(randomInteger)
 
-	tab := getTabsFromDocument(&document, documentID, defaultValues)
-
 	// # Create the envelope request object
+	var signatoryName, signatoryEmail string
 
 	if sendAsEmail {
 		log.WithFields(f).Warnf("assigning signatory name/email: %s/%s", authorityOrSignatoryName, authorityOrSignatoryEmail)
-		signatoryEmail := authorityOrSignatoryEmail
-		signatoryName := authorityOrSignatoryName
+		signatoryEmail = authorityOrSignatoryEmail
+		signatoryName = authorityOrSignatoryName
 
 		var projectName string
 		var companyName string
@@ -1795,23 +1770,13 @@ func (s *service) populateSignURL(ctx context.Context,
 		emailSubject, emailBody = claSignatoryEmailContent(*claSignatoryParams)
 		log.WithFields(f).Debugf("subject: %s, body: %s", emailSubject, emailBody)
 
-		signer = DocuSignRecipient{
-			Email:       signatoryEmail,
-			Name:        signatoryName,
-			Tabs:        tab,
-			RecipientId: "1",
-			RoleName:    "signer",
-		}
-
 	} else {
 		// This will be the Initial CLA Manager
-		signatoryName := userSignatureName
-		signatoryEmail := userSignatureEmail
+		signatoryName = userSignatureName
+		signatoryEmail = userSignatureEmail
 
-		// Assigning a clientUserId does not send an email.
-		// It assumes that the user handles the communication with the client.
-		// In this case, the user opened the docusign document to manually sign it.
-		// Thus the email does not need to be sent.
+		// Embedded requests do not send an email - the user opens the document
+		// from the returned sign URL to manually sign it.
 
 		log.WithFields(f).Debugf("signatoryName: %s, signatoryEmail: %s", signatoryName, signatoryEmail)
 
@@ -1836,15 +1801,6 @@ func (s *service) populateSignURL(ctx context.Context,
 		log.WithFields(f).Debugf("userIdentifier: %s", userIdentifier)
 
 		emailBody = fmt.Sprintf("CLA Sign Request for %s", userIdentifier)
-
-		signer = DocuSignRecipient{
-			Email:        signatoryEmail,
-			Name:         signatoryName,
-			Tabs:         tab,
-			RecipientId:  "1",
-			ClientUserId: latestSignature.SignatureID,
-			RoleName:     "signer",
-		}
 	}
 
 	contentType := document.DocumentContentType
@@ -1875,107 +1831,43 @@ func (s *service) populateSignURL(ctx context.Context,
 	log.WithFields(f).Debugf("documentName: %s", documentName)
 	log.WithFields(f).Debugf("contentType: %s", contentType)
 
-	docusignDocument := DocuSignDocument{
-		Name:           documentName,
-		DocumentId:     documentID,
-		FileExtension:  "pdf",
-		FileFormatHint: "pdf",
-		Order:          "1",
-		DocumentBase64: base64.StdEncoding.EncodeToString(pdf),
-	}
-
-	var envelopeRequest DocuSignEnvelopeRequest
-
-	if callbackURL != "" {
-		// Webhook properties for callbacks after the user signs the document.
-		// Ensure that a webhook is returned on the status "Completed" where
-		// all signers on a document finish signing the document.
-		log.WithFields(f).Debugf("setting up webhook properties with callback url: %s", callbackURL)
-		recipientEvents := []DocuSignRecipientEvent{
-			{
-				EnvelopeEventStatusCode: "Completed",
-			},
-		}
-
-		eventNotification := DocuSignEventNotification{
-			URL:            callbackURL,
-			LoggingEnabled: true,
-			EnvelopeEvents: recipientEvents,
-		}
-
-		envelopeRequest = DocuSignEnvelopeRequest{
-			Documents: []DocuSignDocument{
-				docusignDocument,
-			},
-			EmailSubject:      emailSubject,
-			EmailBlurb:        emailBody,
-			EventNotification: eventNotification,
-			Status:            "sent",
-			Recipients: DocuSignRecipientType{
-				Signers: []DocuSignRecipient{
-					signer,
-				},
-			},
-		}
-
-	} else {
-
-		envelopeRequest = DocuSignEnvelopeRequest{
-			Documents: []DocuSignDocument{
-				docusignDocument,
-			},
-			EmailSubject: emailSubject,
-			EmailBlurb:   emailBody,
-			Status:       "sent",
-			Recipients: DocuSignRecipientType{
-				Signers: []DocuSignRecipient{
-					signer,
-				},
-			},
-		}
-
+	envelopeRequest := &EnvelopeRequest{
+		SignatureID:   latestSignature.SignatureID,
+		ProjectID:     project.ProjectID,
+		Document:      &document,
+		DocumentID:    documentID,
+		DocumentName:  documentName,
+		PDF:           pdf,
+		DefaultValues: defaultValues,
+		SignerName:    signatoryName,
+		SignerEmail:   signatoryEmail,
+		Embedded:      !sendAsEmail,
+		EmailSubject:  emailSubject,
+		EmailBody:     emailBody,
+		CallbackURL:   callbackURL,
+		// The URL the user will be redirected to after signing.
+		// This route will be in charge of extracting the signature's return_url and redirecting.
+		ReturnURL: fmt.Sprintf("%s/v2/return-url/%s", s.ClaV1ApiURL, latestSignature.SignatureID),
 	}
 
-	envelopeResponse, err := s.PrepareSignRequest(ctx, &envelopeRequest)
-
+	provider := s.providers.ForCLAGroup(project.ProjectID)
+	log.WithFields(f).Debugf("creating envelope using the %s provider...", provider.Name())
+	envelope, err := provider.CreateEnvelope(ctx, envelopeRequest)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("unable to create envelope for user: %s", latestSignature.SignatureReferenceID)
 		return err
 	}
 
-	log.WithFields(f).Debugf("envelopeID: %s", envelopeResponse.EnvelopeId)
+	log.WithFields(f).Debugf("envelopeID: %s", envelope.EnvelopeID)
 
 	if !sendAsEmail {
-		// The URL the user will be redirected to after signing.
-		// This route will be in charge of extracting the signature's return_url and redirecting.
-		recipients, recipientErr := s.getEnvelopeRecipients(ctx, envelopeResponse.EnvelopeId)
-		if recipientErr != nil {
-			log.WithFields(f).Debugf("unable to fetch recipients for envelope: %s", envelopeResponse.EnvelopeId)
-			return recipientErr
-		}
-
-		if len(recipients) == 0 {
-			log.WithFields(f).Debugf("no envelope recipients found : %s", envelopeResponse.EnvelopeId)
-			return errors.New("no envelope recipients found")
-		}
-		recipient := recipients[0]
-		returnURL := fmt.Sprintf("%s/v2/return-url/%s", s.ClaV1ApiURL, recipient.ClientUserId)
-
-		log.WithFields(f).Debugf("generating signature sign_url, using return-url as: %s", returnURL)
-		signURL, signErr := s.GetSignURL(signer.Email, signer.RecipientId, signer.Name, signer.ClientUserId, envelopeResponse.EnvelopeId, returnURL)
-
-		if signErr != nil {
-			log.WithFields(f).WithError(err).Warnf("unable to get sign url for user: %s", latestSignature.SignatureReferenceID)
-			return signErr
-		}
-
-		log.WithFields(f).Debugf("setting signature sign_url as: %s", signURL)
-		latestSignature.SignatureSignURL = signURL
+		log.WithFields(f).Debugf("setting signature sign_url as: %s", envelope.SignURL)
+		latestSignature.SignatureSignURL = envelope.SignURL
 	}
 
 	// Save Envelope ID in signature.
 	log.WithFields(f).Debugf("saving signature to database...")
-	latestSignature.SignatureEnvelopeID = envelopeResponse.EnvelopeId
+	latestSignature.SignatureEnvelopeID = envelope.EnvelopeID
 
 	log.WithFields(f).Debugf("signature: %+v", latestSignature)
 
@@ -2376,7 +2268,8 @@ func (s *service) RequestIndividualSignatureGerrit(ctx context.Context, input *m
 
 	if latestSignature != nil {
 		log.WithFields(f).Debugf("comparing latest signature document version: %s to latest document version: %s", latestSignature.SignatureDocumentMajorVersion, latestDocument.DocumentMajorVersion)
-		if latestDocument.DocumentMajorVersion == latestSignature.SignatureDocumentMajorVersion {
+		if latestDocument.DocumentMajorVersion == latestSignature.SignatureDocumentMajorVersion &&
+			!signatures.IsSignatureOutdated(latestSignature, signatures.ResignPolicyFor(project, utils.ClaTypeICLA)) {
 
 			log.WithFields(f).Warnf("user: already has a signature with this project: %s", *input.ProjectID)
 
@@ -2557,9 +2450,11 @@ func (s *service) requestCorporateSignature(ctx context.Context, apiURL string,
 
 	log.WithFields(f).Debugf("found %d corporate signatures", len(companySignatures))
 
+	// signatures outdated by the re-sign policy can be signed again on the latest document
+	resignPolicy := signatures.ResignPolicyFor(proj, utils.ClaTypeCCLA)
 	haveSigned := false
 	for _, s := range companySignatures {
-		if s.SignatureSigned {
+		if s.SignatureSigned && !signatures.IsSignatureOutdated(s, resignPolicy) {
 			haveSigned = true
 			break
 		}
@@ -2596,6 +2491,20 @@ func (s *service) requestCorporateSignature(ctx context.Context, apiURL string,
 			}
 		}
 
+		if signatures.IsSignatureOutdated(companySignature, resignPolicy) {
+			log.WithFields(f).Debugf("re-signing outdated signature: %s on document version: %s.%s", companySignature.SignatureID, latestDocument.DocumentMajorVersion, latestDocument.DocumentMinorVersion)
+			majorVersion, majorVersionErr = strconv.Atoi(latestDocument.DocumentMajorVersion)
+			if majorVersionErr != nil {
+				log.WithFields(f).WithError(majorVersionErr).Warnf("unable to convert document major version to int: %s", latestDocument.DocumentMajorVersion)
+				return nil, majorVersionErr
+			}
+			minorVersion, minorVersionErr = strconv.Atoi(latestDocument.DocumentMinorVersion)
+			if minorVersionErr != nil {
+				log.WithFields(f).WithError(minorVersionErr).Warnf("unable to convert document minor version to int: %s", latestDocument.DocumentMinorVersion)
+				return nil, minorVersionErr
+			}
+		}
+
 		itemSignature = &signatures.ItemSignature{
 			SignatureID:                   companySignature.SignatureID,
 			SignatureReferenceType:        companySignature.SignatureReferenceType,
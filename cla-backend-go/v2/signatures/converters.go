@@ -144,6 +144,7 @@ func (s *Service) TransformSignatureToCorporateSignature(signature *models.Signa
 				if len(foundApprovals) > 0 {
 					// ideally this should be one record
 					approvalItem.DateAdded = foundApprovals[0].DateAdded
+					approvalItem.DateExpires = foundApprovals[0].DateExpires
 					log.WithFields(f).Debugf("found approval for %s: %s :%s", key, item, approvalItem.DateAdded)
 				}
 
@@ -0,0 +1,364 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/juju/zip"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// The CSV archive of a CLA Group contains one CSV file per build with the signatures added or modified since the
+// previous build, and a manifest per build listing the signature ID and modification date of each exported
+// signature. A signature appears again in a later CSV file when it was modified, the latest row is the current one.
+//
+// ICLA columns: Signature ID, Name, GitHub Username, GitLab Username, LF_ID, Email, Document Version, Signed Date,
+// Approved, Signed, Date Modified
+//
+// CCLA columns: Signature ID, Company ID, Company Name, Signatory Name, Document Version, Domain Approval List,
+// Email Approval List, GitHub Org Approval List, GitHub Username Approval List, GitLab Org Approval List,
+// GitLab Username Approval List, Signed Date, Approved, Signed, Date Modified
+//
+// ECLA columns: Signature ID, Company ID, Name, GitHub Username, GitLab Username, LF_ID, Email, Signed Date,
+// Approved, Signed, Date Modified
+//
+// Approval lists are comma separated, dates are converted to the RFC3339 format in UTC - the python backend stores
+// dates such as 2022-08-25T16:26:04.000000+0000 - dates in an unknown format are kept as stored. The manifests keep
+// the modification date as stored, it is only compared with the stored value.
+
+// csvZipManifestSuffix is the suffix of the manifest files in the CSV archive
+const csvZipManifestSuffix = "-manifest.csv"
+
+// csvZipTimestampFormat is the format of the build timestamp in the CSV archive file names, sortable and without colons
+const csvZipTimestampFormat = "2006-01-02T15-04-05Z"
+
+var (
+	iclaCSVZipHeader = []string{"Signature ID", "Name", "GitHub Username", "GitLab Username", "LF_ID", "Email",
+		"Document Version", "Signed Date", "Approved", "Signed", "Date Modified"}
+	cclaCSVZipHeader = []string{"Signature ID", "Company ID", "Company Name", "Signatory Name", "Document Version",
+		"Domain Approval List", "Email Approval List", "GitHub Org Approval List", "GitHub Username Approval List",
+		"GitLab Org Approval List", "GitLab Username Approval List", "Signed Date", "Approved", "Signed", "Date Modified"}
+	eclaCSVZipHeader = []string{"Signature ID", "Company ID", "Name", "GitHub Username", "GitLab Username", "LF_ID",
+		"Email", "Signed Date", "Approved", "Signed", "Date Modified"}
+	csvZipManifestHeader = []string{"Signature ID", "Date Modified"}
+)
+
+// s3CSVZipFilepath returns the location of the CSV archive, next to the PDF archive of the CLA Group
+func s3CSVZipFilepath(claType string, claGroupID string) string {
+	return fmt.Sprintf("contract-group/%s/%s-csv.zip", claGroupID, claType)
+}
+
+func (z *Zipper) buildCSVZip(claType string, claGroupID string) error {
+	f := logrus.Fields{
+		"functionName": "v2.signatures.csv_zip_builder.buildCSVZip",
+		"cla_group_id": claGroupID,
+		"cla_type":     claType,
+	}
+
+	header := csvZipHeader(claType)
+	if header == nil {
+		return fmt.Errorf("not supported cla type: %s", claType)
+	}
+
+	remoteZipFileKey := s3CSVZipFilepath(claType, claGroupID)
+	buff, err := z.getFileFromS3(remoteZipFileKey)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to download the csv zip file: %s", remoteZipFileKey)
+		return err
+	}
+
+	exported := map[string]string{}
+	if buff.Len() != 0 {
+		log.WithFields(f).Debug("reading the manifests present in zip")
+		exported, err = readCSVZipManifests(buff)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to read the manifests of the csv zip file: %s", remoteZipFileKey)
+			return err
+		}
+	}
+
+	sigs, err := z.getCLAGroupSignatures(claType, claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the signatures of the cla group")
+		return err
+	}
+
+	var changed []signatures.ItemSignature
+	for _, sig := range sigs {
+		if dateModified, ok := exported[sig.SignatureID]; ok && dateModified == sig.DateModified {
+			continue
+		}
+		changed = append(changed, sig)
+	}
+	log.WithFields(f).Debugf("loaded %d signatures, %d added or modified since the previous build", len(sigs), len(changed))
+	if len(changed) == 0 {
+		return nil
+	}
+
+	csvFile, manifestFile, err := buildCSVZipEntries(claType, changed)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to encode the signatures as csv")
+		return err
+	}
+
+	writer, err := getZipWriter(buff)
+	if err != nil {
+		return err
+	}
+	baseName := fmt.Sprintf("%s-%s", claType, time.Now().UTC().Format(csvZipTimestampFormat))
+	if err = writeZipEntry(writer, baseName+".csv", csvFile); err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to write file: %s.csv in zip", baseName)
+		return err
+	}
+	if err = writeZipEntry(writer, baseName+csvZipManifestSuffix, manifestFile); err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to write file: %s%s in zip", baseName, csvZipManifestSuffix)
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	log.WithFields(f).Debugf("Uploading zip file %s", remoteZipFileKey)
+	err = z.uploadFile(buff, remoteZipFileKey)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("Uploading zip file %s failed", remoteZipFileKey)
+		return err
+	}
+	log.WithFields(f).Debugf("Uploaded zip file %s with %d signatures", remoteZipFileKey, len(changed))
+	return nil
+}
+
+// getCLAGroupSignatures returns the signed signatures of the CLA type for the CLA Group
+func (z *Zipper) getCLAGroupSignatures(claType string, claGroupID string) ([]signatures.ItemSignature, error) {
+	condition := expression.Key("signature_project_id").Equal(expression.Value(claGroupID))
+	filter := expression.Name("signature_signed").Equal(expression.Value(true))
+	switch claType {
+	case utils.ClaTypeICLA:
+		filter = filter.And(expression.Name("signature_type").Equal(expression.Value(utils.SignatureTypeCLA))).
+			And(expression.Name("signature_reference_type").Equal(expression.Value(utils.SignatureReferenceTypeUser))).
+			And(expression.Name("signature_user_ccla_company_id").AttributeNotExists())
+	case utils.ClaTypeECLA:
+		filter = filter.And(expression.Name("signature_type").Equal(expression.Value(utils.SignatureTypeCLA))).
+			And(expression.Name("signature_reference_type").Equal(expression.Value(utils.SignatureReferenceTypeUser))).
+			And(expression.Name("signature_user_ccla_company_id").AttributeExists())
+	case utils.ClaTypeCCLA:
+		filter = filter.And(expression.Name("signature_type").Equal(expression.Value(utils.SignatureTypeCCLA))).
+			And(expression.Name("signature_reference_type").Equal(expression.Value(utils.SignatureReferenceTypeCompany)))
+	default:
+		return nil, fmt.Errorf("not supported cla type: %s", claType)
+	}
+
+	// the raw DocuSign XML is not exported, only load the columns of the archive
+	projection := expression.NamesList(
+		expression.Name("signature_id"), expression.Name("date_created"), expression.Name("date_modified"),
+		expression.Name("signature_approved"), expression.Name("signature_signed"),
+		expression.Name("signature_document_major_version"), expression.Name("signature_document_minor_version"),
+		expression.Name("signature_reference_id"), expression.Name("signature_reference_name"),
+		expression.Name("signature_user_ccla_company_id"), expression.Name("signatory_name"),
+		expression.Name("domain_whitelist"), expression.Name("email_whitelist"), expression.Name("github_org_whitelist"),
+		expression.Name("github_whitelist"), expression.Name("gitlab_org_approval_list"), expression.Name("gitlab_username_approval_list"),
+		expression.Name("user_github_username"), expression.Name("user_gitlab_username"), expression.Name("user_lf_username"),
+		expression.Name("user_name"), expression.Name("user_email"), expression.Name("signed_on"))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(filter).WithProjection(projection).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 aws.String(fmt.Sprintf("cla-%s-signatures", z.stage)),
+		IndexName:                 aws.String(signatures.SignatureProjectIDIndex),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+
+	var sigs []signatures.ItemSignature
+	for {
+		results, queryErr := z.dynamoDB.Query(queryInput)
+		if queryErr != nil {
+			return nil, queryErr
+		}
+
+		var page []signatures.ItemSignature
+		if err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page); err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return sigs, nil
+}
+
+// csvZipHeader returns the CSV header of the CLA type, nil if the CLA type is not supported
+func csvZipHeader(claType string) []string {
+	switch claType {
+	case utils.ClaTypeICLA:
+		return iclaCSVZipHeader
+	case utils.ClaTypeCCLA:
+		return cclaCSVZipHeader
+	case utils.ClaTypeECLA:
+		return eclaCSVZipHeader
+	default:
+		return nil
+	}
+}
+
+// csvZipRecord returns the CSV record of the signature using the column schema of the CLA type
+func csvZipRecord(claType string, sig signatures.ItemSignature) []string {
+	signedOn := sig.SignedOn
+	if signedOn == "" {
+		signedOn = sig.DateCreated
+	}
+	signedOn = csvZipDate(signedOn)
+	dateModified := csvZipDate(sig.DateModified)
+	approved := strconv.FormatBool(sig.SignatureApproved)
+	signed := strconv.FormatBool(sig.SignatureSigned)
+
+	switch claType {
+	case utils.ClaTypeICLA:
+		return []string{sig.SignatureID, sig.UserName, sig.UserGithubUsername, sig.UserGitlabUsername, sig.UserLFUsername,
+			sig.UserEmail, signatureDocumentVersion(sig), signedOn, approved, signed, dateModified}
+	case utils.ClaTypeCCLA:
+		return []string{sig.SignatureID, sig.SignatureReferenceID, sig.SignatureReferenceName, sig.SignatoryName,
+			signatureDocumentVersion(sig), strings.Join(sig.EmailDomainApprovalList, ","), strings.Join(sig.EmailApprovalList, ","),
+			strings.Join(sig.GitHubOrgApprovalList, ","), strings.Join(sig.GitHubUsernameApprovalList, ","),
+			strings.Join(sig.GitlabOrgApprovalList, ","), strings.Join(sig.GitlabUsernameApprovalList, ","),
+			signedOn, approved, signed, dateModified}
+	default:
+		return []string{sig.SignatureID, sig.SignatureUserCompanyID, sig.UserName, sig.UserGithubUsername,
+			sig.UserGitlabUsername, sig.UserLFUsername, sig.UserEmail, signedOn, approved, signed, dateModified}
+	}
+}
+
+// csvZipDate converts the stored date to the RFC3339 format, empty dates stay empty
+func csvZipDate(date string) string {
+	if date == "" {
+		return ""
+	}
+	return utils.FormatTimeString(date)
+}
+
+// signatureDocumentVersion returns the major.minor version of the signed document
+func signatureDocumentVersion(sig signatures.ItemSignature) string {
+	return fmt.Sprintf("%d.%d", sig.SignatureDocumentMajorVersion, sig.SignatureDocumentMinorVersion)
+}
+
+// buildCSVZipEntries encodes the signatures as the CSV file and the manifest file of a build, sorted by signature ID
+func buildCSVZipEntries(claType string, sigs []signatures.ItemSignature) ([]byte, []byte, error) {
+	sort.Slice(sigs, func(i, j int) bool {
+		return sigs[i].SignatureID < sigs[j].SignatureID
+	})
+
+	var csvBuff, manifestBuff bytes.Buffer
+	csvWriter := csv.NewWriter(&csvBuff)
+	manifestWriter := csv.NewWriter(&manifestBuff)
+	if err := csvWriter.Write(csvZipHeader(claType)); err != nil {
+		return nil, nil, err
+	}
+	if err := manifestWriter.Write(csvZipManifestHeader); err != nil {
+		return nil, nil, err
+	}
+	for _, sig := range sigs {
+		if err := csvWriter.Write(csvZipRecord(claType, sig)); err != nil {
+			return nil, nil, err
+		}
+		if err := manifestWriter.Write([]string{sig.SignatureID, sig.DateModified}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return nil, nil, err
+	}
+	manifestWriter.Flush()
+	if err := manifestWriter.Error(); err != nil {
+		return nil, nil, err
+	}
+	return csvBuff.Bytes(), manifestBuff.Bytes(), nil
+}
+
+// readCSVZipManifests returns the modification date of each signature exported in the CSV archive, the manifests are
+// read in build order so the latest modification date is kept
+func readCSVZipManifests(buff *bytes.Buffer) (map[string]string, error) {
+	reader := bytes.NewReader(buff.Bytes())
+	r, err := zip.NewReader(reader, reader.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []*zip.File
+	for _, file := range r.File {
+		if strings.HasSuffix(file.Name, csvZipManifestSuffix) {
+			manifests = append(manifests, file)
+		}
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].Name < manifests[j].Name
+	})
+
+	exported := map[string]string{}
+	for _, file := range manifests {
+		rc, openErr := file.Open()
+		if openErr != nil {
+			return nil, openErr
+		}
+		records, readErr := csv.NewReader(rc).ReadAll()
+		closeErr := rc.Close()
+		if readErr != nil {
+			return nil, fmt.Errorf("unable to read manifest %s: %w", file.Name, readErr)
+		}
+		if closeErr != nil {
+			return nil, closeErr
+		}
+		for i, record := range records {
+			// skip the header and malformed lines
+			if i == 0 || len(record) != len(csvZipManifestHeader) {
+				continue
+			}
+			exported[record[0]] = record[1]
+		}
+	}
+
+	return exported, nil
+}
+
+// writeZipEntry adds a file to the zip
+func writeZipEntry(writer *zip.Writer, filename string, content []byte) error {
+	header := &zip.FileHeader{
+		Name:   filename,
+		Method: zip.Deflate,
+	}
+	header.SetModTime(time.Now())
+	header.SetMode(0644)
+	w, err := writer.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, bytes.NewReader(content))
+	return err
+}
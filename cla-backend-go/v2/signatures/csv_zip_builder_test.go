@@ -0,0 +1,113 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/juju/zip"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVZipRecordMatchesHeader(t *testing.T) {
+	sig := signatures.ItemSignature{
+		SignatureID:                   "sig-1",
+		DateCreated:                   "2021-01-01T00:00:00Z",
+		DateModified:                  "2021-01-02T00:00:00Z",
+		SignatureApproved:             true,
+		SignatureSigned:               true,
+		SignatureDocumentMajorVersion: 2,
+		SignatureDocumentMinorVersion: 1,
+		SignatureReferenceName:        "Acme, Inc.",
+		EmailDomainApprovalList:       []string{"acme.com", "acme.org"},
+	}
+
+	for _, claType := range []string{utils.ClaTypeICLA, utils.ClaTypeCCLA, utils.ClaTypeECLA} {
+		assert.Len(t, csvZipRecord(claType, sig), len(csvZipHeader(claType)), claType)
+	}
+	assert.Nil(t, csvZipHeader("invalid"))
+
+	record := csvZipRecord(utils.ClaTypeCCLA, sig)
+	assert.Equal(t, "Acme, Inc.", record[2])
+	assert.Equal(t, "2.1", record[4])
+	assert.Equal(t, "acme.com,acme.org", record[5])
+	// the creation date is used when the signed date is unknown
+	assert.Equal(t, "2021-01-01T00:00:00Z", record[11])
+}
+
+func TestCSVZipRecordDates(t *testing.T) {
+	sig := signatures.ItemSignature{
+		SignatureID:  "sig-1",
+		SignedOn:     "2022-08-25T16:26:04.000000+0000",
+		DateModified: "2022-08-26T18:26:04.000000+0200",
+	}
+	record := csvZipRecord(utils.ClaTypeICLA, sig)
+	assert.Equal(t, "2022-08-25T16:26:04Z", record[7])
+	assert.Equal(t, "2022-08-26T16:26:04Z", record[10])
+
+	// the manifest keeps the stored modification date, it is compared with the stored value on the next build
+	_, manifestFile, err := buildCSVZipEntries(utils.ClaTypeICLA, []signatures.ItemSignature{sig})
+	assert.NoError(t, err)
+	manifest, err := csv.NewReader(bytes.NewReader(manifestFile)).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sig-1", "2022-08-26T18:26:04.000000+0200"}, manifest[1])
+
+	assert.Equal(t, "", csvZipDate(""))
+	assert.Equal(t, "not a date", csvZipDate("not a date"))
+}
+
+func TestBuildCSVZipEntries(t *testing.T) {
+	sigs := []signatures.ItemSignature{
+		{SignatureID: "b", UserName: "Jane \"JD\" Doe", DateModified: "m2"},
+		{SignatureID: "a", UserName: "John Doe", DateModified: "m1"},
+	}
+
+	csvFile, manifestFile, err := buildCSVZipEntries(utils.ClaTypeICLA, sigs)
+	assert.NoError(t, err)
+
+	records, err := csv.NewReader(bytes.NewReader(csvFile)).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, iclaCSVZipHeader, records[0])
+	assert.Equal(t, "a", records[1][0])
+	assert.Equal(t, "Jane \"JD\" Doe", records[2][1])
+
+	manifest, err := csv.NewReader(bytes.NewReader(manifestFile)).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{csvZipManifestHeader, {"a", "m1"}, {"b", "m2"}}, manifest)
+}
+
+func TestReadCSVZipManifests(t *testing.T) {
+	buff := &bytes.Buffer{}
+
+	// two builds - the second build exports the modified signature b again
+	for _, build := range []struct {
+		name string
+		sigs []signatures.ItemSignature
+	}{
+		{name: "icla-2021-01-01T00-00-00Z", sigs: []signatures.ItemSignature{{SignatureID: "a", DateModified: "m1"}, {SignatureID: "b", DateModified: "m1"}}},
+		{name: "icla-2021-02-01T00-00-00Z", sigs: []signatures.ItemSignature{{SignatureID: "b", DateModified: "m2"}}},
+	} {
+		writer, err := getZipWriter(buff)
+		assert.NoError(t, err)
+		csvFile, manifestFile, err := buildCSVZipEntries(utils.ClaTypeICLA, build.sigs)
+		assert.NoError(t, err)
+		assert.NoError(t, writeZipEntry(writer, build.name+".csv", csvFile))
+		assert.NoError(t, writeZipEntry(writer, build.name+csvZipManifestSuffix, manifestFile))
+		assert.NoError(t, writer.Close())
+	}
+
+	exported, err := readCSVZipManifests(buff)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "m1", "b": "m2"}, exported)
+
+	reader := bytes.NewReader(buff.Bytes())
+	r, err := zip.NewReader(reader, reader.Size())
+	assert.NoError(t, err)
+	assert.Len(t, r.File, 4)
+}
@@ -1062,6 +1062,65 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		return signatures.NewDownloadProjectSignatureICLAsOK().WithXRequestID(reqID).WithPayload(result)
 	})
 
+	// Download the signatures CSV archive
+	api.SignaturesDownloadProjectSignatureCSVArchiveHandler = signatures.DownloadProjectSignatureCSVArchiveHandlerFunc(func(params signatures.DownloadProjectSignatureCSVArchiveParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.signatures.handlers.SignaturesDownloadProjectSignatureCSVArchiveHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"claType":        params.ClaType,
+		}
+
+		log.WithFields(f).Debug("loading cla group by id...")
+		claGroupModel, err := claGroupService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn(problemLoadingCLAGroupByID)
+			if err == repository.ErrProjectDoesNotExist {
+				return signatures.NewDownloadProjectSignatureCSVArchiveNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, problemLoadingCLAGroupByID, err))
+			}
+			return signatures.NewDownloadProjectSignatureCSVArchiveBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, problemLoadingCLAGroupByID, err))
+		}
+
+		if params.ClaType == utils.ClaTypeICLA && !claGroupModel.ProjectICLAEnabled {
+			log.WithFields(f).Warn(iclaNotSupportedForCLAGroup)
+			return signatures.NewDownloadProjectSignatureCSVArchiveBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequest(reqID, "icla is not enabled for this cla group"))
+		}
+		if params.ClaType != utils.ClaTypeICLA && !claGroupModel.ProjectCCLAEnabled {
+			msg := fmt.Sprintf("%s is not enabled for this cla group", params.ClaType)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewDownloadProjectSignatureCSVArchiveBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequest(reqID, msg))
+		}
+
+		log.WithFields(f).Debug("checking access control permissions for user...")
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) {
+			msg := fmt.Sprintf("user %s is not authorized to view project %s signatures any scope of project", authUser.UserName, params.ClaType)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewDownloadProjectSignatureCSVArchiveForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := v2SignatureService.GetSignedZipCsv(params.ClaGroupID, params.ClaType)
+		if err != nil {
+			if err == ErrZipNotPresent {
+				msg := fmt.Sprintf("no %s signatures csv archive found for this cla group", params.ClaType)
+				log.WithFields(f).Warn(msg)
+				return signatures.NewDownloadProjectSignatureCSVArchiveNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return signatures.NewDownloadProjectSignatureCSVArchiveBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, "unexpected response from query", err))
+		}
+
+		return signatures.NewDownloadProjectSignatureCSVArchiveOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
 	// Download ICLAs as a CSV document
 	api.SignaturesDownloadProjectSignatureICLAAsCSVHandler = signatures.DownloadProjectSignatureICLAAsCSVHandlerFunc(func(params signatures.DownloadProjectSignatureICLAAsCSVParams, authUser *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
@@ -1416,6 +1475,47 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		log.WithFields(f).Debug("returning authorization result to caller...")
 		return signatures.NewIsAuthorizedOK().WithXRequestID(reqID).WithPayload(result)
 	})
+
+	api.SignaturesGetGitHubPullRequestCLAReportHandler = signatures.GetGitHubPullRequestCLAReportHandlerFunc(func(params signatures.GetGitHubPullRequestCLAReportParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.signatures.handlers.SignaturesGetGitHubPullRequestCLAReportHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"projectSFID":    params.ProjectSFID,
+			"repositoryID":   params.RepositoryID,
+			"pullRequestID":  params.PullRequestID,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to get the CLA report of pull request: %d for project: %s",
+				authUser.UserName, params.PullRequestID, params.ProjectSFID)
+			log.WithFields(f).Debug(msg)
+			return signatures.NewGetGitHubPullRequestCLAReportForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		log.WithFields(f).Debug("generating pull request CLA report...")
+		report, err := v1SignatureService.GetGitHubPullRequestCLAReport(ctx, params.ProjectSFID, params.RepositoryID, params.PullRequestID)
+		if err != nil {
+			if _, ok := err.(*utils.GitHubRepositoryNotFound); ok {
+				msg := fmt.Sprintf("repository: %s not found for projectSFID: %s", params.RepositoryID, params.ProjectSFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return signatures.NewGetGitHubPullRequestCLAReportNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFound(reqID, msg))
+			}
+
+			msg := fmt.Sprintf("problem generating the CLA report for pull request: %d", params.PullRequestID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return signatures.NewGetGitHubPullRequestCLAReportBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		return signatures.NewGetGitHubPullRequestCLAReportOK().WithXRequestID(reqID).WithPayload(report)
+	})
 }
 
 // getProjectIDsFromModels is a helper function to extract the project SFIDs from the project CLA Group models
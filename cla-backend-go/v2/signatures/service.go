@@ -56,6 +56,7 @@ type ServiceInterface interface {
 	GetClaGroupCorporateContributors(ctx context.Context, params v2Sigs.ListClaGroupCorporateContributorsParams) (*models.CorporateContributorList, error)
 	GetSignedDocument(ctx context.Context, signatureID string) (*models.SignedDocument, error)
 	GetSignedIclaZipPdf(claGroupID string) (*models.URLObject, error)
+	GetSignedZipCsv(claGroupID, claType string) (*models.URLObject, error)
 	GetSignedCclaZipPdf(claGroupID string) (*models.URLObject, error)
 	InvalidateICLA(ctx context.Context, claGroupID string, userID string, authUser *auth.User, eventsService events.Service, eventArgs *events.LogEventArgs) error
 	EclaAutoCreate(ctx context.Context, signatureID string, autoCreateECLA bool) error
@@ -307,6 +308,25 @@ func (s *Service) GetSignedIclaZipPdf(claGroupID string) (*models.URLObject, err
 	}, nil
 }
 
+// GetSignedZipCsv returns the signatures CSV Zip reference of the CLA type
+func (s *Service) GetSignedZipCsv(claGroupID, claType string) (*models.URLObject, error) {
+	url := s3CSVZipFilepath(claType, claGroupID)
+	ok, err := s.IsZipPresentOnS3(url)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrZipNotPresent
+	}
+	signedURL, err := utils.GetDownloadLink(url)
+	if err != nil {
+		return nil, err
+	}
+	return &models.URLObject{
+		URL: signedURL,
+	}, nil
+}
+
 // IsZipPresentOnS3 returns true if the specified file is present in S3
 func (s *Service) IsZipPresentOnS3(zipFilePath string) (bool, error) {
 	_, err := s.s3.GetObject(&s3.GetObjectInput{
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/linuxfoundation/easycla/cla-backend-go/approval_rules"
+	"github.com/linuxfoundation/easycla/cla-backend-go/domain_matcher"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/signatures"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 )
@@ -33,7 +35,9 @@ func hasApprovalListUpdates(params signatures.UpdateApprovalListParams) bool {
 		len(params.Body.AddGithubUsernameApprovalList) > 0 || len(params.Body.RemoveGithubUsernameApprovalList) > 0 ||
 		len(params.Body.AddGithubOrgApprovalList) > 0 || len(params.Body.RemoveGithubOrgApprovalList) > 0 ||
 		len(params.Body.AddGitlabUsernameApprovalList) > 0 || len(params.Body.RemoveGitlabUsernameApprovalList) > 0 ||
-		len(params.Body.AddGitlabOrgApprovalList) > 0 || len(params.Body.RemoveGitlabOrgApprovalList) > 0 {
+		len(params.Body.AddGitlabOrgApprovalList) > 0 || len(params.Body.RemoveGitlabOrgApprovalList) > 0 ||
+		len(params.Body.AddEmailRegexApprovalList) > 0 || len(params.Body.RemoveEmailRegexApprovalList) > 0 ||
+		len(params.Body.AddGerritGroupApprovalList) > 0 || len(params.Body.RemoveGerritGroupApprovalList) > 0 {
 		return true
 	}
 
@@ -60,17 +64,16 @@ func entriesAreValid(params signatures.UpdateApprovalListParams) (string, bool)
 
 	// Ensure the domains are valid
 	for _, domain := range params.Body.AddDomainApprovalList {
-		msg, valid := utils.ValidDomain(domain, true)
-		if !valid {
+		if _, err := domain_matcher.ParsePattern(domain); err != nil {
 			isValid = false
-			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid add approval list domain %s - %s", domain, msg))
+			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid add approval list domain %s - %s", domain, err))
 		}
 	}
 	for _, domain := range params.Body.RemoveDomainApprovalList {
-		msg, valid := utils.ValidDomain(domain, true)
-		if !valid {
+		// existing public suffix entries can still be removed
+		if _, err := domain_matcher.ParsePattern(domain); err != nil && !errors.Is(err, domain_matcher.ErrPublicSuffix) {
 			isValid = false
-			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid remove approval list domain %s - %s", domain, msg))
+			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid remove approval list domain %s - %s", domain, err))
 		}
 	}
 
@@ -138,5 +141,13 @@ func entriesAreValid(params signatures.UpdateApprovalListParams) (string, bool)
 		}
 	}
 
+	// Ensure the email regular expressions compile
+	for _, expression := range params.Body.AddEmailRegexApprovalList {
+		if _, err := approval_rules.CompileEmailRegex(expression); err != nil {
+			isValid = false
+			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid add approval list email regex %s - %s", expression, err.Error()))
+		}
+	}
+
 	return strings.Join(listOfErrors, ", "), isValid
 }
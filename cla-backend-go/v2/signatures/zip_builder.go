@@ -24,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/s3"
 )
 
@@ -35,7 +36,9 @@ const (
 // Zipper implements ZipBuilder interface
 type Zipper struct {
 	s3         *s3.S3
+	dynamoDB   *dynamodb.DynamoDB
 	bucketName string
+	stage      string
 }
 
 // ZipBuilder provides method to build ICLA/CCLA zip
@@ -48,10 +51,12 @@ type ZipBuilder interface {
 }
 
 // NewZipBuilder returns the ZipBuilder
-func NewZipBuilder(awsSession *session.Session, bucketName string) ZipBuilder {
+func NewZipBuilder(awsSession *session.Session, bucketName string, stage string) ZipBuilder {
 	return &Zipper{
 		s3:         s3.New(awsSession),
+		dynamoDB:   dynamodb.New(awsSession),
 		bucketName: bucketName,
+		stage:      stage,
 	}
 }
 
@@ -91,7 +96,7 @@ func (z *Zipper) BuildECLACSVZip(claGroupID string) error {
 func (z *Zipper) buildPDFZip(claType string, claGroupID string) error {
 	f := logrus.Fields{"cla_group_id": claGroupID, "cla_type": claType}
 	// get zip file from s3
-	buff, err := z.getZipFileFromS3(claType, claGroupID)
+	buff, err := z.getFileFromS3(s3ZipFilepath(claType, claGroupID))
 	if err != nil {
 		return err
 	}
@@ -165,12 +170,6 @@ func (z *Zipper) buildPDFZip(claType string, claGroupID string) error {
 	}
 	return nil
 }
-func (z *Zipper) buildCSVZip(claType string, claGroupID string) error {
-	f := logrus.Fields{"cla_group_id": claGroupID, "cla_type": claType}
-	// TODO: DAD - requires query to the signatures table to get the list of signatures, then encode as CSV, then build a zip file, and upload to S3
-	log.WithFields(f).Infof("building %s csv zip for cla-group: %s is currently not supported", claType, claGroupID)
-	return nil
-}
 
 // FileContent contains file content of s3 file
 type FileContent struct {
@@ -261,9 +260,9 @@ func getZipWriter(buff *bytes.Buffer) (*zip.Writer, error) {
 	return writer, nil
 }
 
-func (z *Zipper) getZipFileFromS3(claType string, claGroupID string) (*bytes.Buffer, error) {
+// getFileFromS3 returns the content of the zip file, empty if the file does not exist yet
+func (z *Zipper) getFileFromS3(remoteFileKey string) (*bytes.Buffer, error) {
 	var buff aws.WriteAtBuffer
-	remoteFileKey := s3ZipFilepath(claType, claGroupID)
 	_, err := z.s3.GetObject(&s3.GetObjectInput{
 		Bucket: aws.String(z.bucketName),
 		Key:    aws.String(remoteFileKey),
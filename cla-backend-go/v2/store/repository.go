@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -31,6 +32,9 @@ type Repository interface {
 	SetActiveSignatureMetaData(ctx context.Context, key string, expire int64, value string) error
 	GetActiveSignatureMetaData(ctx context.Context, UserId string) (map[string]interface{}, error)
 	DeleteActiveSignatureMetaData(ctx context.Context, key string) error
+	SetValue(ctx context.Context, key string, expire int64, value string) error
+	GetValue(ctx context.Context, key string) (string, error)
+	DeleteValue(ctx context.Context, key string) error
 }
 
 type repo struct {
@@ -204,3 +208,79 @@ func (r repo) DeleteActiveSignatureMetaData(ctx context.Context, key string) err
 
 	return nil
 }
+
+// SetValue stores the raw string value under the key until the expire epoch time
+func (r repo) SetValue(ctx context.Context, key string, expire int64, value string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.store.repository.SetValue",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"key":            key,
+		"expire":         expire,
+	}
+
+	v, err := dynamodbattribute.MarshalMap(DBStore{
+		Key:    key,
+		Value:  value,
+		Expire: float64(expire),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem marshalling store record")
+		return err
+	}
+
+	_, err = r.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      v,
+		TableName: &r.storeTableName,
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save store record")
+		return err
+	}
+
+	return nil
+}
+
+// GetValue returns the raw string value stored under the key, or an empty string if the key does not exist or has expired
+func (r repo) GetValue(ctx context.Context, key string) (string, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.store.repository.GetValue",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"key":            key,
+	}
+
+	result, err := r.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: &r.storeTableName,
+		Key: map[string]*dynamodb.AttributeValue{
+			"key": {
+				S: &key,
+			},
+		},
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem querying store table")
+		return "", err
+	}
+
+	if result.Item == nil {
+		return "", nil
+	}
+
+	var record DBStore
+	err = dynamodbattribute.UnmarshalMap(result.Item, &record)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem unmarshalling store record")
+		return "", err
+	}
+
+	// DynamoDB TTL deletion is lazy - ignore records which have already expired
+	if record.Expire > 0 && int64(record.Expire) < time.Now().Unix() {
+		return "", nil
+	}
+
+	return record.Value, nil
+}
+
+// DeleteValue removes the value stored under the key
+func (r repo) DeleteValue(ctx context.Context, key string) error {
+	return r.DeleteActiveSignatureMetaData(ctx, key)
+}
@@ -5,6 +5,7 @@ package template
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -29,7 +30,7 @@ import (
 )
 
 // Configure API call
-func Configure(api *operations.EasyclaAPI, service v1Template.ServiceInterface, v1ProjectClaGroupService v1ProjectsCLAGroups.Service, eventsService v1Events.Service) {
+func Configure(api *operations.EasyclaAPI, service v1Template.ServiceInterface, v2Service Service, v1ProjectClaGroupService v1ProjectsCLAGroups.Service, eventsService v1Events.Service) {
 	// Retrieve a list of available templates
 	api.TemplateGetTemplatesHandler = template.GetTemplatesHandlerFunc(func(params template.GetTemplatesParams, user *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
@@ -130,6 +131,82 @@ func Configure(api *operations.EasyclaAPI, service v1Template.ServiceInterface,
 		return template.NewCreateCLAGroupTemplateOK().WithPayload(response)
 	})
 
+	api.TemplateUploadCLAGroupTemplateHandler = template.UploadCLAGroupTemplateHandlerFunc(func(params template.UploadCLAGroupTemplateParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.handlers.TemplateUploadCLAGroupTemplateHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+		}
+
+		projectCLAGroups, msg, ok := isUserAuthorizedForCLAGroup(ctx, authUser, v1ProjectClaGroupService, params.ClaGroupID)
+		if !ok {
+			log.WithFields(f).Debug(msg)
+			return template.NewUploadCLAGroupTemplateForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		input := &v1Template.CustomTemplateInput{
+			Name:                 utils.StringValue(params.Body.Name),
+			Description:          params.Body.Description,
+			TemplateMajorVersion: params.Body.TemplateMajorVersion,
+			IclaHTMLBody:         params.Body.IclaHTMLBody,
+			CclaHTMLBody:         params.Body.CclaHTMLBody,
+			CreatedBy:            authUser.UserName,
+		}
+		err := copier.Copy(&input.MetaFields, &params.Body.MetaFields)
+		if err == nil {
+			err = copier.Copy(&input.IclaFields, &params.Body.IclaFields)
+		}
+		if err == nil {
+			err = copier.Copy(&input.CclaFields, &params.Body.CclaFields)
+		}
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem converting the template input")
+			return template.NewUploadCLAGroupTemplateInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		upload, err := service.UploadCLAGroupTemplate(ctx, params.ClaGroupID, input)
+		if err != nil {
+			var validationErr *v1Template.TemplateValidationError
+			if errors.As(err, &validationErr) {
+				log.WithFields(f).WithError(err).Debug("the uploaded CLA group template is not valid")
+				return template.NewUploadCLAGroupTemplateBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+			log.WithFields(f).WithError(err).Warn("problem uploading the CLA group template")
+			return template.NewUploadCLAGroupTemplateInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		// Keep track of who changed the legal text of the CLA Group - the upload saves the documents as its last step,
+		// so the documents changed exactly when it succeeds
+		eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:         events.CLATemplateUploaded,
+			CLAGroupID:        params.ClaGroupID,
+			ProjectID:         params.ClaGroupID,
+			ProjectSFID:       projectCLAGroups[0].ProjectSFID,
+			ParentProjectSFID: projectCLAGroups[0].FoundationSFID,
+			LfUsername:        authUser.UserName,
+			EventData: &events.CLATemplateUploadedEventData{
+				TemplateID:   upload.TemplateID,
+				TemplateName: upload.TemplateName,
+				Version:      upload.Version,
+				ICLADigest:   upload.IclaDigest,
+				CCLADigest:   upload.CclaDigest,
+			},
+		})
+
+		return template.NewUploadCLAGroupTemplateOK().WithXRequestID(reqID).WithPayload(&models.ClaGroupCustomTemplate{
+			TemplateID:           upload.TemplateID,
+			Name:                 upload.TemplateName,
+			Version:              upload.Version,
+			IndividualPDFURL:     upload.TemplatePdfs.IndividualPDFURL,
+			CorporatePDFURL:      upload.TemplatePdfs.CorporatePDFURL,
+			IndividualPreviewURL: upload.IclaPreviewURL,
+			CorporatePreviewURL:  upload.CclaPreviewURL,
+		})
+	})
+
 	api.TemplateTemplatePreviewHandler = template.TemplatePreviewHandlerFunc(func(params template.TemplatePreviewParams, user *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
 		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
@@ -181,6 +258,224 @@ func Configure(api *operations.EasyclaAPI, service v1Template.ServiceInterface,
 			}
 		})
 	})
+
+	api.TemplateListCLAGroupTemplateVersionsHandler = template.ListCLAGroupTemplateVersionsHandlerFunc(func(params template.ListCLAGroupTemplateVersionsParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.handlers.TemplateListCLAGroupTemplateVersionsHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"claType":        params.ClaType,
+		}
+
+		if _, msg, ok := isUserAuthorizedForCLAGroup(ctx, authUser, v1ProjectClaGroupService, params.ClaGroupID); !ok {
+			log.WithFields(f).Debug(msg)
+			return template.NewListCLAGroupTemplateVersionsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		versions, policy, err := service.GetCLAGroupTemplateVersions(ctx, params.ClaGroupID, params.ClaType)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem loading the CLA group template versions")
+			return template.NewListCLAGroupTemplateVersionsBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		response := &models.ClaGroupTemplateVersions{
+			ClaGroupID: params.ClaGroupID,
+			ClaType:    params.ClaType,
+		}
+		err = copier.Copy(&response.Versions, buildTemplateVersions(versions))
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem converting the template versions")
+			return template.NewListCLAGroupTemplateVersionsInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+		if policy != nil {
+			response.ResignPolicy = &models.ClaGroupResignPolicy{}
+			err = copier.Copy(response.ResignPolicy, policy)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("problem converting the re-sign policy")
+				return template.NewListCLAGroupTemplateVersionsInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+		}
+
+		return template.NewListCLAGroupTemplateVersionsOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	api.TemplateGetCLAGroupTemplateDiffHandler = template.GetCLAGroupTemplateDiffHandlerFunc(func(params template.GetCLAGroupTemplateDiffParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.handlers.TemplateGetCLAGroupTemplateDiffHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"claType":        params.ClaType,
+			"fromVersion":    params.FromVersion,
+			"toVersion":      params.ToVersion,
+		}
+
+		if _, msg, ok := isUserAuthorizedForCLAGroup(ctx, authUser, v1ProjectClaGroupService, params.ClaGroupID); !ok {
+			log.WithFields(f).Debug(msg)
+			return template.NewGetCLAGroupTemplateDiffForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		diff, err := service.GetCLAGroupTemplateDiff(ctx, params.ClaGroupID, params.ClaType, params.FromVersion, params.ToVersion)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem comparing the CLA group template versions")
+			if errors.Is(err, v1Template.ErrTemplateVersionNotFound) {
+				return template.NewGetCLAGroupTemplateDiffNotFound().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+			return template.NewGetCLAGroupTemplateDiffBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		response := &models.ClaGroupTemplateDiff{
+			ClaGroupID:  params.ClaGroupID,
+			ClaType:     params.ClaType,
+			FromVersion: diff.FromVersion,
+			ToVersion:   diff.ToVersion,
+			Added:       int64(diff.Added),
+			Removed:     int64(diff.Removed),
+		}
+		err = copier.Copy(&response.Lines, diff.Lines)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem converting the template diff")
+			return template.NewGetCLAGroupTemplateDiffInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		return template.NewGetCLAGroupTemplateDiffOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	api.TemplateRequireCLAGroupTemplateResignHandler = template.RequireCLAGroupTemplateResignHandlerFunc(func(params template.RequireCLAGroupTemplateResignParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.handlers.TemplateRequireCLAGroupTemplateResignHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"claType":        params.ClaType,
+		}
+
+		projectCLAGroups, msg, ok := isUserAuthorizedForCLAGroup(ctx, authUser, v1ProjectClaGroupService, params.ClaGroupID)
+		if !ok {
+			log.WithFields(f).Debug(msg)
+			return template.NewRequireCLAGroupTemplateResignForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		input := &v1Template.ResignInput{
+			Version:         utils.StringValue(params.Body.Version),
+			Enforcement:     utils.StringValue(params.Body.Enforcement),
+			GracePeriodDays: params.Body.GracePeriodDays,
+			CreatedBy:       authUser.UserName,
+		}
+		policy, err := service.RequireCLAGroupTemplateResign(ctx, params.ClaGroupID, params.ClaType, input)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem requiring re-signature of the CLA group template version")
+			if errors.Is(err, v1Template.ErrTemplateVersionNotFound) {
+				return template.NewRequireCLAGroupTemplateResignNotFound().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+			return template.NewRequireCLAGroupTemplateResignBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:         events.CLATemplateResignRequired,
+			CLAGroupID:        params.ClaGroupID,
+			ProjectID:         params.ClaGroupID,
+			ProjectSFID:       projectCLAGroups[0].ProjectSFID,
+			ParentProjectSFID: projectCLAGroups[0].FoundationSFID,
+			LfUsername:        authUser.UserName,
+			EventData: &events.CLATemplateResignRequiredEventData{
+				ClaType:         params.ClaType,
+				Version:         fmt.Sprintf("%s.%s", policy.DocumentMajorVersion, policy.DocumentMinorVersion),
+				Enforcement:     policy.Enforcement,
+				GracePeriodEnds: policy.GracePeriodEnds,
+			},
+		})
+
+		response := &models.ClaGroupResignPolicy{}
+		err = copier.Copy(response, policy)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem converting the re-sign policy")
+			return template.NewRequireCLAGroupTemplateResignInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		return template.NewRequireCLAGroupTemplateResignOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	api.TemplateStartCLAGroupResignCampaignHandler = template.StartCLAGroupResignCampaignHandlerFunc(func(params template.StartCLAGroupResignCampaignParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.handlers.TemplateStartCLAGroupResignCampaignHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"claType":        params.ClaType,
+		}
+
+		if _, msg, ok := isUserAuthorizedForCLAGroup(ctx, authUser, v1ProjectClaGroupService, params.ClaGroupID); !ok {
+			log.WithFields(f).Debug(msg)
+			return template.NewStartCLAGroupResignCampaignForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		campaign, err := v2Service.StartResignCampaign(ctx, params.ClaGroupID, params.ClaType, authUser.UserName)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem starting the re-sign campaign")
+			return template.NewStartCLAGroupResignCampaignBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		return template.NewStartCLAGroupResignCampaignOK().WithXRequestID(reqID).WithPayload(campaign)
+	})
+}
+
+// isUserAuthorizedForCLAGroup checks the user has access to any of the projects of the CLA Group, returns the
+// project mappings, or a message explaining why access was denied
+func isUserAuthorizedForCLAGroup(ctx context.Context, authUser *auth.User, v1ProjectClaGroupService v1ProjectsCLAGroups.Service, claGroupID string) ([]*v1ProjectsCLAGroups.ProjectClaGroup, string, bool) {
+	projectCLAGroups, lookupErr := v1ProjectClaGroupService.GetProjectsIdsForClaGroup(ctx, claGroupID)
+	if lookupErr != nil || len(projectCLAGroups) == 0 {
+		return nil, fmt.Sprintf("unable to lookup CLA Group mapping using CLA Group ID: %s", claGroupID), false
+	}
+
+	projectSFIDs := getProjectSFIDList(projectCLAGroups)
+	if !utils.IsUserAuthorizedForAnyProjects(ctx, authUser, projectSFIDs, utils.ALLOW_ADMIN_SCOPE) {
+		return nil, fmt.Sprintf("authUser '%s' does not have access to the CLA Group templates with Project scope of any %s",
+			authUser.UserName, strings.Join(projectSFIDs, ",")), false
+	}
+
+	return projectCLAGroups, "", true
+}
+
+// templateVersion is the flattened template version converted to the response model
+type templateVersion struct {
+	Version              string
+	DocumentMajorVersion string
+	DocumentMinorVersion string
+	DocumentName         string
+	DocumentFileID       string
+	DocumentS3URL        string
+	DocumentCreationDate string
+	Latest               bool
+	RequiresResign       bool
+}
+
+// buildTemplateVersions flattens the template versions and their documents
+func buildTemplateVersions(versions []*v1Template.TemplateVersion) []templateVersion {
+	response := make([]templateVersion, 0, len(versions))
+	for _, version := range versions {
+		response = append(response, templateVersion{
+			Version:              version.Version,
+			DocumentMajorVersion: version.Document.DocumentMajorVersion,
+			DocumentMinorVersion: version.Document.DocumentMinorVersion,
+			DocumentName:         version.Document.DocumentName,
+			DocumentFileID:       version.Document.DocumentFileID,
+			DocumentS3URL:        version.Document.DocumentS3URL,
+			DocumentCreationDate: version.Document.DocumentCreationDate,
+			Latest:               version.Latest,
+			RequiresResign:       version.RequiresResign,
+		})
+	}
+	return response
 }
 
 // getProjectSFIDList is a helper function to extract the project SFID values from the list of project to CLA group mapping records
@@ -0,0 +1,233 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	service2 "github.com/linuxfoundation/easycla/cla-backend-go/project/service"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrNoResignPolicy is returned when a re-sign campaign is started before a document version requires re-signature
+var ErrNoResignPolicy = errors.New("no document version requires re-signature")
+
+// Service interface
+type Service interface {
+	StartResignCampaign(ctx context.Context, claGroupID, claType, lfUsername string) (*models.ClaGroupResignCampaign, error)
+}
+
+type service struct {
+	claGroupService      service2.Service
+	signatureService     signatures.SignatureService
+	usersService         users.Service
+	emailTemplateService emails.EmailTemplateService
+	eventsService        events.Service
+	emailOutbox          utils.EmailOutboxStore
+}
+
+// NewService returns an instance of the v2 template service
+func NewService(claGroupService service2.Service, signatureService signatures.SignatureService, usersService users.Service, emailTemplateService emails.EmailTemplateService, eventsService events.Service, emailOutbox utils.EmailOutboxStore) Service {
+	return &service{
+		claGroupService:      claGroupService,
+		signatureService:     signatureService,
+		usersService:         usersService,
+		emailTemplateService: emailTemplateService,
+		eventsService:        eventsService,
+		emailOutbox:          emailOutbox,
+	}
+}
+
+// resignNotification is the outcome of notifying the signatories of one outdated signature
+type resignNotification int
+
+const (
+	resignNotificationQueued resignNotification = iota
+	resignNotificationAlreadyQueued
+)
+
+// resignIdempotencyKey returns the outbox idempotency key of the re-sign emails of the signature for the version, the
+// signatories are emailed once per required version however many times the campaign is started
+func resignIdempotencyKey(claGroupID, claType, version, signatureID string) string {
+	return fmt.Sprintf("resign-campaign:%s:%s:%s:%s", claGroupID, claType, version, signatureID)
+}
+
+// StartResignCampaign queues the notifications to the signatories of every ICLA or CCLA signed on a document version
+// older than the re-sign policy version - ICLA contributors and the CLA managers of CCLA companies are emailed by the
+// email outbox worker. The signatories already notified for the version are skipped.
+func (s *service) StartResignCampaign(ctx context.Context, claGroupID, claType, lfUsername string) (*models.ClaGroupResignCampaign, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.template.service.StartResignCampaign",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"claType":        claType,
+	}
+
+	if claType != utils.ClaTypeICLA && claType != utils.ClaTypeCCLA {
+		return nil, fmt.Errorf("not supported cla type provided : %s", claType)
+	}
+
+	claGroupModel, err := s.claGroupService.GetCLAGroupByID(ctx, claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA group")
+		return nil, err
+	}
+
+	policy := signatures.ResignPolicyFor(claGroupModel, claType)
+	if policy == nil {
+		return nil, ErrNoResignPolicy
+	}
+	version := fmt.Sprintf("%s.%s", policy.DocumentMajorVersion, policy.DocumentMinorVersion)
+
+	outdated, err := s.signatureService.GetOutdatedSignatures(ctx, claGroupID, claType, policy)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the outdated signatures")
+		return nil, err
+	}
+	log.WithFields(f).Debugf("found %d signatures outdated by version: %s", len(outdated), version)
+
+	campaign := &models.ClaGroupResignCampaign{
+		ClaGroupID:         claGroupID,
+		ClaType:            claType,
+		Version:            version,
+		OutdatedSignatures: int64(len(outdated)),
+	}
+
+	for _, signature := range outdated {
+		notification, notifyErr := s.notifySignatories(ctx, claGroupModel, signature, claType, policy)
+		if notifyErr != nil {
+			log.WithFields(f).WithError(notifyErr).Warnf("unable to notify the signatories of signature: %s", signature.SignatureID)
+			campaign.Failed++
+			continue
+		}
+		if notification == resignNotificationAlreadyQueued {
+			campaign.Skipped++
+			continue
+		}
+		campaign.Notified++
+	}
+
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:    events.CLATemplateResignCampaign,
+		CLAGroupID:   claGroupID,
+		ProjectID:    claGroupID,
+		CLAGroupName: claGroupModel.ProjectName,
+		LfUsername:   lfUsername,
+		EventData: &events.CLATemplateResignCampaignEventData{
+			ClaType:            claType,
+			Version:            version,
+			OutdatedSignatures: len(outdated),
+			Notified:           int(campaign.Notified),
+			Skipped:            int(campaign.Skipped),
+			Failed:             int(campaign.Failed),
+		},
+	})
+
+	return campaign, nil
+}
+
+// notifySignatories queues the emails to the ICLA contributor or the CLA managers of the CCLA company about the
+// outdated signature
+func (s *service) notifySignatories(ctx context.Context, claGroupModel *v1Models.ClaGroup, signature *v1Models.Signature, claType string, policy *v1Models.ClaGroupResignPolicy) (resignNotification, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.template.service.notifySignatories",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signature.SignatureID,
+	}
+
+	params := emails.ResignRequiredTemplateParams{
+		SignedVersion:   fmt.Sprintf("%s.%s", signature.SignatureDocumentMajorVersion, signature.SignatureDocumentMinorVersion),
+		RequiredVersion: fmt.Sprintf("%s.%s", policy.DocumentMajorVersion, policy.DocumentMinorVersion),
+	}
+	if policy.Enforcement == utils.ResignEnforcementGracePeriod {
+		params.GracePeriodEnds = policy.GracePeriodEnds
+	}
+
+	var recipients []*v1Models.User
+	if signature.SignatureReferenceType == utils.SignatureReferenceTypeCompany {
+		params.CompanyName = signature.SignatureReferenceName
+		for _, manager := range signature.SignatureACL {
+			user, err := s.usersService.GetUserByLFUserName(manager.LfUsername)
+			if err != nil || user == nil {
+				log.WithFields(f).WithError(err).Warnf("unable to lookup CLA manager: %s", manager.LfUsername)
+				continue
+			}
+			recipients = append(recipients, user)
+		}
+	} else {
+		params.ICLA = true
+		user, err := s.usersService.GetUser(signature.SignatureReferenceID)
+		if err != nil || user == nil {
+			log.WithFields(f).WithError(err).Warnf("unable to lookup contributor: %s", signature.SignatureReferenceID)
+		} else {
+			recipients = append(recipients, user)
+		}
+	}
+
+	subject := fmt.Sprintf("EasyCLA: Please sign the updated CLA for %s", claGroupModel.ProjectName)
+	idempotencyKey := resignIdempotencyKey(claGroupModel.ProjectID, claType, params.RequiredVersion, signature.SignatureID)
+	queued, alreadyQueued := 0, 0
+	for _, recipient := range recipients {
+		email := getUserEmail(recipient)
+		if email == "" {
+			log.WithFields(f).Warnf("no email address for user: %s", recipient.UserID)
+			continue
+		}
+
+		params.RecipientName = getUserName(recipient)
+		params.RecipientAddress = email
+		emailSubject, body, err := emails.RenderResignRequiredTemplate(s.emailTemplateService, subject, claGroupModel.Version, claGroupModel.ProjectID, params)
+		if err != nil {
+			return resignNotificationQueued, err
+		}
+		ok, err := s.emailOutbox.QueueEmail(utils.NewQueuedEmail(idempotencyKey, email, emailSubject, body))
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem queueing the re-sign email to: %s", email)
+			continue
+		}
+		if !ok {
+			log.WithFields(f).Debugf("re-sign email to: %s already queued for version: %s", email, params.RequiredVersion)
+			alreadyQueued++
+			continue
+		}
+		queued++
+	}
+
+	if queued > 0 {
+		return resignNotificationQueued, nil
+	}
+	if alreadyQueued > 0 {
+		return resignNotificationAlreadyQueued, nil
+	}
+	return resignNotificationQueued, fmt.Errorf("no signatory of signature: %s could be notified", signature.SignatureID)
+}
+
+// getUserEmail returns the LF email of the user, or the first of the user emails
+func getUserEmail(user *v1Models.User) string {
+	if user.LfEmail != "" {
+		return user.LfEmail.String()
+	}
+	if len(user.Emails) > 0 {
+		return user.Emails[0]
+	}
+	return ""
+}
+
+// getUserName returns the best available name of the user
+func getUserName(user *v1Models.User) string {
+	if user.Username != "" {
+		return user.Username
+	}
+	return user.LfUsername
+}
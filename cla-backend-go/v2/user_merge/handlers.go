@@ -0,0 +1,133 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package user_merge
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/user_merge"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service) {
+	api.UserMergeGetUserDuplicatesHandler = user_merge.GetUserDuplicatesHandlerFunc(func(params user_merge.GetUserDuplicatesParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.user_merge.handlers.UserMergeGetUserDuplicatesHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+		}
+
+		if !utils.IsUserAdmin(authUser) {
+			msg := fmt.Sprintf("user %s does not have access to the user duplicate report", authUser.UserName)
+			log.WithFields(f).Debug(msg)
+			return user_merge.NewGetUserDuplicatesForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.GetDuplicates(ctx)
+		if err != nil {
+			msg := "unable to load the user duplicate report"
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrReportNotFound) {
+				return user_merge.NewGetUserDuplicatesNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return user_merge.NewGetUserDuplicatesInternalServerError().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return user_merge.NewGetUserDuplicatesOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.UserMergeMergeUsersHandler = user_merge.MergeUsersHandlerFunc(func(params user_merge.MergeUsersParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":    "v2.user_merge.handlers.UserMergeMergeUsersHandler",
+			utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+			"authUser":        authUser.UserName,
+			"authEmail":       authUser.Email,
+			"survivingUserID": utils.StringValue(params.Input.SurvivingUserID),
+			"duplicateUserID": utils.StringValue(params.Input.DuplicateUserID),
+		}
+
+		if !utils.IsUserAdmin(authUser) {
+			msg := fmt.Sprintf("user %s does not have access to merge users", authUser.UserName)
+			log.WithFields(f).Debug(msg)
+			return user_merge.NewMergeUsersForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.MergeUsers(ctx, utils.StringValue(params.Input.SurvivingUserID), utils.StringValue(params.Input.DuplicateUserID))
+		if err != nil {
+			msg := "unable to merge the users"
+			log.WithFields(f).WithError(err).Warn(msg)
+			switch {
+			case errors.Is(err, ErrUserNotFound):
+				return user_merge.NewMergeUsersNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			case errors.Is(err, ErrIdentityConflict), errors.Is(err, ErrUserAlreadyMerged):
+				return user_merge.NewMergeUsersConflict().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseConflictWithError(reqID, msg, err))
+			case errors.Is(err, ErrInvalidMerge):
+				return user_merge.NewMergeUsersBadRequest().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+			return user_merge.NewMergeUsersInternalServerError().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return user_merge.NewMergeUsersOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.UserMergeRevertUserMergeHandler = user_merge.RevertUserMergeHandlerFunc(func(params user_merge.RevertUserMergeParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.user_merge.handlers.UserMergeRevertUserMergeHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"eventID":        params.EventID,
+		}
+
+		if !utils.IsUserAdmin(authUser) {
+			msg := fmt.Sprintf("user %s does not have access to revert user merges", authUser.UserName)
+			log.WithFields(f).Debug(msg)
+			return user_merge.NewRevertUserMergeForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.RevertMerge(ctx, params.EventID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to revert the user merge of the event: %s", params.EventID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			switch {
+			case errors.Is(err, ErrEventNotFound), errors.Is(err, ErrUserNotFound):
+				return user_merge.NewRevertUserMergeNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			case errors.Is(err, ErrMergeNotRevertible):
+				return user_merge.NewRevertUserMergeBadRequest().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+			return user_merge.NewRevertUserMergeInternalServerError().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return user_merge.NewRevertUserMergeOK().WithXRequestID(reqID).WithPayload(result)
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package user_merge
+
+import (
+	"errors"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+)
+
+// errors returned by the user merge service
+var (
+	ErrUserNotFound       = errors.New("user not found")
+	ErrEventNotFound      = errors.New("user merge event not found")
+	ErrInvalidMerge       = errors.New("invalid user merge")
+	ErrIdentityConflict   = errors.New("conflicting user identities")
+	ErrUserAlreadyMerged  = errors.New("user already merged")
+	ErrMergeNotRevertible = errors.New("user merge can not be reverted")
+	ErrReportNotFound     = errors.New("user duplicate report not found")
+)
+
+// MergedUserNote is appended to the note of the duplicate user record when it is merged
+const MergedUserNote = "Merged into the user ID: "
+
+// DuplicateReportKey is the S3 key of the user duplicate report, built offline by the user duplicates lambda
+const DuplicateReportKey = "user-merge/duplicate-report.json"
+
+// ReportStore stores the user duplicate report, the signature files bucket by default
+type ReportStore interface {
+	Put(key string, fileContent []byte) error
+	Download(filename string) ([]byte, error)
+	KeyExists(key string) (bool, error)
+}
+
+// s3ReportStore stores the report in the default S3 storage
+type s3ReportStore struct{}
+
+// Put stores the report
+func (s3ReportStore) Put(key string, fileContent []byte) error {
+	return utils.PutToS3(key, fileContent)
+}
+
+// Download returns the stored report
+func (s3ReportStore) Download(filename string) ([]byte, error) {
+	return utils.DownloadFromS3(filename)
+}
+
+// KeyExists returns true when the report was stored
+func (s3ReportStore) KeyExists(key string) (bool, error) {
+	return utils.DocumentExists(key)
+}
+
+// MergeRecord is the state before the merge, stored in the user merged event to revert the merge
+type MergeRecord struct {
+	SurvivingUserID    string               `json:"survivingUserID"`
+	DuplicateUserID    string               `json:"duplicateUserID"`
+	SurvivingUser      users.UserIdentities `json:"survivingUser"`
+	DuplicateUser      users.UserIdentities `json:"duplicateUser"`
+	SignatureIDs       []string             `json:"signatureIDs,omitempty"`
+	ApprovalRequestIDs []string             `json:"approvalRequestIDs,omitempty"`
+}
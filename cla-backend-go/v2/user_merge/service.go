@@ -0,0 +1,667 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package user_merge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/copier"
+	"github.com/linuxfoundation/easycla/cla-backend-go/approval_list"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Service contains the functions to detect and merge the duplicate user records
+type Service interface {
+	GetDuplicates(ctx context.Context) (*models.UserDuplicateReport, error)
+	RefreshDuplicates(ctx context.Context) (*models.UserDuplicateReport, error)
+	MergeUsers(ctx context.Context, survivingUserID, duplicateUserID string) (*models.UserMergeResult, error)
+	RevertMerge(ctx context.Context, eventID string) (*models.UserMergeResult, error)
+}
+
+type service struct {
+	usersRepo                users.UserRepository
+	signatureRepo            signatures.SignatureRepository
+	approvalListRequestsRepo approval_list.IRepository
+	eventsRepo               events.Repository
+	eventsService            events.Service
+	reportStore              ReportStore
+}
+
+// NewService creates a new user merge service
+func NewService(usersRepo users.UserRepository, signatureRepo signatures.SignatureRepository, approvalListRequestsRepo approval_list.IRepository, eventsRepo events.Repository, eventsService events.Service) Service {
+	return service{
+		usersRepo:                usersRepo,
+		signatureRepo:            signatureRepo,
+		approvalListRequestsRepo: approvalListRequestsRepo,
+		eventsRepo:               eventsRepo,
+		eventsService:            eventsService,
+		reportStore:              s3ReportStore{},
+	}
+}
+
+// GetDuplicates returns the last user duplicate report, the report is built offline as it scans the users table
+func (s service) GetDuplicates(ctx context.Context) (*models.UserDuplicateReport, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.user_merge.service.GetDuplicates",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	exists, err := s.reportStore.KeyExists(DuplicateReportKey)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to check for the user duplicate report")
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: the report has not been built yet", ErrReportNotFound)
+	}
+	reportJSON, err := s.reportStore.Download(DuplicateReportKey)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the user duplicate report")
+		return nil, err
+	}
+	var report models.UserDuplicateReport
+	if err = json.Unmarshal(reportJSON, &report); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to decode the user duplicate report")
+		return nil, err
+	}
+	return &report, nil
+}
+
+// RefreshDuplicates groups the user records sharing an email address, a LF username, a GitHub identity or a GitLab
+// identity and stores the report - called by the user duplicates lambda, the users merged since are only left out of
+// the next report
+func (s service) RefreshDuplicates(ctx context.Context) (*models.UserDuplicateReport, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.user_merge.service.RefreshDuplicates",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	userModels, err := s.usersRepo.GetAllUsers()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the users")
+		return nil, err
+	}
+
+	groups, err := duplicateGroups(userModels)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to convert the duplicate users")
+		return nil, err
+	}
+	log.WithFields(f).Debugf("found %d groups of duplicate users in %d users", len(groups), len(userModels))
+
+	_, generatedOn := utils.CurrentTime()
+	report := &models.UserDuplicateReport{Groups: groups, GeneratedOn: generatedOn}
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.reportStore.Put(DuplicateReportKey, reportJSON); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to store the user duplicate report")
+		return nil, err
+	}
+	return report, nil
+}
+
+// MergeUsers merges the duplicate user record into the surviving user record
+func (s service) MergeUsers(ctx context.Context, survivingUserID, duplicateUserID string) (*models.UserMergeResult, error) {
+	f := logrus.Fields{
+		"functionName":    "v2.user_merge.service.MergeUsers",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"survivingUserID": survivingUserID,
+		"duplicateUserID": duplicateUserID,
+	}
+
+	if survivingUserID == "" || duplicateUserID == "" || survivingUserID == duplicateUserID {
+		return nil, fmt.Errorf("%w: the surviving user ID and the duplicate user ID must be different", ErrInvalidMerge)
+	}
+	survivingUser, err := s.getUser(survivingUserID)
+	if err != nil {
+		return nil, err
+	}
+	duplicateUser, err := s.getUser(duplicateUserID)
+	if err != nil {
+		return nil, err
+	}
+	if isMerged(survivingUser) || isMerged(duplicateUser) {
+		return nil, fmt.Errorf("%w: the user %s or %s was merged into another user", ErrUserAlreadyMerged, survivingUserID, duplicateUserID)
+	}
+
+	mergedIdentities, err := mergeIdentities(toIdentities(survivingUser), toIdentities(duplicateUser))
+	if err != nil {
+		return nil, err
+	}
+
+	signatureIDs, err := s.signatureRepo.GetSignatureIDsByReference(ctx, duplicateUserID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the signatures of the duplicate user")
+		return nil, err
+	}
+	requestIDs, err := s.approvalListRequestsRepo.GetRequestIDsByUser(duplicateUserID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the approval requests of the duplicate user")
+		return nil, err
+	}
+	warnings, err := s.iclaWarnings(ctx, survivingUserID, signatureIDs)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to compare the individual signatures of the users")
+		return nil, err
+	}
+
+	record := MergeRecord{
+		SurvivingUserID:    survivingUserID,
+		DuplicateUserID:    duplicateUserID,
+		SurvivingUser:      toIdentities(survivingUser),
+		DuplicateUser:      toIdentities(duplicateUser),
+		SignatureIDs:       signatureIDs,
+		ApprovalRequestIDs: requestIDs,
+	}
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	// the merge record is stored before any change, so a merge which fails half way can still be reverted
+	if err = s.recordMergeStarted(ctx, survivingUserID, duplicateUserID, string(recordJSON)); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to store the merge record, the users were not merged")
+		return nil, err
+	}
+
+	// the duplicate record is kept with its identities cleared, so the lookups by identity return the surviving user
+	result, err := s.apply(ctx, MergeRecord{
+		SurvivingUserID:    survivingUserID,
+		DuplicateUserID:    duplicateUserID,
+		SurvivingUser:      mergedIdentities,
+		DuplicateUser:      users.UserIdentities{Note: appendNote(duplicateUser.Note, MergedUserNote+survivingUserID)},
+		SignatureIDs:       signatureIDs,
+		ApprovalRequestIDs: requestIDs,
+	}, survivingUserID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to merge the users, restoring the records from the merge record: %s", recordJSON)
+		if _, restoreErr := s.apply(ctx, record, duplicateUserID); restoreErr != nil {
+			log.WithFields(f).WithError(restoreErr).Errorf("unable to restore the records, revert the %s event of the merge", events.UserMergeStarted)
+		}
+		return nil, err
+	}
+	result.Warnings = warnings
+	for _, warning := range warnings {
+		log.WithFields(f).Warn(warning)
+	}
+
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.UserMerged,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		EventData: &events.UserMergedEventData{
+			SurvivingUserID: survivingUserID,
+			DuplicateUserID: duplicateUserID,
+			MergeRecord:     string(recordJSON),
+		},
+	})
+
+	log.WithFields(f).Infof("merged the user %s into the user %s, moved %d signatures and %d approval requests",
+		duplicateUserID, survivingUserID, len(signatureIDs), len(requestIDs))
+	return result, nil
+}
+
+// recordMergeStarted stores the merge record in the user merge started event, unlike the other events the merge
+// fails when it can't be stored
+func (s service) recordMergeStarted(ctx context.Context, survivingUserID, duplicateUserID, recordJSON string) error {
+	args := &events.LogEventArgs{
+		EventType:  events.UserMergeStarted,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		UserName:   utils.GetUserNameFromContext(ctx),
+	}
+	eventData := &events.UserMergeStartedEventData{
+		SurvivingUserID: survivingUserID,
+		DuplicateUserID: duplicateUserID,
+		MergeRecord:     recordJSON,
+	}
+	details, containsPII := eventData.GetEventDetailsString(args)
+	summary, _ := eventData.GetEventSummaryString(args)
+	return s.eventsRepo.CreateEvent(&v1Models.Event{
+		EventType:    events.UserMergeStarted,
+		UserID:       survivingUserID,
+		UserName:     args.UserName,
+		LfUsername:   args.LfUsername,
+		EventData:    details,
+		EventSummary: summary,
+		ContainsPII:  containsPII,
+	})
+}
+
+// iclaWarnings returns a warning per CLA group with an ICLA of both users, both ICLAs are kept by the merge
+func (s service) iclaWarnings(ctx context.Context, survivingUserID string, duplicateSignatureIDs []string) ([]string, error) {
+	survivingSignatureIDs, err := s.signatureRepo.GetSignatureIDsByReference(ctx, survivingUserID)
+	if err != nil {
+		return nil, err
+	}
+	survivingICLAs, err := s.iclasByCLAGroup(ctx, survivingSignatureIDs)
+	if err != nil || len(survivingICLAs) == 0 {
+		return nil, err
+	}
+	duplicateICLAs, err := s.iclasByCLAGroup(ctx, duplicateSignatureIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for claGroupID, signatureID := range duplicateICLAs {
+		if survivingSignatureID, ok := survivingICLAs[claGroupID]; ok {
+			warnings = append(warnings, fmt.Sprintf("both users signed an ICLA for the CLA group %s - the surviving user keeps the ICLAs %s and %s",
+				claGroupID, survivingSignatureID, signatureID))
+		}
+	}
+	sort.Strings(warnings)
+	return warnings, nil
+}
+
+// iclasByCLAGroup returns the signed ICLA IDs of the signatures by CLA group ID
+func (s service) iclasByCLAGroup(ctx context.Context, signatureIDs []string) (map[string]string, error) {
+	iclas := map[string]string{}
+	for _, signatureID := range signatureIDs {
+		sig, err := s.signatureRepo.GetSignature(ctx, signatureID)
+		if err != nil {
+			return nil, err
+		}
+		if sig != nil && sig.ClaType == utils.ClaTypeICLA && sig.SignatureSigned {
+			iclas[sig.ProjectID] = sig.SignatureID
+		}
+	}
+	return iclas, nil
+}
+
+// RevertMerge restores the user records, the signatures and the approval requests from the user merged event
+func (s service) RevertMerge(ctx context.Context, eventID string) (*models.UserMergeResult, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.user_merge.service.RevertMerge",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"eventID":        eventID,
+	}
+
+	event, err := s.eventsRepo.GetEvent(eventID)
+	if err != nil {
+		if errors.Is(err, events.ErrEventNotFound) {
+			return nil, ErrEventNotFound
+		}
+		log.WithFields(f).WithError(err).Warn("unable to load the user merge event")
+		return nil, err
+	}
+	if event.EventType != events.UserMerged && event.EventType != events.UserMergeStarted {
+		return nil, fmt.Errorf("%w: the event %s is a %s event", ErrMergeNotRevertible, eventID, event.EventType)
+	}
+	recordJSON, ok := events.UserMergeRecordFromEventData(event.EventData)
+	if !ok {
+		return nil, fmt.Errorf("%w: the event %s has no merge record", ErrMergeNotRevertible, eventID)
+	}
+	var record MergeRecord
+	if err = json.Unmarshal([]byte(recordJSON), &record); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to decode the merge record")
+		return nil, fmt.Errorf("%w: the merge record of the event %s is invalid", ErrMergeNotRevertible, eventID)
+	}
+
+	survivingUser, err := s.getUser(record.SurvivingUserID)
+	if err != nil {
+		return nil, err
+	}
+	duplicateUser, err := s.getUser(record.DuplicateUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkRevertible(event.EventType, record, toIdentities(survivingUser), toIdentities(duplicateUser)); err != nil {
+		log.WithFields(f).WithError(err).Warn("the user merge can not be reverted")
+		return nil, err
+	}
+
+	// the user records are restored to the state before the merge
+	result, err := s.apply(ctx, record, record.DuplicateUserID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to revert the user merge")
+		return nil, err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.UserMergeReverted,
+		LfUsername: utils.GetUserNameFromContext(ctx),
+		EventData: &events.UserMergeRevertedEventData{
+			SurvivingUserID: record.SurvivingUserID,
+			DuplicateUserID: record.DuplicateUserID,
+			MergeEventID:    eventID,
+		},
+	})
+
+	log.WithFields(f).Infof("reverted the merge of the user %s into the user %s", record.DuplicateUserID, record.SurvivingUserID)
+	return result, nil
+}
+
+// checkRevertible returns an error unless the user records are still as the merge left them - the revert would
+// otherwise undo the changes made since the merge, or revert the merge twice. A merge which failed half way is
+// reverted from its started event, its records may be in either state.
+func checkRevertible(eventType string, record MergeRecord, survivingUser, duplicateUser users.UserIdentities) error {
+	merged, err := mergeIdentities(record.SurvivingUser, record.DuplicateUser)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMergeNotRevertible, err)
+	}
+	cleared := users.UserIdentities{Note: appendNote(record.DuplicateUser.Note, MergedUserNote+record.SurvivingUserID)}
+
+	if sameIdentities(survivingUser, record.SurvivingUser) && sameIdentities(duplicateUser, record.DuplicateUser) {
+		return fmt.Errorf("%w: the users %s and %s are not merged, the merge was already reverted",
+			ErrMergeNotRevertible, record.SurvivingUserID, record.DuplicateUserID)
+	}
+	survivingMerged := sameIdentities(survivingUser, merged)
+	duplicateMerged := sameIdentities(duplicateUser, cleared)
+	if eventType == events.UserMergeStarted {
+		survivingMerged = survivingMerged || sameIdentities(survivingUser, record.SurvivingUser)
+		duplicateMerged = duplicateMerged || sameIdentities(duplicateUser, record.DuplicateUser)
+	}
+	if !survivingMerged || !duplicateMerged {
+		return fmt.Errorf("%w: the user %s or %s changed since the merge", ErrMergeNotRevertible, record.SurvivingUserID, record.DuplicateUserID)
+	}
+	return nil
+}
+
+// sameIdentities returns true when the identities are equal, the email addresses in any order and case
+func sameIdentities(a, b users.UserIdentities) bool {
+	fields := [][2]string{
+		{a.LFEmail, b.LFEmail},
+		{a.LFUsername, b.LFUsername},
+		{a.GitHubID, b.GitHubID},
+		{a.GitHubUsername, b.GitHubUsername},
+		{a.GitLabID, b.GitLabID},
+		{a.GitLabUsername, b.GitLabUsername},
+		{a.CompanyID, b.CompanyID},
+	}
+	for _, field := range fields {
+		if !strings.EqualFold(field[0], field[1]) {
+			return false
+		}
+	}
+	if a.Note != b.Note {
+		return false
+	}
+	return equalEmails(a.Emails, b.Emails)
+}
+
+// equalEmails returns true when both lists have the same email addresses, ignoring the case and the order
+func equalEmails(a, b []string) bool {
+	set := func(emails []string) map[string]bool {
+		keys := map[string]bool{}
+		for _, email := range emails {
+			if key := strings.ToLower(strings.TrimSpace(email)); key != "" {
+				keys[key] = true
+			}
+		}
+		return keys
+	}
+	aKeys, bKeys := set(a), set(b)
+	if len(aKeys) != len(bKeys) {
+		return false
+	}
+	for key := range aKeys {
+		if !bKeys[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// apply writes the identities of the record to both user records and points the signatures and the approval requests of the record to the target user
+func (s service) apply(ctx context.Context, record MergeRecord, targetUserID string) (*models.UserMergeResult, error) {
+	// the signatures store the identities of their user, they get the identities of the target user in the record
+	targetIdentities := record.SurvivingUser
+	if targetUserID == record.DuplicateUserID {
+		targetIdentities = record.DuplicateUser
+	}
+
+	survivingUser, err := s.usersRepo.UpdateUserIdentities(record.SurvivingUserID, record.SurvivingUser)
+	if err != nil {
+		return nil, err
+	}
+	duplicateUser, err := s.usersRepo.UpdateUserIdentities(record.DuplicateUserID, record.DuplicateUser)
+	if err != nil {
+		return nil, err
+	}
+	for _, signatureID := range record.SignatureIDs {
+		err = s.signatureRepo.UpdateSignature(ctx, signatureID, signatureUserUpdates(targetUserID, targetIdentities))
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, requestID := range record.ApprovalRequestIDs {
+		err = s.approvalListRequestsRepo.UpdateRequestUserID(requestID, targetUserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &models.UserMergeResult{
+		SignatureIDs:       record.SignatureIDs,
+		ApprovalRequestIDs: record.ApprovalRequestIDs,
+	}
+	if result.SurvivingUser, err = toUserModel(survivingUser); err != nil {
+		return nil, err
+	}
+	if result.DuplicateUser, err = toUserModel(duplicateUser); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// signatureUserUpdates returns the signature attributes pointing to the user, the name and the email of the signer are
+// kept as signed
+func signatureUserUpdates(userID string, identities users.UserIdentities) map[string]interface{} {
+	return map[string]interface{}{
+		"signature_reference_id":               userID,
+		"user_lf_username":                     identities.LFUsername,
+		"user_github_id":                       identities.GitHubID,
+		signatures.SignatureUserGitHubUsername: identities.GitHubUsername,
+		"user_gitlab_id":                       identities.GitLabID,
+		signatures.SignatureUserGitlabUsername: identities.GitLabUsername,
+	}
+}
+
+// getUser loads the user record, returns ErrUserNotFound when it does not exist
+func (s service) getUser(userID string) (*v1Models.User, error) {
+	userModel, err := s.usersRepo.GetUser(userID)
+	if err != nil {
+		var notFound *utils.UserNotFound
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+		}
+		return nil, err
+	}
+	if userModel == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+	return userModel, nil
+}
+
+// mergeIdentities fills the empty identities of the surviving user with the identities of the duplicate user
+func mergeIdentities(surviving, duplicate users.UserIdentities) (users.UserIdentities, error) {
+	merged := surviving
+	merged.Emails = nil
+
+	fields := []struct {
+		name      string
+		surviving *string
+		duplicate string
+	}{
+		{"LF username", &merged.LFUsername, duplicate.LFUsername},
+		{"GitHub ID", &merged.GitHubID, duplicate.GitHubID},
+		{"GitHub username", &merged.GitHubUsername, duplicate.GitHubUsername},
+		{"GitLab ID", &merged.GitLabID, duplicate.GitLabID},
+		{"GitLab username", &merged.GitLabUsername, duplicate.GitLabUsername},
+		{"company ID", &merged.CompanyID, duplicate.CompanyID},
+	}
+	for _, field := range fields {
+		if field.duplicate == "" {
+			continue
+		}
+		if *field.surviving == "" {
+			*field.surviving = field.duplicate
+			continue
+		}
+		if !strings.EqualFold(*field.surviving, field.duplicate) {
+			return users.UserIdentities{}, fmt.Errorf("%w: the %s of the surviving user is %s and the %s of the duplicate user is %s",
+				ErrIdentityConflict, field.name, *field.surviving, field.name, field.duplicate)
+		}
+	}
+	if merged.LFEmail == "" {
+		merged.LFEmail = duplicate.LFEmail
+	}
+
+	// the email addresses of both users, including the LF email of the duplicate user
+	seen := map[string]bool{}
+	for _, emails := range [][]string{surviving.Emails, duplicate.Emails, {duplicate.LFEmail}} {
+		for _, email := range emails {
+			key := strings.ToLower(strings.TrimSpace(email))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged.Emails = append(merged.Emails, email)
+		}
+	}
+	return merged, nil
+}
+
+// duplicateGroups groups the users sharing at least one identity
+func duplicateGroups(userModels []*v1Models.User) ([]*models.UserDuplicateGroup, error) {
+	// union find of the user indexes, users sharing an identity key are joined
+	parent := make([]int, len(userModels))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	usersByKey := map[string][]int{}
+	for i, userModel := range userModels {
+		if isMerged(userModel) {
+			continue
+		}
+		for _, key := range identityKeys(userModel) {
+			if others := usersByKey[key]; len(others) > 0 {
+				parent[find(i)] = find(others[0])
+			}
+			usersByKey[key] = append(usersByKey[key], i)
+		}
+	}
+
+	members := map[int][]int{}
+	for i := range userModels {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+
+	groups := make([]*models.UserDuplicateGroup, 0)
+	for root, indexes := range members {
+		if len(indexes) < 2 {
+			continue
+		}
+		group := &models.UserDuplicateGroup{}
+		for key, keyUsers := range usersByKey {
+			if len(keyUsers) > 1 && find(keyUsers[0]) == root {
+				group.SharedIdentities = append(group.SharedIdentities, key)
+			}
+		}
+		sort.Strings(group.SharedIdentities)
+		sort.Slice(indexes, func(a, b int) bool { return userModels[indexes[a]].UserID < userModels[indexes[b]].UserID })
+		for _, i := range indexes {
+			userModel, err := toUserModel(userModels[i])
+			if err != nil {
+				return nil, err
+			}
+			group.Users = append(group.Users, userModel)
+		}
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(a, b int) bool { return groups[a].Users[0].UserID < groups[b].Users[0].UserID })
+	return groups, nil
+}
+
+// identityKeys returns the identities of the user, prefixed with the identity type
+func identityKeys(userModel *v1Models.User) []string {
+	var keys []string
+	add := func(identityType, value string) {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value != "" {
+			keys = append(keys, identityType+":"+value)
+		}
+	}
+	add("email", userModel.LfEmail.String())
+	for _, email := range userModel.Emails {
+		add("email", email)
+	}
+	add("lfUsername", userModel.LfUsername)
+	add("githubID", userModel.GithubID)
+	add("githubUsername", userModel.GithubUsername)
+	add("gitlabID", userModel.GitlabID)
+	add("gitlabUsername", userModel.GitlabUsername)
+
+	// an email address may be both the LF email and one of the user emails
+	sort.Strings(keys)
+	unique := keys[:0]
+	for i, key := range keys {
+		if i == 0 || key != keys[i-1] {
+			unique = append(unique, key)
+		}
+	}
+	return unique
+}
+
+// toIdentities returns the identity attributes of the user record
+func toIdentities(userModel *v1Models.User) users.UserIdentities {
+	return users.UserIdentities{
+		LFEmail:        userModel.LfEmail.String(),
+		LFUsername:     userModel.LfUsername,
+		Emails:         userModel.Emails,
+		GitHubID:       userModel.GithubID,
+		GitHubUsername: userModel.GithubUsername,
+		GitLabID:       userModel.GitlabID,
+		GitLabUsername: userModel.GitlabUsername,
+		CompanyID:      userModel.CompanyID,
+		Note:           userModel.Note,
+	}
+}
+
+// toUserModel converts the v1 user model to the v2 user model
+func toUserModel(userModel *v1Models.User) (*models.User, error) {
+	if userModel == nil {
+		return nil, nil
+	}
+	var v2UserModel models.User
+	if err := copier.Copy(&v2UserModel, userModel); err != nil {
+		return nil, err
+	}
+	return &v2UserModel, nil
+}
+
+// isMerged returns true when the user record was merged into another user record
+func isMerged(userModel *v1Models.User) bool {
+	return strings.Contains(userModel.Note, MergedUserNote)
+}
+
+// appendNote appends the note to the existing note of the user record
+func appendNote(existing, note string) string {
+	if existing == "" {
+		return note
+	}
+	return fmt.Sprintf("%s. %s", existing, note)
+}
@@ -0,0 +1,372 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package user_merge
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/approval_list"
+	mock_approval_list "github.com/linuxfoundation/easycla/cla-backend-go/approval_list/mock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	mock_events "github.com/linuxfoundation/easycla/cla-backend-go/events/mock"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	mock_signatures "github.com/linuxfoundation/easycla/cla-backend-go/signatures/mocks"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	mock_users "github.com/linuxfoundation/easycla/cla-backend-go/users/mocks"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	lfUser     = &v1Models.User{UserID: "lf-user", LfEmail: "dev@example.com", LfUsername: "dev", Emails: []string{"dev@example.com"}, CompanyID: "company-id"}
+	githubUser = &v1Models.User{UserID: "github-user", Emails: []string{"Dev@example.com", "dev@users.noreply.github.com"}, GithubID: "123",
+		GithubUsername: "dev-gh"}
+	gitlabUser = &v1Models.User{UserID: "gitlab-user", Emails: []string{"dev@users.noreply.github.com"}, GitlabID: "456", GitlabUsername: "dev-gl"}
+	otherUser  = &v1Models.User{UserID: "other-user", Emails: []string{"other@example.com"}, GithubID: "789", GithubUsername: "other"}
+)
+
+// memoryReportStore keeps the stored reports in memory
+type memoryReportStore map[string][]byte
+
+func (m memoryReportStore) Put(key string, fileContent []byte) error {
+	m[key] = fileContent
+	return nil
+}
+
+func (m memoryReportStore) Download(filename string) ([]byte, error) {
+	return m[filename], nil
+}
+
+func (m memoryReportStore) KeyExists(key string) (bool, error) {
+	_, ok := m[key]
+	return ok, nil
+}
+
+// newTestService returns the service storing the duplicate report in memory
+func newTestService(usersRepo users.UserRepository, signatureRepo signatures.SignatureRepository, requestsRepo approval_list.IRepository,
+	eventsRepo events.Repository, eventsService events.Service) service {
+	s := NewService(usersRepo, signatureRepo, requestsRepo, eventsRepo, eventsService).(service)
+	s.reportStore = memoryReportStore{}
+	return s
+}
+
+// userWithIdentities returns the user record as written by UpdateUserIdentities
+func userWithIdentities(userID string, identities users.UserIdentities) *v1Models.User {
+	return &v1Models.User{
+		UserID:         userID,
+		LfEmail:        strfmt.Email(identities.LFEmail),
+		LfUsername:     identities.LFUsername,
+		Emails:         identities.Emails,
+		GithubID:       identities.GitHubID,
+		GithubUsername: identities.GitHubUsername,
+		GitlabID:       identities.GitLabID,
+		GitlabUsername: identities.GitLabUsername,
+		CompanyID:      identities.CompanyID,
+		Note:           identities.Note,
+	}
+}
+
+// expectMergeLoads expects the loads of the merge of the github user into the lf user, the users signed an ICLA for
+// the same CLA group
+func expectMergeLoads(usersRepo *mock_users.MockUserRepository, signatureRepo *mock_signatures.MockSignatureRepository, requestsRepo *mock_approval_list.MockIRepository) {
+	usersRepo.EXPECT().GetUser("lf-user").Return(lfUser, nil)
+	usersRepo.EXPECT().GetUser("github-user").Return(githubUser, nil)
+	signatureRepo.EXPECT().GetSignatureIDsByReference(gomock.Any(), "github-user").Return([]string{"icla-github", "ecla-github"}, nil)
+	signatureRepo.EXPECT().GetSignatureIDsByReference(gomock.Any(), "lf-user").Return([]string{"icla-lf"}, nil)
+	signatureRepo.EXPECT().GetSignature(gomock.Any(), "icla-lf").
+		Return(&v1Models.Signature{SignatureID: "icla-lf", ClaType: "icla", ProjectID: "cla-group-1", SignatureSigned: true}, nil)
+	signatureRepo.EXPECT().GetSignature(gomock.Any(), "icla-github").
+		Return(&v1Models.Signature{SignatureID: "icla-github", ClaType: "icla", ProjectID: "cla-group-1", SignatureSigned: true}, nil)
+	signatureRepo.EXPECT().GetSignature(gomock.Any(), "ecla-github").
+		Return(&v1Models.Signature{SignatureID: "ecla-github", ClaType: "ecla", ProjectID: "cla-group-2", SignatureSigned: true}, nil)
+	requestsRepo.EXPECT().GetRequestIDsByUser("github-user").Return([]string{"request-github"}, nil)
+}
+
+func TestGetDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	usersRepo.EXPECT().GetAllUsers().Return([]*v1Models.User{lfUser, githubUser, gitlabUser, otherUser}, nil)
+	service := newTestService(usersRepo, nil, nil, nil, nil)
+
+	// the report is built offline
+	_, err := service.GetDuplicates(context.Background())
+	assert.True(t, errors.Is(err, ErrReportNotFound))
+	refreshed, err := service.RefreshDuplicates(context.Background())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, refreshed.GeneratedOn)
+
+	report, err := service.GetDuplicates(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, refreshed.GeneratedOn, report.GeneratedOn)
+	if assert.Len(t, report.Groups, 1) {
+		group := report.Groups[0]
+		assert.Equal(t, []string{"email:dev@example.com", "email:dev@users.noreply.github.com"}, group.SharedIdentities)
+		if assert.Len(t, group.Users, 3) {
+			assert.Equal(t, "github-user", group.Users[0].UserID)
+			assert.Equal(t, "gitlab-user", group.Users[1].UserID)
+			assert.Equal(t, "lf-user", group.Users[2].UserID)
+		}
+	}
+}
+
+func TestMergeUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	requestsRepo := mock_approval_list.NewMockIRepository(ctrl)
+	eventsRepo := mock_events.NewMockRepository(ctrl)
+	eventsService := mock_events.NewMockService(ctrl)
+	service := newTestService(usersRepo, signatureRepo, requestsRepo, eventsRepo, eventsService)
+
+	expectMergeLoads(usersRepo, signatureRepo, requestsRepo)
+	var survivor, duplicate *v1Models.User
+	gomock.InOrder(
+		eventsRepo.EXPECT().CreateEvent(gomock.Any()).Do(func(event *v1Models.Event) {
+			assert.Equal(t, events.UserMergeStarted, event.EventType)
+			assert.Equal(t, "lf-user", event.UserID)
+		}).Return(nil),
+		usersRepo.EXPECT().UpdateUserIdentities("lf-user", gomock.Any()).DoAndReturn(func(userID string, identities users.UserIdentities) (*v1Models.User, error) {
+			survivor = userWithIdentities(userID, identities)
+			return survivor, nil
+		}),
+		usersRepo.EXPECT().UpdateUserIdentities("github-user", gomock.Any()).DoAndReturn(func(userID string, identities users.UserIdentities) (*v1Models.User, error) {
+			duplicate = userWithIdentities(userID, identities)
+			return duplicate, nil
+		}),
+	)
+	// the signatures store the identities of the surviving user
+	survivorUpdates := map[string]interface{}{
+		"signature_reference_id": "lf-user",
+		"user_lf_username":       "dev",
+		"user_github_id":         "123",
+		"user_github_username":   "dev-gh",
+		"user_gitlab_id":         "",
+		"user_gitlab_username":   "",
+	}
+	signatureRepo.EXPECT().UpdateSignature(gomock.Any(), "icla-github", survivorUpdates).Return(nil)
+	signatureRepo.EXPECT().UpdateSignature(gomock.Any(), "ecla-github", survivorUpdates).Return(nil)
+	requestsRepo.EXPECT().UpdateRequestUserID("request-github", "lf-user").Return(nil)
+	eventsService.EXPECT().LogEventWithContext(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, args *events.LogEventArgs) {
+		assert.Equal(t, events.UserMerged, args.EventType)
+	})
+
+	result, err := service.MergeUsers(context.Background(), "lf-user", "github-user")
+	assert.NoError(t, err)
+	assert.Equal(t, "lf-user", result.SurvivingUser.UserID)
+	assert.Equal(t, []string{"icla-github", "ecla-github"}, result.SignatureIDs)
+	assert.Equal(t, []string{"request-github"}, result.ApprovalRequestIDs)
+	// both users signed an ICLA for the same CLA group
+	assert.Equal(t, []string{"both users signed an ICLA for the CLA group cla-group-1 - the surviving user keeps the ICLAs icla-lf and icla-github"},
+		result.Warnings)
+
+	assert.Equal(t, "dev", survivor.LfUsername)
+	assert.Equal(t, "123", survivor.GithubID)
+	assert.Equal(t, "dev-gh", survivor.GithubUsername)
+	assert.Equal(t, "company-id", survivor.CompanyID)
+	assert.Equal(t, []string{"dev@example.com", "dev@users.noreply.github.com"}, survivor.Emails)
+	assert.Empty(t, duplicate.Emails)
+	assert.Empty(t, duplicate.GithubID)
+	assert.Equal(t, "Merged into the user ID: lf-user", duplicate.Note)
+
+	// the merged user is no longer reported and can't be merged again
+	usersRepo.EXPECT().GetAllUsers().Return([]*v1Models.User{survivor, duplicate, gitlabUser, otherUser}, nil)
+	report, err := service.RefreshDuplicates(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, report.Groups, 1) {
+		assert.Len(t, report.Groups[0].Users, 2)
+	}
+
+	usersRepo.EXPECT().GetUser("gitlab-user").Return(gitlabUser, nil)
+	usersRepo.EXPECT().GetUser("github-user").Return(duplicate, nil)
+	_, err = service.MergeUsers(context.Background(), "gitlab-user", "github-user")
+	assert.True(t, errors.Is(err, ErrUserAlreadyMerged))
+}
+
+func TestMergeUsersErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	// nothing is moved or logged when the merge is rejected
+	service := NewService(usersRepo, mock_signatures.NewMockSignatureRepository(ctrl), mock_approval_list.NewMockIRepository(ctrl),
+		mock_events.NewMockRepository(ctrl), mock_events.NewMockService(ctrl))
+
+	usersRepo.EXPECT().GetUser("github-user").Return(githubUser, nil)
+	usersRepo.EXPECT().GetUser("other-user").Return(otherUser, nil)
+	_, err := service.MergeUsers(context.Background(), "github-user", "other-user")
+	assert.True(t, errors.Is(err, ErrIdentityConflict))
+
+	usersRepo.EXPECT().GetUser("lf-user").Return(lfUser, nil)
+	usersRepo.EXPECT().GetUser("unknown-user").Return(nil, &utils.UserNotFound{Message: "user not found"})
+	_, err = service.MergeUsers(context.Background(), "lf-user", "unknown-user")
+	assert.True(t, errors.Is(err, ErrUserNotFound))
+
+	_, err = service.MergeUsers(context.Background(), "lf-user", "lf-user")
+	assert.True(t, errors.Is(err, ErrInvalidMerge))
+}
+
+func TestMergeUsersFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	requestsRepo := mock_approval_list.NewMockIRepository(ctrl)
+	eventsRepo := mock_events.NewMockRepository(ctrl)
+	eventsService := mock_events.NewMockService(ctrl)
+	service := newTestService(usersRepo, signatureRepo, requestsRepo, eventsRepo, eventsService)
+
+	// the merge fails after the first signature, the records are restored and the merged event isn't logged
+	expectMergeLoads(usersRepo, signatureRepo, requestsRepo)
+	var started *v1Models.Event
+	duplicateUpdates := map[string]interface{}{
+		"signature_reference_id": "github-user",
+		"user_lf_username":       "",
+		"user_github_id":         "123",
+		"user_github_username":   "dev-gh",
+		"user_gitlab_id":         "",
+		"user_gitlab_username":   "",
+	}
+	gomock.InOrder(
+		eventsRepo.EXPECT().CreateEvent(gomock.Any()).Do(func(event *v1Models.Event) {
+			started = event
+		}).Return(nil),
+		usersRepo.EXPECT().UpdateUserIdentities("lf-user", gomock.Any()).Return(lfUser, nil),
+		usersRepo.EXPECT().UpdateUserIdentities("github-user", gomock.Any()).Return(githubUser, nil),
+		signatureRepo.EXPECT().UpdateSignature(gomock.Any(), "icla-github", gomock.Any()).Return(nil),
+		signatureRepo.EXPECT().UpdateSignature(gomock.Any(), "ecla-github", gomock.Any()).Return(errors.New("update failed")),
+
+		usersRepo.EXPECT().UpdateUserIdentities("lf-user", toIdentities(lfUser)).Return(lfUser, nil),
+		usersRepo.EXPECT().UpdateUserIdentities("github-user", toIdentities(githubUser)).Return(githubUser, nil),
+		signatureRepo.EXPECT().UpdateSignature(gomock.Any(), "icla-github", duplicateUpdates).Return(nil),
+		signatureRepo.EXPECT().UpdateSignature(gomock.Any(), "ecla-github", duplicateUpdates).Return(nil),
+		requestsRepo.EXPECT().UpdateRequestUserID("request-github", "github-user").Return(nil),
+	)
+
+	_, err := service.MergeUsers(context.Background(), "lf-user", "github-user")
+	assert.Error(t, err)
+	if assert.NotNil(t, started) {
+		assert.Equal(t, events.UserMergeStarted, started.EventType)
+	}
+
+	// the records were restored, the merge can't be reverted again
+	started.EventID = "started-event-id"
+	eventsRepo.EXPECT().GetEvent("started-event-id").Return(started, nil)
+	usersRepo.EXPECT().GetUser("lf-user").Return(lfUser, nil)
+	usersRepo.EXPECT().GetUser("github-user").Return(githubUser, nil)
+	_, err = service.RevertMerge(context.Background(), "started-event-id")
+	assert.True(t, errors.Is(err, ErrMergeNotRevertible))
+
+	// a merge which didn't complete and wasn't restored is reverted from its started event, the surviving user has
+	// the merged identities and the duplicate user wasn't cleared yet
+	mergedIdentities, err := mergeIdentities(toIdentities(lfUser), toIdentities(githubUser))
+	assert.NoError(t, err)
+	eventsRepo.EXPECT().GetEvent("started-event-id").Return(started, nil)
+	usersRepo.EXPECT().GetUser("lf-user").Return(userWithIdentities("lf-user", mergedIdentities), nil)
+	usersRepo.EXPECT().GetUser("github-user").Return(githubUser, nil)
+	gomock.InOrder(
+		usersRepo.EXPECT().UpdateUserIdentities("lf-user", toIdentities(lfUser)).Return(lfUser, nil),
+		usersRepo.EXPECT().UpdateUserIdentities("github-user", toIdentities(githubUser)).Return(githubUser, nil),
+		signatureRepo.EXPECT().UpdateSignature(gomock.Any(), "icla-github", duplicateUpdates).Return(nil),
+		signatureRepo.EXPECT().UpdateSignature(gomock.Any(), "ecla-github", duplicateUpdates).Return(nil),
+		requestsRepo.EXPECT().UpdateRequestUserID("request-github", "github-user").Return(nil),
+	)
+	eventsService.EXPECT().LogEventWithContext(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, args *events.LogEventArgs) {
+		assert.Equal(t, events.UserMergeReverted, args.EventType)
+	})
+
+	result, err := service.RevertMerge(context.Background(), "started-event-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "github-user", result.DuplicateUser.UserID)
+}
+
+func TestRevertMerge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	requestsRepo := mock_approval_list.NewMockIRepository(ctrl)
+	eventsRepo := mock_events.NewMockRepository(ctrl)
+	eventsService := mock_events.NewMockService(ctrl)
+	service := newTestService(usersRepo, signatureRepo, requestsRepo, eventsRepo, eventsService)
+
+	expectMergeLoads(usersRepo, signatureRepo, requestsRepo)
+	var survivor, duplicate *v1Models.User
+	merged := &v1Models.Event{EventID: "merged-event-id"}
+	eventsRepo.EXPECT().CreateEvent(gomock.Any()).Return(nil)
+	usersRepo.EXPECT().UpdateUserIdentities("lf-user", gomock.Any()).DoAndReturn(func(userID string, identities users.UserIdentities) (*v1Models.User, error) {
+		survivor = userWithIdentities(userID, identities)
+		return survivor, nil
+	})
+	usersRepo.EXPECT().UpdateUserIdentities("github-user", gomock.Any()).DoAndReturn(func(userID string, identities users.UserIdentities) (*v1Models.User, error) {
+		duplicate = userWithIdentities(userID, identities)
+		return duplicate, nil
+	})
+	signatureRepo.EXPECT().UpdateSignature(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	requestsRepo.EXPECT().UpdateRequestUserID("request-github", "lf-user").Return(nil)
+	eventsService.EXPECT().LogEventWithContext(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, args *events.LogEventArgs) {
+		merged.EventType = args.EventType
+		merged.EventData, _ = args.EventData.GetEventDetailsString(args)
+	})
+
+	_, err := service.MergeUsers(context.Background(), "lf-user", "github-user")
+	assert.NoError(t, err)
+
+	// the surviving user changed since the merge, the revert would undo the change
+	changed := *survivor
+	changed.CompanyID = "other-company-id"
+	eventsRepo.EXPECT().GetEvent("merged-event-id").Return(merged, nil)
+	usersRepo.EXPECT().GetUser("lf-user").Return(&changed, nil)
+	usersRepo.EXPECT().GetUser("github-user").Return(duplicate, nil)
+	_, err = service.RevertMerge(context.Background(), "merged-event-id")
+	assert.True(t, errors.Is(err, ErrMergeNotRevertible))
+
+	// the records are restored from the merge record, the signatures get the identities of the duplicate user
+	eventsRepo.EXPECT().GetEvent("merged-event-id").Return(merged, nil)
+	usersRepo.EXPECT().GetUser("lf-user").Return(survivor, nil)
+	usersRepo.EXPECT().GetUser("github-user").Return(duplicate, nil)
+	usersRepo.EXPECT().UpdateUserIdentities("lf-user", toIdentities(lfUser)).Return(lfUser, nil)
+	usersRepo.EXPECT().UpdateUserIdentities("github-user", toIdentities(githubUser)).Return(githubUser, nil)
+	duplicateUpdates := map[string]interface{}{
+		"signature_reference_id": "github-user",
+		"user_lf_username":       "",
+		"user_github_id":         "123",
+		"user_github_username":   "dev-gh",
+		"user_gitlab_id":         "",
+		"user_gitlab_username":   "",
+	}
+	signatureRepo.EXPECT().UpdateSignature(gomock.Any(), "icla-github", duplicateUpdates).Return(nil)
+	signatureRepo.EXPECT().UpdateSignature(gomock.Any(), "ecla-github", duplicateUpdates).Return(nil)
+	requestsRepo.EXPECT().UpdateRequestUserID("request-github", "github-user").Return(nil)
+	eventsService.EXPECT().LogEventWithContext(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, args *events.LogEventArgs) {
+		assert.Equal(t, events.UserMergeReverted, args.EventType)
+	})
+
+	result, err := service.RevertMerge(context.Background(), "merged-event-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "github-user", result.DuplicateUser.UserID)
+	assert.Equal(t, "123", result.DuplicateUser.GithubID)
+	assert.Equal(t, "dev-gh", result.DuplicateUser.GithubUsername)
+	assert.Empty(t, result.SurvivingUser.GithubID)
+
+	// the merge can only be reverted once
+	eventsRepo.EXPECT().GetEvent("merged-event-id").Return(merged, nil)
+	usersRepo.EXPECT().GetUser("lf-user").Return(lfUser, nil)
+	usersRepo.EXPECT().GetUser("github-user").Return(githubUser, nil)
+	_, err = service.RevertMerge(context.Background(), "merged-event-id")
+	assert.True(t, errors.Is(err, ErrMergeNotRevertible))
+
+	eventsRepo.EXPECT().GetEvent("reverted-event-id").Return(&v1Models.Event{EventID: "reverted-event-id", EventType: events.UserMergeReverted}, nil)
+	_, err = service.RevertMerge(context.Background(), "reverted-event-id")
+	assert.True(t, errors.Is(err, ErrMergeNotRevertible))
+
+	eventsRepo.EXPECT().GetEvent("unknown-event").Return(nil, events.ErrEventNotFound)
+	_, err = service.RevertMerge(context.Background(), "unknown-event")
+	assert.True(t, errors.Is(err, ErrEventNotFound))
+}
@@ -0,0 +1,447 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gofrs/uuid"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// delivery headers
+const (
+	HeaderEvent     = "X-EasyCLA-Event"
+	HeaderDelivery  = "X-EasyCLA-Delivery"
+	HeaderTimestamp = "X-EasyCLA-Timestamp"
+	HeaderSignature = "X-EasyCLA-Signature"
+)
+
+const (
+	maxDeliveryAttempts = 4
+	// initialDeliveryBackoff is the delay before the first retry, doubled after each attempt - the retry worker runs
+	// every five minutes
+	initialDeliveryBackoff = 5 * time.Minute
+	// deliveryLease is the time an attempt in progress holds a pending delivery before the retry worker can take it
+	deliveryLease        = time.Minute
+	deliveryTimeout      = 10 * time.Second
+	deliveryLogRetention = 30 * 24 * time.Hour
+	maxConcurrentRetries = 10
+)
+
+// RetryResult is the number of the pending deliveries retried by outcome
+type RetryResult struct {
+	Sent     int
+	Requeued int
+	Failed   int
+}
+
+// Sign returns the signature header value of the delivery body: the hex HMAC SHA-256 of the timestamp, a period
+// and the body
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp)) // nolint
+	mac.Write([]byte("."))       // nolint
+	mac.Write(body)              // nolint
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// validateEndpointURL returns an error when the endpoint is not an HTTPS URL of a public host
+func validateEndpointURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if u.Scheme != "https" || u.Hostname() == "" {
+		return errors.New("the webhook URL must be an https URL")
+	}
+	if u.User != nil {
+		return errors.New("the webhook URL must not include credentials")
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".internal") {
+		return fmt.Errorf("the webhook URL host %s is not a public host", host)
+	}
+	if ip := net.ParseIP(host); ip != nil && !isPublicIP(ip) {
+		return fmt.Errorf("the webhook URL host %s is not a public address", host)
+	}
+	return nil
+}
+
+// isPublicIP returns false for the loopback, private, link local and unspecified addresses
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast())
+}
+
+// newDeliveryClient returns the HTTP client of the deliveries, the connections to the non public addresses are
+// refused after the DNS resolution so an endpoint can't target the internal network
+func newDeliveryClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: deliveryTimeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("refusing to deliver webhook to the non public address %s", host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Transport: transport,
+		Timeout:   deliveryTimeout,
+		// the redirects are not followed, the endpoint must answer
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// DispatchEvent delivers the event to the active subscriptions of its foundation and company which chose the event
+// type, the deliveries are concurrent and attempted once - the failed deliveries which can be retried are left
+// pending for the retry worker
+func (s *service) DispatchEvent(ctx context.Context, event *Event) error {
+	eventType := WebhookEventType(event.EventType)
+	if eventType == "" {
+		return nil
+	}
+	f := logrus.Fields{
+		"functionName":     "v2.webhooks.service.DispatchEvent",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"recordID":         event.RecordID,
+		"eventID":          event.EventID,
+		"eventType":        event.EventType,
+		"webhookEventType": eventType,
+		"foundationSFID":   event.FoundationSFID,
+		"companySFID":      event.CompanySFID,
+	}
+
+	var subscriptions []*Subscription
+	for _, scope := range []struct{ scope, scopeID string }{
+		{ScopeFoundation, event.FoundationSFID},
+		{ScopeCompany, event.CompanySFID},
+	} {
+		if scope.scopeID == "" {
+			continue
+		}
+		scopeSubscriptions, err := s.repo.ListSubscriptions(ctx, scope.scope, scope.scopeID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to load the webhook subscriptions of the %s %s", scope.scope, scope.scopeID)
+			return err
+		}
+		for _, subscription := range scopeSubscriptions {
+			if subscription.subscribes(eventType) {
+				subscriptions = append(subscriptions, subscription)
+			}
+		}
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	log.WithFields(f).Debugf("delivering the event to %d webhook subscriptions", len(subscriptions))
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var addErr error
+	wg.Add(len(subscriptions))
+	for _, subscription := range subscriptions {
+		go func(subscription *Subscription) {
+			defer wg.Done()
+			delivery := s.newDelivery(ctx, subscription, eventType, event)
+			// the delivery is saved before the attempt so a replayed stream record doesn't deliver it again
+			if err := s.repo.AddDelivery(ctx, delivery); err != nil {
+				if errors.Is(err, ErrDeliveryExists) {
+					log.WithFields(f).Debugf("the delivery %s was already added", delivery.DeliveryID)
+					return
+				}
+				lock.Lock()
+				addErr = err
+				lock.Unlock()
+				return
+			}
+			s.deliver(ctx, subscription, delivery)
+			if err := s.repo.UpdateDelivery(ctx, delivery); err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to log the delivery %s", delivery.DeliveryID)
+			}
+		}(subscription)
+	}
+	wg.Wait()
+
+	// the stream record is retried when a delivery couldn't be saved, the saved deliveries are skipped the next time
+	return addErr
+}
+
+// RetryPendingDeliveries attempts the pending deliveries which are due, up to the limit
+func (s *service) RetryPendingDeliveries(ctx context.Context, limit int64) (*RetryResult, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.service.RetryPendingDeliveries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"limit":          limit,
+	}
+
+	now := s.now()
+	deliveries, err := s.repo.ListPendingDeliveries(ctx, now.Unix(), limit)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the pending webhook deliveries")
+		return nil, err
+	}
+	log.WithFields(f).Debugf("retrying %d pending webhook deliveries", len(deliveries))
+
+	result := &RetryResult{}
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	workers := make(chan struct{}, maxConcurrentRetries)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(delivery *Delivery) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			outcome := s.retry(ctx, delivery, now.Add(deliveryLease).Unix())
+			lock.Lock()
+			defer lock.Unlock()
+			switch outcome {
+			case retryOutcomeSent:
+				result.Sent++
+			case retryOutcomeRequeued:
+				result.Requeued++
+			case retryOutcomeFailed:
+				result.Failed++
+			}
+		}(delivery)
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// retry outcomes of a pending delivery
+const (
+	retryOutcomeSkipped = iota
+	retryOutcomeSent
+	retryOutcomeRequeued
+	retryOutcomeFailed
+)
+
+// retry claims the pending delivery and attempts it once more
+func (s *service) retry(ctx context.Context, delivery *Delivery, leaseUntil int64) int {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.service.retry",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": delivery.SubscriptionID,
+		"deliveryID":     delivery.DeliveryID,
+	}
+
+	claimed, err := s.repo.ClaimDelivery(ctx, delivery, leaseUntil)
+	if err != nil || !claimed {
+		return retryOutcomeSkipped
+	}
+
+	subscription, err := s.repo.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil && !errors.Is(err, ErrSubscriptionNotFound) {
+		// the delivery stays claimed and is retried once the lease expires
+		log.WithFields(f).WithError(err).Warn("unable to load the webhook subscription of the delivery")
+		return retryOutcomeSkipped
+	}
+	if subscription == nil || subscription.Paused {
+		log.WithFields(f).Debug("the webhook subscription was deleted or paused, the delivery is abandoned")
+		delivery.stopRetrying()
+	} else {
+		s.deliver(ctx, subscription, delivery)
+	}
+
+	if err := s.repo.UpdateDelivery(ctx, delivery); err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to log the delivery %s", delivery.DeliveryID)
+		return retryOutcomeSkipped
+	}
+	switch {
+	case delivery.Success:
+		return retryOutcomeSent
+	case delivery.pending():
+		return retryOutcomeRequeued
+	default:
+		return retryOutcomeFailed
+	}
+}
+
+// deliveryID returns the ID of the delivery of the stream record, the stream record time keeps the deliveries of a
+// subscription sorted by date and the record ID makes the ID the same when the record is replayed
+func deliveryID(event *Event, now time.Time) string {
+	recordTime := event.RecordTime
+	if recordTime.IsZero() {
+		recordTime = now
+	}
+	recordID := event.RecordID
+	if recordID == "" {
+		recordID = event.EventID
+	}
+	return fmt.Sprintf("%s#%s", recordTime.UTC().Format(time.RFC3339), recordID)
+}
+
+// newDelivery returns the pending delivery of the event to the subscription with the payload to post, the first
+// attempt is made right away so the retry worker only picks the delivery up when the attempt wasn't logged
+func (s *service) newDelivery(ctx context.Context, subscription *Subscription, eventType string, event *Event) *Delivery {
+	created := s.now().UTC()
+	delivery := &Delivery{
+		SubscriptionID: subscription.SubscriptionID,
+		DeliveryID:     deliveryID(event, created),
+		EventID:        event.EventID,
+		EventType:      eventType,
+		DateCreated:    created.Format(time.RFC3339),
+		Expire:         created.Add(deliveryLogRetention).Unix(),
+		RetryStatus:    RetryStatusPending,
+		NextAttemptAt:  created.Add(deliveryLease).Unix(),
+	}
+
+	body, err := json.Marshal(newPayload(delivery.DeliveryID, eventType, event))
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"functionName":   "v2.webhooks.service.newDelivery",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"subscriptionID": subscription.SubscriptionID,
+			"eventID":        event.EventID,
+		}).WithError(err).Warn("unable to marshal the webhook payload")
+		delivery.Attempts = append(delivery.Attempts, DeliveryAttempt{Attempt: 1, Error: err.Error(), DateCreated: delivery.DateCreated})
+		delivery.stopRetrying()
+		return delivery
+	}
+	delivery.Body = string(body)
+	return delivery
+}
+
+// deliver posts the payload of the pending delivery to the subscription endpoint once, a failure which can be
+// retried leaves the delivery pending with an exponential backoff until the attempts are exhausted
+func (s *service) deliver(ctx context.Context, subscription *Subscription, delivery *Delivery) {
+	if !delivery.pending() {
+		return
+	}
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.service.deliver",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscription.SubscriptionID,
+		"deliveryID":     delivery.DeliveryID,
+	}
+
+	result, retry := s.attempt(ctx, subscription, delivery.DeliveryID, delivery.EventType, []byte(delivery.Body))
+	result.Attempt = len(delivery.Attempts) + 1
+	delivery.Attempts = append(delivery.Attempts, result)
+	delivery.StatusCode = result.StatusCode
+	if result.Error == "" {
+		delivery.Success = true
+		delivery.stopRetrying()
+		return
+	}
+
+	log.WithFields(f).Debugf("webhook delivery attempt %d failed: %s", result.Attempt, result.Error)
+	if !retry || result.Attempt >= maxDeliveryAttempts {
+		log.WithFields(f).Warnf("unable to deliver the event to %s after %d attempts", subscription.URL, result.Attempt)
+		delivery.stopRetrying()
+		return
+	}
+	backoff := initialDeliveryBackoff << (result.Attempt - 1)
+	delivery.NextAttemptAt = s.now().Add(backoff).Unix()
+}
+
+// attempt posts the signed body once, it returns the result and true when a failure should be retried
+func (s *service) attempt(ctx context.Context, subscription *Subscription, deliveryID, eventType string, body []byte) (result DeliveryAttempt, retry bool) {
+	start := s.now()
+	result.DateCreated = start.UTC().Format(time.RFC3339)
+	defer func() {
+		result.Duration = s.now().Sub(start).String()
+	}()
+
+	timestamp := strconv.FormatInt(start.Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		result.Error = err.Error()
+		return result, false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "EasyCLA-Webhooks")
+	req.Header.Set(HeaderEvent, eventType)
+	req.Header.Set(HeaderDelivery, deliveryID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		result.Error = err.Error()
+		return result, true
+	}
+	defer func() {
+		// drain a bit of the body so the connection can be reused
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096)) // nolint
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.WithError(closeErr).Warn("problem closing response body")
+		}
+	}()
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return result, false
+	}
+	result.Error = fmt.Sprintf("the endpoint returned status code %d", resp.StatusCode)
+	// the client errors won't go away by retrying, except the timeouts and the rate limits
+	retry = resp.StatusCode >= 500 || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests
+	return result, retry
+}
+
+// newPayload returns the JSON body of the delivery
+func newPayload(deliveryID, eventType string, event *Event) *Payload {
+	return &Payload{
+		DeliveryID:       deliveryID,
+		EventType:        eventType,
+		EventID:          event.EventID,
+		EasyCLAEventType: event.EventType,
+		EventTime:        event.EventTime,
+		Summary:          event.EventSummary,
+		Data:             event.EventData,
+		FoundationSFID:   event.FoundationSFID,
+		ProjectSFID:      event.ProjectSFID,
+		ProjectName:      event.ProjectName,
+		CLAGroupID:       event.CLAGroupID,
+		CLAGroupName:     event.CLAGroupName,
+		CompanyID:        event.CompanyID,
+		CompanySFID:      event.CompanySFID,
+		CompanyName:      event.CompanyName,
+		UserName:         event.UserName,
+		LfUsername:       event.LfUsername,
+	}
+}
+
+// newID returns a new random ID
+func newID() string {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return id.String()
+}
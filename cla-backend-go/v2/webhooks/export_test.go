@@ -0,0 +1,21 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"net/http"
+	"time"
+)
+
+// ValidateEndpointURL exposes validateEndpointURL to the tests
+var ValidateEndpointURL = validateEndpointURL
+
+// NewTestService returns the service with the HTTP client of the tests and a clock fixed at now
+func NewTestService(repo Repository, httpClient *http.Client, now time.Time) Service {
+	return &service{
+		repo:       repo,
+		httpClient: httpClient,
+		now:        func() time.Time { return now },
+	}
+}
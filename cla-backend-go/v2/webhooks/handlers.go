@@ -0,0 +1,275 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/webhooks"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service, eventService events.Service) {
+	api.WebhooksListWebhookSubscriptionsHandler = webhooks.ListWebhookSubscriptionsHandlerFunc(func(params webhooks.ListWebhookSubscriptionsParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksListWebhookSubscriptionsHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"scope":          params.Scope,
+			"scopeID":        params.ScopeID,
+		}
+
+		if !isUserAuthorizedForScope(ctx, authUser, params.Scope, params.ScopeID) {
+			msg := fmt.Sprintf("user %s does not have access to list the webhook subscriptions of the %s: %s",
+				authUser.UserName, params.Scope, params.ScopeID)
+			log.WithFields(f).Debug(msg)
+			return webhooks.NewListWebhookSubscriptionsForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.ListSubscriptions(ctx, params.Scope, params.ScopeID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to list the webhook subscriptions of the %s: %s", params.Scope, params.ScopeID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return webhooks.NewListWebhookSubscriptionsBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		return webhooks.NewListWebhookSubscriptionsOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.WebhooksCreateWebhookSubscriptionHandler = webhooks.CreateWebhookSubscriptionHandlerFunc(func(params webhooks.CreateWebhookSubscriptionParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksCreateWebhookSubscriptionHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"scope":          params.Scope,
+			"scopeID":        params.ScopeID,
+		}
+
+		if !isUserAuthorizedForScope(ctx, authUser, params.Scope, params.ScopeID) {
+			msg := fmt.Sprintf("user %s does not have access to create a webhook subscription for the %s: %s",
+				authUser.UserName, params.Scope, params.ScopeID)
+			log.WithFields(f).Debug(msg)
+			return webhooks.NewCreateWebhookSubscriptionForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.CreateSubscription(ctx, params.Scope, params.ScopeID, authUser.UserName, params.Body)
+		if err != nil {
+			msg := fmt.Sprintf("unable to create the webhook subscription for the %s: %s", params.Scope, params.ScopeID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return webhooks.NewCreateWebhookSubscriptionBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		eventService.LogEventWithContext(ctx, subscriptionEventArgs(authUser.UserName, events.WebhookSubscriptionCreated, result, "created"))
+
+		return webhooks.NewCreateWebhookSubscriptionOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.WebhooksGetWebhookSubscriptionHandler = webhooks.GetWebhookSubscriptionHandlerFunc(func(params webhooks.GetWebhookSubscriptionParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksGetWebhookSubscriptionHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"scope":          params.Scope,
+			"scopeID":        params.ScopeID,
+			"subscriptionID": params.SubscriptionID,
+		}
+
+		if !isUserAuthorizedForScope(ctx, authUser, params.Scope, params.ScopeID) {
+			msg := fmt.Sprintf("user %s does not have access to the webhook subscriptions of the %s: %s",
+				authUser.UserName, params.Scope, params.ScopeID)
+			log.WithFields(f).Debug(msg)
+			return webhooks.NewGetWebhookSubscriptionForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.GetSubscription(ctx, params.Scope, params.ScopeID, params.SubscriptionID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the webhook subscription: %s", params.SubscriptionID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrSubscriptionNotFound) {
+				return webhooks.NewGetWebhookSubscriptionNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return webhooks.NewGetWebhookSubscriptionBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		return webhooks.NewGetWebhookSubscriptionOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.WebhooksUpdateWebhookSubscriptionHandler = webhooks.UpdateWebhookSubscriptionHandlerFunc(func(params webhooks.UpdateWebhookSubscriptionParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksUpdateWebhookSubscriptionHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"scope":          params.Scope,
+			"scopeID":        params.ScopeID,
+			"subscriptionID": params.SubscriptionID,
+		}
+
+		if !isUserAuthorizedForScope(ctx, authUser, params.Scope, params.ScopeID) {
+			msg := fmt.Sprintf("user %s does not have access to update the webhook subscriptions of the %s: %s",
+				authUser.UserName, params.Scope, params.ScopeID)
+			log.WithFields(f).Debug(msg)
+			return webhooks.NewUpdateWebhookSubscriptionForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.UpdateSubscription(ctx, params.Scope, params.ScopeID, params.SubscriptionID, params.Body)
+		if err != nil {
+			msg := fmt.Sprintf("unable to update the webhook subscription: %s", params.SubscriptionID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrSubscriptionNotFound) {
+				return webhooks.NewUpdateWebhookSubscriptionNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return webhooks.NewUpdateWebhookSubscriptionBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		action := "updated"
+		if params.Body.RotateSecret {
+			action = "updated with a new secret"
+		}
+		eventService.LogEventWithContext(ctx, subscriptionEventArgs(authUser.UserName, events.WebhookSubscriptionUpdated, result, action))
+
+		return webhooks.NewUpdateWebhookSubscriptionOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.WebhooksDeleteWebhookSubscriptionHandler = webhooks.DeleteWebhookSubscriptionHandlerFunc(func(params webhooks.DeleteWebhookSubscriptionParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksDeleteWebhookSubscriptionHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"scope":          params.Scope,
+			"scopeID":        params.ScopeID,
+			"subscriptionID": params.SubscriptionID,
+		}
+
+		if !isUserAuthorizedForScope(ctx, authUser, params.Scope, params.ScopeID) {
+			msg := fmt.Sprintf("user %s does not have access to delete the webhook subscriptions of the %s: %s",
+				authUser.UserName, params.Scope, params.ScopeID)
+			log.WithFields(f).Debug(msg)
+			return webhooks.NewDeleteWebhookSubscriptionForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.DeleteSubscription(ctx, params.Scope, params.ScopeID, params.SubscriptionID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to delete the webhook subscription: %s", params.SubscriptionID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrSubscriptionNotFound) {
+				return webhooks.NewDeleteWebhookSubscriptionNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return webhooks.NewDeleteWebhookSubscriptionBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		eventService.LogEventWithContext(ctx, subscriptionEventArgs(authUser.UserName, events.WebhookSubscriptionDeleted, result, "deleted"))
+
+		return webhooks.NewDeleteWebhookSubscriptionNoContent().WithXRequestID(reqID)
+	})
+
+	api.WebhooksListWebhookDeliveriesHandler = webhooks.ListWebhookDeliveriesHandlerFunc(func(params webhooks.ListWebhookDeliveriesParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksListWebhookDeliveriesHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"authEmail":      authUser.Email,
+			"scope":          params.Scope,
+			"scopeID":        params.ScopeID,
+			"subscriptionID": params.SubscriptionID,
+		}
+
+		if !isUserAuthorizedForScope(ctx, authUser, params.Scope, params.ScopeID) {
+			msg := fmt.Sprintf("user %s does not have access to the webhook deliveries of the %s: %s",
+				authUser.UserName, params.Scope, params.ScopeID)
+			log.WithFields(f).Debug(msg)
+			return webhooks.NewListWebhookDeliveriesForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.ListDeliveries(ctx, params.Scope, params.ScopeID, params.SubscriptionID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to list the deliveries of the webhook subscription: %s", params.SubscriptionID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrSubscriptionNotFound) {
+				return webhooks.NewListWebhookDeliveriesNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return webhooks.NewListWebhookDeliveriesBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		return webhooks.NewListWebhookDeliveriesOK().WithXRequestID(reqID).WithPayload(result)
+	})
+}
+
+// isUserAuthorizedForScope returns true when the user manages the foundation or the company of the subscriptions
+func isUserAuthorizedForScope(ctx context.Context, authUser *auth.User, scope, scopeID string) bool {
+	if scope == ScopeCompany {
+		return utils.IsUserAuthorizedForOrganization(ctx, authUser, scopeID, utils.ALLOW_ADMIN_SCOPE)
+	}
+	return utils.IsUserAuthorizedForProjectTree(ctx, authUser, scopeID, utils.ALLOW_ADMIN_SCOPE)
+}
+
+// subscriptionEventArgs returns the event args of the foundation or the company of the subscription
+func subscriptionEventArgs(lfUsername, eventType string, subscription *models.WebhookSubscription, action string) *events.LogEventArgs {
+	args := &events.LogEventArgs{
+		LfUsername: lfUsername,
+		EventType:  eventType,
+		EventData: &events.WebhookSubscriptionEventData{
+			Scope:          subscription.Scope,
+			ScopeID:        subscription.ScopeID,
+			SubscriptionID: subscription.SubscriptionID,
+			URL:            subscription.URL,
+			EventTypes:     subscription.EventTypes,
+			Action:         action,
+		},
+	}
+	if subscription.Scope == ScopeCompany {
+		args.CompanySFID = subscription.ScopeID
+	} else {
+		args.ProjectSFID = subscription.ScopeID
+	}
+	return args
+}
@@ -0,0 +1,227 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+)
+
+// subscription scopes
+const (
+	ScopeFoundation = "foundation"
+	ScopeCompany    = "company"
+)
+
+// webhook event types
+const (
+	EventTypeSignatureSigned     = "signature.signed"
+	EventTypeApprovalListChanged = "approval_list.changed"
+	EventTypeCLAManagerAdded     = "cla_manager.added"
+	EventTypeRepositoryEnabled   = "repository.enabled"
+)
+
+// webhookEventTypes maps the event types of the events table to the webhook event types, the events of the other
+// types are not delivered
+var webhookEventTypes = map[string]string{
+	events.IndividualSignatureSigned: EventTypeSignatureSigned,
+	events.CorporateSignatureSigned:  EventTypeSignatureSigned,
+	// written by the python backend
+	"Individual signature signed": EventTypeSignatureSigned,
+	"Employee signature signed":   EventTypeSignatureSigned,
+	"Company signature signed":    EventTypeSignatureSigned,
+
+	events.ClaApprovalListUpdated:                EventTypeApprovalListChanged,
+	events.CCLAApprovalListRequestApproved:       EventTypeApprovalListChanged,
+	events.ApprovalListGitHubOrganizationAdded:   EventTypeApprovalListChanged,
+	events.ApprovalListGitHubOrganizationDeleted: EventTypeApprovalListChanged,
+
+	events.ClaManagerCreated: EventTypeCLAManagerAdded,
+
+	events.RepositoryAdded:       EventTypeRepositoryEnabled,
+	events.GerritRepositoryAdded: EventTypeRepositoryEnabled,
+}
+
+// WebhookEventType returns the webhook event type of the event type, empty when the event is not delivered
+func WebhookEventType(eventType string) string {
+	return webhookEventTypes[eventType]
+}
+
+// isValidWebhookEventType returns true for the event types a subscription can choose
+func isValidWebhookEventType(eventType string) bool {
+	switch eventType {
+	case EventTypeSignatureSigned, EventTypeApprovalListChanged, EventTypeCLAManagerAdded, EventTypeRepositoryEnabled:
+		return true
+	}
+	return false
+}
+
+// Subscription is an endpoint of a foundation or company notified of the events
+type Subscription struct {
+	SubscriptionID string `dynamodbav:"subscription_id"`
+	// ScopeKey is the scope and the scope ID, the key of the scope index
+	ScopeKey     string   `dynamodbav:"scope_key"`
+	Scope        string   `dynamodbav:"scope"`
+	ScopeID      string   `dynamodbav:"scope_id"`
+	URL          string   `dynamodbav:"url"`
+	EventTypes   []string `dynamodbav:"event_types"`
+	Secret       string   `dynamodbav:"secret"`
+	Paused       bool     `dynamodbav:"paused"`
+	CreatedBy    string   `dynamodbav:"created_by"`
+	DateCreated  string   `dynamodbav:"date_created"`
+	DateModified string   `dynamodbav:"date_modified"`
+}
+
+// subscribes returns true when the subscription is active and notified of the event type
+func (s *Subscription) subscribes(eventType string) bool {
+	if s.Paused {
+		return false
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// toModel returns the API model of the subscription, without the secret
+func (s *Subscription) toModel() *models.WebhookSubscription {
+	return &models.WebhookSubscription{
+		SubscriptionID: s.SubscriptionID,
+		Scope:          s.Scope,
+		ScopeID:        s.ScopeID,
+		URL:            s.URL,
+		EventTypes:     s.EventTypes,
+		Paused:         s.Paused,
+		CreatedBy:      s.CreatedBy,
+		DateCreated:    s.DateCreated,
+		DateModified:   s.DateModified,
+	}
+}
+
+// delivery retry statuses
+const (
+	// RetryStatusPending is the retry status of the deliveries waiting for the next attempt of the retry worker
+	RetryStatusPending = "pending"
+)
+
+// Delivery is the delivery of an event to a subscription with the result of each attempt
+type Delivery struct {
+	SubscriptionID string            `dynamodbav:"subscription_id"`
+	DeliveryID     string            `dynamodbav:"delivery_id"`
+	EventID        string            `dynamodbav:"event_id"`
+	EventType      string            `dynamodbav:"event_type"`
+	Success        bool              `dynamodbav:"success"`
+	StatusCode     int               `dynamodbav:"status_code"`
+	Attempts       []DeliveryAttempt `dynamodbav:"attempts"`
+	DateCreated    string            `dynamodbav:"date_created"`
+	// Expire is the epoch time the delivery log is deleted by the table TTL
+	Expire int64 `dynamodbav:"expire"`
+
+	// RetryStatus is pending while the delivery waits for another attempt, it is removed once the delivery succeeds
+	// or the attempts are exhausted so the retry index only holds the pending deliveries
+	RetryStatus string `dynamodbav:"retry_status,omitempty"`
+	// NextAttemptAt is the epoch time of the next attempt of a pending delivery
+	NextAttemptAt int64 `dynamodbav:"next_attempt_at,omitempty"`
+	// Body is the JSON payload posted to the endpoint, kept while the delivery is pending
+	Body string `dynamodbav:"body,omitempty"`
+}
+
+// pending returns true when the delivery waits for another attempt
+func (d *Delivery) pending() bool {
+	return d.RetryStatus == RetryStatusPending
+}
+
+// stopRetrying removes the delivery from the retry index and drops the payload
+func (d *Delivery) stopRetrying() {
+	d.RetryStatus = ""
+	d.NextAttemptAt = 0
+	d.Body = ""
+}
+
+// DeliveryAttempt is the result of one attempt to deliver an event
+type DeliveryAttempt struct {
+	Attempt     int    `dynamodbav:"attempt"`
+	StatusCode  int    `dynamodbav:"status_code"`
+	Error       string `dynamodbav:"error"`
+	Duration    string `dynamodbav:"duration"`
+	DateCreated string `dynamodbav:"date_created"`
+}
+
+// toModel returns the API model of the delivery
+func (d *Delivery) toModel() *models.WebhookDelivery {
+	attempts := make([]*models.WebhookDeliveryAttempt, 0, len(d.Attempts))
+	for _, attempt := range d.Attempts {
+		attempts = append(attempts, &models.WebhookDeliveryAttempt{
+			Attempt:     int64(attempt.Attempt),
+			StatusCode:  int64(attempt.StatusCode),
+			Error:       attempt.Error,
+			Duration:    attempt.Duration,
+			DateCreated: attempt.DateCreated,
+		})
+	}
+	delivery := &models.WebhookDelivery{
+		DeliveryID:     d.DeliveryID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Success:        d.Success,
+		Pending:        d.pending(),
+		StatusCode:     int64(d.StatusCode),
+		Attempts:       attempts,
+		DateCreated:    d.DateCreated,
+	}
+	if d.pending() {
+		delivery.NextAttempt = time.Unix(d.NextAttemptAt, 0).UTC().Format(time.RFC3339)
+	}
+	return delivery
+}
+
+// Event is an event of the events table to deliver, with the foundation and company it belongs to
+type Event struct {
+	// RecordID is the ID of the stream record of the event, the delivery IDs are derived from it so a replayed
+	// record isn't delivered twice
+	RecordID string
+	// RecordTime is the creation time of the stream record
+	RecordTime     time.Time
+	EventID        string
+	EventType      string
+	EventTime      string
+	EventSummary   string
+	EventData      string
+	FoundationSFID string
+	ProjectSFID    string
+	ProjectName    string
+	CLAGroupID     string
+	CLAGroupName   string
+	CompanyID      string
+	CompanySFID    string
+	CompanyName    string
+	UserName       string
+	LfUsername     string
+}
+
+// Payload is the JSON body posted to the webhook endpoints
+type Payload struct {
+	DeliveryID       string `json:"deliveryID"`
+	EventType        string `json:"eventType"`
+	EventID          string `json:"eventID"`
+	EasyCLAEventType string `json:"easyclaEventType"`
+	EventTime        string `json:"eventTime,omitempty"`
+	Summary          string `json:"summary,omitempty"`
+	Data             string `json:"data,omitempty"`
+	FoundationSFID   string `json:"foundationSFID,omitempty"`
+	ProjectSFID      string `json:"projectSFID,omitempty"`
+	ProjectName      string `json:"projectName,omitempty"`
+	CLAGroupID       string `json:"claGroupID,omitempty"`
+	CLAGroupName     string `json:"claGroupName,omitempty"`
+	CompanyID        string `json:"companyID,omitempty"`
+	CompanySFID      string `json:"companySFID,omitempty"`
+	CompanyName      string `json:"companyName,omitempty"`
+	UserName         string `json:"userName,omitempty"`
+	LfUsername       string `json:"lfUsername,omitempty"`
+}
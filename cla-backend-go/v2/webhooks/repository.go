@@ -0,0 +1,421 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrSubscriptionNotFound is returned when the webhook subscription does not exist
+var ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+
+// ErrDeliveryExists is returned when the delivery was already added, the stream record was replayed
+var ErrDeliveryExists = errors.New("webhook delivery already exists")
+
+// scopeIndex is the index of the subscriptions table by scope and scope ID
+const scopeIndex = "webhook-subscriptions-scope-key-index"
+
+// retryIndex is the index of the deliveries table by retry status and next attempt time, only the pending deliveries
+// have the retry status - hash key retry_status, range key next_attempt_at
+const retryIndex = "webhook-deliveries-retry-status-index"
+
+// Repository stores the webhook subscriptions and their delivery logs
+type Repository interface {
+	CreateSubscription(ctx context.Context, subscription *Subscription) error
+	GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error)
+	ListSubscriptions(ctx context.Context, scope, scopeID string) ([]*Subscription, error)
+	UpdateSubscription(ctx context.Context, subscription *Subscription) error
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+
+	// AddDelivery saves the new delivery, ErrDeliveryExists is returned when it was already added
+	AddDelivery(ctx context.Context, delivery *Delivery) error
+	// UpdateDelivery replaces the delivery with the result of its last attempt
+	UpdateDelivery(ctx context.Context, delivery *Delivery) error
+	// ListPendingDeliveries returns the pending deliveries due for an attempt at the epoch time
+	ListPendingDeliveries(ctx context.Context, dueAt int64, limit int64) ([]*Delivery, error)
+	// ClaimDelivery moves the next attempt of the pending delivery from the expected time to the lease time, it
+	// returns false when another worker claimed the delivery first
+	ClaimDelivery(ctx context.Context, delivery *Delivery, leaseUntil int64) (bool, error)
+	// ListDeliveries returns the recent deliveries of the subscription, newest first
+	ListDeliveries(ctx context.Context, subscriptionID string, limit int64) ([]*Delivery, error)
+}
+
+type repository struct {
+	dynamoDBClient         *dynamodb.DynamoDB
+	subscriptionsTableName string
+	deliveriesTableName    string
+}
+
+// NewRepository creates the repository of the webhook subscriptions
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		dynamoDBClient:         dynamodb.New(awsSession),
+		subscriptionsTableName: fmt.Sprintf("cla-%s-webhook-subscriptions", stage),
+		deliveriesTableName:    fmt.Sprintf("cla-%s-webhook-deliveries", stage),
+	}
+}
+
+// scopeKey returns the scope index key of the scope and scope ID
+func scopeKey(scope, scopeID string) string {
+	return fmt.Sprintf("%s#%s", scope, scopeID)
+}
+
+// CreateSubscription saves the new subscription
+func (repo *repository) CreateSubscription(ctx context.Context, subscription *Subscription) error {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.CreateSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscription.SubscriptionID,
+		"scope":          subscription.Scope,
+		"scopeID":        subscription.ScopeID,
+	}
+
+	subscription.ScopeKey = scopeKey(subscription.Scope, subscription.ScopeID)
+	av, err := dynamodbattribute.MarshalMap(subscription)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal the webhook subscription")
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.subscriptionsTableName),
+		ConditionExpression: aws.String("attribute_not_exists(subscription_id)"),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save the webhook subscription")
+		return err
+	}
+
+	return nil
+}
+
+// GetSubscription returns the subscription
+func (repo *repository) GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.GetSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscriptionID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.subscriptionsTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"subscription_id": {S: aws.String(subscriptionID)},
+		},
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the webhook subscription")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrSubscriptionNotFound
+	}
+
+	var subscription Subscription
+	err = dynamodbattribute.UnmarshalMap(result.Item, &subscription)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to unmarshal the webhook subscription")
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// ListSubscriptions returns the subscriptions of the foundation or company
+func (repo *repository) ListSubscriptions(ctx context.Context, scope, scopeID string) ([]*Subscription, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.ListSubscriptions",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scope":          scope,
+		"scopeID":        scopeID,
+	}
+
+	condition := expression.Key("scope_key").Equal(expression.Value(scopeKey(scope, scopeID)))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to build the query expression")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 aws.String(repo.subscriptionsTableName),
+		IndexName:                 aws.String(scopeIndex),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+
+	var subscriptions []*Subscription
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to query the webhook subscriptions")
+			return nil, err
+		}
+
+		var page []*Subscription
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to unmarshal the webhook subscriptions")
+			return nil, err
+		}
+		subscriptions = append(subscriptions, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return subscriptions, nil
+}
+
+// UpdateSubscription replaces the existing subscription
+func (repo *repository) UpdateSubscription(ctx context.Context, subscription *Subscription) error {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.UpdateSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscription.SubscriptionID,
+	}
+
+	subscription.ScopeKey = scopeKey(subscription.Scope, subscription.ScopeID)
+	av, err := dynamodbattribute.MarshalMap(subscription)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal the webhook subscription")
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.subscriptionsTableName),
+		ConditionExpression: aws.String("attribute_exists(subscription_id)"),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrSubscriptionNotFound
+		}
+		log.WithFields(f).WithError(err).Warn("unable to update the webhook subscription")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSubscription deletes the subscription, the delivery logs expire with the table TTL
+func (repo *repository) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(repo.subscriptionsTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"subscription_id": {S: aws.String(subscriptionID)},
+		},
+	})
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"functionName":   "v2.webhooks.repository.DeleteSubscription",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"subscriptionID": subscriptionID,
+		}).WithError(err).Warn("unable to delete the webhook subscription")
+		return err
+	}
+	return nil
+}
+
+// AddDelivery saves the new delivery log, ErrDeliveryExists is returned when the delivery was already added
+func (repo *repository) AddDelivery(ctx context.Context, delivery *Delivery) error {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.AddDelivery",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": delivery.SubscriptionID,
+		"deliveryID":     delivery.DeliveryID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(delivery)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal the webhook delivery")
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.deliveriesTableName),
+		ConditionExpression: aws.String("attribute_not_exists(delivery_id)"),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrDeliveryExists
+		}
+		log.WithFields(f).WithError(err).Warn("unable to save the webhook delivery")
+		return err
+	}
+
+	return nil
+}
+
+// UpdateDelivery replaces the delivery log with the result of its last attempt
+func (repo *repository) UpdateDelivery(ctx context.Context, delivery *Delivery) error {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.UpdateDelivery",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": delivery.SubscriptionID,
+		"deliveryID":     delivery.DeliveryID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(delivery)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal the webhook delivery")
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.deliveriesTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update the webhook delivery")
+		return err
+	}
+
+	return nil
+}
+
+// ListPendingDeliveries returns the pending deliveries with a next attempt at or before the epoch time, oldest first
+func (repo *repository) ListPendingDeliveries(ctx context.Context, dueAt int64, limit int64) ([]*Delivery, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.ListPendingDeliveries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"dueAt":          dueAt,
+	}
+
+	condition := expression.Key("retry_status").Equal(expression.Value(RetryStatusPending)).
+		And(expression.Key("next_attempt_at").LessThanEqual(expression.Value(dueAt)))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to build the query expression")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 aws.String(repo.deliveriesTableName),
+		IndexName:                 aws.String(retryIndex),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Limit:                     aws.Int64(limit),
+	}
+
+	var deliveries []*Delivery
+	for int64(len(deliveries)) < limit {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to query the pending webhook deliveries")
+			return nil, err
+		}
+
+		var page []*Delivery
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to unmarshal the pending webhook deliveries")
+			return nil, err
+		}
+		deliveries = append(deliveries, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	if int64(len(deliveries)) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDelivery moves the next attempt of the pending delivery to the lease time when it wasn't changed since the
+// delivery was loaded, it returns false when another worker claimed the delivery first
+func (repo *repository) ClaimDelivery(ctx context.Context, delivery *Delivery, leaseUntil int64) (bool, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.ClaimDelivery",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": delivery.SubscriptionID,
+		"deliveryID":     delivery.DeliveryID,
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.deliveriesTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"subscription_id": {S: aws.String(delivery.SubscriptionID)},
+			"delivery_id":     {S: aws.String(delivery.DeliveryID)},
+		},
+		ConditionExpression: aws.String("retry_status = :pending AND next_attempt_at = :expected"),
+		UpdateExpression:    aws.String("SET next_attempt_at = :lease"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":pending":  {S: aws.String(RetryStatusPending)},
+			":expected": {N: aws.String(strconv.FormatInt(delivery.NextAttemptAt, 10))},
+			":lease":    {N: aws.String(strconv.FormatInt(leaseUntil, 10))},
+		},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return false, nil
+		}
+		log.WithFields(f).WithError(err).Warn("unable to claim the webhook delivery")
+		return false, err
+	}
+
+	delivery.NextAttemptAt = leaseUntil
+	return true, nil
+}
+
+// ListDeliveries returns the recent deliveries of the subscription, newest first
+func (repo *repository) ListDeliveries(ctx context.Context, subscriptionID string, limit int64) ([]*Delivery, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.ListDeliveries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscriptionID,
+	}
+
+	condition := expression.Key("subscription_id").Equal(expression.Value(subscriptionID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to build the query expression")
+		return nil, err
+	}
+
+	results, err := repo.dynamoDBClient.Query(&dynamodb.QueryInput{
+		TableName:                 aws.String(repo.deliveriesTableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ScanIndexForward:          aws.Bool(false),
+		Limit:                     aws.Int64(limit),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to query the webhook deliveries")
+		return nil, err
+	}
+
+	var deliveries []*Delivery
+	err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &deliveries)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to unmarshal the webhook deliveries")
+		return nil, err
+	}
+
+	return deliveries, nil
+}
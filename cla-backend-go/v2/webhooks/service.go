@@ -0,0 +1,247 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// maxSubscriptionsPerScope limits the number of endpoints a foundation or company can register
+const maxSubscriptionsPerScope = 10
+
+// deliveriesPageSize is the number of recent deliveries returned for a subscription
+const deliveriesPageSize = 50
+
+// ErrInvalidSubscription is returned when the subscription input is not valid
+var ErrInvalidSubscription = errors.New("invalid webhook subscription")
+
+// Service manages the webhook subscriptions and delivers the events to them
+type Service interface {
+	ListSubscriptions(ctx context.Context, scope, scopeID string) (*models.WebhookSubscriptionList, error)
+	CreateSubscription(ctx context.Context, scope, scopeID, createdBy string, input *models.WebhookSubscriptionInput) (*models.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, scope, scopeID, subscriptionID string) (*models.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, scope, scopeID, subscriptionID string, input *models.WebhookSubscriptionInput) (*models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, scope, scopeID, subscriptionID string) (*models.WebhookSubscription, error)
+	ListDeliveries(ctx context.Context, scope, scopeID, subscriptionID string) (*models.WebhookDeliveryList, error)
+
+	// DispatchEvent delivers the event of the events table to the subscriptions of its foundation and company
+	DispatchEvent(ctx context.Context, event *Event) error
+	// RetryPendingDeliveries attempts the failed deliveries which are due for a retry
+	RetryPendingDeliveries(ctx context.Context, limit int64) (*RetryResult, error)
+}
+
+type service struct {
+	repo       Repository
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewService creates the webhook subscriptions service
+func NewService(repo Repository) Service {
+	return &service{
+		repo:       repo,
+		httpClient: newDeliveryClient(),
+		now:        time.Now,
+	}
+}
+
+// ListSubscriptions returns the subscriptions of the foundation or company, the secrets are not returned
+func (s *service) ListSubscriptions(ctx context.Context, scope, scopeID string) (*models.WebhookSubscriptionList, error) {
+	subscriptions, err := s.repo.ListSubscriptions(ctx, scope, scopeID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].DateCreated < subscriptions[j].DateCreated
+	})
+
+	result := &models.WebhookSubscriptionList{Subscriptions: make([]*models.WebhookSubscription, 0, len(subscriptions))}
+	for _, subscription := range subscriptions {
+		result.Subscriptions = append(result.Subscriptions, subscription.toModel())
+	}
+	return result, nil
+}
+
+// CreateSubscription registers the endpoint, the response is the only one with the generated secret
+func (s *service) CreateSubscription(ctx context.Context, scope, scopeID, createdBy string, input *models.WebhookSubscriptionInput) (*models.WebhookSubscription, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.service.CreateSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scope":          scope,
+		"scopeID":        scopeID,
+	}
+
+	if err := validateSubscriptionInput(input); err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.ListSubscriptions(ctx, scope, scopeID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxSubscriptionsPerScope {
+		return nil, fmt.Errorf("%w: the %s already has %d webhook subscriptions", ErrInvalidSubscription, scope, maxSubscriptionsPerScope)
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate the webhook secret")
+		return nil, err
+	}
+	now := s.now().UTC().Format(time.RFC3339)
+	subscription := &Subscription{
+		SubscriptionID: newID(),
+		Scope:          scope,
+		ScopeID:        scopeID,
+		URL:            *input.URL,
+		EventTypes:     uniqueEventTypes(input.EventTypes),
+		Secret:         secret,
+		Paused:         input.Paused,
+		CreatedBy:      createdBy,
+		DateCreated:    now,
+		DateModified:   now,
+	}
+	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	result := subscription.toModel()
+	result.Secret = subscription.Secret
+	return result, nil
+}
+
+// GetSubscription returns the subscription of the foundation or company, the secret is not returned
+func (s *service) GetSubscription(ctx context.Context, scope, scopeID, subscriptionID string) (*models.WebhookSubscription, error) {
+	subscription, err := s.getScopeSubscription(ctx, scope, scopeID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return subscription.toModel(), nil
+}
+
+// UpdateSubscription updates the endpoint, the event types and the paused flag of the subscription, the secret is
+// returned when it is rotated
+func (s *service) UpdateSubscription(ctx context.Context, scope, scopeID, subscriptionID string, input *models.WebhookSubscriptionInput) (*models.WebhookSubscription, error) {
+	if err := validateSubscriptionInput(input); err != nil {
+		return nil, err
+	}
+	subscription, err := s.getScopeSubscription(ctx, scope, scopeID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.URL = *input.URL
+	subscription.EventTypes = uniqueEventTypes(input.EventTypes)
+	subscription.Paused = input.Paused
+	if input.RotateSecret {
+		subscription.Secret, err = newSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+	subscription.DateModified = s.now().UTC().Format(time.RFC3339)
+	if err := s.repo.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	result := subscription.toModel()
+	if input.RotateSecret {
+		result.Secret = subscription.Secret
+	}
+	return result, nil
+}
+
+// DeleteSubscription deletes the subscription of the foundation or company, the deleted subscription is returned
+// for the event log
+func (s *service) DeleteSubscription(ctx context.Context, scope, scopeID, subscriptionID string) (*models.WebhookSubscription, error) {
+	subscription, err := s.getScopeSubscription(ctx, scope, scopeID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.DeleteSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return subscription.toModel(), nil
+}
+
+// ListDeliveries returns the recent deliveries of the subscription, newest first
+func (s *service) ListDeliveries(ctx context.Context, scope, scopeID, subscriptionID string) (*models.WebhookDeliveryList, error) {
+	if _, err := s.getScopeSubscription(ctx, scope, scopeID, subscriptionID); err != nil {
+		return nil, err
+	}
+	deliveries, err := s.repo.ListDeliveries(ctx, subscriptionID, deliveriesPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.WebhookDeliveryList{Deliveries: make([]*models.WebhookDelivery, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		result.Deliveries = append(result.Deliveries, delivery.toModel())
+	}
+	return result, nil
+}
+
+// getScopeSubscription returns the subscription, not found when it belongs to another foundation or company
+func (s *service) getScopeSubscription(ctx context.Context, scope, scopeID, subscriptionID string) (*Subscription, error) {
+	subscription, err := s.repo.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Scope != scope || subscription.ScopeID != scopeID {
+		return nil, ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// validateSubscriptionInput checks the endpoint URL and the event types
+func validateSubscriptionInput(input *models.WebhookSubscriptionInput) error {
+	if input == nil || input.URL == nil {
+		return fmt.Errorf("%w: the webhook URL is required", ErrInvalidSubscription)
+	}
+	if err := validateEndpointURL(*input.URL); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSubscription, err)
+	}
+	if len(input.EventTypes) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", ErrInvalidSubscription)
+	}
+	for _, eventType := range input.EventTypes {
+		if !isValidWebhookEventType(eventType) {
+			return fmt.Errorf("%w: unknown event type: %s", ErrInvalidSubscription, eventType)
+		}
+	}
+	return nil
+}
+
+// uniqueEventTypes returns the event types without the duplicates
+func uniqueEventTypes(eventTypes []string) []string {
+	seen := map[string]bool{}
+	var result []string
+	for _, eventType := range eventTypes {
+		if !seen[eventType] {
+			seen[eventType] = true
+			result = append(result, eventType)
+		}
+	}
+	return result
+}
+
+// newSecret returns a new random HMAC secret
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
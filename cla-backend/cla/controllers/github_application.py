@@ -11,6 +11,26 @@ from requests.exceptions import RequestException
 
 import cla
 
+PULL_REQUEST_COMMITS_QUERY = """
+query($owner: String!, $name: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      commits(first: 100, after: $cursor) {
+        nodes {
+          commit {
+            oid
+            message
+            author { name email user { databaseId login name email } }
+            committer { name email user { databaseId login name email } }
+          }
+        }
+        pageInfo { endCursor hasNextPage }
+      }
+    }
+  }
+}
+"""
+
 
 class GitHubInstallation(object):
 
@@ -75,6 +95,79 @@ class GitHubInstallation(object):
         except RequestException as err:
             cla.log.debug(err)
 
+    def get_check_run(self, repository_name, sha, check_name):
+        """
+        Function that returns the check run with the given name created by this app on the commit, None if
+        the commit has none. A failed lookup raises, so a second check run isn't created next to the existing one.
+        """
+        try:
+            url = 'https://api.github.com/repos/{}/commits/{}/check-runs'.format(repository_name, sha)
+            response = requests.get(
+                url,
+                params={'check_name': check_name},
+                headers={
+                    'Authorization': 'token %s' % self.token,
+                    'Accept': 'application/vnd.github+json'
+                }
+            )
+            response.raise_for_status()
+        except RequestException as err:
+            cla.log.warning('unable to list the check runs of commit: {} in repository: {}, error: {}'
+                            .format(sha, repository_name, err))
+            raise err
+        for check_run in response.json().get('check_runs', []):
+            if str((check_run.get('app') or {}).get('id')) == str(self.app_id):
+                return check_run
+        return None
+
+    def update_check_run(self, repository_name, check_run_id, data):
+        """
+        Function that updates a check run of this app
+        """
+        try:
+            url = 'https://api.github.com/repos/{}/check-runs/{}'.format(repository_name, check_run_id)
+            requests.patch(
+                url,
+                data=data,
+                headers={
+                    'Content-Type': 'application/json',
+                    'Authorization': 'token %s' % self.token,
+                    'Accept': 'application/vnd.github+json'
+                }
+            )
+
+        except RequestException as err:
+            cla.log.debug(err)
+
+    def get_pull_request_commits(self, owner, name, number):
+        """
+        Function that returns every commit of the pull request with the GraphQL API - the REST API returns at
+        most 250 commits for a pull request. The commits are the GraphQL commit nodes, the oldest first.
+        """
+        commits = []
+        cursor = None
+        while True:
+            response = requests.post(
+                'https://api.github.com/graphql',
+                json={
+                    'query': PULL_REQUEST_COMMITS_QUERY,
+                    'variables': {'owner': owner, 'name': name, 'number': number, 'cursor': cursor},
+                },
+                headers={
+                    'Authorization': 'bearer %s' % self.token,
+                    'Accept': 'application/vnd.github+json'
+                }
+            )
+            response.raise_for_status()
+            result = response.json()
+            if result.get('errors'):
+                raise GithubException(response.status_code, result['errors'])
+            pull_request_commits = result['data']['repository']['pullRequest']['commits']
+            commits.extend(node['commit'] for node in pull_request_commits['nodes'])
+            if not pull_request_commits['pageInfo']['hasNextPage']:
+                return commits
+            cursor = pull_request_commits['pageInfo']['endCursor']
+
 
 class GithubCLAIntegration(GithubIntegration):
     """
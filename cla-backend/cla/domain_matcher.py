@@ -0,0 +1,124 @@
+# Copyright The Linux Foundation and each contributor to CommunityBridge.
+# SPDX-License-Identifier: MIT
+
+"""
+domain_matcher.py matches user emails against domain approval list entries, using the same rules as the go
+domain_matcher package so both backends approve the same users.
+"""
+
+from typing import Optional
+
+import idna
+from publicsuffixlist import PublicSuffixList
+
+# Match kinds of a domain approval list pattern
+# MATCH_EXACT matches the domain only, e.g. "example.com"
+MATCH_EXACT = "exact"
+# MATCH_SUBDOMAINS matches the subdomains of the domain, but not the domain itself, e.g. "+.example.com"
+MATCH_SUBDOMAINS = "subdomains"
+# MATCH_DOMAIN_AND_SUBDOMAINS matches the domain and its subdomains, e.g. "*.example.com", ".example.com" or
+# "*example.com"
+MATCH_DOMAIN_AND_SUBDOMAINS = "domain_and_subdomains"
+
+_public_suffix_list = None
+
+
+class DomainPatternError(ValueError):
+    """Raised for domain approval list entries which can't be used for matching"""
+
+
+class PublicSuffixError(DomainPatternError):
+    """
+    Raised for patterns covering a whole public suffix, e.g. "com" or "*.co.uk", which would approve anyone able to
+    register a domain under it
+    """
+
+
+class Pattern:
+    """Parsed domain approval list entry"""
+
+    def __init__(self, entry: str, domain: str, kind: str):
+        # entry is the approval list entry as stored
+        self.entry = entry
+        # domain is the normalized ASCII domain of the entry, without the wildcard prefix
+        self.domain = domain
+        self.kind = kind
+
+    def matches(self, domain: str) -> bool:
+        """Returns True if the normalized domain matches the pattern"""
+        if self.kind == MATCH_SUBDOMAINS:
+            return domain.endswith("." + self.domain)
+        if self.kind == MATCH_DOMAIN_AND_SUBDOMAINS:
+            return domain == self.domain or domain.endswith("." + self.domain)
+        return domain == self.domain
+
+    def __str__(self):
+        return f"{self.entry} ({self.kind}: {self.domain})"
+
+
+def get_public_suffix_list() -> PublicSuffixList:
+    """Returns the public suffix list, loading it on first use"""
+    global _public_suffix_list
+    if _public_suffix_list is None:
+        _public_suffix_list = PublicSuffixList()
+    return _public_suffix_list
+
+
+def normalize_domain(domain: str) -> str:
+    """
+    Returns the lower case ASCII form of the domain, converting internationalized names to punycode
+
+    :raises DomainPatternError: if the domain isn't a valid domain name
+    """
+    domain = domain.strip().lower()
+    if domain.endswith("."):
+        domain = domain[:-1]
+    if not domain:
+        raise DomainPatternError("domain is empty")
+    try:
+        return idna.encode(domain, uts46=True, std3_rules=True).decode("ascii")
+    except idna.IDNAError as err:
+        raise DomainPatternError(f"invalid domain {domain!r}: {err}") from err
+
+
+def parse_pattern(entry: str) -> Pattern:
+    """
+    Parses a domain approval list entry. The entry is one of:
+      - "example.com" which matches the domain only
+      - "*.example.com", ".example.com" or "*example.com" which match example.com and its subdomains
+      - "+.example.com" which matches the subdomains of example.com, but not example.com itself
+
+    Internationalized domain names are accepted in both unicode and punycode form.
+
+    :raises DomainPatternError: if the entry is invalid, PublicSuffixError if it covers a public suffix
+    """
+    value = entry.strip()
+    kind = MATCH_EXACT
+    if value.startswith("+."):
+        kind, value = MATCH_SUBDOMAINS, value[2:]
+    elif value.startswith("*."):
+        kind, value = MATCH_DOMAIN_AND_SUBDOMAINS, value[2:]
+    elif value.startswith("."):
+        kind, value = MATCH_DOMAIN_AND_SUBDOMAINS, value[1:]
+    elif value.startswith("*"):
+        kind, value = MATCH_DOMAIN_AND_SUBDOMAINS, value[1:]
+    if "*" in value:
+        raise DomainPatternError(f"invalid domain pattern {entry!r}: a wildcard is only allowed as the leading label")
+
+    domain = normalize_domain(value)
+    if get_public_suffix_list().publicsuffix(domain) == domain:
+        raise PublicSuffixError(f"invalid domain pattern {entry!r}: domain is a public suffix")
+
+    return Pattern(entry, domain, kind)
+
+
+def email_domain(email: str) -> Optional[str]:
+    """Returns the normalized domain of the email address, or None if it doesn't have a valid domain"""
+    email = email.strip()
+    at = email.rfind("@")
+    if at <= 0 or at == len(email) - 1:
+        return None
+    try:
+        return normalize_domain(email[at + 1:])
+    except DomainPatternError:
+        return None
@@ -0,0 +1,107 @@
+# Copyright The Linux Foundation and each contributor to CommunityBridge.
+# SPDX-License-Identifier: MIT
+
+"""
+github_membership.py checks the GitHub organization membership of users for the approval lists. The results are
+cached in the table shared with the go backend, which also removes them when the GitHub organization and membership
+webhooks are received.
+"""
+
+import time
+from typing import List
+
+import requests
+
+import cla
+from cla.models.dynamo_models import GitHubMembershipCacheModel
+
+# TTL is how long a positive membership result is kept, in seconds
+TTL = 15 * 60
+# NEGATIVE_TTL is how long a "not a member" result is kept, in seconds - shorter than TTL, so new members are picked
+# up quickly even if the membership webhook is missed
+NEGATIVE_TTL = 5 * 60
+
+
+class GitHubMembershipUnknown(Exception):
+    """
+    Raised when the membership couldn't be determined, e.g. GitHub is unavailable or rate limiting us - callers should
+    treat the membership as unknown rather than as not a member
+    """
+
+
+def lookup_organization_member(organization: str, username: str) -> bool:
+    """
+    Queries GitHub for the membership of the user in the organization
+
+    :raises requests.exceptions.RequestException: if GitHub can't be queried
+    """
+    headers = {
+        "Authorization": "Bearer {}".format(cla.conf["GITHUB_OAUTH_TOKEN"]),
+        "Accept": "application/json",
+    }
+    r = requests.get(f"https://api.github.com/orgs/{organization}/members/{username}", headers=headers, timeout=10)
+    if r.status_code == 204:
+        return True
+    if r.status_code == 404:
+        return False
+    r.raise_for_status()
+    raise requests.exceptions.HTTPError(f"unexpected status code: {r.status_code}", response=r)
+
+
+def is_organization_member(organization: str, username: str) -> bool:
+    """
+    Returns True if the user is a member of the GitHub organization, using the cached result if it hasn't expired.
+    Lookup errors are never cached and a cache failure only skips the cache.
+
+    :raises GitHubMembershipUnknown: if GitHub can't be queried
+    """
+    fn = "github_membership.is_organization_member"
+    # GitHub logins and organization names are case-insensitive
+    organization_key, username_key = organization.strip().lower(), username.strip().lower()
+    now = int(time.time())
+
+    try:
+        entry = GitHubMembershipCacheModel.get(organization_key, username_key)
+        if entry.expires is not None and now < entry.expires:
+            cla.log.debug(f"{fn} - using cached membership of {username} in {organization}: {entry.is_member}")
+            return entry.is_member
+    except GitHubMembershipCacheModel.DoesNotExist:
+        pass
+    except Exception as err:
+        cla.log.warning(f"{fn} - unable to load the cached membership of {username} in {organization}: {err}")
+
+    try:
+        is_member = lookup_organization_member(organization.strip(), username.strip())
+    except requests.exceptions.RequestException as err:
+        cla.log.warning(f"{fn} - unable to query the membership of {username} in {organization}: {err}")
+        raise GitHubMembershipUnknown(f"unable to query the membership of {username} in {organization}: {err}") from err
+
+    try:
+        GitHubMembershipCacheModel(
+            organization=organization_key,
+            username=username_key,
+            is_member=is_member,
+            expires=now + (TTL if is_member else NEGATIVE_TTL),
+        ).save()
+    except Exception as err:
+        cla.log.warning(f"{fn} - unable to cache the membership of {username} in {organization}: {err}")
+    return is_member
+
+
+def is_member_of_any_organization(organizations: List[str], username: str) -> bool:
+    """
+    Returns True if the user is a member of one of the GitHub organizations - a failed lookup doesn't hide a match in
+    another organization
+
+    :raises GitHubMembershipUnknown: if none matched and the membership of one of the organizations is unknown
+    """
+    unknown = None
+    for organization in organizations:
+        try:
+            if is_organization_member(organization, username):
+                return True
+        except GitHubMembershipUnknown as err:
+            unknown = err
+    if unknown is not None:
+        raise unknown
+    return False
@@ -1523,9 +1523,9 @@ class DocuSign(signing_service_interface.SigningService):
             cla.log.debug(f'populate_sign_url - {sig_type} - setting signature sign_url as: {sign_url}')
             signature.set_signature_sign_url(sign_url)
 
-        # Save Envelope ID in signature.
+        # Save Envelope ID in signature, the envelope reconciliation job tracks the envelope from now on.
         cla.log.debug(f'{fn} - {sig_type} - saving signature to database...')
-        signature.set_signature_envelope_id(envelope.envelopeId)
+        signature.set_signature_envelope_sent(envelope.envelopeId)
         signature.save()
         cla.log.debug(f'{fn} - {sig_type} - saved signature to database - id: {signature.get_signature_id()}...')
         cla.log.debug(f'populate_sign_url - {sig_type} - complete')
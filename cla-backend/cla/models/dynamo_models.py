@@ -32,6 +32,7 @@ from pynamodb.indexes import GlobalSecondaryIndex, AllProjection
 from pynamodb.models import Model
 
 import cla
+from cla import domain_matcher
 from cla.models import model_interfaces, key_value_store_interface, DoesNotExist
 from cla.models.event_types import EventType
 from cla.models.model_interfaces import User, Signature, ProjectCLAGroup, Repository, Gerrit
@@ -1164,6 +1165,19 @@ class Document(model_interfaces.Document):
         self.add_document_tab(tab)
 
 
+class ResignPolicyModel(MapAttribute):
+    """
+    Represents the CLA Group document version requiring re-signature, set by the go backend.
+    """
+
+    document_major_version = NumberAttribute()
+    document_minor_version = NumberAttribute()
+    enforcement = UnicodeAttribute()
+    grace_period_ends = UnicodeAttribute(null=True)
+    date_created = UnicodeAttribute(null=True)
+    created_by = UnicodeAttribute(null=True)
+
+
 class ProjectModel(BaseModel):
     """
     Represents a project in the database.
@@ -1190,6 +1204,8 @@ class ProjectModel(BaseModel):
     foundation_sfid = UnicodeAttribute(null=True)
     root_project_repositories_count = NumberAttribute(null=True)
     note = UnicodeAttribute(null=True)
+    project_individual_resign_policy = ResignPolicyModel(null=True)
+    project_corporate_resign_policy = ResignPolicyModel(null=True)
     # Indexes
     project_external_id_index = ExternalProjectIndex()
     project_name_search_index = ProjectNameIndex()
@@ -1426,6 +1442,12 @@ class Project(model_interfaces.Project):  # pylint: disable=too-many-public-meth
     def get_project_ccla_requires_icla_signature(self):
         return self.model.project_ccla_requires_icla_signature
 
+    def get_project_individual_resign_policy(self) -> Optional[ResignPolicyModel]:
+        return self.model.project_individual_resign_policy
+
+    def get_project_corporate_resign_policy(self) -> Optional[ResignPolicyModel]:
+        return self.model.project_corporate_resign_policy
+
     def get_project_latest_major_version(self):
         pass
         # @todo: Loop through documents for this project, return the highest version of them all.
@@ -2016,19 +2038,45 @@ class User(model_interfaces.User):  # pylint: disable=too-many-public-methods
         :rtype: bool
         """
         fn = 'dynamo_models.preprocess_pattern'
+        parsed = []
         for pattern in patterns:
-            if pattern.startswith("*."):
-                pattern = pattern.replace("*.", ".*")
-            elif pattern.startswith("*"):
-                pattern = pattern.replace("*", ".*")
-            elif pattern.startswith("."):
-                pattern = pattern.replace(".", ".*")
-
-            preprocessed_pattern = "^.*@" + pattern + "$"
-            pat = re.compile(preprocessed_pattern)
+            try:
+                parsed.append(domain_matcher.parse_pattern(pattern))
+            except domain_matcher.DomainPatternError as err:
+                cla.log.warning(f'{fn} - skipping domain approval list entry: {err}')
+
+        for email in emails:
+            domain = domain_matcher.email_domain(email)
+            if domain is None:
+                continue
+            for pattern in parsed:
+                if pattern.matches(domain):
+                    self.log_debug(f'{fn} - found user email in email approval pattern: {pattern}')
+                    return True
+        return False
+
+    def preprocess_email_regex(self, emails, expressions) -> bool:
+        """
+        Helper function that matches the given emails against the email regex approval list, each expression must
+        match the whole email address, case-insensitive - the invalid expressions are skipped
+
+        :param emails: User emails to be checked
+        :type emails: list
+        :param expressions: The email regex approval list entries
+        :type expressions: list
+        :return: True if at least one email matches one of the expressions else False
+        :rtype: bool
+        """
+        fn = 'dynamo_models.preprocess_email_regex'
+        for expression in expressions:
+            try:
+                pat = re.compile(f'^(?:{expression.strip()})$', re.IGNORECASE)
+            except re.error as err:
+                cla.log.warning(f'{fn} - skipping invalid email regex approval list entry: {expression}: {err}')
+                continue
             for email in emails:
-                if pat.match(email) is not None:
-                    self.log_debug(f'{fn} - found user email in email approval pattern')
+                if pat.match(email.strip()) is not None:
+                    self.log_debug(f'{fn} - found user email in email regex approval list')
                     return True
         return False
 
@@ -2065,11 +2113,17 @@ class User(model_interfaces.User):  # pylint: disable=too-many-public-methods
         else:
             cla.log.debug(f'{fn} - no email whitelist match for user: {self}')
 
+        # Then the email regex approval list
+        expressions = ccla_signature.get_email_regex_approval_list()
+        if expressions:
+            cla.log.debug(f'{fn} - testing user emails: {emails} with '
+                          f'CCLA email regex approval list: {expressions}')
+            if self.preprocess_email_regex(emails, expressions):
+                return True
+
         # Secondly, let's check domain whitelist
-        # If a naked domain (e.g. google.com) is provided, we prefix it with '^.*@',
-        # so that sub-domains are not allowed.
-        # If a '*', '*.' or '.' prefix is provided, we replace the prefix with '.*\.',
-        # which will allow subdomains.
+        # A naked domain (e.g. google.com) matches the domain only, a '*', '*.' or '.' prefix
+        # also matches its subdomains and a '+.' prefix matches the subdomains only.
         patterns = ccla_signature.get_domain_whitelist()
         cla.log.debug(f'{fn} - testing user email domains: {emails} with '
                       f'domain approval values: {patterns}')
@@ -2132,26 +2186,17 @@ class User(model_interfaces.User):  # pylint: disable=too-many-public-methods
         if github_username is not None:
             # Load the github org approval list for this CCLA signature record
             github_org_approval_list = ccla_signature.get_github_org_whitelist()
-            if github_org_approval_list is not None:
-                # Fetch the list of orgs associated with this user
+            if github_org_approval_list:
                 cla.log.debug(f'{fn} - determining if github user {github_username} is associated '
                               f'with any of the github organizations: {github_org_approval_list}')
-                github_orgs = cla.utils.lookup_github_organizations(github_username)
-                if "error" not in github_orgs:
-                    cla.log.debug(f'{fn} - testing user github org: {github_orgs} with '
-                                  f'CCLA github org approval list: {github_org_approval_list}')
-
-                    for dynamo_github_org in github_org_approval_list:
-                        # case insensitive search
-                        if dynamo_github_org.lower() in (s.lower() for s in github_orgs):
-                            cla.log.debug(f'{fn} - found matching github organization for user')
-                            return True
-                        else:
-                            cla.log.debug(f'{fn} - user {github_username} is not in the '
-                                          f'organization: {dynamo_github_org}')
-                else:
-                    cla.log.warning(f'{fn} - unable to lookup github organizations for the user: {github_username}: '
-                                    f'{github_orgs}')
+                # a failed membership lookup raises GitHubMembershipUnknown, so the caller doesn't treat the user
+                # as not approved
+                from cla import github_membership  # pylint: disable=import-outside-toplevel
+                if github_membership.is_member_of_any_organization(github_org_approval_list, github_username):
+                    cla.log.debug(f'{fn} - found matching github organization for user')
+                    return True
+                cla.log.debug(f'{fn} - user {github_username} is not in any of the '
+                              f'organizations: {github_org_approval_list}')
             else:
                 cla.log.debug(f'{fn} - no github organization approval list defined for this CCLA')
         else:
@@ -2213,6 +2258,14 @@ class User(model_interfaces.User):  # pylint: disable=too-many-public-methods
                     except DoesNotExist as err:
                         cla.log.debug(f'gitlab group with full path: {gl_name} does not exist: {err}')
 
+        # Check the LF LDAP groups used by Gerrit
+        lf_username = self.get_lf_username()
+        gerrit_group_approval_list = ccla_signature.get_gerrit_group_approval_list()
+        if lf_username and gerrit_group_approval_list:
+            if cla.utils.is_lf_group_member(gerrit_group_approval_list, lf_username):
+                cla.log.debug(f'{fn} - found lf username in gerrit group approval list')
+                return True
+
         cla.log.debug(f'{fn} - unable to find user in any whitelist')
         return False
 
@@ -2534,6 +2587,13 @@ class SignatureModel(BaseModel):  # pylint: disable=too-many-instance-attributes
     signature_project_index = ProjectSignatureIndex()
     signature_reference_index = ReferenceSignatureIndex()
     signature_envelope_id = UnicodeAttribute(null=True)
+    # envelope lifecycle tracked by the go envelope reconciliation job, signature_envelope_pending is the partition key
+    # of the pending envelope index and is removed once the envelope is completed, declined or voided
+    signature_envelope_sent_on = UnicodeAttribute(null=True)
+    signature_envelope_status = UnicodeAttribute(null=True)
+    signature_envelope_status_reason = UnicodeAttribute(null=True)
+    signature_envelope_status_date = UnicodeAttribute(null=True)
+    signature_envelope_pending = UnicodeAttribute(null=True)
     signature_embargo_acked = BooleanAttribute(default=True, null=True)
     # Callback type refers to either Gerrit or GitHub
     signature_return_url_type = UnicodeAttribute(null=True)
@@ -2558,6 +2618,8 @@ class SignatureModel(BaseModel):  # pylint: disable=too-many-instance-attributes
     github_org_whitelist = ListAttribute(null=True)
     gitlab_org_approval_list = ListAttribute(null=True)
     gitlab_username_approval_list = ListAttribute(null=True)
+    email_regex_approval_list = ListAttribute(null=True)
+    gerrit_group_approval_list = ListAttribute(null=True)
 
     # Additional attributes for ICLAs
     user_email = UnicodeAttribute(null=True)
@@ -2844,6 +2906,12 @@ class Signature(model_interfaces.Signature):  # pylint: disable=too-many-public-
     def get_gitlab_username_approval_list(self):
         return self.model.gitlab_username_approval_list
 
+    def get_email_regex_approval_list(self):
+        return self.model.email_regex_approval_list
+
+    def get_gerrit_group_approval_list(self):
+        return self.model.gerrit_group_approval_list
+
     def get_note(self):
         return self.model.note
 
@@ -2965,6 +3033,28 @@ class Signature(model_interfaces.Signature):  # pylint: disable=too-many-public-
     def set_signature_envelope_id(self, signature_envelope_id) -> None:
         self.model.signature_envelope_id = signature_envelope_id
 
+    def set_signature_envelope_sent(self, signature_envelope_id) -> None:
+        """
+        Records the envelope sent to the signatory, restarting the envelope lifecycle tracked by the envelope
+        reconciliation job - keep in sync with UpdateEnvelopeDetails in cla-backend-go/signatures/repository.go
+        """
+        sent_on = datetime.datetime.now(timezone.utc).strftime('%Y-%m-%dT%H:%M:%SZ')
+        self.model.signature_envelope_id = signature_envelope_id
+        self.model.signature_envelope_sent_on = sent_on
+        self.model.signature_envelope_status = 'Sent'
+        self.model.signature_envelope_status_date = sent_on
+        self.model.signature_envelope_status_reason = None
+        self.model.signature_envelope_pending = 'pending'
+
+    def get_signature_envelope_sent_on(self):
+        return self.model.signature_envelope_sent_on
+
+    def get_signature_envelope_status(self):
+        return self.model.signature_envelope_status
+
+    def get_signature_envelope_pending(self):
+        return self.model.signature_envelope_pending
+
     def set_signature_company_signatory_id(self, signature_company_signatory_id) -> None:
         self.model.signature_company_signatory_id = signature_company_signatory_id
 
@@ -3007,6 +3097,12 @@ class Signature(model_interfaces.Signature):  # pylint: disable=too-many-public-
     def set_gitlab_org_approval_list(self, gitlab_org_approval_list) -> None:
         self.model.gitlab_org_approval_list = [gitlab_org.strip() for gitlab_org in gitlab_org_approval_list]
 
+    def set_email_regex_approval_list(self, email_regex_approval_list) -> None:
+        self.model.email_regex_approval_list = [expression.strip() for expression in email_regex_approval_list]
+
+    def set_gerrit_group_approval_list(self, gerrit_group_approval_list) -> None:
+        self.model.gerrit_group_approval_list = [group.strip() for group in gerrit_group_approval_list]
+
     def set_note(self, note) -> None:
         self.model.note = note
 
@@ -3800,6 +3896,25 @@ class StoreModel(Model):
     expire = NumberAttribute(null=True)
 
 
+class GitHubMembershipCacheModel(Model):
+    """
+    Represents a cached GitHub organization membership result, shared with the go backend - the organization and
+    the username are lower case and expires is the table TTL.
+    """
+
+    class Meta:
+        """Meta class for GitHubMembershipCache."""
+
+        table_name = "cla-{}-github-membership-cache".format(stage)
+        if stage == "local":
+            host = "http://localhost:8000"
+
+    organization = UnicodeAttribute(hash_key=True)
+    username = UnicodeAttribute(range_key=True)
+    is_member = BooleanAttribute(default=False)
+    expires = NumberAttribute(null=True)
+
+
 class Store(key_value_store_interface.KeyValueStore):
     """
     ORM-agnostic wrapper for the DynamoDB key-value store model.
@@ -3902,6 +4017,8 @@ class GitHubOrgModel(BaseModel):
     enabled = BooleanAttribute(null=True)
     note = UnicodeAttribute(null=True)
     skip_cla = MapAttribute(of=UnicodeAttribute, null=True)
+    commit_identity_policy = UnicodeAttribute(null=True)
+    check_run_enabled = BooleanAttribute(null=True)
 
 
 class GitHubOrg(model_interfaces.GitHubOrg):  # pylint: disable=too-many-public-methods
@@ -3986,6 +4103,12 @@ class GitHubOrg(model_interfaces.GitHubOrg):  # pylint: disable=too-many-public-
     def get_skip_cla(self):
         return self.model.skip_cla
 
+    def get_commit_identity_policy(self):
+        return self.model.commit_identity_policy
+
+    def get_check_run_enabled(self):
+        return self.model.check_run_enabled
+
     def get_note(self):
         """
         Getter for the note.
@@ -4026,6 +4149,12 @@ class GitHubOrg(model_interfaces.GitHubOrg):  # pylint: disable=too-many-public-
     def set_skip_cla(self, skip_cla):
         self.model.skip_cla = skip_cla
 
+    def set_commit_identity_policy(self, commit_identity_policy):
+        self.model.commit_identity_policy = commit_identity_policy
+
+    def set_check_run_enabled(self, check_run_enabled):
+        self.model.check_run_enabled = check_run_enabled
+
     def set_note(self, note):
         self.model.note = note
 
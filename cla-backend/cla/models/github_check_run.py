@@ -0,0 +1,234 @@
+# Copyright The Linux Foundation and each contributor to CommunityBridge.
+# SPDX-License-Identifier: MIT
+
+"""
+Publishes the CLA result of a pull request as the EasyCLA check run, when the check run mode is enabled for the
+GitHub organization. Keep in sync with cla-backend-go/github/check_run.go
+"""
+
+import datetime
+import json
+import os
+from typing import List, Optional
+
+import cla
+from cla.controllers.github_application import GitHubInstallation
+from cla.user import UserCommitSummary
+
+# name of the EasyCLA check run
+CHECK_RUN_NAME = "EasyCLA"
+# identifier of the check run action which re-evaluates the pull request
+CHECK_RUN_RERUN_ACTION = "rerun"
+# maximum number of annotations GitHub accepts per check run request
+MAX_CHECK_RUN_ANNOTATIONS = 50
+# maximum length of the check run summary GitHub accepts
+MAX_CHECK_RUN_SUMMARY_LENGTH = 65535
+# number of commits listed per commit identity in the summary
+MAX_IDENTITY_COMMITS = 20
+# room kept at the end of the summary for the note of the unlisted identities
+SUMMARY_TRUNCATION_RESERVE = 256
+
+HELP_URL = "https://help.github.com/en/github/committing-changes-to-your-project/why-are-my-commits-linked-to-the-wrong-user"
+SUPPORT_URL = "https://jira.linuxfoundation.org/servicedesk/customer/portal/4"
+
+
+def update_pull_request_check_run(
+    installation_id,
+    github_repository_id,
+    pull_request,
+    repository_name,
+    signed: List[UserCommitSummary],
+    missing: List[UserCommitSummary],
+    project_version,
+):
+    """
+    Helper function to publish the CLA result of the PR as the EasyCLA check run on the latest commit, instead of
+    the comment and the commit status. The check run of a previous evaluation of the same commit is updated.
+
+    :param: installation_id: The ID of the GitHub installation
+    :type: installation_id: int
+    :param: github_repository_id: The ID of the GitHub repository this PR belongs to.
+    :type: github_repository_id: int
+    :param: pull_request: The GitHub PullRequest object for this PR.
+    :type: pull_request: GitHub.PullRequest
+    :param: repository_name: The GitHub repository full name for this PR, e.g. 'linuxfoundation/easycla'.
+    :type: repository_name: string
+    :param: signed: The list of User Commit Summary objects covered by a signed CLA.
+    :type: signed: List[UserCommitSummary]
+    :param: missing: The list of User Commit Summary objects not covered by a signed CLA.
+    :type: missing: List[UserCommitSummary]
+    :param: project_version: Project version associated with PR
+    :type: project_version: string
+    """
+    fn = "cla.models.github_check_run.update_pull_request_check_run"
+    commits = list(pull_request.get_commits())
+    last_commit = commits[-1]
+    sign_url = cla.utils.get_full_sign_url(
+        "github", str(installation_id), github_repository_id, pull_request.number, project_version
+    )
+
+    output = get_check_run_output(signed, missing, sign_url)
+    commits_by_sha = {commit.sha: commit for commit in commits}
+    annotations = []
+    for sha, reasons in get_blocking_commits(missing):
+        if len(annotations) == MAX_CHECK_RUN_ANNOTATIONS:
+            cla.log.debug(f"{fn} - more than {MAX_CHECK_RUN_ANNOTATIONS} blocking commits, the remaining commits aren't annotated")
+            break
+        annotation = get_commit_annotation(commits_by_sha.get(sha), sha, reasons)
+        if annotation is not None:
+            annotations.append(annotation)
+    if annotations:
+        output["annotations"] = annotations
+
+    if missing or not signed:
+        conclusion, details_url = "action_required", sign_url
+    else:
+        conclusion = "success"
+        details_url = cla.utils.append_project_version_to_url(
+            address=os.path.join(cla.conf["CLA_LANDING_PAGE"], "#/"), project_version=project_version
+        )
+
+    now = datetime.datetime.utcnow().strftime("%Y-%m-%dT%H:%M:%SZ")
+    payload = {
+        "name": CHECK_RUN_NAME,
+        "details_url": details_url,
+        "external_id": str(pull_request.number),
+        "status": "completed",
+        "conclusion": conclusion,
+        "completed_at": now,
+        "output": output,
+        "actions": [
+            {"label": "Re-run", "description": "Check the CLA coverage again", "identifier": CHECK_RUN_RERUN_ACTION}
+        ],
+    }
+
+    client = GitHubInstallation(installation_id)
+    existing = client.get_check_run(repository_name, last_commit.sha, CHECK_RUN_NAME)
+    if existing is not None:
+        cla.log.debug(f"{fn} - PR: {pull_request.number}, updating check run: {existing['id']} with conclusion: {conclusion}")
+        client.update_check_run(repository_name, existing["id"], json.dumps(payload))
+    else:
+        cla.log.debug(f"{fn} - PR: {pull_request.number}, creating check run with conclusion: {conclusion}")
+        payload["head_sha"] = last_commit.sha
+        payload["started_at"] = now
+        client.create_check_run(repository_name, json.dumps(payload))
+
+
+def get_check_run_output(signed: List[UserCommitSummary], missing: List[UserCommitSummary], sign_url: str) -> dict:
+    """
+    Helper function to build the check run title, the summary table of the commit identities and the help text.
+    The summary is cut to the length GitHub accepts, the identities which aren't covered are listed first.
+    """
+    _, title = cla.utils.assemble_cla_status(CHECK_RUN_NAME, signed=not missing and bool(signed))
+
+    summary = f"{len(signed)} of {len(signed) + len(missing)} commit identities are covered by a signed CLA.\n\n"
+    if signed or missing:
+        summary += "| | Identity | Commits | Details |\n| --- | --- | --- | --- |\n"
+        rows = []
+        for identity, shas, summaries in group_by_identity(missing):
+            rows.append(
+                f"| :x: | {table_cell(identity)} | {identity_commits(shas)} | "
+                f"{table_cell(get_missing_reason(summaries[0]))} [Sign the CLA]({sign_url}) |\n"
+            )
+        for identity, shas, _ in group_by_identity(signed):
+            rows.append(f"| :white_check_mark: | {table_cell(identity)} | {identity_commits(shas)} | Authorized |\n")
+        for i, row in enumerate(rows):
+            if len(summary) + len(row) > MAX_CHECK_RUN_SUMMARY_LENGTH - SUMMARY_TRUNCATION_RESERVE:
+                summary += f"\n{len(rows) - i} more commit identities are not listed.\n"
+                break
+            summary += row
+
+    if missing or not signed:
+        text = (
+            f"![CLA Not Signed]({cla.utils.CLA_LOGO_URL}/cla-not-signed.svg{cla.utils.SVG_VERSION})\n\n"
+            f"Every identity marked :x: must be covered by a signed CLA: [start the authorization process]({sign_url}). "
+            f"Commits whose author isn't linked to a GitHub account can be fixed by following [GitHub Help]({HELP_URL}). "
+            "Once the CLA is signed, use the **Re-run** button to check the pull request again. "
+            f"For further assistance with EasyCLA, [please submit a support request ticket]({SUPPORT_URL})."
+        )
+    else:
+        text = "The commit identities listed above are authorized under a signed CLA."
+
+    return {"title": title, "summary": summary, "text": text}
+
+
+def get_blocking_commits(missing: List[UserCommitSummary]) -> List[tuple]:
+    """
+    Helper function to return the (sha, reasons) of the commits with identities which aren't covered, in pull
+    request order
+    """
+    commits = {}
+    for summary in missing:
+        commits.setdefault(summary.commit_sha, []).append(f"{identity_label(summary)}: {get_missing_reason(summary)}")
+    return list(commits.items())
+
+
+def get_commit_annotation(commit, sha: str, reasons: List[str]) -> Optional[dict]:
+    """
+    Helper function to annotate the first changed file of the blocking commit, GitHub only accepts annotations on
+    a file path. Returns None when the commit changes no file, e.g. an empty commit.
+    """
+    fn = "cla.models.github_check_run.get_commit_annotation"
+    if commit is None:
+        return None
+    try:
+        files = commit.files
+    except Exception as e:
+        cla.log.warning(f"{fn} - unable to load the files of the blocking commit: {sha} - error: {e}")
+        return None
+    if not files:
+        return None
+    return {
+        "path": files[0].filename,
+        "start_line": 1,
+        "end_line": 1,
+        "annotation_level": "failure",
+        "title": f"Commit {sha[:7]} is missing CLA authorization",
+        "message": "\n".join(reasons),
+    }
+
+
+def get_missing_reason(summary: UserCommitSummary) -> str:
+    """
+    Helper function to explain why the commit identity isn't covered
+    """
+    if not summary.is_valid_user():
+        return "the commit is missing the GitHub user ID, link the commit email to a GitHub account."
+    if summary.affiliated:
+        return "associated with a company, but not on an approval list."
+    return "not authorized under a signed CLA."
+
+
+def group_by_identity(summaries: List[UserCommitSummary]) -> List[tuple]:
+    """
+    Helper function to group the summaries by commit identity in pull request order, as (identity, short SHAs,
+    summaries) tuples
+    """
+    groups = {}
+    for summary in summaries:
+        identity = identity_label(summary)
+        _, shas, grouped = groups.setdefault(identity, (identity, [], []))
+        shas.append(summary.commit_sha[:7])
+        grouped.append(summary)
+    return list(groups.values())
+
+
+def identity_commits(shas: List[str]) -> str:
+    """
+    Helper function to return the short SHAs of the identity commits, the first ones only when the identity has
+    many commits
+    """
+    if len(shas) <= MAX_IDENTITY_COMMITS:
+        return ", ".join(shas)
+    return f"{', '.join(shas[:MAX_IDENTITY_COMMITS])} and {len(shas) - MAX_IDENTITY_COMMITS} more"
+
+
+def identity_label(summary: UserCommitSummary) -> str:
+    label = summary.get_user_info(tag_user=False).strip()
+    if not label and summary.author_email:
+        label = f"email: {summary.author_email}"
+    return label or "Unknown"
+
+
+def table_cell(value: str) -> str:
+    return value.replace("|", "\\|")
@@ -19,9 +19,11 @@ import cla
 import falcon
 import github
 from cla.controllers.github_application import GitHubInstallation
+from cla.github_membership import GitHubMembershipUnknown
 from cla.models import DoesNotExist, repository_service_interface
 from cla.models.dynamo_models import GitHubOrg, Repository, Event
 from cla.models.event_types import EventType
+from cla.models.github_check_run import update_pull_request_check_run
 from cla.user import UserCommitSummary
 from cla.utils import (append_project_version_to_url, get_project_instance,
                        set_active_pr_metadata)
@@ -34,6 +36,8 @@ from requests_oauthlib import OAuth2Session
 
 # some emails we want to exclude when we register the users
 EXCLUDE_GITHUB_EMAILS = ["noreply.github.com"]
+# the maximum number of commits returned by the REST API for a pull request
+REST_COMMIT_LIMIT = 250
 
 
 class GitHub(repository_service_interface.RepositoryService):
@@ -642,17 +646,6 @@ class GitHub(repository_service_interface.RepositoryService):
             )
             return
 
-        try:
-            # Get Commit authors
-            commit_authors = get_pull_request_commit_authors(pull_request, installation_id)
-            cla.log.debug(f"{fn} - commit authors: {commit_authors}")
-        except Exception as e:
-            cla.log.warning(
-                f"{fn} - unable to load commit authors for PR {pull_request_id} from GitHub repository "
-                f"{github_repository_id} using installation id {installation_id} - error: {e}"
-            )
-            return
-
         try:
             # Get existing repository info using the repository's external ID,
             # which is the repository ID assigned by github.
@@ -726,6 +719,19 @@ class GitHub(repository_service_interface.RepositoryService):
             )
             return
 
+        try:
+            # Get Commit authors - the commit identity policy of the organization selects the identities to check
+            commit_authors = get_pull_request_commit_authors(
+                pull_request, installation_id, github_org.get_commit_identity_policy()
+            )
+            cla.log.debug(f"{fn} - commit authors: {commit_authors}")
+        except Exception as e:
+            cla.log.warning(
+                f"{fn} - unable to load commit authors for PR {pull_request_id} from GitHub repository "
+                f"{github_repository_id} using installation id {installation_id} - error: {e}"
+            )
+            return
+
         project_id = repository.get_repository_project_id()
         project = get_project_instance()
         project.load(project_id)
@@ -735,8 +741,16 @@ class GitHub(repository_service_interface.RepositoryService):
 
         # Check if the user has signed the CLA
         cla.log.debug(f"{fn} - checking if the user has signed the CLA...")
-        for user_commit_summary in commit_authors:
-            handle_commit_from_user(project, user_commit_summary, signed, missing)
+        try:
+            for user_commit_summary in commit_authors:
+                handle_commit_from_user(project, user_commit_summary, signed, missing)
+        except GitHubMembershipUnknown as e:
+            # don't flip the merge group status because of a transient failure - the next event re-evaluates it
+            cla.log.warning(
+                f"{fn} - unable to determine the approval status of the commit authors for merge group: "
+                f"{merge_group_sha} - leaving the status unchanged - error: {e}"
+            )
+            return
 
         # Skip allowlisted bots per org/repo GitHub login/email regexps
         missing, allowlisted = self.skip_allowlisted_bots(github_org, repository.get_repository_name(), missing)
@@ -786,14 +800,6 @@ class GitHub(repository_service_interface.RepositoryService):
             break
         cla.log.debug(f"{fn} - retrieved pull request: {pull_request}")
 
-        # Get all unique users/authors involved in this PR - returns a List[UserCommitSummary] objects
-        commit_authors = get_pull_request_commit_authors(pull_request, installation_id)
-
-        cla.log.debug(
-            f"{fn} - PR: {pull_request.number}, found {len(commit_authors)} unique commit authors "
-            f"for pull request: {pull_request.number}"
-        )
-
         try:
             # Get existing repository info using the repository's external ID,
             # which is the repository ID assigned by github.
@@ -867,6 +873,17 @@ class GitHub(repository_service_interface.RepositoryService):
             )
             return
 
+        # Get all unique users/authors involved in this PR - returns a List[UserCommitSummary] objects, the commit
+        # identity policy of the organization selects whether the committers and co-authors are included
+        commit_authors = get_pull_request_commit_authors(
+            pull_request, installation_id, github_org.get_commit_identity_policy()
+        )
+
+        cla.log.debug(
+            f"{fn} - PR: {pull_request.number}, found {len(commit_authors)} unique commit authors "
+            f"for pull request: {pull_request.number}"
+        )
+
         # Retrieve project ID from the repository.
         project_id = repository.get_repository_project_id()
         project = get_project_instance()
@@ -901,8 +918,19 @@ class GitHub(repository_service_interface.RepositoryService):
             # Wait for all threads to be finished before moving on
             executor.shutdown(wait=True)
 
+        unknown = 0
         for future in concurrent.futures.as_completed(futures):
             cla.log.debug(f"{fn} - ThreadClosed for handle_commit_from_user")
+            if isinstance(future.exception(), GitHubMembershipUnknown):
+                unknown += 1
+
+        # don't flip the pull request status because of a transient failure - the next event re-evaluates it
+        if unknown > 0:
+            cla.log.warning(
+                f"{fn} - PR: {pull_request.number}, unable to determine the approval status of {unknown} commit "
+                "authors - leaving the pull request status unchanged"
+            )
+            return
 
         # Skip allowlisted bots per org/repo GitHub login/email regexps
         missing, allowlisted = self.skip_allowlisted_bots(github_org, repository.get_repository_name(), missing)
@@ -918,6 +946,19 @@ class GitHub(repository_service_interface.RepositoryService):
             f"with missing authors: {missing}"
         )
         repository_name = repository.get_repository_name()
+        if github_org.get_check_run_enabled():
+            # the check run mode replaces the comment and the commit status, see CHECK_RUNS.md
+            cla.log.debug(f"{fn} - PR: {pull_request.number}, check run mode enabled - publishing the check run")
+            update_pull_request_check_run(
+                installation_id=installation_id,
+                github_repository_id=github_repository_id,
+                pull_request=pull_request,
+                repository_name=repository_name,
+                signed=signed,
+                missing=missing,
+                project_version=project.get_version(),
+            )
+            return
         update_pull_request(
             installation_id=installation_id,
             github_repository_id=github_repository_id,
@@ -1600,6 +1641,7 @@ def handle_commit_from_user(
                 email=user_commit_summary.author_email,
                 github_id=user_commit_summary.author_id,
                 github_username=user_commit_summary.author_login,  # double check this...
+                lf_username=user.get_lf_username(),
             ):
                 cla.log.debug(
                     f"{fn} - User Commit Summary: {user_commit_summary}, "
@@ -1652,16 +1694,48 @@ def get_merge_group_commit_authors(merge_group_sha, installation_id=None) -> Lis
     return commit_authors
 
 
-def get_author_summary(commit, pr, installation_id) -> List[UserCommitSummary]:
+def get_author_summary(commit, pr, installation_id, commit_identity_policy=None) -> List[UserCommitSummary]:
     """
     Helper function to extract author information from a GitHub commit.
+    The committer and the Co-authored-by trailers of the commit are added when the commit identity policy
+    of the organization includes them and they differ from the author.
     :param commit: A GitHub commit object.
     :type commit: github.Commit.Commit
     :param pr: PR number
     :type pr: int
+    :param commit_identity_policy: the commit identity policy of the GitHub organization
+    :type commit_identity_policy: string
     """
     fn = "cla.models.github_models.get_author_summary"
-    commit_authors = []
+    commit_author_summary = get_commit_author(commit, pr)
+    commit_authors = [commit_author_summary]
+    seen = get_commit_identity_keys(commit_author_summary)
+
+    if cla.utils.commit_identity_policy_includes_committers(commit_identity_policy):
+        committer_summary = get_commit_committer(commit, pr)
+        if committer_summary is not None and add_commit_identity(seen, committer_summary):
+            cla.log.debug(f"{fn} - PR: {pr}, adding committer {committer_summary}")
+            commit_authors.append(committer_summary)
+
+    if cla.utils.commit_identity_policy_includes_co_authors(commit_identity_policy):
+        for co_author in cla.utils.get_co_authors_from_commit(commit):
+            co_author_summary = get_co_author_commits(co_author, commit, pr, installation_id)
+            if add_commit_identity(seen, co_author_summary):
+                cla.log.debug(f"{fn} - PR: {pr}, adding co-author {co_author_summary}")
+                commit_authors.append(co_author_summary)
+
+    return commit_authors
+
+
+def get_commit_author(commit, pr) -> UserCommitSummary:
+    """
+    Helper function to extract the author information from a GitHub commit.
+    :param commit: A GitHub commit object.
+    :type commit: github.Commit.Commit
+    :param pr: PR number
+    :type pr: int
+    """
+    fn = "cla.models.github_models.get_commit_author"
     if commit.author:
         try:
             commit_author_summary = UserCommitSummary(
@@ -1674,17 +1748,7 @@ def get_author_summary(commit, pr, installation_id) -> List[UserCommitSummary]:
                 False,  # default not authorized - will be evaluated and updated later
             )
             cla.log.debug(f"{fn} - PR: {pr}, {commit_author_summary}")
-            # check for co-author details
-            # issue # 3884
-            commit_authors.append(commit_author_summary)
-            # co_authors = cla.utils.get_co_authors_from_commit(commit)
-            # with concurrent.futures.ThreadPoolExecutor(max_workers=5) as executor:
-            #     for co_author in co_authors:
-            #         commit_authors.append(
-            #             executor.submit(get_co_author_commits, co_author, commit, pr, installation_id).result()
-            #         )
-
-            return commit_authors
+            return commit_author_summary
         except (GithubException, IncompletableObject) as exc:
             cla.log.warning(f"{fn} - PR: {pr}, unable to get commit author summary: {exc}")
             try:
@@ -1707,22 +1771,85 @@ def get_author_summary(commit, pr, installation_id) -> List[UserCommitSummary]:
                     f"however, we did find GitAuthor info"
                 )
                 cla.log.debug(f"{fn} - PR: {pr}, {commit_author_summary}")
-                commit_authors.append(commit_author_summary)
-                return commit_authors
+                return commit_author_summary
             except (GithubException, IncompletableObject) as exc:
                 cla.log.warning(f"{fn} - PR: {pr}, unable to get commit author summary: {exc}")
                 commit_author_summary = UserCommitSummary(commit.sha, None, None, None, None, False, False)
                 cla.log.warning(f"{fn} - PR: {pr}, " f"could not find any commit author for SHA {commit_author_summary}")
-                commit_authors.append(commit_author_summary)
-                return commit_authors
+                return commit_author_summary
     else:
         cla.log.warning(f"{fn} - PR: {pr}, " f"could not find any commit author for SHA {commit.sha}")
-        commit_author_summary = UserCommitSummary(commit.sha, None, None, None, None, False, False)
-        commit_authors.append(commit_author_summary)
-        return commit_authors
+        return UserCommitSummary(commit.sha, None, None, None, None, False, False)
+
+
+def get_commit_committer(commit, pr) -> Optional[UserCommitSummary]:
+    """
+    Helper function to extract the committer information from a GitHub commit. Returns None when the commit
+    has no committer or was committed by GitHub itself (web-flow), e.g. the commits made on the web interface.
+    :param commit: A GitHub commit object.
+    :type commit: github.Commit.Commit
+    :param pr: PR number
+    :type pr: int
+    """
+    fn = "cla.models.github_models.get_commit_committer"
+    try:
+        git_committer = commit.commit.committer if commit.commit else None
+        git_committer_email = git_committer.email if git_committer else None
+        if commit.committer:
+            if (commit.committer.login or "").lower() == "web-flow":
+                return None
+            summary = UserCommitSummary(
+                commit.sha,
+                commit.committer.id,
+                commit.committer.login,
+                commit.committer.name,
+                commit.committer.email or git_committer_email,
+                False,
+                False,  # default not authorized - will be evaluated and updated later
+            )
+        elif git_committer:
+            summary = UserCommitSummary(
+                commit.sha, None, None, git_committer.name, git_committer_email, False, False
+            )
+        else:
+            return None
+    except (GithubException, IncompletableObject) as exc:
+        cla.log.warning(f"{fn} - PR: {pr}, unable to get commit committer summary: {exc}")
+        return None
+
+    if (summary.author_email or "").lower() == "noreply@github.com":
+        return None
+    cla.log.debug(f"{fn} - PR: {pr}, {summary}")
+    return summary
+
+
+def get_commit_identity_keys(summary: UserCommitSummary) -> set:
+    """
+    Helper function to return the GitHub ID, the login and the email keys of a commit identity
+    """
+    keys = set()
+    if summary.author_id is not None:
+        keys.add(f"id:{summary.author_id}")
+    if summary.author_login:
+        keys.add(f"login:{summary.author_login.lower()}")
+    if summary.author_email:
+        keys.add(f"email:{summary.author_email.lower()}")
+    return keys
 
 
-def get_pull_request_commit_authors(pull_request, installation_id) -> List[UserCommitSummary]:
+def add_commit_identity(seen: set, summary: UserCommitSummary) -> bool:
+    """
+    Helper function to add the keys of the commit identity to the seen keys, returns False when the
+    identity has no keys or was already seen
+    """
+    keys = get_commit_identity_keys(summary)
+    if not keys or keys & seen:
+        return False
+    seen.update(keys)
+    return True
+
+
+def get_pull_request_commit_authors(pull_request, installation_id, commit_identity_policy=None) -> List[UserCommitSummary]:
     """
     Helper function to extract all committer information for a GitHub PR.
 
@@ -1735,20 +1862,24 @@ def get_pull_request_commit_authors(pull_request, installation_id) -> List[UserC
 
     :param: pull_request: A GitHub pull request to examine.
     :type: pull_request: GitHub.PullRequest
+    :param: commit_identity_policy: the commit identity policy of the GitHub organization, authors only when None
+    :type: commit_identity_policy: string
     :return: A list of User Commit Summary objects containing the commit sha and available user information
     :rtype: List[UserCommitSummary]
     """
     fn = "cla.models.github_models.get_pull_request_commit_authors"
     cla.log.debug(f"{fn} - Querying pull request commits for author information...")
-    no_commits = pull_request.get_commits().totalCount
-    cla.log.debug(f"{fn} - PR: {pull_request.number}, number of commits: {no_commits}")
+    commits = get_pull_request_commits(pull_request, installation_id)
+    cla.log.debug(f"{fn} - PR: {pull_request.number}, number of commits: {len(commits)}")
 
     commit_authors = []
 
     with concurrent.futures.ThreadPoolExecutor(max_workers=30) as executor:
         future_to_commit = {
-            executor.submit(get_author_summary, commit, pull_request.number, installation_id): commit
-            for commit in pull_request.get_commits()
+            executor.submit(
+                get_author_summary, commit, pull_request.number, installation_id, commit_identity_policy
+            ): commit
+            for commit in commits
         }
         for future in concurrent.futures.as_completed(future_to_commit):
             future_to_commit[future]
@@ -1761,6 +1892,83 @@ def get_pull_request_commit_authors(pull_request, installation_id) -> List[UserC
     return commit_authors
 
 
+def get_pull_request_commits(pull_request, installation_id) -> list:
+    """
+    Helper function to return every commit of a GitHub PR. The REST API returns at most 250 commits for a
+    pull request, the commits of the larger pull requests are loaded with the GraphQL API.
+
+    :param: pull_request: A GitHub pull request to examine.
+    :type: pull_request: GitHub.PullRequest
+    :return: the commits, the GraphQL commits have the attributes of the REST commits used to collect the identities
+    :rtype: list
+    """
+    fn = "cla.models.github_models.get_pull_request_commits"
+    commits = list(pull_request.get_commits())
+    if len(commits) < REST_COMMIT_LIMIT:
+        return commits
+
+    cla.log.debug(
+        f"{fn} - PR: {pull_request.number} has at least {REST_COMMIT_LIMIT} commits, "
+        "loading the commits with the GraphQL API"
+    )
+    owner, name = pull_request.base.repo.full_name.split("/", 1)
+    nodes = GitHubInstallation(installation_id).get_pull_request_commits(owner, name, pull_request.number)
+    return [GraphQLCommit(node) for node in nodes]
+
+
+class GraphQLCommitUser:  # pylint: disable=too-few-public-methods
+    """
+    The GitHub account of a commit identity loaded with the GraphQL API, the attributes of github.NamedUser
+    used to collect the commit identities.
+    """
+
+    def __init__(self, user, git_actor):
+        self.id = user.get("databaseId")
+        self.login = user.get("login")
+        self.name = user.get("name") or git_actor.get("name")
+        self.email = user.get("email") or git_actor.get("email")
+
+
+class GraphQLGitActor:  # pylint: disable=too-few-public-methods
+    """
+    The git identity of a commit loaded with the GraphQL API, the attributes of github.GitAuthor.
+    """
+
+    def __init__(self, git_actor):
+        self.name = git_actor.get("name")
+        self.email = git_actor.get("email")
+
+
+class GraphQLGitCommit:  # pylint: disable=too-few-public-methods
+    """
+    The git commit of a commit loaded with the GraphQL API, the attributes of github.GitCommit.
+    """
+
+    def __init__(self, node):
+        self.message = node.get("message")
+        self.author = GraphQLGitActor(node.get("author") or {})
+        self.committer = GraphQLGitActor(node.get("committer") or {})
+
+
+class GraphQLCommit:  # pylint: disable=too-few-public-methods
+    """
+    A pull request commit loaded with the GraphQL API, the attributes of github.Commit used to collect the
+    commit identities. The author and the committer are None when the git identity is not linked to a GitHub account.
+    """
+
+    def __init__(self, node):
+        self.sha = node.get("oid")
+        self.commit = GraphQLGitCommit(node)
+        self.author = self._user(node.get("author"))
+        self.committer = self._user(node.get("committer"))
+
+    @staticmethod
+    def _user(git_actor):
+        if not git_actor or not git_actor.get("user"):
+            return None
+        return GraphQLCommitUser(git_actor["user"], git_actor)
+
+
 def get_co_author_commits(co_author, commit, pr, installation_id):
     fn = "cla.models.github_models.get_co_author_commits"
     # check if co-author is a github user
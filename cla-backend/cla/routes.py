@@ -1650,6 +1650,9 @@ def github_app_activity(body, request, response):
     if event_type == "installation_repositories" or \
             event_type == "integration_installation_repositories" or \
             event_type == "repository" or \
+            event_type == "organization" or \
+            event_type == "membership" or \
+            (event_type == "check_run" and action in ("requested_action", "rerequested")) or \
             (event_type == "push" and action and action == "created"):
         try:
             cla.log.debug(f'{fn} - redirecting event type: \'{event_type}\' with action: \'{action}\' to v4 golang api')
@@ -0,0 +1,75 @@
+# Copyright The Linux Foundation and each contributor to CommunityBridge.
+# SPDX-License-Identifier: MIT
+
+import pytest
+from cla import domain_matcher
+
+
+@pytest.mark.parametrize("entry,domain,kind", [
+    ("example.com", "example.com", domain_matcher.MATCH_EXACT),
+    ("EXAMPLE.com.", "example.com", domain_matcher.MATCH_EXACT),
+    ("+.example.com", "example.com", domain_matcher.MATCH_SUBDOMAINS),
+    ("*.example.com", "example.com", domain_matcher.MATCH_DOMAIN_AND_SUBDOMAINS),
+    (".example.com", "example.com", domain_matcher.MATCH_DOMAIN_AND_SUBDOMAINS),
+    ("*example.com", "example.com", domain_matcher.MATCH_DOMAIN_AND_SUBDOMAINS),
+    ("bücher.de", "xn--bcher-kva.de", domain_matcher.MATCH_EXACT),
+    ("+.xn--bcher-kva.de", "xn--bcher-kva.de", domain_matcher.MATCH_SUBDOMAINS),
+    ("example.co.uk", "example.co.uk", domain_matcher.MATCH_EXACT),
+])
+def test_parse_pattern(entry, domain, kind):
+    """ Test valid domain approval list entries are normalized """
+    pattern = domain_matcher.parse_pattern(entry)
+    assert pattern.domain == domain
+    assert pattern.kind == kind
+
+
+@pytest.mark.parametrize("entry", [
+    "",
+    "*",
+    "example.*.com",
+    "example_org.com",
+    "+example.com",
+])
+def test_parse_pattern_invalid(entry):
+    """ Test invalid domain approval list entries are rejected """
+    with pytest.raises(domain_matcher.DomainPatternError):
+        domain_matcher.parse_pattern(entry)
+
+
+@pytest.mark.parametrize("entry", [
+    "com",
+    "*.com",
+    "*.co.uk",
+    "+.co.uk",
+    "*.github.io",
+])
+def test_parse_pattern_public_suffix(entry):
+    """ Test domain approval list entries covering a public suffix are rejected """
+    with pytest.raises(domain_matcher.PublicSuffixError):
+        domain_matcher.parse_pattern(entry)
+
+
+@pytest.mark.parametrize("entry,email,expected", [
+    ("example.com", "user@example.com", True),
+    ("example.com", "user@EXAMPLE.com.", True),
+    ("example.com", "user@sub.example.com", False),
+    ("+.example.com", "user@sub.example.com", True),
+    ("+.example.com", "user@example.com", False),
+    ("+.example.com", "user@sexample.com", False),
+    ("*.example.com", "user@example.com", True),
+    ("*.example.com", "user@a.b.example.com", True),
+    ("*example.com", "user@badexample.com", False),
+    ("bücher.de", "user@BÜCHER.de", True),
+    ("bücher.de", "user@xn--bcher-kva.de", True),
+    ("example.co.uk", "user@company.co.uk", False),
+])
+def test_pattern_matches(entry, email, expected):
+    """ Test emails against parsed domain approval list entries """
+    domain = domain_matcher.email_domain(email)
+    assert domain_matcher.parse_pattern(entry).matches(domain) == expected
+
+
+@pytest.mark.parametrize("email", ["", "user", "@example.com", "user@", "user@exa mple.com"])
+def test_email_domain_invalid(email):
+    """ Test emails without a valid domain """
+    assert domain_matcher.email_domain(email) is None
@@ -5,7 +5,7 @@ from unittest.mock import Mock
 
 import pytest
 from cla import utils
-from cla.models.dynamo_models import Company, User, Project, Document
+from cla.models.dynamo_models import Company, User, Project, Document, Signature
 
 
 @pytest.fixture
@@ -92,3 +92,15 @@ def test_get_user_email(user):
     """ Test getting user email with valid email """
     user.model.user_emails = set(["wanyaland@gmail.com"])
     assert utils.get_public_email(user) == "wanyaland@gmail.com"
+
+
+def test_set_signature_envelope_sent():
+    """ Test a sent envelope is added to the pending envelope index used by the envelope reconciliation """
+    signature = Signature()
+    signature.model.signature_envelope_status_reason = "declined by the signatory"
+    signature.set_signature_envelope_sent("envelope-id")
+    assert signature.get_signature_envelope_id() == "envelope-id"
+    assert signature.get_signature_envelope_status() == "Sent"
+    assert signature.get_signature_envelope_pending() == "pending"
+    assert signature.get_signature_envelope_sent_on().endswith("Z")
+    assert signature.model.signature_envelope_status_reason is None
@@ -64,6 +64,28 @@ def test_pattern_with_dot_prefix(create_user):
     assert create_user.preprocess_pattern(domain_emails, patterns) == True
 
 
+def test_pattern_with_plus_dot_prefix(create_user):
+    """Test given user email against pattern starting with plus_dot_prefix, which matches subdomains only """
+    patterns = ["+.bar.com"]
+    assert create_user.preprocess_pattern(["harold@help.bar.com"], patterns) == True
+    assert create_user.preprocess_pattern(["harold@bar.com"], patterns) == False
+    assert create_user.preprocess_pattern(["harold@sbar.com"], patterns) == False
+
+
+def test_pattern_with_idn_domain(create_user):
+    """Test internationalized domains match in both unicode and punycode form """
+    assert create_user.preprocess_pattern(["harold@BÜCHER.de"], ["xn--bcher-kva.de"]) == True
+    assert create_user.preprocess_pattern(["harold@xn--bcher-kva.de"], ["bücher.de"]) == True
+
+
+def test_public_suffix_pattern_is_skipped(create_user):
+    """Test public suffix entries are skipped without disabling the later entries """
+    patterns = ["*.co.uk", "com", "+.bar.co.uk"]
+    assert create_user.preprocess_pattern(["harold@foo.co.uk"], patterns) == False
+    assert create_user.preprocess_pattern(["harold@foo.com"], patterns) == False
+    assert create_user.preprocess_pattern(["harold@help.bar.co.uk"], patterns) == True
+
+
 def test_email_approval_list_fail(create_user):
     """Test email that fails domain and email approval list checks """
     signature = Signature()
@@ -79,3 +101,36 @@ def test_gerrit_project_approval_listing(create_user):
     signature.get_email_whitelist = MagicMock(return_value={"phillip.leigh@amdocs.com"})
     create_user.get_all_user_emails = MagicMock(return_value=["phillip.leigh@amdocs.com"])
     assert create_user.is_approved(signature) == True
+
+
+def test_email_regex_must_match_whole_address(create_user):
+    """Test user emails against email regex approval list entries, case-insensitive"""
+    expressions = [r"octo\..*@example\.com"]
+    assert create_user.preprocess_email_regex(["Octo.Cat@Example.com"], expressions) == True
+    assert create_user.preprocess_email_regex(["octo@example.com"], ["octo"]) == False
+
+
+def test_invalid_email_regex_is_skipped(create_user):
+    """Test an invalid email regex entry doesn't disable the later entries"""
+    expressions = ["(", r"octo\..*@example\.com"]
+    assert create_user.preprocess_email_regex(["octo.cat@example.com"], expressions) == True
+
+
+def test_email_regex_approval_listing(create_user):
+    """Test for email matching the signature email regex approval list"""
+    signature = Signature()
+    signature.get_email_whitelist = MagicMock(return_value=None)
+    signature.get_email_regex_approval_list = MagicMock(return_value=[r".*@example\.com"])
+    create_user.get_all_user_emails = MagicMock(return_value=["octo.cat@example.com"])
+    assert create_user.is_approved(signature) == True
+
+
+def test_gerrit_group_approval_listing(create_user):
+    """Test for LF username in one of the signature gerrit groups"""
+    signature = Signature()
+    signature.get_gerrit_group_approval_list = MagicMock(return_value=["ldap-group"])
+    create_user.get_all_user_emails = MagicMock(return_value=["octo.cat@example.com"])
+    create_user.get_lf_username = MagicMock(return_value="octocat")
+    with patch("cla.utils.is_lf_group_member", return_value=True) as is_lf_group_member:
+        assert create_user.is_approved(signature) == True
+        is_lf_group_member.assert_called_once_with(["ldap-group"], "octocat")
@@ -0,0 +1,66 @@
+# Copyright The Linux Foundation and each contributor to CommunityBridge.
+# SPDX-License-Identifier: MIT
+
+import re
+import unittest
+from unittest import TestCase
+
+from cla.models.github_check_run import (MAX_CHECK_RUN_SUMMARY_LENGTH,
+                                         get_blocking_commits,
+                                         get_check_run_output)
+from cla.user import UserCommitSummary
+
+
+class TestGetCheckRunOutput(TestCase):
+    def test_output(self):
+        signed = [
+            UserCommitSummary("aaaaaaaaaa", 1, "jane", None, None, True, True),
+            UserCommitSummary("bbbbbbbbbb", 1, "jane", None, None, True, True),
+        ]
+        missing = [
+            UserCommitSummary("cccccccccc", 2, "john", None, None, False, True),
+            UserCommitSummary("cccccccccc", None, None, "Bob|B", "bob@example.org", False, False),
+            UserCommitSummary("dddddddddd", 3, "carol", None, None, False, False),
+        ]
+
+        output = get_check_run_output(signed, missing, "https://sign")
+
+        self.assertEqual("Missing CLA Authorization.", output["title"])
+        summary = output["summary"]
+        self.assertTrue(summary.startswith("2 of 5 commit identities are covered by a signed CLA."))
+        self.assertIn("| :white_check_mark: | login: jane | aaaaaaa, bbbbbbb | Authorized |", summary)
+        self.assertIn(
+            "| :x: | login: john | ccccccc | associated with a company, but not on an approval list. "
+            "[Sign the CLA](https://sign) |",
+            summary,
+        )
+        self.assertIn("| :x: | name: Bob\\|B | ccccccc | the commit is missing the GitHub user ID", summary)
+        self.assertIn("| :x: | login: carol | ddddddd | not authorized under a signed CLA.", summary)
+        self.assertIn("Re-run", output["text"])
+
+        output = get_check_run_output(signed, [], "https://sign")
+        self.assertEqual("EasyCLA check passed. You are authorized to contribute.", output["title"])
+        self.assertNotIn(":x:", output["summary"])
+
+        commits = get_blocking_commits(missing)
+        self.assertEqual(["cccccccccc", "dddddddddd"], [sha for sha, _ in commits])
+        self.assertEqual(2, len(commits[0][1]))
+
+    def test_summary_length(self):
+        missing = [
+            UserCommitSummary(f"{i:040d}", i + 1, f"contributor-with-a-long-login-{i}", None, None, False, False)
+            for i in range(2000)
+        ]
+        missing += [UserCommitSummary(f"{10000 + i:040d}", 10000, "jane", None, None, False, False) for i in range(30)]
+
+        summary = get_check_run_output([], missing, "https://sign")["summary"]
+        self.assertLessEqual(len(summary), MAX_CHECK_RUN_SUMMARY_LENGTH)
+        self.assertIn("| :x: | login: contributor-with-a-long-login-0 |", summary)
+        self.assertIsNotNone(re.search(r"\n\d+ more commit identities are not listed\.\n$", summary))
+
+        summary = get_check_run_output([], missing[2000:], "https://sign")["summary"]
+        self.assertIn("0000000, 0000000 and 10 more |", summary)
+
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,78 @@
+# Copyright The Linux Foundation and each contributor to CommunityBridge.
+# SPDX-License-Identifier: MIT
+
+import time
+from unittest.mock import MagicMock, patch
+
+import pytest
+import requests
+from cla import github_membership
+from cla.models.dynamo_models import GitHubMembershipCacheModel
+
+
+@pytest.fixture()
+def cache_miss():
+    """ Mock an empty membership cache table """
+    with patch.object(GitHubMembershipCacheModel, "get", side_effect=GitHubMembershipCacheModel.DoesNotExist), \
+            patch.object(GitHubMembershipCacheModel, "save") as save:
+        yield save
+
+
+def test_cached_membership_is_used():
+    """ Test a cached result which hasn't expired is returned without querying GitHub """
+    entry = GitHubMembershipCacheModel("org", "member", is_member=True, expires=int(time.time()) + 60)
+    with patch.object(GitHubMembershipCacheModel, "get", return_value=entry) as get, \
+            patch("cla.github_membership.lookup_organization_member") as lookup:
+        assert github_membership.is_organization_member("ORG", "Member") == True
+        get.assert_called_once_with("org", "member")
+        lookup.assert_not_called()
+
+
+def test_expired_membership_is_looked_up(cache_miss):
+    """ Test an expired result is looked up again and cached """
+    entry = GitHubMembershipCacheModel("org", "member", is_member=True, expires=int(time.time()) - 1)
+    with patch.object(GitHubMembershipCacheModel, "get", return_value=entry), \
+            patch("cla.github_membership.lookup_organization_member", return_value=False) as lookup:
+        assert github_membership.is_organization_member("org", "member") == False
+        lookup.assert_called_once_with("org", "member")
+        cache_miss.assert_called_once()
+
+
+def test_lookup_error_is_unknown(cache_miss):
+    """ Test a GitHub failure is reported as unknown and not cached """
+    with patch("cla.github_membership.lookup_organization_member",
+               side_effect=requests.exceptions.HTTPError("502 bad gateway")):
+        with pytest.raises(github_membership.GitHubMembershipUnknown):
+            github_membership.is_organization_member("org", "member")
+        cache_miss.assert_not_called()
+
+
+def test_cache_error_skips_the_cache():
+    """ Test the membership is still looked up when the cache table is unavailable """
+    with patch.object(GitHubMembershipCacheModel, "get", side_effect=Exception("table not found")), \
+            patch.object(GitHubMembershipCacheModel, "save", side_effect=Exception("table not found")), \
+            patch("cla.github_membership.lookup_organization_member", return_value=True):
+        assert github_membership.is_organization_member("org", "member") == True
+
+
+def test_unknown_membership_doesnt_hide_a_match():
+    """ Test a failed lookup for one organization doesn't hide a match in another """
+    def is_member(organization, username):
+        if organization == "down-org":
+            raise github_membership.GitHubMembershipUnknown("502 bad gateway")
+        return organization == "member-org"
+
+    with patch("cla.github_membership.is_organization_member", side_effect=is_member):
+        assert github_membership.is_member_of_any_organization(["down-org", "member-org"], "user") == True
+        with pytest.raises(github_membership.GitHubMembershipUnknown):
+            github_membership.is_member_of_any_organization(["down-org", "other-org"], "user")
+        assert github_membership.is_member_of_any_organization(["other-org"], "user") == False
+
+
+def test_lookup_organization_member():
+    """ Test the GitHub membership status codes """
+    with patch("cla.github_membership.requests.get") as get, patch.dict("cla.conf", {"GITHUB_OAUTH_TOKEN": "token"}):
+        get.return_value = MagicMock(status_code=204)
+        assert github_membership.lookup_organization_member("org", "member") == True
+        get.return_value = MagicMock(status_code=404)
+        assert github_membership.lookup_organization_member("org", "member") == False
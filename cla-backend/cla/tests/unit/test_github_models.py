@@ -5,9 +5,11 @@ import unittest
 from unittest import TestCase
 from unittest.mock import MagicMock, Mock, patch
 
-from cla.models.github_models import (UserCommitSummary, get_author_summary,
+from cla.models.github_models import (REST_COMMIT_LIMIT, UserCommitSummary,
+                                      get_author_summary,
                                       get_co_author_commits,
-                                      get_pull_request_commit_authors)
+                                      get_pull_request_commit_authors,
+                                      get_pull_request_commits)
 
 
 class TestGetPullRequestCommitAuthors(TestCase):
@@ -91,5 +93,114 @@ class TestGetPullRequestCommitAuthors(TestCase):
         self.assertEqual(result.author_name, "co_author")
 
 
+class TestGetAuthorSummary(TestCase):
+    @staticmethod
+    def commit(message, committer_login="committer_login", committer_email="committer@example.com"):
+        commit = MagicMock()
+        commit.sha = "fake_sha"
+        commit.author.id = 1
+        commit.author.login = "author_login"
+        commit.author.name = "Author"
+        commit.author.email = "author@example.com"
+        commit.committer.id = 2
+        commit.committer.login = committer_login
+        commit.committer.name = "Committer"
+        commit.committer.email = committer_email
+        commit.commit.message = message
+        return commit
+
+    @patch("cla.utils.get_repository_service")
+    def test_authors_policy(self, mock_github_instance):
+        commit = self.commit("fix\n\nCo-authored-by: Co Author <co_author@example.com>")
+
+        for policy in [None, "", "authors"]:
+            result = get_author_summary(commit, 1, 123, policy)
+            self.assertEqual(["author_login"], [summary.author_login for summary in result])
+        mock_github_instance.return_value.get_github_user_by_email.assert_not_called()
+
+    @patch("cla.utils.get_repository_service")
+    def test_all_policy(self, mock_github_instance):
+        mock_github_instance.return_value.get_github_user_by_email.return_value = None
+        commit = self.commit(
+            "fix\n\nCo-authored-by: Co Author <co_author@example.com>\n"
+            "Co-authored-by: Author <AUTHOR@example.com>"
+        )
+
+        result = get_author_summary(commit, 1, 123, "all")
+
+        # the co-author trailer of the author is not checked twice
+        self.assertEqual(
+            ["author@example.com", "committer@example.com", "co_author@example.com"],
+            [summary.author_email for summary in result],
+        )
+        self.assertEqual(2, result[1].author_id)
+
+    @patch("cla.utils.get_repository_service")
+    def test_web_flow_committer_is_skipped(self, mock_github_instance):
+        commit = self.commit("fix", committer_login="web-flow", committer_email="noreply@github.com")
+
+        result = get_author_summary(commit, 1, 123, "authors_committers")
+
+        self.assertEqual(["author_login"], [summary.author_login for summary in result])
+
+    @patch("cla.utils.get_repository_service")
+    def test_committer_same_as_author(self, mock_github_instance):
+        commit = self.commit("fix", committer_login="AUTHOR_LOGIN")
+
+        result = get_author_summary(commit, 1, 123, "authors_committers")
+
+        self.assertEqual(1, len(result))
+
+
+class TestGetPullRequestCommits(TestCase):
+    @staticmethod
+    def pull_request(commit_count):
+        pull_request = MagicMock()
+        pull_request.number = 1
+        pull_request.base.repo.full_name = "owner/repo"
+        pull_request.get_commits.return_value = [MagicMock() for _ in range(commit_count)]
+        return pull_request
+
+    @patch("cla.models.github_models.GitHubInstallation")
+    def test_rest_commits(self, mock_installation):
+        pull_request = self.pull_request(3)
+
+        result = get_pull_request_commits(pull_request, 123)
+
+        self.assertEqual(3, len(result))
+        mock_installation.assert_not_called()
+
+    @patch("cla.utils.get_repository_service")
+    @patch("cla.models.github_models.GitHubInstallation")
+    def test_graphql_commits(self, mock_installation, mock_github_instance):
+        pull_request = self.pull_request(REST_COMMIT_LIMIT)
+        nodes = [
+            {
+                "oid": f"sha-{i}",
+                "message": "fix",
+                "author": {
+                    "name": "Author",
+                    "email": "author@example.com",
+                    "user": {"databaseId": 1, "login": "author_login", "name": "Author", "email": ""},
+                },
+                "committer": {"name": "Committer", "email": "committer@example.com", "user": None},
+            }
+            for i in range(REST_COMMIT_LIMIT + 1)
+        ]
+        mock_installation.return_value.get_pull_request_commits.return_value = nodes
+
+        result = get_pull_request_commits(pull_request, 123)
+
+        # the commits beyond the REST limit are loaded with the GraphQL API
+        mock_installation.return_value.get_pull_request_commits.assert_called_once_with("owner", "repo", 1)
+        self.assertEqual(REST_COMMIT_LIMIT + 1, len(result))
+
+        summaries = get_author_summary(result[-1], 1, 123, "authors_committers")
+        self.assertEqual(
+            [("sha-250", 1, "author_login", "author@example.com"), ("sha-250", None, None, "committer@example.com")],
+            [(summary.commit_sha, summary.author_id, summary.author_login, summary.author_email) for summary in summaries],
+        )
+
+
 if __name__ == "__main__":
     unittest.main()
@@ -2,14 +2,18 @@
 # SPDX-License-Identifier: MIT
 import logging
 import unittest
+from datetime import datetime, timezone
 from unittest.mock import Mock, patch
 
 import cla
 from cla import utils
-from cla.models.dynamo_models import Project, Signature, User
+from cla.models.dynamo_models import (Project, ResignPolicyModel, Signature,
+                                      User)
 from cla.utils import (append_email_help_sign_off_content, extract_pull_request_number,
-                       append_project_version_to_url, get_email_help_content,
-                       get_email_sign_off_content, get_full_sign_url)
+                       append_project_version_to_url, get_co_authors_from_commit,
+                       get_commit_identity_policy, get_email_help_content,
+                       get_email_sign_off_content, get_full_sign_url,
+                       is_signature_blocked)
 
 
 class TestUtils(unittest.TestCase):
@@ -152,15 +156,22 @@ class TestUtils(unittest.TestCase):
         """
         Test given github user passes github org check against ccla_signature
         """
-        self.mock_get.return_value.ok = True
-        github_orgs = [{
-            'login': 'foo-org',
-        }]
-        self.mock_get.return_value = Mock()
-        self.mock_get.return_value.json.return_value = github_orgs
         signature = Signature()
         signature.get_github_org_whitelist = Mock(return_value=['foo-org'])
-        self.assertTrue(utils.is_approved(signature, github_username='foo'))
+        with patch('cla.utils.is_member_of_any_organization', return_value=True) as is_member:
+            self.assertTrue(utils.is_approved(signature, github_username='foo'))
+            is_member.assert_called_once_with(['foo-org'], 'foo')
+
+    def test_github_org_membership_unknown(self) -> None:
+        """
+        Test a failed github org membership lookup isn't treated as not approved
+        """
+        from cla.github_membership import GitHubMembershipUnknown
+        signature = Signature()
+        signature.get_github_org_whitelist = Mock(return_value=['foo-org'])
+        with patch('cla.utils.is_member_of_any_organization', side_effect=GitHubMembershipUnknown('502')):
+            with self.assertRaises(GitHubMembershipUnknown):
+                utils.is_approved(signature, github_username='foo')
 
 
 def test_append_email_help_sign_off_content():
@@ -312,3 +323,58 @@ def test_extract_pull_request_number():
     for i, (message, expected) in enumerate(tests, 1):
         result = extract_pull_request_number(message)
         assert result == expected
+
+
+def test_get_co_authors_from_commit():
+    tests = [
+        ["Fix typo", []],
+        ["Fix typo\n\nCo-authored-by: Jane Doe <jane@example.com>", [("Jane Doe", "jane@example.com")]],
+        # the key is case insensitive and the trailers are read once per email address
+        [
+            "Fix typo\n\nco-authored-by: Jane Doe <jane@example.com>\r\nCo-Authored-By: Jane <JANE@example.com>\n"
+            "Co-authored-by: John Doe <john@example.com>",
+            [("Jane Doe", "jane@example.com"), ("John Doe", "john@example.com")],
+        ],
+        # a trailer quoted in the body is not a co-author
+        ["Fix typo\n\nCo-authored-by: Jane Doe <jane@example.com>\n\nSigned-off-by: John Doe <john@example.com>", []],
+        ["Co-authored-by: Jane Doe <jane@example.com>", []],
+    ]
+
+    for message, expected in tests:
+        commit = Mock()
+        commit.commit.message = message
+        assert get_co_authors_from_commit(commit) == expected
+
+
+def test_get_commit_identity_policy():
+    assert get_commit_identity_policy(None) == "authors"
+    assert get_commit_identity_policy("") == "authors"
+    assert get_commit_identity_policy("unknown") == "authors"
+    assert get_commit_identity_policy(" ALL ") == "all"
+    assert get_commit_identity_policy("authors_committers") == "authors_committers"
+    assert utils.commit_identity_policy_includes_committers("all")
+    assert not utils.commit_identity_policy_includes_committers("authors_co_authors")
+    assert utils.commit_identity_policy_includes_co_authors("authors_co_authors")
+    assert not utils.commit_identity_policy_includes_co_authors(None)
+
+
+def test_is_signature_blocked():
+    """ Test the re-sign policy enforcement matches the go backend """
+    now = datetime(2024, 6, 1, tzinfo=timezone.utc)
+    outdated = Signature(signature_document_major_version=1, signature_document_minor_version=0)
+    current = Signature(signature_document_major_version=2, signature_document_minor_version=1)
+    unversioned = Signature()
+
+    blocked = ResignPolicyModel(document_major_version=2, document_minor_version=1, enforcement="blocked")
+    assert is_signature_blocked(outdated, blocked, now) == True
+    assert is_signature_blocked(unversioned, blocked, now) == True
+    assert is_signature_blocked(current, blocked, now) == False
+    assert is_signature_blocked(outdated, None, now) == False
+
+    grace_period = ResignPolicyModel(document_major_version=2, document_minor_version=1, enforcement="grace_period",
+                                     grace_period_ends="2024-07-01T00:00:00Z")
+    assert is_signature_blocked(outdated, grace_period, now) == False
+    assert is_signature_blocked(outdated, grace_period, datetime(2024, 7, 1, tzinfo=timezone.utc)) == True
+
+    grace_period.grace_period_ends = "not a date"
+    assert is_signature_blocked(outdated, grace_period, now) == True
@@ -13,13 +13,15 @@ import secrets
 import base64
 import urllib.parse
 import urllib.parse as urlparse
-from datetime import datetime
+from datetime import datetime, timezone
 from typing import List, Optional
 from urllib.parse import urlencode
 
 import cla
+import dateutil.parser
 import falcon
 import requests
+from cla.github_membership import is_member_of_any_organization
 from cla.middleware import CLALogMiddleware
 from cla.models import DoesNotExist
 from cla.models.dynamo_models import (CCLAWhitelistRequest, CLAManagerRequest,
@@ -38,6 +40,21 @@ CORPORATE_BASE = os.environ.get("CLA_CORPORATE_BASE", "")
 CORPORATE_V2_BASE = os.environ.get("CLA_CORPORATE_V2_BASE", "")
 SVG_VERSION = "?v=2"
 
+# Commit identity policies of a GitHub organization - which commit identities must be covered by a CLA, the authors
+# only when the organization has no policy. Keep in sync with cla-backend-go/commit_identity/policy.go
+COMMIT_IDENTITY_POLICY_AUTHORS = "authors"
+COMMIT_IDENTITY_POLICY_AUTHORS_COMMITTERS = "authors_committers"
+COMMIT_IDENTITY_POLICY_AUTHORS_CO_AUTHORS = "authors_co_authors"
+COMMIT_IDENTITY_POLICY_ALL = "all"
+
+# Enforcement of the CLA Group re-sign policy for signatures on an outdated document version. Keep in sync with
+# cla-backend-go/utils/constants.go
+RESIGN_ENFORCEMENT_GRACE_PERIOD = "grace_period"
+RESIGN_ENFORCEMENT_BLOCKED = "blocked"
+
+# Co-authored-by trailer line, the key is case insensitive as git accepts it
+CO_AUTHOR_TRAILER = re.compile(r"^co-authored-by:\s*(.*?)\s*<([^<>\s]+)>\s*$", re.IGNORECASE)
+
 def get_cla_path():
     """Returns the CLA code root directory on the current system."""
     cla_folder_dir = os.path.dirname(os.path.abspath(inspect.getfile(inspect.currentframe())))
@@ -591,6 +608,63 @@ def get_last_version(documents):
     return last_major, last_minor
 
 
+def is_signature_outdated(signature: Signature, policy) -> bool:
+    """
+    Returns True if the signature was made on a document version older than the version of the CLA Group re-sign
+    policy - mirrors IsSignatureOutdated of the go backend
+
+    :param signature: the signature to check
+    :param policy: the re-sign policy of the CLA Group, None if none was set
+    """
+    if signature is None or policy is None:
+        return False
+    try:
+        required_major = int(policy.document_major_version)
+        required_minor = int(policy.document_minor_version)
+    except (TypeError, ValueError):
+        return False
+
+    # signatures without a valid version predate document versioning
+    try:
+        major = int(signature.get_signature_document_major_version())
+    except (TypeError, ValueError):
+        major = 0
+    try:
+        minor = int(signature.get_signature_document_minor_version())
+    except (TypeError, ValueError):
+        minor = 0
+
+    return major < required_major or (major == required_major and minor < required_minor)
+
+
+def is_signature_blocked(signature: Signature, policy, now: Optional[datetime] = None) -> bool:
+    """
+    Returns True if the CLA checks must fail for the signature - the signature is outdated and either the policy
+    blocks outdated signatures or its grace period has ended - mirrors IsSignatureBlocked of the go backend
+
+    :param signature: the signature to check
+    :param policy: the re-sign policy of the CLA Group, None if none was set
+    :param now: the current time, defaults to now
+    """
+    if not is_signature_outdated(signature, policy):
+        return False
+
+    if policy.enforcement == RESIGN_ENFORCEMENT_BLOCKED:
+        return True
+    if policy.enforcement == RESIGN_ENFORCEMENT_GRACE_PERIOD:
+        try:
+            grace_period_ends = dateutil.parser.isoparse(policy.grace_period_ends.strip())
+        except (AttributeError, ValueError):
+            # without a valid end date the grace period is treated as ended
+            return True
+        if grace_period_ends.tzinfo is None:
+            grace_period_ends = grace_period_ends.replace(tzinfo=timezone.utc)
+        if now is None:
+            now = datetime.now(timezone.utc)
+        return now >= grace_period_ends
+    return False
+
+
 def user_icla_check(user: User, project: Project, signature: Signature, latest_major_version=False) -> bool:
     cla.log.debug(
         f"ICLA signature found for user: {user} on project: {project}, " f"signature_id: {signature.get_signature_id()}"
@@ -672,7 +746,14 @@ def user_signed_project_signature(user: User, project: Project) -> bool:
     signature = user.get_latest_signature(project.get_project_id(), signature_signed=True, signature_approved=True)
     icla_pass = False
     if signature is not None:
-        icla_pass = True
+        if is_signature_blocked(signature, project.get_project_individual_resign_policy()):
+            cla.log.debug(
+                f"{fn} - ICLA signature: {signature.get_signature_id()} for User: {user} on project: {project} "
+                f"was signed on an outdated document version: {signature.get_signature_document_major_version()}."
+                f"{signature.get_signature_document_minor_version()}"
+            )
+        else:
+            icla_pass = True
     else:
         cla.log.debug(f"{fn} - ICLA signature NOT found for User: {user} on project: {project}")
 
@@ -724,8 +805,15 @@ def user_signed_project_signature(user: User, project: Project) -> bool:
                 project.get_project_id(), signature_signed=True, signature_approved=True
             )
 
-            # Don't check the version for employee signatures.
-            if signature is not None:
+            # Don't check the version for employee signatures - the CCLA must meet the re-sign policy
+            if signature is not None and is_signature_blocked(signature, project.get_project_corporate_resign_policy()):
+                cla.log.debug(
+                    f"{fn} - CCLA signature check failed - CCLA signature: {signature.get_signature_id()} "
+                    f"for project|company, project_id: {project}, company_id: {company_id} was signed on an "
+                    f"outdated document version: {signature.get_signature_document_major_version()}."
+                    f"{signature.get_signature_document_minor_version()}"
+                )
+            elif signature is not None:
                 cla.log.debug(
                     f"{fn} - CCLA signature check - loaded signed CCLA for project|company, "
                     f"user: {user}, project_id: {project}, company_id: {company_id}, "
@@ -1697,6 +1785,35 @@ def lookup_github_organizations(github_username: str):
     return [github_org["login"] for github_org in r.json()]
 
 
+def is_lf_group_member(group_ids: List[str], lf_username: str) -> bool:
+    """
+    Checks if the LF username is a member of one of the LF LDAP groups, as used by Gerrit - a failed group lookup
+    doesn't hide a match in another group
+
+    :param group_ids: The LF LDAP group IDs
+    :param lf_username: The LF username of the user
+    :return: True if the user is a member of one of the groups
+    """
+    fn = "utils.is_lf_group_member"
+    from cla.controllers.lf_group import LFGroup  # pylint: disable=import-outside-toplevel
+
+    lf_group = LFGroup(
+        os.environ.get("LF_GROUP_CLIENT_URL", ""),
+        os.environ.get("LF_GROUP_CLIENT_ID", ""),
+        os.environ.get("LF_GROUP_CLIENT_SECRET", ""),
+        os.environ.get("LF_GROUP_REFRESH_TOKEN", ""),
+    )
+    for group_id in group_ids:
+        group = lf_group.get_group(group_id)
+        if group.get("error") is not None:
+            cla.log.warning(f"{fn} - unable to load the members of the LF group: {group_id}: {group.get('error')}")
+            continue
+        for member in group.get("members") or []:
+            if (member.get("username") or "").lower() == lf_username.strip().lower():
+                return True
+    return False
+
+
 def lookup_gitlab_org_members(organization_id):
     # Use the v2 Endpoint thats a wrapper for Gitlab Group member query
     try:
@@ -1731,7 +1848,7 @@ def update_github_username(github_user: dict, user: User):
             user.set_user_github_username(github_user["login"])
 
 
-def is_approved(ccla_signature: Signature, email=None, github_username=None, github_id=None):
+def is_approved(ccla_signature: Signature, email=None, github_username=None, github_id=None, lf_username=None):
     """
     Given either email, github username or github id a check is made against ccla signature to
     check whether a given parameter is whitelisted . This check is vital for a first time user
@@ -1741,6 +1858,7 @@ def is_approved(ccla_signature: Signature, email=None, github_username=None, git
     :param email: email that is checked against ccla signature email whitelist
     :param github_username: A given github username checked against ccla signature github/github-org whitelists
     :param github_id: A given github id checked against ccla signature github/github-org whitelists
+    :param lf_username: A given LF username checked against ccla signature gerrit group approval list
     """
     fn = "utils.is_approved"
 
@@ -1753,6 +1871,13 @@ def is_approved(ccla_signature: Signature, email=None, github_username=None, git
                 cla.log.debug(f"{fn} found user email in email approval list")
                 return True
 
+        # Checking email regex approval list
+        expressions = ccla_signature.get_email_regex_approval_list()
+        if expressions:
+            cla.log.debug(f"{fn} - testing email: {email} with CCLA email regex approval list: {expressions}")
+            if get_user_instance().preprocess_email_regex([email], expressions):
+                return True
+
         # Checking domain whitelist
         patterns = ccla_signature.get_domain_whitelist()
         cla.log.debug(
@@ -1789,24 +1914,25 @@ def is_approved(ccla_signature: Signature, email=None, github_username=None, git
 
     # Check github org approval list
     if github_username is not None:
-        github_orgs = cla.utils.lookup_github_organizations(github_username)
-        if "error" not in github_orgs:
-            # Fetch the list of orgs this user is part of
-            github_org_approval_list = ccla_signature.get_github_org_whitelist()
-            cla.log.debug(
-                f"{fn} - testing user github orgs: {github_orgs} with "
-                f"CCLA github org approval list values: {github_org_approval_list}"
-            )
-
-            if github_org_approval_list is not None:
-                for dynamo_github_org in github_org_approval_list:
-                    # case insensitive search
-                    if dynamo_github_org.lower() in (s.lower() for s in github_orgs):
-                        cla.log.debug(f"{fn} - found matching github org for user")
-                        return True
+        github_org_approval_list = ccla_signature.get_github_org_whitelist()
+        cla.log.debug(
+            f"{fn} - testing user github username: {github_username} with "
+            f"CCLA github org approval list values: {github_org_approval_list}"
+        )
+        # a failed membership lookup raises GitHubMembershipUnknown, so the caller doesn't treat the user as not approved
+        if github_org_approval_list and is_member_of_any_organization(github_org_approval_list, github_username):
+            cla.log.debug(f"{fn} - found matching github org for user")
+            return True
     else:
         cla.log.debug(f"{fn} - users github_username is not defined - skipping github org approval list check")
 
+    # Check the LF LDAP groups used by Gerrit
+    gerrit_group_approval_list = ccla_signature.get_gerrit_group_approval_list()
+    if lf_username and gerrit_group_approval_list:
+        if is_lf_group_member(gerrit_group_approval_list, lf_username):
+            cla.log.debug(f"{fn} - found lf username in gerrit group approval list")
+            return True
+
     cla.log.debug(f"{fn} - unable to find user in any approval list")
     return False
 
@@ -1941,7 +2067,9 @@ def get_public_email(user):
 
 def get_co_authors_from_commit(commit):
     """
-    Helper function to return co-authors from commit
+    Helper function to return co-authors from commit, as (name, email) tuples once per email address.
+    Only the last paragraph of the commit message holds trailers, so a Co-authored-by line quoted in
+    the body doesn't add a co-author.
     """
     fn = "get_co_authors_from_commit"
     co_authors = []
@@ -1949,10 +2077,50 @@ def get_co_authors_from_commit(commit):
         commit_message = commit.commit.message
         cla.log.debug(f"{fn} - commit message: {commit_message}")
         if commit_message:
-            co_authors = re.findall(r"Co-authored-by: (.*) <(.*)>", commit_message)
+            paragraphs = commit_message.replace("\r\n", "\n").strip().split("\n\n")
+            if len(paragraphs) < 2:
+                # a subject line only message has no trailers
+                return co_authors
+            seen = set()
+            for line in paragraphs[-1].split("\n"):
+                match = CO_AUTHOR_TRAILER.match(line.strip())
+                if match is None or match.group(2).lower() in seen:
+                    continue
+                seen.add(match.group(2).lower())
+                co_authors.append((match.group(1), match.group(2)))
     return co_authors
 
 
+def get_commit_identity_policy(value: Optional[str]) -> str:
+    """
+    Helper function to return the commit identity policy of the stored value, empty and invalid
+    values are the authors only policy
+    """
+    policies = [
+        COMMIT_IDENTITY_POLICY_AUTHORS,
+        COMMIT_IDENTITY_POLICY_AUTHORS_COMMITTERS,
+        COMMIT_IDENTITY_POLICY_AUTHORS_CO_AUTHORS,
+        COMMIT_IDENTITY_POLICY_ALL,
+    ]
+    if value and value.strip().lower() in policies:
+        return value.strip().lower()
+    return COMMIT_IDENTITY_POLICY_AUTHORS
+
+
+def commit_identity_policy_includes_committers(policy: Optional[str]) -> bool:
+    """
+    Helper function to check whether the commit committers must be covered by a CLA
+    """
+    return get_commit_identity_policy(policy) in (COMMIT_IDENTITY_POLICY_AUTHORS_COMMITTERS, COMMIT_IDENTITY_POLICY_ALL)
+
+
+def commit_identity_policy_includes_co_authors(policy: Optional[str]) -> bool:
+    """
+    Helper function to check whether the commit co-authors must be covered by a CLA
+    """
+    return get_commit_identity_policy(policy) in (COMMIT_IDENTITY_POLICY_AUTHORS_CO_AUTHORS, COMMIT_IDENTITY_POLICY_ALL)
+
+
 def extract_pull_request_number(pull_request_message):
     """
     Helper function to return pull request number from pull request message